@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/parser"
+	"github.com/ludo-technologies/jscan/service"
+)
+
+// Analyses run by RunAnalyses
+const (
+	AnalysisComplexity   = "complexity"
+	AnalysisDeadCode     = "deadcode"
+	AnalysisClone        = "clone"
+	AnalysisCBO          = "cbo"
+	AnalysisDependencies = "deps"
+	AnalysisTypeSafety   = "typesafety"
+	AnalysisReact        = "react"
+)
+
+// AnalysisRequest selects the analyses RunAnalyses runs and the files they cover
+type AnalysisRequest struct {
+	Analyses []string
+	Files    []string
+
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte
+
+	// ASTs holds already parsed files, keyed by path, that the complexity, dead code
+	// and clone analyses use instead of parsing them
+	ASTs map[string]*parser.Node
+
+	// StatementSequences turns on statement sequence clones regardless of the configuration
+	StatementSequences bool
+
+	// Progress, if set, returns the progress manager of an analysis
+	Progress func(analysis string) domain.ProgressManager
+}
+
+// AnalysisResult holds the responses of the analyses that ran; the response of an
+// analysis that failed is nil and its error is in Errors
+type AnalysisResult struct {
+	Complexity   *domain.ComplexityResponse
+	DeadCode     *domain.DeadCodeResponse
+	Clone        *domain.CloneResponse
+	CBO          *domain.CBOResponse
+	Dependencies *domain.DependencyGraphResponse
+	TypeSafety   *domain.TypeSafetyResponse
+	React        *domain.ReactResponse
+
+	Errors map[string]error
+}
+
+// RunAnalyses runs the selected analyses in parallel with cfg
+func RunAnalyses(ctx context.Context, cfg *config.Config, req AnalysisRequest) *AnalysisResult {
+	result := &AnalysisResult{Errors: make(map[string]error)}
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, analysis := range req.Analyses {
+		wg.Add(1)
+		go func(analysis string) {
+			defer wg.Done()
+			err := runAnalysis(ctx, analysis, cfg, req, result, &mu)
+			if err != nil {
+				mu.Lock()
+				result.Errors[analysis] = err
+				mu.Unlock()
+			}
+		}(analysis)
+	}
+	wg.Wait()
+	return result
+}
+
+// runAnalysis runs one analysis and stores its response in result
+func runAnalysis(ctx context.Context, analysis string, cfg *config.Config, req AnalysisRequest,
+	result *AnalysisResult, mu *sync.Mutex) error {
+	pm := service.NewProgressManager(false)
+	if req.Progress != nil {
+		pm = req.Progress(analysis)
+	}
+
+	switch analysis {
+	case AnalysisComplexity:
+		svc := service.NewComplexityServiceWithProgress(&cfg.Complexity, pm)
+		resp, err := svc.Analyze(ctx, domain.ComplexityRequest{
+			Paths:           req.Files,
+			Sources:         req.Sources,
+			ASTs:            req.ASTs,
+			LowThreshold:    cfg.Complexity.LowThreshold,
+			MediumThreshold: cfg.Complexity.MediumThreshold,
+			SortBy:          domain.SortByComplexity,
+		})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.Complexity = resp
+		mu.Unlock()
+
+	case AnalysisDeadCode:
+		task := pm.StartTask("Detecting dead code", len(req.Files))
+		defer task.Complete()
+		resp, err := service.AnalyzeDeadCodeWithTask(ctx, domain.DeadCodeRequest{
+			Paths:       req.Files,
+			Sources:     req.Sources,
+			ASTs:        req.ASTs,
+			MinSeverity: domain.DeadCodeSeverityInfo,
+			SortBy:      domain.DeadCodeSortBySeverity,
+		}, task)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.DeadCode = resp
+		mu.Unlock()
+
+	case AnalysisClone:
+		task := pm.StartTask("Detecting clones", len(req.Files))
+		defer task.Complete()
+		cloneReq := service.CloneRequestFromConfig(cfg, req.Files)
+		cloneReq.Sources = req.Sources
+		cloneReq.ASTs = req.ASTs
+		if req.StatementSequences {
+			cloneReq.StatementSequences = true
+		}
+		resp, err := service.NewCloneServiceWithDefaults().DetectClones(ctx, cloneReq)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.Clone = resp
+		mu.Unlock()
+
+	case AnalysisCBO:
+		task := pm.StartTask("Analyzing coupling", len(req.Files))
+		defer task.Complete()
+		resp, err := service.NewCBOServiceWithDefaults().Analyze(ctx, domain.CBORequest{Paths: req.Files, Sources: req.Sources})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.CBO = resp
+		mu.Unlock()
+
+	case AnalysisDependencies:
+		task := pm.StartTask("Analyzing dependencies", len(req.Files))
+		defer task.Complete()
+		resp, err := service.NewDependencyGraphServiceWithDefaults().Analyze(ctx, domain.DependencyGraphRequest{
+			Paths:        req.Files,
+			Sources:      req.Sources,
+			DetectCycles: domain.BoolPtr(true),
+		})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.Dependencies = resp
+		mu.Unlock()
+
+	case AnalysisTypeSafety:
+		task := pm.StartTask("Measuring type safety", len(req.Files))
+		defer task.Complete()
+		resp, err := service.NewTypeSafetyService().Analyze(ctx, domain.TypeSafetyRequest{Paths: req.Files, Sources: req.Sources})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.TypeSafety = resp
+		mu.Unlock()
+
+	case AnalysisReact:
+		task := pm.StartTask("Analyzing React components", len(req.Files))
+		defer task.Complete()
+		resp, err := service.NewReactService().Analyze(ctx, domain.ReactRequest{
+			Paths:      req.Files,
+			Sources:    req.Sources,
+			Thresholds: service.ReactThresholdsFromConfig(&cfg.React),
+		})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		result.React = resp
+		mu.Unlock()
+	}
+	return nil
+}
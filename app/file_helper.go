@@ -1,10 +1,12 @@
 package app
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/ludo-technologies/jscan/domain"
 	ignore "github.com/sabhiram/go-gitignore"
 )
 
@@ -117,6 +119,20 @@ func (h *FileHelper) IsValidPythonFile(path string) bool {
 	return h.IsValidJSFile(path)
 }
 
+// AddSource adds an in-memory source to contents under its cleaned path, which it
+// returns. It fails on paths that are not JavaScript/TypeScript files or already added.
+func (h *FileHelper) AddSource(contents map[string][]byte, path string, content []byte) (string, error) {
+	clean := filepath.Clean(path)
+	if !h.IsValidJSFile(clean) {
+		return "", domain.NewInvalidInputError(fmt.Sprintf("not a JavaScript/TypeScript file: %s", path), nil)
+	}
+	if _, ok := contents[clean]; ok {
+		return "", domain.NewInvalidInputError(fmt.Sprintf("duplicate source: %s", path), nil)
+	}
+	contents[clean] = content
+	return clean, nil
+}
+
 // FileExists checks if a file exists
 func (h *FileHelper) FileExists(path string) (bool, error) {
 	info, err := os.Stat(path)
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
@@ -17,21 +18,26 @@ import (
 )
 
 var (
-	selectAnalyses []string
-	outputFormat   string
-	configPath     string
-	jsonOutput     bool
-	htmlOutput     bool
-	textOutput     bool
-	noOpenBrowser  bool
-	outputPath     string
+	selectAnalyses     []string
+	outputFormat       string
+	configPath         string
+	jsonOutput         bool
+	htmlOutput         bool
+	textOutput         bool
+	noOpenBrowser      bool
+	outputPath         string
+	recordHistory      bool
+	groupBy            string
+	cloneMode          string
+	statementSequences bool
 )
 
 func analyzeCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "analyze [path...]",
 		Short: "Analyze JavaScript/TypeScript files",
-		Long: `Analyze JavaScript/TypeScript files for complexity, dead code, code clones, and coupling.
+		Long: `Analyze JavaScript/TypeScript files for complexity, dead code, code clones, coupling,
+dependencies, TypeScript type safety and React components.
 
 By default, generates an HTML report and opens it in your browser.
 
@@ -39,18 +45,26 @@ Examples:
   jscan analyze src/                              # All analyses (default)
   jscan analyze --select complexity,deadcode src/ # Complexity + dead code only
   jscan analyze --select clone src/               # Clone detection only
+  jscan analyze --clone-mode hybrid src/          # Token matches plus Type-3/Type-4 clones
   jscan analyze --select cbo src/                 # CBO coupling analysis only
+  jscan analyze --select typesafety src/          # TypeScript type coverage only
+  jscan analyze --select react src/               # React components and hooks only
   jscan analyze --json src/                       # Output JSON to stdout
   jscan analyze --text src/                       # Output text to stdout
   jscan analyze --no-open src/                    # Generate HTML without opening browser
-  jscan analyze -o report.html src/               # Custom output path`,
+  jscan analyze -o report.html src/               # Custom output path
+  jscan analyze -f markdown -o report.md src/     # Markdown for a PR comment
+  jscan analyze -f codeclimate -o gl-code-quality-report.json src/  # GitLab Code Quality
+  jscan analyze --history --json src/ > /dev/null # Record the run for 'jscan trend'
+  jscan analyze --group-by dir src/               # Scores per directory subtree
+  jscan analyze --group-by owner --json .         # Scores per CODEOWNERS owner`,
 		RunE: runAnalyze,
 	}
 
-	cmd.Flags().StringSliceVarP(&selectAnalyses, "select", "s", []string{"complexity", "deadcode", "clone", "cbo", "deps"},
-		"Analyses to run (comma-separated): complexity,deadcode,clone,cbo,deps")
+	cmd.Flags().StringSliceVarP(&selectAnalyses, "select", "s", []string{"complexity", "deadcode", "clone", "cbo", "deps", "typesafety", "react"},
+		"Analyses to run (comma-separated): complexity,deadcode,clone,cbo,deps,typesafety,react")
 	cmd.Flags().StringVarP(&outputFormat, "format", "f", "html",
-		"Output format: html, json, text (default: html)")
+		"Output format: html, json, text, markdown, codeclimate (default: html)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false,
 		"Output results as JSON to stdout")
 	cmd.Flags().BoolVar(&textOutput, "text", false,
@@ -60,9 +74,17 @@ Examples:
 	cmd.Flags().BoolVar(&noOpenBrowser, "no-open", false,
 		"Don't auto-open HTML report in browser")
 	cmd.Flags().StringVarP(&outputPath, "output", "o", "",
-		"Output file path (default: jscan-report.html)")
+		"Output file path (default: jscan-report.html for HTML, stdout for other formats)")
 	cmd.Flags().StringVarP(&configPath, "config", "c", "",
 		"Path to config file")
+	cmd.Flags().BoolVar(&recordHistory, "history", false,
+		"Record the run in the history file for 'jscan trend' (also enabled by history.enabled)")
+	cmd.Flags().StringVar(&groupBy, "group-by", "",
+		"Break the results down per directory subtree or CODEOWNERS owner: dir, owner")
+	cmd.Flags().StringVar(&cloneMode, "clone-mode", "",
+		"Clone detection mode: tree, token, hybrid (overrides clones.analysis.detection_mode)")
+	cmd.Flags().BoolVar(&statementSequences, "statement-sequences", false,
+		"Also detect duplicated statement sequences inside functions (overrides clones.analysis.statement_sequences)")
 
 	return cmd
 }
@@ -78,16 +100,44 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		format = domain.OutputFormatJSON
 	} else if textOutput || outputFormat == "text" {
 		format = domain.OutputFormatText
+	} else if outputFormat == "markdown" || outputFormat == "md" {
+		format = domain.OutputFormatMarkdown
+	} else if outputFormat == "codeclimate" {
+		format = domain.OutputFormatCodeClimate
 	}
 
+	// Keep stdout clean for machine-readable output
+	quiet := format == domain.OutputFormatJSON || format == domain.OutputFormatMarkdown ||
+		format == domain.OutputFormatCodeClimate
+
 	// Load configuration
 	cfg, err := config.LoadConfigWithTarget(configPath, args[0])
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
-	if configPath != "" && format != domain.OutputFormatJSON {
+	if configPath != "" && !quiet {
 		fmt.Printf("Using config: %s\n", configPath)
 	}
+	if err := applyCloneFlags(cfg, cloneMode, changedBool(cmd, "statement-sequences", statementSequences)); err != nil {
+		return err
+	}
+
+	// Resolve the breakdown grouping before running the analyses
+	var codeOwners *service.CodeOwners
+	if groupBy != "" {
+		if err := domain.GroupBy(groupBy).Validate(); err != nil {
+			return err
+		}
+		if domain.GroupBy(groupBy) == domain.GroupByOwner {
+			codeOwners, err = service.FindCodeOwners(args[0])
+			if err != nil {
+				return err
+			}
+			if !quiet {
+				fmt.Printf("Using owners: %s\n", codeOwners.Path())
+			}
+		}
+	}
 
 	// Collect JavaScript/TypeScript files (using exclude patterns from config)
 	var files []string
@@ -103,12 +153,12 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no JavaScript/TypeScript files found")
 	}
 
-	if format != domain.OutputFormatJSON {
+	if !quiet {
 		fmt.Printf("Analyzing %d files...\n", len(files))
 	}
 
 	// Create progress manager (auto-disabled for JSON output or non-TTY)
-	pm := service.NewProgressManager(format != domain.OutputFormatJSON)
+	pm := service.NewProgressManager(format != domain.OutputFormatJSON && format != domain.OutputFormatCodeClimate)
 	defer pm.Close()
 
 	// Start timing
@@ -120,6 +170,8 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	var cloneResponse *domain.CloneResponse
 	var cboResponse *domain.CBOResponse
 	var depsResponse *domain.DependencyGraphResponse
+	var typeSafetyResponse *domain.TypeSafetyResponse
+	var reactResponse *domain.ReactResponse
 
 	// Determine which analyses to run
 	runComplexity := contains(selectAnalyses, "complexity")
@@ -127,6 +179,8 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	runClone := contains(selectAnalyses, "clone")
 	runCBO := contains(selectAnalyses, "cbo")
 	runDeps := contains(selectAnalyses, "deps")
+	runTypeSafety := contains(selectAnalyses, "typesafety")
+	runReact := contains(selectAnalyses, "react")
 
 	// Single progress bar for all analyses (only when interactive)
 	var task domain.TaskProgress
@@ -139,7 +193,7 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 
 	// Run analyses in parallel
 	var wg sync.WaitGroup
-	var complexityErr, deadCodeErr, cloneErr, cboErr, depsErr error
+	var complexityErr, deadCodeErr, cloneErr, cboErr, depsErr, typeSafetyErr, reactErr error
 	var mu sync.Mutex
 	ctx := context.Background()
 
@@ -171,7 +225,7 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			resp, err := runCloneAnalysisInternal(ctx, files)
+			resp, err := runCloneAnalysisInternal(ctx, files, cfg)
 			mu.Lock()
 			cloneResponse = resp
 			cloneErr = err
@@ -203,6 +257,33 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		}()
 	}
 
+	if runTypeSafety {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := service.NewTypeSafetyService().Analyze(ctx, domain.TypeSafetyRequest{Paths: files})
+			mu.Lock()
+			typeSafetyResponse = resp
+			typeSafetyErr = err
+			mu.Unlock()
+		}()
+	}
+
+	if runReact {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := service.NewReactService().Analyze(ctx, domain.ReactRequest{
+				Paths:      files,
+				Thresholds: service.ReactThresholdsFromConfig(&cfg.React),
+			})
+			mu.Lock()
+			reactResponse = resp
+			reactErr = err
+			mu.Unlock()
+		}()
+	}
+
 	wg.Wait()
 	if progressDone != nil {
 		close(progressDone)
@@ -228,12 +309,46 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	if depsErr != nil && format != domain.OutputFormatJSON {
 		fmt.Fprintf(os.Stderr, "Dependency analysis error: %v\n", depsErr)
 	}
+	if typeSafetyErr != nil && format != domain.OutputFormatJSON {
+		fmt.Fprintf(os.Stderr, "Type-safety analysis error: %v\n", typeSafetyErr)
+	}
+	if reactErr != nil && format != domain.OutputFormatJSON {
+		fmt.Fprintf(os.Stderr, "React analysis error: %v\n", reactErr)
+	}
 
 	// Calculate duration
 	duration := time.Since(startTime)
 
+	scoring := service.ScoringFromConfig(&cfg.Scoring)
+	buildSummary := func() *domain.AnalyzeSummary {
+		summary := service.BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, scoring)
+		service.AddTypeSafetySummary(summary, typeSafetyResponse)
+		service.AddReactSummary(summary, reactResponse)
+		return summary
+	}
+
+	// Record the run for trend reports
+	if recordHistory || cfg.History.Enabled {
+		summary := buildSummary()
+		if err := recordAnalysisHistory(ctx, cfg.History.Path, summary, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record analysis history: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Recorded run in %s\n", cfg.History.Path)
+		}
+	}
+
 	// Output results
-	formatter := service.NewOutputFormatter()
+	formatter := service.NewOutputFormatterWithScoring(scoring)
+	formatter.SetTypeSafety(typeSafetyResponse)
+	formatter.SetReact(reactResponse)
+	switch domain.GroupBy(groupBy) {
+	case domain.GroupByDirectory:
+		formatter.SetBreakdown(service.BuildDirectoryBreakdown(files,
+			complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, scoring))
+	case domain.GroupByOwner:
+		formatter.SetBreakdown(service.BuildOwnerBreakdown(files, codeOwners,
+			complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, scoring))
+	}
 
 	// Handle HTML output with file writing and browser opening
 	if format == domain.OutputFormatHTML {
@@ -267,22 +382,49 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		}
 
 		// Print CLI summary
-		summary := service.BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+		summary := buildSummary()
 		fmt.Print(service.FormatCLISummary(summary, duration))
 
 		return nil
 	}
 
-	// JSON, Text, or other format output to stdout
-	if err := formatter.WriteAnalyze(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, format, os.Stdout, duration); err != nil {
+	// Other formats are written to stdout, or to the output file if given
+	writer := io.Writer(os.Stdout)
+	var file *os.File
+	if outputPath != "" {
+		file, err = os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		// Closed and checked once the report is written; this only covers early returns
+		defer file.Close()
+		writer = file
+	}
+
+	if format == domain.OutputFormatMarkdown {
+		// Markdown reports are cut to the configured size budget
+		markdownConfig := service.DefaultMarkdownFormatterConfig()
+		markdownConfig.MaxBytes = cfg.Output.MarkdownMaxBytes
+		markdownConfig.Scoring = scoring
+		markdownFormatter := service.NewMarkdownFormatter(markdownConfig)
+		if err := markdownFormatter.WriteAnalyze(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, writer, duration); err != nil {
+			return err
+		}
+	} else if err := formatter.WriteAnalyze(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, format, writer, duration); err != nil {
 		return err
 	}
+	if file != nil {
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to close output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Report written to %s\n", outputPath)
+	}
 
 	// Print CLI summary to stderr for structured formats (JSON/YAML/CSV)
 	// so it doesn't pollute the machine-readable output on stdout.
 	// Text format already includes a Health Score section, so skip it.
 	if format != domain.OutputFormatText {
-		summary := service.BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+		summary := buildSummary()
 		fmt.Fprint(os.Stderr, service.FormatCLISummary(summary, duration))
 	}
 
@@ -330,6 +472,17 @@ func runDeadCodeAnalysisInternal(files []string) (*domain.DeadCodeResponse, erro
 	return service.AnalyzeDeadCode(context.Background(), req)
 }
 
+// recordAnalysisHistory appends the summary to the history file, keyed by the git
+// commit checked out at the analyzed path
+func recordAnalysisHistory(ctx context.Context, historyPath string, summary *domain.AnalyzeSummary, target string) error {
+	dir := target
+	if info, err := os.Stat(target); err == nil && !info.IsDir() {
+		dir = filepath.Dir(target)
+	}
+	entry := service.NewHistoryEntry(ctx, summary, dir)
+	return service.NewHistoryStore(historyPath).Append(entry)
+}
+
 // collectJSFiles collects JavaScript/TypeScript files from a path using FileHelper
 func collectJSFiles(path string, excludePatterns []string) ([]string, error) {
 	helper := app.NewFileHelper()
@@ -432,13 +585,42 @@ func startTimeBasedProgressUpdater(task domain.TaskProgress, estimatedDuration t
 	return done
 }
 
+// applyCloneFlags overrides the configured clone detection with the --clone-mode flag
+// and, when it is set, the --statement-sequences flag
+func applyCloneFlags(cfg *config.Config, mode string, statementSequences *bool) error {
+	switch mode {
+	case "", "tree", "token", "hybrid":
+	default:
+		return fmt.Errorf("invalid --clone-mode %q: must be one of tree, token, hybrid", mode)
+	}
+	if mode == "" && statementSequences == nil {
+		return nil
+	}
+	if cfg.Clones == nil {
+		cfg.Clones = config.DefaultPyscnConfig()
+	}
+	if mode != "" {
+		cfg.Clones.Analysis.DetectionMode = mode
+	}
+	if statementSequences != nil {
+		cfg.Clones.Analysis.StatementSequences = config.BoolPtr(*statementSequences)
+	}
+	return nil
+}
+
+// changedBool returns the value of a boolean flag, or nil when it was not set
+func changedBool(cmd *cobra.Command, name string, value bool) *bool {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+	return &value
+}
+
 // runCloneAnalysisInternal runs clone detection without progress tracking
-func runCloneAnalysisInternal(ctx context.Context, files []string) (*domain.CloneResponse, error) {
+func runCloneAnalysisInternal(ctx context.Context, files []string, cfg *config.Config) (*domain.CloneResponse, error) {
 	svc := service.NewCloneServiceWithDefaults()
 
-	req := domain.DefaultCloneRequest()
-	req.Paths = files
-
+	req := service.CloneRequestFromConfig(cfg, files)
 	return svc.DetectClones(ctx, req)
 }
 
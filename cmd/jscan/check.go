@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ludo-technologies/jscan/domain"
@@ -26,14 +27,26 @@ func (e *CheckExitError) Error() string {
 }
 
 var (
-	checkMaxComplexity  int
-	checkAllowDeadCode  bool
-	checkAllowCircDeps  bool
-	checkMaxCycles      int
-	checkSelectAnalyses []string
-	checkVerbose        bool
-	checkJSON           bool
-	checkConfigPath     string
+	checkMaxComplexity      int
+	checkAllowDeadCode      bool
+	checkAllowCircDeps      bool
+	checkMaxCycles          int
+	checkMinHealthScore     int
+	checkMinGrade           string
+	checkMaxDuplication     float64
+	checkMaxCBO             int
+	checkMaxNesting         int
+	checkMaxDepsDepth       int
+	checkMaxMSD             float64
+	checkMinTypeCov         float64
+	checkDeadCodeSeverity   string
+	checkSelectAnalyses     []string
+	checkVerbose            bool
+	checkJSON               bool
+	checkFormat             string
+	checkConfigPath         string
+	checkCloneMode          string
+	checkStatementSequences bool
 )
 
 func checkCmd() *cobra.Command {
@@ -43,10 +56,15 @@ func checkCmd() *cobra.Command {
 		Long: `Run quality checks against configurable thresholds for CI/CD integration.
 
 Exit codes:
-  0 - All checks pass
+  0 - All checks pass (gates with warning severity may report violations)
   1 - Quality threshold(s) violated
   2 - Analysis error (file not found, parse error, etc.)
 
+Gates can also be set in the "check" section of the config file, where
+"check.severities" turns a gate into a warning that does not fail the check.
+Gates run the analyses they need; the health score and grade cover the
+analyses that ran.
+
 Examples:
   # Basic check with defaults
   jscan check src/
@@ -57,9 +75,31 @@ Examples:
   # Allow dead code, fail on circular deps
   jscan check --allow-dead-code src/
 
+  # Fail when the health score (per the scoring config) drops below 70
+  jscan check --min-health-score 70 src/
+
+  # Gate on duplication, coupling and nesting
+  jscan check --max-duplication 5 --max-cbo 10 --max-nesting-depth 4 src/
+
+  # Measure duplication with token-based clone detection
+  jscan check --max-duplication 5 --clone-mode token src/
+
+  # Fail when less than 90% of TypeScript parameters and annotations are typed
+  jscan check --min-type-coverage 90 src/
+
+  # Fail on hooks called conditionally and on components over the React thresholds
+  jscan check --select react src/
+
   # JSON output for machine parsing
   jscan check --json src/
 
+  # Markdown summary for a merge request comment
+  jscan check --format markdown src/ > check.md
+
+  # JUnit or Checkstyle XML for CI dashboards (Jenkins, GitLab, ...)
+  jscan check --format junit src/ > jscan-junit.xml
+  jscan check --format checkstyle src/ > jscan-checkstyle.xml
+
   # Select specific analyses
   jscan check --select complexity,deps src/`,
 		RunE:          runCheck,
@@ -75,15 +115,39 @@ Examples:
 		"Allow circular dependencies without failing")
 	cmd.Flags().IntVar(&checkMaxCycles, "max-cycles", 0,
 		"Maximum allowed dependency cycles (0 = none allowed)")
+	cmd.Flags().IntVar(&checkMinHealthScore, "min-health-score", 0,
+		"Minimum health score (0-100) of the selected analyses (0 = disabled)")
+	cmd.Flags().StringVar(&checkMinGrade, "min-grade", "",
+		"Minimum grade of the selected analyses: A, B, C or D")
+	cmd.Flags().Float64Var(&checkMaxDuplication, "max-duplication", 0,
+		"Maximum percentage of duplicated code (0 = disabled, runs clone detection)")
+	cmd.Flags().IntVar(&checkMaxCBO, "max-cbo", 0,
+		"Maximum coupling (CBO) per class (0 = disabled, runs CBO analysis)")
+	cmd.Flags().IntVar(&checkMaxNesting, "max-nesting-depth", 0,
+		"Maximum nesting depth per function (0 = disabled)")
+	cmd.Flags().IntVar(&checkMaxDepsDepth, "max-dependency-depth", 0,
+		"Maximum module dependency chain length (0 = disabled)")
+	cmd.Flags().Float64Var(&checkMaxMSD, "max-main-sequence-distance", 0,
+		"Maximum average distance from the main sequence, 0-1 (0 = disabled)")
+	cmd.Flags().Float64Var(&checkMinTypeCov, "min-type-coverage", 0,
+		"Minimum TypeScript type coverage percentage (0 = disabled, runs type-safety analysis)")
+	cmd.Flags().StringVar(&checkDeadCodeSeverity, "dead-code-severity", "info",
+		"Lowest dead code severity that fails the check: info, warning, critical")
 	cmd.Flags().StringSliceVarP(&checkSelectAnalyses, "select", "s",
 		[]string{"complexity", "deadcode", "deps"},
-		"Analyses to run: complexity,deadcode,deps")
+		"Analyses to run: complexity,deadcode,clone,cbo,deps,typesafety,react")
 	cmd.Flags().BoolVarP(&checkVerbose, "verbose", "v", false,
 		"Show detailed output")
 	cmd.Flags().BoolVar(&checkJSON, "json", false,
-		"Output results as JSON")
+		"Output results as JSON (shorthand for --format json)")
+	cmd.Flags().StringVarP(&checkFormat, "format", "f", "text",
+		"Output format: text, json, markdown, junit, checkstyle")
 	cmd.Flags().StringVarP(&checkConfigPath, "config", "c", "",
 		"Path to config file")
+	cmd.Flags().StringVar(&checkCloneMode, "clone-mode", "",
+		"Clone detection mode: tree, token, hybrid (overrides clones.analysis.detection_mode)")
+	cmd.Flags().BoolVar(&checkStatementSequences, "statement-sequences", false,
+		"Also detect duplicated statement sequences inside functions (overrides clones.analysis.statement_sequences)")
 
 	return cmd
 }
@@ -95,16 +159,28 @@ func runCheck(cmd *cobra.Command, args []string) error {
 
 	startTime := time.Now()
 
+	format, err := checkOutputFormat()
+	if err != nil {
+		return &CheckExitError{Code: 2, Message: err.Error()}
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfigWithTarget(checkConfigPath, args[0])
 	if err != nil {
 		return &CheckExitError{Code: 2, Message: fmt.Sprintf("failed to load configuration: %v", err)}
 	}
+	if err := applyCloneFlags(cfg, checkCloneMode, changedBool(cmd, "statement-sequences", checkStatementSequences)); err != nil {
+		return &CheckExitError{Code: 2, Message: err.Error()}
+	}
 
 	// Apply config values for flags not explicitly set on CLI
 	if !cmd.Flags().Changed("max-complexity") && cfg.Complexity.MaxComplexity > 0 {
 		checkMaxComplexity = cfg.Complexity.MaxComplexity
 	}
+	gates, err := checkGates(cmd, cfg)
+	if err != nil {
+		return &CheckExitError{Code: 2, Message: err.Error()}
+	}
 
 	// Collect JavaScript/TypeScript files (using exclude patterns from config)
 	var files []string
@@ -120,8 +196,8 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		return &CheckExitError{Code: 2, Message: "no JavaScript/TypeScript files found"}
 	}
 
-	// Create progress manager (auto-disabled for JSON output or non-TTY/CI)
-	pm := service.NewProgressManager(!checkJSON)
+	// Create progress manager (auto-disabled for machine-readable output or non-TTY/CI)
+	pm := service.NewProgressManager(format == domain.OutputFormatText)
 	defer pm.Close()
 
 	// Initialize result
@@ -136,29 +212,111 @@ func runCheck(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 
-	// Run selected analyses
+	// Run selected analyses, plus the ones the gates need
+	var complexityResp *domain.ComplexityResponse
+	var deadCodeResp *domain.DeadCodeResponse
+	var cloneResp *domain.CloneResponse
+	var cboResp *domain.CBOResponse
+	var depsResp *domain.DependencyGraphResponse
+	var typeSafetyResp *domain.TypeSafetyResponse
+	var reactResp *domain.ReactResponse
+
 	if contains(checkSelectAnalyses, "complexity") {
-		if err := checkComplexity(ctx, files, cfg, result, pm); err != nil {
+		if complexityResp, err = checkComplexity(ctx, files, cfg, result, pm); err != nil {
 			return &CheckExitError{Code: 2, Message: err.Error()}
 		}
+	} else if gates.MaxNestingDepth > 0 {
+		if complexityResp, err = runComplexityAnalysisInternal(files, cfg); err != nil {
+			return &CheckExitError{Code: 2, Message: fmt.Sprintf("complexity analysis failed: %v", err)}
+		}
 	}
 
 	if contains(checkSelectAnalyses, "deadcode") {
-		if err := checkDeadCode(ctx, files, cfg, result, pm); err != nil {
+		// JUnit and Checkstyle attach violations to files, so each finding is reported
+		perFinding := format == domain.OutputFormatJUnit || format == domain.OutputFormatCheckstyle
+		if deadCodeResp, err = checkDeadCode(ctx, files, cfg, &gates, perFinding, result, pm); err != nil {
 			return &CheckExitError{Code: 2, Message: err.Error()}
 		}
 	}
 
+	if contains(checkSelectAnalyses, "clone") || gates.MaxDuplication > 0 {
+		result.Summary.CloneChecked = true
+		if cloneResp, err = runCloneAnalysisInternal(ctx, files, cfg); err != nil {
+			return &CheckExitError{Code: 2, Message: fmt.Sprintf("clone detection failed: %v", err)}
+		}
+	}
+
+	if contains(checkSelectAnalyses, "cbo") || gates.MaxCBO > 0 {
+		result.Summary.CBOChecked = true
+		if cboResp, err = runCBOAnalysisInternal(ctx, files); err != nil {
+			return &CheckExitError{Code: 2, Message: fmt.Sprintf("CBO analysis failed: %v", err)}
+		}
+	}
+
 	if contains(checkSelectAnalyses, "deps") {
-		if err := checkDependencies(ctx, files, cfg, result); err != nil {
+		if depsResp, err = checkDependencies(ctx, files, cfg, result); err != nil {
 			return &CheckExitError{Code: 2, Message: err.Error()}
 		}
+	} else if gates.MaxDependencyDepth > 0 || gates.MaxMainSequenceDistance > 0 {
+		if depsResp, err = runDepsAnalysisInternal(ctx, files); err != nil {
+			return &CheckExitError{Code: 2, Message: fmt.Sprintf("dependency analysis failed: %v", err)}
+		}
+	}
+
+	if contains(checkSelectAnalyses, "typesafety") || gates.MinTypeCoverage > 0 {
+		result.Summary.TypeSafetyChecked = true
+		if typeSafetyResp, err = service.NewTypeSafetyService().Analyze(ctx, domain.TypeSafetyRequest{Paths: files}); err != nil {
+			return &CheckExitError{Code: 2, Message: fmt.Sprintf("type-safety analysis failed: %v", err)}
+		}
 	}
 
-	return outputCheckResult(result, startTime)
+	if contains(checkSelectAnalyses, "react") {
+		result.Summary.ReactChecked = true
+		reactResp, err = service.NewReactService().Analyze(ctx, domain.ReactRequest{
+			Paths:      files,
+			Thresholds: service.ReactThresholdsFromConfig(&cfg.React),
+		})
+		if err != nil {
+			return &CheckExitError{Code: 2, Message: fmt.Sprintf("React analysis failed: %v", err)}
+		}
+	}
+
+	// Score the analyses that ran the same way as analyze, then apply the gates
+	summary := service.BuildAnalyzeSummary(complexityResp, deadCodeResp, cloneResp, cboResp, depsResp, service.ScoringFromConfig(&cfg.Scoring))
+	service.AddTypeSafetySummary(summary, typeSafetyResp)
+	service.AddReactSummary(summary, reactResp)
+	result.AnalyzeSummary = summary
+	result.Summary.HealthScore = summary.HealthScore
+	result.Summary.Grade = summary.Grade
+	checkSummaryGates(summary, &gates, result)
+	checkFunctionGates(complexityResp, cboResp, &gates, result)
+	checkReactGates(reactResp, &gates, result)
+
+	return outputCheckResult(result, startTime, format, cfg)
 }
 
-func checkComplexity(ctx context.Context, files []string, cfg *config.Config, result *domain.CheckResult, pm domain.ProgressManager) error {
+// checkOutputFormat resolves the output format from --format and --json
+func checkOutputFormat() (domain.OutputFormat, error) {
+	if checkJSON {
+		return domain.OutputFormatJSON, nil
+	}
+	switch checkFormat {
+	case "text", "":
+		return domain.OutputFormatText, nil
+	case "json":
+		return domain.OutputFormatJSON, nil
+	case "markdown", "md":
+		return domain.OutputFormatMarkdown, nil
+	case "junit":
+		return domain.OutputFormatJUnit, nil
+	case "checkstyle":
+		return domain.OutputFormatCheckstyle, nil
+	default:
+		return "", fmt.Errorf("unsupported output format '%s', must be one of: text, json, markdown, junit, checkstyle", checkFormat)
+	}
+}
+
+func checkComplexity(ctx context.Context, files []string, cfg *config.Config, result *domain.CheckResult, pm domain.ProgressManager) (*domain.ComplexityResponse, error) {
 	result.Summary.ComplexityChecked = true
 
 	svc := service.NewComplexityServiceWithProgress(&cfg.Complexity, pm)
@@ -171,7 +329,7 @@ func checkComplexity(ctx context.Context, files []string, cfg *config.Config, re
 
 	resp, err := svc.Analyze(ctx, req)
 	if err != nil {
-		return fmt.Errorf("complexity analysis failed: %w", err)
+		return nil, fmt.Errorf("complexity analysis failed: %w", err)
 	}
 
 	// Check each function against threshold
@@ -191,51 +349,112 @@ func checkComplexity(ctx context.Context, files []string, cfg *config.Config, re
 		}
 	}
 
-	return nil
+	return resp, nil
 }
 
-func checkDeadCode(_ context.Context, files []string, cfg *config.Config, result *domain.CheckResult, pm domain.ProgressManager) error {
+// checkDeadCode fails the check on dead code findings at or above the configured
+// severity. perFinding reports one located violation per finding, for the formats
+// attaching violations to files, instead of one violation per severity.
+func checkDeadCode(_ context.Context, files []string, cfg *config.Config, gates *config.CheckConfig, perFinding bool, result *domain.CheckResult, pm domain.ProgressManager) (*domain.DeadCodeResponse, error) {
 	result.Summary.DeadCodeChecked = true
 
 	resp, err := runDeadCodeAnalysis(files, cfg, pm)
 	if err != nil {
-		return fmt.Errorf("dead code analysis failed: %w", err)
+		return nil, fmt.Errorf("dead code analysis failed: %w", err)
 	}
 
 	result.Summary.DeadCodeFindings = resp.Summary.TotalFindings
 
-	if !checkAllowDeadCode && resp.Summary.TotalFindings > 0 {
-		result.Passed = false
+	if checkAllowDeadCode {
+		return resp, nil
+	}
 
-		// Add violation for critical findings
-		if resp.Summary.CriticalFindings > 0 {
-			result.Violations = append(result.Violations, domain.CheckViolation{
-				Category:  "deadcode",
-				Rule:      "no-dead-code",
-				Severity:  "error",
-				Message:   fmt.Sprintf("Found %d critical dead code issues", resp.Summary.CriticalFindings),
-				Actual:    strconv.Itoa(resp.Summary.CriticalFindings),
-				Threshold: "0",
-			})
+	// Findings below check.dead_code_severity are reported without failing the check
+	minSeverity := domain.DeadCodeSeverityInfo
+	if gates.DeadCodeSeverity != "" {
+		minSeverity = domain.DeadCodeSeverity(gates.DeadCodeSeverity)
+	}
+
+	if perFinding {
+		violations := deadCodeFindingViolations(resp, minSeverity)
+		if len(violations) > 0 {
+			result.Passed = false
+			result.Violations = append(result.Violations, violations...)
 		}
+		return resp, nil
+	}
 
-		// Add violation for warnings
-		if resp.Summary.WarningFindings > 0 {
-			result.Violations = append(result.Violations, domain.CheckViolation{
+	levels := []struct {
+		severity domain.DeadCodeSeverity
+		count    int
+		message  string
+	}{
+		{domain.DeadCodeSeverityCritical, resp.Summary.CriticalFindings, "Found %d critical dead code issues"},
+		{domain.DeadCodeSeverityWarning, resp.Summary.WarningFindings, "Found %d warning-level dead code issues"},
+		{domain.DeadCodeSeverityInfo, resp.Summary.InfoFindings, "Found %d info-level dead code issues"},
+	}
+	for _, level := range levels {
+		if level.count == 0 || !level.severity.IsAtLeast(minSeverity) {
+			continue
+		}
+		result.Passed = false
+		result.Violations = append(result.Violations, domain.CheckViolation{
+			Category:  "deadcode",
+			Rule:      "no-dead-code",
+			Severity:  deadCodeViolationSeverity(level.severity),
+			Message:   fmt.Sprintf(level.message, level.count),
+			Actual:    strconv.Itoa(level.count),
+			Threshold: "0",
+		})
+	}
+
+	return resp, nil
+}
+
+// deadCodeFindingViolations returns a violation located at each finding at or above
+// minSeverity, in file order
+func deadCodeFindingViolations(resp *domain.DeadCodeResponse, minSeverity domain.DeadCodeSeverity) []domain.CheckViolation {
+	var violations []domain.CheckViolation
+	for _, file := range resp.Files {
+		findings := append([]domain.DeadCodeFinding(nil), file.FileLevelFindings...)
+		for _, fn := range file.Functions {
+			findings = append(findings, fn.Findings...)
+		}
+		for _, finding := range findings {
+			if !finding.Severity.IsAtLeast(minSeverity) {
+				continue
+			}
+			message := finding.Description
+			if message == "" {
+				message = finding.Reason
+			}
+			violations = append(violations, domain.CheckViolation{
 				Category:  "deadcode",
 				Rule:      "no-dead-code",
-				Severity:  "warning",
-				Message:   fmt.Sprintf("Found %d warning-level dead code issues", resp.Summary.WarningFindings),
-				Actual:    strconv.Itoa(resp.Summary.WarningFindings),
-				Threshold: "0",
+				Severity:  deadCodeViolationSeverity(finding.Severity),
+				Message:   message,
+				Location:  fmt.Sprintf("%s:%d", file.FilePath, finding.Location.StartLine),
+				Actual:    string(finding.Severity),
+				Threshold: string(minSeverity),
 			})
 		}
 	}
+	return violations
+}
 
-	return nil
+// deadCodeViolationSeverity maps a dead code severity to the severity of its violation
+func deadCodeViolationSeverity(severity domain.DeadCodeSeverity) string {
+	switch severity {
+	case domain.DeadCodeSeverityCritical:
+		return "error"
+	case domain.DeadCodeSeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
 }
 
-func checkDependencies(ctx context.Context, files []string, _ *config.Config, result *domain.CheckResult) error {
+func checkDependencies(ctx context.Context, files []string, _ *config.Config, result *domain.CheckResult) (*domain.DependencyGraphResponse, error) {
 	result.Summary.DepsChecked = true
 
 	// Create dependency graph service
@@ -248,7 +467,7 @@ func checkDependencies(ctx context.Context, files []string, _ *config.Config, re
 
 	resp, err := svc.Analyze(ctx, req)
 	if err != nil {
-		return fmt.Errorf("dependency analysis failed: %w", err)
+		return nil, fmt.Errorf("dependency analysis failed: %w", err)
 	}
 
 	if resp.Analysis != nil && resp.Analysis.CircularDependencies != nil {
@@ -275,7 +494,7 @@ func checkDependencies(ctx context.Context, files []string, _ *config.Config, re
 							Category: "deps",
 							Rule:     "circular-dependency",
 							Severity: string(cycle.Severity),
-							Message:  cycle.Description,
+							Message:  cycleViolationMessage(cycle),
 						})
 					}
 				}
@@ -283,10 +502,243 @@ func checkDependencies(ctx context.Context, files []string, _ *config.Config, re
 		}
 	}
 
-	return nil
+	return resp, nil
+}
+
+// checkGates merges the gates of the config file with the gate flags set on the CLI
+func checkGates(cmd *cobra.Command, cfg *config.Config) (config.CheckConfig, error) {
+	gates := cfg.Check
+	flags := cmd.Flags()
+	if flags.Changed("min-health-score") {
+		gates.MinHealthScore = checkMinHealthScore
+	}
+	if flags.Changed("min-grade") {
+		gates.MinGrade = strings.ToUpper(checkMinGrade)
+	}
+	if flags.Changed("max-duplication") {
+		gates.MaxDuplication = checkMaxDuplication
+	}
+	if flags.Changed("max-cbo") {
+		gates.MaxCBO = checkMaxCBO
+	}
+	if flags.Changed("max-nesting-depth") {
+		gates.MaxNestingDepth = checkMaxNesting
+	}
+	if flags.Changed("max-dependency-depth") {
+		gates.MaxDependencyDepth = checkMaxDepsDepth
+	}
+	if flags.Changed("max-main-sequence-distance") {
+		gates.MaxMainSequenceDistance = checkMaxMSD
+	}
+	if flags.Changed("min-type-coverage") {
+		gates.MinTypeCoverage = checkMinTypeCov
+	}
+	if flags.Changed("dead-code-severity") {
+		gates.DeadCodeSeverity = strings.ToLower(checkDeadCodeSeverity)
+	}
+	return gates, gates.Validate()
+}
+
+// addGateViolation records a gate violation; only error severity fails the check
+func addGateViolation(result *domain.CheckResult, gates *config.CheckConfig, gate string, v domain.CheckViolation) {
+	v.Severity = gates.Severity(gate)
+	if v.Severity == "error" {
+		result.Passed = false
+	}
+	result.Violations = append(result.Violations, v)
+}
+
+// checkSummaryGates applies the project-level gates to the analysis summary
+func checkSummaryGates(summary *domain.AnalyzeSummary, gates *config.CheckConfig, result *domain.CheckResult) {
+	if gates.MinHealthScore > 0 {
+		result.Summary.HealthScoreChecked = true
+		if summary.HealthScore < gates.MinHealthScore {
+			addGateViolation(result, gates, config.CheckGateHealthScore, domain.CheckViolation{
+				Category:  "health",
+				Rule:      "min-health-score",
+				Message:   fmt.Sprintf("Health score %d (grade %s) is below the minimum of %d", summary.HealthScore, summary.Grade, gates.MinHealthScore),
+				Actual:    strconv.Itoa(summary.HealthScore),
+				Threshold: strconv.Itoa(gates.MinHealthScore),
+			})
+		}
+	}
+
+	if gates.MinGrade != "" {
+		result.Summary.HealthScoreChecked = true
+		if gradeBelow(summary.Grade, gates.MinGrade) {
+			addGateViolation(result, gates, config.CheckGateGrade, domain.CheckViolation{
+				Category:  "health",
+				Rule:      "min-grade",
+				Message:   fmt.Sprintf("Grade %s (health score %d) is below the minimum grade %s", summary.Grade, summary.HealthScore, gates.MinGrade),
+				Actual:    summary.Grade,
+				Threshold: gates.MinGrade,
+			})
+		}
+	}
+
+	if gates.MaxDuplication > 0 && summary.CodeDuplication > gates.MaxDuplication {
+		addGateViolation(result, gates, config.CheckGateDuplication, domain.CheckViolation{
+			Category:  "clone",
+			Rule:      "max-duplication",
+			Message:   fmt.Sprintf("Code duplication is %.1f%% (max: %g%%)", summary.CodeDuplication, gates.MaxDuplication),
+			Actual:    fmt.Sprintf("%.1f", summary.CodeDuplication),
+			Threshold: strconv.FormatFloat(gates.MaxDuplication, 'g', -1, 64),
+		})
+	}
+
+	if gates.MaxDependencyDepth > 0 && summary.DepsMaxDepth > gates.MaxDependencyDepth {
+		addGateViolation(result, gates, config.CheckGateDependencyDepth, domain.CheckViolation{
+			Category:  "deps",
+			Rule:      "max-dependency-depth",
+			Message:   fmt.Sprintf("Longest dependency chain has depth %d (max: %d)", summary.DepsMaxDepth, gates.MaxDependencyDepth),
+			Actual:    strconv.Itoa(summary.DepsMaxDepth),
+			Threshold: strconv.Itoa(gates.MaxDependencyDepth),
+		})
+	}
+
+	if gates.MaxMainSequenceDistance > 0 && summary.DepsMainSequenceDeviation > gates.MaxMainSequenceDistance {
+		addGateViolation(result, gates, config.CheckGateMainSequenceDistance, domain.CheckViolation{
+			Category:  "deps",
+			Rule:      "max-main-sequence-distance",
+			Message:   fmt.Sprintf("Average distance from the main sequence is %.2f (max: %g)", summary.DepsMainSequenceDeviation, gates.MaxMainSequenceDistance),
+			Actual:    fmt.Sprintf("%.2f", summary.DepsMainSequenceDeviation),
+			Threshold: strconv.FormatFloat(gates.MaxMainSequenceDistance, 'g', -1, 64),
+		})
+	}
+
+	// Projects without TypeScript files have nothing to measure
+	if gates.MinTypeCoverage > 0 && summary.TypeSafetyEnabled && summary.TypeCoverage < gates.MinTypeCoverage {
+		addGateViolation(result, gates, config.CheckGateTypeCoverage, domain.CheckViolation{
+			Category:  "typesafety",
+			Rule:      "min-type-coverage",
+			Message:   fmt.Sprintf("TypeScript type coverage is %.1f%% (min: %g%%)", summary.TypeCoverage, gates.MinTypeCoverage),
+			Actual:    fmt.Sprintf("%.1f", summary.TypeCoverage),
+			Threshold: strconv.FormatFloat(gates.MinTypeCoverage, 'g', -1, 64),
+		})
+	}
+}
+
+// checkFunctionGates applies the per-function nesting and per-class coupling gates
+func checkFunctionGates(complexityResp *domain.ComplexityResponse, cboResp *domain.CBOResponse, gates *config.CheckConfig, result *domain.CheckResult) {
+	if gates.MaxNestingDepth > 0 && complexityResp != nil {
+		for _, fn := range complexityResp.Functions {
+			if fn.Metrics.NestingDepth > gates.MaxNestingDepth {
+				addGateViolation(result, gates, config.CheckGateNestingDepth, domain.CheckViolation{
+					Category:  "complexity",
+					Rule:      "max-nesting-depth",
+					Message:   fmt.Sprintf("Function '%s' has nesting depth %d", fn.Name, fn.Metrics.NestingDepth),
+					Location:  fmt.Sprintf("%s:%d", fn.FilePath, fn.StartLine),
+					Actual:    strconv.Itoa(fn.Metrics.NestingDepth),
+					Threshold: strconv.Itoa(gates.MaxNestingDepth),
+				})
+			}
+		}
+	}
+
+	if gates.MaxCBO > 0 && cboResp != nil {
+		for _, class := range cboResp.Classes {
+			if class.Metrics.CouplingCount > gates.MaxCBO {
+				addGateViolation(result, gates, config.CheckGateCBO, domain.CheckViolation{
+					Category:  "cbo",
+					Rule:      "max-cbo",
+					Message:   fmt.Sprintf("Class '%s' depends on %d classes", class.Name, class.Metrics.CouplingCount),
+					Location:  fmt.Sprintf("%s:%d", class.FilePath, class.StartLine),
+					Actual:    strconv.Itoa(class.Metrics.CouplingCount),
+					Threshold: strconv.Itoa(gates.MaxCBO),
+				})
+			}
+		}
+	}
 }
 
-func outputCheckResult(result *domain.CheckResult, startTime time.Time) error {
+// checkReactGates reports the hook violations and the components over the React
+// thresholds or defined inside another component
+func checkReactGates(reactResp *domain.ReactResponse, gates *config.CheckConfig, result *domain.CheckResult) {
+	if reactResp == nil {
+		return
+	}
+	for _, file := range reactResp.Files {
+		for _, hook := range file.HookViolations {
+			addGateViolation(result, gates, config.CheckGateRulesOfHooks, domain.CheckViolation{
+				Category: "react",
+				Rule:     "rules-of-hooks",
+				Message:  fmt.Sprintf("Hook '%s' is called %s in '%s'", hook.Hook, hookReasonText(hook.Reason), hook.Function),
+				Location: fmt.Sprintf("%s:%d", file.FilePath, hook.Line),
+				Actual:   hook.Reason,
+			})
+		}
+		for _, component := range file.Components {
+			location := fmt.Sprintf("%s:%d", file.FilePath, component.StartLine)
+			if len(component.Exceeds) > 0 {
+				addGateViolation(result, gates, config.CheckGateReactComponent, domain.CheckViolation{
+					Category: "react",
+					Rule:     "max-component-size",
+					Message:  fmt.Sprintf("Component '%s' exceeds the %s thresholds", component.Name, strings.Join(component.Exceeds, ", ")),
+					Location: location,
+					Actual: fmt.Sprintf("depth %d, props %d, hooks %d, conditional renders %d",
+						component.JSXDepth, component.Props, component.Hooks, component.ConditionalRenders),
+				})
+			}
+			if component.DefinedIn != "" {
+				addGateViolation(result, gates, config.CheckGateReactComponent, domain.CheckViolation{
+					Category: "react",
+					Rule:     "no-nested-component",
+					Message:  fmt.Sprintf("Component '%s' is defined inside '%s' and remounts on every render", component.Name, component.DefinedIn),
+					Location: location,
+					Actual:   component.DefinedIn,
+				})
+			}
+		}
+	}
+}
+
+// hookReasonText describes where a hook is called against the rules of hooks
+func hookReasonText(reason string) string {
+	switch reason {
+	case domain.HookInCondition:
+		return "conditionally"
+	case domain.HookInLoop:
+		return "in a loop"
+	case domain.HookInCallback:
+		return "in a nested function"
+	case domain.HookAfterReturn:
+		return "after an early return"
+	}
+	return reason
+}
+
+// gradeBelow reports whether grade is worse than minGrade (unknown grades are worst)
+func gradeBelow(grade, minGrade string) bool {
+	rank := func(g string) int {
+		if i := strings.Index("ABCDF", g); i >= 0 && len(g) == 1 {
+			return i
+		}
+		return len("ABCDF")
+	}
+	return rank(grade) > rank(minGrade)
+}
+
+// cycleViolationMessage describes a cycle along with the imports recommended for removal
+func cycleViolationMessage(cycle domain.CircularDependency) string {
+	if len(cycle.BreakingEdges) == 0 {
+		return cycle.Description
+	}
+
+	breaks := make([]string, 0, len(cycle.BreakingEdges))
+	for _, edge := range cycle.BreakingEdges {
+		switch {
+		case edge.CanBeTypeOnly:
+			breaks = append(breaks, fmt.Sprintf("%s -> %s (use import type)", edge.From, edge.To))
+		case edge.TypeOnly:
+			breaks = append(breaks, fmt.Sprintf("%s -> %s (type-only, erased at runtime)", edge.From, edge.To))
+		default:
+			breaks = append(breaks, fmt.Sprintf("%s -> %s", edge.From, edge.To))
+		}
+	}
+	return fmt.Sprintf("%s; break it by removing %s", cycle.Description, strings.Join(breaks, ", "))
+}
+
+func outputCheckResult(result *domain.CheckResult, startTime time.Time, format domain.OutputFormat, cfg *config.Config) error {
 	result.Duration = time.Since(startTime).Milliseconds()
 	result.GeneratedAt = time.Now().Format(time.RFC3339)
 	result.Version = version.Version
@@ -296,16 +748,26 @@ func outputCheckResult(result *domain.CheckResult, startTime time.Time) error {
 	}
 	result.Summary.TotalViolations = len(result.Violations)
 
-	if checkJSON {
+	switch format {
+	case domain.OutputFormatJSON:
 		return outputCheckJSON(result)
+	case domain.OutputFormatMarkdown:
+		return outputCheckMarkdown(result, cfg)
+	case domain.OutputFormatJUnit, domain.OutputFormatCheckstyle:
+		return outputCheckReport(result, format)
+	default:
+		return outputCheckText(result)
 	}
-
-	return outputCheckText(result)
 }
 
 func outputCheckText(result *domain.CheckResult) error {
 	if result.Passed {
-		fmt.Println("PASS: All quality checks passed")
+		if len(result.Violations) > 0 {
+			fmt.Printf("PASS: Quality checks passed with %d warnings\n", len(result.Violations))
+			printCheckViolations(result.Violations)
+		} else {
+			fmt.Println("PASS: All quality checks passed")
+		}
 		if checkVerbose {
 			fmt.Printf("  Files analyzed: %d\n", result.Summary.FilesAnalyzed)
 			fmt.Printf("  Duration: %dms\n", result.Duration)
@@ -315,9 +777,22 @@ func outputCheckText(result *domain.CheckResult) error {
 			if result.Summary.DeadCodeChecked {
 				fmt.Printf("  Dead code: checked\n")
 			}
+			if result.Summary.CloneChecked {
+				fmt.Printf("  Clones: checked\n")
+			}
+			if result.Summary.CBOChecked {
+				fmt.Printf("  Coupling: checked\n")
+			}
 			if result.Summary.DepsChecked {
 				fmt.Printf("  Dependencies: checked\n")
 			}
+			if result.Summary.TypeSafetyChecked {
+				fmt.Printf("  Type safety: checked\n")
+			}
+			if result.Summary.ReactChecked {
+				fmt.Printf("  React: checked\n")
+			}
+			fmt.Printf("  Health score: %d/100 (grade %s)\n", result.Summary.HealthScore, result.Summary.Grade)
 		}
 		return nil
 	}
@@ -325,17 +800,7 @@ func outputCheckText(result *domain.CheckResult) error {
 	fmt.Println("FAIL: Quality check failed")
 	fmt.Printf("  Violations: %d\n", result.Summary.TotalViolations)
 
-	// Print violations
-	for _, v := range result.Violations {
-		severity := "ERROR"
-		if v.Severity == "warning" {
-			severity = "WARN"
-		}
-		fmt.Printf("  [%s] %s: %s\n", severity, v.Category, v.Message)
-		if checkVerbose && v.Location != "" {
-			fmt.Printf("         at %s\n", v.Location)
-		}
-	}
+	printCheckViolations(result.Violations)
 
 	if checkVerbose {
 		fmt.Printf("\nSummary:\n")
@@ -349,12 +814,30 @@ func outputCheckText(result *domain.CheckResult) error {
 		if result.Summary.DepsChecked {
 			fmt.Printf("  Circular dependencies: %d\n", result.Summary.CircularDependencies)
 		}
+		fmt.Printf("  Health score: %d/100 (grade %s)\n", result.Summary.HealthScore, result.Summary.Grade)
 		fmt.Printf("  Duration: %dms\n", result.Duration)
 	}
 
 	return &CheckExitError{Code: 1, Message: ""}
 }
 
+// printCheckViolations prints one line per violation, with its location in verbose mode
+func printCheckViolations(violations []domain.CheckViolation) {
+	for _, v := range violations {
+		severity := "ERROR"
+		switch v.Severity {
+		case "warning":
+			severity = "WARN"
+		case "info":
+			severity = "INFO"
+		}
+		fmt.Printf("  [%s] %s: %s\n", severity, v.Category, v.Message)
+		if checkVerbose && v.Location != "" {
+			fmt.Printf("         at %s\n", v.Location)
+		}
+	}
+}
+
 func outputCheckJSON(result *domain.CheckResult) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -367,3 +850,28 @@ func outputCheckJSON(result *domain.CheckResult) error {
 	}
 	return nil
 }
+
+func outputCheckMarkdown(result *domain.CheckResult, cfg *config.Config) error {
+	markdownConfig := service.DefaultMarkdownFormatterConfig()
+	markdownConfig.MaxBytes = cfg.Output.MarkdownMaxBytes
+	if err := service.NewMarkdownFormatter(markdownConfig).WriteCheck(result, os.Stdout); err != nil {
+		return &CheckExitError{Code: 2, Message: fmt.Sprintf("failed to write Markdown: %v", err)}
+	}
+
+	if !result.Passed {
+		return &CheckExitError{Code: 1, Message: ""}
+	}
+	return nil
+}
+
+// outputCheckReport writes a CI report format such as JUnit or Checkstyle XML
+func outputCheckReport(result *domain.CheckResult, format domain.OutputFormat) error {
+	if err := service.NewOutputFormatter().WriteCheck(result, format, os.Stdout); err != nil {
+		return &CheckExitError{Code: 2, Message: fmt.Sprintf("failed to write %s report: %v", format, err)}
+	}
+
+	if !result.Passed {
+		return &CheckExitError{Code: 1, Message: ""}
+	}
+	return nil
+}
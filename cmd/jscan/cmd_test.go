@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/service"
 )
 
 func TestAnalyzeCmd_FlagsExist(t *testing.T) {
 	cmd := analyzeCmd()
 
-	expectedFlags := []string{"select", "format", "json", "text", "html", "no-open", "output", "config"}
+	expectedFlags := []string{"select", "format", "json", "text", "html", "no-open", "output", "config", "clone-mode", "statement-sequences"}
 	for _, flagName := range expectedFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		if flag == nil {
@@ -50,8 +57,8 @@ func TestAnalyzeCmd_DefaultValues(t *testing.T) {
 		t.Fatal("select flag not found")
 	}
 	// Default is all analyses
-	if selectFlag.DefValue != "[complexity,deadcode,clone,cbo,deps]" {
-		t.Errorf("Expected default select to be '[complexity,deadcode,clone,cbo,deps]', got '%s'", selectFlag.DefValue)
+	if selectFlag.DefValue != "[complexity,deadcode,clone,cbo,deps,typesafety,react]" {
+		t.Errorf("Expected default select to be '[complexity,deadcode,clone,cbo,deps,typesafety,react]', got '%s'", selectFlag.DefValue)
 	}
 }
 
@@ -65,10 +72,35 @@ func TestAnalyzeCmd_NoPathsError(t *testing.T) {
 	}
 }
 
+func TestApplyCloneFlags(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Clones.Analysis.DetectionMode = "tree"
+
+	if err := applyCloneFlags(cfg, "", nil); err != nil || cfg.Clones.Analysis.DetectionMode != "tree" {
+		t.Errorf("Expected the configured mode to be kept, got %q: %v", cfg.Clones.Analysis.DetectionMode, err)
+	}
+	if err := applyCloneFlags(cfg, "hybrid", nil); err != nil || cfg.Clones.Analysis.DetectionMode != "hybrid" {
+		t.Errorf("Expected the flag to override the configured mode, got %q: %v", cfg.Clones.Analysis.DetectionMode, err)
+	}
+	if err := applyCloneFlags(cfg, "fuzzy", nil); err == nil {
+		t.Error("Expected an error for an unknown clone mode")
+	}
+
+	cfg.Clones = nil
+	if err := applyCloneFlags(cfg, "token", nil); err != nil || cfg.Clones == nil || cfg.Clones.Analysis.DetectionMode != "token" {
+		t.Errorf("Expected the mode to be set without a clones section, got %+v: %v", cfg.Clones, err)
+	}
+
+	if err := applyCloneFlags(cfg, "", config.BoolPtr(true)); err != nil ||
+		!config.BoolValue(cfg.Clones.Analysis.StatementSequences, false) || cfg.Clones.Analysis.DetectionMode != "token" {
+		t.Errorf("Expected --statement-sequences to enable the pass only, got %+v: %v", cfg.Clones.Analysis, err)
+	}
+}
+
 func TestCheckCmd_FlagsExist(t *testing.T) {
 	cmd := checkCmd()
 
-	expectedFlags := []string{"max-complexity", "allow-dead-code", "allow-circular-deps", "max-cycles", "select", "verbose", "json", "config"}
+	expectedFlags := []string{"max-complexity", "allow-dead-code", "allow-circular-deps", "max-cycles", "min-health-score", "min-grade", "max-duplication", "max-cbo", "max-nesting-depth", "max-dependency-depth", "max-main-sequence-distance", "select", "verbose", "json", "format", "config", "clone-mode", "statement-sequences", "dead-code-severity"}
 	for _, flagName := range expectedFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		if flag == nil {
@@ -83,6 +115,7 @@ func TestCheckCmd_ShortFlags(t *testing.T) {
 	shortFlags := map[string]string{
 		"s": "select",
 		"v": "verbose",
+		"f": "format",
 		"c": "config",
 	}
 
@@ -104,6 +137,36 @@ func TestCheckCmd_NoPathsError(t *testing.T) {
 	}
 }
 
+func TestCheckOutputFormat(t *testing.T) {
+	defer func() { checkJSON, checkFormat = false, "text" }()
+
+	tests := []struct {
+		json     bool
+		format   string
+		expected domain.OutputFormat
+	}{
+		{format: "text", expected: domain.OutputFormatText},
+		{format: "json", expected: domain.OutputFormatJSON},
+		{format: "markdown", expected: domain.OutputFormatMarkdown},
+		{format: "md", expected: domain.OutputFormatMarkdown},
+		{format: "junit", expected: domain.OutputFormatJUnit},
+		{format: "checkstyle", expected: domain.OutputFormatCheckstyle},
+		{json: true, format: "text", expected: domain.OutputFormatJSON},
+	}
+	for _, tc := range tests {
+		checkJSON, checkFormat = tc.json, tc.format
+		format, err := checkOutputFormat()
+		if err != nil || format != tc.expected {
+			t.Errorf("checkOutputFormat() with --json=%v --format %s = %q, %v; want %q", tc.json, tc.format, format, err, tc.expected)
+		}
+	}
+
+	checkJSON, checkFormat = false, "xml"
+	if _, err := checkOutputFormat(); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
 func TestCheckExitError_Error(t *testing.T) {
 	err := &CheckExitError{Code: 1, Message: "test error"}
 	if err.Error() != "test error" {
@@ -111,10 +174,26 @@ func TestCheckExitError_Error(t *testing.T) {
 	}
 }
 
+func TestCycleViolationMessage(t *testing.T) {
+	cycle := domain.CircularDependency{Description: "Circular dependency involving 2 modules: a.ts <-> b.ts"}
+	if msg := cycleViolationMessage(cycle); msg != cycle.Description {
+		t.Errorf("Expected plain description without recommendation, got %q", msg)
+	}
+
+	cycle.BreakingEdges = []domain.CycleBreakingEdge{
+		{From: "src/a.ts", To: "src/b.ts"},
+		{From: "src/c.ts", To: "src/a.ts", CanBeTypeOnly: true},
+	}
+	expected := "Circular dependency involving 2 modules: a.ts <-> b.ts; break it by removing src/a.ts -> src/b.ts, src/c.ts -> src/a.ts (use import type)"
+	if msg := cycleViolationMessage(cycle); msg != expected {
+		t.Errorf("Expected %q, got %q", expected, msg)
+	}
+}
+
 func TestDepsCmd_FlagsExist(t *testing.T) {
 	cmd := depsCmd()
 
-	expectedFlags := []string{"format", "output", "config", "dot", "include-external", "include-types", "no-cycles", "max-depth", "min-coupling", "no-legend", "rank-dir"}
+	expectedFlags := []string{"format", "output", "config", "dot", "include-external", "include-types", "no-cycles", "max-depth", "min-coupling", "no-legend", "rank-dir", "why", "dependents-of", "dependencies-of", "between", "transitive", "max-paths"}
 	for _, flagName := range expectedFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		if flag == nil {
@@ -162,6 +241,213 @@ func TestDepsCmd_NoPathsError(t *testing.T) {
 	}
 }
 
+func TestBuildDepsQuery(t *testing.T) {
+	defer func() {
+		depsWhy, depsDependentsOf, depsBetween, depsTransitive = "", "", "", false
+	}()
+
+	depsWhy = "src/a.ts"
+	query, paths, err := buildDepsQuery([]string{"src/z.ts", "src/"})
+	if err != nil {
+		t.Fatalf("buildDepsQuery failed: %v", err)
+	}
+	if query.Source != "src/a.ts" || query.Target != "src/z.ts" {
+		t.Errorf("Expected why src/a.ts src/z.ts, got %s %s", query.Source, query.Target)
+	}
+	if len(paths) != 1 || paths[0] != "src/" {
+		t.Errorf("Expected paths [src/], got %v", paths)
+	}
+
+	query, paths, err = buildDepsQuery([]string{"src/z.ts"})
+	if err != nil {
+		t.Fatalf("buildDepsQuery failed: %v", err)
+	}
+	if query.Target != "src/z.ts" || len(paths) != 1 || paths[0] != "." {
+		t.Errorf("Expected paths to default to '.', got %v", paths)
+	}
+
+	if _, _, err := buildDepsQuery(nil); err == nil {
+		t.Error("Expected error when --why has no second module")
+	}
+
+	depsWhy = ""
+	depsDependentsOf = "src/a.ts"
+	depsTransitive = true
+	query, paths, err = buildDepsQuery([]string{"src/"})
+	if err != nil {
+		t.Fatalf("buildDepsQuery failed: %v", err)
+	}
+	if !query.Transitive || query.Target != "" || len(paths) != 1 {
+		t.Errorf("Unexpected query %+v with paths %v", query, paths)
+	}
+
+	depsBetween = "src/ui/**"
+	if _, _, err := buildDepsQuery([]string{"src/db/**"}); err == nil {
+		t.Error("Expected error when combining query flags")
+	}
+}
+
+func TestImpactCmd_FlagsExist(t *testing.T) {
+	cmd := impactCmd()
+
+	expectedFlags := []string{"format", "output", "config", "root", "from-git", "include-types"}
+	for _, flagName := range expectedFlags {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			t.Errorf("Missing expected flag: --%s", flagName)
+		}
+	}
+}
+
+func TestImpactCmd_NoChangedFilesError(t *testing.T) {
+	cmd := impactCmd()
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("Expected error when no changed files specified")
+	}
+}
+
+func TestAnalyzeCmd_HistoryFlag(t *testing.T) {
+	cmd := analyzeCmd()
+
+	flag := cmd.Flags().Lookup("history")
+	if flag == nil {
+		t.Fatal("Missing expected flag: --history")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("Expected --history to default to false, got %s", flag.DefValue)
+	}
+}
+
+func TestAnalyzeCmd_GroupByFlag(t *testing.T) {
+	cmd := analyzeCmd()
+
+	flag := cmd.Flags().Lookup("group-by")
+	if flag == nil {
+		t.Fatal("Missing expected flag: --group-by")
+	}
+	if flag.DefValue != "" {
+		t.Errorf("Expected --group-by to default to no breakdown, got %s", flag.DefValue)
+	}
+}
+
+func TestTrendCmd_FlagsExist(t *testing.T) {
+	cmd := trendCmd()
+
+	expectedFlags := []string{"format", "output", "config", "history-file", "limit", "top", "no-open"}
+	for _, flagName := range expectedFlags {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			t.Errorf("Missing expected flag: --%s", flagName)
+		}
+	}
+}
+
+func TestDepsCmd_TemporalCouplingFlags(t *testing.T) {
+	cmd := depsCmd()
+
+	for _, flagName := range []string{"temporal-coupling", "temporal-since"} {
+		if cmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Missing expected flag: --%s", flagName)
+		}
+	}
+}
+
+func TestHotspotsCmd_FlagsExist(t *testing.T) {
+	cmd := hotspotsCmd()
+
+	expectedFlags := []string{"format", "output", "config", "since", "recent", "top", "no-open"}
+	for _, flagName := range expectedFlags {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			t.Errorf("Missing expected flag: --%s", flagName)
+		}
+	}
+}
+
+func TestHotspotsCmd_InvalidWindow(t *testing.T) {
+	cmd := hotspotsCmd()
+	cmd.SetArgs([]string{"--since", "ten days", "."})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error for an invalid --since window")
+	}
+}
+
+func TestLSPCmd(t *testing.T) {
+	cmd := lspCmd()
+	if cmd.Flags().Lookup("config") == nil {
+		t.Error("Missing expected flag: --config")
+	}
+
+	cmd.SetArgs([]string{"src/"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error for positional arguments")
+	}
+}
+
+func TestServeCmd(t *testing.T) {
+	cmd := serveCmd()
+	for _, flagName := range []string{"addr", "config", "poll"} {
+		if cmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Missing expected flag: --%s", flagName)
+		}
+	}
+	if got := cmd.Flags().Lookup("addr").DefValue; got != "127.0.0.1:7878" {
+		t.Errorf("Expected the default address to be loopback, got %s", got)
+	}
+
+	cmd.SetArgs([]string{filepath.Join(t.TempDir(), "missing")})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestMCPCmd(t *testing.T) {
+	cmd := mcpCmd()
+	if cmd.Flags().Lookup("config") == nil {
+		t.Error("Missing expected flag: --config")
+	}
+
+	cmd.SetArgs([]string{filepath.Join(t.TempDir(), "missing")})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestDiffCmd_FlagsExist(t *testing.T) {
+	cmd := diffCmd()
+
+	expectedFlags := []string{"format", "output", "no-open"}
+	for _, flagName := range expectedFlags {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			t.Errorf("Missing expected flag: --%s", flagName)
+		}
+	}
+}
+
+func TestDiffCmd_RequiresTwoReports(t *testing.T) {
+	cmd := diffCmd()
+	cmd.SetArgs([]string{"old.json"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error with a single report")
+	}
+}
+
 func TestVersionCmd_FlagsExist(t *testing.T) {
 	cmd := versionCmd()
 
@@ -183,3 +469,220 @@ func TestVersionCmd_ShortFlag(t *testing.T) {
 		t.Error("Missing short flag -v for --verbose")
 	}
 }
+
+func TestCheckSummaryGates(t *testing.T) {
+	summary := &domain.AnalyzeSummary{HealthScore: 52, Grade: "D", CodeDuplication: 12.5, DepsMaxDepth: 4, DepsMainSequenceDeviation: 0.3}
+
+	result := &domain.CheckResult{Passed: true}
+	gates := &config.CheckConfig{MinHealthScore: 50, MinGrade: "D", MaxDuplication: 15, MaxDependencyDepth: 4, MaxMainSequenceDistance: 0.5}
+	checkSummaryGates(summary, gates, result)
+	if !result.Passed || len(result.Violations) != 0 || !result.Summary.HealthScoreChecked {
+		t.Fatalf("Expected all gates to pass, got %+v", result)
+	}
+
+	gates = &config.CheckConfig{
+		MinHealthScore:          70,
+		MinGrade:                "C",
+		MaxDuplication:          10,
+		MaxDependencyDepth:      3,
+		MaxMainSequenceDistance: 0.2,
+		Severities:              map[string]string{config.CheckGateDuplication: "warning"},
+	}
+	checkSummaryGates(summary, gates, result)
+	if result.Passed || len(result.Violations) != 5 {
+		t.Fatalf("Expected 5 violations failing the check, got %+v", result)
+	}
+
+	rules := make(map[string]domain.CheckViolation)
+	for _, v := range result.Violations {
+		rules[v.Rule] = v
+	}
+	if v := rules["min-health-score"]; v.Category != "health" || v.Severity != "error" || v.Actual != "52" || v.Threshold != "70" {
+		t.Errorf("Unexpected health score violation %+v", v)
+	}
+	if v := rules["max-duplication"]; v.Category != "clone" || v.Severity != "warning" || v.Actual != "12.5" || v.Threshold != "10" {
+		t.Errorf("Unexpected duplication violation %+v", v)
+	}
+	if v := rules["min-grade"]; v.Actual != "D" || v.Threshold != "C" {
+		t.Errorf("Unexpected grade violation %+v", v)
+	}
+}
+
+func TestCheckSummaryGates_TypeCoverage(t *testing.T) {
+	gates := &config.CheckConfig{MinTypeCoverage: 90}
+
+	// JavaScript-only projects have no type coverage to gate on
+	result := &domain.CheckResult{Passed: true}
+	checkSummaryGates(&domain.AnalyzeSummary{}, gates, result)
+	if !result.Passed || len(result.Violations) != 0 {
+		t.Fatalf("Expected no violation without TypeScript files, got %+v", result)
+	}
+
+	summary := &domain.AnalyzeSummary{TypeSafetyEnabled: true, TypeCoverage: 82.4}
+	checkSummaryGates(summary, gates, result)
+	if result.Passed || len(result.Violations) != 1 {
+		t.Fatalf("Expected 1 violation failing the check, got %+v", result)
+	}
+	if v := result.Violations[0]; v.Rule != "min-type-coverage" || v.Category != "typesafety" || v.Actual != "82.4" || v.Threshold != "90" {
+		t.Errorf("Unexpected type coverage violation %+v", v)
+	}
+}
+
+func TestCheckReactGates(t *testing.T) {
+	react := &domain.ReactResponse{Files: []domain.FileReact{{
+		FilePath: "src/App.tsx",
+		Components: []domain.ReactComponent{
+			{Name: "App", StartLine: 1, JSXDepth: 9, Exceeds: []string{domain.ReactMetricJSXDepth}},
+			{Name: "Row", StartLine: 4, DefinedIn: "App"},
+			{Name: "Footer", StartLine: 20},
+		},
+		HookViolations: []domain.HookViolation{
+			{Hook: "useEffect", Function: "App", Line: 3, Reason: domain.HookInCondition},
+		},
+	}}}
+	gates := &config.CheckConfig{Severities: map[string]string{config.CheckGateReactComponent: "warning"}}
+
+	result := &domain.CheckResult{Passed: true}
+	checkReactGates(react, gates, result)
+	if result.Passed || len(result.Violations) != 3 {
+		t.Fatalf("Expected 3 violations failing the check, got %+v", result)
+	}
+
+	hook := result.Violations[0]
+	if hook.Rule != "rules-of-hooks" || hook.Severity != "error" || hook.Location != "src/App.tsx:3" ||
+		hook.Message != "Hook 'useEffect' is called conditionally in 'App'" {
+		t.Errorf("Unexpected hook violation %+v", hook)
+	}
+	if v := result.Violations[1]; v.Rule != "max-component-size" || v.Severity != "warning" {
+		t.Errorf("Unexpected component size violation %+v", v)
+	}
+	if v := result.Violations[2]; v.Rule != "no-nested-component" || v.Location != "src/App.tsx:4" {
+		t.Errorf("Unexpected nested component violation %+v", v)
+	}
+}
+
+func TestCheckGates_WarningsDoNotFail(t *testing.T) {
+	complexity := &domain.ComplexityResponse{Functions: []domain.FunctionComplexity{
+		{Name: "deep", FilePath: "src/a.ts", StartLine: 3, Metrics: domain.ComplexityMetrics{NestingDepth: 6}},
+		{Name: "flat", FilePath: "src/a.ts", StartLine: 20, Metrics: domain.ComplexityMetrics{NestingDepth: 1}},
+	}}
+	cbo := &domain.CBOResponse{Classes: []domain.ClassCoupling{
+		{Name: "Hub", FilePath: "src/hub.ts", StartLine: 1, Metrics: domain.CBOMetrics{CouplingCount: 12}},
+	}}
+	gates := &config.CheckConfig{
+		MaxNestingDepth: 4,
+		MaxCBO:          10,
+		Severities:      map[string]string{config.CheckGateNestingDepth: "warning", config.CheckGateCBO: "warning"},
+	}
+
+	result := &domain.CheckResult{Passed: true}
+	checkFunctionGates(complexity, cbo, gates, result)
+	if !result.Passed || len(result.Violations) != 2 {
+		t.Fatalf("Expected 2 warnings without failing, got %+v", result)
+	}
+	if v := result.Violations[0]; v.Rule != "max-nesting-depth" || v.Location != "src/a.ts:3" || v.Severity != "warning" {
+		t.Errorf("Unexpected nesting violation %+v", v)
+	}
+	if v := result.Violations[1]; v.Rule != "max-cbo" || v.Category != "cbo" || v.Location != "src/hub.ts:1" {
+		t.Errorf("Unexpected CBO violation %+v", v)
+	}
+}
+
+func TestGradeBelow(t *testing.T) {
+	tests := []struct {
+		grade, min string
+		want       bool
+	}{
+		{"A", "B", false},
+		{"B", "B", false},
+		{"C", "B", true},
+		{"F", "D", true},
+		{"N/A", "D", true},
+	}
+	for _, tt := range tests {
+		if got := gradeBelow(tt.grade, tt.min); got != tt.want {
+			t.Errorf("gradeBelow(%q, %q) = %v, want %v", tt.grade, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestCheckGates_FlagsOverrideConfig(t *testing.T) {
+	cmd := checkCmd()
+	if err := cmd.ParseFlags([]string{"--max-cbo", "8", "--min-grade", "b", "--dead-code-severity", "Warning"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Check.MaxCBO = 20
+	cfg.Check.MaxNestingDepth = 5
+
+	gates, err := checkGates(cmd, cfg)
+	if err != nil {
+		t.Fatalf("checkGates failed: %v", err)
+	}
+	if gates.MaxCBO != 8 || gates.MinGrade != "B" || gates.MaxNestingDepth != 5 || gates.DeadCodeSeverity != "warning" {
+		t.Errorf("Expected flags to override config, got %+v", gates)
+	}
+
+	if err := cmd.ParseFlags([]string{"--min-health-score", "101"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkGates(cmd, cfg); err == nil {
+		t.Error("Expected error for out of range --min-health-score")
+	}
+}
+
+func TestCheckDeadCode_Severity(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"lib/index.ts": "export * from './a';\nexport { b } from './b';\n",
+		"lib/a.ts":     "export const a = 1;\n",
+		"lib/b.ts":     "export const b = 1;\n",
+		"main.ts":      "import { a } from './lib';\nconsole.log(a);\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	cfg := config.DefaultConfig()
+	result := &domain.CheckResult{Passed: true}
+	resp, err := checkDeadCode(context.Background(), paths, cfg, &cfg.Check, false, result, service.NewProgressManager(false))
+	if err != nil {
+		t.Fatalf("checkDeadCode failed: %v", err)
+	}
+	// b.ts is only re-exported: an info-level unused export
+	if resp.Summary.TotalFindings != 1 || resp.Summary.InfoFindings != 1 {
+		t.Errorf("Expected one info finding for b, got %+v", resp.Summary)
+	}
+	if result.Passed || len(result.Violations) != 1 || result.Violations[0].Severity != "info" {
+		t.Errorf("Expected any finding to fail the check by default, got %+v", result)
+	}
+
+	// JUnit and Checkstyle get the finding located in its file
+	result = &domain.CheckResult{Passed: true}
+	if _, err := checkDeadCode(context.Background(), paths, cfg, &cfg.Check, true, result, service.NewProgressManager(false)); err != nil {
+		t.Fatalf("checkDeadCode failed: %v", err)
+	}
+	if len(result.Violations) != 1 {
+		t.Fatalf("Expected one violation per finding, got %+v", result.Violations)
+	}
+	if file, line := result.Violations[0].FileLine(); file != filepath.Join(dir, "lib", "b.ts") || line != 1 || result.Passed {
+		t.Errorf("Expected a failing violation at lib/b.ts:1, got %+v", result.Violations[0])
+	}
+
+	cfg.Check.DeadCodeSeverity = "warning"
+	result = &domain.CheckResult{Passed: true}
+	if _, err := checkDeadCode(context.Background(), paths, cfg, &cfg.Check, false, result, service.NewProgressManager(false)); err != nil {
+		t.Fatalf("checkDeadCode failed: %v", err)
+	}
+	if !result.Passed || len(result.Violations) != 0 {
+		t.Errorf("Expected info-level findings to pass with dead_code_severity warning, got %+v", result)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ludo-technologies/jscan/domain"
@@ -25,6 +26,29 @@ var (
 	depsMinCoupling     int
 	depsNoLegend        bool
 	depsRankDir         string
+
+	// Query flags
+	depsWhy            string
+	depsDependentsOf   string
+	depsDependenciesOf string
+	depsBetween        string
+	depsTransitive     bool
+	depsMaxPaths       int
+
+	// Temporal coupling flags
+	depsTemporalCoupling bool
+	depsTemporalSince    string
+
+	// Package hygiene flags
+	depsPackages bool
+
+	// Barrel flags
+	depsBarrels           bool
+	depsBarrelSuggestions bool
+
+	// Code-splitting flags
+	depsCodeSplitting bool
+	depsEntries       []string
 )
 
 func depsCmd() *cobra.Command {
@@ -37,6 +61,7 @@ Supports multiple output formats:
   - text: Human-readable text summary
   - json: JSON format for programmatic consumption
   - dot:  Graphviz DOT format for visualization
+  - markdown: Markdown summary for merge request comments
 
 Examples:
   # Generate DOT and render with Graphviz
@@ -53,12 +78,88 @@ Examples:
   jscan deps --format json src/
 
   # Save to file
-  jscan deps --dot -o deps.dot src/`,
+  jscan deps --dot -o deps.dot src/
+
+  # Markdown summary for a PR comment
+  jscan deps --format markdown src/ > deps.md
+
+Queries:
+  --why and --between take two modules; the second one is the first
+  positional argument. Modules are given as paths, path suffixes or
+  globs. Paths to analyze default to the current directory.
+
+  # Why does the UI import the database layer? (all import paths)
+  jscan deps --why src/ui/app.ts src/db/client.ts src/
+
+  # Who imports this module, directly or transitively?
+  jscan deps --dependents-of src/utils/date.ts --transitive src/
+
+  # Everything a module pulls in
+  jscan deps --dependencies-of src/index.ts --transitive src/
+
+  # Direct imports from one layer into another, highlighted in DOT
+  jscan deps --between 'src/ui/**' 'src/db/**' --dot src/ > deps.dot
+
+Temporal coupling:
+  --temporal-coupling reads the local git history and compares how often
+  modules change together with their imports. It reports pairs that change
+  together without importing each other (hidden coupling) and imports between
+  modules that rarely change together, and draws them as a layer in DOT
+  output. Thresholds come from the temporal_coupling config section.
+
+  # Hidden coupling over the last 6 months
+  jscan deps --temporal-coupling --temporal-since 6m src/
+
+  # As a layer of the DOT graph
+  jscan deps --temporal-coupling --dot src/ | dot -Tsvg -o deps.svg
+
+Packages:
+  --packages compares the package imports with the package.json files above
+  the analyzed files and the workspaces they declare. It reports dependencies
+  never imported, imports missing from package.json, devDependencies imported
+  from production code, deep imports into package internals (lib/, dist/,
+  internal/...) and packages declared with different versions across
+  workspaces. Config, tooling and test files may import devDependencies;
+  more patterns and ignored packages come from the packages config section.
+
+  # Dependency hygiene of a project
+  jscan deps --packages .
+
+  # As JSON for CI
+  jscan deps --packages --format json .
+
+Barrels:
+  --barrels finds barrel files (modules such as index.ts that mostly re-export
+  other modules), traces each symbol imported through a barrel to the module
+  defining it, and reports cycles that only exist because of barrels and
+  re-exported symbols nothing imports. --barrel-suggestions adds the direct
+  import statements replacing each import through a barrel.
+
+  # Barrels and the cycles they cause
+  jscan deps --barrels src/
+
+  # With the direct imports to use instead
+  jscan deps --barrels --barrel-suggestions src/
+
+Code splitting:
+  --code-splitting computes the chunk of each entry point and of each dynamic
+  import() target: the modules they reach through static imports, with their
+  source size. Modules reachable both statically from an entry point and from
+  a dynamic import are reported, and dynamic imports of modules the entry
+  points already load statically are flagged as defeated. Entry points are
+  the modules nothing imports unless --entry is given. DOT output clusters
+  the modules by chunk.
+
+  # Chunks and the lazy modules pulled into the initial bundle
+  jscan deps --code-splitting --entry src/main.tsx src/
+
+  # Chunk clusters as SVG
+  jscan deps --code-splitting --dot src/ | dot -Tsvg -o chunks.svg`,
 		RunE: runDeps,
 	}
 
 	cmd.Flags().StringVarP(&depsOutputFormat, "format", "f", "text",
-		"Output format: text, json, dot")
+		"Output format: text, json, dot, markdown")
 	cmd.Flags().StringVarP(&depsOutputPath, "output", "o", "",
 		"Output file path (default: stdout)")
 	cmd.Flags().StringVarP(&depsConfigPath, "config", "c", "",
@@ -79,11 +180,41 @@ Examples:
 		"Disable legend in DOT output")
 	cmd.Flags().StringVar(&depsRankDir, "rank-dir", "TB",
 		"Layout direction for DOT: TB, LR, BT, RL")
+	cmd.Flags().StringVar(&depsWhy, "why", "",
+		"Show all import paths from a module to another module (--why FROM TO)")
+	cmd.Flags().StringVar(&depsDependentsOf, "dependents-of", "",
+		"Show the modules importing the given module")
+	cmd.Flags().StringVar(&depsDependenciesOf, "dependencies-of", "",
+		"Show the modules imported by the given module")
+	cmd.Flags().StringVar(&depsBetween, "between", "",
+		"Show dependencies from modules matching a glob to another (--between GLOB GLOB)")
+	cmd.Flags().BoolVar(&depsTransitive, "transitive", false,
+		"Follow indirect dependencies in --dependents-of, --dependencies-of and --between")
+	cmd.Flags().IntVar(&depsMaxPaths, "max-paths", domain.DefaultMaxQueryPaths,
+		"Maximum number of paths shown by --why, shortest first")
+	cmd.Flags().BoolVar(&depsTemporalCoupling, "temporal-coupling", false,
+		"Compare co-changes in the git history with the imports")
+	cmd.Flags().StringVar(&depsTemporalSince, "temporal-since", "",
+		"History window of --temporal-coupling, e.g. 90d, 6m or all (default: temporal_coupling.since from config, 1y)")
+	cmd.Flags().BoolVar(&depsPackages, "packages", false,
+		"Compare package imports with package.json (unused, undeclared, dev-only and deep imports)")
+	cmd.Flags().BoolVar(&depsBarrels, "barrels", false,
+		"Analyze barrel files: imports through them, the cycles they cause and unused re-exports")
+	cmd.Flags().BoolVar(&depsBarrelSuggestions, "barrel-suggestions", false,
+		"Suggest direct imports for the imports through barrels (with --barrels)")
+	cmd.Flags().BoolVar(&depsCodeSplitting, "code-splitting", false,
+		"Analyze the chunks of the entry points and dynamic imports and the modules loaded both ways")
+	cmd.Flags().StringSliceVar(&depsEntries, "entry", nil,
+		"Entry point module or glob of --code-splitting, repeatable (default: modules nothing imports)")
 
 	return cmd
 }
 
 func runDeps(cmd *cobra.Command, args []string) (err error) {
+	query, args, err := buildDepsQuery(args)
+	if err != nil {
+		return err
+	}
 	if len(args) == 0 {
 		return fmt.Errorf("no paths specified")
 	}
@@ -94,6 +225,8 @@ func runDeps(cmd *cobra.Command, args []string) (err error) {
 		format = domain.OutputFormatDOT
 	} else if depsOutputFormat == "json" {
 		format = domain.OutputFormatJSON
+	} else if depsOutputFormat == "markdown" || depsOutputFormat == "md" {
+		format = domain.OutputFormatMarkdown
 	} else if depsOutputFormat == "text" {
 		format = domain.OutputFormatText
 	}
@@ -118,7 +251,20 @@ func runDeps(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("no JavaScript/TypeScript files found")
 	}
 
-	if format != domain.OutputFormatJSON && format != domain.OutputFormatDOT {
+	temporalCoupling, err := buildTemporalCouplingOptions(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	barrels, err := buildBarrelOptions()
+	if err != nil {
+		return err
+	}
+	codeSplitting, err := buildCodeSplittingOptions()
+	if err != nil {
+		return err
+	}
+
+	if format == domain.OutputFormatText {
 		fmt.Printf("Analyzing %d files...\n", len(files))
 	}
 
@@ -132,6 +278,11 @@ func runDeps(cmd *cobra.Command, args []string) (err error) {
 		IncludeExternal:    domain.BoolPtr(depsIncludeExternal),
 		IncludeTypeImports: domain.BoolPtr(depsIncludeTypes),
 		DetectCycles:       domain.BoolPtr(!depsNoCycles),
+		Query:              query,
+		TemporalCoupling:   temporalCoupling,
+		Packages:           buildPackageHygieneOptions(cfg),
+		Barrels:            barrels,
+		CodeSplitting:      codeSplitting,
 	}
 
 	// Analyze
@@ -191,6 +342,14 @@ func runDeps(cmd *cobra.Command, args []string) (err error) {
 			return fmt.Errorf("failed to write JSON output: %w", err)
 		}
 
+	case domain.OutputFormatMarkdown:
+		markdownConfig := service.DefaultMarkdownFormatterConfig()
+		markdownConfig.MaxBytes = cfg.Output.MarkdownMaxBytes
+		markdownFormatter := service.NewMarkdownFormatter(markdownConfig)
+		if err := markdownFormatter.WriteDependencyGraph(response, writer); err != nil {
+			return fmt.Errorf("failed to write Markdown output: %w", err)
+		}
+
 	default:
 		if err := formatter.WriteDependencyGraph(response, format, writer); err != nil {
 			return fmt.Errorf("failed to write output: %w", err)
@@ -199,10 +358,123 @@ func runDeps(cmd *cobra.Command, args []string) (err error) {
 	}
 
 	// Print output path if writing to file
-	if depsOutputPath != "" && format != domain.OutputFormatJSON && format != domain.OutputFormatDOT {
+	if depsOutputPath != "" && format == domain.OutputFormatText {
 		absPath, _ := filepath.Abs(depsOutputPath)
 		fmt.Printf("Output saved to: %s\n", absPath)
 	}
 
 	return nil
 }
+
+// buildDepsQuery builds the dependency query from the query flags. --why and
+// --between consume the first positional argument as their second operand; the
+// remaining arguments are the paths to analyze, defaulting to "." for queries.
+func buildDepsQuery(args []string) (*domain.DependencyQuery, []string, error) {
+	var queries []*domain.DependencyQuery
+	if depsWhy != "" {
+		queries = append(queries, &domain.DependencyQuery{Kind: domain.QueryWhy, Source: depsWhy})
+	}
+	if depsDependentsOf != "" {
+		queries = append(queries, &domain.DependencyQuery{Kind: domain.QueryDependentsOf, Source: depsDependentsOf})
+	}
+	if depsDependenciesOf != "" {
+		queries = append(queries, &domain.DependencyQuery{Kind: domain.QueryDependenciesOf, Source: depsDependenciesOf})
+	}
+	if depsBetween != "" {
+		queries = append(queries, &domain.DependencyQuery{Kind: domain.QueryBetween, Source: depsBetween})
+	}
+
+	switch len(queries) {
+	case 0:
+		return nil, args, nil
+	case 1:
+	default:
+		return nil, nil, fmt.Errorf("only one of --why, --dependents-of, --dependencies-of and --between can be used")
+	}
+
+	query := queries[0]
+	query.Transitive = depsTransitive
+	query.MaxPaths = depsMaxPaths
+
+	if query.Kind == domain.QueryWhy || query.Kind == domain.QueryBetween {
+		if len(args) == 0 {
+			return nil, nil, fmt.Errorf("--%s requires two modules", strings.ReplaceAll(string(query.Kind), "_", "-"))
+		}
+		query.Target = args[0]
+		args = args[1:]
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	return query, args, nil
+}
+
+// buildTemporalCouplingOptions builds the temporal coupling options from the config
+// and flags (nil unless --temporal-coupling is set)
+func buildTemporalCouplingOptions(cmd *cobra.Command, cfg *config.Config) (*domain.TemporalCouplingOptions, error) {
+	if !depsTemporalCoupling {
+		if cmd.Flags().Changed("temporal-since") {
+			return nil, fmt.Errorf("--temporal-since requires --temporal-coupling")
+		}
+		return nil, nil
+	}
+
+	window := cfg.TemporalCoupling.Since
+	if cmd.Flags().Changed("temporal-since") {
+		window = depsTemporalSince
+	}
+	d, err := config.ParseTimeWindow(window)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --temporal-since: %w", err)
+	}
+
+	options := &domain.TemporalCouplingOptions{
+		MinSharedCommits: cfg.TemporalCoupling.MinSharedCommits,
+		MinDegree:        cfg.TemporalCoupling.MinDegree,
+		MaxCommitFiles:   cfg.TemporalCoupling.MaxCommitFiles,
+	}
+	if d > 0 {
+		options.Since = time.Now().Add(-d)
+	}
+	return options, nil
+}
+
+// buildPackageHygieneOptions builds the package hygiene options from the config (nil
+// unless --packages is set)
+func buildPackageHygieneOptions(cfg *config.Config) *domain.PackageHygieneOptions {
+	if !depsPackages {
+		return nil
+	}
+	patterns := append([]string{}, domain.DefaultDevFilePatterns...)
+	return &domain.PackageHygieneOptions{
+		DevFilePatterns: append(patterns, cfg.Packages.DevFilePatterns...),
+		IgnorePackages:  cfg.Packages.IgnorePackages,
+	}
+}
+
+// buildBarrelOptions builds the barrel options from the flags (nil unless --barrels is set)
+func buildBarrelOptions() (*domain.BarrelOptions, error) {
+	if !depsBarrels {
+		if depsBarrelSuggestions {
+			return nil, fmt.Errorf("--barrel-suggestions requires --barrels")
+		}
+		return nil, nil
+	}
+	return &domain.BarrelOptions{Suggest: depsBarrelSuggestions}, nil
+}
+
+// buildCodeSplittingOptions builds the code-splitting options from the flags (nil
+// unless --code-splitting is set)
+func buildCodeSplittingOptions() (*domain.CodeSplittingOptions, error) {
+	if !depsCodeSplitting {
+		if len(depsEntries) > 0 {
+			return nil, fmt.Errorf("--entry requires --code-splitting")
+		}
+		return nil, nil
+	}
+	return &domain.CodeSplittingOptions{Entries: depsEntries}, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffOutputFormat string
+	diffOutputPath   string
+	diffNoOpen       bool
+)
+
+func diffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "Compare two analysis reports",
+		Long: `Compare two reports written by 'jscan analyze --format json', e.g. from two
+releases, and list what was added, removed, worsened or improved along with the
+change of the health score and of every category score.
+
+Items are matched by stable identity rather than by line number:
+  - functions and classes by file and name
+  - dead code findings by file, function, reason and code
+  - clone groups by the files their clones live in
+  - circular dependencies by their modules (a cycle that grew or shrank is
+    reported as worsened or improved)
+
+Examples:
+  # Text summary
+  jscan diff reports/v1.2.json reports/v1.3.json
+
+  # Markdown for a PR comment or release notes
+  jscan diff old.json new.json --format markdown > diff.md
+
+  # HTML report
+  jscan diff old.json new.json --format html -o diff.html`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+
+	cmd.Flags().StringVarP(&diffOutputFormat, "format", "f", "text",
+		"Output format: text, json, markdown, html")
+	cmd.Flags().StringVarP(&diffOutputPath, "output", "o", "",
+		"Output file path (default: stdout, jscan-diff.html for html)")
+	cmd.Flags().BoolVar(&diffNoOpen, "no-open", false,
+		"Don't auto-open HTML report in browser")
+
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) (err error) {
+	var format domain.OutputFormat
+	switch diffOutputFormat {
+	case "text":
+		format = domain.OutputFormatText
+	case "json":
+		format = domain.OutputFormatJSON
+	case "markdown", "md":
+		format = domain.OutputFormatMarkdown
+	case "html":
+		format = domain.OutputFormatHTML
+	default:
+		return fmt.Errorf("unsupported format %q: must be text, json, markdown or html", diffOutputFormat)
+	}
+
+	svc := service.NewDiffService()
+	response, err := svc.Diff(context.Background(), domain.DiffRequest{
+		OldPath:      args[0],
+		NewPath:      args[1],
+		OutputFormat: format,
+	})
+	if err != nil {
+		return fmt.Errorf("diff failed: %w", err)
+	}
+
+	outputPath := diffOutputPath
+	if outputPath == "" && format == domain.OutputFormatHTML {
+		outputPath = "jscan-diff.html"
+	}
+
+	// Determine output writer
+	var writer *os.File
+	if outputPath != "" {
+		f, createErr := os.Create(outputPath)
+		if createErr != nil {
+			return fmt.Errorf("failed to create output file: %w", createErr)
+		}
+		defer func() {
+			if closeErr := f.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", closeErr)
+			}
+		}()
+		writer = f
+	} else {
+		writer = os.Stdout
+	}
+
+	formatter := service.NewOutputFormatter()
+	if err := formatter.WriteDiff(response, format, writer); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if outputPath != "" && format != domain.OutputFormatJSON {
+		absPath, _ := filepath.Abs(outputPath)
+		fmt.Printf("Output saved to: %s\n", absPath)
+		if format == domain.OutputFormatHTML && !diffNoOpen && !service.IsSSH() {
+			if err := service.OpenBrowser("file://" + absPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Could not open browser: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hotspotsOutputFormat string
+	hotspotsOutputPath   string
+	hotspotsConfigPath   string
+	hotspotsSince        string
+	hotspotsRecent       string
+	hotspotsTop          int
+	hotspotsNoOpen       bool
+)
+
+func hotspotsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hotspots [path...]",
+		Short: "Rank files and functions that are both complex and frequently changed",
+		Long: `Rank hotspots: code that changes often and is hard to change. The local git
+history gives the commits, authors and line churn of each file, and the changed
+line ranges of each commit give the commits that touched each function, following
+its lines back as code above it moves. Churn is combined with cyclomatic
+complexity and CBO coupling of the current code:
+
+  file score     = churn x (total complexity + max CBO)
+  function score = churn x complexity
+
+where churn counts commits in the history window, and commits in the recent
+window count twice. Scores are relative to the top hotspot (100).
+
+Windows are a number followed by d (days), w (weeks), m (months) or y (years),
+or "all". Defaults come from the hotspots section of the config file.
+
+Examples:
+  # Hotspots of the last year (default), weighting the last 30 days
+  jscan hotspots src/
+
+  # Last 6 months, weighting the last 2 weeks, top 10
+  jscan hotspots --since 6m --recent 2w --top 10
+
+  # HTML report
+  jscan hotspots --format html -o hotspots.html`,
+		RunE: runHotspots,
+	}
+
+	cmd.Flags().StringVarP(&hotspotsOutputFormat, "format", "f", "text",
+		"Output format: text, json, html")
+	cmd.Flags().StringVarP(&hotspotsOutputPath, "output", "o", "",
+		"Output file path (default: stdout, jscan-hotspots.html for html)")
+	cmd.Flags().StringVarP(&hotspotsConfigPath, "config", "c", "",
+		"Path to config file")
+	cmd.Flags().StringVar(&hotspotsSince, "since", "",
+		"History window, e.g. 90d, 6m, 1y or all (default: hotspots.since from config, 1y)")
+	cmd.Flags().StringVar(&hotspotsRecent, "recent", "",
+		"Recent window whose commits count twice, e.g. 30d, 2w or all (default: hotspots.recent from config, 30d)")
+	cmd.Flags().IntVarP(&hotspotsTop, "top", "n", domain.DefaultHotspotTop,
+		"Number of files and functions to report (0 = all)")
+	cmd.Flags().BoolVar(&hotspotsNoOpen, "no-open", false,
+		"Don't auto-open HTML report in browser")
+
+	return cmd
+}
+
+func runHotspots(cmd *cobra.Command, args []string) (err error) {
+	var format domain.OutputFormat
+	switch hotspotsOutputFormat {
+	case "text":
+		format = domain.OutputFormatText
+	case "json":
+		format = domain.OutputFormatJSON
+	case "html":
+		format = domain.OutputFormatHTML
+	default:
+		return fmt.Errorf("unsupported format %q: must be text, json or html", hotspotsOutputFormat)
+	}
+
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	cfg, err := config.LoadConfigWithTarget(hotspotsConfigPath, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Flags override the config file
+	sinceWindow, recentWindow, top := cfg.Hotspots.Since, cfg.Hotspots.Recent, cfg.Hotspots.Top
+	if cmd.Flags().Changed("since") {
+		sinceWindow = hotspotsSince
+	}
+	if cmd.Flags().Changed("recent") {
+		recentWindow = hotspotsRecent
+	}
+	if cmd.Flags().Changed("top") {
+		top = hotspotsTop
+	}
+
+	now := time.Now()
+	var since, recentSince time.Time
+	if d, err := config.ParseTimeWindow(sinceWindow); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	} else if d > 0 {
+		since = now.Add(-d)
+	}
+	if d, err := config.ParseTimeWindow(recentWindow); err != nil {
+		return fmt.Errorf("invalid --recent: %w", err)
+	} else if d > 0 {
+		recentSince = now.Add(-d)
+	}
+
+	var files []string
+	for _, path := range args {
+		pathFiles, err := collectJSFiles(path, cfg.Analysis.ExcludePatterns)
+		if err != nil {
+			return fmt.Errorf("failed to collect files from %s: %w", path, err)
+		}
+		files = append(files, pathFiles...)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no JavaScript/TypeScript files found")
+	}
+
+	svc := service.NewHotspotService(&cfg.Complexity)
+	response, err := svc.Analyze(context.Background(), domain.HotspotRequest{
+		Paths:        files,
+		Since:        since,
+		RecentSince:  recentSince,
+		Top:          top,
+		OutputFormat: format,
+	})
+	if err != nil {
+		return fmt.Errorf("hotspot analysis failed: %w", err)
+	}
+
+	outputPath := hotspotsOutputPath
+	if outputPath == "" && format == domain.OutputFormatHTML {
+		outputPath = "jscan-hotspots.html"
+	}
+
+	// Determine output writer
+	var writer *os.File
+	if outputPath != "" {
+		f, createErr := os.Create(outputPath)
+		if createErr != nil {
+			return fmt.Errorf("failed to create output file: %w", createErr)
+		}
+		defer func() {
+			if closeErr := f.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", closeErr)
+			}
+		}()
+		writer = f
+	} else {
+		writer = os.Stdout
+	}
+
+	formatter := service.NewOutputFormatter()
+	if err := formatter.WriteHotspots(response, format, writer); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if outputPath != "" && format != domain.OutputFormatJSON {
+		absPath, _ := filepath.Abs(outputPath)
+		fmt.Printf("Output saved to: %s\n", absPath)
+		if format == domain.OutputFormatHTML && !hotspotsNoOpen && !service.IsSSH() {
+			if err := service.OpenBrowser("file://" + absPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Could not open browser: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	impactOutputFormat string
+	impactOutputPath   string
+	impactConfigPath   string
+	impactRoot         string
+	impactFromGit      string
+	impactIncludeTypes bool
+)
+
+func impactCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "impact [changed-file...]",
+		Short: "Find the modules and tests affected by a change set",
+		Long: `Find the modules, test files and entry points that transitively depend on
+a set of changed files by walking the reverse edges of the dependency graph.
+
+Each affected module is classified by its strongest dependency path:
+  - runtime:   reached through static imports only
+  - dynamic:   every path goes through a dynamic import()
+  - type_only: every path goes through a type-only import
+
+Test files are *.test.*, *.spec.* and files under __tests__, plus any
+analysis.test_patterns from the config file.
+
+Examples:
+  # Impact of specific files
+  jscan impact src/utils/date.ts src/api/client.ts
+
+  # Impact of everything changed since main (including uncommitted changes)
+  jscan impact --from-git main
+
+  # JSON for CI, analyzing only the src directory
+  jscan impact --from-git origin/main --root src --format json`,
+		RunE: runImpact,
+	}
+
+	cmd.Flags().StringVarP(&impactOutputFormat, "format", "f", "text",
+		"Output format: text, json")
+	cmd.Flags().StringVarP(&impactOutputPath, "output", "o", "",
+		"Output file path (default: stdout)")
+	cmd.Flags().StringVarP(&impactConfigPath, "config", "c", "",
+		"Path to config file")
+	cmd.Flags().StringVarP(&impactRoot, "root", "r", ".",
+		"Directory whose modules are checked for dependents")
+	cmd.Flags().StringVar(&impactFromGit, "from-git", "",
+		"Use the files changed since the given git ref as the change set")
+	cmd.Flags().BoolVar(&impactIncludeTypes, "include-types", true,
+		"Follow TypeScript type-only imports")
+
+	return cmd
+}
+
+func runImpact(cmd *cobra.Command, args []string) (err error) {
+	var format domain.OutputFormat
+	switch impactOutputFormat {
+	case "text":
+		format = domain.OutputFormatText
+	case "json":
+		format = domain.OutputFormatJSON
+	default:
+		return fmt.Errorf("unsupported format %q: must be text or json", impactOutputFormat)
+	}
+
+	changedFiles := append([]string{}, args...)
+	ctx := context.Background()
+	if impactFromGit != "" {
+		gitFiles, gitErr := service.GitChangedFiles(ctx, impactRoot, impactFromGit)
+		if gitErr != nil {
+			return fmt.Errorf("failed to list changed files: %w", gitErr)
+		}
+		changedFiles = append(changedFiles, gitFiles...)
+	}
+	if len(changedFiles) == 0 {
+		if impactFromGit != "" {
+			fmt.Fprintf(os.Stderr, "No files changed since %s\n", impactFromGit)
+			return nil
+		}
+		return fmt.Errorf("no changed files specified (pass files or --from-git <ref>)")
+	}
+
+	cfg, err := config.LoadConfigWithTarget(impactConfigPath, impactRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	files, err := collectJSFiles(impactRoot, cfg.Analysis.ExcludePatterns)
+	if err != nil {
+		return fmt.Errorf("failed to collect files from %s: %w", impactRoot, err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no JavaScript/TypeScript files found")
+	}
+
+	svc := service.NewImpactService()
+	response, err := svc.Analyze(ctx, domain.ImpactRequest{
+		Paths:              files,
+		ChangedFiles:       changedFiles,
+		IncludeTypeImports: domain.BoolPtr(impactIncludeTypes),
+		TestPatterns:       cfg.Analysis.TestPatterns,
+		OutputFormat:       format,
+	})
+	if err != nil {
+		return fmt.Errorf("impact analysis failed: %w", err)
+	}
+
+	// Determine output writer
+	var writer *os.File
+	if impactOutputPath != "" {
+		f, createErr := os.Create(impactOutputPath)
+		if createErr != nil {
+			return fmt.Errorf("failed to create output file: %w", createErr)
+		}
+		defer func() {
+			if closeErr := f.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", closeErr)
+			}
+		}()
+		writer = f
+	} else {
+		writer = os.Stdout
+	}
+
+	formatter := service.NewOutputFormatter()
+	if err := formatter.WriteImpact(response, format, writer); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if impactOutputPath != "" && format != domain.OutputFormatJSON {
+		absPath, _ := filepath.Abs(impactOutputPath)
+		fmt.Printf("Output saved to: %s\n", absPath)
+	}
+
+	return nil
+}
@@ -420,6 +420,33 @@ func TestReactProjectPresetHasNextExclusion(t *testing.T) {
 	}
 }
 
+func TestReactProjectPresetHasReactThresholds(t *testing.T) {
+	presets := config.GetProjectPresets()
+	reactPreset := presets[config.ProjectTypeReact]
+	if reactPreset.React == nil {
+		t.Fatal("React preset should set the React component thresholds")
+	}
+	if presets[config.ProjectTypeNodeBackend].React != nil {
+		t.Error("Node backend preset should not set React thresholds")
+	}
+
+	defaults := config.DefaultConfig().React
+	if reactPreset.React.MaxJSXDepth >= defaults.MaxJSXDepth || reactPreset.React.MaxProps >= defaults.MaxProps {
+		t.Errorf("React preset thresholds %+v should be stricter than the defaults %+v", *reactPreset.React, defaults)
+	}
+
+	template := config.GetFullConfigTemplate(config.ProjectTypeReact, config.StrictnessStandard)
+	if !json.Valid([]byte(template)) {
+		t.Fatal("React template should be valid JSON")
+	}
+	if !strings.Contains(template, `"max_jsx_depth": 6`) {
+		t.Error("React template should hold the React thresholds")
+	}
+	if strings.Contains(config.GetFullConfigTemplate(config.ProjectTypeGeneric, config.StrictnessStandard), `"react"`) {
+		t.Error("Generic template should not have a react section")
+	}
+}
+
 func TestVueProjectPresetHasNuxtExclusion(t *testing.T) {
 	presets := config.GetProjectPresets()
 	vuePreset := presets[config.ProjectTypeVue]
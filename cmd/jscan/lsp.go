@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ludo-technologies/jscan/internal/lsp"
+	"github.com/spf13/cobra"
+)
+
+var lspConfigPath string
+
+func lspCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a Language Server Protocol server over stdio",
+		Long: `Run jscan as a language server speaking LSP over stdin/stdout, for editor
+integration. Editors start it as a subprocess; it is not meant to be run by hand.
+
+Open documents are analyzed from their in-memory content, so findings reflect
+unsaved edits. The server provides:
+
+  Diagnostics    complexity, dead code, unused imports, clones and circular
+                 dependencies, published when a document is opened or saved
+  Code lenses    cyclomatic complexity above each function
+  Hover          coupling metrics (Ca, Ce, instability, CBO) over imports and exports
+  Quick fixes    removal of unused imports
+
+Settings come from the jscan config file of the workspace root, or --config.
+
+Example (Neovim):
+  vim.lsp.start({ name = "jscan", cmd = { "jscan", "lsp" }, root_dir = vim.fn.getcwd() })`,
+		Args: cobra.NoArgs,
+		RunE: runLSP,
+	}
+
+	cmd.Flags().StringVarP(&lspConfigPath, "config", "c", "",
+		"Path to config file (default: discovered from the workspace root)")
+
+	return cmd
+}
+
+func runLSP(cmd *cobra.Command, args []string) error {
+	if lspConfigPath != "" {
+		if _, err := os.Stat(lspConfigPath); err != nil {
+			return fmt.Errorf("config file not found: %w", err)
+		}
+	}
+	server := lsp.NewServer(os.Stdin, os.Stdout, lspConfigPath)
+	if err := server.Run(context.Background()); err != nil {
+		return fmt.Errorf("language server stopped: %w", err)
+	}
+	return nil
+}
@@ -25,6 +25,13 @@ It provides complexity analysis, dead code detection, and more.`,
 	// Add subcommands
 	rootCmd.AddCommand(analyzeCmd())
 	rootCmd.AddCommand(depsCmd())
+	rootCmd.AddCommand(impactCmd())
+	rootCmd.AddCommand(trendCmd())
+	rootCmd.AddCommand(hotspotsCmd())
+	rootCmd.AddCommand(lspCmd())
+	rootCmd.AddCommand(serveCmd())
+	rootCmd.AddCommand(mcpCmd())
+	rootCmd.AddCommand(diffCmd())
 	rootCmd.AddCommand(checkCmd())
 	rootCmd.AddCommand(initCmd())
 	rootCmd.AddCommand(versionCmd())
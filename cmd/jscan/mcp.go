@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ludo-technologies/jscan/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+var mcpConfigPath string
+
+func mcpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp [path]",
+		Short: "Run a Model Context Protocol server over stdio",
+		Long: `Run jscan as an MCP server over stdin/stdout for the project at path (default:
+the current directory), so that AI assistants can call analyses as tools and get
+structured JSON back instead of scraping CLI output. Assistants start it as a
+subprocess; it is not meant to be run by hand.
+
+Tools:
+  analyze_file             Complexity, dead code, coupling and import cycles of
+                           one file, optionally of unsaved content
+  get_complexity           Function complexity in a file or directory
+  find_clones_of_function  Code duplicating a function elsewhere in the project
+  find_dead_code           Dead code in a file or directory
+  explain_cycle            Import cycles of a module and how to break them
+  get_dependents           Modules importing a module
+
+File contents and the import graph stay in memory between calls and only
+changed files are reread.
+Only files inside path are read. Settings come from the jscan config file of
+path, or --config.
+
+Example (MCP client configuration):
+  {"mcpServers": {"jscan": {"command": "jscan", "args": ["mcp"]}}}`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runMCP,
+	}
+
+	cmd.Flags().StringVarP(&mcpConfigPath, "config", "c", "",
+		"Path to config file (default: discovered from the project path)")
+
+	return cmd
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", root)
+	}
+	if mcpConfigPath != "" {
+		if _, err := os.Stat(mcpConfigPath); err != nil {
+			return fmt.Errorf("config file not found: %w", err)
+		}
+	}
+
+	server, err := mcp.NewServer(os.Stdin, os.Stdout, root, mcpConfigPath)
+	if err != nil {
+		return err
+	}
+	if err := server.Run(context.Background()); err != nil {
+		return fmt.Errorf("MCP server stopped: %w", err)
+	}
+	return nil
+}
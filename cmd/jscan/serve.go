@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ludo-technologies/jscan/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr       string
+	serveConfigPath string
+	servePoll       time.Duration
+)
+
+func serveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve [path]",
+		Short: "Run a local HTTP/JSON-RPC analysis server",
+		Long: `Run jscan as a long-running analysis server for the project at path (default:
+the current directory), so that tools such as review bots avoid paying process
+start-up costs and repeated analyses on every request.
+
+The file contents and the import graph stay in memory. Files are checked for
+changes every --poll interval and on every request; changed files are reread,
+the import graph is rebuilt and cached results of the paths containing them are
+recomputed on the next request. Only the 64 most recently used results are kept.
+Only files inside the served path are read, and requests are only accepted for a
+loopback Host (localhost, 127.0.0.1 or ::1).
+
+Endpoints:
+  GET  /v1/health          Server state
+  POST /v1/analyze         {"path": "src/", "analyses": ["complexity"]}
+                           or {"sources": [{"path": "a.ts", "content": "..."}]}
+                           returns the 'jscan analyze --json' report with a result ID
+  GET  /v1/graph           Import graph and cycles, or a query with
+                           ?kind=dependents_of|dependencies_of|why|between&source=..&target=..
+  GET  /v1/results         Cached results
+  GET  /v1/results/{id}    One cached result
+  POST /rpc                JSON-RPC 2.0 methods: analyze, graph, results, result, health
+
+Examples:
+  jscan serve                                  # Serve . on 127.0.0.1:7878
+  jscan serve --addr 127.0.0.1:9000 src/
+  curl -s -d '{"path":"src"}' localhost:7878/v1/analyze
+  curl -s 'localhost:7878/v1/graph?kind=dependents_of&source=src/api.ts'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runServe,
+	}
+
+	cmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7878",
+		"Address to listen on")
+	cmd.Flags().StringVarP(&serveConfigPath, "config", "c", "",
+		"Path to config file (default: discovered from the served path)")
+	cmd.Flags().DurationVar(&servePoll, "poll", 2*time.Second,
+		"Interval of the file change checks between requests (0 disables them)")
+
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", root)
+	}
+	if servePoll < 0 {
+		return fmt.Errorf("--poll must be >= 0")
+	}
+
+	srv, err := server.New(root, serveConfigPath)
+	if err != nil {
+		return err
+	}
+	// Parse the project before accepting requests
+	if err := srv.Refresh(); err != nil {
+		return fmt.Errorf("failed to load %s: %w", root, err)
+	}
+
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serveAddr, err)
+	}
+	if host, _, err := net.SplitHostPort(serveAddr); err == nil {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			fmt.Fprintf(os.Stderr, "Warning: %s is reachable from other hosts and has no authentication\n", serveAddr)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if servePoll > 0 {
+		go srv.Watch(ctx, servePoll)
+	}
+
+	health := srv.Health()
+	fmt.Fprintf(os.Stderr, "Serving %s (%d files) on http://%s\n", health.Root, health.Files, listener.Addr())
+
+	httpServer := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() { errCh <- httpServer.Serve(listener) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trendOutputFormat   string
+	trendOutputPath     string
+	trendConfigPath     string
+	trendHistoryPath    string
+	trendLimit          int
+	trendTopRegressions int
+	trendNoOpen         bool
+)
+
+func trendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show how code quality evolved over the recorded analysis runs",
+		Long: `Show how the health score, complexity, duplication, dead code and
+dependency cycles evolved over the analysis runs recorded in the history file,
+and highlight the runs (commits) that caused the biggest score regressions.
+
+Runs are recorded by 'jscan analyze --history', or on every analyze run when
+history.enabled is set in the config file. Each record is keyed by the git
+commit that was checked out.
+
+Examples:
+  # Record a run (e.g. in CI on every merge to main)
+  jscan analyze --history --json src/ > /dev/null
+
+  # Text report of the last 30 runs
+  jscan trend --limit 30
+
+  # HTML charts
+  jscan trend --format html -o trend.html`,
+		RunE: runTrend,
+	}
+
+	cmd.Flags().StringVarP(&trendOutputFormat, "format", "f", "text",
+		"Output format: text, json, html")
+	cmd.Flags().StringVarP(&trendOutputPath, "output", "o", "",
+		"Output file path (default: stdout, jscan-trend.html for html)")
+	cmd.Flags().StringVarP(&trendConfigPath, "config", "c", "",
+		"Path to config file")
+	cmd.Flags().StringVar(&trendHistoryPath, "history-file", "",
+		"History file (default: history.path from config, "+config.DefaultHistoryPath+")")
+	cmd.Flags().IntVarP(&trendLimit, "limit", "n", 0,
+		"Only include the most recent N runs (0 = all)")
+	cmd.Flags().IntVar(&trendTopRegressions, "top", domain.DefaultMaxTrendRegressions,
+		"Number of regressions to highlight")
+	cmd.Flags().BoolVar(&trendNoOpen, "no-open", false,
+		"Don't auto-open HTML report in browser")
+
+	return cmd
+}
+
+func runTrend(cmd *cobra.Command, args []string) (err error) {
+	var format domain.OutputFormat
+	switch trendOutputFormat {
+	case "text":
+		format = domain.OutputFormatText
+	case "json":
+		format = domain.OutputFormatJSON
+	case "html":
+		format = domain.OutputFormatHTML
+	default:
+		return fmt.Errorf("unsupported format %q: must be text, json or html", trendOutputFormat)
+	}
+
+	historyPath := trendHistoryPath
+	if historyPath == "" {
+		cfg, err := config.LoadConfigWithTarget(trendConfigPath, ".")
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		historyPath = cfg.History.Path
+	}
+
+	svc := service.NewTrendService()
+	response, err := svc.Analyze(context.Background(), domain.TrendRequest{
+		HistoryPath:    historyPath,
+		Limit:          trendLimit,
+		MaxRegressions: trendTopRegressions,
+		OutputFormat:   format,
+	})
+	if err != nil {
+		return fmt.Errorf("trend report failed: %w", err)
+	}
+
+	outputPath := trendOutputPath
+	if outputPath == "" && format == domain.OutputFormatHTML {
+		outputPath = "jscan-trend.html"
+	}
+
+	// Determine output writer
+	var writer *os.File
+	if outputPath != "" {
+		f, createErr := os.Create(outputPath)
+		if createErr != nil {
+			return fmt.Errorf("failed to create output file: %w", createErr)
+		}
+		defer func() {
+			if closeErr := f.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", closeErr)
+			}
+		}()
+		writer = f
+	} else {
+		writer = os.Stdout
+	}
+
+	formatter := service.NewOutputFormatter()
+	if err := formatter.WriteTrend(response, format, writer); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if outputPath != "" && format != domain.OutputFormatJSON {
+		absPath, _ := filepath.Abs(outputPath)
+		fmt.Printf("Output saved to: %s\n", absPath)
+		if format == domain.OutputFormatHTML && !trendNoOpen && !service.IsSSH() {
+			if err := service.OpenBrowser("file://" + absPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Could not open browser: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -40,6 +40,12 @@ const (
 	MaxArchPenalty     = 12 // Increased from 8 for stricter scoring
 	MaxMSDPenalty      = 3  // Increased from 2 for stricter scoring
 
+	// Saturation points: where a category reaches its maximum penalty
+	ComplexitySaturationRatio    = 0.05 // 5% weighted high/medium complexity functions
+	DeadCodeSaturationRate       = 3.0  // 3 weighted dead code findings per file
+	DuplicationSaturationPercent = 20.0 // 20% duplicated code
+	CouplingSaturationRatio      = 0.50 // 50% weighted high/medium coupling classes
+
 	// Score display scale - all categories normalized to this base
 	MaxScoreBase = 20
 
@@ -66,6 +72,63 @@ const (
 	FallbackPenalty             = 5
 )
 
+// ScoringConfig holds the tunable parameters of the health score. Weights are the
+// maximum penalty of each category; DefaultScoringConfig reproduces the built-in scoring.
+type ScoringConfig struct {
+	ComplexityWeight   int `json:"complexity_weight" yaml:"complexity_weight"`
+	DeadCodeWeight     int `json:"dead_code_weight" yaml:"dead_code_weight"`
+	DuplicationWeight  int `json:"duplication_weight" yaml:"duplication_weight"`
+	CouplingWeight     int `json:"coupling_weight" yaml:"coupling_weight"`
+	DependencyWeight   int `json:"dependency_weight" yaml:"dependency_weight"`
+	ArchitectureWeight int `json:"architecture_weight" yaml:"architecture_weight"`
+
+	ComplexitySaturation  float64 `json:"complexity_saturation" yaml:"complexity_saturation"`
+	DeadCodeSaturation    float64 `json:"dead_code_saturation" yaml:"dead_code_saturation"`
+	DuplicationSaturation float64 `json:"duplication_saturation" yaml:"duplication_saturation"`
+	CouplingSaturation    float64 `json:"coupling_saturation" yaml:"coupling_saturation"`
+
+	GradeAThreshold int `json:"grade_a_threshold" yaml:"grade_a_threshold"`
+	GradeBThreshold int `json:"grade_b_threshold" yaml:"grade_b_threshold"`
+	GradeCThreshold int `json:"grade_c_threshold" yaml:"grade_c_threshold"`
+	GradeDThreshold int `json:"grade_d_threshold" yaml:"grade_d_threshold"`
+}
+
+// DefaultScoringConfig returns the built-in health score parameters
+func DefaultScoringConfig() *ScoringConfig {
+	return &ScoringConfig{
+		ComplexityWeight:      MaxScoreBase,
+		DeadCodeWeight:        MaxDeadCodePenalty,
+		DuplicationWeight:     MaxScoreBase,
+		CouplingWeight:        MaxScoreBase,
+		DependencyWeight:      MaxDependencyPenalty,
+		ArchitectureWeight:    MaxArchitecturePenalty,
+		ComplexitySaturation:  ComplexitySaturationRatio,
+		DeadCodeSaturation:    DeadCodeSaturationRate,
+		DuplicationSaturation: DuplicationSaturationPercent,
+		CouplingSaturation:    CouplingSaturationRatio,
+		GradeAThreshold:       GradeAThreshold,
+		GradeBThreshold:       GradeBThreshold,
+		GradeCThreshold:       GradeCThreshold,
+		GradeDThreshold:       GradeDThreshold,
+	}
+}
+
+// Grade maps a health score to a letter grade using the configured boundaries
+func (c *ScoringConfig) Grade(score int) string {
+	switch {
+	case score >= c.GradeAThreshold:
+		return "A"
+	case score >= c.GradeBThreshold:
+		return "B"
+	case score >= c.GradeCThreshold:
+		return "C"
+	case score >= c.GradeDThreshold:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
 // AnalyzeResponse represents the combined results of all analyses
 type AnalyzeResponse struct {
 	// Analysis results
@@ -127,6 +190,22 @@ type AnalyzeSummary struct {
 	MediumCouplingClasses int     `json:"medium_coupling_classes" yaml:"medium_coupling_classes"` // 3 < CBO ≤ 7 (Medium Risk)
 	AverageCoupling       float64 `json:"average_coupling" yaml:"average_coupling"`
 
+	// TypeScript type safety; reported but not part of the health score
+	TypeSafetyEnabled     bool    `json:"type_safety_enabled" yaml:"type_safety_enabled"`
+	TypeCoverage          float64 `json:"type_coverage" yaml:"type_coverage"` // Percentage of typed parameters and annotations
+	ExplicitAnyCount      int     `json:"explicit_any_count" yaml:"explicit_any_count"`
+	AsCastCount           int     `json:"as_cast_count" yaml:"as_cast_count"`
+	NonNullAssertionCount int     `json:"non_null_assertion_count" yaml:"non_null_assertion_count"`
+	TSSuppressionCount    int     `json:"ts_suppression_count" yaml:"ts_suppression_count"`
+	UntypedParamCount     int     `json:"untyped_param_count" yaml:"untyped_param_count"`
+
+	// React components; reported but not part of the health score
+	ReactEnabled            bool `json:"react_enabled" yaml:"react_enabled"`
+	ReactComponents         int  `json:"react_components" yaml:"react_components"`
+	ReactOverThresholdCount int  `json:"react_over_threshold_count" yaml:"react_over_threshold_count"`
+	NestedComponentCount    int  `json:"nested_component_count" yaml:"nested_component_count"`
+	HookViolationCount      int  `json:"hook_violation_count" yaml:"hook_violation_count"`
+
 	// Overall health score (0-100)
 	HealthScore int    `json:"health_score" yaml:"health_score"`
 	Grade       string `json:"grade" yaml:"grade"` // A, B, C, D, F
@@ -151,6 +230,10 @@ func (s *AnalyzeSummary) Validate() error {
 		return fmt.Errorf("CodeDuplication must be 0-100: %f", s.CodeDuplication)
 	}
 
+	if s.TypeCoverage < 0 || s.TypeCoverage > 100 {
+		return fmt.Errorf("TypeCoverage must be 0-100: %f", s.TypeCoverage)
+	}
+
 	// Architecture compliance check (when enabled)
 	if s.ArchEnabled {
 		if s.ArchCompliance < 0 || s.ArchCompliance > 1 {
@@ -189,11 +272,11 @@ func (s *AnalyzeSummary) Validate() error {
 	return nil
 }
 
-// calculateComplexityPenalty calculates the penalty for complexity (max 20)
+// calculateComplexityPenalty calculates the penalty for complexity (up to maxPenalty)
 // Uses ratio of high/medium complexity functions (ESLint-aligned: high > 20, medium 10-20)
 // Weight: High = 1.0, Medium = 0.5
-// Reaches max penalty when 30% or more functions are problematic
-func (s *AnalyzeSummary) calculateComplexityPenalty() int {
+// Reaches max penalty when the weighted ratio reaches saturation (5% by default)
+func (s *AnalyzeSummary) calculateComplexityPenalty(maxPenalty int, saturation float64) int {
 	if s.TotalFunctions == 0 {
 		return 0
 	}
@@ -202,21 +285,28 @@ func (s *AnalyzeSummary) calculateComplexityPenalty() int {
 	weighted := float64(s.HighComplexityCount) + 0.5*float64(s.MediumComplexityCount)
 	ratio := weighted / float64(s.TotalFunctions)
 
-	// Linear penalty: reaches max (20) at 5% problematic ratio
-	penalty := ratio / 0.05 * 20.0
-	if penalty > 20.0 {
-		penalty = 20.0
-	}
+	// Linear penalty: reaches max at the saturation ratio
+	return linearPenalty(ratio, saturation, maxPenalty)
+}
 
+// linearPenalty maps value linearly from 0 at 0 to maxPenalty at saturation, capped at maxPenalty
+func linearPenalty(value, saturation float64, maxPenalty int) int {
+	if saturation <= 0 {
+		return 0
+	}
+	penalty := value / saturation * float64(maxPenalty)
+	if penalty > float64(maxPenalty) {
+		penalty = float64(maxPenalty)
+	}
 	return int(math.Round(penalty))
 }
 
-// calculateDeadCodePenalty calculates the penalty for dead code (max 20)
+// calculateDeadCodePenalty calculates the penalty for dead code (up to maxPenalty)
 // Uses per-file rate of weighted findings so that large repos are not unfairly penalized.
 // Weights: Critical=1.0, Warning=0.5, Info=0.2
-// The rate (weightedFindings / totalFiles) is mapped linearly to 0–20,
-// reaching the maximum penalty at a rate of 3.0 findings per file.
-func (s *AnalyzeSummary) calculateDeadCodePenalty(_ float64) int {
+// The rate (weightedFindings / totalFiles) is mapped linearly to 0–maxPenalty,
+// reaching the maximum penalty at the saturation rate (3.0 findings per file by default).
+func (s *AnalyzeSummary) calculateDeadCodePenalty(maxPenalty int, saturation float64) int {
 	weightedDeadCode := float64(s.CriticalDeadCode)*1.0 +
 		float64(s.WarningDeadCode)*0.5 +
 		float64(s.InfoDeadCode)*0.2
@@ -233,36 +323,25 @@ func (s *AnalyzeSummary) calculateDeadCodePenalty(_ float64) int {
 	// Per-file rate: how many weighted findings per file
 	rate := weightedDeadCode / float64(files)
 
-	// Linear mapping: rate 0 → penalty 0, rate 3.0 → penalty 20 (max)
-	const maxRate = 3.0
-	penalty := rate / maxRate * float64(MaxDeadCodePenalty)
-	if penalty > float64(MaxDeadCodePenalty) {
-		penalty = float64(MaxDeadCodePenalty)
-	}
-
-	return int(math.Round(penalty))
+	// Linear mapping: rate 0 → penalty 0, saturation rate → max penalty
+	return linearPenalty(rate, saturation, maxPenalty)
 }
 
-// calculateDuplicationPenalty calculates the penalty for code duplication (max 20)
+// calculateDuplicationPenalty calculates the penalty for code duplication (up to maxPenalty)
 // Uses continuous linear function starting from 1% duplication
-func (s *AnalyzeSummary) calculateDuplicationPenalty() int {
-	// Linear penalty: starts at 1%, reaches max (20) at 20%
-	// Formula: penalty = (duplication - 1) / 19 * 20
+func (s *AnalyzeSummary) calculateDuplicationPenalty(maxPenalty int, saturation float64) int {
+	// Linear penalty: starts at 1%, reaches max at the saturation percentage (20% by default)
+	// Formula: penalty = (duplication - 1) / (saturation - 1) * maxPenalty
 	if s.CodeDuplication <= 1.0 {
 		return 0
 	}
 
-	penalty := (s.CodeDuplication - 1.0) / 19.0 * 20.0
-	if penalty > 20.0 {
-		penalty = 20.0
-	}
-
-	return int(math.Round(penalty))
+	return linearPenalty(s.CodeDuplication-1.0, saturation-1.0, maxPenalty)
 }
 
-// calculateCouplingPenalty calculates the penalty for class coupling (max 20)
+// calculateCouplingPenalty calculates the penalty for class coupling (up to maxPenalty)
 // Uses continuous linear function based on weighted ratio of problematic classes
-func (s *AnalyzeSummary) calculateCouplingPenalty() int {
+func (s *AnalyzeSummary) calculateCouplingPenalty(maxPenalty int, saturation float64) int {
 	if s.CBOClasses == 0 {
 		return 0
 	}
@@ -272,14 +351,8 @@ func (s *AnalyzeSummary) calculateCouplingPenalty() int {
 	weightedProblematicClasses := float64(s.HighCouplingClasses) + (0.5 * float64(s.MediumCouplingClasses))
 	ratio := weightedProblematicClasses / float64(s.CBOClasses)
 
-	// Linear penalty: starts at 0%, reaches max (20) at 50%
-	// Formula: penalty = ratio / 0.50 * 20
-	penalty := ratio / 0.50 * 20.0
-	if penalty > 20.0 {
-		penalty = 20.0
-	}
-
-	return int(math.Round(penalty))
+	// Linear penalty: starts at 0%, reaches max at the saturation ratio (50% by default)
+	return linearPenalty(ratio, saturation, maxPenalty)
 }
 
 // calculateDependencyPenalty calculates the penalty for module dependencies (max 16: cycles=10, depth=3, MSD=3)
@@ -367,6 +440,14 @@ func normalizeToScoreBase(penalty int, maxPenalty int) int {
 	return normalized
 }
 
+// scalePenalty rescales a penalty from its built-in maximum to the configured weight
+func scalePenalty(penalty, maxPenalty, weight int) int {
+	if maxPenalty == 0 || weight == maxPenalty {
+		return penalty
+	}
+	return int(math.Round(float64(penalty) * float64(weight) / float64(maxPenalty)))
+}
+
 // penaltyToScore converts a penalty value to a 0-100 score
 func penaltyToScore(penalty int, maxPenalty int) int {
 	if maxPenalty == 0 {
@@ -384,6 +465,17 @@ func penaltyToScore(penalty int, maxPenalty int) int {
 
 // CalculateHealthScore calculates an overall health score based on analysis results
 func (s *AnalyzeSummary) CalculateHealthScore() error {
+	return s.CalculateHealthScoreWith(nil)
+}
+
+// CalculateHealthScoreWith calculates the health score with custom weights, saturation
+// points and grade boundaries (nil uses DefaultScoringConfig). Category scores are
+// independent of the weights, which only decide how much each category costs overall.
+func (s *AnalyzeSummary) CalculateHealthScoreWith(scoring *ScoringConfig) error {
+	if scoring == nil {
+		scoring = DefaultScoringConfig()
+	}
+
 	// Validate input values first
 	if err := s.Validate(); err != nil {
 		// Set default values on error
@@ -399,41 +491,32 @@ func (s *AnalyzeSummary) CalculateHealthScore() error {
 	}
 	score := 100
 
-	// Project size normalization (affects dead code penalties)
-	normalizationFactor := 1.0
-	if s.TotalFiles > 10 {
-		normalizationFactor = 1.0 + math.Log10(float64(s.TotalFiles)/10.0)
-	}
-
 	// Calculate penalties and corresponding scores
-	// Individual scores are normalized to a consistent 20-point scale for display consistency
+	// Individual scores are normalized to a consistent 20-point scale for display consistency,
+	// while the health score deducts each penalty on the scale of its category weight
 
-	complexityPenalty := s.calculateComplexityPenalty()
-	s.ComplexityScore = penaltyToScore(complexityPenalty, MaxScoreBase)
-	score -= complexityPenalty
+	s.ComplexityScore = penaltyToScore(s.calculateComplexityPenalty(MaxScoreBase, scoring.ComplexitySaturation), MaxScoreBase)
+	score -= s.calculateComplexityPenalty(scoring.ComplexityWeight, scoring.ComplexitySaturation)
 
-	deadCodePenalty := s.calculateDeadCodePenalty(normalizationFactor)
-	s.DeadCodeScore = penaltyToScore(deadCodePenalty, MaxScoreBase)
-	score -= deadCodePenalty
+	s.DeadCodeScore = penaltyToScore(s.calculateDeadCodePenalty(MaxScoreBase, scoring.DeadCodeSaturation), MaxScoreBase)
+	score -= s.calculateDeadCodePenalty(scoring.DeadCodeWeight, scoring.DeadCodeSaturation)
 
-	duplicationPenalty := s.calculateDuplicationPenalty()
-	s.DuplicationScore = penaltyToScore(duplicationPenalty, MaxScoreBase)
-	score -= duplicationPenalty
+	s.DuplicationScore = penaltyToScore(s.calculateDuplicationPenalty(MaxScoreBase, scoring.DuplicationSaturation), MaxScoreBase)
+	score -= s.calculateDuplicationPenalty(scoring.DuplicationWeight, scoring.DuplicationSaturation)
 
-	couplingPenalty := s.calculateCouplingPenalty()
-	s.CouplingScore = penaltyToScore(couplingPenalty, MaxScoreBase)
-	score -= couplingPenalty
+	s.CouplingScore = penaltyToScore(s.calculateCouplingPenalty(MaxScoreBase, scoring.CouplingSaturation), MaxScoreBase)
+	score -= s.calculateCouplingPenalty(scoring.CouplingWeight, scoring.CouplingSaturation)
 
 	// Dependencies and Architecture need normalization since their max penalties differ from MaxScoreBase
 	dependencyPenalty := s.calculateDependencyPenalty()
 	normalizedDepPenalty := normalizeToScoreBase(dependencyPenalty, MaxDependencyPenalty)
 	s.DependencyScore = penaltyToScore(normalizedDepPenalty, MaxScoreBase)
-	score -= dependencyPenalty
+	score -= scalePenalty(dependencyPenalty, MaxDependencyPenalty, scoring.DependencyWeight)
 
 	architecturePenalty := s.calculateArchitecturePenalty()
 	normalizedArchPenalty := normalizeToScoreBase(architecturePenalty, MaxArchitecturePenalty)
 	s.ArchitectureScore = penaltyToScore(normalizedArchPenalty, MaxScoreBase)
-	score -= architecturePenalty
+	score -= scalePenalty(architecturePenalty, MaxArchitecturePenalty, scoring.ArchitectureWeight)
 
 	// Minimum score floor
 	if score < MinimumScore {
@@ -442,18 +525,7 @@ func (s *AnalyzeSummary) CalculateHealthScore() error {
 	s.HealthScore = score
 
 	// Grade mapping
-	switch {
-	case score >= GradeAThreshold:
-		s.Grade = "A"
-	case score >= GradeBThreshold:
-		s.Grade = "B"
-	case score >= GradeCThreshold:
-		s.Grade = "C"
-	case score >= GradeDThreshold:
-		s.Grade = "D"
-	default:
-		s.Grade = "F"
-	}
+	s.Grade = scoring.Grade(score)
 
 	return nil
 }
@@ -487,18 +559,7 @@ func (s *AnalyzeSummary) CalculateFallbackScore() int {
 
 // GetGradeFromScore maps a health score to a letter grade
 func GetGradeFromScore(score int) string {
-	switch {
-	case score >= GradeAThreshold:
-		return "A"
-	case score >= GradeBThreshold:
-		return "B"
-	case score >= GradeCThreshold:
-		return "C"
-	case score >= GradeDThreshold:
-		return "D"
-	default:
-		return "F"
-	}
+	return DefaultScoringConfig().Grade(score)
 }
 
 // IsHealthy returns true if the codebase is considered healthy
@@ -128,3 +128,105 @@ func TestCalculateHealthScore_MSDPenalty(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateHealthScoreWith_Scoring(t *testing.T) {
+	base := AnalyzeSummary{
+		ComplexityEnabled:   true,
+		TotalFunctions:      100,
+		HighComplexityCount: 10, // Saturates the default 5% complexity ratio
+		DeadCodeEnabled:     true,
+		TotalFiles:          10,
+	}
+
+	defaults := base
+	if err := defaults.CalculateHealthScore(); err != nil {
+		t.Fatalf("CalculateHealthScore() error: %v", err)
+	}
+	if defaults.HealthScore != 80 || defaults.ComplexityScore != 0 || defaults.Grade != "B" {
+		t.Fatalf("Expected default score 80 (B) with complexity score 0, got %d (%s), %d",
+			defaults.HealthScore, defaults.Grade, defaults.ComplexityScore)
+	}
+
+	tests := []struct {
+		name           string
+		configure      func(c *ScoringConfig)
+		wantHealth     int
+		wantGrade      string
+		wantComplexity int
+	}{
+		{
+			name:           "nil uses the default scoring",
+			wantHealth:     80,
+			wantGrade:      "B",
+			wantComplexity: 0,
+		},
+		{
+			name:           "weight only changes the health score",
+			configure:      func(c *ScoringConfig) { c.ComplexityWeight = 40 },
+			wantHealth:     60,
+			wantGrade:      "C",
+			wantComplexity: 0,
+		},
+		{
+			name:           "zero weight ignores the category",
+			configure:      func(c *ScoringConfig) { c.ComplexityWeight = 0 },
+			wantHealth:     100,
+			wantGrade:      "A",
+			wantComplexity: 0,
+		},
+		{
+			name:           "higher saturation softens the penalty",
+			configure:      func(c *ScoringConfig) { c.ComplexitySaturation = 0.2 },
+			wantHealth:     90,
+			wantGrade:      "A",
+			wantComplexity: 50,
+		},
+		{
+			name:           "grade boundaries",
+			configure:      func(c *ScoringConfig) { c.GradeAThreshold, c.GradeBThreshold = 95, 85 },
+			wantHealth:     80,
+			wantGrade:      "C",
+			wantComplexity: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var scoring *ScoringConfig
+			if tt.configure != nil {
+				scoring = DefaultScoringConfig()
+				tt.configure(scoring)
+			}
+			s := base
+			if err := s.CalculateHealthScoreWith(scoring); err != nil {
+				t.Fatalf("CalculateHealthScoreWith() error: %v", err)
+			}
+			if s.HealthScore != tt.wantHealth || s.Grade != tt.wantGrade || s.ComplexityScore != tt.wantComplexity {
+				t.Errorf("Got score %d (%s), complexity %d; want %d (%s), complexity %d",
+					s.HealthScore, s.Grade, s.ComplexityScore, tt.wantHealth, tt.wantGrade, tt.wantComplexity)
+			}
+		})
+	}
+}
+
+func TestCalculateHealthScoreWith_DependencyWeight(t *testing.T) {
+	s := &AnalyzeSummary{DepsEnabled: true, DepsTotalModules: 10, DepsModulesInCycles: 10}
+	if err := s.CalculateHealthScore(); err != nil {
+		t.Fatalf("CalculateHealthScore() error: %v", err)
+	}
+	if s.HealthScore != 100-MaxCyclesPenalty {
+		t.Fatalf("Expected default score %d, got %d", 100-MaxCyclesPenalty, s.HealthScore)
+	}
+
+	// Doubling the weight doubles the cycle penalty, the category score is unchanged
+	scoring := DefaultScoringConfig()
+	scoring.DependencyWeight = 2 * MaxDependencyPenalty
+	dependencyScore := s.DependencyScore
+	if err := s.CalculateHealthScoreWith(scoring); err != nil {
+		t.Fatalf("CalculateHealthScoreWith() error: %v", err)
+	}
+	if s.HealthScore != 100-2*MaxCyclesPenalty || s.DependencyScore != dependencyScore {
+		t.Errorf("Expected score %d with dependency score %d, got %d and %d",
+			100-2*MaxCyclesPenalty, dependencyScore, s.HealthScore, s.DependencyScore)
+	}
+}
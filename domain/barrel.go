@@ -0,0 +1,110 @@
+package domain
+
+// DefaultBarrelMinReExports is the number of re-export statements from which a module
+// whose exports are mostly re-exports counts as a barrel
+const DefaultBarrelMinReExports = 3
+
+// BarrelOptions configures barrel file detection on the dependency graph
+type BarrelOptions struct {
+	// MinReExports is the number of re-export statements making a barrel (0 = default)
+	MinReExports int `json:"min_re_exports,omitempty"`
+
+	// Suggest lists direct import statements replacing the imports through barrels
+	Suggest bool `json:"suggest,omitempty"`
+}
+
+// Validate checks the barrel options
+func (o *BarrelOptions) Validate() error {
+	if o.MinReExports < 0 {
+		return NewValidationError("min_re_exports must be >= 0")
+	}
+	return nil
+}
+
+// WithDefaults returns the options with zero values replaced by the defaults
+func (o BarrelOptions) WithDefaults() BarrelOptions {
+	if o.MinReExports == 0 {
+		o.MinReExports = DefaultBarrelMinReExports
+	}
+	return o
+}
+
+// Barrel is a module that mostly re-exports other modules, such as an index.ts
+type Barrel struct {
+	Module string `json:"module"`
+
+	// ReExports is the number of re-export statements, StarExports of them export *
+	ReExports   int `json:"re_exports"`
+	StarExports int `json:"star_exports"`
+
+	// Sources are the modules re-exported
+	Sources []string `json:"sources"`
+
+	// Symbols is the number of names the barrel exposes
+	Symbols int `json:"symbols"`
+
+	// Importers is the number of modules importing the barrel
+	Importers int `json:"importers"`
+
+	// NestedBarrels are the barrels this barrel re-exports
+	NestedBarrels []string `json:"nested_barrels,omitempty"`
+}
+
+// BarrelImport is a symbol imported through a barrel with the module defining it
+type BarrelImport struct {
+	Importer string `json:"importer"`
+	Line     int    `json:"line"`
+	Barrel   string `json:"barrel"`
+
+	// Symbol is the imported name ("default" for default imports, "*" for namespace imports)
+	Symbol string `json:"symbol"`
+
+	// Source is the module the symbol is defined in; empty when it cannot be resolved,
+	// as for namespace imports
+	Source string `json:"source,omitempty"`
+}
+
+// BarrelCycle is a circular dependency that disappears when the imports through
+// barrels point at the modules defining the imported symbols
+type BarrelCycle struct {
+	Modules []string `json:"modules"`
+	Barrels []string `json:"barrels"`
+}
+
+// UnusedReExport is a symbol a barrel re-exports that no analyzed module imports
+// through it
+type UnusedReExport struct {
+	Barrel string `json:"barrel"`
+	Line   int    `json:"line"`
+	Symbol string `json:"symbol"`
+	Source string `json:"source"`
+}
+
+// BarrelSuggestion lists the direct imports replacing an import through a barrel
+type BarrelSuggestion struct {
+	Importer string   `json:"importer"`
+	Line     int      `json:"line"`
+	Barrel   string   `json:"barrel"`
+	Imports  []string `json:"imports"`
+}
+
+// BarrelResult holds the barrel files of the dependency graph and their effects
+type BarrelResult struct {
+	Options BarrelOptions `json:"options"`
+
+	// Barrels are ordered by importers, most imported first
+	Barrels []Barrel `json:"barrels"`
+
+	// Imports are the symbols imported through barrels
+	Imports []BarrelImport `json:"imports"`
+
+	// Cycles exist only because of imports through barrels
+	Cycles []BarrelCycle `json:"cycles"`
+
+	// UnusedReExports are re-exported symbols nobody imports through the barrel;
+	// barrels nothing imports are public entry points and are not reported
+	UnusedReExports []UnusedReExport `json:"unused_re_exports"`
+
+	// Suggestions are direct imports for the imports through barrels, if requested
+	Suggestions []BarrelSuggestion `json:"suggestions,omitempty"`
+}
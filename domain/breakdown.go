@@ -0,0 +1,56 @@
+package domain
+
+import "fmt"
+
+// GroupBy selects how analysis results are broken down
+type GroupBy string
+
+const (
+	// GroupByDirectory aggregates results per directory subtree
+	GroupByDirectory GroupBy = "dir"
+	// GroupByOwner aggregates results per CODEOWNERS owner
+	GroupByOwner GroupBy = "owner"
+)
+
+// UnownedGroup is the owner group of files that no CODEOWNERS rule assigns an owner
+const UnownedGroup = "(unowned)"
+
+// Validate checks that the grouping is supported
+func (g GroupBy) Validate() error {
+	switch g {
+	case GroupByDirectory, GroupByOwner:
+		return nil
+	default:
+		return fmt.Errorf("invalid group-by %q: must be one of dir, owner", g)
+	}
+}
+
+// QualityGroup holds the metrics of the files of one directory subtree or owner,
+// scored like the whole project
+type QualityGroup struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Parent is the enclosing directory group and Depth its distance from the root
+	// group; both are only set for directory breakdowns
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Depth  int    `json:"depth" yaml:"depth"`
+
+	Files int `json:"files" yaml:"files"`
+	Lines int `json:"lines" yaml:"lines"`
+
+	// Complexity distribution (functions that are neither medium nor high risk)
+	LowComplexityCount int `json:"low_complexity_count" yaml:"low_complexity_count"`
+
+	// Cycles is the number of circular dependencies with a module in the group
+	Cycles int `json:"cycles" yaml:"cycles"`
+
+	// Summary holds the group metrics and scores; the dependency depth is a
+	// property of the whole graph and is not attributed to groups
+	Summary AnalyzeSummary `json:"summary" yaml:"summary"`
+}
+
+// QualityBreakdown is the per-directory or per-owner view of an analysis
+type QualityBreakdown struct {
+	GroupBy GroupBy        `json:"group_by" yaml:"group_by"`
+	Groups  []QualityGroup `json:"groups" yaml:"groups"`
+}
@@ -10,6 +10,9 @@ type CBORequest struct {
 	// Input files or directories to analyze
 	Paths []string
 
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte
+
 	// Output configuration
 	OutputFormat OutputFormat
 	OutputWriter io.Writer
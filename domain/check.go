@@ -1,19 +1,28 @@
 package domain
 
+import (
+	"strconv"
+	"strings"
+)
+
 // CheckResult represents the result of a quality check
 type CheckResult struct {
-	Passed      bool             `json:"passed"`
-	ExitCode    int              `json:"exit_code"`
-	Violations  []CheckViolation `json:"violations"`
-	Summary     CheckSummary     `json:"summary"`
-	Duration    int64            `json:"duration_ms"`
-	GeneratedAt string           `json:"generated_at"`
-	Version     string           `json:"version"`
+	Passed     bool             `json:"passed"`
+	ExitCode   int              `json:"exit_code"`
+	Violations []CheckViolation `json:"violations"`
+	Summary    CheckSummary     `json:"summary"`
+
+	// AnalyzeSummary holds the scores of the analyses that ran, as computed by analyze
+	AnalyzeSummary *AnalyzeSummary `json:"analyze_summary,omitempty"`
+
+	Duration    int64  `json:"duration_ms"`
+	GeneratedAt string `json:"generated_at"`
+	Version     string `json:"version"`
 }
 
 // CheckViolation represents a single threshold violation
 type CheckViolation struct {
-	Category  string `json:"category"`            // complexity, deadcode, deps
+	Category  string `json:"category"`            // complexity, deadcode, clone, cbo, deps, health
 	Rule      string `json:"rule"`                // max-complexity, no-dead-code, etc.
 	Severity  string `json:"severity"`            // error, warning
 	Message   string `json:"message"`             // Human-readable description
@@ -22,6 +31,17 @@ type CheckViolation struct {
 	Threshold string `json:"threshold,omitempty"` // Configured threshold
 }
 
+// FileLine splits the location into its file and line; line is 0 if the location
+// has no line and file is empty for project-level violations
+func (v CheckViolation) FileLine() (string, int) {
+	if i := strings.LastIndex(v.Location, ":"); i > 0 {
+		if line, err := strconv.Atoi(v.Location[i+1:]); err == nil {
+			return v.Location[:i], line
+		}
+	}
+	return v.Location, 0
+}
+
 // CheckSummary provides aggregate statistics
 type CheckSummary struct {
 	FilesAnalyzed           int  `json:"files_analyzed"`
@@ -29,7 +49,17 @@ type CheckSummary struct {
 	ComplexityChecked       bool `json:"complexity_checked"`
 	DeadCodeChecked         bool `json:"deadcode_checked"`
 	DepsChecked             bool `json:"deps_checked"`
+	CloneChecked            bool `json:"clone_checked"`
+	CBOChecked              bool `json:"cbo_checked"`
+	TypeSafetyChecked       bool `json:"type_safety_checked"`
+	ReactChecked            bool `json:"react_checked"`
 	HighComplexityFunctions int  `json:"high_complexity_functions"`
 	DeadCodeFindings        int  `json:"dead_code_findings"`
 	CircularDependencies    int  `json:"circular_dependencies"`
+
+	// Health score and grade of the analyses that ran; HealthScoreChecked is set
+	// when a health score or grade gate is used
+	HealthScoreChecked bool   `json:"health_score_checked"`
+	HealthScore        int    `json:"health_score,omitempty"`
+	Grade              string `json:"grade,omitempty"`
 }
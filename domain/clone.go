@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ludo-technologies/jscan/internal/constants"
+	"github.com/ludo-technologies/jscan/internal/parser"
 )
 
 // CloneType represents different types of code clones
@@ -136,6 +137,12 @@ type CloneRequest struct {
 	IncludePatterns []string `json:"include_patterns"`
 	ExcludePatterns []string `json:"exclude_patterns"`
 
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte `json:"-"`
+
+	// ASTs holds already parsed files, keyed by path, analyzed instead of parsing them
+	ASTs map[string]*parser.Node `json:"-"`
+
 	// Analysis configuration
 	MinLines            int     `json:"min_lines"`
 	MinNodes            int     `json:"min_nodes"`
@@ -183,6 +190,14 @@ type CloneRequest struct {
 	LSHBands               int     `json:"lsh_bands"`
 	LSHRows                int     `json:"lsh_rows"`
 	LSHHashes              int     `json:"lsh_hashes"`
+
+	// Detection algorithm: "tree" (APTED), "token" (rolling hash) or "hybrid"
+	DetectionMode string `json:"detection_mode"`
+	MinTokens     int    `json:"min_tokens"` // Minimum token count for token-based clones
+
+	// Statement sequence clones (duplicated blocks inside larger functions)
+	StatementSequences bool `json:"statement_sequences"`
+	MinStatements      int  `json:"min_statements"` // Minimum consecutive statements per sequence
 }
 
 // CloneResponse represents the response from clone detection
@@ -198,6 +213,9 @@ type CloneResponse struct {
 	Duration int64         `json:"duration_ms" yaml:"duration_ms" csv:"duration_ms"`
 	Success  bool          `json:"success" yaml:"success" csv:"success"`
 	Error    string        `json:"error,omitempty" yaml:"error,omitempty" csv:"error"`
+
+	// Warnings report limits that made the detection incomplete
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty" csv:"-"`
 }
 
 // CloneSortCriteria defines how to sort clone results
@@ -298,6 +316,20 @@ func (req *CloneRequest) Validate() error {
 		return NewValidationError("type3_threshold should be > type4_threshold")
 	}
 
+	switch req.DetectionMode {
+	case "", "tree", "token", "hybrid":
+	default:
+		return NewValidationError("detection_mode must be one of tree, token, hybrid")
+	}
+
+	if req.MinTokens < 0 {
+		return NewValidationError("min_tokens must be >= 0")
+	}
+
+	if req.MinStatements < 0 {
+		return NewValidationError("min_statements must be >= 0")
+	}
+
 	return nil
 }
 
@@ -351,6 +383,12 @@ func DefaultCloneRequest() *CloneRequest {
 		LSHBands:               32,
 		LSHRows:                4,
 		LSHHashes:              128,
+		// Detection mode defaults
+		DetectionMode: "tree",
+		MinTokens:     50,
+		// Statement sequence defaults
+		StatementSequences: false,
+		MinStatements:      3,
 	}
 }
 
@@ -0,0 +1,116 @@
+package domain
+
+import "strings"
+
+// ChunkKind distinguishes the chunks loaded on startup from those loaded on demand
+type ChunkKind string
+
+const (
+	// ChunkEntry is the static closure of an entry point
+	ChunkEntry ChunkKind = "entry"
+
+	// ChunkLazy is the static closure of the target of a dynamic import()
+	ChunkLazy ChunkKind = "lazy"
+)
+
+// CodeSplittingOptions configures the code-splitting analysis of the dependency graph
+type CodeSplittingOptions struct {
+	// Entries are the modules (IDs, path suffixes or globs) the application starts
+	// from; nil uses the non-test modules nothing imports
+	Entries []string `json:"entries,omitempty"`
+}
+
+// Validate checks the code-splitting options
+func (o *CodeSplittingOptions) Validate() error {
+	for _, entry := range o.Entries {
+		if strings.TrimSpace(entry) == "" {
+			return NewValidationError("entries must not contain empty patterns")
+		}
+	}
+	return nil
+}
+
+// DynamicImportSite is a dynamic import() of a project module
+type DynamicImportSite struct {
+	Importer string `json:"importer"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// Chunk is the set of modules loaded together from an entry point or a dynamic
+// import boundary, following static imports and re-exports
+type Chunk struct {
+	// Root is the entry point or the dynamically imported module
+	Root string    `json:"root"`
+	Kind ChunkKind `json:"kind"`
+
+	// Modules is the static closure of the root, including the root
+	Modules []string `json:"modules"`
+
+	// Size is the source size of the modules in bytes, an estimate of the chunk
+	// size before minification
+	Size int64 `json:"size"`
+
+	// OwnModules and OwnSize count the modules of a lazy chunk no entry chunk loads,
+	// which is what splitting actually defers
+	OwnModules int   `json:"own_modules"`
+	OwnSize    int64 `json:"own_size"`
+
+	// ImportedBy are the dynamic imports loading a lazy chunk
+	ImportedBy []DynamicImportSite `json:"imported_by,omitempty"`
+}
+
+// MixedModule is a module reachable both through static imports from an entry point
+// and from a dynamic import boundary, so it is part of the initial bundle even though
+// some code loads it lazily
+type MixedModule struct {
+	Module string `json:"module"`
+
+	// EntryChunks and LazyChunks are the roots of the chunks containing the module
+	EntryChunks []string `json:"entry_chunks"`
+	LazyChunks  []string `json:"lazy_chunks"`
+
+	// DefeatsSplit is set when the module is itself dynamically imported: the
+	// boundary is defeated because the module is already loaded statically
+	DefeatsSplit bool `json:"defeats_split,omitempty"`
+
+	// StaticPath is a shortest chain of static imports from an entry point to the module
+	StaticPath []string `json:"static_path"`
+
+	// Size is the source size of the module in bytes
+	Size int64 `json:"size"`
+}
+
+// CodeSplittingResult holds the chunks of the dependency graph and the modules whose
+// static imports undermine the lazy loading boundaries
+type CodeSplittingResult struct {
+	Options CodeSplittingOptions `json:"options"`
+
+	// Chunks are the entry chunks followed by the lazy chunks, each ordered by size
+	Chunks []Chunk `json:"chunks"`
+
+	// Mixed are the modules reachable statically and lazily, boundaries defeated
+	// entirely first
+	Mixed []MixedModule `json:"mixed"`
+}
+
+// EntryChunks returns the number of entry chunks
+func (r *CodeSplittingResult) EntryChunks() int {
+	count := 0
+	for _, chunk := range r.Chunks {
+		if chunk.Kind == ChunkEntry {
+			count++
+		}
+	}
+	return count
+}
+
+// DefeatedSplits returns the number of dynamic import boundaries loaded statically
+func (r *CodeSplittingResult) DefeatedSplits() int {
+	count := 0
+	for _, module := range r.Mixed {
+		if module.DefeatsSplit {
+			count++
+		}
+	}
+	return count
+}
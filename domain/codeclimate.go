@@ -0,0 +1,93 @@
+package domain
+
+// CodeClimateRemediationPointsPerPenalty converts health score penalty points into
+// Code Climate remediation points (50,000 points is the conventional "trivial fix")
+const CodeClimateRemediationPointsPerPenalty = 50000
+
+// Code Climate issue categories
+const (
+	CodeClimateCategoryBugRisk     = "Bug Risk"
+	CodeClimateCategoryClarity     = "Clarity"
+	CodeClimateCategoryComplexity  = "Complexity"
+	CodeClimateCategoryDuplication = "Duplication"
+)
+
+// Code Climate issue severities
+const (
+	CodeClimateSeverityInfo     = "info"
+	CodeClimateSeverityMinor    = "minor"
+	CodeClimateSeverityMajor    = "major"
+	CodeClimateSeverityCritical = "critical"
+)
+
+// CodeClimateIssue is an issue in the Code Climate format, as consumed by the
+// GitLab Code Quality merge request widget
+type CodeClimateIssue struct {
+	Type              string                `json:"type"` // Always "issue"
+	CheckName         string                `json:"check_name"`
+	Description       string                `json:"description"`
+	Content           *CodeClimateContent   `json:"content,omitempty"`
+	Categories        []string              `json:"categories"`
+	Location          CodeClimateLocation   `json:"location"`
+	OtherLocations    []CodeClimateLocation `json:"other_locations,omitempty"`
+	Severity          string                `json:"severity"`
+	RemediationPoints int                   `json:"remediation_points"`
+
+	// Fingerprint identifies the issue across runs, independently of line numbers
+	Fingerprint string `json:"fingerprint"`
+}
+
+// CodeClimateContent holds the Markdown explanation of an issue
+type CodeClimateContent struct {
+	Body string `json:"body"`
+}
+
+// CodeClimateLocation locates an issue by path relative to the repository root
+type CodeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeClimateLines `json:"lines"`
+}
+
+// CodeClimateLines is the line range of a location
+type CodeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// ComplexityIssuePenalty returns the penalty of a function by risk level, using the
+// complexity penalties of the health score (0 for low risk functions)
+func ComplexityIssuePenalty(risk RiskLevel) int {
+	switch risk {
+	case RiskLevelHigh:
+		return ComplexityPenaltyHigh
+	case RiskLevelMedium:
+		return ComplexityPenaltyMedium
+	default:
+		return 0
+	}
+}
+
+// CouplingIssuePenalty returns the penalty of a class by risk level, using the
+// coupling penalties of the health score (0 for low risk classes)
+func CouplingIssuePenalty(risk RiskLevel) int {
+	switch risk {
+	case RiskLevelHigh:
+		return CouplingPenaltyHigh
+	case RiskLevelMedium:
+		return CouplingPenaltyMedium
+	default:
+		return 0
+	}
+}
+
+// DeadCodeIssuePenalty scales MaxCriticalPenalty by the severity level of a finding:
+// critical 10, warning 7, info 3
+func DeadCodeIssuePenalty(severity DeadCodeSeverity) int {
+	return (MaxCriticalPenalty*severity.Level() + 1) / 3
+}
+
+// CloneIssuePenalty grows from DuplicationPenaltyLow by one point per 10 duplicated
+// lines, up to DuplicationPenaltyHigh
+func CloneIssuePenalty(lines int) int {
+	return min(DuplicationPenaltyLow+lines/10, DuplicationPenaltyHigh)
+}
@@ -3,18 +3,24 @@ package domain
 import (
 	"context"
 	"io"
+
+	"github.com/ludo-technologies/jscan/internal/parser"
 )
 
 // OutputFormat represents the supported output formats
 type OutputFormat string
 
 const (
-	OutputFormatText OutputFormat = "text"
-	OutputFormatJSON OutputFormat = "json"
-	OutputFormatYAML OutputFormat = "yaml"
-	OutputFormatCSV  OutputFormat = "csv"
-	OutputFormatHTML OutputFormat = "html"
-	OutputFormatDOT  OutputFormat = "dot"
+	OutputFormatText        OutputFormat = "text"
+	OutputFormatJSON        OutputFormat = "json"
+	OutputFormatYAML        OutputFormat = "yaml"
+	OutputFormatCSV         OutputFormat = "csv"
+	OutputFormatHTML        OutputFormat = "html"
+	OutputFormatDOT         OutputFormat = "dot"
+	OutputFormatMarkdown    OutputFormat = "markdown"
+	OutputFormatJUnit       OutputFormat = "junit"
+	OutputFormatCheckstyle  OutputFormat = "checkstyle"
+	OutputFormatCodeClimate OutputFormat = "codeclimate"
 )
 
 // SortCriteria represents the criteria for sorting results
@@ -44,6 +50,12 @@ type ComplexityRequest struct {
 	// Input files or directories to analyze
 	Paths []string
 
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte
+
+	// ASTs holds already parsed files, keyed by path, analyzed instead of parsing them
+	ASTs map[string]*parser.Node
+
 	// Output configuration
 	OutputFormat OutputFormat
 	OutputWriter io.Writer
@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"io"
+
+	"github.com/ludo-technologies/jscan/internal/parser"
 )
 
 // DeadCodeSeverity represents the severity level of dead code findings
@@ -29,6 +31,12 @@ type DeadCodeRequest struct {
 	// Input files or directories to analyze
 	Paths []string
 
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte
+
+	// ASTs holds already parsed files, keyed by path, analyzed instead of parsing them
+	ASTs map[string]*parser.Node
+
 	// Output configuration
 	OutputFormat OutputFormat
 	OutputWriter io.Writer
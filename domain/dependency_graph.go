@@ -58,6 +58,9 @@ type DependencyEdge struct {
 	// Specifiers are the individual imported items
 	Specifiers []string `json:"specifiers,omitempty"`
 
+	// CanBeTypeOnly marks a value import whose symbols are only used as types
+	CanBeTypeOnly bool `json:"can_be_type_only,omitempty"`
+
 	// Location is the source code location of the import statement
 	Location *SourceLocation `json:"location,omitempty"`
 
@@ -157,6 +160,9 @@ type DependencyGraphRequest struct {
 	// Paths are the input files or directories to analyze
 	Paths []string `json:"paths"`
 
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte `json:"-"`
+
 	// OutputFormat specifies the output format
 	OutputFormat OutputFormat `json:"output_format"`
 
@@ -188,6 +194,21 @@ type DependencyGraphRequest struct {
 	InstabilityHighThreshold float64 `json:"instability_high_threshold,omitempty"`
 	InstabilityLowThreshold  float64 `json:"instability_low_threshold,omitempty"`
 	DistanceThreshold        float64 `json:"distance_threshold,omitempty"`
+
+	// Query is an optional query evaluated on the built graph
+	Query *DependencyQuery `json:"query,omitempty"`
+
+	// TemporalCoupling enables comparing the git co-change history with the graph
+	TemporalCoupling *TemporalCouplingOptions `json:"temporal_coupling,omitempty"`
+
+	// Packages enables comparing the package imports with package.json
+	Packages *PackageHygieneOptions `json:"packages,omitempty"`
+
+	// Barrels enables the barrel file analysis
+	Barrels *BarrelOptions `json:"barrels,omitempty"`
+
+	// CodeSplitting enables the chunk analysis of the entry points and dynamic imports
+	CodeSplitting *CodeSplittingOptions `json:"code_splitting,omitempty"`
 }
 
 // DefaultDependencyGraphRequest returns a DependencyGraphRequest with default values
@@ -214,6 +235,21 @@ type DependencyGraphResponse struct {
 	// Analysis is the dependency analysis result
 	Analysis *DependencyAnalysisResult `json:"analysis"`
 
+	// Query is the result of the request query, if any
+	Query *DependencyQueryResult `json:"query,omitempty"`
+
+	// TemporalCoupling is the co-change comparison, if requested
+	TemporalCoupling *TemporalCouplingResult `json:"temporal_coupling,omitempty"`
+
+	// Packages is the package.json comparison, if requested
+	Packages *PackageHygieneResult `json:"packages,omitempty"`
+
+	// Barrels is the barrel file analysis, if requested
+	Barrels *BarrelResult `json:"barrels,omitempty"`
+
+	// CodeSplitting is the chunk analysis, if requested
+	CodeSplitting *CodeSplittingResult `json:"code_splitting,omitempty"`
+
 	// Warnings contains any warnings from analysis
 	Warnings []string `json:"warnings,omitempty"`
 
@@ -0,0 +1,91 @@
+package domain
+
+import "fmt"
+
+// DependencyQueryKind is the kind of question asked about the dependency graph
+type DependencyQueryKind string
+
+const (
+	// QueryWhy finds all import paths from one module to another
+	QueryWhy DependencyQueryKind = "why"
+
+	// QueryDependentsOf finds the modules that import a module
+	QueryDependentsOf DependencyQueryKind = "dependents_of"
+
+	// QueryDependenciesOf finds the modules imported by a module
+	QueryDependenciesOf DependencyQueryKind = "dependencies_of"
+
+	// QueryBetween finds the dependencies from one set of modules to another
+	QueryBetween DependencyQueryKind = "between"
+)
+
+// DefaultMaxQueryPaths is the default limit on paths enumerated by a why query
+const DefaultMaxQueryPaths = 100
+
+// DependencyQuery describes a query evaluated on the dependency graph.
+// Source and Target are module IDs, path suffixes or glob patterns ("src/ui/**").
+type DependencyQuery struct {
+	// Kind is the query kind
+	Kind DependencyQueryKind `json:"kind"`
+
+	// Source is the module (or pattern) the query starts from
+	Source string `json:"source"`
+
+	// Target is the second operand of why and between queries
+	Target string `json:"target,omitempty"`
+
+	// Transitive follows dependencies beyond direct imports
+	Transitive bool `json:"transitive"`
+
+	// MaxPaths limits the number of paths enumerated by a why query (0 = default)
+	MaxPaths int `json:"max_paths,omitempty"`
+}
+
+// Validate checks that the query is well-formed
+func (q *DependencyQuery) Validate() error {
+	switch q.Kind {
+	case QueryWhy, QueryBetween:
+		if q.Source == "" || q.Target == "" {
+			return NewValidationError(fmt.Sprintf("%s query requires two modules", q.Kind))
+		}
+	case QueryDependentsOf, QueryDependenciesOf:
+		if q.Source == "" {
+			return NewValidationError(fmt.Sprintf("%s query requires a module", q.Kind))
+		}
+	default:
+		return NewValidationError(fmt.Sprintf("unknown dependency query kind: %q", q.Kind))
+	}
+	if q.MaxPaths < 0 {
+		return NewValidationError("max_paths must be >= 0")
+	}
+	return nil
+}
+
+// DependencyQueryResult is the subgraph answering a dependency query
+type DependencyQueryResult struct {
+	// Query is the evaluated query
+	Query DependencyQuery `json:"query"`
+
+	// Sources are the module IDs matched by the query source
+	Sources []string `json:"sources"`
+
+	// Targets are the module IDs matched by the query target
+	Targets []string `json:"targets,omitempty"`
+
+	// Modules are all module IDs in the result subgraph, including sources and targets
+	Modules []string `json:"modules"`
+
+	// Edges are the dependencies in the result subgraph
+	Edges []*DependencyEdge `json:"edges"`
+
+	// Paths are the import paths found by a why query, shortest first
+	Paths [][]string `json:"paths,omitempty"`
+
+	// Truncated indicates that path enumeration stopped at MaxPaths
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// IsEmpty reports whether the query found no dependencies
+func (r *DependencyQueryResult) IsEmpty() bool {
+	return len(r.Edges) == 0
+}
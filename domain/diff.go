@@ -0,0 +1,193 @@
+package domain
+
+// DiffCategory identifies the kind of item compared between two analysis reports
+type DiffCategory string
+
+const (
+	DiffCategoryComplexity DiffCategory = "complexity"
+	DiffCategoryDeadCode   DiffCategory = "dead_code"
+	DiffCategoryClones     DiffCategory = "clones"
+	DiffCategoryCoupling   DiffCategory = "cbo"
+	DiffCategoryCycles     DiffCategory = "cycles"
+)
+
+// DiffCategories lists the compared categories in display order
+var DiffCategories = []DiffCategory{
+	DiffCategoryComplexity,
+	DiffCategoryDeadCode,
+	DiffCategoryClones,
+	DiffCategoryCoupling,
+	DiffCategoryCycles,
+}
+
+// Label returns the human-readable category name
+func (c DiffCategory) Label() string {
+	switch c {
+	case DiffCategoryComplexity:
+		return "Complexity"
+	case DiffCategoryDeadCode:
+		return "Dead Code"
+	case DiffCategoryClones:
+		return "Clones"
+	case DiffCategoryCoupling:
+		return "Coupling"
+	case DiffCategoryCycles:
+		return "Circular Dependencies"
+	default:
+		return string(c)
+	}
+}
+
+// Score returns the category score of a summary, or 0 for categories without one
+func (c DiffCategory) Score(summary *AnalyzeSummary) int {
+	if summary == nil {
+		return 0
+	}
+	switch c {
+	case DiffCategoryComplexity:
+		return summary.ComplexityScore
+	case DiffCategoryDeadCode:
+		return summary.DeadCodeScore
+	case DiffCategoryClones:
+		return summary.DuplicationScore
+	case DiffCategoryCoupling:
+		return summary.CouplingScore
+	case DiffCategoryCycles:
+		return summary.DependencyScore
+	default:
+		return 0
+	}
+}
+
+// DiffStatus describes how an item changed between the old and the new report
+type DiffStatus string
+
+const (
+	DiffStatusAdded    DiffStatus = "added"
+	DiffStatusRemoved  DiffStatus = "removed"
+	DiffStatusWorsened DiffStatus = "worsened"
+	DiffStatusImproved DiffStatus = "improved"
+)
+
+// diffStatusOrder orders statuses in reports: regressions first
+var diffStatusOrder = map[DiffStatus]int{
+	DiffStatusAdded:    1,
+	DiffStatusWorsened: 2,
+	DiffStatusImproved: 3,
+	DiffStatusRemoved:  4,
+}
+
+// Order returns the display rank of the status
+func (s DiffStatus) Order() int {
+	return diffStatusOrder[s]
+}
+
+// IsRegression reports whether the status makes the code base worse
+func (s DiffStatus) IsRegression() bool {
+	return s == DiffStatusAdded || s == DiffStatusWorsened
+}
+
+// DiffItem is a function, finding, clone group, class or cycle that differs between two reports
+type DiffItem struct {
+	Category DiffCategory `json:"category" yaml:"category"`
+	Status   DiffStatus   `json:"status" yaml:"status"`
+
+	// Key is the stable identity the item was matched by
+	Key string `json:"key" yaml:"key"`
+
+	// Name, FilePath and Line locate the item in the new report (the old one if removed)
+	Name     string `json:"name" yaml:"name"`
+	FilePath string `json:"file_path,omitempty" yaml:"file_path,omitempty"`
+	Line     int    `json:"line,omitempty" yaml:"line,omitempty"`
+
+	// Metric is the compared value (complexity, severity level, clone count, CBO or cycle size)
+	Metric string  `json:"metric" yaml:"metric"`
+	Before float64 `json:"before" yaml:"before"` // 0 when added
+	After  float64 `json:"after" yaml:"after"`   // 0 when removed
+
+	// Detail is a human-readable description of the change
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// DiffCategorySummary counts the changes of one category
+type DiffCategorySummary struct {
+	Category    DiffCategory `json:"category" yaml:"category"`
+	Label       string       `json:"label" yaml:"label"`
+	Compared    bool         `json:"compared" yaml:"compared"` // False if a report lacks the category
+	Added       int          `json:"added" yaml:"added"`
+	Removed     int          `json:"removed" yaml:"removed"`
+	Worsened    int          `json:"worsened" yaml:"worsened"`
+	Improved    int          `json:"improved" yaml:"improved"`
+	Unchanged   int          `json:"unchanged" yaml:"unchanged"`
+	ScoreBefore int          `json:"score_before" yaml:"score_before"`
+	ScoreAfter  int          `json:"score_after" yaml:"score_after"`
+	ScoreDelta  int          `json:"score_delta" yaml:"score_delta"`
+}
+
+// Count returns the number of items with the given status
+func (s *DiffCategorySummary) Count(status DiffStatus) int {
+	switch status {
+	case DiffStatusAdded:
+		return s.Added
+	case DiffStatusRemoved:
+		return s.Removed
+	case DiffStatusWorsened:
+		return s.Worsened
+	case DiffStatusImproved:
+		return s.Improved
+	default:
+		return 0
+	}
+}
+
+// DiffReportInfo describes one of the compared reports
+type DiffReportInfo struct {
+	Path        string `json:"path" yaml:"path"`
+	Version     string `json:"version" yaml:"version"`
+	GeneratedAt string `json:"generated_at" yaml:"generated_at"`
+}
+
+// DiffSummary provides the overall result of a report comparison
+type DiffSummary struct {
+	ScoreBefore int    `json:"score_before" yaml:"score_before"`
+	ScoreAfter  int    `json:"score_after" yaml:"score_after"`
+	ScoreDelta  int    `json:"score_delta" yaml:"score_delta"`
+	GradeBefore string `json:"grade_before" yaml:"grade_before"`
+	GradeAfter  string `json:"grade_after" yaml:"grade_after"`
+	Added       int    `json:"added" yaml:"added"`
+	Removed     int    `json:"removed" yaml:"removed"`
+	Worsened    int    `json:"worsened" yaml:"worsened"`
+	Improved    int    `json:"improved" yaml:"improved"`
+}
+
+// HasRegressions reports whether the new report added or worsened any item
+func (s *DiffSummary) HasRegressions() bool {
+	return s.Added > 0 || s.Worsened > 0
+}
+
+// DiffRequest represents a request to compare two analysis reports
+type DiffRequest struct {
+	OldPath      string       `json:"old_path"`
+	NewPath      string       `json:"new_path"`
+	OutputFormat OutputFormat `json:"output_format"`
+}
+
+// Validate validates the diff request
+func (r *DiffRequest) Validate() error {
+	if r.OldPath == "" || r.NewPath == "" {
+		return NewValidationError("both an old and a new report are required")
+	}
+	return nil
+}
+
+// DiffResponse represents the comparison of two analysis reports
+type DiffResponse struct {
+	Old         DiffReportInfo        `json:"old" yaml:"old"`
+	New         DiffReportInfo        `json:"new" yaml:"new"`
+	Summary     DiffSummary           `json:"summary" yaml:"summary"`
+	Categories  []DiffCategorySummary `json:"categories" yaml:"categories"`
+	Items       []DiffItem            `json:"items" yaml:"items"` // Ordered by category, then status
+	Warnings    []string              `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	GeneratedAt string                `json:"generated_at" yaml:"generated_at"`
+	Version     string                `json:"version" yaml:"version"`
+}
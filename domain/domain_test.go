@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 // Error tests
@@ -163,6 +164,11 @@ func TestOutputFormat_Constants(t *testing.T) {
 		OutputFormatCSV:  "csv",
 		OutputFormatHTML: "html",
 		OutputFormatDOT:  "dot",
+
+		OutputFormatMarkdown:    "markdown",
+		OutputFormatJUnit:       "junit",
+		OutputFormatCheckstyle:  "checkstyle",
+		OutputFormatCodeClimate: "codeclimate",
 	}
 
 	for format, expected := range formats {
@@ -172,6 +178,44 @@ func TestOutputFormat_Constants(t *testing.T) {
 	}
 }
 
+func TestCheckViolation_FileLine(t *testing.T) {
+	tests := []struct {
+		location string
+		file     string
+		line     int
+	}{
+		{"src/a.ts:12", "src/a.ts", 12},
+		{"src/a.ts", "src/a.ts", 0},
+		{"C:\\src\\a.ts:3", "C:\\src\\a.ts", 3},
+		{"", "", 0},
+	}
+
+	for _, tc := range tests {
+		file, line := CheckViolation{Location: tc.location}.FileLine()
+		if file != tc.file || line != tc.line {
+			t.Errorf("FileLine() of %q = %q, %d; want %q, %d", tc.location, file, line, tc.file, tc.line)
+		}
+	}
+}
+
+func TestCodeClimateIssuePenalties(t *testing.T) {
+	if p := DeadCodeIssuePenalty(DeadCodeSeverityCritical); p != MaxCriticalPenalty {
+		t.Errorf("Expected critical dead code penalty %d, got %d", MaxCriticalPenalty, p)
+	}
+	if p := DeadCodeIssuePenalty(DeadCodeSeverityInfo); p <= 0 || p >= DeadCodeIssuePenalty(DeadCodeSeverityWarning) {
+		t.Errorf("Expected info penalty below warning penalty, got %d", p)
+	}
+	if p := CloneIssuePenalty(5); p != DuplicationPenaltyLow {
+		t.Errorf("Expected short clone penalty %d, got %d", DuplicationPenaltyLow, p)
+	}
+	if p := CloneIssuePenalty(1000); p != DuplicationPenaltyHigh {
+		t.Errorf("Expected clone penalty capped at %d, got %d", DuplicationPenaltyHigh, p)
+	}
+	if ComplexityIssuePenalty(RiskLevelLow) != 0 || CouplingIssuePenalty(RiskLevelHigh) != CouplingPenaltyHigh {
+		t.Error("Unexpected risk level penalties")
+	}
+}
+
 // Sort criteria tests
 
 func TestSortCriteria_Constants(t *testing.T) {
@@ -505,3 +549,54 @@ func TestErrorCodeConstants(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupByValidate(t *testing.T) {
+	for _, valid := range []GroupBy{GroupByDirectory, GroupByOwner} {
+		if err := valid.Validate(); err != nil {
+			t.Errorf("Expected %s to be valid: %v", valid, err)
+		}
+	}
+	if err := GroupBy("team").Validate(); err == nil {
+		t.Error("Expected an error for an unknown grouping")
+	}
+}
+
+func TestHotspotRequestValidate(t *testing.T) {
+	now := time.Now()
+	valid := HotspotRequest{Paths: []string{"a.js"}, Since: now.AddDate(-1, 0, 0), RecentSince: now.AddDate(0, -1, 0)}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected a valid request: %v", err)
+	}
+
+	for name, req := range map[string]HotspotRequest{
+		"no paths":          {},
+		"negative top":      {Paths: []string{"a.js"}, Top: -1},
+		"recent before all": {Paths: []string{"a.js"}, Since: now.AddDate(0, -1, 0), RecentSince: now.AddDate(-1, 0, 0)},
+	} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	if HotspotRiskLevel(80) != RiskLevelHigh || HotspotRiskLevel(30) != RiskLevelMedium || HotspotRiskLevel(5) != RiskLevelLow {
+		t.Error("Unexpected hotspot risk levels")
+	}
+}
+
+func TestTemporalCouplingOptions(t *testing.T) {
+	options := TemporalCouplingOptions{MinDegree: 0.7}.WithDefaults()
+	if options.MinSharedCommits != DefaultTemporalMinSharedCommits || options.MinDegree != 0.7 ||
+		options.MaxCommitFiles != DefaultTemporalMaxCommitFiles {
+		t.Errorf("Unexpected options with defaults %+v", options)
+	}
+
+	for _, invalid := range []TemporalCouplingOptions{
+		{MinSharedCommits: -1},
+		{MinDegree: 1.2},
+		{MaxCommitFiles: -5},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"time"
+)
+
+// DefaultMaxTrendRegressions is the default number of regressions reported by a trend
+const DefaultMaxTrendRegressions = 5
+
+// HistoryEntry is one analysis run recorded in the history store
+type HistoryEntry struct {
+	Timestamp time.Time      `json:"timestamp" yaml:"timestamp"`
+	Commit    string         `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Branch    string         `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Subject   string         `json:"subject,omitempty" yaml:"subject,omitempty"` // Commit subject line
+	Dirty     bool           `json:"dirty,omitempty" yaml:"dirty,omitempty"`     // Uncommitted changes were analyzed
+	Version   string         `json:"version" yaml:"version"`
+	Summary   AnalyzeSummary `json:"summary" yaml:"summary"`
+}
+
+// ShortCommit returns the abbreviated commit hash, or an empty string outside git
+func (e HistoryEntry) ShortCommit() string {
+	if len(e.Commit) > 8 {
+		return e.Commit[:8]
+	}
+	return e.Commit
+}
+
+// Label returns a short identifier for the run: its commit, or its time outside git
+func (e HistoryEntry) Label() string {
+	label := e.ShortCommit()
+	if label == "" {
+		return e.Timestamp.Format("2006-01-02 15:04")
+	}
+	if e.Dirty {
+		label += "+"
+	}
+	return label
+}
+
+// TrendMetric identifies a summary metric tracked over time
+type TrendMetric string
+
+const (
+	TrendMetricHealthScore     TrendMetric = "health_score"
+	TrendMetricComplexity      TrendMetric = "average_complexity"
+	TrendMetricHighComplexity  TrendMetric = "high_complexity"
+	TrendMetricDuplication     TrendMetric = "duplication"
+	TrendMetricDeadCode        TrendMetric = "dead_code"
+	TrendMetricModulesInCycles TrendMetric = "modules_in_cycles"
+)
+
+// TrendMetrics lists the tracked metrics in display order
+var TrendMetrics = []TrendMetric{
+	TrendMetricHealthScore,
+	TrendMetricComplexity,
+	TrendMetricHighComplexity,
+	TrendMetricDuplication,
+	TrendMetricDeadCode,
+	TrendMetricModulesInCycles,
+}
+
+// Label returns the human-readable name of the metric
+func (m TrendMetric) Label() string {
+	switch m {
+	case TrendMetricHealthScore:
+		return "Health score"
+	case TrendMetricComplexity:
+		return "Average complexity"
+	case TrendMetricHighComplexity:
+		return "High complexity functions"
+	case TrendMetricDuplication:
+		return "Duplication %"
+	case TrendMetricDeadCode:
+		return "Dead code findings"
+	case TrendMetricModulesInCycles:
+		return "Modules in cycles"
+	default:
+		return string(m)
+	}
+}
+
+// HigherIsBetter reports whether an increase of the metric is an improvement
+func (m TrendMetric) HigherIsBetter() bool {
+	return m == TrendMetricHealthScore
+}
+
+// Value extracts the metric from an analysis summary
+func (m TrendMetric) Value(s *AnalyzeSummary) float64 {
+	switch m {
+	case TrendMetricHealthScore:
+		return float64(s.HealthScore)
+	case TrendMetricComplexity:
+		return s.AverageComplexity
+	case TrendMetricHighComplexity:
+		return float64(s.HighComplexityCount)
+	case TrendMetricDuplication:
+		return s.CodeDuplication
+	case TrendMetricDeadCode:
+		return float64(s.DeadCodeCount)
+	case TrendMetricModulesInCycles:
+		return float64(s.DepsModulesInCycles)
+	default:
+		return 0
+	}
+}
+
+// TrendPoint is the value of a metric for one recorded run
+type TrendPoint struct {
+	Label     string    `json:"label" yaml:"label"`
+	Commit    string    `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Value     float64   `json:"value" yaml:"value"`
+}
+
+// TrendSeries is the evolution of one metric over the recorded runs
+type TrendSeries struct {
+	Metric         TrendMetric  `json:"metric" yaml:"metric"`
+	Label          string       `json:"label" yaml:"label"`
+	HigherIsBetter bool         `json:"higher_is_better" yaml:"higher_is_better"`
+	Points         []TrendPoint `json:"points" yaml:"points"`
+	First          float64      `json:"first" yaml:"first"`
+	Last           float64      `json:"last" yaml:"last"`
+	Min            float64      `json:"min" yaml:"min"`
+	Max            float64      `json:"max" yaml:"max"`
+	Change         float64      `json:"change" yaml:"change"` // Last - First
+}
+
+// Improved reports whether the metric got better between the first and last run
+func (s *TrendSeries) Improved() bool {
+	if s.HigherIsBetter {
+		return s.Change > 0
+	}
+	return s.Change < 0
+}
+
+// TrendChange is the change of one metric between two consecutive runs
+type TrendChange struct {
+	Metric TrendMetric `json:"metric" yaml:"metric"`
+	Before float64     `json:"before" yaml:"before"`
+	After  float64     `json:"after" yaml:"after"`
+	Delta  float64     `json:"delta" yaml:"delta"`
+}
+
+// TrendRegression is a run whose health score dropped compared to the previous run
+type TrendRegression struct {
+	Label          string        `json:"label" yaml:"label"`
+	Commit         string        `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Subject        string        `json:"subject,omitempty" yaml:"subject,omitempty"`
+	PreviousLabel  string        `json:"previous_label" yaml:"previous_label"`
+	PreviousCommit string        `json:"previous_commit,omitempty" yaml:"previous_commit,omitempty"`
+	Timestamp      time.Time     `json:"timestamp" yaml:"timestamp"`
+	ScoreBefore    int           `json:"score_before" yaml:"score_before"`
+	ScoreAfter     int           `json:"score_after" yaml:"score_after"`
+	ScoreDelta     int           `json:"score_delta" yaml:"score_delta"`
+	Changes        []TrendChange `json:"changes" yaml:"changes"` // Metrics that got worse
+}
+
+// TrendRequest represents a request for a quality trend report
+type TrendRequest struct {
+	// HistoryPath is the history file written by analyze
+	HistoryPath string
+
+	// Limit restricts the report to the most recent runs (0 = all)
+	Limit int
+
+	// MaxRegressions is the number of regressions to report (0 = default)
+	MaxRegressions int
+
+	OutputFormat OutputFormat
+}
+
+// Validate validates the trend request
+func (r *TrendRequest) Validate() error {
+	if r.HistoryPath == "" {
+		return NewValidationError("history path cannot be empty")
+	}
+	if r.Limit < 0 {
+		return NewValidationError("limit cannot be negative")
+	}
+	if r.MaxRegressions < 0 {
+		return NewValidationError("max regressions cannot be negative")
+	}
+	return nil
+}
+
+// TrendSummary provides aggregate statistics of a trend report
+type TrendSummary struct {
+	Runs        int       `json:"runs" yaml:"runs"`
+	From        time.Time `json:"from" yaml:"from"`
+	To          time.Time `json:"to" yaml:"to"`
+	FirstScore  int       `json:"first_score" yaml:"first_score"`
+	LastScore   int       `json:"last_score" yaml:"last_score"`
+	ScoreChange int       `json:"score_change" yaml:"score_change"`
+	LastGrade   string    `json:"last_grade" yaml:"last_grade"`
+	Regressions int       `json:"regressions" yaml:"regressions"` // Runs with a lower score than the previous run
+}
+
+// TrendResponse represents a quality trend report over the recorded runs
+type TrendResponse struct {
+	HistoryPath string            `json:"history_path" yaml:"history_path"`
+	Entries     []HistoryEntry    `json:"entries" yaml:"entries"`
+	Series      []TrendSeries     `json:"series" yaml:"series"`
+	Regressions []TrendRegression `json:"regressions" yaml:"regressions"` // Biggest score drops first
+	Summary     TrendSummary      `json:"summary" yaml:"summary"`
+	GeneratedAt string            `json:"generated_at" yaml:"generated_at"`
+	Version     string            `json:"version" yaml:"version"`
+}
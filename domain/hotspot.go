@@ -0,0 +1,163 @@
+package domain
+
+import "time"
+
+// Hotspot ranking constants
+const (
+	// DefaultHotspotTop is the default number of files and functions in a hotspot report
+	DefaultHotspotTop = 20
+
+	// HotspotRecentWeight is how much more a change inside the recent window counts
+	HotspotRecentWeight = 2
+
+	// Hotspot scores are relative to the top hotspot (100)
+	HotspotHighScore   = 50.0
+	HotspotMediumScore = 20.0
+)
+
+// GitFileChange is a file changed by a commit, with the line counts of git --numstat
+type GitFileChange struct {
+	Path    string `json:"path"` // Absolute path
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+
+	// Hunks are the changed line ranges, read only when requested
+	Hunks []GitHunk `json:"hunks,omitempty"`
+}
+
+// GitHunk is a changed line range of a file: OldLines lines from OldStart were
+// replaced by NewLines lines from NewStart. An empty side starts at the line the
+// change follows, as in a unified diff.
+type GitHunk struct {
+	OldStart int `json:"old_start"`
+	OldLines int `json:"old_lines"`
+	NewStart int `json:"new_start"`
+	NewLines int `json:"new_lines"`
+}
+
+// GitCommit is a commit read from the local git history
+type GitCommit struct {
+	Hash   string          `json:"hash"`
+	Author string          `json:"author"`
+	Email  string          `json:"email"`
+	Time   time.Time       `json:"time"`
+	Files  []GitFileChange `json:"files"`
+}
+
+// AuthorKey identifies the commit author; the email is preferred as names vary
+func (c GitCommit) AuthorKey() string {
+	if c.Email != "" {
+		return c.Email
+	}
+	return c.Author
+}
+
+// HotspotRiskLevel maps a relative hotspot score to a risk level
+func HotspotRiskLevel(score float64) RiskLevel {
+	switch {
+	case score >= HotspotHighScore:
+		return RiskLevelHigh
+	case score >= HotspotMediumScore:
+		return RiskLevelMedium
+	default:
+		return RiskLevelLow
+	}
+}
+
+// FileHotspot is a file ranked by how often it changes and how complex it is
+type FileHotspot struct {
+	FilePath string `json:"file_path"`
+
+	// Churn inside the history window
+	Commits       int       `json:"commits"`
+	RecentCommits int       `json:"recent_commits"`
+	Authors       int       `json:"authors"`
+	MainAuthor    string    `json:"main_author,omitempty"` // Author of the most commits
+	LinesAdded    int       `json:"lines_added"`
+	LinesDeleted  int       `json:"lines_deleted"`
+	LastChanged   time.Time `json:"last_changed"`
+
+	// Complexity and coupling of the current code
+	Functions       int `json:"functions"`
+	TotalComplexity int `json:"total_complexity"`
+	MaxComplexity   int `json:"max_complexity"`
+	MaxCBO          int `json:"max_cbo"`
+
+	// Score is (commits + recent commits) x (total complexity + max CBO), relative to the top file (0-100)
+	Score     float64   `json:"score"`
+	RiskLevel RiskLevel `json:"risk_level"`
+}
+
+// FunctionHotspot is a function ranked by how often its lines change and how complex it is.
+// Its churn counts the commits whose changes touch its line range, followed back
+// through the history.
+type FunctionHotspot struct {
+	Name      string `json:"name"`
+	FilePath  string `json:"file_path"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+
+	Complexity    int       `json:"complexity"`
+	Commits       int       `json:"commits"`
+	RecentCommits int       `json:"recent_commits"`
+	Authors       int       `json:"authors"`
+	LastChanged   time.Time `json:"last_changed"`
+
+	// Score is (commits + recent commits) x complexity, relative to the top function (0-100)
+	Score     float64   `json:"score"`
+	RiskLevel RiskLevel `json:"risk_level"`
+}
+
+// HotspotRequest represents a request for a hotspot analysis
+type HotspotRequest struct {
+	// Paths are the files to rank; they must be inside a git repository
+	Paths []string
+
+	// Since limits the history to commits after this time (zero = whole history)
+	Since time.Time
+
+	// RecentSince starts the recent window, whose commits count twice (zero = no recent window)
+	RecentSince time.Time
+
+	// Top is the number of files and functions to report (0 = all)
+	Top int
+
+	OutputFormat OutputFormat
+}
+
+// Validate validates the hotspot request
+func (r *HotspotRequest) Validate() error {
+	if len(r.Paths) == 0 {
+		return NewValidationError("no files to analyze")
+	}
+	if r.Top < 0 {
+		return NewValidationError("top cannot be negative")
+	}
+	if !r.Since.IsZero() && !r.RecentSince.IsZero() && r.RecentSince.Before(r.Since) {
+		return NewValidationError("the recent window cannot be longer than the history window")
+	}
+	return nil
+}
+
+// HotspotSummary provides aggregate statistics of a hotspot analysis
+type HotspotSummary struct {
+	Repository        string     `json:"repository"`
+	Since             *time.Time `json:"since,omitempty"`        // Start of the history window, if limited
+	RecentSince       *time.Time `json:"recent_since,omitempty"` // Start of the recent window, if any
+	FilesAnalyzed     int        `json:"files_analyzed"`
+	FilesChanged      int        `json:"files_changed"` // Analyzed files with commits in the window
+	Commits           int        `json:"commits"`
+	Authors           int        `json:"authors"`
+	HighRiskFiles     int        `json:"high_risk_files"`
+	HighRiskFunctions int        `json:"high_risk_functions"`
+}
+
+// HotspotResponse represents the hotspots of a repository, highest score first
+type HotspotResponse struct {
+	Files       []FileHotspot     `json:"files"`
+	Functions   []FunctionHotspot `json:"functions"`
+	Summary     HotspotSummary    `json:"summary"`
+	Warnings    []string          `json:"warnings,omitempty"`
+	GeneratedAt string            `json:"generated_at"`
+	Version     string            `json:"version"`
+}
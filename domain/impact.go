@@ -0,0 +1,127 @@
+package domain
+
+// ImpactKind classifies how a module depends on a changed module
+type ImpactKind string
+
+const (
+	// ImpactRuntime means the module loads a changed module through static imports only
+	ImpactRuntime ImpactKind = "runtime"
+
+	// ImpactDynamic means every path to a changed module goes through a dynamic import()
+	ImpactDynamic ImpactKind = "dynamic"
+
+	// ImpactTypeOnly means every path to a changed module goes through a type-only import,
+	// so the change can only affect type checking
+	ImpactTypeOnly ImpactKind = "type_only"
+)
+
+// impactKindStrength orders impact kinds from weakest to strongest
+var impactKindStrength = map[ImpactKind]int{
+	ImpactTypeOnly: 1,
+	ImpactDynamic:  2,
+	ImpactRuntime:  3,
+}
+
+// Strength returns the rank of the impact kind (higher is stronger, 0 for unknown)
+func (k ImpactKind) Strength() int {
+	return impactKindStrength[k]
+}
+
+// ImpactKindForEdge returns the impact kind carried by a single dependency edge
+func ImpactKindForEdge(edgeType DependencyEdgeType) ImpactKind {
+	switch edgeType {
+	case EdgeTypeTypeOnly:
+		return ImpactTypeOnly
+	case EdgeTypeDynamic:
+		return ImpactDynamic
+	default:
+		return ImpactRuntime
+	}
+}
+
+// ImpactedModule is a module that transitively depends on a changed module
+type ImpactedModule struct {
+	// ID is the module ID
+	ID string `json:"id"`
+
+	// FilePath is the module file path
+	FilePath string `json:"file_path"`
+
+	// Kind is the strongest kind of dependency on the change set
+	Kind ImpactKind `json:"kind"`
+
+	// Distance is the number of imports to the nearest changed module reached with
+	// the strongest kind
+	Distance int `json:"distance"`
+
+	// Via is the next module on that path to the changed module
+	Via string `json:"via"`
+
+	// IsTest indicates that the module is a test file
+	IsTest bool `json:"is_test"`
+
+	// IsEntryPoint indicates that no other module depends on this module
+	IsEntryPoint bool `json:"is_entry_point"`
+}
+
+// ImpactRequest represents a request for change impact analysis
+type ImpactRequest struct {
+	// Paths are the files making up the dependency graph
+	Paths []string `json:"paths"`
+
+	// ChangedFiles are the files of the change set
+	ChangedFiles []string `json:"changed_files"`
+
+	// IncludeTypeImports indicates whether type-only imports propagate impact
+	IncludeTypeImports *bool `json:"include_type_imports,omitempty"`
+
+	// TestPatterns are additional glob patterns identifying test files
+	TestPatterns []string `json:"test_patterns,omitempty"`
+
+	// OutputFormat specifies the output format
+	OutputFormat OutputFormat `json:"output_format"`
+}
+
+// ImpactSummary summarizes the impact of a change set
+type ImpactSummary struct {
+	ChangedModules  int `json:"changed_modules"`
+	AffectedModules int `json:"affected_modules"`
+	RuntimeModules  int `json:"runtime_modules"`
+	DynamicModules  int `json:"dynamic_modules"`
+	TypeOnlyModules int `json:"type_only_modules"`
+	AffectedTests   int `json:"affected_tests"`
+	EntryPoints     int `json:"entry_points"`
+}
+
+// ImpactResponse represents the result of change impact analysis
+type ImpactResponse struct {
+	// ChangedModules are the module IDs of the change set found in the graph
+	ChangedModules []string `json:"changed_modules"`
+
+	// UnmatchedFiles are changed files that are not part of the dependency graph
+	UnmatchedFiles []string `json:"unmatched_files,omitempty"`
+
+	// AffectedModules are all modules depending on the change set, nearest first
+	AffectedModules []ImpactedModule `json:"affected_modules"`
+
+	// AffectedTests are the affected modules that are test files
+	AffectedTests []ImpactedModule `json:"affected_tests"`
+
+	// EntryPoints are the affected modules that no other module depends on
+	EntryPoints []ImpactedModule `json:"entry_points"`
+
+	// Summary holds aggregate counts
+	Summary ImpactSummary `json:"summary"`
+
+	// Warnings contains any warnings from analysis
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Errors contains any errors encountered during analysis
+	Errors []string `json:"errors,omitempty"`
+
+	// GeneratedAt is when the analysis was generated
+	GeneratedAt string `json:"generated_at"`
+
+	// Version is the tool version
+	Version string `json:"version"`
+}
@@ -63,6 +63,10 @@ type Import struct {
 	// IsTypeOnly indicates TypeScript type-only imports
 	IsTypeOnly bool `json:"is_type_only,omitempty"`
 
+	// CanBeTypeOnly indicates a value import whose bindings are only referenced in
+	// type positions, so it could be written as `import type`
+	CanBeTypeOnly bool `json:"can_be_type_only,omitempty"`
+
 	// IsDynamic indicates dynamic import() expressions
 	IsDynamic bool `json:"is_dynamic,omitempty"`
 
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultDevFilePatterns match the config, tooling and test files that may import
+// devDependencies and whose imports do not count as production usage
+var DefaultDevFilePatterns = []string{
+	"**/*.config.*",
+	"**/.*rc.*",
+	"**/*.test.*",
+	"**/*.spec.*",
+	"**/*.stories.*",
+	"**/__tests__/**",
+	"**/__mocks__/**",
+	"**/test/**",
+	"**/tests/**",
+	"**/e2e/**",
+	"**/scripts/**",
+}
+
+// Dependency sections of a package.json
+const (
+	PackageSectionDependencies         = "dependencies"
+	PackageSectionDevDependencies      = "devDependencies"
+	PackageSectionPeerDependencies     = "peerDependencies"
+	PackageSectionOptionalDependencies = "optionalDependencies"
+)
+
+// PackageManifest is the part of a package.json the package hygiene analysis reads
+type PackageManifest struct {
+	// Path is the path of the package.json file
+	Path string `json:"path"`
+
+	Name                 string            `json:"name,omitempty"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	PeerDependencies     map[string]string `json:"peerDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+	Scripts              map[string]string `json:"scripts,omitempty"`
+
+	// Workspaces are the workspace globs of a monorepo root
+	Workspaces []string `json:"workspaces,omitempty"`
+}
+
+// Dir returns the directory of the package
+func (m *PackageManifest) Dir() string {
+	return filepath.Dir(m.Path)
+}
+
+// PackageSections are the dependency sections in the order they are looked up
+var PackageSections = []string{
+	PackageSectionDependencies,
+	PackageSectionPeerDependencies,
+	PackageSectionOptionalDependencies,
+	PackageSectionDevDependencies,
+}
+
+// Section returns the versions declared in a dependency section
+func (m *PackageManifest) Section(name string) map[string]string {
+	switch name {
+	case PackageSectionDependencies:
+		return m.Dependencies
+	case PackageSectionDevDependencies:
+		return m.DevDependencies
+	case PackageSectionPeerDependencies:
+		return m.PeerDependencies
+	case PackageSectionOptionalDependencies:
+		return m.OptionalDependencies
+	}
+	return nil
+}
+
+// PackageHygieneOptions configures the comparison of package imports with package.json
+type PackageHygieneOptions struct {
+	// DevFilePatterns match files allowed to import devDependencies (nil = defaults)
+	DevFilePatterns []string `json:"dev_file_patterns,omitempty"`
+
+	// IgnorePackages are never reported, e.g. packages loaded by a framework or
+	// import specifiers resolved by a bundler alias
+	IgnorePackages []string `json:"ignore_packages,omitempty"`
+}
+
+// Validate checks the package hygiene options
+func (o *PackageHygieneOptions) Validate() error {
+	for _, pattern := range o.DevFilePatterns {
+		if strings.TrimSpace(pattern) == "" {
+			return NewValidationError("dev_file_patterns must not contain empty patterns")
+		}
+		if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+			return NewValidationError(fmt.Sprintf("invalid dev file pattern %q", pattern))
+		}
+	}
+	return nil
+}
+
+// WithDefaults returns the options with nil values replaced by the defaults
+func (o PackageHygieneOptions) WithDefaults() PackageHygieneOptions {
+	if o.DevFilePatterns == nil {
+		o.DevFilePatterns = DefaultDevFilePatterns
+	}
+	return o
+}
+
+// PackageImportSite is an import of a package in a source file
+type PackageImportSite struct {
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line"`
+
+	// Source is the import specifier, e.g. "lodash/fp"
+	Source string `json:"source"`
+}
+
+// PackageFinding is a package whose declaration in a package.json disagrees with its imports
+type PackageFinding struct {
+	Package string `json:"package"`
+
+	// Manifest is the package.json the package is (or should be) declared in
+	Manifest string `json:"manifest"`
+
+	// Version is the declared version range, if declared
+	Version string `json:"version,omitempty"`
+
+	// Sites are the offending imports; empty for unused dependencies
+	Sites []PackageImportSite `json:"sites,omitempty"`
+}
+
+// PackageDeclaration is the declaration of a package in a workspace package.json
+type PackageDeclaration struct {
+	Manifest string `json:"manifest"`
+	Section  string `json:"section"`
+	Version  string `json:"version"`
+}
+
+// DuplicatePackage is a package declared with different version ranges across the
+// workspaces of a monorepo, which installs several copies of it
+type DuplicatePackage struct {
+	Package      string               `json:"package"`
+	Declarations []PackageDeclaration `json:"declarations"`
+}
+
+// PackageHygieneResult compares the package imports of the source files with the
+// dependencies declared in their package.json files
+type PackageHygieneResult struct {
+	Options PackageHygieneOptions `json:"options"`
+
+	// Manifests are the package.json files of the analyzed files and workspaces
+	Manifests []string `json:"manifests"`
+
+	// Unused are packages in dependencies that no file of the package imports
+	Unused []PackageFinding `json:"unused"`
+
+	// Undeclared are imported packages missing from every dependency section
+	Undeclared []PackageFinding `json:"undeclared"`
+
+	// DevOnly are devDependencies imported from production code
+	DevOnly []PackageFinding `json:"dev_only"`
+
+	// DeepImports reach into the build output or internals of a package
+	DeepImports []PackageFinding `json:"deep_imports"`
+
+	// Duplicates are packages declared with different versions across workspaces
+	Duplicates []DuplicatePackage `json:"duplicates"`
+}
+
+// TotalIssues returns the number of reported packages
+func (r *PackageHygieneResult) TotalIssues() int {
+	return len(r.Unused) + len(r.Undeclared) + len(r.DevOnly) + len(r.DeepImports) + len(r.Duplicates)
+}
@@ -0,0 +1,101 @@
+package domain
+
+// Rules of hooks broken by a HookViolation
+const (
+	HookInCondition = "condition"    // inside an if, switch, ternary or logical expression
+	HookInLoop      = "loop"         // inside a loop
+	HookInCallback  = "callback"     // inside a nested function or callback
+	HookAfterReturn = "early_return" // after a conditional return
+)
+
+// Component metrics compared with the React thresholds
+const (
+	ReactMetricJSXDepth           = "jsx_depth"
+	ReactMetricProps              = "props"
+	ReactMetricHooks              = "hooks"
+	ReactMetricConditionalRenders = "conditional_renders"
+)
+
+// ReactThresholds are the component limits of the React analysis; a zero value
+// disables a limit
+type ReactThresholds struct {
+	MaxJSXDepth           int `json:"max_jsx_depth" yaml:"max_jsx_depth"`
+	MaxProps              int `json:"max_props" yaml:"max_props"`
+	MaxHooks              int `json:"max_hooks" yaml:"max_hooks"`
+	MaxConditionalRenders int `json:"max_conditional_renders" yaml:"max_conditional_renders"`
+}
+
+// ReactComponent holds the metrics of a function component
+type ReactComponent struct {
+	Name      string `json:"name" yaml:"name"`
+	StartLine int    `json:"start_line" yaml:"start_line"`
+	EndLine   int    `json:"end_line" yaml:"end_line"`
+
+	// JSXDepth is the deepest nesting of JSX elements, including render callbacks
+	JSXDepth int `json:"jsx_depth" yaml:"jsx_depth"`
+
+	// Props is the number of destructured props or distinct props.x accesses
+	Props int `json:"props" yaml:"props"`
+
+	// Hooks is the number of hook calls in the component body
+	Hooks int `json:"hooks" yaml:"hooks"`
+
+	// ConditionalRenders counts the ternaries and logical expressions rendering JSX
+	// and the conditional early returns
+	ConditionalRenders int `json:"conditional_renders" yaml:"conditional_renders"`
+
+	// DefinedIn is the enclosing component when the component is defined inside
+	// another one, which remounts it on every render
+	DefinedIn string `json:"defined_in,omitempty" yaml:"defined_in,omitempty"`
+
+	// Exceeds lists the metrics above their threshold (jsx_depth, props, hooks,
+	// conditional_renders)
+	Exceeds []string `json:"exceeds,omitempty" yaml:"exceeds,omitempty"`
+}
+
+// HookViolation records a hook called where the rules of hooks forbid it, in a
+// component or a custom hook
+type HookViolation struct {
+	Hook     string `json:"hook" yaml:"hook"`
+	Function string `json:"function" yaml:"function"`
+	Line     int    `json:"line" yaml:"line"`
+	Reason   string `json:"reason" yaml:"reason"` // condition, loop, callback or early_return
+}
+
+// FileReact holds the components and hook violations of a file
+type FileReact struct {
+	FilePath       string           `json:"file_path" yaml:"file_path"`
+	Components     []ReactComponent `json:"components" yaml:"components"`
+	HookViolations []HookViolation  `json:"hook_violations" yaml:"hook_violations"`
+}
+
+// ReactSummary aggregates the React analysis of all files
+type ReactSummary struct {
+	FilesAnalyzed           int             `json:"files_analyzed" yaml:"files_analyzed"` // files with components or hooks
+	Components              int             `json:"components" yaml:"components"`
+	ComponentsOverThreshold int             `json:"components_over_threshold" yaml:"components_over_threshold"`
+	NestedComponents        int             `json:"nested_components" yaml:"nested_components"`
+	HookViolations          int             `json:"hook_violations" yaml:"hook_violations"`
+	MaxJSXDepth             int             `json:"max_jsx_depth" yaml:"max_jsx_depth"`
+	Thresholds              ReactThresholds `json:"thresholds" yaml:"thresholds"`
+}
+
+// ReactRequest represents a request for a React component analysis
+type ReactRequest struct {
+	Paths      []string
+	Sources    map[string][]byte // In-memory contents analyzed instead of the files on disk
+	Thresholds ReactThresholds
+}
+
+// ReactResponse holds the React components of the analyzed files, files with the
+// most problems first
+type ReactResponse struct {
+	Files   []FileReact  `json:"files" yaml:"files"`
+	Summary ReactSummary `json:"summary" yaml:"summary"`
+
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Errors   []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+
+	GeneratedAt string `json:"generated_at" yaml:"generated_at"`
+	Version     string `json:"version" yaml:"version"`
+}
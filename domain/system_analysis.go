@@ -166,6 +166,23 @@ type CircularDependency struct {
 	Severity     CycleSeverity    // Severity level
 	Size         int              // Number of modules
 	Description  string           // Human-readable description
+
+	// Cycle-breaking recommendation: the cheapest set of imports whose removal
+	// makes the cycle acyclic (a minimum feedback arc set of the SCC)
+	BreakingEdges []CycleBreakingEdge // Imports to remove, invert or convert to `import type`
+	BreakingCost  float64             // Total cost of the recommended edges
+}
+
+// CycleBreakingEdge is an import recommended for removal to break a circular dependency
+type CycleBreakingEdge struct {
+	From          string             // Importing module
+	To            string             // Imported module
+	EdgeType      DependencyEdgeType // Strongest edge type between the two modules
+	Specifiers    []string           // Symbols imported through the edge
+	TypeOnly      bool               // True if the imports are already type-only: erased at runtime, type-level cycle only
+	CanBeTypeOnly bool               // True if the imported symbols are only used as types, so `import type` breaks the runtime cycle
+	Cost          float64            // Refactoring cost (imported symbols, discounted when `import type` suffices)
+	Suggestion    string             // Human-readable recommendation
 }
 
 // DependencyPath represents a path of dependencies
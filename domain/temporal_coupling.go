@@ -0,0 +1,113 @@
+package domain
+
+import "time"
+
+// Temporal coupling defaults
+const (
+	// DefaultTemporalMinSharedCommits is the minimum number of commits changing both
+	// modules of a pair before it is reported
+	DefaultTemporalMinSharedCommits = 3
+
+	// DefaultTemporalMinDegree is the co-change degree from which a pair counts as
+	// logically coupled
+	DefaultTemporalMinDegree = 0.5
+
+	// DefaultTemporalMaxCommitFiles skips commits changing more files than this, such as
+	// formatting runs and mass renames, which couple everything with everything
+	DefaultTemporalMaxCommitFiles = 30
+)
+
+// TemporalCouplingKind classifies a pair of modules by comparing how they change with
+// how they import each other
+type TemporalCouplingKind string
+
+const (
+	// TemporalHidden pairs change together but neither imports the other
+	TemporalHidden TemporalCouplingKind = "hidden"
+
+	// TemporalStaticOnly pairs have an import edge but rarely change together
+	TemporalStaticOnly TemporalCouplingKind = "static_only"
+)
+
+// TemporalCouplingOptions configures temporal coupling detection on the dependency graph
+type TemporalCouplingOptions struct {
+	// Since limits the history to commits after this time (zero = whole history)
+	Since time.Time `json:"since,omitempty"`
+
+	// MinSharedCommits is the minimum number of commits changing both modules (0 = default).
+	// Import edges need both modules to have this many commits to be judged.
+	MinSharedCommits int `json:"min_shared_commits,omitempty"`
+
+	// MinDegree is the co-change degree (0-1) from which a pair is logically coupled (0 = default)
+	MinDegree float64 `json:"min_degree,omitempty"`
+
+	// MaxCommitFiles skips commits changing more modules than this (0 = default)
+	MaxCommitFiles int `json:"max_commit_files,omitempty"`
+}
+
+// Validate checks the temporal coupling options
+func (o *TemporalCouplingOptions) Validate() error {
+	if o.MinSharedCommits < 0 {
+		return NewValidationError("min_shared_commits must be >= 0")
+	}
+	if o.MinDegree < 0 || o.MinDegree > 1 {
+		return NewValidationError("min_degree must be between 0 and 1")
+	}
+	if o.MaxCommitFiles < 0 {
+		return NewValidationError("max_commit_files must be >= 0")
+	}
+	return nil
+}
+
+// WithDefaults returns the options with zero values replaced by the defaults
+func (o TemporalCouplingOptions) WithDefaults() TemporalCouplingOptions {
+	if o.MinSharedCommits == 0 {
+		o.MinSharedCommits = DefaultTemporalMinSharedCommits
+	}
+	if o.MinDegree == 0 {
+		o.MinDegree = DefaultTemporalMinDegree
+	}
+	if o.MaxCommitFiles == 0 {
+		o.MaxCommitFiles = DefaultTemporalMaxCommitFiles
+	}
+	return o
+}
+
+// TemporalCouplingPair is a pair of modules with their change history. Modules are
+// ordered by ID; for static-only pairs the import edge may go either way.
+type TemporalCouplingPair struct {
+	ModuleA string               `json:"module_a"`
+	ModuleB string               `json:"module_b"`
+	Kind    TemporalCouplingKind `json:"kind"`
+
+	// Commits changing each module and both of them
+	CommitsA      int `json:"commits_a"`
+	CommitsB      int `json:"commits_b"`
+	SharedCommits int `json:"shared_commits"`
+
+	// Degree is the shared commits over the average commits of the two modules (0-1)
+	Degree float64 `json:"degree"`
+
+	// Edges are the import edges between the modules (static-only pairs)
+	Edges []*DependencyEdge `json:"edges,omitempty"`
+}
+
+// TemporalCouplingResult compares the co-change history of modules with the dependency graph
+type TemporalCouplingResult struct {
+	Options TemporalCouplingOptions `json:"options"`
+
+	// Repository is the root of the git repository the history was read from
+	Repository string `json:"repository"`
+
+	// Commits is the number of commits changing at least one module
+	Commits int `json:"commits"`
+
+	// SkippedCommits were ignored for changing more than Options.MaxCommitFiles modules
+	SkippedCommits int `json:"skipped_commits"`
+
+	// Hidden are pairs that change together without an import between them, highest degree first
+	Hidden []TemporalCouplingPair `json:"hidden"`
+
+	// StaticOnly are import edges whose modules rarely change together, lowest degree first
+	StaticOnly []TemporalCouplingPair `json:"static_only"`
+}
@@ -0,0 +1,89 @@
+package domain
+
+// TypeSafetyMetrics counts the constructs that erode type checking in a function or
+// file. Type positions are parameters and explicit type annotations; a position is
+// typed unless it is an untyped parameter or annotated with a bare any.
+type TypeSafetyMetrics struct {
+	ExplicitAny       int `json:"explicit_any" yaml:"explicit_any"`
+	AsCasts           int `json:"as_casts" yaml:"as_casts"` // `as T` casts, not `as const`
+	NonNullAssertions int `json:"non_null_assertions" yaml:"non_null_assertions"`
+	TSSuppressions    int `json:"ts_suppressions" yaml:"ts_suppressions"` // @ts-ignore and @ts-expect-error comments
+	UntypedParams     int `json:"untyped_params" yaml:"untyped_params"`
+
+	TypePositions  int `json:"type_positions" yaml:"type_positions"`
+	TypedPositions int `json:"typed_positions" yaml:"typed_positions"`
+}
+
+// Add adds the counts of other to m
+func (m *TypeSafetyMetrics) Add(other TypeSafetyMetrics) {
+	m.ExplicitAny += other.ExplicitAny
+	m.AsCasts += other.AsCasts
+	m.NonNullAssertions += other.NonNullAssertions
+	m.TSSuppressions += other.TSSuppressions
+	m.UntypedParams += other.UntypedParams
+	m.TypePositions += other.TypePositions
+	m.TypedPositions += other.TypedPositions
+}
+
+// Escapes is the number of constructs that bypass the type checker
+func (m TypeSafetyMetrics) Escapes() int {
+	return m.ExplicitAny + m.AsCasts + m.NonNullAssertions + m.TSSuppressions + m.UntypedParams
+}
+
+// TypeCoverage is the percentage of typed positions (100 when there are none)
+func (m TypeSafetyMetrics) TypeCoverage() float64 {
+	if m.TypePositions == 0 {
+		return 100
+	}
+	return float64(m.TypedPositions) / float64(m.TypePositions) * 100
+}
+
+// FunctionTypeSafety holds the type-safety metrics of one function; constructs in
+// nested functions count toward the nested function
+type FunctionTypeSafety struct {
+	Name         string            `json:"name" yaml:"name"`
+	StartLine    int               `json:"start_line" yaml:"start_line"`
+	EndLine      int               `json:"end_line" yaml:"end_line"`
+	Metrics      TypeSafetyMetrics `json:"metrics" yaml:"metrics"`
+	TypeCoverage float64           `json:"type_coverage" yaml:"type_coverage"`
+}
+
+// FileTypeSafety holds the type-safety metrics of a TypeScript file, including the
+// code outside functions
+type FileTypeSafety struct {
+	FilePath     string               `json:"file_path" yaml:"file_path"`
+	Metrics      TypeSafetyMetrics    `json:"metrics" yaml:"metrics"`
+	TypeCoverage float64              `json:"type_coverage" yaml:"type_coverage"`
+	Functions    []FunctionTypeSafety `json:"functions" yaml:"functions"`
+}
+
+// TypeSafetySummary aggregates the type-safety metrics of all TypeScript files
+type TypeSafetySummary struct {
+	FilesAnalyzed int               `json:"files_analyzed" yaml:"files_analyzed"`
+	SkippedFiles  int               `json:"skipped_files" yaml:"skipped_files"` // JavaScript and declaration files
+	Functions     int               `json:"functions" yaml:"functions"`
+	Metrics       TypeSafetyMetrics `json:"metrics" yaml:"metrics"`
+	TypeCoverage  float64           `json:"type_coverage" yaml:"type_coverage"`
+}
+
+// TypeSafetyRequest represents a request for a type-safety analysis
+type TypeSafetyRequest struct {
+	// Paths are the files to analyze; only TypeScript files are measured
+	Paths []string
+
+	// Sources holds in-memory contents analyzed instead of the files on disk, keyed by path
+	Sources map[string][]byte
+}
+
+// TypeSafetyResponse holds the type-safety metrics of the analyzed files, least
+// covered file first
+type TypeSafetyResponse struct {
+	Files   []FileTypeSafety  `json:"files" yaml:"files"`
+	Summary TypeSafetySummary `json:"summary" yaml:"summary"`
+
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Errors   []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+
+	GeneratedAt string `json:"generated_at" yaml:"generated_at"`
+	Version     string `json:"version" yaml:"version"`
+}
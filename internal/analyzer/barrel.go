@@ -0,0 +1,577 @@
+package analyzer
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// reExport is a re-export statement of a module
+type reExport struct {
+	target string // resolved module ID
+	local  string // name in the target module (named re-exports)
+	line   int
+}
+
+// moduleExports indexes the exports of a module by name
+type moduleExports struct {
+	local      map[string]bool     // names declared by the module itself
+	named      map[string]reExport // export { a as b } from: exported name -> symbol
+	stars      []reExport          // export * from
+	statements int
+	reExports  int
+}
+
+// BarrelAnalyzer finds barrel files (modules that mostly re-export other modules) and
+// resolves the imports going through them to the modules defining the symbols
+type BarrelAnalyzer struct {
+	builder *DependencyGraphBuilder
+	graph   *domain.DependencyGraph
+	options domain.BarrelOptions
+
+	files   map[string]string // module ID -> file path
+	infos   map[string]*domain.ModuleInfo
+	known   map[string]bool
+	exports map[string]*moduleExports
+	barrels map[string]bool
+
+	// reExporters maps a module to the modules re-exporting it
+	reExporters map[string][]string
+}
+
+// NewBarrelAnalyzer creates a barrel analyzer for a graph built by builder from moduleResult
+func NewBarrelAnalyzer(builder *DependencyGraphBuilder, graph *domain.DependencyGraph, moduleResult *domain.ModuleAnalysisResult, options domain.BarrelOptions) *BarrelAnalyzer {
+	a := &BarrelAnalyzer{
+		builder: builder,
+		graph:   graph,
+		options: options.WithDefaults(),
+		files:   make(map[string]string),
+		infos:   make(map[string]*domain.ModuleInfo),
+		known:   make(map[string]bool),
+		exports: make(map[string]*moduleExports),
+		barrels: make(map[string]bool),
+
+		reExporters: make(map[string][]string),
+	}
+	for id, node := range graph.Nodes {
+		if !node.IsExternal {
+			a.known[id] = true
+		}
+	}
+	for filePath, info := range moduleResult.Files {
+		id := builder.normalizeModuleID(filePath)
+		a.files[id] = filePath
+		a.infos[id] = info
+	}
+	for id, info := range a.infos {
+		idx := a.indexExports(a.files[id], info)
+		a.exports[id] = idx
+		if idx.reExports >= a.options.MinReExports && idx.reExports*2 >= idx.statements {
+			a.barrels[id] = true
+		}
+		targets := make(map[string]bool)
+		for _, re := range idx.named {
+			targets[re.target] = true
+		}
+		for _, star := range idx.stars {
+			targets[star.target] = true
+		}
+		for target := range targets {
+			a.reExporters[target] = append(a.reExporters[target], id)
+		}
+	}
+	return a
+}
+
+// indexExports indexes the local exports and re-exports of a module
+func (a *BarrelAnalyzer) indexExports(filePath string, info *domain.ModuleInfo) *moduleExports {
+	idx := &moduleExports{local: make(map[string]bool), named: make(map[string]reExport)}
+	for _, exp := range info.Exports {
+		idx.statements++
+		if exp.Source == "" {
+			if exp.ExportType == "default" {
+				idx.local["default"] = true
+			} else if exp.Name != "" {
+				idx.local[exp.Name] = true
+			}
+			for _, spec := range exp.Specifiers {
+				if spec.Exported != "" {
+					idx.local[spec.Exported] = true
+				}
+			}
+			continue
+		}
+
+		idx.reExports++
+		target := a.builder.resolveImportTarget(exp.Source, exp.SourceType, filePath, a.known)
+		if exp.ExportType == "all" {
+			idx.stars = append(idx.stars, reExport{target: target, line: exp.Location.StartLine})
+			continue
+		}
+		for _, spec := range exp.Specifiers {
+			idx.named[spec.Exported] = reExport{target: target, local: spec.Local, line: exp.Location.StartLine}
+		}
+	}
+	return idx
+}
+
+// barrelImportSite is an import statement targeting a barrel
+type barrelImportSite struct {
+	importer string
+	barrel   string
+	imp      *domain.Import
+	sources  []string // module defining each specifier, "" when unresolved
+	names    []string // name of each specifier in its module
+}
+
+// resolved reports whether every imported name was traced to its module
+func (s barrelImportSite) resolved() bool {
+	if len(s.sources) == 0 {
+		return false
+	}
+	for _, source := range s.sources {
+		if source == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Analyze reports the barrels, the symbols imported through them, the cycles that
+// only exist because of them and the re-exported symbols nobody imports
+func (a *BarrelAnalyzer) Analyze() *domain.BarrelResult {
+	result := &domain.BarrelResult{
+		Options:         a.options,
+		Barrels:         []domain.Barrel{},
+		Imports:         []domain.BarrelImport{},
+		Cycles:          []domain.BarrelCycle{},
+		UnusedReExports: []domain.UnusedReExport{},
+	}
+
+	used := make(map[string]map[string]bool)
+	importers := make(map[string]map[string]bool)
+	var sites []barrelImportSite
+
+	for _, id := range sortedKeys(a.known) {
+		info := a.infos[id]
+		if info == nil {
+			continue
+		}
+		for _, imp := range info.Imports {
+			if imp.ImportType == domain.ImportTypeSideEffect {
+				continue
+			}
+			target := a.builder.resolveImportTarget(imp.Source, imp.SourceType, a.files[id], a.known)
+			wholeModule := len(imp.Specifiers) == 0
+			for _, spec := range imp.Specifiers {
+				if spec.Imported == "*" {
+					wholeModule = true
+				} else {
+					a.markUsed(used, target, spec.Imported)
+				}
+			}
+			if wholeModule {
+				for name := range a.exposed(target, make(map[string]bool)) {
+					a.markUsed(used, target, name)
+				}
+			}
+
+			if !a.barrels[target] {
+				continue
+			}
+			if importers[target] == nil {
+				importers[target] = make(map[string]bool)
+			}
+			importers[target][id] = true
+
+			site := barrelImportSite{importer: id, barrel: target, imp: imp}
+			for _, spec := range imp.Specifiers {
+				source, name := "", spec.Imported
+				if spec.Imported != "*" {
+					if module, local, ok := a.resolve(target, spec.Imported, make(map[string]bool)); ok {
+						source, name = module, local
+					}
+				}
+				site.sources = append(site.sources, source)
+				site.names = append(site.names, name)
+				result.Imports = append(result.Imports, domain.BarrelImport{
+					Importer: id,
+					Line:     imp.Location.StartLine,
+					Barrel:   target,
+					Symbol:   spec.Imported,
+					Source:   source,
+				})
+			}
+			sites = append(sites, site)
+		}
+	}
+
+	for _, id := range sortedKeys(a.barrels) {
+		result.Barrels = append(result.Barrels, a.describeBarrel(id, len(importers[id])))
+		if !a.isPublic(id, make(map[string]bool)) {
+			result.UnusedReExports = append(result.UnusedReExports, a.unusedReExports(id, used[id])...)
+		}
+	}
+	sort.SliceStable(result.Barrels, func(i, j int) bool {
+		bi, bj := result.Barrels[i], result.Barrels[j]
+		if bi.Importers != bj.Importers {
+			return bi.Importers > bj.Importers
+		}
+		return bi.ReExports > bj.ReExports
+	})
+
+	result.Cycles = a.barrelCycles(sites)
+	if a.options.Suggest {
+		result.Suggestions = a.suggestions(sites)
+	}
+	return result
+}
+
+// resolve follows re-exports from a module to the module defining an exported name;
+// names of modules outside the analyzed files are assumed to be defined there
+func (a *BarrelAnalyzer) resolve(module, name string, visited map[string]bool) (string, string, bool) {
+	idx := a.exports[module]
+	if idx == nil {
+		return module, name, true
+	}
+	key := module + "\x00" + name
+	if visited[key] {
+		return "", "", false
+	}
+	visited[key] = true
+
+	if idx.local[name] {
+		return module, name, true
+	}
+	if re, ok := idx.named[name]; ok {
+		return a.resolve(re.target, re.local, visited)
+	}
+	if name == "default" {
+		return "", "", false // export * does not re-export the default export
+	}
+	external := ""
+	for _, star := range idx.stars {
+		if a.exports[star.target] == nil {
+			if external == "" {
+				external = star.target
+			}
+			continue
+		}
+		if module, local, ok := a.resolve(star.target, name, visited); ok {
+			return module, local, true
+		}
+	}
+	if external != "" {
+		return external, name, true
+	}
+	return "", "", false
+}
+
+// exposed returns the names a module exports with the line of the statement
+// exporting them (0 for its own declarations)
+func (a *BarrelAnalyzer) exposed(module string, visited map[string]bool) map[string]int {
+	idx := a.exports[module]
+	if idx == nil || visited[module] {
+		return nil
+	}
+	visited[module] = true
+
+	names := make(map[string]int)
+	for name := range idx.local {
+		names[name] = 0
+	}
+	for name, re := range idx.named {
+		names[name] = re.line
+	}
+	for _, star := range idx.stars {
+		for name := range a.exposed(star.target, visited) {
+			if _, ok := names[name]; !ok && name != "default" {
+				names[name] = star.line
+			}
+		}
+	}
+	return names
+}
+
+// markUsed records that a name is imported from a module, and from the modules its
+// re-exports lead to
+func (a *BarrelAnalyzer) markUsed(used map[string]map[string]bool, module, name string) {
+	if used[module] == nil {
+		used[module] = make(map[string]bool)
+	}
+	if used[module][name] {
+		return
+	}
+	used[module][name] = true
+
+	idx := a.exports[module]
+	if idx == nil || idx.local[name] {
+		return
+	}
+	if re, ok := idx.named[name]; ok {
+		a.markUsed(used, re.target, re.local)
+		return
+	}
+	if name == "default" {
+		return
+	}
+	for _, star := range idx.stars {
+		if a.exports[star.target] == nil {
+			continue
+		}
+		if _, _, ok := a.resolve(star.target, name, make(map[string]bool)); ok {
+			a.markUsed(used, star.target, name)
+			return
+		}
+	}
+}
+
+// isPublic reports whether a barrel is an entry point or re-exported from one, such
+// as the index of a library, whose exports are meant for code outside the project
+func (a *BarrelAnalyzer) isPublic(module string, visited map[string]bool) bool {
+	if visited[module] {
+		return false
+	}
+	visited[module] = true
+	if len(a.graph.GetIncomingEdges(module)) == 0 {
+		return true
+	}
+	for _, id := range a.reExporters[module] {
+		if a.isPublic(id, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeBarrel builds the report of a barrel
+func (a *BarrelAnalyzer) describeBarrel(id string, importers int) domain.Barrel {
+	idx := a.exports[id]
+	sources := make(map[string]bool)
+	for _, re := range idx.named {
+		sources[re.target] = true
+	}
+	for _, star := range idx.stars {
+		sources[star.target] = true
+	}
+
+	barrel := domain.Barrel{
+		Module:      id,
+		ReExports:   idx.reExports,
+		StarExports: len(idx.stars),
+		Sources:     sortedKeys(sources),
+		Symbols:     len(a.exposed(id, make(map[string]bool))),
+		Importers:   importers,
+	}
+	for _, source := range barrel.Sources {
+		if a.barrels[source] {
+			barrel.NestedBarrels = append(barrel.NestedBarrels, source)
+		}
+	}
+	return barrel
+}
+
+// unusedReExports returns the symbols a barrel re-exports that are never imported
+// through it
+func (a *BarrelAnalyzer) unusedReExports(id string, used map[string]bool) []domain.UnusedReExport {
+	idx := a.exports[id]
+	var unused []domain.UnusedReExport
+	for name, line := range a.exposed(id, make(map[string]bool)) {
+		if idx.local[name] || used[name] {
+			continue
+		}
+		source, _, _ := a.resolve(id, name, make(map[string]bool))
+		unused = append(unused, domain.UnusedReExport{Barrel: id, Line: line, Symbol: name, Source: source})
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].Line != unused[j].Line {
+			return unused[i].Line < unused[j].Line
+		}
+		return unused[i].Symbol < unused[j].Symbol
+	})
+	return unused
+}
+
+// barrelCycles returns the cycles through a barrel that disappear once the imports
+// through barrels point at the modules defining the imported symbols
+func (a *BarrelAnalyzer) barrelCycles(sites []barrelImportSite) []domain.BarrelCycle {
+	replacements := make(map[string][]string)
+	for _, site := range sites {
+		if site.resolved() {
+			replacements[barrelEdgeKey(site.importer, site.barrel, site.imp.Location.StartLine)] = site.sources
+		}
+	}
+
+	resolved := domain.NewDependencyGraph()
+	for _, node := range a.graph.Nodes {
+		resolved.AddNode(node)
+	}
+	for _, edges := range a.graph.Edges {
+		for _, edge := range edges {
+			line := 0
+			if edge.Location != nil {
+				line = edge.Location.StartLine
+			}
+			targets, ok := replacements[barrelEdgeKey(edge.From, edge.To, line)]
+			if !ok || edge.EdgeType == domain.EdgeTypeReExport {
+				resolved.AddEdge(edge)
+				continue
+			}
+			seen := make(map[string]bool)
+			for _, target := range targets {
+				if target == edge.From || seen[target] || resolved.GetNode(target) == nil {
+					continue
+				}
+				seen[target] = true
+				direct := *edge
+				direct.To = target
+				resolved.AddEdge(&direct)
+			}
+		}
+	}
+
+	detector := NewCircularDependencyDetector()
+	before := detector.DetectCycles(a.graph)
+	after := NewCircularDependencyDetector().DetectCycles(resolved)
+
+	cycles := []domain.BarrelCycle{}
+	for _, cycle := range before.CircularDependencies {
+		var barrels []string
+		members := make(map[string]bool, len(cycle.Modules))
+		for _, module := range cycle.Modules {
+			members[module] = true
+			if a.barrels[module] {
+				barrels = append(barrels, module)
+			}
+		}
+		if len(barrels) == 0 || overlapsCycle(after, members) {
+			continue
+		}
+		sort.Strings(barrels)
+		cycles = append(cycles, domain.BarrelCycle{Modules: cycle.Modules, Barrels: barrels})
+	}
+	return cycles
+}
+
+// overlapsCycle reports whether a cycle of the analysis shares two modules with members
+func overlapsCycle(analysis *domain.CircularDependencyAnalysis, members map[string]bool) bool {
+	for _, cycle := range analysis.CircularDependencies {
+		shared := 0
+		for _, module := range cycle.Modules {
+			if members[module] {
+				shared++
+			}
+		}
+		if shared >= 2 {
+			return true
+		}
+	}
+	return false
+}
+
+// barrelEdgeKey identifies the import edge of a statement
+func barrelEdgeKey(from, to string, line int) string {
+	return from + "\x00" + to + "\x00" + strconv.Itoa(line)
+}
+
+// suggestions returns the direct imports replacing each fully resolved import
+// through a barrel, one statement per module defining the symbols
+func (a *BarrelAnalyzer) suggestions(sites []barrelImportSite) []domain.BarrelSuggestion {
+	suggestions := []domain.BarrelSuggestion{}
+	for _, site := range sites {
+		if !site.resolved() {
+			continue
+		}
+
+		type statement struct {
+			defaultName string
+			named       []string
+		}
+		statements := make(map[string]*statement)
+		internal := true
+		for i, spec := range site.imp.Specifiers {
+			source := site.sources[i]
+			if a.files[source] == "" {
+				internal = false
+				break
+			}
+			if statements[source] == nil {
+				statements[source] = &statement{}
+			}
+			s := statements[source]
+			name := site.names[i]
+			switch {
+			case name == "default":
+				s.defaultName = spec.Local
+			case name == spec.Local:
+				s.named = append(s.named, typePrefix(spec.IsType)+name)
+			default:
+				s.named = append(s.named, typePrefix(spec.IsType)+name+" as "+spec.Local)
+			}
+		}
+		if !internal {
+			continue
+		}
+
+		suggestion := domain.BarrelSuggestion{
+			Importer: site.importer,
+			Line:     site.imp.Location.StartLine,
+			Barrel:   site.barrel,
+		}
+		keyword := "import "
+		if site.imp.IsTypeOnly {
+			keyword = "import type "
+		}
+		sources := make([]string, 0, len(statements))
+		for source := range statements {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			s := statements[source]
+			var clause []string
+			if s.defaultName != "" {
+				clause = append(clause, s.defaultName)
+			}
+			if len(s.named) > 0 {
+				clause = append(clause, "{ "+strings.Join(s.named, ", ")+" }")
+			}
+			suggestion.Imports = append(suggestion.Imports, keyword+strings.Join(clause, ", ")+
+				" from '"+relativeImportPath(a.files[site.importer], a.files[source])+"'")
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions
+}
+
+// typePrefix returns the inline "type " modifier of a type-only specifier
+func typePrefix(isType bool) string {
+	if isType {
+		return "type "
+	}
+	return ""
+}
+
+// relativeImportPath returns the import specifier of a file from another, without
+// extension or /index
+func relativeImportPath(fromFile, toFile string) string {
+	rel, err := filepath.Rel(filepath.Dir(fromFile), toFile)
+	if err != nil {
+		rel = toFile
+	}
+	rel = filepath.ToSlash(rel)
+	rel = strings.TrimSuffix(rel, path.Ext(rel))
+	if rel == "index" {
+		rel = "."
+	} else {
+		rel = strings.TrimSuffix(rel, "/index")
+	}
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
+	}
+	return rel
+}
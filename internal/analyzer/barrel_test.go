@@ -0,0 +1,161 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+var barrelSources = map[string]string{
+	"/project/src/components/index.ts": `export { Button } from './Button';
+export { default as Modal } from './Modal';
+export * from './Icon';
+export type { Theme } from './theme';`,
+	"/project/src/components/Button.tsx": `import { formatLabel } from '../utils';
+export const Button = () => formatLabel('x');`,
+	"/project/src/components/Modal.tsx": `import { Button } from './index';
+export default function Modal() { return Button(); }`,
+	"/project/src/components/Icon.tsx": `export const Icon = 1;
+export const IconSet = 2;`,
+	"/project/src/components/theme.ts": `export type Theme = { dark: boolean };`,
+	"/project/src/utils/index.ts": `export * from './format';
+export * from './date';
+export { parse as parseDate } from './date';`,
+	"/project/src/utils/format.ts": `export function formatLabel(s: string) { return s; }`,
+	"/project/src/utils/date.ts": `export function parse() {}
+export function today() {}`,
+	"/project/src/main.ts": `import { Modal, Icon } from './components';
+import { today } from './utils';
+Modal(); today(); Icon;`,
+}
+
+func analyzeBarrels(t *testing.T, sources map[string]string, options domain.BarrelOptions) *domain.BarrelResult {
+	t.Helper()
+
+	asts := make(map[string]*parser.Node)
+	for path, source := range sources {
+		ast, err := parser.ParseForLanguage(path, []byte(source))
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", path, err)
+		}
+		asts[path] = ast
+	}
+
+	config := DefaultDependencyGraphBuilderConfig()
+	config.ProjectRoot = "/project"
+	builder := NewDependencyGraphBuilder(config)
+	moduleResult, err := builder.AnalyzeModules(asts)
+	if err != nil {
+		t.Fatalf("Failed to analyze modules: %v", err)
+	}
+	graph := builder.BuildGraph(moduleResult)
+	return NewBarrelAnalyzer(builder, graph, moduleResult, options.WithDefaults()).Analyze()
+}
+
+func TestBarrelAnalyzerDetectsBarrels(t *testing.T) {
+	result := analyzeBarrels(t, barrelSources, domain.BarrelOptions{})
+
+	if len(result.Barrels) != 2 {
+		t.Fatalf("Expected 2 barrels, got %+v", result.Barrels)
+	}
+	components := result.Barrels[0]
+	if components.Module != "src/components/index.ts" {
+		t.Errorf("Expected the most imported barrel first, got %s", components.Module)
+	}
+	if components.ReExports != 4 || components.StarExports != 1 || components.Importers != 2 {
+		t.Errorf("Unexpected components barrel: %+v", components)
+	}
+
+	// A module re-exporting two names is not a barrel with the default threshold
+	small := map[string]string{
+		"/project/index.ts": `export { a } from './a';
+export { b } from './b';`,
+		"/project/a.ts": `export const a = 1;`,
+		"/project/b.ts": `export const b = 1;`,
+	}
+	if result := analyzeBarrels(t, small, domain.BarrelOptions{}); len(result.Barrels) != 0 {
+		t.Errorf("Expected no barrels, got %+v", result.Barrels)
+	}
+	if result := analyzeBarrels(t, small, domain.BarrelOptions{MinReExports: 2}); len(result.Barrels) != 1 {
+		t.Errorf("Expected 1 barrel with min_re_exports 2, got %+v", result.Barrels)
+	}
+}
+
+func TestBarrelAnalyzerResolvesImports(t *testing.T) {
+	result := analyzeBarrels(t, barrelSources, domain.BarrelOptions{})
+
+	sources := make(map[string]string)
+	for _, imp := range result.Imports {
+		sources[imp.Importer+" "+imp.Symbol] = imp.Source
+	}
+	expected := map[string]string{
+		"src/main.ts Modal":                     "src/components/Modal.tsx",
+		"src/main.ts Icon":                      "src/components/Icon.tsx",
+		"src/main.ts today":                     "src/utils/date.ts",
+		"src/components/Button.tsx formatLabel": "src/utils/format.ts",
+		"src/components/Modal.tsx Button":       "src/components/Button.tsx",
+	}
+	for key, want := range expected {
+		if sources[key] != want {
+			t.Errorf("Expected %s to resolve to %s, got %q", key, want, sources[key])
+		}
+	}
+}
+
+func TestBarrelAnalyzerCycles(t *testing.T) {
+	result := analyzeBarrels(t, barrelSources, domain.BarrelOptions{})
+
+	if len(result.Cycles) != 1 {
+		t.Fatalf("Expected 1 barrel cycle, got %+v", result.Cycles)
+	}
+	cycle := result.Cycles[0]
+	if strings.Join(cycle.Modules, ",") != "src/components/Modal.tsx,src/components/index.ts" &&
+		strings.Join(cycle.Modules, ",") != "src/components/index.ts,src/components/Modal.tsx" {
+		t.Errorf("Unexpected cycle modules: %v", cycle.Modules)
+	}
+	if len(cycle.Barrels) != 1 || cycle.Barrels[0] != "src/components/index.ts" {
+		t.Errorf("Unexpected cycle barrels: %v", cycle.Barrels)
+	}
+}
+
+func TestBarrelAnalyzerUnusedReExports(t *testing.T) {
+	result := analyzeBarrels(t, barrelSources, domain.BarrelOptions{})
+
+	var unused []string
+	for _, reExport := range result.UnusedReExports {
+		unused = append(unused, reExport.Symbol)
+	}
+	got := strings.Join(unused, ",")
+	for _, symbol := range []string{"IconSet", "Theme", "parseDate"} {
+		if !strings.Contains(got, symbol) {
+			t.Errorf("Expected %s to be an unused re-export, got %v", symbol, unused)
+		}
+	}
+	for _, symbol := range []string{"Modal", "Button", "today", "formatLabel"} {
+		for _, name := range unused {
+			if name == symbol {
+				t.Errorf("Expected %s to be used, got %v", symbol, unused)
+			}
+		}
+	}
+}
+
+func TestBarrelAnalyzerSuggestions(t *testing.T) {
+	if result := analyzeBarrels(t, barrelSources, domain.BarrelOptions{}); len(result.Suggestions) != 0 {
+		t.Errorf("Expected no suggestions unless requested, got %+v", result.Suggestions)
+	}
+
+	result := analyzeBarrels(t, barrelSources, domain.BarrelOptions{Suggest: true})
+	var imports []string
+	for _, suggestion := range result.Suggestions {
+		if suggestion.Importer == "src/main.ts" && suggestion.Barrel == "src/components/index.ts" {
+			imports = suggestion.Imports
+		}
+	}
+	want := []string{"import { Icon } from './components/Icon'", "import Modal from './components/Modal'"}
+	if strings.Join(imports, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %v, got %v", want, imports)
+	}
+}
@@ -191,9 +191,9 @@ func (ca *CBOAnalyzer) extractTypesFromNode(node *parser.Node, deps *ClassDepend
 		return
 	}
 
-	// Walk through the node to find identifier types
+	// Walk through the node to find type references, skipping property names
 	node.Walk(func(n *parser.Node) bool {
-		if n.Type == parser.NodeIdentifier && n.Name != "" {
+		if n.Type == parser.NodeIdentifier && n.Kind == "type" && n.Name != "" {
 			typeName := n.Name
 			// Skip primitive types and common utility types
 			if !isPrimitiveType(typeName) && !isBuiltinType(typeName) {
@@ -66,7 +66,7 @@ func (d *CircularDependencyDetector) DetectCycles(graph *domain.DependencyGraph)
 	sort.Strings(coreModules)
 
 	// Generate cycle breaking suggestions
-	suggestions := d.suggestCycleBreaking(cycles, graph)
+	suggestions := d.suggestCycleBreaking(cycles)
 
 	return &domain.CircularDependencyAnalysis{
 		HasCircularDependencies:  len(cycles) > 0,
@@ -177,12 +177,17 @@ func (d *CircularDependencyDetector) buildCycleInfo(scc []string, graph *domain.
 	// Generate description
 	description := d.generateCycleDescription(scc)
 
+	// Recommend the cheapest set of imports to remove
+	breakingEdges, breakingCost := recommendCycleBreaks(scc, graph)
+
 	return domain.CircularDependency{
-		Modules:      scc,
-		Dependencies: paths,
-		Severity:     severity,
-		Size:         len(scc),
-		Description:  description,
+		Modules:       scc,
+		Dependencies:  paths,
+		Severity:      severity,
+		Size:          len(scc),
+		Description:   description,
+		BreakingEdges: breakingEdges,
+		BreakingCost:  breakingCost,
 	}
 }
 
@@ -223,7 +228,7 @@ func (d *CircularDependencyDetector) generateCycleDescription(scc []string) stri
 }
 
 // suggestCycleBreaking generates suggestions for breaking cycles
-func (d *CircularDependencyDetector) suggestCycleBreaking(cycles []domain.CircularDependency, graph *domain.DependencyGraph) []string {
+func (d *CircularDependencyDetector) suggestCycleBreaking(cycles []domain.CircularDependency) []string {
 	var suggestions []string
 
 	for _, cycle := range cycles {
@@ -231,15 +236,9 @@ func (d *CircularDependencyDetector) suggestCycleBreaking(cycles []domain.Circul
 			continue
 		}
 
-		// Find the best edge to break
-		bestEdge := d.findBestEdgeToBreak(cycle, graph)
-		if bestEdge != nil {
-			suggestion := fmt.Sprintf(
-				"Consider removing or inverting the dependency from '%s' to '%s' to break the cycle",
-				getModuleBaseName(bestEdge.From),
-				getModuleBaseName(bestEdge.To),
-			)
-			suggestions = append(suggestions, suggestion)
+		// Recommend the imports of the minimum feedback arc set
+		for _, edge := range cycle.BreakingEdges {
+			suggestions = append(suggestions, edge.Suggestion)
 		}
 
 		// Suggest interface extraction for larger cycles
@@ -255,29 +254,6 @@ func (d *CircularDependencyDetector) suggestCycleBreaking(cycles []domain.Circul
 	return suggestions
 }
 
-// findBestEdgeToBreak finds the edge with the lowest weight (least used) to break a cycle
-func (d *CircularDependencyDetector) findBestEdgeToBreak(cycle domain.CircularDependency, graph *domain.DependencyGraph) *domain.DependencyEdge {
-	var bestEdge *domain.DependencyEdge
-	minWeight := int(^uint(0) >> 1) // Max int
-
-	sccSet := make(map[string]bool)
-	for _, module := range cycle.Modules {
-		sccSet[module] = true
-	}
-
-	for _, module := range cycle.Modules {
-		edges := graph.GetOutgoingEdges(module)
-		for _, edge := range edges {
-			if sccSet[edge.To] && edge.Weight < minWeight {
-				minWeight = edge.Weight
-				bestEdge = edge
-			}
-		}
-	}
-
-	return bestEdge
-}
-
 // getModuleBaseName extracts a readable module name from a path
 func getModuleBaseName(path string) string {
 	// Get the last component of the path
@@ -101,6 +101,18 @@ type CloneDetectorConfig struct {
 	// Cost model to use for APTED
 	CostModelType string // "default", "javascript", "weighted"
 
+	// Detection mode: tree (APTED), token (rolling hash) or hybrid
+	DetectionMode DetectionMode
+
+	// Minimum number of tokens for a token-based clone
+	MinTokens int
+
+	// Whether to detect clones of statement sequences within blocks (off by default)
+	DetectStatementSequences bool
+
+	// Minimum number of consecutive statements for a statement sequence clone
+	MinStatements int
+
 	// Performance tuning parameters
 	MaxClonePairs      int // Maximum pairs to keep in memory
 	BatchSizeThreshold int // Minimum fragments to trigger batching
@@ -134,6 +146,11 @@ func DefaultCloneDetectorConfig() *CloneDetectorConfig {
 		IgnoreLiterals:    false,
 		IgnoreIdentifiers: false,
 		CostModelType:     "javascript",
+		DetectionMode:     DetectionModeTree,
+		MinTokens:         50,
+		// Statement sequence defaults
+		DetectStatementSequences: false,
+		MinStatements:            3,
 		// Performance parameters
 		MaxClonePairs:      10000,
 		BatchSizeThreshold: 50,
@@ -165,6 +182,9 @@ type CloneDetector struct {
 	fragments   []*CodeFragment
 	clonePairs  []*domain.ClonePair
 	cloneGroups []*domain.CloneGroup
+
+	// warnings record where detection compared fewer candidates than usual
+	warnings []string
 }
 
 // NewCloneDetector creates a new clone detector with the given configuration
@@ -200,6 +220,12 @@ func (cd *CloneDetector) SetUseLSH(enabled bool) {
 	cd.cloneDetectorConfig.UseLSH = enabled
 }
 
+// Warnings returns the limits hit by the detections run so far, such as token
+// sequences too frequent to compare all their occurrences
+func (cd *CloneDetector) Warnings() []string {
+	return cd.warnings
+}
+
 // SetBatchSizeLarge sets the batch size for normal projects (used in testing)
 func (cd *CloneDetector) SetBatchSizeLarge(size int) {
 	cd.cloneDetectorConfig.BatchSizeLarge = size
@@ -346,28 +372,7 @@ func (cd *CloneDetector) DetectClonesWithContext(ctx context.Context, fragments
 	}
 
 	// Group related clones using configured strategy
-	// Clamp threshold to [0,1]
-	thr := cd.cloneDetectorConfig.GroupingThreshold
-	if thr < 0.0 {
-		thr = 0.0
-	} else if thr > 1.0 {
-		thr = 1.0
-	}
-	k := cd.cloneDetectorConfig.KCoreK
-	if k < 2 {
-		k = 2
-	}
-	groupingConfig := GroupingConfig{
-		Mode:           cd.cloneDetectorConfig.GroupingMode,
-		Threshold:      thr,
-		KCoreK:         k,
-		Type1Threshold: cd.cloneDetectorConfig.Type1Threshold,
-		Type2Threshold: cd.cloneDetectorConfig.Type2Threshold,
-		Type3Threshold: cd.cloneDetectorConfig.Type3Threshold,
-		Type4Threshold: cd.cloneDetectorConfig.Type4Threshold,
-	}
-	strategy := CreateGroupingStrategy(groupingConfig)
-	cd.groupClonesWithStrategy(strategy)
+	cd.groupClonesWithStrategy(cd.newGroupingStrategy())
 
 	return cd.clonePairs, cd.cloneGroups
 }
@@ -501,27 +506,7 @@ func (cd *CloneDetector) DetectClonesWithLSH(ctx context.Context, fragments []*C
 	cd.limitAndSortClonePairs(cd.cloneDetectorConfig.MaxClonePairs)
 
 	// Grouping
-	thr := cd.cloneDetectorConfig.GroupingThreshold
-	if thr < 0.0 {
-		thr = 0.0
-	} else if thr > 1.0 {
-		thr = 1.0
-	}
-	k := cd.cloneDetectorConfig.KCoreK
-	if k < 2 {
-		k = 2
-	}
-	groupingConfig := GroupingConfig{
-		Mode:           cd.cloneDetectorConfig.GroupingMode,
-		Threshold:      thr,
-		KCoreK:         k,
-		Type1Threshold: cd.cloneDetectorConfig.Type1Threshold,
-		Type2Threshold: cd.cloneDetectorConfig.Type2Threshold,
-		Type3Threshold: cd.cloneDetectorConfig.Type3Threshold,
-		Type4Threshold: cd.cloneDetectorConfig.Type4Threshold,
-	}
-	strategy := CreateGroupingStrategy(groupingConfig)
-	cd.groupClonesWithStrategy(strategy)
+	cd.groupClonesWithStrategy(cd.newGroupingStrategy())
 
 	return cd.clonePairs, cd.cloneGroups
 }
@@ -827,6 +812,31 @@ func (cd *CloneDetector) isSignificantClone(pair *domain.ClonePair) bool {
 	return minSize >= float64(cd.cloneDetectorConfig.MinNodes)
 }
 
+// newGroupingStrategy creates the grouping strategy from the detector configuration
+func (cd *CloneDetector) newGroupingStrategy() GroupingStrategy {
+	// Clamp threshold to [0,1]
+	thr := cd.cloneDetectorConfig.GroupingThreshold
+	if thr < 0.0 {
+		thr = 0.0
+	} else if thr > 1.0 {
+		thr = 1.0
+	}
+	k := cd.cloneDetectorConfig.KCoreK
+	if k < 2 {
+		k = 2
+	}
+	groupingConfig := GroupingConfig{
+		Mode:           cd.cloneDetectorConfig.GroupingMode,
+		Threshold:      thr,
+		KCoreK:         k,
+		Type1Threshold: cd.cloneDetectorConfig.Type1Threshold,
+		Type2Threshold: cd.cloneDetectorConfig.Type2Threshold,
+		Type3Threshold: cd.cloneDetectorConfig.Type3Threshold,
+		Type4Threshold: cd.cloneDetectorConfig.Type4Threshold,
+	}
+	return CreateGroupingStrategy(groupingConfig)
+}
+
 // groupClonesWithStrategy groups clone pairs using a pluggable strategy.
 func (cd *CloneDetector) groupClonesWithStrategy(strategy GroupingStrategy) {
 	if strategy == nil {
@@ -0,0 +1,287 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// CodeSplittingAnalyzer splits the dependency graph into the chunks a bundler emits:
+// one per entry point and one per dynamic import() target, each holding the modules
+// reachable through static imports and re-exports
+type CodeSplittingAnalyzer struct {
+	graph   *domain.DependencyGraph
+	sizes   map[string]int64
+	options domain.CodeSplittingOptions
+}
+
+// NewCodeSplittingAnalyzer creates a new CodeSplittingAnalyzer. sizes maps module IDs
+// to their source size in bytes.
+func NewCodeSplittingAnalyzer(graph *domain.DependencyGraph, sizes map[string]int64, options domain.CodeSplittingOptions) *CodeSplittingAnalyzer {
+	if graph == nil {
+		graph = domain.NewDependencyGraph()
+	}
+	return &CodeSplittingAnalyzer{graph: graph, sizes: sizes, options: options}
+}
+
+// Analyze computes the entry and lazy chunks and the modules reachable both statically
+// from an entry point and from a dynamic import boundary
+func (a *CodeSplittingAnalyzer) Analyze() (*domain.CodeSplittingResult, error) {
+	entries, err := a.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.CodeSplittingResult{
+		Options: a.options,
+		Chunks:  []domain.Chunk{},
+		Mixed:   []domain.MixedModule{},
+	}
+
+	// Chunks of the entry points, and the shortest static path to each module
+	entryChunks := make(map[string][]string) // module -> entry roots
+	isEntry := make(map[string]bool, len(entries))
+	var entryList []domain.Chunk
+	for _, entry := range entries {
+		isEntry[entry] = true
+		modules := a.staticClosure(entry)
+		for _, module := range modules {
+			entryChunks[module] = append(entryChunks[module], entry)
+		}
+		entryList = append(entryList, a.newChunk(entry, domain.ChunkEntry, modules))
+	}
+	parents := a.staticParents(entries)
+
+	// Chunks of the dynamic imports made by the modules the entry points load
+	lazyChunks := make(map[string][]string) // module -> lazy roots
+	var lazyList []domain.Chunk
+	reachable := a.reachable(entries)
+	for _, root := range sortedKeys(reachable) {
+		sites := a.dynamicImportSites(root, reachable)
+		if len(sites) == 0 || isEntry[root] {
+			continue
+		}
+		modules := a.staticClosure(root)
+		chunk := a.newChunk(root, domain.ChunkLazy, modules)
+		chunk.ImportedBy = sites
+		for _, module := range modules {
+			lazyChunks[module] = append(lazyChunks[module], root)
+			if len(entryChunks[module]) == 0 {
+				chunk.OwnModules++
+				chunk.OwnSize += a.sizes[module]
+			}
+		}
+		lazyList = append(lazyList, chunk)
+	}
+
+	sortChunks(entryList)
+	sortChunks(lazyList)
+	result.Chunks = append(append(result.Chunks, entryList...), lazyList...)
+
+	for module, lazyRoots := range lazyChunks {
+		entryRoots := entryChunks[module]
+		if len(entryRoots) == 0 {
+			continue
+		}
+		defeats := false
+		for _, root := range lazyRoots {
+			if root == module {
+				defeats = true
+			}
+		}
+		result.Mixed = append(result.Mixed, domain.MixedModule{
+			Module:       module,
+			EntryChunks:  entryRoots,
+			LazyChunks:   lazyRoots,
+			DefeatsSplit: defeats,
+			StaticPath:   staticPath(parents, module),
+			Size:         a.sizes[module],
+		})
+	}
+	sort.Slice(result.Mixed, func(i, j int) bool {
+		x, y := result.Mixed[i], result.Mixed[j]
+		if x.DefeatsSplit != y.DefeatsSplit {
+			return x.DefeatsSplit
+		}
+		if x.Size != y.Size {
+			return x.Size > y.Size
+		}
+		return x.Module < y.Module
+	})
+
+	return result, nil
+}
+
+// entries returns the modules matched by the entry options, or the non-test project
+// modules no other non-test module imports
+func (a *CodeSplittingAnalyzer) entries() ([]string, error) {
+	selected := make(map[string]bool)
+	if a.options.Entries == nil {
+		for id, node := range a.graph.Nodes {
+			if node.IsExternal || isTestFile(id) {
+				continue
+			}
+			imported := false
+			for _, edge := range a.graph.GetIncomingEdges(id) {
+				if !isTestFile(edge.From) {
+					imported = true
+					break
+				}
+			}
+			if !imported {
+				selected[id] = true
+			}
+		}
+		return sortedKeys(selected), nil
+	}
+
+	engine := NewDependencyQueryEngine(a.graph)
+	for _, pattern := range a.options.Entries {
+		matched := false
+		for _, id := range engine.ResolveModules(pattern) {
+			if node := a.graph.GetNode(id); node != nil && !node.IsExternal {
+				selected[id] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("no module matches entry %q", pattern), nil)
+		}
+	}
+	return sortedKeys(selected), nil
+}
+
+// isStaticEdge reports whether an edge puts its target in the chunk of its source.
+// Type-only imports are erased and dynamic imports start a chunk of their own.
+func isStaticEdge(edge *domain.DependencyEdge) bool {
+	return edge.EdgeType == domain.EdgeTypeImport || edge.EdgeType == domain.EdgeTypeReExport
+}
+
+// isProjectModule reports whether a module ID is an analyzed file of the project
+func (a *CodeSplittingAnalyzer) isProjectModule(id string) bool {
+	node := a.graph.GetNode(id)
+	return node != nil && !node.IsExternal
+}
+
+// staticClosure returns the root and the project modules it reaches through static
+// edges, sorted
+func (a *CodeSplittingAnalyzer) staticClosure(root string) []string {
+	visited := map[string]bool{root: true}
+	stack := []string{root}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, edge := range a.graph.GetOutgoingEdges(current) {
+			if !isStaticEdge(edge) || visited[edge.To] || !a.isProjectModule(edge.To) {
+				continue
+			}
+			visited[edge.To] = true
+			stack = append(stack, edge.To)
+		}
+	}
+	return sortedKeys(visited)
+}
+
+// staticParents runs a breadth-first search from all entry points over static edges
+// and returns the module each reached module was first imported from
+func (a *CodeSplittingAnalyzer) staticParents(entries []string) map[string]string {
+	parents := make(map[string]string, len(entries))
+	queue := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		parents[entry] = ""
+		queue = append(queue, entry)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		edges := a.graph.GetOutgoingEdges(current)
+		targets := make([]string, 0, len(edges))
+		for _, edge := range edges {
+			if isStaticEdge(edge) && a.isProjectModule(edge.To) {
+				targets = append(targets, edge.To)
+			}
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			if _, seen := parents[target]; seen {
+				continue
+			}
+			parents[target] = current
+			queue = append(queue, target)
+		}
+	}
+	return parents
+}
+
+// staticPath returns the chain of static imports from an entry point to a module
+func staticPath(parents map[string]string, module string) []string {
+	var path []string
+	for current := module; current != ""; current = parents[current] {
+		path = append([]string{current}, path...)
+	}
+	return path
+}
+
+// reachable returns the project modules the entry points load, statically or lazily
+func (a *CodeSplittingAnalyzer) reachable(entries []string) map[string]bool {
+	visited := make(map[string]bool, len(entries))
+	stack := append([]string(nil), entries...)
+	for _, entry := range entries {
+		visited[entry] = true
+	}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, edge := range a.graph.GetOutgoingEdges(current) {
+			if edge.EdgeType == domain.EdgeTypeTypeOnly || visited[edge.To] || !a.isProjectModule(edge.To) {
+				continue
+			}
+			visited[edge.To] = true
+			stack = append(stack, edge.To)
+		}
+	}
+	return visited
+}
+
+// dynamicImportSites returns the dynamic imports of a module made by the reachable
+// modules, sorted by importer and line
+func (a *CodeSplittingAnalyzer) dynamicImportSites(module string, reachable map[string]bool) []domain.DynamicImportSite {
+	var sites []domain.DynamicImportSite
+	for _, edge := range a.graph.GetIncomingEdges(module) {
+		if edge.EdgeType != domain.EdgeTypeDynamic || !reachable[edge.From] {
+			continue
+		}
+		site := domain.DynamicImportSite{Importer: edge.From}
+		if edge.Location != nil {
+			site.Line = edge.Location.StartLine
+		}
+		sites = append(sites, site)
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].Importer != sites[j].Importer {
+			return sites[i].Importer < sites[j].Importer
+		}
+		return sites[i].Line < sites[j].Line
+	})
+	return sites
+}
+
+// newChunk creates a chunk and sums the sizes of its modules
+func (a *CodeSplittingAnalyzer) newChunk(root string, kind domain.ChunkKind, modules []string) domain.Chunk {
+	chunk := domain.Chunk{Root: root, Kind: kind, Modules: modules}
+	for _, module := range modules {
+		chunk.Size += a.sizes[module]
+	}
+	return chunk
+}
+
+// sortChunks orders chunks by size, largest first
+func sortChunks(chunks []domain.Chunk) {
+	sort.Slice(chunks, func(i, j int) bool {
+		if chunks[i].Size != chunks[j].Size {
+			return chunks[i].Size > chunks[j].Size
+		}
+		return chunks[i].Root < chunks[j].Root
+	})
+}
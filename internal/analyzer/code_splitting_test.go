@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// buildCodeSplittingTestGraph builds:
+//
+//	src/main.ts -> src/nav.ts -> src/format.ts
+//	src/main.ts -> src/admin.ts
+//	src/nav.ts --dynamic--> src/settings.ts -> src/format.ts, src/chart.ts
+//	src/nav.ts --dynamic--> src/admin.ts
+//	src/settings.ts ..type..> src/types.ts
+//	src/main.test.ts -> src/main.ts
+func buildCodeSplittingTestGraph() *domain.DependencyGraph {
+	graph := domain.NewDependencyGraph()
+	for _, id := range []string{
+		"src/main.ts", "src/nav.ts", "src/format.ts", "src/admin.ts",
+		"src/settings.ts", "src/chart.ts", "src/types.ts", "src/main.test.ts",
+	} {
+		graph.AddNode(&domain.ModuleNode{ID: id, FilePath: id})
+	}
+	addEdge := func(from, to string, edgeType domain.DependencyEdgeType, line int) {
+		graph.AddEdge(&domain.DependencyEdge{From: from, To: to, EdgeType: edgeType, Weight: 1,
+			Location: &domain.SourceLocation{StartLine: line}})
+	}
+	addEdge("src/main.ts", "src/nav.ts", domain.EdgeTypeImport, 1)
+	addEdge("src/main.ts", "src/admin.ts", domain.EdgeTypeImport, 2)
+	addEdge("src/nav.ts", "src/format.ts", domain.EdgeTypeImport, 1)
+	addEdge("src/nav.ts", "src/settings.ts", domain.EdgeTypeDynamic, 3)
+	addEdge("src/nav.ts", "src/admin.ts", domain.EdgeTypeDynamic, 4)
+	addEdge("src/settings.ts", "src/format.ts", domain.EdgeTypeImport, 1)
+	addEdge("src/settings.ts", "src/chart.ts", domain.EdgeTypeImport, 2)
+	addEdge("src/settings.ts", "src/types.ts", domain.EdgeTypeTypeOnly, 3)
+	addEdge("src/main.test.ts", "src/main.ts", domain.EdgeTypeImport, 1)
+	graph.UpdateNodeFlags()
+	return graph
+}
+
+var codeSplittingTestSizes = map[string]int64{
+	"src/main.ts": 100, "src/nav.ts": 200, "src/format.ts": 50, "src/admin.ts": 400,
+	"src/settings.ts": 300, "src/chart.ts": 5000, "src/types.ts": 70,
+}
+
+func TestCodeSplittingAnalyzerChunks(t *testing.T) {
+	result, err := NewCodeSplittingAnalyzer(buildCodeSplittingTestGraph(), codeSplittingTestSizes, domain.CodeSplittingOptions{}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	// The test file is not an entry point and type-only imports are not bundled
+	if len(result.Chunks) != 3 || result.EntryChunks() != 1 {
+		t.Fatalf("Expected 1 entry and 2 lazy chunks, got %+v", result.Chunks)
+	}
+	entry := result.Chunks[0]
+	if entry.Root != "src/main.ts" || entry.Kind != domain.ChunkEntry ||
+		strings.Join(entry.Modules, ",") != "src/admin.ts,src/format.ts,src/main.ts,src/nav.ts" || entry.Size != 750 {
+		t.Errorf("Unexpected entry chunk: %+v", entry)
+	}
+
+	settings := result.Chunks[1]
+	if settings.Root != "src/settings.ts" || settings.Kind != domain.ChunkLazy || settings.Size != 5350 ||
+		settings.OwnModules != 2 || settings.OwnSize != 5300 {
+		t.Errorf("Unexpected settings chunk: %+v", settings)
+	}
+	if len(settings.ImportedBy) != 1 || settings.ImportedBy[0].Importer != "src/nav.ts" || settings.ImportedBy[0].Line != 3 {
+		t.Errorf("Expected settings to be imported by nav.ts:3, got %+v", settings.ImportedBy)
+	}
+
+	admin := result.Chunks[2]
+	if admin.Root != "src/admin.ts" || admin.OwnModules != 0 || admin.OwnSize != 0 {
+		t.Errorf("Expected the admin chunk to defer nothing, got %+v", admin)
+	}
+}
+
+func TestCodeSplittingAnalyzerMixedModules(t *testing.T) {
+	result, err := NewCodeSplittingAnalyzer(buildCodeSplittingTestGraph(), codeSplittingTestSizes, domain.CodeSplittingOptions{}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(result.Mixed) != 2 || result.DefeatedSplits() != 1 {
+		t.Fatalf("Expected 2 mixed modules with 1 defeated split, got %+v", result.Mixed)
+	}
+	admin := result.Mixed[0]
+	if admin.Module != "src/admin.ts" || !admin.DefeatsSplit ||
+		strings.Join(admin.StaticPath, ",") != "src/main.ts,src/admin.ts" || admin.Size != 400 {
+		t.Errorf("Expected the admin.ts boundary to be defeated, got %+v", admin)
+	}
+	format := result.Mixed[1]
+	if format.Module != "src/format.ts" || format.DefeatsSplit ||
+		strings.Join(format.LazyChunks, ",") != "src/settings.ts" ||
+		strings.Join(format.StaticPath, ",") != "src/main.ts,src/nav.ts,src/format.ts" {
+		t.Errorf("Expected format.ts in the settings chunk and the entry chunk, got %+v", format)
+	}
+}
+
+func TestCodeSplittingAnalyzerEntries(t *testing.T) {
+	graph := buildCodeSplittingTestGraph()
+
+	// Starting from the settings page, admin.ts is neither loaded nor imported lazily
+	result, err := NewCodeSplittingAnalyzer(graph, nil, domain.CodeSplittingOptions{Entries: []string{"settings"}}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Chunks) != 1 || result.Chunks[0].Root != "src/settings.ts" || len(result.Mixed) != 0 {
+		t.Errorf("Expected only the settings entry chunk, got %+v", result)
+	}
+
+	_, err = NewCodeSplittingAnalyzer(graph, nil, domain.CodeSplittingOptions{Entries: []string{"src/missing.ts"}}).Analyze()
+	if err == nil {
+		t.Error("Expected an error for an entry matching no module")
+	}
+}
@@ -0,0 +1,403 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+const (
+	// typeOnlyBreakingCostFactor discounts value imports whose symbols are only used
+	// as types, which can be turned into `import type` without touching runtime code
+	typeOnlyBreakingCostFactor = 0.25
+
+	// maxBreakingSearchSteps bounds the exact branch-and-bound search. When it is
+	// exhausted the best set found so far (at worst the greedy one) is returned.
+	maxBreakingSearchSteps = 20000
+
+	// maxSuggestionSpecifiers is the number of imported symbols named in a suggestion
+	maxSuggestionSpecifiers = 3
+)
+
+// breakCandidate aggregates all imports from one module of an SCC to another
+type breakCandidate struct {
+	from       string
+	to         string
+	edgeType   domain.DependencyEdgeType
+	specifiers []string
+	typeOnly   bool // every import is already type-only
+	canBeType  bool // no import is needed at runtime, and at least one is a value import
+	cost       float64
+}
+
+// cycleBreaker computes a minimum-cost feedback arc set of a strongly connected component
+type cycleBreaker struct {
+	nodes      []string
+	candidates []breakCandidate
+	outgoing   map[string][]int // module -> candidate indices
+	removed    []bool
+}
+
+// recommendCycleBreaks returns the cheapest set of imports whose removal leaves the
+// SCC acyclic, along with its total cost. A weighted Eades-Lin-Smyth ordering gives
+// the initial answer, which a bounded branch-and-bound search then improves (exactly
+// for all but very large SCCs).
+func recommendCycleBreaks(scc []string, graph *domain.DependencyGraph) ([]domain.CycleBreakingEdge, float64) {
+	b := newCycleBreaker(scc, graph)
+	if len(b.candidates) == 0 {
+		return nil, 0
+	}
+
+	best := b.greedy()
+	bestCost := b.cost(best)
+	b.search(&best, &bestCost)
+
+	sort.Ints(best)
+	edges := make([]domain.CycleBreakingEdge, 0, len(best))
+	for _, idx := range best {
+		c := b.candidates[idx]
+		edges = append(edges, domain.CycleBreakingEdge{
+			From:          c.from,
+			To:            c.to,
+			EdgeType:      c.edgeType,
+			Specifiers:    c.specifiers,
+			TypeOnly:      c.typeOnly,
+			CanBeTypeOnly: c.canBeType,
+			Cost:          c.cost,
+			Suggestion:    breakingSuggestion(c),
+		})
+	}
+	return edges, bestCost
+}
+
+// newCycleBreaker collects the edges between modules of the SCC, merging parallel
+// imports of the same module
+func newCycleBreaker(scc []string, graph *domain.DependencyGraph) *cycleBreaker {
+	b := &cycleBreaker{
+		nodes:    append([]string(nil), scc...),
+		outgoing: make(map[string][]int),
+	}
+	sort.Strings(b.nodes)
+
+	sccSet := make(map[string]bool, len(scc))
+	for _, module := range scc {
+		sccSet[module] = true
+	}
+
+	for _, from := range b.nodes {
+		byTarget := make(map[string]int)
+		for _, edge := range graph.GetOutgoingEdges(from) {
+			if !sccSet[edge.To] {
+				continue
+			}
+			idx, exists := byTarget[edge.To]
+			if !exists {
+				idx = len(b.candidates)
+				byTarget[edge.To] = idx
+				b.candidates = append(b.candidates, breakCandidate{
+					from:      from,
+					to:        edge.To,
+					edgeType:  edge.EdgeType,
+					typeOnly:  true,
+					canBeType: true,
+				})
+			}
+			c := &b.candidates[idx]
+			if edgeTypeStrength(edge.EdgeType) > edgeTypeStrength(c.edgeType) {
+				c.edgeType = edge.EdgeType
+			}
+			if edge.EdgeType != domain.EdgeTypeTypeOnly {
+				c.typeOnly = false
+				if !edge.CanBeTypeOnly {
+					c.canBeType = false
+				}
+			}
+			c.specifiers = append(c.specifiers, edge.Specifiers...)
+			c.cost += float64(max(edge.Weight, 1))
+		}
+	}
+
+	// Order by source and target for deterministic results
+	sort.SliceStable(b.candidates, func(i, j int) bool {
+		if b.candidates[i].from != b.candidates[j].from {
+			return b.candidates[i].from < b.candidates[j].from
+		}
+		return b.candidates[i].to < b.candidates[j].to
+	})
+
+	for i := range b.candidates {
+		c := &b.candidates[i]
+		c.specifiers = uniqueSorted(c.specifiers)
+		c.canBeType = c.canBeType && !c.typeOnly
+		if c.canBeType {
+			c.cost *= typeOnlyBreakingCostFactor
+		}
+		b.outgoing[c.from] = append(b.outgoing[c.from], i)
+	}
+	b.removed = make([]bool, len(b.candidates))
+	return b
+}
+
+// greedy orders the modules with the weighted Eades-Lin-Smyth heuristic, takes the
+// backward edges as the feedback set and then drops the ones that are not needed
+func (b *cycleBreaker) greedy() []int {
+	remaining := make(map[string]bool, len(b.nodes))
+	incoming := make(map[string][]int)
+	inWeight := make(map[string]float64)
+	outWeight := make(map[string]float64)
+	for _, node := range b.nodes {
+		remaining[node] = true
+	}
+	for i, c := range b.candidates {
+		if c.from == c.to {
+			continue
+		}
+		incoming[c.to] = append(incoming[c.to], i)
+		outWeight[c.from] += c.cost
+		inWeight[c.to] += c.cost
+	}
+
+	remove := func(node string) {
+		delete(remaining, node)
+		for _, idx := range b.outgoing[node] {
+			if c := b.candidates[idx]; c.from != c.to && remaining[c.to] {
+				inWeight[c.to] -= c.cost
+			}
+		}
+		for _, idx := range incoming[node] {
+			if c := b.candidates[idx]; remaining[c.from] {
+				outWeight[c.from] -= c.cost
+			}
+		}
+	}
+
+	// Weights are sums of positive costs, so compare against a small epsilon
+	// rather than exact zero after repeated subtraction
+	const epsilon = 1e-9
+
+	var head, tail []string
+	for len(remaining) > 0 {
+		progress := true
+		for progress {
+			progress = false
+			for _, node := range b.nodes {
+				if !remaining[node] {
+					continue
+				}
+				switch {
+				case outWeight[node] < epsilon:
+					tail = append([]string{node}, tail...)
+				case inWeight[node] < epsilon:
+					head = append(head, node)
+				default:
+					continue
+				}
+				remove(node)
+				progress = true
+			}
+		}
+		if len(remaining) == 0 {
+			break
+		}
+
+		var pick string
+		bestDelta := 0.0
+		for _, node := range b.nodes {
+			if !remaining[node] {
+				continue
+			}
+			if delta := outWeight[node] - inWeight[node]; pick == "" || delta > bestDelta {
+				pick, bestDelta = node, delta
+			}
+		}
+		head = append(head, pick)
+		remove(pick)
+	}
+
+	position := make(map[string]int, len(b.nodes))
+	for i, node := range append(head, tail...) {
+		position[node] = i
+	}
+
+	var feedback []int
+	for i, c := range b.candidates {
+		if position[c.from] >= position[c.to] {
+			feedback = append(feedback, i)
+			b.removed[i] = true
+		}
+	}
+
+	// Restore the most expensive edges first when they do not close a cycle again
+	sort.SliceStable(feedback, func(i, j int) bool {
+		return b.candidates[feedback[i]].cost > b.candidates[feedback[j]].cost
+	})
+	var minimal []int
+	for _, idx := range feedback {
+		b.removed[idx] = false
+		if b.findCycle() != nil {
+			b.removed[idx] = true
+			minimal = append(minimal, idx)
+		}
+	}
+
+	for i := range b.removed {
+		b.removed[i] = false
+	}
+	return minimal
+}
+
+// search improves best with a branch-and-bound over the edges of the remaining cycles
+func (b *cycleBreaker) search(best *[]int, bestCost *float64) {
+	steps := 0
+	var current []int
+
+	var visit func(cost float64)
+	visit = func(cost float64) {
+		if steps >= maxBreakingSearchSteps {
+			return
+		}
+		steps++
+
+		cycle := b.findCycle()
+		if cycle == nil {
+			if cost < *bestCost || (cost == *bestCost && len(current) < len(*best)) {
+				*best = append([]int(nil), current...)
+				*bestCost = cost
+			}
+			return
+		}
+
+		// One edge of every cycle must go; try the cheapest first
+		sort.SliceStable(cycle, func(i, j int) bool {
+			return b.candidates[cycle[i]].cost < b.candidates[cycle[j]].cost
+		})
+		for _, idx := range cycle {
+			next := cost + b.candidates[idx].cost
+			if next > *bestCost || (next == *bestCost && len(current)+1 >= len(*best)) {
+				continue
+			}
+			b.removed[idx] = true
+			current = append(current, idx)
+			visit(next)
+			current = current[:len(current)-1]
+			b.removed[idx] = false
+		}
+	}
+	visit(0)
+}
+
+// findCycle returns the candidate indices of a cycle among the edges that are not
+// removed, or nil if none is left
+func (b *cycleBreaker) findCycle() []int {
+	const (
+		white = iota
+		grey
+		black
+	)
+	color := make(map[string]int, len(b.nodes))
+	var stack []int // candidate indices on the current DFS path
+
+	var dfs func(node string) []int
+	dfs = func(node string) []int {
+		color[node] = grey
+		for _, idx := range b.outgoing[node] {
+			if b.removed[idx] {
+				continue
+			}
+			next := b.candidates[idx].to
+			switch color[next] {
+			case grey:
+				// Unwind the path back to next to extract the cycle
+				cycle := []int{idx}
+				for i := len(stack) - 1; i >= 0; i-- {
+					if b.candidates[stack[i]].to == next {
+						break
+					}
+					cycle = append(cycle, stack[i])
+				}
+				return cycle
+			case white:
+				stack = append(stack, idx)
+				if cycle := dfs(next); cycle != nil {
+					return cycle
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+		color[node] = black
+		return nil
+	}
+
+	for _, node := range b.nodes {
+		if color[node] == white {
+			if cycle := dfs(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// cost returns the total cost of a set of candidates
+func (b *cycleBreaker) cost(indices []int) float64 {
+	total := 0.0
+	for _, idx := range indices {
+		total += b.candidates[idx].cost
+	}
+	return total
+}
+
+// edgeTypeStrength ranks edge types by how hard the dependency is to remove
+func edgeTypeStrength(edgeType domain.DependencyEdgeType) int {
+	switch edgeType {
+	case domain.EdgeTypeTypeOnly:
+		return 0
+	case domain.EdgeTypeDynamic:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// breakingSuggestion describes how to remove a candidate edge
+func breakingSuggestion(c breakCandidate) string {
+	from := getModuleBaseName(c.from)
+	to := getModuleBaseName(c.to)
+
+	symbols := ""
+	if len(c.specifiers) > 0 {
+		names := c.specifiers
+		if len(names) > maxSuggestionSpecifiers {
+			names = append(append([]string(nil), names[:maxSuggestionSpecifiers]...), "...")
+		}
+		symbols = fmt.Sprintf(" (%s)", strings.Join(names, ", "))
+	}
+
+	switch {
+	case c.canBeType:
+		return fmt.Sprintf("Use `import type` for the import of '%s' in '%s'%s, its symbols are only used as types",
+			to, from, symbols)
+	case c.typeOnly:
+		return fmt.Sprintf("The type-only import of '%s' in '%s'%s is erased at runtime, type-level cycle only; move the types to a shared module to remove it",
+			to, from, symbols)
+	}
+	return fmt.Sprintf("Remove or invert the import of '%s' in '%s'%s", to, from, symbols)
+}
+
+// uniqueSorted returns the sorted distinct values of a slice
+func uniqueSorted(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	result := sorted[:1]
+	for _, v := range sorted[1:] {
+		if v != result[len(result)-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
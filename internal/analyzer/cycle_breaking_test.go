@@ -0,0 +1,210 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func buildCycleGraph(edges ...*domain.DependencyEdge) *domain.DependencyGraph {
+	graph := domain.NewDependencyGraph()
+	for _, edge := range edges {
+		for _, id := range []string{edge.From, edge.To} {
+			if graph.GetNode(id) == nil {
+				graph.AddNode(&domain.ModuleNode{ID: id})
+			}
+		}
+		graph.AddEdge(edge)
+	}
+	return graph
+}
+
+func breakingPairs(edges []domain.CycleBreakingEdge) []string {
+	var pairs []string
+	for _, edge := range edges {
+		pairs = append(pairs, edge.From+"->"+edge.To)
+	}
+	return pairs
+}
+
+func TestRecommendCycleBreaksSharedEdge(t *testing.T) {
+	// a -> b is part of both a -> b -> a and a -> b -> c -> a
+	graph := buildCycleGraph(
+		&domain.DependencyEdge{From: "a", To: "b", Weight: 1},
+		&domain.DependencyEdge{From: "b", To: "a", Weight: 1},
+		&domain.DependencyEdge{From: "b", To: "c", Weight: 1},
+		&domain.DependencyEdge{From: "c", To: "a", Weight: 1},
+	)
+
+	edges, cost := recommendCycleBreaks([]string{"a", "b", "c"}, graph)
+	if got := breakingPairs(edges); len(got) != 1 || got[0] != "a->b" {
+		t.Errorf("Expected [a->b], got %v", got)
+	}
+	if cost != 1 {
+		t.Errorf("Expected cost 1, got %v", cost)
+	}
+}
+
+func TestRecommendCycleBreaksWeighting(t *testing.T) {
+	tests := []struct {
+		name      string
+		edges     []*domain.DependencyEdge
+		expected  string
+		typeOnly  bool
+		canBeType bool
+		cost      float64
+	}{
+		{
+			name: "fewer imported symbols",
+			edges: []*domain.DependencyEdge{
+				{From: "a", To: "b", Specifiers: []string{"x", "y", "z"}, Weight: 3},
+				{From: "b", To: "a", Specifiers: []string{"w"}, Weight: 1},
+			},
+			expected: "b->a",
+			cost:     1,
+		},
+		{
+			name: "imports used only as types preferred",
+			edges: []*domain.DependencyEdge{
+				{From: "a", To: "b", Specifiers: []string{"x"}, Weight: 1},
+				{From: "b", To: "a", Specifiers: []string{"T", "U"}, CanBeTypeOnly: true, Weight: 2},
+			},
+			expected:  "b->a",
+			canBeType: true,
+			cost:      0.5,
+		},
+		{
+			name: "existing type-only imports are not discounted",
+			edges: []*domain.DependencyEdge{
+				{From: "a", To: "b", Specifiers: []string{"x"}, Weight: 1},
+				{From: "b", To: "a", EdgeType: domain.EdgeTypeTypeOnly, Specifiers: []string{"T", "U"}, Weight: 2},
+			},
+			expected: "a->b",
+			cost:     1,
+		},
+		{
+			name: "type-only import in a type-level cycle",
+			edges: []*domain.DependencyEdge{
+				{From: "a", To: "b", Specifiers: []string{"x", "y"}, Weight: 2},
+				{From: "b", To: "a", EdgeType: domain.EdgeTypeTypeOnly, Specifiers: []string{"T"}, Weight: 1},
+			},
+			expected: "b->a",
+			typeOnly: true,
+			cost:     1,
+		},
+		{
+			name: "parallel imports are merged",
+			edges: []*domain.DependencyEdge{
+				{From: "a", To: "b", EdgeType: domain.EdgeTypeTypeOnly, Specifiers: []string{"T"}, Weight: 1},
+				{From: "a", To: "b", EdgeType: domain.EdgeTypeImport, Specifiers: []string{"x"}, Weight: 1},
+				{From: "b", To: "a", Specifiers: []string{"y", "z", "w"}, Weight: 3},
+			},
+			expected: "a->b",
+			cost:     2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			edges, cost := recommendCycleBreaks([]string{"a", "b"}, buildCycleGraph(tc.edges...))
+			if got := breakingPairs(edges); len(got) != 1 || got[0] != tc.expected {
+				t.Fatalf("Expected [%s], got %v", tc.expected, got)
+			}
+			if edges[0].TypeOnly != tc.typeOnly || edges[0].CanBeTypeOnly != tc.canBeType {
+				t.Errorf("Expected TypeOnly=%v CanBeTypeOnly=%v", tc.typeOnly, tc.canBeType)
+			}
+			if cost != tc.cost || edges[0].Cost != tc.cost {
+				t.Errorf("Expected cost %v, got %v (edge %v)", tc.cost, cost, edges[0].Cost)
+			}
+			if tc.canBeType != strings.Contains(edges[0].Suggestion, "import type") {
+				t.Errorf("Unexpected suggestion %q", edges[0].Suggestion)
+			}
+			if tc.typeOnly && !strings.Contains(edges[0].Suggestion, "erased at runtime, type-level cycle only") {
+				t.Errorf("Expected a type-level cycle suggestion, got %q", edges[0].Suggestion)
+			}
+		})
+	}
+}
+
+func TestRecommendCycleBreaksMergedEdgeType(t *testing.T) {
+	graph := buildCycleGraph(
+		&domain.DependencyEdge{From: "a", To: "b", EdgeType: domain.EdgeTypeTypeOnly, Specifiers: []string{"T"}, Weight: 1},
+		&domain.DependencyEdge{From: "a", To: "b", EdgeType: domain.EdgeTypeImport, Specifiers: []string{"x", "T"}, Weight: 2},
+		&domain.DependencyEdge{From: "b", To: "a", Weight: 10},
+	)
+
+	edges, _ := recommendCycleBreaks([]string{"a", "b"}, graph)
+	if len(edges) != 1 {
+		t.Fatalf("Expected 1 edge, got %d", len(edges))
+	}
+	if edges[0].EdgeType != domain.EdgeTypeImport || edges[0].TypeOnly || edges[0].CanBeTypeOnly {
+		t.Errorf("Expected a runtime import, got %s (type-only=%v)", edges[0].EdgeType, edges[0].TypeOnly)
+	}
+	if strings.Join(edges[0].Specifiers, ",") != "T,x" {
+		t.Errorf("Expected merged specifiers [T x], got %v", edges[0].Specifiers)
+	}
+}
+
+func TestRecommendCycleBreaksCompleteGraph(t *testing.T) {
+	// Every pair of modules imports each other: the minimum feedback arc set of a
+	// complete digraph on n nodes has n(n-1)/2 edges
+	var edges []*domain.DependencyEdge
+	var modules []string
+	const n = 5
+	for i := 0; i < n; i++ {
+		modules = append(modules, fmt.Sprintf("m%d", i))
+	}
+	for _, from := range modules {
+		for _, to := range modules {
+			if from != to {
+				edges = append(edges, &domain.DependencyEdge{From: from, To: to, Weight: 1})
+			}
+		}
+	}
+	graph := buildCycleGraph(edges...)
+
+	breaking, cost := recommendCycleBreaks(modules, graph)
+	if len(breaking) != n*(n-1)/2 || cost != n*(n-1)/2 {
+		t.Errorf("Expected %d edges, got %d (cost %v)", n*(n-1)/2, len(breaking), cost)
+	}
+
+	// Removing the recommended edges must leave no cycle
+	removed := make(map[[2]string]bool)
+	for _, edge := range breaking {
+		removed[[2]string{edge.From, edge.To}] = true
+	}
+	var remaining []*domain.DependencyEdge
+	for _, edge := range edges {
+		if !removed[[2]string{edge.From, edge.To}] {
+			remaining = append(remaining, edge)
+		}
+	}
+	if NewCircularDependencyDetector().DetectCycles(buildCycleGraph(remaining...)).HasCircularDependencies {
+		t.Error("Expected the recommendation to break all cycles")
+	}
+}
+
+func TestDetectCyclesBreakingRecommendation(t *testing.T) {
+	graph := buildCycleGraph(
+		&domain.DependencyEdge{From: "src/a.ts", To: "src/b.ts", Specifiers: []string{"x", "y"}, Weight: 2},
+		&domain.DependencyEdge{From: "src/b.ts", To: "src/a.ts", Specifiers: []string{"z"}, Weight: 1},
+	)
+
+	result := NewCircularDependencyDetector().DetectCycles(graph)
+	if result.TotalCycles != 1 {
+		t.Fatalf("Expected 1 cycle, got %d", result.TotalCycles)
+	}
+	cycle := result.CircularDependencies[0]
+	if got := breakingPairs(cycle.BreakingEdges); len(got) != 1 || got[0] != "src/b.ts->src/a.ts" {
+		t.Errorf("Expected [src/b.ts->src/a.ts], got %v", got)
+	}
+	if cycle.BreakingCost != 1 {
+		t.Errorf("Expected breaking cost 1, got %v", cycle.BreakingCost)
+	}
+	if len(result.CycleBreakingSuggestions) != 1 ||
+		result.CycleBreakingSuggestions[0] != "Remove or invert the import of 'a.ts' in 'b.ts' (z)" {
+		t.Errorf("Unexpected suggestions %v", result.CycleBreakingSuggestions)
+	}
+}
@@ -55,6 +55,15 @@ const (
 
 	// ReasonUnusedExportedFunction indicates an exported function/class that is not imported by any other file
 	ReasonUnusedExportedFunction DeadCodeReason = "unused_exported_function"
+
+	// ReasonUnusedExportedType indicates an exported type, interface or enum that is not imported by any other file
+	ReasonUnusedExportedType DeadCodeReason = "unused_exported_type"
+
+	// ReasonUnusedType indicates a non-exported type, interface or enum that is never referenced
+	ReasonUnusedType DeadCodeReason = "unused_type"
+
+	// ReasonUnusedEnumMember indicates an enum member that is never read
+	ReasonUnusedEnumMember DeadCodeReason = "unused_enum_member"
 )
 
 // DeadCodeFinding represents a single dead code detection result
@@ -238,6 +247,9 @@ func (dcd *DeadCodeDetector) generateDescription(reason DeadCodeReason) string {
 		ReasonUnusedExport:                 "Exported name is not imported by any other analyzed file",
 		ReasonOrphanFile:                   "File is not imported by any other analyzed file",
 		ReasonUnusedExportedFunction:       "Exported function is not imported by any other analyzed file",
+		ReasonUnusedExportedType:           "Exported type is not imported by any other analyzed file",
+		ReasonUnusedType:                   "Type is never referenced in this file",
+		ReasonUnusedEnumMember:             "Enum member is never read",
 	}
 
 	if desc, exists := descriptions[reason]; exists {
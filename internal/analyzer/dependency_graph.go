@@ -96,6 +96,22 @@ func (b *DependencyGraphBuilder) BuildGraph(moduleResult *domain.ModuleAnalysisR
 				graph.AddEdge(edge)
 			}
 		}
+
+		// Re-exports depend on their source like imports do
+		for _, exp := range moduleInfo.Exports {
+			if exp.Source == "" || (exp.IsTypeOnly && !b.config.IncludeTypeImports) {
+				continue
+			}
+			if b.isExternalModule(exp.Source, exp.SourceType) && !b.config.IncludeExternal {
+				continue
+			}
+
+			edge := b.createReExportEdge(fromID, exp, filePath, knownNodeIDs)
+			if graph.GetNode(edge.To) == nil {
+				graph.AddNode(b.createExternalNode(edge.To, exp.Source, exp.SourceType))
+			}
+			graph.AddEdge(edge)
+		}
 	}
 
 	// Update node flags (IsEntryPoint, IsLeaf)
@@ -104,9 +120,14 @@ func (b *DependencyGraphBuilder) BuildGraph(moduleResult *domain.ModuleAnalysisR
 	return graph
 }
 
+// AnalyzeModules runs the module analysis the graph is built from
+func (b *DependencyGraphBuilder) AnalyzeModules(asts map[string]*parser.Node) (*domain.ModuleAnalysisResult, error) {
+	return b.moduleAnalyzer.AnalyzeAll(asts)
+}
+
 // BuildGraphFromASTs constructs a DependencyGraph directly from parsed ASTs
 func (b *DependencyGraphBuilder) BuildGraphFromASTs(asts map[string]*parser.Node) (*domain.DependencyGraph, error) {
-	moduleResult, err := b.moduleAnalyzer.AnalyzeAll(asts)
+	moduleResult, err := b.AnalyzeModules(asts)
 	if err != nil {
 		return nil, err
 	}
@@ -194,12 +215,40 @@ func (b *DependencyGraphBuilder) createDependencyEdge(fromID string, imp *domain
 		weight = 1
 	}
 
+	return &domain.DependencyEdge{
+		From:          fromID,
+		To:            toID,
+		EdgeType:      edgeType,
+		Specifiers:    specifiers,
+		CanBeTypeOnly: imp.CanBeTypeOnly,
+		Location:      &imp.Location,
+		Weight:        weight,
+	}
+}
+
+// createReExportEdge creates a DependencyEdge from a re-export (export ... from);
+// type-only re-exports get a type-only edge
+func (b *DependencyGraphBuilder) createReExportEdge(fromID string, exp *domain.Export, fromFilePath string, knownNodeIDs map[string]bool) *domain.DependencyEdge {
+	edgeType := domain.EdgeTypeReExport
+	if exp.IsTypeOnly {
+		edgeType = domain.EdgeTypeTypeOnly
+	}
+
+	var specifiers []string
+	for _, spec := range exp.Specifiers {
+		specifiers = append(specifiers, spec.Exported)
+	}
+	weight := len(specifiers)
+	if weight == 0 {
+		weight = 1
+	}
+
 	return &domain.DependencyEdge{
 		From:       fromID,
-		To:         toID,
+		To:         b.resolveImportTarget(exp.Source, exp.SourceType, fromFilePath, knownNodeIDs),
 		EdgeType:   edgeType,
 		Specifiers: specifiers,
-		Location:   &imp.Location,
+		Location:   &exp.Location,
 		Weight:     weight,
 	}
 }
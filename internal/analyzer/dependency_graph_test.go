@@ -588,3 +588,39 @@ export const app = 1;
 		t.Error("Expected at least one node")
 	}
 }
+
+func TestBuildGraphWithReExports(t *testing.T) {
+	moduleResult := &domain.ModuleAnalysisResult{
+		Files: map[string]*domain.ModuleInfo{
+			"/project/index.ts": {
+				Exports: []*domain.Export{
+					{ExportType: "all", Source: "./button", SourceType: domain.ModuleTypeRelative},
+					{
+						ExportType: "named", Source: "./theme", SourceType: domain.ModuleTypeRelative, IsTypeOnly: true,
+						Specifiers: []domain.ExportSpecifier{{Local: "Theme", Exported: "Theme", IsType: true}},
+					},
+				},
+			},
+			"/project/button.ts": {},
+			"/project/theme.ts":  {},
+		},
+	}
+
+	config := DefaultDependencyGraphBuilderConfig()
+	config.ProjectRoot = "/project"
+	graph := NewDependencyGraphBuilder(config).BuildGraph(moduleResult)
+
+	types := make(map[string]domain.DependencyEdgeType)
+	for _, edge := range graph.GetOutgoingEdges("index.ts") {
+		types[edge.To] = edge.EdgeType
+	}
+	if types["button.ts"] != domain.EdgeTypeReExport || types["theme.ts"] != domain.EdgeTypeTypeOnly {
+		t.Errorf("Expected a re-export edge to button.ts and a type-only edge to theme.ts, got %v", types)
+	}
+
+	config.IncludeTypeImports = false
+	graph = NewDependencyGraphBuilder(config).BuildGraph(moduleResult)
+	if len(graph.GetOutgoingEdges("index.ts")) != 1 {
+		t.Errorf("Expected the type-only re-export to be skipped, got %d edges", len(graph.GetOutgoingEdges("index.ts")))
+	}
+}
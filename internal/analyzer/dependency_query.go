@@ -0,0 +1,411 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// maxQueryVisits bounds the DFS steps spent enumerating paths for a why query
+const maxQueryVisits = 100000
+
+// DependencyQueryEngine evaluates queries on a dependency graph
+type DependencyQueryEngine struct {
+	graph *domain.DependencyGraph
+}
+
+// NewDependencyQueryEngine creates a new DependencyQueryEngine for the given graph
+func NewDependencyQueryEngine(graph *domain.DependencyGraph) *DependencyQueryEngine {
+	if graph == nil {
+		graph = domain.NewDependencyGraph()
+	}
+	return &DependencyQueryEngine{graph: graph}
+}
+
+// Evaluate runs the query and returns the subgraph answering it
+func (e *DependencyQueryEngine) Evaluate(query domain.DependencyQuery) (*domain.DependencyQueryResult, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if query.MaxPaths == 0 {
+		query.MaxPaths = domain.DefaultMaxQueryPaths
+	}
+
+	sources, err := e.resolve(query.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.DependencyQueryResult{
+		Query:   query,
+		Sources: sources,
+	}
+
+	var modules map[string]bool
+	var edges []*domain.DependencyEdge
+
+	// Traversals include the sources; why and between only include the
+	// modules taking part in a dependency
+	switch query.Kind {
+	case domain.QueryDependentsOf:
+		modules, edges = e.traverse(sources, query.Transitive, true)
+
+	case domain.QueryDependenciesOf:
+		modules, edges = e.traverse(sources, query.Transitive, false)
+
+	case domain.QueryWhy, domain.QueryBetween:
+		targets, err := e.resolve(query.Target)
+		if err != nil {
+			return nil, err
+		}
+		result.Targets = targets
+
+		if query.Kind == domain.QueryWhy {
+			result.Paths, result.Truncated = e.findPaths(sources, targets, query.MaxPaths)
+			modules, edges = e.pathSubgraph(result.Paths)
+		} else {
+			modules, edges = e.between(sources, targets, query.Transitive)
+		}
+	}
+
+	// Queries matching no edge report an empty list rather than null in JSON
+	if edges == nil {
+		edges = []*domain.DependencyEdge{}
+	}
+	result.Modules = sortedKeys(modules)
+	sortEdges(edges)
+	result.Edges = edges
+	return result, nil
+}
+
+// ResolveModules returns the IDs of the modules matched by a pattern. A pattern
+// matches a module ID exactly, as a path suffix ("utils/date.ts" matches
+// "src/utils/date.ts"), without its extension, or as a glob with "**" support.
+func (e *DependencyQueryEngine) ResolveModules(pattern string) []string {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	isGlob := strings.ContainsAny(pattern, "*?[")
+
+	var matches []string
+	for id := range e.graph.Nodes {
+		if isGlob {
+			if matchModuleGlob(pattern, id) {
+				matches = append(matches, id)
+			}
+		} else if matchModulePath(pattern, id) {
+			matches = append(matches, id)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// resolve resolves a pattern and reports an error when it matches no module
+func (e *DependencyQueryEngine) resolve(pattern string) ([]string, error) {
+	matches := e.ResolveModules(pattern)
+	if len(matches) == 0 {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("no module matches %q", pattern), nil)
+	}
+	return matches, nil
+}
+
+// traverse collects the dependents (reverse) or dependencies of the start modules,
+// following only direct edges unless transitive is set
+func (e *DependencyQueryEngine) traverse(start []string, transitive, reverse bool) (map[string]bool, []*domain.DependencyEdge) {
+	visited := make(map[string]bool)
+	var edges []*domain.DependencyEdge
+
+	queue := append([]string{}, start...)
+	for _, id := range start {
+		visited[id] = true
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		var next []*domain.DependencyEdge
+		if reverse {
+			next = e.graph.GetIncomingEdges(current)
+		} else {
+			next = e.graph.GetOutgoingEdges(current)
+		}
+
+		for _, edge := range next {
+			edges = append(edges, edge)
+			neighbor := edge.To
+			if reverse {
+				neighbor = edge.From
+			}
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+			if transitive {
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return visited, edges
+}
+
+// findPaths enumerates simple paths from any source to any target, shortest first:
+// paths are searched with an increasing length bound, so that stopping at maxPaths
+// keeps the shortest ones. Only modules that can reach a target within the bound are
+// explored. Returns the paths and whether enumeration stopped early.
+func (e *DependencyQueryEngine) findPaths(sources, targets []string, maxPaths int) ([][]string, bool) {
+	targetSet := make(map[string]bool, len(targets))
+	for _, id := range targets {
+		targetSet[id] = true
+	}
+	distance := e.distancesTo(targets)
+
+	var paths [][]string
+	truncated := false
+	visits := 0
+	onPath := make(map[string]bool)
+	var current []string
+
+	// dfs records the paths of exactly bound imports; deeper is set when a longer
+	// path may exist
+	var bound int
+	var deeper bool
+	var dfs func(id string)
+	dfs = func(id string) {
+		if truncated {
+			return
+		}
+		visits++
+		if visits > maxQueryVisits {
+			truncated = true
+			return
+		}
+
+		current = append(current, id)
+		onPath[id] = true
+		defer func() {
+			current = current[:len(current)-1]
+			onPath[id] = false
+		}()
+
+		if len(current) > 1 && targetSet[id] {
+			// Shorter paths were recorded with a smaller bound
+			if len(current)-1 < bound {
+				return
+			}
+			if len(paths) >= maxPaths {
+				truncated = true
+				return
+			}
+			paths = append(paths, append([]string{}, current...))
+			return
+		}
+
+		for _, next := range e.successors(id) {
+			d, ok := distance[next]
+			if onPath[next] || !ok {
+				continue
+			}
+			if len(current)+d > bound {
+				deeper = true
+				continue
+			}
+			dfs(next)
+		}
+	}
+
+	for bound = 1; bound < len(distance) && !truncated; bound++ {
+		found := len(paths)
+		deeper = false
+		for _, source := range sources {
+			if !targetSet[source] {
+				dfs(source)
+			}
+		}
+		if len(paths) == found && !deeper {
+			break
+		}
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) < len(paths[j])
+		}
+		return strings.Join(paths[i], "\x00") < strings.Join(paths[j], "\x00")
+	})
+
+	return paths, truncated
+}
+
+// distancesTo returns the number of imports from each module to the nearest target,
+// for the modules that can reach one
+func (e *DependencyQueryEngine) distancesTo(targets []string) map[string]int {
+	distance := make(map[string]int, len(targets))
+	queue := append([]string{}, targets...)
+	for _, id := range targets {
+		distance[id] = 0
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range e.graph.GetIncomingEdges(current) {
+			if _, seen := distance[edge.From]; !seen {
+				distance[edge.From] = distance[current] + 1
+				queue = append(queue, edge.From)
+			}
+		}
+	}
+	return distance
+}
+
+// successors returns the distinct modules imported by a module in sorted order
+func (e *DependencyQueryEngine) successors(id string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, edge := range e.graph.GetOutgoingEdges(id) {
+		if !seen[edge.To] {
+			seen[edge.To] = true
+			result = append(result, edge.To)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// pathSubgraph returns the modules and edges along the given paths
+func (e *DependencyQueryEngine) pathSubgraph(paths [][]string) (map[string]bool, []*domain.DependencyEdge) {
+	modules := make(map[string]bool)
+	steps := make(map[[2]string]bool)
+	for _, p := range paths {
+		for i, id := range p {
+			modules[id] = true
+			if i > 0 {
+				steps[[2]string{p[i-1], id}] = true
+			}
+		}
+	}
+
+	var edges []*domain.DependencyEdge
+	for id := range modules {
+		for _, edge := range e.graph.GetOutgoingEdges(id) {
+			if steps[[2]string{edge.From, edge.To}] {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return modules, edges
+}
+
+// between returns the direct edges from sources to targets, or with transitive set,
+// every module and edge on some path from a source to a target
+func (e *DependencyQueryEngine) between(sources, targets []string, transitive bool) (map[string]bool, []*domain.DependencyEdge) {
+	modules := make(map[string]bool)
+	var edges []*domain.DependencyEdge
+
+	if !transitive {
+		targetSet := make(map[string]bool, len(targets))
+		for _, id := range targets {
+			targetSet[id] = true
+		}
+		for _, id := range sources {
+			for _, edge := range e.graph.GetOutgoingEdges(id) {
+				if targetSet[edge.To] {
+					edges = append(edges, edge)
+					modules[edge.From] = true
+					modules[edge.To] = true
+				}
+			}
+		}
+		return modules, edges
+	}
+
+	reachable, _ := e.traverse(sources, true, false)
+	canReach, _ := e.traverse(targets, true, true)
+	for id := range reachable {
+		if canReach[id] {
+			modules[id] = true
+		}
+	}
+	for id := range modules {
+		for _, edge := range e.graph.GetOutgoingEdges(id) {
+			if modules[edge.To] {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return modules, edges
+}
+
+// matchModulePath reports whether a plain pattern names the module, exactly or as a
+// path suffix, with or without the file extension
+func matchModulePath(pattern, id string) bool {
+	pattern = path.Clean(pattern)
+	for _, candidate := range []string{id, strings.TrimSuffix(id, path.Ext(id))} {
+		if candidate == pattern || strings.HasSuffix(candidate, "/"+pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchModuleGlob reports whether a glob matches the module ID or one of its trailing
+// path suffixes, so that "src/ui/**" also matches "app/src/ui/button.tsx"
+func matchModuleGlob(pattern, id string) bool {
+	patternParts := strings.Split(pattern, "/")
+	idParts := strings.Split(id, "/")
+	for i := range idParts {
+		if matchGlobSegments(patternParts, idParts[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlobSegments matches path segments, where a "**" segment matches any number of segments
+func matchGlobSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchGlobSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+		return false
+	}
+	return matchGlobSegments(pattern[1:], name[1:])
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortEdges sorts edges by source and target for deterministic output
+func sortEdges(edges []*domain.DependencyEdge) {
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].EdgeType < edges[j].EdgeType
+	})
+}
@@ -0,0 +1,271 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// buildQueryTestGraph builds:
+//
+//	src/ui/app.ts -> src/core/service.ts -> src/db/client.ts
+//	src/ui/app.ts -> src/core/format.ts -> src/core/service.ts
+//	src/ui/view.ts -> src/db/client.ts
+//	src/core/service.ts -> src/db/types.ts (type only)
+func buildQueryTestGraph() *domain.DependencyGraph {
+	graph := domain.NewDependencyGraph()
+	for _, id := range []string{
+		"src/ui/app.ts", "src/ui/view.ts", "src/core/service.ts",
+		"src/core/format.ts", "src/db/client.ts", "src/db/types.ts",
+	} {
+		graph.AddNode(&domain.ModuleNode{ID: id})
+	}
+	addEdge := func(from, to string, edgeType domain.DependencyEdgeType) {
+		graph.AddEdge(&domain.DependencyEdge{From: from, To: to, EdgeType: edgeType, Weight: 1})
+	}
+	addEdge("src/ui/app.ts", "src/core/service.ts", domain.EdgeTypeImport)
+	addEdge("src/ui/app.ts", "src/core/format.ts", domain.EdgeTypeImport)
+	addEdge("src/core/format.ts", "src/core/service.ts", domain.EdgeTypeImport)
+	addEdge("src/core/service.ts", "src/db/client.ts", domain.EdgeTypeImport)
+	addEdge("src/core/service.ts", "src/db/types.ts", domain.EdgeTypeTypeOnly)
+	addEdge("src/ui/view.ts", "src/db/client.ts", domain.EdgeTypeImport)
+	return graph
+}
+
+func TestResolveModules(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"src/db/client.ts", []string{"src/db/client.ts"}},
+		{"./src/db/client.ts", []string{"src/db/client.ts"}},
+		{"db/client.ts", []string{"src/db/client.ts"}},
+		{"db/client", []string{"src/db/client.ts"}},
+		{"client.ts", []string{"src/db/client.ts"}},
+		{"b/client.ts", nil},
+		{"src/ui/**", []string{"src/ui/app.ts", "src/ui/view.ts"}},
+		{"ui/*.ts", []string{"src/ui/app.ts", "src/ui/view.ts"}},
+		{"**/service.ts", []string{"src/core/service.ts"}},
+		{"src/**/t*.ts", []string{"src/db/types.ts"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern, func(t *testing.T) {
+			got := engine.ResolveModules(tc.pattern)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("ResolveModules(%q) = %v, want %v", tc.pattern, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestQueryWhyFindsAllPaths(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	result, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:   domain.QueryWhy,
+		Source: "ui/app.ts",
+		Target: "db/client.ts",
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	expectedPaths := [][]string{
+		{"src/ui/app.ts", "src/core/service.ts", "src/db/client.ts"},
+		{"src/ui/app.ts", "src/core/format.ts", "src/core/service.ts", "src/db/client.ts"},
+	}
+	if !reflect.DeepEqual(result.Paths, expectedPaths) {
+		t.Errorf("Paths = %v, want %v", result.Paths, expectedPaths)
+	}
+	if result.Truncated {
+		t.Error("Expected paths not to be truncated")
+	}
+	if len(result.Edges) != 4 {
+		t.Errorf("Expected 4 edges on the paths, got %d", len(result.Edges))
+	}
+	for _, mod := range result.Modules {
+		if mod == "src/ui/view.ts" || mod == "src/db/types.ts" {
+			t.Errorf("Module %s is not on any path", mod)
+		}
+	}
+}
+
+func TestQueryWhyMaxPaths(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	result, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:     domain.QueryWhy,
+		Source:   "src/ui/app.ts",
+		Target:   "src/db/client.ts",
+		MaxPaths: 1,
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if len(result.Paths) != 1 || !result.Truncated {
+		t.Fatalf("Expected 1 truncated path, got %d (truncated=%v)", len(result.Paths), result.Truncated)
+	}
+	// The longer path through src/core/format.ts comes first in depth-first order,
+	// but the shortest one is kept
+	expected := []string{"src/ui/app.ts", "src/core/service.ts", "src/db/client.ts"}
+	if !reflect.DeepEqual(result.Paths[0], expected) {
+		t.Errorf("Path = %v, want %v", result.Paths[0], expected)
+	}
+}
+
+func TestQueryWhyNoPath(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	result, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:   domain.QueryWhy,
+		Source: "src/db/client.ts",
+		Target: "src/ui/app.ts",
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !result.IsEmpty() || len(result.Paths) != 0 {
+		t.Errorf("Expected no paths, got %v", result.Paths)
+	}
+	if result.Edges == nil {
+		t.Error("Expected an empty edge list rather than nil")
+	}
+}
+
+func TestQueryDependentsOf(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	direct, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:   domain.QueryDependentsOf,
+		Source: "src/core/service.ts",
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	expected := []string{"src/core/format.ts", "src/core/service.ts", "src/ui/app.ts"}
+	if !reflect.DeepEqual(direct.Modules, expected) {
+		t.Errorf("Direct dependents = %v, want %v", direct.Modules, expected)
+	}
+
+	transitive, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:       domain.QueryDependentsOf,
+		Source:     "src/db/client.ts",
+		Transitive: true,
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	expected = []string{"src/core/format.ts", "src/core/service.ts", "src/db/client.ts", "src/ui/app.ts", "src/ui/view.ts"}
+	if !reflect.DeepEqual(transitive.Modules, expected) {
+		t.Errorf("Transitive dependents = %v, want %v", transitive.Modules, expected)
+	}
+}
+
+func TestQueryDependenciesOf(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	direct, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:   domain.QueryDependenciesOf,
+		Source: "src/ui/app.ts",
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if len(direct.Modules) != 3 || len(direct.Edges) != 2 {
+		t.Errorf("Expected 3 modules and 2 edges, got %v and %d edges", direct.Modules, len(direct.Edges))
+	}
+
+	transitive, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:       domain.QueryDependenciesOf,
+		Source:     "src/ui/app.ts",
+		Transitive: true,
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	expected := []string{"src/core/format.ts", "src/core/service.ts", "src/db/client.ts", "src/db/types.ts", "src/ui/app.ts"}
+	if !reflect.DeepEqual(transitive.Modules, expected) {
+		t.Errorf("Transitive dependencies = %v, want %v", transitive.Modules, expected)
+	}
+}
+
+func TestQueryBetween(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	direct, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:   domain.QueryBetween,
+		Source: "src/ui/**",
+		Target: "src/db/**",
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if len(direct.Edges) != 1 || direct.Edges[0].From != "src/ui/view.ts" {
+		t.Errorf("Expected only the view -> client edge, got %d edges", len(direct.Edges))
+	}
+	if !reflect.DeepEqual(direct.Modules, []string{"src/db/client.ts", "src/ui/view.ts"}) {
+		t.Errorf("Unexpected modules %v", direct.Modules)
+	}
+
+	transitive, err := engine.Evaluate(domain.DependencyQuery{
+		Kind:       domain.QueryBetween,
+		Source:     "src/ui/**",
+		Target:     "src/db/**",
+		Transitive: true,
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if len(transitive.Modules) != 6 || len(transitive.Edges) != 6 {
+		t.Errorf("Expected all 6 modules and edges, got %d modules and %d edges",
+			len(transitive.Modules), len(transitive.Edges))
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	engine := NewDependencyQueryEngine(buildQueryTestGraph())
+
+	tests := []struct {
+		name  string
+		query domain.DependencyQuery
+	}{
+		{"unknown module", domain.DependencyQuery{Kind: domain.QueryDependentsOf, Source: "src/missing.ts"}},
+		{"unknown target", domain.DependencyQuery{Kind: domain.QueryWhy, Source: "src/ui/app.ts", Target: "src/missing.ts"}},
+		{"missing target", domain.DependencyQuery{Kind: domain.QueryBetween, Source: "src/ui/**"}},
+		{"unknown kind", domain.DependencyQuery{Kind: "impact", Source: "src/ui/app.ts"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := engine.Evaluate(tc.query); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestQueryWhyWithCycle(t *testing.T) {
+	graph := domain.NewDependencyGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		graph.AddNode(&domain.ModuleNode{ID: id})
+	}
+	graph.AddEdge(&domain.DependencyEdge{From: "a", To: "b"})
+	graph.AddEdge(&domain.DependencyEdge{From: "b", To: "a"})
+	graph.AddEdge(&domain.DependencyEdge{From: "b", To: "c"})
+
+	result, err := NewDependencyQueryEngine(graph).Evaluate(domain.DependencyQuery{
+		Kind:   domain.QueryWhy,
+		Source: "a",
+		Target: "c",
+	})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Paths, [][]string{{"a", "b", "c"}}) {
+		t.Errorf("Paths = %v, want [[a b c]]", result.Paths)
+	}
+}
@@ -0,0 +1,274 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// HotspotAnalyzer ranks files and functions by churn times complexity. Changes made
+// since the start of the recent window count domain.HotspotRecentWeight times.
+type HotspotAnalyzer struct {
+	since       time.Time
+	recentSince time.Time
+}
+
+// NewHotspotAnalyzer creates a hotspot analyzer for the given history and recent windows
+// (a zero time disables the window)
+func NewHotspotAnalyzer(since, recentSince time.Time) *HotspotAnalyzer {
+	return &HotspotAnalyzer{since: since, recentSince: recentSince}
+}
+
+// RankFiles ranks the files changed by commits. Commit file paths must match the paths
+// of the complexity and CBO results; files without churn or complexity are not hotspots.
+func (a *HotspotAnalyzer) RankFiles(commits []domain.GitCommit, complexity *domain.ComplexityResponse, cbo *domain.CBOResponse) []domain.FileHotspot {
+	type fileChurn struct {
+		hotspot       *domain.FileHotspot
+		commitsByKey  map[string]int
+		namesByAuthor map[string]string
+	}
+	churn := make(map[string]*fileChurn)
+
+	for _, commit := range commits {
+		if !a.inWindow(commit.Time) {
+			continue
+		}
+		for _, change := range commit.Files {
+			fc, ok := churn[change.Path]
+			if !ok {
+				fc = &fileChurn{
+					hotspot:       &domain.FileHotspot{FilePath: change.Path},
+					commitsByKey:  make(map[string]int),
+					namesByAuthor: make(map[string]string),
+				}
+				churn[change.Path] = fc
+			}
+			fc.hotspot.Commits++
+			if a.isRecent(commit.Time) {
+				fc.hotspot.RecentCommits++
+			}
+			fc.hotspot.LinesAdded += change.Added
+			fc.hotspot.LinesDeleted += change.Deleted
+			if commit.Time.After(fc.hotspot.LastChanged) {
+				fc.hotspot.LastChanged = commit.Time
+			}
+			fc.commitsByKey[commit.AuthorKey()]++
+			fc.namesByAuthor[commit.AuthorKey()] = commit.Author
+		}
+	}
+
+	if complexity != nil {
+		for _, fn := range complexity.Functions {
+			if fc, ok := churn[fn.FilePath]; ok {
+				fc.hotspot.Functions++
+				fc.hotspot.TotalComplexity += fn.Metrics.Complexity
+				if fn.Metrics.Complexity > fc.hotspot.MaxComplexity {
+					fc.hotspot.MaxComplexity = fn.Metrics.Complexity
+				}
+			}
+		}
+	}
+	if cbo != nil {
+		for _, class := range cbo.Classes {
+			if fc, ok := churn[class.FilePath]; ok && class.Metrics.CouplingCount > fc.hotspot.MaxCBO {
+				fc.hotspot.MaxCBO = class.Metrics.CouplingCount
+			}
+		}
+	}
+
+	var hotspots []domain.FileHotspot
+	var raw []float64
+	for _, fc := range churn {
+		h := fc.hotspot
+		h.Authors = len(fc.commitsByKey)
+		h.MainAuthor = mainAuthor(fc.commitsByKey, fc.namesByAuthor)
+
+		score := float64(a.weightedChurn(h.Commits, h.RecentCommits) * (h.TotalComplexity + h.MaxCBO))
+		if score == 0 {
+			continue
+		}
+		hotspots = append(hotspots, *h)
+		raw = append(raw, score)
+	}
+
+	normalizeHotspotScores(raw, func(i int, score float64) {
+		hotspots[i].Score = score
+		hotspots[i].RiskLevel = domain.HotspotRiskLevel(score)
+	})
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Score != hotspots[j].Score {
+			return hotspots[i].Score > hotspots[j].Score
+		}
+		return hotspots[i].FilePath < hotspots[j].FilePath
+	})
+	return hotspots
+}
+
+// RankFunctions ranks functions by the commits that changed their lines. Commits are
+// newest first with the hunks of their changes; each function's line range is followed
+// back through them, so a commit counts when one of its hunks touches the range as it
+// was at that commit. A leading commit without hash holds the uncommitted changes,
+// which move the range without counting.
+func (a *HotspotAnalyzer) RankFunctions(functions []domain.FunctionComplexity, commits []domain.GitCommit) []domain.FunctionHotspot {
+	type fileChange struct {
+		commit *domain.GitCommit
+		hunks  []domain.GitHunk
+	}
+	history := make(map[string][]fileChange)
+	for i := range commits {
+		for _, change := range commits[i].Files {
+			history[change.Path] = append(history[change.Path], fileChange{commit: &commits[i], hunks: change.Hunks})
+		}
+	}
+
+	var hotspots []domain.FunctionHotspot
+	var raw []float64
+
+	for _, fn := range functions {
+		changes, ok := history[fn.FilePath]
+		if !ok || fn.StartLine < 1 {
+			continue
+		}
+
+		authors := make(map[string]bool)
+		hotspot := domain.FunctionHotspot{
+			Name:       fn.Name,
+			FilePath:   fn.FilePath,
+			StartLine:  fn.StartLine,
+			EndLine:    fn.EndLine,
+			Complexity: fn.Metrics.Complexity,
+		}
+		start, end := fn.StartLine, max(fn.EndLine, fn.StartLine)
+		for _, change := range changes {
+			var touched bool
+			touched, start, end = traceLineRange(change.hunks, start, end)
+			commit := change.commit
+			if touched && commit.Hash != "" && a.inWindow(commit.Time) {
+				hotspot.Commits++
+				if a.isRecent(commit.Time) {
+					hotspot.RecentCommits++
+				}
+				authors[commit.AuthorKey()] = true
+				if commit.Time.After(hotspot.LastChanged) {
+					hotspot.LastChanged = commit.Time
+				}
+			}
+			// The function did not exist before this change
+			if start > end {
+				break
+			}
+		}
+		hotspot.Authors = len(authors)
+
+		score := float64(a.weightedChurn(hotspot.Commits, hotspot.RecentCommits) * hotspot.Complexity)
+		if score == 0 {
+			continue
+		}
+		hotspots = append(hotspots, hotspot)
+		raw = append(raw, score)
+	}
+
+	normalizeHotspotScores(raw, func(i int, score float64) {
+		hotspots[i].Score = score
+		hotspots[i].RiskLevel = domain.HotspotRiskLevel(score)
+	})
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Score != hotspots[j].Score {
+			return hotspots[i].Score > hotspots[j].Score
+		}
+		if hotspots[i].FilePath != hotspots[j].FilePath {
+			return hotspots[i].FilePath < hotspots[j].FilePath
+		}
+		return hotspots[i].StartLine < hotspots[j].StartLine
+	})
+	return hotspots
+}
+
+// traceLineRange reports whether the hunks of a change touch the lines start-end of
+// the changed file, and returns the range in the file before the change. The range
+// is empty (start > end) when the change added all of its lines.
+func traceLineRange(hunks []domain.GitHunk, start, end int) (bool, int, int) {
+	touched := false
+	oldStart, oldEnd := start, end
+	for _, h := range hunks {
+		delta := h.NewLines - h.OldLines
+		if h.NewLines == 0 {
+			// Lines deleted after line NewStart
+			if h.NewStart >= start && h.NewStart < end {
+				touched = true
+			}
+			if h.NewStart < start {
+				oldStart -= delta
+			}
+			if h.NewStart < end {
+				oldEnd -= delta
+			}
+			continue
+		}
+
+		first, last := h.NewStart, h.NewStart+h.NewLines-1
+		if first <= end && last >= start {
+			touched = true
+		}
+		// A changed boundary line maps to the edge of the lines it replaced; inserted
+		// lines (no old lines) follow line OldStart
+		switch {
+		case last < start:
+			oldStart -= delta
+		case first <= start:
+			oldStart = h.OldStart
+			if h.OldLines == 0 {
+				oldStart++
+			}
+		}
+		switch {
+		case last < end:
+			oldEnd -= delta
+		case first <= end:
+			oldEnd = h.OldStart + max(h.OldLines, 1) - 1
+		}
+	}
+	return touched, oldStart, oldEnd
+}
+
+// inWindow reports whether a change falls inside the history window
+func (a *HotspotAnalyzer) inWindow(t time.Time) bool {
+	return a.since.IsZero() || !t.Before(a.since)
+}
+
+// isRecent reports whether a change falls inside the recent window
+func (a *HotspotAnalyzer) isRecent(t time.Time) bool {
+	return !a.recentSince.IsZero() && !t.Before(a.recentSince)
+}
+
+// weightedChurn counts recent commits domain.HotspotRecentWeight times
+func (a *HotspotAnalyzer) weightedChurn(commits, recentCommits int) int {
+	return commits + (domain.HotspotRecentWeight-1)*recentCommits
+}
+
+// normalizeHotspotScores rescales raw scores so that the top one is 100, rounded to one decimal
+func normalizeHotspotScores(raw []float64, set func(i int, score float64)) {
+	top := 0.0
+	for _, score := range raw {
+		top = math.Max(top, score)
+	}
+	if top == 0 {
+		return
+	}
+	for i, score := range raw {
+		set(i, math.Round(score/top*1000)/10)
+	}
+}
+
+// mainAuthor returns the name of the author with the most commits (ties broken by key)
+func mainAuthor(commitsByKey map[string]int, namesByKey map[string]string) string {
+	best := ""
+	for key, count := range commitsByKey {
+		if best == "" || count > commitsByKey[best] || (count == commitsByKey[best] && key < best) {
+			best = key
+		}
+	}
+	return namesByKey[best]
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func hotspotCommit(hash, email string, day int, files ...string) domain.GitCommit {
+	commit := domain.GitCommit{
+		Hash:   hash,
+		Author: email[:1],
+		Email:  email,
+		Time:   time.Date(2026, 1, day, 12, 0, 0, 0, time.UTC),
+	}
+	for _, file := range files {
+		commit.Files = append(commit.Files, domain.GitFileChange{Path: file, Added: 2, Deleted: 1})
+	}
+	return commit
+}
+
+func hotspotFunction(name, file string, start, end, complexity int) domain.FunctionComplexity {
+	return domain.FunctionComplexity{
+		Name:      name,
+		FilePath:  file,
+		StartLine: start,
+		EndLine:   end,
+		Metrics:   domain.ComplexityMetrics{Complexity: complexity},
+	}
+}
+
+func TestHotspotAnalyzerRankFiles(t *testing.T) {
+	commits := []domain.GitCommit{
+		hotspotCommit("c1", "ann@example.com", 1, "a.js", "b.js", "c.js"),
+		hotspotCommit("c2", "bob@example.com", 10, "a.js"),
+		hotspotCommit("c3", "bob@example.com", 20, "a.js", "b.js"),
+		hotspotCommit("old", "ann@example.com", 1, "c.js"),
+	}
+	// The old commit falls outside the history window
+	commits[3].Time = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	complexity := &domain.ComplexityResponse{Functions: []domain.FunctionComplexity{
+		hotspotFunction("f", "a.js", 1, 10, 5),
+		hotspotFunction("g", "a.js", 11, 20, 3),
+		hotspotFunction("h", "b.js", 1, 10, 2),
+		hotspotFunction("unchanged", "d.js", 1, 10, 50),
+	}}
+	cbo := &domain.CBOResponse{Classes: []domain.ClassCoupling{
+		{Name: "B", FilePath: "b.js", Metrics: domain.CBOMetrics{CouplingCount: 4}},
+	}}
+
+	analyzer := NewHotspotAnalyzer(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	files := analyzer.RankFiles(commits, complexity, cbo)
+
+	// a.js: churn 3+1 recent = 4, x (8+0) = 32; b.js: churn 2+1 = 3, x (2+4) = 18; c.js has no complexity
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 file hotspots, got %+v", files)
+	}
+	a, b := files[0], files[1]
+	if a.FilePath != "a.js" || a.Score != 100 || a.RiskLevel != domain.RiskLevelHigh {
+		t.Errorf("Expected a.js to be the top hotspot, got %+v", a)
+	}
+	if a.Commits != 3 || a.RecentCommits != 1 || a.Authors != 2 || a.MainAuthor != "b" {
+		t.Errorf("Unexpected churn for a.js %+v", a)
+	}
+	if a.Functions != 2 || a.TotalComplexity != 8 || a.MaxComplexity != 5 || a.LinesAdded != 6 || a.LinesDeleted != 3 {
+		t.Errorf("Unexpected metrics for a.js %+v", a)
+	}
+	if !a.LastChanged.Equal(commits[2].Time) {
+		t.Errorf("Expected a.js last changed by c3, got %v", a.LastChanged)
+	}
+	if b.FilePath != "b.js" || b.MaxCBO != 4 || b.Score != 56.3 || b.RiskLevel != domain.RiskLevelHigh {
+		t.Errorf("Unexpected hotspot for b.js %+v", b)
+	}
+}
+
+func TestHotspotAnalyzerRankFunctions(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 12, 0, 0, 0, time.UTC) }
+	change := func(hunks ...domain.GitHunk) []domain.GitFileChange {
+		return []domain.GitFileChange{{Path: "a.js", Hunks: hunks}}
+	}
+	// busy is lines 2-7 and quiet lines 9-10 of the working tree, one line below HEAD
+	commits := []domain.GitCommit{
+		{Files: change(domain.GitHunk{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 1})}, // Uncommitted
+		{Hash: "c3", Author: "bob", Email: "bob@example.com", Time: day(20),
+			Files: change(domain.GitHunk{OldStart: 2, OldLines: 0, NewStart: 3, NewLines: 2})},
+		{Hash: "c2", Author: "bob", Email: "bob@example.com", Time: day(10),
+			Files: change(domain.GitHunk{OldStart: 2, OldLines: 1, NewStart: 2, NewLines: 1})},
+		{Hash: "c1", Author: "ann", Email: "ann@example.com", Time: day(1),
+			Files: change(domain.GitHunk{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 7})},
+	}
+	functions := []domain.FunctionComplexity{
+		hotspotFunction("busy", "a.js", 2, 7, 4),
+		hotspotFunction("quiet", "a.js", 9, 10, 2),
+		hotspotFunction("unchanged", "b.js", 1, 3, 10),
+	}
+
+	analyzer := NewHotspotAnalyzer(time.Time{}, day(15))
+	hotspots := analyzer.RankFunctions(functions, commits)
+
+	// busy: 3 commits + 1 recent = 4, x 4 = 16; quiet: 1 commit x 2 = 2
+	if len(hotspots) != 2 {
+		t.Fatalf("Expected 2 function hotspots, got %+v", hotspots)
+	}
+	busy, quiet := hotspots[0], hotspots[1]
+	if busy.Name != "busy" || busy.Commits != 3 || busy.RecentCommits != 1 || busy.Authors != 2 || busy.Score != 100 {
+		t.Errorf("Unexpected busy hotspot %+v", busy)
+	}
+	if !busy.LastChanged.Equal(day(20)) {
+		t.Errorf("Expected busy last changed on day 20, got %v", busy.LastChanged)
+	}
+	if quiet.Name != "quiet" || quiet.Commits != 1 || quiet.Score != 12.5 || quiet.RiskLevel != domain.RiskLevelLow {
+		t.Errorf("Unexpected quiet hotspot %+v", quiet)
+	}
+
+	// Outside the history window nothing changed
+	if hotspots := NewHotspotAnalyzer(day(25), time.Time{}).RankFunctions(functions, commits); len(hotspots) != 0 {
+		t.Errorf("Expected no hotspots after the last change, got %+v", hotspots)
+	}
+}
+
+func TestTraceLineRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		hunks      []domain.GitHunk
+		touched    bool
+		start, end int
+	}{
+		{"lines inserted above", []domain.GitHunk{{OldStart: 1, OldLines: 0, NewStart: 2, NewLines: 3}}, false, 7, 9},
+		{"lines deleted above", []domain.GitHunk{{OldStart: 2, OldLines: 2, NewStart: 1, NewLines: 0}}, false, 12, 14},
+		{"lines changed below", []domain.GitHunk{{OldStart: 20, OldLines: 1, NewStart: 20, NewLines: 4}}, false, 10, 12},
+		{"line changed inside", []domain.GitHunk{{OldStart: 11, OldLines: 1, NewStart: 11, NewLines: 1}}, true, 10, 12},
+		{"lines deleted inside", []domain.GitHunk{{OldStart: 11, OldLines: 5, NewStart: 10, NewLines: 0}}, true, 10, 17},
+		{"lines deleted after the end", []domain.GitHunk{{OldStart: 13, OldLines: 5, NewStart: 12, NewLines: 0}}, false, 10, 12},
+		{"first line rewritten", []domain.GitHunk{{OldStart: 8, OldLines: 3, NewStart: 9, NewLines: 2}}, true, 8, 13},
+		{"function added", []domain.GitHunk{{OldStart: 5, OldLines: 0, NewStart: 6, NewLines: 10}}, true, 6, 5},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			touched, start, end := traceLineRange(tc.hunks, 10, 12)
+			if touched != tc.touched || start != tc.start || end != tc.end {
+				t.Errorf("Expected (%v, %d, %d), got (%v, %d, %d)", tc.touched, tc.start, tc.end, touched, start, end)
+			}
+		})
+	}
+}
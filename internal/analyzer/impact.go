@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// ImpactAnalyzer finds the modules affected by a change set by walking the
+// reverse edges of the dependency graph
+type ImpactAnalyzer struct {
+	graph        *domain.DependencyGraph
+	testPatterns []string
+}
+
+// NewImpactAnalyzer creates a new ImpactAnalyzer. testPatterns are globs that mark
+// additional files as tests on top of the *.test.*, *.spec.* and __tests__ conventions.
+func NewImpactAnalyzer(graph *domain.DependencyGraph, testPatterns []string) *ImpactAnalyzer {
+	if graph == nil {
+		graph = domain.NewDependencyGraph()
+	}
+	return &ImpactAnalyzer{graph: graph, testPatterns: testPatterns}
+}
+
+// Analyze returns the changed modules and every module depending on them, nearest first.
+// A path to a changed module is as weak as its weakest edge (type-only < dynamic < runtime)
+// and each module is reported with the shortest of its strongest paths, whose length and
+// next module give Distance and Via.
+func (a *ImpactAnalyzer) Analyze(changed []string) []domain.ImpactedModule {
+	impacted := make(map[string]*domain.ImpactedModule)
+	var queue []string
+
+	for _, id := range changed {
+		if _, exists := impacted[id]; exists || a.graph.GetNode(id) == nil {
+			continue
+		}
+		impacted[id] = a.newImpactedModule(id, domain.ImpactRuntime, 0, "")
+		queue = append(queue, id)
+	}
+
+	// BFS discovers each module at its minimum distance; a module is queued again
+	// when a stronger kind, or the same kind over a shorter path, reaches it so the
+	// new path propagates to its dependents
+	for len(queue) > 0 {
+		current := impacted[queue[0]]
+		queue = queue[1:]
+
+		for _, edge := range a.graph.GetIncomingEdges(current.ID) {
+			kind := weakerImpact(current.Kind, domain.ImpactKindForEdge(edge.EdgeType))
+
+			dependent, exists := impacted[edge.From]
+			if !exists {
+				impacted[edge.From] = a.newImpactedModule(edge.From, kind, current.Distance+1, current.ID)
+				queue = append(queue, edge.From)
+				continue
+			}
+			stronger := kind.Strength() > dependent.Kind.Strength()
+			shorter := kind == dependent.Kind && current.Distance+1 < dependent.Distance
+			if stronger || shorter {
+				dependent.Kind = kind
+				dependent.Distance = current.Distance + 1
+				dependent.Via = current.ID
+				queue = append(queue, edge.From)
+			}
+		}
+	}
+
+	result := make([]domain.ImpactedModule, 0, len(impacted))
+	for _, module := range impacted {
+		result = append(result, *module)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Distance != result[j].Distance {
+			return result[i].Distance < result[j].Distance
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// IsTestModule reports whether a module is a test file
+func (a *ImpactAnalyzer) IsTestModule(id string) bool {
+	if isTestFile(id) {
+		return true
+	}
+	slashed := filepath.ToSlash(id)
+	for _, pattern := range a.testPatterns {
+		if matchModuleGlob(filepath.ToSlash(pattern), slashed) {
+			return true
+		}
+	}
+	return false
+}
+
+// newImpactedModule creates an ImpactedModule for a graph node
+func (a *ImpactAnalyzer) newImpactedModule(id string, kind domain.ImpactKind, distance int, via string) *domain.ImpactedModule {
+	module := &domain.ImpactedModule{
+		ID:       id,
+		FilePath: id,
+		Kind:     kind,
+		Distance: distance,
+		Via:      via,
+		IsTest:   a.IsTestModule(id),
+	}
+	if node := a.graph.GetNode(id); node != nil {
+		if node.FilePath != "" {
+			module.FilePath = node.FilePath
+		}
+		module.IsEntryPoint = node.IsEntryPoint
+	}
+	return module
+}
+
+// weakerImpact returns the weaker of two impact kinds
+func weakerImpact(a, b domain.ImpactKind) domain.ImpactKind {
+	if b.Strength() < a.Strength() {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// buildImpactTestGraph builds:
+//
+//	src/api.ts -> src/service.ts -> src/db.ts
+//	src/service.ts ..type..> src/types.ts
+//	src/routes.ts --dynamic--> src/api.ts
+//	src/api.ts ..type..> src/types.ts
+//	src/__tests__/service.ts -> src/service.ts
+//	e2e/flow.ts -> src/routes.ts
+func buildImpactTestGraph() *domain.DependencyGraph {
+	graph := domain.NewDependencyGraph()
+	for _, id := range []string{
+		"src/api.ts", "src/service.ts", "src/db.ts", "src/types.ts",
+		"src/routes.ts", "src/__tests__/service.ts", "e2e/flow.ts",
+	} {
+		graph.AddNode(&domain.ModuleNode{ID: id, FilePath: id})
+	}
+	addEdge := func(from, to string, edgeType domain.DependencyEdgeType) {
+		graph.AddEdge(&domain.DependencyEdge{From: from, To: to, EdgeType: edgeType, Weight: 1})
+	}
+	addEdge("src/api.ts", "src/service.ts", domain.EdgeTypeImport)
+	addEdge("src/service.ts", "src/db.ts", domain.EdgeTypeImport)
+	addEdge("src/service.ts", "src/types.ts", domain.EdgeTypeTypeOnly)
+	addEdge("src/api.ts", "src/types.ts", domain.EdgeTypeTypeOnly)
+	addEdge("src/routes.ts", "src/api.ts", domain.EdgeTypeDynamic)
+	addEdge("src/__tests__/service.ts", "src/service.ts", domain.EdgeTypeImport)
+	addEdge("e2e/flow.ts", "src/routes.ts", domain.EdgeTypeImport)
+	graph.UpdateNodeFlags()
+	return graph
+}
+
+func impactByID(modules []domain.ImpactedModule) map[string]domain.ImpactedModule {
+	result := make(map[string]domain.ImpactedModule, len(modules))
+	for _, m := range modules {
+		result[m.ID] = m
+	}
+	return result
+}
+
+func TestImpactAnalyzerRuntimeAndDynamic(t *testing.T) {
+	analyzer := NewImpactAnalyzer(buildImpactTestGraph(), nil)
+	impacted := impactByID(analyzer.Analyze([]string{"src/db.ts"}))
+
+	tests := []struct {
+		id       string
+		kind     domain.ImpactKind
+		distance int
+		via      string
+	}{
+		{"src/db.ts", domain.ImpactRuntime, 0, ""},
+		{"src/service.ts", domain.ImpactRuntime, 1, "src/db.ts"},
+		{"src/api.ts", domain.ImpactRuntime, 2, "src/service.ts"},
+		{"src/__tests__/service.ts", domain.ImpactRuntime, 2, "src/service.ts"},
+		{"src/routes.ts", domain.ImpactDynamic, 3, "src/api.ts"},
+		{"e2e/flow.ts", domain.ImpactDynamic, 4, "src/routes.ts"},
+	}
+
+	if len(impacted) != len(tests) {
+		t.Errorf("Expected %d impacted modules, got %d", len(tests), len(impacted))
+	}
+	for _, tc := range tests {
+		m, ok := impacted[tc.id]
+		if !ok {
+			t.Errorf("Expected %s to be impacted", tc.id)
+			continue
+		}
+		if m.Kind != tc.kind || m.Distance != tc.distance || m.Via != tc.via {
+			t.Errorf("%s: got kind=%s distance=%d via=%q, want kind=%s distance=%d via=%q",
+				tc.id, m.Kind, m.Distance, m.Via, tc.kind, tc.distance, tc.via)
+		}
+	}
+	if _, ok := impacted["src/types.ts"]; ok {
+		t.Error("src/types.ts does not depend on src/db.ts")
+	}
+}
+
+func TestImpactAnalyzerStrongestPathWins(t *testing.T) {
+	analyzer := NewImpactAnalyzer(buildImpactTestGraph(), nil)
+
+	// src/api.ts reaches src/types.ts directly (type-only) and through
+	// src/service.ts (type-only as well), so it stays type-only
+	impacted := impactByID(analyzer.Analyze([]string{"src/types.ts"}))
+	if impacted["src/api.ts"].Kind != domain.ImpactTypeOnly {
+		t.Errorf("Expected src/api.ts to be type-only, got %s", impacted["src/api.ts"].Kind)
+	}
+
+	// Changing src/service.ts as well gives src/api.ts a runtime path
+	impacted = impactByID(analyzer.Analyze([]string{"src/types.ts", "src/service.ts"}))
+	if impacted["src/api.ts"].Kind != domain.ImpactRuntime {
+		t.Errorf("Expected src/api.ts to be runtime, got %s", impacted["src/api.ts"].Kind)
+	}
+	if impacted["src/routes.ts"].Kind != domain.ImpactDynamic {
+		t.Errorf("Expected src/routes.ts to be dynamic, got %s", impacted["src/routes.ts"].Kind)
+	}
+}
+
+func TestImpactAnalyzerStrongestPathDistance(t *testing.T) {
+	// src/app.ts imports the types of src/core.ts directly, and its values through
+	// src/facade.ts and src/adapter.ts
+	graph := domain.NewDependencyGraph()
+	for _, id := range []string{"src/app.ts", "src/core.ts", "src/facade.ts", "src/adapter.ts"} {
+		graph.AddNode(&domain.ModuleNode{ID: id, FilePath: id})
+	}
+	graph.AddEdge(&domain.DependencyEdge{From: "src/app.ts", To: "src/core.ts", EdgeType: domain.EdgeTypeTypeOnly, Weight: 1})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/app.ts", To: "src/facade.ts", EdgeType: domain.EdgeTypeImport, Weight: 1})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/facade.ts", To: "src/adapter.ts", EdgeType: domain.EdgeTypeImport, Weight: 1})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/adapter.ts", To: "src/core.ts", EdgeType: domain.EdgeTypeImport, Weight: 1})
+
+	impacted := impactByID(NewImpactAnalyzer(graph, nil).Analyze([]string{"src/core.ts"}))
+	app := impacted["src/app.ts"]
+	if app.Kind != domain.ImpactRuntime || app.Distance != 3 || app.Via != "src/facade.ts" {
+		t.Errorf("Expected src/app.ts impacted at runtime through src/facade.ts at distance 3, got kind=%s distance=%d via=%q",
+			app.Kind, app.Distance, app.Via)
+	}
+}
+
+func TestImpactAnalyzerTestFiles(t *testing.T) {
+	analyzer := NewImpactAnalyzer(buildImpactTestGraph(), []string{"e2e/**"})
+	impacted := impactByID(analyzer.Analyze([]string{"src/db.ts"}))
+
+	if !impacted["src/__tests__/service.ts"].IsTest {
+		t.Error("Expected __tests__ file to be a test")
+	}
+	if !impacted["e2e/flow.ts"].IsTest {
+		t.Error("Expected e2e/flow.ts to match the configured test pattern")
+	}
+	if impacted["src/api.ts"].IsTest {
+		t.Error("src/api.ts is not a test")
+	}
+	if !impacted["e2e/flow.ts"].IsEntryPoint {
+		t.Error("Expected e2e/flow.ts to be an entry point")
+	}
+}
+
+func TestImpactAnalyzerUnknownModules(t *testing.T) {
+	analyzer := NewImpactAnalyzer(buildImpactTestGraph(), nil)
+	if impacted := analyzer.Analyze([]string{"src/missing.ts"}); len(impacted) != 0 {
+		t.Errorf("Expected no impact for unknown modules, got %d", len(impacted))
+	}
+}
@@ -106,6 +106,9 @@ func (ma *ModuleAnalyzer) AnalyzeFile(ast *parser.Node, filePath string) (*domai
 	// Extract imports
 	ma.extractImports(ast, info)
 
+	// Flag value imports only referenced as types
+	ma.markTypeUsageOnly(ast, info)
+
 	// Extract exports
 	ma.extractExports(ast, info)
 
@@ -217,6 +220,68 @@ func (ma *ModuleAnalyzer) extractImports(ast *parser.Node, info *domain.ModuleIn
 	})
 }
 
+// markTypeUsageOnly sets CanBeTypeOnly on the static value imports whose bindings are
+// referenced in type positions and never as values
+func (ma *ModuleAnalyzer) markTypeUsageOnly(ast *parser.Node, info *domain.ModuleInfo) {
+	typeUses := make(map[string]bool)
+	valueUses := make(map[string]bool)
+	ast.Walk(func(n *parser.Node) bool {
+		switch n.Type {
+		case parser.NodeImportDeclaration:
+			// Import declarations bind names rather than reference them
+			return false
+
+		case parser.NodeIdentifier:
+			if n.Kind == "type" {
+				typeUses[n.Name] = true
+			} else {
+				valueUses[n.Name] = true
+			}
+
+		case parser.NodeExportSpecifier:
+			name := n.Name
+			if n.Local != nil && n.Local.Name != "" {
+				name = n.Local.Name
+			}
+			valueUses[name] = true
+
+		case parser.NodeMemberExpression:
+			// ns.value, or Ns.Type in a type; the property is not a reference
+			if n.Object != nil && n.Object.Type == parser.NodeIdentifier {
+				if n.Property != nil && n.Property.Kind == "type" {
+					typeUses[n.Object.Name] = true
+				} else {
+					valueUses[n.Object.Name] = true
+				}
+				return false
+			}
+
+		case parser.NodeJSXElement:
+			// <Component.Item /> uses Component as a value
+			if name, _, _ := strings.Cut(n.Name, "."); name != "" {
+				valueUses[name] = true
+			}
+		}
+		return true
+	})
+
+	for _, imp := range info.Imports {
+		if imp.IsTypeOnly || imp.IsDynamic || imp.ImportType == domain.ImportTypeRequire || len(imp.Specifiers) == 0 {
+			continue
+		}
+		usedAsType := false
+		usedAsValue := false
+		for _, spec := range imp.Specifiers {
+			if spec.IsType {
+				continue
+			}
+			usedAsType = usedAsType || typeUses[spec.Local]
+			usedAsValue = usedAsValue || valueUses[spec.Local]
+		}
+		imp.CanBeTypeOnly = usedAsType && !usedAsValue
+	}
+}
+
 // nodeLocationKey creates a unique key for a node based on its location
 func nodeLocationKey(node *parser.Node) string {
 	if node == nil {
@@ -265,6 +330,11 @@ func (ma *ModuleAnalyzer) extractExports(ast *parser.Node, info *domain.ModuleIn
 			}
 			return false // Don't walk children of export declaration
 
+		case "internal_module", "module", "ambient_declaration":
+			// Exports of namespaces and of `declare module` or `declare global`
+			// blocks are not exports of the file
+			return false
+
 		case parser.NodeAssignmentExpression:
 			if !visited[key] {
 				visited[key] = true
@@ -316,7 +386,8 @@ func (ma *ModuleAnalyzer) processImportDeclaration(node *parser.Node) *domain.Im
 		case parser.NodeImportSpecifier:
 			hasNamed = true
 			specifier := domain.ImportSpecifier{
-				Local: spec.Name,
+				Local:  spec.Name,
+				IsType: spec.Kind == "type",
 			}
 			if spec.Imported != nil {
 				specifier.Imported = spec.Imported.Name
@@ -338,9 +409,18 @@ func (ma *ModuleAnalyzer) processImportDeclaration(node *parser.Node) *domain.Im
 		imp.ImportType = domain.ImportTypeSideEffect
 	}
 
-	// Check for TypeScript type import
-	// This would require additional AST parsing for 'import type'
-	// For now, we check if the node might be a type import based on children
+	// TypeScript type import: import type { X } or import { type X, type Y },
+	// both of which are erased at runtime
+	imp.IsTypeOnly = node.Kind == "type"
+	if !imp.IsTypeOnly && len(imp.Specifiers) > 0 {
+		imp.IsTypeOnly = true
+		for _, spec := range imp.Specifiers {
+			if !spec.IsType {
+				imp.IsTypeOnly = false
+				break
+			}
+		}
+	}
 
 	return imp
 }
@@ -352,9 +432,9 @@ func (ma *ModuleAnalyzer) processDynamicImport(node *parser.Node) *domain.Import
 	}
 
 	// Check if callee is 'import' (dynamic import)
-	// Tree-sitter may represent it as an identifier or use Raw
+	// Tree-sitter may represent it as an identifier, an "import" node or use Raw
 	isImportCall := (node.Callee.Type == parser.NodeIdentifier && node.Callee.Name == "import") ||
-		node.Callee.Raw == "import"
+		node.Callee.Type == "import" || node.Callee.Raw == "import"
 
 	if !isImportCall || len(node.Arguments) == 0 {
 		return nil
@@ -422,12 +502,20 @@ func (ma *ModuleAnalyzer) processExportNamedDeclaration(node *parser.Node) *doma
 				Exported: node.Declaration.Name,
 			})
 		}
+		// export const a = 1, b = 2: one specifier per declared identifier
+		for _, declarator := range node.Declaration.Declarations {
+			if len(declarator.Children) > 0 && declarator.Children[0].Type == parser.NodeIdentifier {
+				name := declarator.Children[0].Name
+				exp.Specifiers = append(exp.Specifiers, domain.ExportSpecifier{Local: name, Exported: name})
+			}
+		}
 	}
 
 	// Process specifiers
 	for _, spec := range node.Specifiers {
 		specifier := domain.ExportSpecifier{
-			Local: spec.Name,
+			Local:  spec.Name,
+			IsType: node.Kind == "type" || spec.Kind == "type",
 		}
 		if spec.Local != nil {
 			specifier.Local = spec.Local.Name
@@ -436,6 +524,9 @@ func (ma *ModuleAnalyzer) processExportNamedDeclaration(node *parser.Node) *doma
 		exp.Specifiers = append(exp.Specifiers, specifier)
 	}
 
+	// TypeScript type export: export type { X }, erased at runtime
+	exp.IsTypeOnly = node.Kind == "type"
+
 	return exp
 }
 
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ludo-technologies/jscan/domain"
@@ -276,9 +277,90 @@ func TestDynamicImport(t *testing.T) {
 			return // Found and validated
 		}
 	}
-	// Note: Dynamic import detection depends on tree-sitter grammar
-	// If not detected, this is acceptable for now
-	t.Log("Dynamic import not detected (tree-sitter grammar limitation)")
+	t.Error("Expected dynamic import to be detected")
+}
+
+func TestTypeOnlyImports(t *testing.T) {
+	source := `import type { User } from './types';
+import { type Order, type Item } from './orders';
+import { type Config, loadConfig } from './config';
+`
+	ast, err := parser.ParseForLanguage("app.ts", []byte(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	analyzer := NewModuleAnalyzer(DefaultModuleAnalyzerConfig())
+	info, err := analyzer.AnalyzeFile(ast, "app.ts")
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+
+	expected := map[string]bool{
+		"./types":  true,
+		"./orders": true,
+		"./config": false,
+	}
+	if len(info.Imports) != len(expected) {
+		t.Fatalf("Expected %d imports, got %d", len(expected), len(info.Imports))
+	}
+	for _, imp := range info.Imports {
+		if imp.IsTypeOnly != expected[imp.Source] {
+			t.Errorf("Import %s: expected IsTypeOnly=%v", imp.Source, expected[imp.Source])
+		}
+		if imp.Source == "./config" {
+			for _, spec := range imp.Specifiers {
+				if spec.IsType != (spec.Local == "Config") {
+					t.Errorf("Specifier %s: unexpected IsType=%v", spec.Local, spec.IsType)
+				}
+			}
+		}
+	}
+}
+
+func TestImportsUsedOnlyAsTypes(t *testing.T) {
+	source := `import { User } from './user';
+import { Order, createOrder } from './order';
+import * as models from './models';
+import * as api from './api';
+import Widget from './widget';
+import { Unused } from './unused';
+import type { Item } from './item';
+
+export function save(user: User, item: Item): models.Record {
+	api.send(createOrder());
+	return <Widget />;
+}
+let order: Order;
+`
+	ast, err := parser.ParseForLanguage("app.tsx", []byte(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	analyzer := NewModuleAnalyzer(DefaultModuleAnalyzerConfig())
+	info, err := analyzer.AnalyzeFile(ast, "app.tsx")
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+
+	expected := map[string]bool{
+		"./user":   true,
+		"./order":  false,
+		"./models": true,
+		"./api":    false,
+		"./widget": false,
+		"./unused": false,
+		"./item":   false,
+	}
+	if len(info.Imports) != len(expected) {
+		t.Fatalf("Expected %d imports, got %d", len(expected), len(info.Imports))
+	}
+	for _, imp := range info.Imports {
+		if imp.CanBeTypeOnly != expected[imp.Source] {
+			t.Errorf("Import %s: expected CanBeTypeOnly=%v", imp.Source, expected[imp.Source])
+		}
+	}
 }
 
 func TestExportNamedDeclaration(t *testing.T) {
@@ -308,6 +390,34 @@ func TestExportNamedDeclaration(t *testing.T) {
 	}
 }
 
+func TestExportVariableDeclaration(t *testing.T) {
+	source := `export const a = 1, b = () => a;`
+
+	p := parser.NewParser()
+	defer p.Close()
+
+	ast, err := p.ParseString(source)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	info, err := NewModuleAnalyzer(DefaultModuleAnalyzerConfig()).AnalyzeFile(ast, "test.js")
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+
+	if len(info.Exports) != 1 {
+		t.Fatalf("Expected 1 export, got %d", len(info.Exports))
+	}
+	var names []string
+	for _, spec := range info.Exports[0].Specifiers {
+		names = append(names, spec.Exported)
+	}
+	if strings.Join(names, ",") != "a,b" {
+		t.Errorf("Expected the exported names a and b, got %v", names)
+	}
+}
+
 func TestExportDefaultDeclaration(t *testing.T) {
 	source := `export default function() { return 'world'; }`
 
@@ -0,0 +1,421 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// deepImportSegments are leading subpath segments pointing into the build output or
+// the sources of a package rather than at an entry point it publishes
+var deepImportSegments = map[string]bool{
+	"src":   true,
+	"lib":   true,
+	"dist":  true,
+	"build": true,
+	"es":    true,
+	"esm":   true,
+	"cjs":   true,
+	"umd":   true,
+}
+
+// ParsePackageManifest parses a package.json; workspaces may be an array of globs
+// or an object with a packages array (yarn)
+func ParsePackageManifest(path string, content []byte) (*domain.PackageManifest, error) {
+	var raw struct {
+		domain.PackageManifest
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid package.json: %w", err)
+	}
+
+	manifest := raw.PackageManifest
+	manifest.Path = path
+	if len(raw.Workspaces) > 0 {
+		if err := json.Unmarshal(raw.Workspaces, &manifest.Workspaces); err != nil {
+			var object struct {
+				Packages []string `json:"packages"`
+			}
+			if err := json.Unmarshal(raw.Workspaces, &object); err != nil {
+				return nil, fmt.Errorf("invalid package.json workspaces: %w", err)
+			}
+			manifest.Workspaces = object.Packages
+		}
+	}
+	return &manifest, nil
+}
+
+// PackageName returns the package an import specifier resolves to ("@scope/name" or
+// "name") and its subpath, or "" for specifiers that are not npm packages, such as
+// package.json subpath imports ("#utils") and protocol imports ("virtual:pwa")
+func PackageName(source string) (name, subpath string) {
+	if source == "" || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "#") || strings.Contains(source, ":") {
+		return "", ""
+	}
+	parts := strings.SplitN(source, "/", 3)
+	if strings.HasPrefix(source, "@") {
+		if len(parts) < 2 || parts[1] == "" {
+			return "", ""
+		}
+		name = parts[0] + "/" + parts[1]
+		if len(parts) == 3 {
+			subpath = parts[2]
+		}
+		return name, subpath
+	}
+	name = parts[0]
+	if len(parts) > 1 {
+		subpath = strings.Join(parts[1:], "/")
+	}
+	return name, subpath
+}
+
+// IsDeepImport reports whether a package subpath points into the build output or
+// internals of the package, e.g. "lib/utils" or "dist/esm/index.js"
+func IsDeepImport(subpath string) bool {
+	if subpath == "" {
+		return false
+	}
+	segments := strings.Split(subpath, "/")
+	if deepImportSegments[segments[0]] {
+		return true
+	}
+	for _, segment := range segments {
+		// internal, internals, _internal, __internal
+		if name := strings.Trim(segment, "_"); name == "internal" || name == "internals" {
+			return true
+		}
+	}
+	return false
+}
+
+// typesPackage returns the DefinitelyTyped package of a package ("@types/scope__name"
+// for scoped packages)
+func typesPackage(name string) string {
+	if strings.HasPrefix(name, "@") {
+		return "@types/" + strings.Replace(name[1:], "/", "__", 1)
+	}
+	return "@types/" + name
+}
+
+// packageImport is a package import of a source file
+type packageImport struct {
+	name       string
+	subpath    string
+	site       domain.PackageImportSite
+	isTypeOnly bool
+}
+
+// packageOwner holds the package.json files a source file belongs to
+type packageOwner struct {
+	manifest *domain.PackageManifest // nearest package.json
+	root     *domain.PackageManifest // workspace root above it, if any
+	isDev    bool                    // config, tooling or test file
+}
+
+// declares reports whether the package is declared in one of the sections of the
+// owner's package.json or of the workspace root
+func (o packageOwner) declares(name string, sections ...string) (*domain.PackageManifest, string, bool) {
+	for _, manifest := range []*domain.PackageManifest{o.manifest, o.root} {
+		if manifest == nil {
+			continue
+		}
+		for _, section := range sections {
+			if version, ok := manifest.Section(section)[name]; ok {
+				return manifest, version, true
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// PackageHygieneAnalyzer compares the package imports of source files with the
+// dependencies declared in the package.json files they belong to
+type PackageHygieneAnalyzer struct {
+	manifests []*domain.PackageManifest
+	options   domain.PackageHygieneOptions
+	ignored   map[string]bool
+}
+
+// NewPackageHygieneAnalyzer creates an analyzer for the given package.json files
+func NewPackageHygieneAnalyzer(manifests []*domain.PackageManifest, options domain.PackageHygieneOptions) *PackageHygieneAnalyzer {
+	options = options.WithDefaults()
+	ignored := make(map[string]bool, len(options.IgnorePackages))
+	for _, name := range options.IgnorePackages {
+		ignored[name] = true
+	}
+	return &PackageHygieneAnalyzer{manifests: manifests, options: options, ignored: ignored}
+}
+
+// Analyze reports unused dependencies, undeclared imports, devDependencies used from
+// production code, deep imports and packages declared with different versions
+// across workspaces. Files outside of any package.json are skipped.
+func (a *PackageHygieneAnalyzer) Analyze(modules map[string]*domain.ModuleInfo) *domain.PackageHygieneResult {
+	result := &domain.PackageHygieneResult{
+		Options:     a.options,
+		Manifests:   make([]string, 0, len(a.manifests)),
+		Unused:      []domain.PackageFinding{},
+		Undeclared:  []domain.PackageFinding{},
+		DevOnly:     []domain.PackageFinding{},
+		DeepImports: []domain.PackageFinding{},
+		Duplicates:  []domain.DuplicatePackage{},
+	}
+	for _, manifest := range a.manifests {
+		result.Manifests = append(result.Manifests, manifest.Path)
+	}
+	sort.Strings(result.Manifests)
+
+	undeclared := make(map[string]*domain.PackageFinding)
+	devOnly := make(map[string]*domain.PackageFinding)
+	deep := make(map[string]*domain.PackageFinding)
+	used := make(map[*domain.PackageManifest]map[string]bool)
+	owned := make(map[*domain.PackageManifest]bool)
+
+	for filePath, info := range modules {
+		owner, ok := a.owner(filePath)
+		if !ok {
+			continue
+		}
+		owned[owner.manifest] = true
+
+		for _, imp := range packageImports(filePath, info) {
+			if imp.name == owner.manifest.Name || a.ignored[imp.name] {
+				continue
+			}
+			for _, manifest := range []*domain.PackageManifest{owner.manifest, owner.root} {
+				if manifest == nil {
+					continue
+				}
+				if used[manifest] == nil {
+					used[manifest] = make(map[string]bool)
+				}
+				used[manifest][imp.name] = true
+				used[manifest][typesPackage(imp.name)] = true
+			}
+
+			if IsDeepImport(imp.subpath) {
+				addPackageSite(deep, owner.manifest.Path, imp.name, "", imp.site)
+			}
+
+			_, _, declared := owner.declares(imp.name, domain.PackageSections...)
+			if !declared && imp.isTypeOnly {
+				_, _, declared = owner.declares(typesPackage(imp.name), domain.PackageSections...)
+			}
+			if !declared {
+				addPackageSite(undeclared, owner.manifest.Path, imp.name, "", imp.site)
+				continue
+			}
+
+			if owner.isDev || imp.isTypeOnly {
+				continue
+			}
+			if _, _, ok := owner.declares(imp.name, domain.PackageSectionDependencies,
+				domain.PackageSectionPeerDependencies, domain.PackageSectionOptionalDependencies); ok {
+				continue
+			}
+			if manifest, version, ok := owner.declares(imp.name, domain.PackageSectionDevDependencies); ok {
+				addPackageSite(devOnly, manifest.Path, imp.name, version, imp.site)
+			}
+		}
+	}
+
+	for _, manifest := range a.manifests {
+		if !owned[manifest] {
+			continue
+		}
+		for name, version := range manifest.Dependencies {
+			if used[manifest][name] || a.ignored[name] || strings.HasPrefix(name, "@types/") ||
+				usedByScripts(manifest, name) {
+				continue
+			}
+			result.Unused = append(result.Unused, domain.PackageFinding{
+				Package:  name,
+				Manifest: manifest.Path,
+				Version:  version,
+			})
+		}
+	}
+
+	result.Undeclared = sortedPackageFindings(undeclared)
+	result.DevOnly = sortedPackageFindings(devOnly)
+	result.DeepImports = sortedPackageFindings(deep)
+	sortPackageFindings(result.Unused)
+	result.Duplicates = a.duplicates()
+	return result
+}
+
+// owner finds the nearest package.json above a file and the workspace root above it
+func (a *PackageHygieneAnalyzer) owner(filePath string) (packageOwner, bool) {
+	var owner packageOwner
+	for _, manifest := range a.manifests {
+		if !isInsideDir(filePath, manifest.Dir()) {
+			continue
+		}
+		if owner.manifest == nil || len(manifest.Dir()) > len(owner.manifest.Dir()) {
+			owner.manifest = manifest
+		}
+	}
+	if owner.manifest == nil {
+		return owner, false
+	}
+	for _, manifest := range a.manifests {
+		if manifest == owner.manifest || len(manifest.Workspaces) == 0 || !isInsideDir(owner.manifest.Path, manifest.Dir()) {
+			continue
+		}
+		if owner.root == nil || len(manifest.Dir()) > len(owner.root.Dir()) {
+			owner.root = manifest
+		}
+	}
+
+	rel, err := filepath.Rel(owner.manifest.Dir(), filePath)
+	if err != nil {
+		rel = filePath
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range a.options.DevFilePatterns {
+		if matchModuleGlob(pattern, rel) {
+			owner.isDev = true
+			break
+		}
+	}
+	return owner, true
+}
+
+// duplicates returns the packages declared with different version ranges in the
+// dependencies, devDependencies or optionalDependencies of several package.json files.
+// Workspace protocol ranges ("workspace:*") link local packages and are ignored.
+func (a *PackageHygieneAnalyzer) duplicates() []domain.DuplicatePackage {
+	declarations := make(map[string][]domain.PackageDeclaration)
+	for _, manifest := range a.manifests {
+		for _, section := range []string{domain.PackageSectionDependencies, domain.PackageSectionDevDependencies,
+			domain.PackageSectionOptionalDependencies} {
+			for name, version := range manifest.Section(section) {
+				if a.ignored[name] || strings.HasPrefix(version, "workspace:") {
+					continue
+				}
+				declarations[name] = append(declarations[name], domain.PackageDeclaration{
+					Manifest: manifest.Path,
+					Section:  section,
+					Version:  version,
+				})
+			}
+		}
+	}
+
+	duplicates := []domain.DuplicatePackage{}
+	for name, decls := range declarations {
+		versions := make(map[string]bool)
+		for _, decl := range decls {
+			versions[decl.Version] = true
+		}
+		if len(versions) < 2 {
+			continue
+		}
+		sort.Slice(decls, func(i, j int) bool {
+			if decls[i].Manifest != decls[j].Manifest {
+				return decls[i].Manifest < decls[j].Manifest
+			}
+			return decls[i].Section < decls[j].Section
+		})
+		duplicates = append(duplicates, domain.DuplicatePackage{Package: name, Declarations: decls})
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return duplicates[i].Package < duplicates[j].Package
+	})
+	return duplicates
+}
+
+// packageImports returns the npm package imports and re-exports of a file
+func packageImports(filePath string, info *domain.ModuleInfo) []packageImport {
+	var imports []packageImport
+	add := func(source string, sourceType domain.ModuleType, line int, isTypeOnly bool) {
+		if sourceType != domain.ModuleTypePackage {
+			return
+		}
+		name, subpath := PackageName(source)
+		if name == "" {
+			return
+		}
+		imports = append(imports, packageImport{
+			name:       name,
+			subpath:    subpath,
+			site:       domain.PackageImportSite{FilePath: filePath, Line: line, Source: source},
+			isTypeOnly: isTypeOnly,
+		})
+	}
+	for _, imp := range info.Imports {
+		add(imp.Source, imp.SourceType, imp.Location.StartLine, imp.IsTypeOnly)
+	}
+	for _, exp := range info.Exports {
+		if exp.Source != "" {
+			add(exp.Source, exp.SourceType, exp.Location.StartLine, exp.IsTypeOnly)
+		}
+	}
+	return imports
+}
+
+// usedByScripts reports whether a package.json script runs the package's binary,
+// which is assumed to be named after the package
+func usedByScripts(manifest *domain.PackageManifest, name string) bool {
+	binary := name[strings.LastIndex(name, "/")+1:]
+	for _, script := range manifest.Scripts {
+		for _, word := range strings.FieldsFunc(script, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == '&' || r == '|' || r == ';' || r == '(' || r == ')'
+		}) {
+			if word == name || word == binary {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addPackageSite records an import site of a package finding keyed by package.json
+// and package
+func addPackageSite(findings map[string]*domain.PackageFinding, manifest, name, version string, site domain.PackageImportSite) {
+	key := manifest + "\x00" + name
+	finding, ok := findings[key]
+	if !ok {
+		finding = &domain.PackageFinding{Package: name, Manifest: manifest, Version: version}
+		findings[key] = finding
+	}
+	finding.Sites = append(finding.Sites, site)
+}
+
+// sortedPackageFindings returns the findings ordered by package and package.json, with
+// their import sites ordered by file and line
+func sortedPackageFindings(findings map[string]*domain.PackageFinding) []domain.PackageFinding {
+	sorted := make([]domain.PackageFinding, 0, len(findings))
+	for _, finding := range findings {
+		sort.Slice(finding.Sites, func(i, j int) bool {
+			if finding.Sites[i].FilePath != finding.Sites[j].FilePath {
+				return finding.Sites[i].FilePath < finding.Sites[j].FilePath
+			}
+			return finding.Sites[i].Line < finding.Sites[j].Line
+		})
+		sorted = append(sorted, *finding)
+	}
+	sortPackageFindings(sorted)
+	return sorted
+}
+
+// sortPackageFindings orders findings by package and package.json
+func sortPackageFindings(findings []domain.PackageFinding) {
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Package != findings[j].Package {
+			return findings[i].Package < findings[j].Package
+		}
+		return findings[i].Manifest < findings[j].Manifest
+	})
+}
+
+// isInsideDir reports whether a path is inside a directory
+func isInsideDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		source, name, subpath string
+	}{
+		{"react", "react", ""},
+		{"lodash/fp", "lodash", "fp"},
+		{"@scope/pkg", "@scope/pkg", ""},
+		{"@scope/pkg/dist/index.js", "@scope/pkg", "dist/index.js"},
+		{"#utils", "", ""},
+		{"virtual:pwa-register", "", ""},
+		{"@scope", "", ""},
+	}
+	for _, tt := range tests {
+		name, subpath := PackageName(tt.source)
+		if name != tt.name || subpath != tt.subpath {
+			t.Errorf("PackageName(%q) = %q, %q, want %q, %q", tt.source, name, subpath, tt.name, tt.subpath)
+		}
+	}
+
+	for subpath, want := range map[string]bool{
+		"":                     false,
+		"fp":                   false,
+		"client":               false,
+		"lib/utils":            true,
+		"dist/esm/index.js":    true,
+		"internal/operators":   true,
+		"operators/_internal":  true,
+		"locale/en-US/library": false,
+	} {
+		if got := IsDeepImport(subpath); got != want {
+			t.Errorf("IsDeepImport(%q) = %v, want %v", subpath, got, want)
+		}
+	}
+}
+
+func TestParsePackageManifest_Workspaces(t *testing.T) {
+	manifest, err := ParsePackageManifest("/repo/package.json", []byte(`{"name": "root", "workspaces": {"packages": ["packages/*"]}}`))
+	if err != nil {
+		t.Fatalf("ParsePackageManifest failed: %v", err)
+	}
+	if manifest.Name != "root" || !reflect.DeepEqual(manifest.Workspaces, []string{"packages/*"}) {
+		t.Errorf("Unexpected manifest %+v", manifest)
+	}
+
+	if _, err := ParsePackageManifest("/repo/package.json", []byte(`{"workspaces": 1}`)); err == nil {
+		t.Error("Expected an error for invalid workspaces")
+	}
+}
+
+func packageModule(imports ...*domain.Import) *domain.ModuleInfo {
+	for i, imp := range imports {
+		imp.SourceType = domain.ModuleTypePackage
+		imp.Location.StartLine = i + 1
+	}
+	return &domain.ModuleInfo{Imports: imports}
+}
+
+func TestPackageHygieneAnalyzer(t *testing.T) {
+	root := &domain.PackageManifest{
+		Path:            "/repo/package.json",
+		Workspaces:      []string{"packages/*"},
+		DevDependencies: map[string]string{"eslint": "^9.0.0", "vitest": "^2.0.0"},
+	}
+	web := &domain.PackageManifest{
+		Path:            "/repo/packages/web/package.json",
+		Name:            "@acme/web",
+		Dependencies:    map[string]string{"react": "^18.2.0", "lodash": "^4.17.0", "left-pad": "1.3.0", "next": "14.0.0", "@types/react": "^18.0.0"},
+		DevDependencies: map[string]string{"msw": "^2.0.0", "@types/node": "^20.0.0"},
+		Scripts:         map[string]string{"build": "next build"},
+	}
+	api := &domain.PackageManifest{
+		Path:         "/repo/packages/api/package.json",
+		Dependencies: map[string]string{"react": "^17.0.2"},
+	}
+
+	modules := map[string]*domain.ModuleInfo{
+		"/repo/packages/web/src/app.tsx": packageModule(
+			&domain.Import{Source: "react"},
+			&domain.Import{Source: "lodash/lib/debounce"},
+			&domain.Import{Source: "msw"},
+			&domain.Import{Source: "zod"},
+			&domain.Import{Source: "@acme/web/utils"},
+			&domain.Import{Source: "node", IsTypeOnly: true},
+		),
+		"/repo/packages/web/src/app.test.tsx": packageModule(
+			&domain.Import{Source: "msw"},
+			&domain.Import{Source: "vitest"},
+		),
+		"/repo/packages/web/vite.config.ts": packageModule(
+			&domain.Import{Source: "vite"},
+		),
+	}
+
+	result := NewPackageHygieneAnalyzer([]*domain.PackageManifest{root, web, api}, domain.PackageHygieneOptions{
+		IgnorePackages: []string{"vite"},
+	}).Analyze(modules)
+
+	names := func(findings []domain.PackageFinding) []string {
+		var got []string
+		for _, f := range findings {
+			got = append(got, f.Package)
+		}
+		return got
+	}
+
+	// next runs from the scripts and @types packages are never unused
+	if got := names(result.Unused); !reflect.DeepEqual(got, []string{"left-pad"}) {
+		t.Errorf("Expected left-pad to be unused, got %v", got)
+	}
+	// zod is undeclared; the type-only import of node is covered by @types/node
+	if got := names(result.Undeclared); !reflect.DeepEqual(got, []string{"zod"}) {
+		t.Errorf("Expected zod to be undeclared, got %v", got)
+	}
+	// msw is only a devDependency of web; the test file may use it
+	if len(result.DevOnly) != 1 || result.DevOnly[0].Package != "msw" || len(result.DevOnly[0].Sites) != 1 ||
+		result.DevOnly[0].Sites[0].FilePath != "/repo/packages/web/src/app.tsx" || result.DevOnly[0].Manifest != web.Path {
+		t.Errorf("Expected msw used in production from app.tsx, got %+v", result.DevOnly)
+	}
+	if len(result.DeepImports) != 1 || result.DeepImports[0].Sites[0].Source != "lodash/lib/debounce" {
+		t.Errorf("Expected the lodash deep import, got %+v", result.DeepImports)
+	}
+	if len(result.Duplicates) != 1 || result.Duplicates[0].Package != "react" || len(result.Duplicates[0].Declarations) != 2 {
+		t.Errorf("Expected react to be declared twice, got %+v", result.Duplicates)
+	}
+	if result.TotalIssues() != 5 {
+		t.Errorf("Expected 5 issues, got %d", result.TotalIssues())
+	}
+}
@@ -0,0 +1,439 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// hookNamePattern matches hook names such as useState; the use() API may be called
+// conditionally and does not match
+var hookNamePattern = regexp.MustCompile(`^use[A-Z0-9]`)
+
+// componentWrappers are the calls returning a component for their function argument
+var componentWrappers = map[string]bool{"memo": true, "forwardRef": true}
+
+// reactVisitor finds the function components and custom hooks of a file
+type reactVisitor struct {
+	thresholds domain.ReactThresholds
+	result     *domain.FileReact
+	names      map[*parser.Node]string // anonymous functions by the variable they are assigned to
+	visited    map[*parser.Node]bool
+}
+
+// AnalyzeReact measures the function components of a file and checks the rules of
+// hooks in components and custom hooks. A component is a function with a PascalCase
+// name, possibly wrapped in memo or forwardRef, that renders JSX; class components
+// are not analyzed.
+func AnalyzeReact(ast *parser.Node, filePath string, thresholds domain.ReactThresholds) *domain.FileReact {
+	v := &reactVisitor{
+		thresholds: thresholds,
+		result: &domain.FileReact{
+			FilePath:       filePath,
+			Components:     []domain.ReactComponent{},
+			HookViolations: []domain.HookViolation{},
+		},
+		names:   make(map[*parser.Node]string),
+		visited: make(map[*parser.Node]bool),
+	}
+	ast.Walk(func(n *parser.Node) bool {
+		if n.Type == "variable_declarator" {
+			v.nameAssignedFunction(n)
+		}
+		return true
+	})
+	v.scan(ast, "")
+
+	sort.SliceStable(v.result.Components, func(i, j int) bool {
+		return v.result.Components[i].StartLine < v.result.Components[j].StartLine
+	})
+	sort.SliceStable(v.result.HookViolations, func(i, j int) bool {
+		return v.result.HookViolations[i].Line < v.result.HookViolations[j].Line
+	})
+	return v.result
+}
+
+// nameAssignedFunction names the anonymous function assigned by a variable
+// declarator, looking through memo and forwardRef
+func (v *reactVisitor) nameAssignedFunction(declarator *parser.Node) {
+	if len(declarator.Children) == 0 || declarator.Children[0].Type != parser.NodeIdentifier {
+		return
+	}
+	for _, child := range declarator.Children[1:] {
+		if fn := unwrapComponentWrapper(child); fn != nil && fn.Name == "" {
+			v.names[fn] = declarator.Children[0].Name
+		}
+	}
+}
+
+// scan visits the functions under root; owner is the innermost enclosing component
+func (v *reactVisitor) scan(root *parser.Node, owner string) {
+	root.Walk(func(n *parser.Node) bool {
+		if n == root {
+			return true
+		}
+		// The program holds its statements in both Children and Body
+		if v.visited[n] {
+			return false
+		}
+		v.visited[n] = true
+		if n.IsFunction() {
+			v.visitFunction(n, owner)
+			return false
+		}
+		return true
+	})
+}
+
+// visitFunction records a component, checks the hooks of components and custom
+// hooks, and scans the nested functions
+func (v *reactVisitor) visitFunction(fn *parser.Node, owner string) {
+	name := v.functionName(fn)
+	switch {
+	case v.isComponent(fn):
+		v.result.Components = append(v.result.Components, v.measureComponent(fn, name, owner))
+		v.checkHooks(fn, name)
+		v.scan(fn, name)
+	case hookNamePattern.MatchString(name):
+		v.checkHooks(fn, name)
+		v.scan(fn, owner)
+	default:
+		v.scan(fn, owner)
+	}
+}
+
+// functionName returns the declared name of a function or the variable it is assigned to
+func (v *reactVisitor) functionName(fn *parser.Node) string {
+	if fn.Name != "" {
+		return fn.Name
+	}
+	return v.names[fn]
+}
+
+// isComponent reports whether a function is a function component
+func (v *reactVisitor) isComponent(fn *parser.Node) bool {
+	if fn.Type == parser.NodeMethodDefinition {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(v.functionName(fn))
+	return unicode.IsUpper(r) && containsJSX(fn)
+}
+
+// isInlineCallback reports whether a function is an anonymous callback, such as
+// the render function passed to map
+func (v *reactVisitor) isInlineCallback(fn *parser.Node) bool {
+	return v.functionName(fn) == ""
+}
+
+// measureComponent computes the metrics of a component
+func (v *reactVisitor) measureComponent(fn *parser.Node, name, owner string) domain.ReactComponent {
+	component := domain.ReactComponent{
+		Name:      name,
+		StartLine: fn.Location.StartLine,
+		EndLine:   fn.Location.EndLine,
+		JSXDepth:  v.jsxDepth(fn),
+		Props:     countProps(fn),
+		DefinedIn: owner,
+	}
+
+	walkOwnBody(fn, func(n *parser.Node) {
+		if reactHookName(n) != "" {
+			component.Hooks++
+		}
+		switch n.Type {
+		case parser.NodeIfStatement:
+			if containsReturn(n.Consequent) {
+				component.ConditionalRenders++
+			}
+		case parser.NodeCaseClause, parser.NodeDefaultClause:
+			if containsReturn(n) {
+				component.ConditionalRenders++
+			}
+		}
+	})
+	// Conditional JSX counts in render callbacks too
+	fn.Walk(func(n *parser.Node) bool {
+		if n != fn && n.IsFunction() && !v.isInlineCallback(n) {
+			return false
+		}
+		switch n.Type {
+		case parser.NodeConditionalExpression:
+			if isJSX(n.Consequent) || isJSX(n.Alternate) {
+				component.ConditionalRenders++
+			}
+		case parser.NodeLogicalExpression:
+			if isJSX(n.Right) {
+				component.ConditionalRenders++
+			}
+		}
+		return true
+	})
+
+	t := v.thresholds
+	for _, limit := range []struct {
+		metric     string
+		value, max int
+	}{
+		{domain.ReactMetricJSXDepth, component.JSXDepth, t.MaxJSXDepth},
+		{domain.ReactMetricProps, component.Props, t.MaxProps},
+		{domain.ReactMetricHooks, component.Hooks, t.MaxHooks},
+		{domain.ReactMetricConditionalRenders, component.ConditionalRenders, t.MaxConditionalRenders},
+	} {
+		if limit.max > 0 && limit.value > limit.max {
+			component.Exceeds = append(component.Exceeds, limit.metric)
+		}
+	}
+	return component
+}
+
+// jsxDepth returns the deepest nesting of JSX elements under n, following render
+// callbacks but not nested named functions
+func (v *reactVisitor) jsxDepth(n *parser.Node) int {
+	depth := 0
+	for _, child := range childNodes(n) {
+		if child.IsFunction() && !v.isInlineCallback(child) {
+			continue
+		}
+		if d := v.jsxDepth(child); d > depth {
+			depth = d
+		}
+	}
+	if n.Type == parser.NodeJSXElement || n.Type == parser.NodeJSXFragment {
+		depth++
+	}
+	return depth
+}
+
+// checkHooks reports the hooks of a component or custom hook called conditionally,
+// in loops, in nested functions or after a conditional return
+func (v *reactVisitor) checkHooks(fn *parser.Node, name string) {
+	earlyReturn := false
+	for _, stmt := range fn.Body {
+		reason := ""
+		if earlyReturn {
+			reason = domain.HookAfterReturn
+		}
+		v.checkHookCalls(stmt, reason, name)
+		if stmt.Type != parser.NodeReturnStatement && containsReturn(stmt) {
+			earlyReturn = true
+		}
+	}
+}
+
+// checkHookCalls reports the hook calls under n; reason is why a hook is not
+// allowed there, or empty at the top level of the function
+func (v *reactVisitor) checkHookCalls(n *parser.Node, reason, function string) {
+	if n == nil {
+		return
+	}
+	switch n.Type {
+	case parser.NodeIfStatement, parser.NodeConditionalExpression:
+		v.checkHookCalls(n.Test, reason, function)
+		branch := firstHookReason(reason, domain.HookInCondition)
+		v.checkHookCalls(n.Consequent, branch, function)
+		v.checkHookCalls(n.Alternate, branch, function)
+		return
+	case parser.NodeLogicalExpression:
+		v.checkHookCalls(n.Left, reason, function)
+		v.checkHookCalls(n.Right, firstHookReason(reason, domain.HookInCondition), function)
+		return
+	case parser.NodeSwitchStatement:
+		reason = firstHookReason(reason, domain.HookInCondition)
+	case parser.NodeForStatement, parser.NodeForInStatement, parser.NodeForOfStatement,
+		parser.NodeWhileStatement, parser.NodeDoWhileStatement:
+		reason = firstHookReason(reason, domain.HookInLoop)
+	case parser.NodeCallExpression:
+		if hook := reactHookName(n); hook != "" && reason != "" {
+			v.result.HookViolations = append(v.result.HookViolations, domain.HookViolation{
+				Hook:     hook,
+				Function: function,
+				Line:     n.Location.StartLine,
+				Reason:   reason,
+			})
+		}
+	}
+	if n.IsFunction() {
+		// Nested components and custom hooks are checked on their own
+		if v.isComponent(n) || hookNamePattern.MatchString(v.functionName(n)) {
+			return
+		}
+		reason = firstHookReason(reason, domain.HookInCallback)
+	}
+	for _, child := range childNodes(n) {
+		v.checkHookCalls(child, reason, function)
+	}
+}
+
+// firstHookReason keeps the outermost reason a hook call is not allowed
+func firstHookReason(reason, inner string) string {
+	if reason != "" {
+		return reason
+	}
+	return inner
+}
+
+// reactHookName returns the hook called by a call expression such as useState() or
+// React.useState(), or "" if it does not call a hook
+func reactHookName(n *parser.Node) string {
+	if n.Type != parser.NodeCallExpression {
+		return ""
+	}
+	if name := reactCalleeName(n.Callee); hookNamePattern.MatchString(name) {
+		return name
+	}
+	return ""
+}
+
+// reactCalleeName returns the called identifier, or the property of a member callee
+func reactCalleeName(callee *parser.Node) string {
+	if callee == nil {
+		return ""
+	}
+	switch callee.Type {
+	case parser.NodeIdentifier:
+		return callee.Name
+	case parser.NodeMemberExpression:
+		if callee.Property != nil {
+			return callee.Property.Name
+		}
+	}
+	return ""
+}
+
+// unwrapComponentWrapper returns the function of n, looking through memo() and
+// forwardRef(), or nil if n is not a function
+func unwrapComponentWrapper(n *parser.Node) *parser.Node {
+	switch {
+	case n.IsFunction():
+		return n
+	case n.Type == parser.NodeCallExpression && componentWrappers[reactCalleeName(n.Callee)] && len(n.Arguments) > 0:
+		return unwrapComponentWrapper(n.Arguments[0])
+	}
+	return nil
+}
+
+// countProps counts the destructured props of a component, or the distinct props
+// read from its props parameter
+func countProps(fn *parser.Node) int {
+	if len(fn.Params) == 0 {
+		return 0
+	}
+	param := fn.Params[0]
+	if (param.Type == "required_parameter" || param.Type == "optional_parameter") && len(param.Children) > 0 {
+		param = param.Children[0]
+	}
+
+	switch param.Type {
+	case "object_pattern":
+		return countPatternEntries(param)
+	case parser.NodeIdentifier:
+		read := make(map[string]bool)
+		destructured := 0
+		fn.Walk(func(n *parser.Node) bool {
+			switch n.Type {
+			case parser.NodeMemberExpression:
+				if isIdentifierNamed(n.Object, param.Name) && n.Property != nil {
+					read[n.Property.Name] = true
+				}
+			case "variable_declarator":
+				// const { a, b } = props
+				if len(n.Children) > 0 && n.Children[0].Type == "object_pattern" &&
+					isIdentifierNamed(n.Children[len(n.Children)-1], param.Name) {
+					destructured += countPatternEntries(n.Children[0])
+				}
+			}
+			return true
+		})
+		return len(read) + destructured
+	}
+	return 0
+}
+
+// countPatternEntries counts the named entries of an object pattern; a rest entry
+// stands for an unknown number of props and is not counted
+func countPatternEntries(pattern *parser.Node) int {
+	count := 0
+	for _, child := range pattern.Children {
+		switch child.Type {
+		case "{", "}", ",", "rest_pattern", "comment":
+		default:
+			count++
+		}
+	}
+	return count
+}
+
+// isIdentifierNamed reports whether n is the identifier name
+func isIdentifierNamed(n *parser.Node, name string) bool {
+	return n != nil && n.Type == parser.NodeIdentifier && n.Name == name
+}
+
+// isJSX reports whether an expression is a JSX element or fragment
+func isJSX(n *parser.Node) bool {
+	for n != nil && n.Type == "parenthesized_expression" {
+		var inner *parser.Node
+		for _, child := range n.Children {
+			if child.Type != "(" && child.Type != ")" {
+				inner = child
+				break
+			}
+		}
+		n = inner
+	}
+	return n != nil && (n.Type == parser.NodeJSXElement || n.Type == parser.NodeJSXFragment)
+}
+
+// containsJSX reports whether a JSX element or fragment occurs under n
+func containsJSX(n *parser.Node) bool {
+	found := false
+	n.Walk(func(child *parser.Node) bool {
+		if child.Type == parser.NodeJSXElement || child.Type == parser.NodeJSXFragment {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// containsReturn reports whether a return statement occurs under n outside nested
+// functions
+func containsReturn(n *parser.Node) bool {
+	if n == nil {
+		return false
+	}
+	found := false
+	walkOwnBody(n, func(child *parser.Node) {
+		if child.Type == parser.NodeReturnStatement {
+			found = true
+		}
+	})
+	return found
+}
+
+// walkOwnBody calls visit for root and the nodes under it, stopping at nested functions
+func walkOwnBody(root *parser.Node, visit func(*parser.Node)) {
+	root.Walk(func(n *parser.Node) bool {
+		if n != root && n.IsFunction() {
+			return false
+		}
+		visit(n)
+		return true
+	})
+}
+
+// childNodes returns the direct children of n in all of its fields
+func childNodes(n *parser.Node) []*parser.Node {
+	var children []*parser.Node
+	n.Walk(func(child *parser.Node) bool {
+		if child == n {
+			return true
+		}
+		children = append(children, child)
+		return false
+	})
+	return children
+}
@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+func analyzeReactOf(t *testing.T, source string, thresholds domain.ReactThresholds) *domain.FileReact {
+	t.Helper()
+	p := parser.NewTypeScriptParser()
+	defer p.Close()
+	ast, err := p.ParseFile("/repo/src/App.tsx", []byte(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return AnalyzeReact(ast, "/repo/src/App.tsx", thresholds)
+}
+
+func TestAnalyzeReact_Components(t *testing.T) {
+	result := analyzeReactOf(t, `export const List = memo(({ items, title, onSelect }: Props) => {
+  const [open, setOpen] = useState(false);
+  const ref = React.useRef(null);
+  if (!items) return null;
+  return (
+    <div className="list">
+      {open && <Header title={title} />}
+      <ul>{items.map(i => <li key={i.id}><Item {...i} /></li>)}</ul>
+      {title ? <b>{title}</b> : <></>}
+    </div>
+  );
+});
+
+function Card(props) {
+  const { footer } = props;
+  function Body() {
+    return <span>{props.text}</span>;
+  }
+  return <section title={props.title}><Body />{footer}</section>;
+}
+
+function formatTitle(title) {
+  return title.trim();
+}
+`, domain.ReactThresholds{MaxJSXDepth: 3, MaxProps: 5, MaxHooks: 5, MaxConditionalRenders: 2})
+
+	if len(result.Components) != 3 {
+		t.Fatalf("Expected 3 components, got %+v", result.Components)
+	}
+
+	list := result.Components[0]
+	if list.Name != "List" || list.JSXDepth != 4 || list.Props != 3 || list.Hooks != 2 || list.ConditionalRenders != 3 {
+		t.Errorf("Unexpected List metrics %+v", list)
+	}
+	if want := []string{domain.ReactMetricJSXDepth, domain.ReactMetricConditionalRenders}; !reflect.DeepEqual(list.Exceeds, want) {
+		t.Errorf("Expected List to exceed %v, got %v", want, list.Exceeds)
+	}
+
+	card, body := result.Components[1], result.Components[2]
+	if card.Name != "Card" || card.Props != 3 || card.DefinedIn != "" || len(card.Exceeds) != 0 {
+		t.Errorf("Unexpected Card metrics %+v", card)
+	}
+	if body.Name != "Body" || body.DefinedIn != "Card" {
+		t.Errorf("Expected Body to be defined in Card, got %+v", body)
+	}
+}
+
+func TestAnalyzeReact_HookViolations(t *testing.T) {
+	result := analyzeReactOf(t, `function Profile({ user }) {
+  if (user.admin) {
+    useEffect(() => {});
+  }
+  for (const role of user.roles) {
+    useMemo(() => role);
+  }
+  const onClick = () => {
+    const [x] = useState(0);
+  };
+  const ok = useCallback(() => {}, []);
+  if (!user) return null;
+  const theme = useContext(Theme);
+  return <div onClick={onClick}>{theme}</div>;
+}
+
+function useUser(id) {
+  const enabled = id && useFlag('users');
+  return useQuery(id);
+}
+`, domain.ReactThresholds{})
+
+	got := make([]string, 0, len(result.HookViolations))
+	for _, v := range result.HookViolations {
+		got = append(got, v.Function+":"+v.Hook+":"+v.Reason)
+	}
+	want := []string{
+		"Profile:useEffect:condition",
+		"Profile:useMemo:loop",
+		"Profile:useState:callback",
+		"Profile:useContext:early_return",
+		"useUser:useFlag:condition",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected hook violations %v, got %v", want, got)
+	}
+
+	if len(result.Components) != 1 || result.Components[0].Hooks != 4 {
+		t.Errorf("Expected Profile with 4 hooks, got %+v", result.Components)
+	}
+}
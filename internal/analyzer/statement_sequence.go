@@ -0,0 +1,390 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// maxSequenceBucketSize caps the number of equal statement windows paired with each
+// other; windows of larger buckets are only paired with the first one
+const maxSequenceBucketSize = 50
+
+// StatementBlock is a run of consecutive statements within one block
+type StatementBlock struct {
+	FilePath   string
+	Statements []*parser.Node
+	hashes     []uint64 // Structural hash per statement, identifiers and literals ignored
+}
+
+// sequenceMatch represents a maximal run of structurally equal statements in two blocks
+type sequenceMatch struct {
+	block1, start1 int
+	block2, start2 int
+	length         int
+}
+
+// lineSpan returns the number of lines covered by the first sequence of the match
+func (m sequenceMatch) lineSpan(blocks []*StatementBlock) int {
+	statements := blocks[m.block1].Statements
+	return statements[m.start1+m.length-1].Location.EndLine - statements[m.start1].Location.StartLine + 1
+}
+
+// sequenceWindow is an occurrence of a MinStatements-long statement window
+type sequenceWindow struct {
+	hash  uint64
+	block int
+	start int
+}
+
+// ExtractStatementBlocks collects statement runs of at least MinStatements statements
+// from function bodies and nested blocks below the given nodes. Top-level statements
+// are skipped since they are mostly imports and module wiring.
+func (cd *CloneDetector) ExtractStatementBlocks(astNodes []*parser.Node, filePath string) []*StatementBlock {
+	var blocks []*StatementBlock
+
+	for _, node := range astNodes {
+		node.Walk(func(n *parser.Node) bool {
+			// Class bodies hold member declarations, not statements
+			if !isClassNode(n) {
+				cd.appendStatementBlocks(n.Body, filePath, &blocks)
+			}
+			return true
+		})
+	}
+
+	return blocks
+}
+
+// appendStatementBlocks splits a node list into runs of consecutive statements.
+// Nested functions and classes end a run since they are fragments of their own.
+func (cd *CloneDetector) appendStatementBlocks(nodes []*parser.Node, filePath string, blocks *[]*StatementBlock) {
+	minStatements := maxInt(2, cd.cloneDetectorConfig.MinStatements)
+
+	var run []*parser.Node
+	flush := func() {
+		if len(run) >= minStatements {
+			*blocks = append(*blocks, &StatementBlock{FilePath: filePath, Statements: run})
+		}
+		run = nil
+	}
+
+	for _, node := range nodes {
+		if node != nil && isSequenceStatement(node) {
+			run = append(run, node)
+		} else {
+			flush()
+		}
+	}
+	flush()
+}
+
+// isSequenceStatement reports whether a block item can be part of a statement sequence.
+// Expression statements are unwrapped by the parser, so any item other than a function,
+// class or method definition counts.
+func isSequenceStatement(node *parser.Node) bool {
+	if node.IsFunction() {
+		return false
+	}
+	return !isClassNode(node) && node.Type != parser.NodeMethodDefinition
+}
+
+// isClassNode reports whether a node is a class or class body
+func isClassNode(node *parser.Node) bool {
+	switch node.Type {
+	case parser.NodeClass, parser.NodeClassExpression, "class_body":
+		return true
+	}
+	return false
+}
+
+// DetectStatementSequenceClones finds duplicated statement sequences inside otherwise
+// different functions. Windows of MinStatements structurally equal statements are used
+// as seeds and extended to maximal matches; the resulting sequences are verified with
+// APTED. Sequences already covered by one of the existing clone pairs, or by a larger
+// sequence clone, are suppressed. Returns the existing pairs merged with the new ones
+// and regroups all of them.
+func (cd *CloneDetector) DetectStatementSequenceClones(ctx context.Context, blocks []*StatementBlock, existing []*domain.ClonePair) ([]*domain.ClonePair, []*domain.CloneGroup) {
+	cd.clonePairs = append([]*domain.ClonePair{}, existing...)
+	cd.cloneGroups = []*domain.CloneGroup{}
+
+	if isCancelled(ctx) {
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	matches := cd.findSequenceMatches(ctx, blocks)
+
+	// Verify longer sequences first so that contained sequences can be suppressed
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].lineSpan(blocks) > matches[j].lineSpan(blocks)
+	})
+
+	covered := newClonePairIndex(cd.clonePairs)
+	pairID := 0
+	for _, pair := range existing {
+		if pair.ID >= pairID {
+			pairID = pair.ID + 1
+		}
+	}
+
+	for _, m := range matches {
+		if isCancelled(ctx) {
+			break
+		}
+		fragment1 := cd.sequenceFragment(blocks[m.block1], m.start1, m.length)
+		fragment2 := cd.sequenceFragment(blocks[m.block2], m.start2, m.length)
+		if !cd.shouldIncludeFragment(fragment1) || !cd.shouldIncludeFragment(fragment2) {
+			continue
+		}
+		if locationsOverlap(fragment1.Location, fragment2.Location) ||
+			covered.covers(fragment1.Location, fragment2.Location) {
+			continue
+		}
+
+		pair := cd.compareFragments(fragment1, fragment2, pairID)
+		if pair == nil || !cd.isSignificantClone(pair) {
+			continue
+		}
+		cd.clonePairs = append(cd.clonePairs, pair)
+		covered.add(pair)
+		pairID++
+	}
+
+	cd.limitAndSortClonePairs(cd.cloneDetectorConfig.MaxClonePairs)
+
+	if isCancelled(ctx) {
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	cd.groupClonesWithStrategy(cd.newGroupingStrategy())
+
+	return cd.clonePairs, cd.cloneGroups
+}
+
+// findSequenceMatches finds maximal runs of at least MinStatements structurally equal statements
+func (cd *CloneDetector) findSequenceMatches(ctx context.Context, blocks []*StatementBlock) []sequenceMatch {
+	window := maxInt(2, cd.cloneDetectorConfig.MinStatements)
+
+	var windows []sequenceWindow
+	for b, block := range blocks {
+		if block.hashes == nil {
+			block.hashes = make([]uint64, len(block.Statements))
+			for i, stmt := range block.Statements {
+				block.hashes[i] = cd.statementHash(stmt)
+			}
+		}
+		for start := 0; start+window <= len(block.hashes); start++ {
+			windows = append(windows, sequenceWindow{
+				hash:  combineHashes(block.hashes[start : start+window]),
+				block: b,
+				start: start,
+			})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].hash != windows[j].hash {
+			return windows[i].hash < windows[j].hash
+		}
+		if windows[i].block != windows[j].block {
+			return windows[i].block < windows[j].block
+		}
+		return windows[i].start < windows[j].start
+	})
+
+	var matches []sequenceMatch
+	oversized := 0
+	defer func() {
+		if oversized > 0 {
+			cd.warnings = append(cd.warnings, fmt.Sprintf(
+				"%d statement sequences occur more than %d times; their occurrences were only compared with the first one",
+				oversized, maxSequenceBucketSize))
+		}
+	}()
+	for lo := 0; lo < len(windows); {
+		if isCancelled(ctx) {
+			return matches
+		}
+		hi := lo + 1
+		for hi < len(windows) && windows[hi].hash == windows[lo].hash {
+			hi++
+		}
+		bucket := windows[lo:hi]
+		lo = hi
+		firsts := len(bucket)
+		if len(bucket) > maxSequenceBucketSize {
+			firsts = 1
+			oversized++
+		}
+
+		for a := 0; a < firsts; a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				if m, ok := extendSequenceMatch(blocks, bucket[a], bucket[b], window); ok {
+					matches = append(matches, m)
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// extendSequenceMatch verifies a window match and extends it to a maximal match.
+// Returns false for hash collisions, self-overlapping matches and matches that are
+// not left-maximal (they are found from an earlier window instead).
+func extendSequenceMatch(blocks []*StatementBlock, w1, w2 sequenceWindow, window int) (sequenceMatch, bool) {
+	hashes1 := blocks[w1.block].hashes
+	hashes2 := blocks[w2.block].hashes
+	p1, p2 := w1.start, w2.start
+
+	sameBlock := w1.block == w2.block
+	if sameBlock && p2-p1 < window {
+		return sequenceMatch{}, false
+	}
+
+	if p1 > 0 && p2 > 0 && hashes1[p1-1] == hashes2[p2-1] {
+		return sequenceMatch{}, false
+	}
+
+	for k := 0; k < window; k++ {
+		if hashes1[p1+k] != hashes2[p2+k] {
+			return sequenceMatch{}, false
+		}
+	}
+
+	length := window
+	for p1+length < len(hashes1) && p2+length < len(hashes2) && hashes1[p1+length] == hashes2[p2+length] {
+		if sameBlock && p1+length >= p2 {
+			break
+		}
+		length++
+	}
+
+	return sequenceMatch{block1: w1.block, start1: p1, block2: w2.block, start2: p2, length: length}, true
+}
+
+// sequenceFragment creates a fragment for a statement sequence, wrapping the statements
+// in a synthetic block statement so that APTED can compare it
+func (cd *CloneDetector) sequenceFragment(block *StatementBlock, start, length int) *CodeFragment {
+	statements := block.Statements[start : start+length]
+	first := statements[0].Location
+	last := statements[len(statements)-1].Location
+
+	node := parser.NewNode(parser.NodeBlockStatement)
+	node.Body = statements
+	node.Location = parser.Location{
+		File:      block.FilePath,
+		StartLine: first.StartLine,
+		StartCol:  first.StartCol,
+		EndLine:   last.EndLine,
+		EndCol:    last.EndCol,
+	}
+
+	location := &CodeLocation{
+		FilePath:  block.FilePath,
+		StartLine: first.StartLine,
+		StartCol:  first.StartCol,
+		EndLine:   last.EndLine,
+		EndCol:    last.EndCol,
+	}
+
+	fragment := NewCodeFragment(location, node, "")
+	fragment.TreeNode = cd.converter.ConvertAST(node)
+	if fragment.TreeNode != nil {
+		PrepareTreeForAPTED(fragment.TreeNode)
+		// The converted tree includes expression children that calculateASTSize skips
+		fragment.Size = fragment.TreeNode.Size()
+	}
+	return fragment
+}
+
+// locationsOverlap reports whether two locations share lines of the same file,
+// e.g. a sequence matching a nested block of itself
+func locationsOverlap(loc1, loc2 *CodeLocation) bool {
+	return loc1.FilePath == loc2.FilePath && loc1.StartLine <= loc2.EndLine && loc2.StartLine <= loc1.EndLine
+}
+
+// clonePairIndex indexes clone pairs by the files of their two clones
+type clonePairIndex map[[2]string][]*domain.ClonePair
+
+func newClonePairIndex(pairs []*domain.ClonePair) clonePairIndex {
+	index := make(clonePairIndex)
+	for _, pair := range pairs {
+		index.add(pair)
+	}
+	return index
+}
+
+func (index clonePairIndex) add(pair *domain.ClonePair) {
+	if pair.Clone1 == nil || pair.Clone2 == nil || pair.Clone1.Location == nil || pair.Clone2.Location == nil {
+		return
+	}
+	key := [2]string{pair.Clone1.Location.FilePath, pair.Clone2.Location.FilePath}
+	index[key] = append(index[key], pair)
+}
+
+// covers reports whether a clone pair already contains both locations
+func (index clonePairIndex) covers(loc1, loc2 *CodeLocation) bool {
+	for _, pair := range index[[2]string{loc1.FilePath, loc2.FilePath}] {
+		if cloneContains(pair.Clone1.Location, loc1) && cloneContains(pair.Clone2.Location, loc2) {
+			return true
+		}
+	}
+	for _, pair := range index[[2]string{loc2.FilePath, loc1.FilePath}] {
+		if cloneContains(pair.Clone1.Location, loc2) && cloneContains(pair.Clone2.Location, loc1) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneContains reports whether a clone location covers all lines of loc
+func cloneContains(outer *domain.CloneLocation, loc *CodeLocation) bool {
+	return outer.FilePath == loc.FilePath && outer.StartLine <= loc.StartLine && loc.EndLine <= outer.EndLine
+}
+
+// statementHash computes a structural hash of a statement, ignoring identifier names and
+// literal values so that renamed copies hash equally
+func (cd *CloneDetector) statementHash(stmt *parser.Node) uint64 {
+	h := fnv.New64a()
+	writeStructure(h, cd.converter.ConvertAST(stmt))
+	return h.Sum64()
+}
+
+// writeStructure writes the normalized labels of a tree with nesting markers
+func writeStructure(w io.Writer, node *TreeNode) {
+	if node == nil {
+		return
+	}
+	label := node.Label
+	for _, prefix := range []string{"Identifier(", "Literal(", "Function(", "Class("} {
+		if strings.HasPrefix(label, prefix) {
+			label = prefix[:len(prefix)-1]
+			break
+		}
+	}
+	_, _ = w.Write([]byte(label))
+	_, _ = w.Write([]byte{'('})
+	for _, child := range node.Children {
+		writeStructure(w, child)
+	}
+	_, _ = w.Write([]byte{')'})
+}
+
+// combineHashes combines statement hashes into a window hash
+func combineHashes(hashes []uint64) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, v := range hashes {
+		binary.LittleEndian.PutUint64(buf[:], v)
+		_, _ = h.Write(buf[:])
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,195 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+const sequenceHandlers = `
+function createOrder(req, res) {
+  audit("create");
+  const body = req.body;
+  if (!body.customer) {
+    throw new Error("customer required");
+  }
+  if (!body.items || body.items.length === 0) {
+    throw new Error("items required");
+  }
+  const order = { customer: body.customer, items: body.items };
+  order.total = body.items.reduce((sum, item) => sum + item.price, 0);
+  return res.json(db.insert(order));
+}
+
+function updateOrder(req, res, id) {
+  const existing = db.find(id);
+  if (!existing) {
+    return res.status(404).end();
+  }
+  const payload = req.body;
+  if (!payload.customer) {
+    throw new Error("customer missing");
+  }
+  if (!payload.items || payload.items.length === 0) {
+    throw new Error("items missing");
+  }
+  const record = { customer: payload.customer, items: payload.items };
+  record.total = payload.items.reduce((acc, entry) => acc + entry.price, 0);
+  db.update(id, record);
+  notify(existing.customer);
+  return res.json(record);
+}
+`
+
+func parseStatementBlocks(t *testing.T, detector *CloneDetector, filePath, code string) ([]*StatementBlock, []*CodeFragment) {
+	t.Helper()
+	ast, err := parser.ParseForLanguage(filePath, []byte(code))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return detector.ExtractStatementBlocks(ast.Body, filePath), detector.ExtractFragments(ast.Body, filePath)
+}
+
+func TestExtractStatementBlocks(t *testing.T) {
+	detector := NewCloneDetector(DefaultCloneDetectorConfig())
+	blocks, _ := parseStatementBlocks(t, detector, "orders.js", sequenceHandlers)
+
+	if len(blocks) != 2 {
+		t.Fatalf("Expected 2 statement blocks (one per function body), got %d", len(blocks))
+	}
+	if len(blocks[0].Statements) != 7 {
+		t.Errorf("Expected 7 statements in first block, got %d", len(blocks[0].Statements))
+	}
+}
+
+func TestDetectStatementSequenceClones(t *testing.T) {
+	detector := NewCloneDetector(DefaultCloneDetectorConfig())
+	blocks, _ := parseStatementBlocks(t, detector, "orders.js", sequenceHandlers)
+
+	pairs, _ := detector.DetectStatementSequenceClones(context.Background(), blocks, nil)
+
+	var found *domain.ClonePair
+	for _, pair := range pairs {
+		if pair.Clone1.Location.StartLine == 4 || pair.Clone2.Location.StartLine == 4 {
+			found = pair
+		}
+	}
+	if found == nil {
+		t.Fatalf("Expected a clone of the duplicated validation block, got %d pairs", len(pairs))
+	}
+
+	// Maximal extension: the sequence covers the whole duplicated block, not just a window
+	loc1, loc2 := found.Clone1.Location, found.Clone2.Location
+	if loc1.StartLine > loc2.StartLine {
+		loc1, loc2 = loc2, loc1
+	}
+	if loc1.StartLine != 4 || loc1.EndLine != 12 {
+		t.Errorf("Expected first sequence at lines 4-12, got %d-%d", loc1.StartLine, loc1.EndLine)
+	}
+	if loc2.StartLine != 21 || loc2.EndLine != 29 {
+		t.Errorf("Expected second sequence at lines 21-29, got %d-%d", loc2.StartLine, loc2.EndLine)
+	}
+}
+
+func TestDetectStatementSequenceClones_SuppressesCoveredSequences(t *testing.T) {
+	detector := NewCloneDetector(DefaultCloneDetectorConfig())
+	blocks, _ := parseStatementBlocks(t, detector, "orders.js", sequenceHandlers)
+
+	// An existing pair covering both functions makes the sequence clone redundant
+	existing := []*domain.ClonePair{{
+		ID:         0,
+		Clone1:     &domain.Clone{Location: &domain.CloneLocation{FilePath: "orders.js", StartLine: 2, EndLine: 14}},
+		Clone2:     &domain.Clone{Location: &domain.CloneLocation{FilePath: "orders.js", StartLine: 16, EndLine: 33}},
+		Similarity: 0.9,
+		Type:       domain.Type3Clone,
+	}}
+
+	pairs, _ := detector.DetectStatementSequenceClones(context.Background(), blocks, existing)
+	if len(pairs) != 1 {
+		t.Errorf("Expected only the existing pair, got %d pairs", len(pairs))
+	}
+}
+
+func TestDetectStatementSequenceClones_NoOverlapInSameBlock(t *testing.T) {
+	code := `
+function repeat() {
+  a.push(1);
+  a.push(2);
+  a.push(3);
+  a.push(4);
+  a.push(5);
+  a.push(6);
+}
+`
+	config := DefaultCloneDetectorConfig()
+	config.MinLines = 1
+	config.MinNodes = 1
+	detector := NewCloneDetector(config)
+	blocks, _ := parseStatementBlocks(t, detector, "repeat.js", code)
+
+	pairs, _ := detector.DetectStatementSequenceClones(context.Background(), blocks, nil)
+	for _, pair := range pairs {
+		loc1, loc2 := pair.Clone1.Location, pair.Clone2.Location
+		if loc1.StartLine > loc2.StartLine {
+			loc1, loc2 = loc2, loc1
+		}
+		if loc1.EndLine >= loc2.StartLine {
+			t.Errorf("Expected non-overlapping sequences, got %d-%d and %d-%d",
+				loc1.StartLine, loc1.EndLine, loc2.StartLine, loc2.EndLine)
+		}
+	}
+}
+
+func TestDetectStatementSequenceClones_OversizedBucket(t *testing.T) {
+	// More copies than maxSequenceBucketSize: every copy is still paired with the first one
+	config := DefaultCloneDetectorConfig()
+	config.MinLines = 1
+	config.MinNodes = 1
+	detector := NewCloneDetector(config)
+	copies := maxSequenceBucketSize + 10
+	var blocks []*StatementBlock
+	for i := 0; i < copies; i++ {
+		fileBlocks, _ := parseStatementBlocks(t, detector, fmt.Sprintf("h%03d.js", i),
+			"function push() {\n  a.push(1);\n  b.push(2);\n  c.push(3);\n}\n")
+		blocks = append(blocks, fileBlocks...)
+	}
+
+	pairs, _ := detector.DetectStatementSequenceClones(context.Background(), blocks, nil)
+	found := make(map[string]bool)
+	for _, pair := range pairs {
+		file1, file2 := pair.Clone1.Location.FilePath, pair.Clone2.Location.FilePath
+		if file1 != "h000.js" && file2 != "h000.js" {
+			t.Errorf("Expected every copy to be paired with the first one, got %s and %s", file1, file2)
+		}
+		found[file1], found[file2] = true, true
+	}
+	if len(found) != copies {
+		t.Errorf("Expected all %d copies to be reported, got %d", copies, len(found))
+	}
+	if warnings := detector.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "statement sequences occur more than") {
+		t.Errorf("Expected a warning about the oversized bucket, got %v", warnings)
+	}
+}
+
+func TestClonePairIndexCovers(t *testing.T) {
+	index := newClonePairIndex([]*domain.ClonePair{{
+		Clone1: &domain.Clone{Location: &domain.CloneLocation{FilePath: "b.js", StartLine: 10, EndLine: 30}},
+		Clone2: &domain.Clone{Location: &domain.CloneLocation{FilePath: "a.js", StartLine: 1, EndLine: 20}},
+	}, {Clone1: &domain.Clone{}}})
+
+	a := &CodeLocation{FilePath: "a.js", StartLine: 5, EndLine: 10}
+	b := &CodeLocation{FilePath: "b.js", StartLine: 12, EndLine: 18}
+	if !index.covers(a, b) || !index.covers(b, a) {
+		t.Error("Expected the pair to cover both locations in either order")
+	}
+	if index.covers(a, &CodeLocation{FilePath: "b.js", StartLine: 25, EndLine: 35}) {
+		t.Error("Expected a location reaching past the clone not to be covered")
+	}
+	if index.covers(a, &CodeLocation{FilePath: "c.js", StartLine: 12, EndLine: 18}) {
+		t.Error("Expected a location in another file not to be covered")
+	}
+}
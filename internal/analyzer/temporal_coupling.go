@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// TemporalCouplingAnalyzer compares how often modules change together in the git
+// history with the import edges between them
+type TemporalCouplingAnalyzer struct {
+	graph   *domain.DependencyGraph
+	options domain.TemporalCouplingOptions
+}
+
+// NewTemporalCouplingAnalyzer creates a temporal coupling analyzer for the graph; zero
+// options take their defaults
+func NewTemporalCouplingAnalyzer(graph *domain.DependencyGraph, options domain.TemporalCouplingOptions) *TemporalCouplingAnalyzer {
+	return &TemporalCouplingAnalyzer{graph: graph, options: options.WithDefaults()}
+}
+
+// Analyze counts co-changes in commits whose file paths are module IDs of the graph
+// (other paths are ignored) and reports hidden and static-only pairs
+func (a *TemporalCouplingAnalyzer) Analyze(commits []domain.GitCommit) *domain.TemporalCouplingResult {
+	result := &domain.TemporalCouplingResult{
+		Options:    a.options,
+		Hidden:     []domain.TemporalCouplingPair{},
+		StaticOnly: []domain.TemporalCouplingPair{},
+	}
+
+	changes := make(map[string]int)
+	shared := make(map[[2]string]int)
+	for _, commit := range commits {
+		seen := make(map[string]bool)
+		var modules []string
+		for _, change := range commit.Files {
+			node := a.graph.GetNode(change.Path)
+			if node == nil || node.IsExternal || seen[change.Path] {
+				continue
+			}
+			seen[change.Path] = true
+			modules = append(modules, change.Path)
+		}
+		if len(modules) == 0 {
+			continue
+		}
+		result.Commits++
+		if len(modules) > a.options.MaxCommitFiles {
+			result.SkippedCommits++
+			continue
+		}
+
+		sort.Strings(modules)
+		for i, m := range modules {
+			changes[m]++
+			for _, other := range modules[i+1:] {
+				shared[[2]string{m, other}]++
+			}
+		}
+	}
+
+	for key, count := range shared {
+		if count < a.options.MinSharedCommits {
+			continue
+		}
+		pair := a.newPair(key, changes, count)
+		if pair.Degree < a.options.MinDegree || len(a.edgesBetween(key[0], key[1])) > 0 {
+			continue
+		}
+		pair.Kind = domain.TemporalHidden
+		result.Hidden = append(result.Hidden, pair)
+	}
+
+	judged := make(map[[2]string]bool)
+	for id, node := range a.graph.Nodes {
+		if node.IsExternal {
+			continue
+		}
+		for _, edge := range a.graph.GetOutgoingEdges(id) {
+			key := [2]string{edge.From, edge.To}
+			if key[1] < key[0] {
+				key = [2]string{key[1], key[0]}
+			}
+			if key[0] == key[1] || judged[key] {
+				continue
+			}
+			judged[key] = true
+			// Both modules need enough history for a low degree to mean something
+			if changes[key[0]] < a.options.MinSharedCommits || changes[key[1]] < a.options.MinSharedCommits {
+				continue
+			}
+			pair := a.newPair(key, changes, shared[key])
+			if pair.Degree >= a.options.MinDegree {
+				continue
+			}
+			pair.Kind = domain.TemporalStaticOnly
+			pair.Edges = a.edgesBetween(key[0], key[1])
+			result.StaticOnly = append(result.StaticOnly, pair)
+		}
+	}
+
+	sort.Slice(result.Hidden, func(i, j int) bool {
+		x, y := result.Hidden[i], result.Hidden[j]
+		if x.Degree != y.Degree {
+			return x.Degree > y.Degree
+		}
+		if x.SharedCommits != y.SharedCommits {
+			return x.SharedCommits > y.SharedCommits
+		}
+		return pairLess(x, y)
+	})
+	sort.Slice(result.StaticOnly, func(i, j int) bool {
+		x, y := result.StaticOnly[i], result.StaticOnly[j]
+		if x.Degree != y.Degree {
+			return x.Degree < y.Degree
+		}
+		if x.CommitsA+x.CommitsB != y.CommitsA+y.CommitsB {
+			return x.CommitsA+x.CommitsB > y.CommitsA+y.CommitsB
+		}
+		return pairLess(x, y)
+	})
+
+	return result
+}
+
+// newPair builds a pair with its degree: shared commits over the average commits of
+// the two modules, rounded to two decimals
+func (a *TemporalCouplingAnalyzer) newPair(key [2]string, changes map[string]int, sharedCommits int) domain.TemporalCouplingPair {
+	pair := domain.TemporalCouplingPair{
+		ModuleA:       key[0],
+		ModuleB:       key[1],
+		CommitsA:      changes[key[0]],
+		CommitsB:      changes[key[1]],
+		SharedCommits: sharedCommits,
+	}
+	if total := pair.CommitsA + pair.CommitsB; total > 0 {
+		pair.Degree = math.Round(float64(2*sharedCommits)/float64(total)*100) / 100
+	}
+	return pair
+}
+
+// edgesBetween returns the import edges between two modules in either direction
+func (a *TemporalCouplingAnalyzer) edgesBetween(x, y string) []*domain.DependencyEdge {
+	var edges []*domain.DependencyEdge
+	for _, edge := range a.graph.GetOutgoingEdges(x) {
+		if edge.To == y {
+			edges = append(edges, edge)
+		}
+	}
+	for _, edge := range a.graph.GetOutgoingEdges(y) {
+		if edge.To == x {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// pairLess orders pairs by module IDs
+func pairLess(x, y domain.TemporalCouplingPair) bool {
+	if x.ModuleA != y.ModuleA {
+		return x.ModuleA < y.ModuleA
+	}
+	return x.ModuleB < y.ModuleB
+}
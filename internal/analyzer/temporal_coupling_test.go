@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func temporalCommits(changeSets ...[]string) []domain.GitCommit {
+	var commits []domain.GitCommit
+	for _, files := range changeSets {
+		commit := domain.GitCommit{}
+		for _, file := range files {
+			commit.Files = append(commit.Files, domain.GitFileChange{Path: file})
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+func TestTemporalCouplingAnalyzer(t *testing.T) {
+	graph := buildCycleGraph(
+		&domain.DependencyEdge{From: "a", To: "b", EdgeType: domain.EdgeTypeImport},
+		&domain.DependencyEdge{From: "c", To: "d", EdgeType: domain.EdgeTypeImport},
+	)
+	graph.AddNode(&domain.ModuleNode{ID: "e"})
+	graph.AddNode(&domain.ModuleNode{ID: "react", IsExternal: true})
+
+	commits := temporalCommits(
+		// a and e always change together without an import
+		[]string{"a", "e", "README.md"},
+		[]string{"a", "e"},
+		[]string{"a", "e", "react"},
+		// a -> b is an import but b changes on its own
+		[]string{"b"},
+		[]string{"b"},
+		[]string{"b"},
+		// c -> d change together as expected
+		[]string{"c", "d"},
+		[]string{"c", "d"},
+		[]string{"c", "d"},
+		// Too large: skipped
+		[]string{"a", "b", "c", "d", "e"},
+	)
+
+	result := NewTemporalCouplingAnalyzer(graph, domain.TemporalCouplingOptions{MaxCommitFiles: 4}).Analyze(commits)
+
+	if result.Commits != 10 || result.SkippedCommits != 1 {
+		t.Errorf("Expected 10 commits with 1 skipped, got %d/%d", result.Commits, result.SkippedCommits)
+	}
+	if result.Options.MinSharedCommits != domain.DefaultTemporalMinSharedCommits || result.Options.MinDegree != domain.DefaultTemporalMinDegree {
+		t.Errorf("Expected default thresholds, got %+v", result.Options)
+	}
+
+	if len(result.Hidden) != 1 {
+		t.Fatalf("Expected 1 hidden pair, got %+v", result.Hidden)
+	}
+	hidden := result.Hidden[0]
+	if hidden.ModuleA != "a" || hidden.ModuleB != "e" || hidden.Kind != domain.TemporalHidden ||
+		hidden.SharedCommits != 3 || hidden.Degree != 1 {
+		t.Errorf("Unexpected hidden pair %+v", hidden)
+	}
+
+	if len(result.StaticOnly) != 1 {
+		t.Fatalf("Expected 1 static-only pair, got %+v", result.StaticOnly)
+	}
+	static := result.StaticOnly[0]
+	if static.ModuleA != "a" || static.ModuleB != "b" || static.Kind != domain.TemporalStaticOnly ||
+		static.SharedCommits != 0 || static.Degree != 0 || len(static.Edges) != 1 {
+		t.Errorf("Unexpected static-only pair %+v", static)
+	}
+}
+
+func TestTemporalCouplingAnalyzerThresholds(t *testing.T) {
+	graph := buildCycleGraph(&domain.DependencyEdge{From: "a", To: "b", EdgeType: domain.EdgeTypeImport})
+	graph.AddNode(&domain.ModuleNode{ID: "c"})
+
+	// a and c share 2 of 3 commits each (degree 0.67)
+	commits := temporalCommits([]string{"a", "c"}, []string{"a", "c"}, []string{"a"}, []string{"c"})
+
+	result := NewTemporalCouplingAnalyzer(graph, domain.TemporalCouplingOptions{}).Analyze(commits)
+	if len(result.Hidden) != 0 {
+		t.Errorf("Expected no pair below the minimum shared commits, got %+v", result.Hidden)
+	}
+	// b has no history, so the import cannot be judged
+	if len(result.StaticOnly) != 0 {
+		t.Errorf("Expected no static-only pair without history, got %+v", result.StaticOnly)
+	}
+
+	result = NewTemporalCouplingAnalyzer(graph, domain.TemporalCouplingOptions{MinSharedCommits: 2}).Analyze(commits)
+	if len(result.Hidden) != 1 || result.Hidden[0].Degree != 0.67 {
+		t.Errorf("Expected a hidden pair with degree 0.67, got %+v", result.Hidden)
+	}
+
+	result = NewTemporalCouplingAnalyzer(graph, domain.TemporalCouplingOptions{MinSharedCommits: 2, MinDegree: 0.8}).Analyze(commits)
+	if len(result.Hidden) != 0 {
+		t.Errorf("Expected no pair below the minimum degree, got %+v", result.Hidden)
+	}
+}
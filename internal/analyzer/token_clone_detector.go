@@ -0,0 +1,425 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// DetectionMode represents the clone detection algorithm
+type DetectionMode string
+
+const (
+	// DetectionModeTree compares whole fragments with APTED (default)
+	DetectionModeTree DetectionMode = "tree"
+	// DetectionModeToken finds Type-1/Type-2 clones on token streams using a rolling hash
+	DetectionModeToken DetectionMode = "token"
+	// DetectionModeHybrid combines token matches with APTED verification of the
+	// enclosing fragments to additionally report Type-3/Type-4 clones
+	DetectionModeHybrid DetectionMode = "hybrid"
+)
+
+// Token-based detection constants
+const (
+	tokenHashBase = 1000003
+	// maxTokenBucketSize caps the number of occurrences of one token window that are
+	// paired with each other, to avoid quadratic blow-up on generated or repetitive code.
+	// Occurrences of larger buckets are only paired with the first one.
+	maxTokenBucketSize = 100
+	tokenCheckInterval = 10000
+)
+
+// TokenStream holds the tokens of a single source file
+type TokenStream struct {
+	FilePath string
+	Tokens   []parser.Token
+}
+
+// tokenMatch represents a maximal run of equal normalized tokens at two positions
+type tokenMatch struct {
+	stream1, start1 int
+	stream2, start2 int
+	length          int
+}
+
+// tokenWindow is an occurrence of a MinTokens-long window in a token stream
+type tokenWindow struct {
+	hash   uint64
+	stream int32
+	start  int32
+}
+
+// DetectTokenClones detects Type-1/Type-2 clones on token streams in near-linear time.
+// Unlike fragment-based detection, matches may cover partial functions and statement sequences.
+func (cd *CloneDetector) DetectTokenClones(ctx context.Context, streams []*TokenStream) ([]*domain.ClonePair, []*domain.CloneGroup) {
+	cd.fragments = []*CodeFragment{}
+	cd.clonePairs = []*domain.ClonePair{}
+	cd.cloneGroups = []*domain.CloneGroup{}
+
+	if isCancelled(ctx) {
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	cd.clonePairs = cd.tokenClonePairs(ctx, streams, cd.findTokenMatches(ctx, streams))
+	cd.limitAndSortClonePairs(cd.cloneDetectorConfig.MaxClonePairs)
+
+	if isCancelled(ctx) {
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	cd.groupClonesWithStrategy(cd.newGroupingStrategy())
+
+	return cd.clonePairs, cd.cloneGroups
+}
+
+// DetectClonesHybrid reports Type-1/Type-2 clones from token matches and verifies the
+// fragments overlapping each match with APTED, which adds the Type-3/Type-4 clones
+// (e.g. copies with inserted statements) among them. Only fragment pairs that share a
+// token match are compared, so the cost stays close to the token-based mode.
+func (cd *CloneDetector) DetectClonesHybrid(ctx context.Context, streams []*TokenStream, fragments []*CodeFragment) ([]*domain.ClonePair, []*domain.CloneGroup) {
+	cd.fragments = fragments
+	cd.clonePairs = []*domain.ClonePair{}
+	cd.cloneGroups = []*domain.CloneGroup{}
+
+	if isCancelled(ctx) {
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	// Stage 1: token matches (Type-1/Type-2)
+	matches := cd.findTokenMatches(ctx, streams)
+	pairs := cd.tokenClonePairs(ctx, streams, matches)
+
+	if isCancelled(ctx) {
+		cd.clonePairs = pairs
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	// Stage 2: APTED verification of enclosing fragments (Type-3/Type-4)
+	cd.prepareFragments()
+
+	byFile := make(map[string][]*CodeFragment)
+	for _, f := range cd.fragments {
+		if f != nil && f.TreeNode != nil {
+			byFile[f.Location.FilePath] = append(byFile[f.Location.FilePath], f)
+		}
+	}
+
+	seenPairs := make(map[[2]*CodeFragment]struct{})
+	pairID := len(pairs)
+	for _, m := range matches {
+		if isCancelled(ctx) {
+			break
+		}
+		loc1 := tokenSpanLocation(streams[m.stream1], m.start1, m.length)
+		loc2 := tokenSpanLocation(streams[m.stream2], m.start2, m.length)
+
+		for _, f1 := range overlappingFragments(byFile[loc1.FilePath], loc1) {
+			for _, f2 := range overlappingFragments(byFile[loc2.FilePath], loc2) {
+				if f1 == f2 || cd.isSameLocation(f1.Location, f2.Location) {
+					continue
+				}
+				key := [2]*CodeFragment{f1, f2}
+				if _, ok := seenPairs[key]; ok {
+					continue
+				}
+				seenPairs[key] = struct{}{}
+				seenPairs[[2]*CodeFragment{f2, f1}] = struct{}{}
+
+				pair := cd.compareFragments(f1, f2, pairID)
+				if pair == nil || !cd.isSignificantClone(pair) {
+					continue
+				}
+				// Fragments lying entirely within the token match are already reported
+				if containsLines(loc1, f1.Location) && containsLines(loc2, f2.Location) {
+					continue
+				}
+				pairs = append(pairs, pair)
+				pairID++
+			}
+		}
+	}
+
+	cd.clonePairs = pairs
+	cd.limitAndSortClonePairs(cd.cloneDetectorConfig.MaxClonePairs)
+
+	if isCancelled(ctx) {
+		return cd.clonePairs, cd.cloneGroups
+	}
+
+	cd.groupClonesWithStrategy(cd.newGroupingStrategy())
+
+	return cd.clonePairs, cd.cloneGroups
+}
+
+// findTokenMatches finds maximal runs of at least MinTokens equal normalized tokens.
+// Windows are hashed with a Rabin-Karp rolling hash and sorted by hash, so that only
+// windows in the same bucket are compared.
+func (cd *CloneDetector) findTokenMatches(ctx context.Context, streams []*TokenStream) []tokenMatch {
+	window := cd.cloneDetectorConfig.MinTokens
+	if window < 1 {
+		window = 1
+	}
+
+	// Normalize tokens to hashes: identifiers and literals are abstracted for Type-2 matching
+	normalized := make([][]uint64, len(streams))
+	for i, stream := range streams {
+		normalized[i] = make([]uint64, len(stream.Tokens))
+		for j, tok := range stream.Tokens {
+			normalized[i][j] = normalizedTokenHash(tok)
+		}
+	}
+
+	// B^(window-1) for removing the outgoing token from the rolling hash
+	power := uint64(1)
+	for i := 1; i < window; i++ {
+		power *= tokenHashBase
+	}
+
+	var windows []tokenWindow
+	for i, norm := range normalized {
+		if len(norm) < window {
+			continue
+		}
+		var h uint64
+		for j := 0; j < window; j++ {
+			h = h*tokenHashBase + norm[j]
+		}
+		windows = append(windows, tokenWindow{hash: h, stream: int32(i), start: 0})
+		for j := window; j < len(norm); j++ {
+			h = (h-norm[j-window]*power)*tokenHashBase + norm[j]
+			windows = append(windows, tokenWindow{hash: h, stream: int32(i), start: int32(j - window + 1)})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].hash != windows[j].hash {
+			return windows[i].hash < windows[j].hash
+		}
+		if windows[i].stream != windows[j].stream {
+			return windows[i].stream < windows[j].stream
+		}
+		return windows[i].start < windows[j].start
+	})
+
+	var matches []tokenMatch
+	checked, oversized := 0, 0
+	defer func() {
+		if oversized > 0 {
+			cd.warnings = append(cd.warnings, fmt.Sprintf(
+				"%d token sequences occur more than %d times; their occurrences were only compared with the first one",
+				oversized, maxTokenBucketSize))
+		}
+	}()
+	for lo := 0; lo < len(windows); {
+		hi := lo + 1
+		for hi < len(windows) && windows[hi].hash == windows[lo].hash {
+			hi++
+		}
+		bucket := windows[lo:hi]
+		lo = hi
+		if len(bucket) < 2 {
+			continue
+		}
+		// Oversized buckets pair every occurrence with the first one only; the order
+		// of the bucket is deterministic, so the same clones are found on every run
+		firsts := len(bucket)
+		if len(bucket) > maxTokenBucketSize {
+			firsts = 1
+			oversized++
+		}
+
+		for a := 0; a < firsts; a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				checked++
+				if checked%tokenCheckInterval == 0 && isCancelled(ctx) {
+					return matches
+				}
+				s1, p1 := int(bucket[a].stream), int(bucket[a].start)
+				s2, p2 := int(bucket[b].stream), int(bucket[b].start)
+				if m, ok := extendTokenMatch(normalized, s1, p1, s2, p2, window); ok {
+					matches = append(matches, m)
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// extendTokenMatch verifies a window match and extends it to a maximal match.
+// Returns false for hash collisions, self-overlapping matches and matches that are
+// not left-maximal (they are found from an earlier window instead).
+func extendTokenMatch(normalized [][]uint64, s1, p1, s2, p2, window int) (tokenMatch, bool) {
+	norm1 := normalized[s1]
+	norm2 := normalized[s2]
+
+	// Same stream: p1 < p2 by sort order, the two occurrences must not overlap
+	sameStream := s1 == s2
+	if sameStream && p2-p1 < window {
+		return tokenMatch{}, false
+	}
+
+	// Left-maximality: skip if the match can be extended to the left
+	if p1 > 0 && p2 > 0 && norm1[p1-1] == norm2[p2-1] {
+		return tokenMatch{}, false
+	}
+
+	// Guard against hash collisions
+	for k := 0; k < window; k++ {
+		if norm1[p1+k] != norm2[p2+k] {
+			return tokenMatch{}, false
+		}
+	}
+
+	length := window
+	for p1+length < len(norm1) && p2+length < len(norm2) && norm1[p1+length] == norm2[p2+length] {
+		if sameStream && p1+length >= p2 {
+			break
+		}
+		length++
+	}
+
+	return tokenMatch{stream1: s1, start1: p1, stream2: s2, start2: p2, length: length}, true
+}
+
+// tokenClonePairs converts token matches into clone pairs
+func (cd *CloneDetector) tokenClonePairs(ctx context.Context, streams []*TokenStream, matches []tokenMatch) []*domain.ClonePair {
+	pairs := make([]*domain.ClonePair, 0, len(matches))
+	pairID := 0
+
+	for i, m := range matches {
+		if i%tokenCheckInterval == 0 && isCancelled(ctx) {
+			break
+		}
+		stream1 := streams[m.stream1]
+		stream2 := streams[m.stream2]
+
+		m = trimTokenMatch(stream1, stream2, m)
+		if m.length < cd.cloneDetectorConfig.MinTokens {
+			continue
+		}
+
+		fragment1 := NewTokenFragment(stream1, m.start1, m.length)
+		fragment2 := NewTokenFragment(stream2, m.start2, m.length)
+		if fragment1.LineCount < cd.cloneDetectorConfig.MinLines || fragment2.LineCount < cd.cloneDetectorConfig.MinLines {
+			continue
+		}
+
+		// Type-1 if the raw token text is identical, otherwise Type-2 (renamed identifiers/literals)
+		differing := 0
+		for k := 0; k < m.length; k++ {
+			if stream1.Tokens[m.start1+k].Value != stream2.Tokens[m.start2+k].Value {
+				differing++
+			}
+		}
+		cloneType := domain.Type1Clone
+		if differing > 0 {
+			cloneType = domain.Type2Clone
+		}
+		similarity := 1.0 - float64(differing)/float64(m.length)
+
+		pairs = append(pairs, &domain.ClonePair{
+			ID:         pairID,
+			Clone1:     cd.fragmentToClone(fragment1, pairID*2),
+			Clone2:     cd.fragmentToClone(fragment2, pairID*2+1),
+			Similarity: similarity,
+			Distance:   float64(differing),
+			Type:       cloneType,
+			Confidence: cd.calculateConfidence(fragment1, fragment2, similarity),
+		})
+		pairID++
+	}
+
+	return pairs
+}
+
+// trimTokenMatch shrinks a match so that clone boundaries align with the copied code:
+// renamed tokens at either end and dangling punctuation, such as the ")" or ";" that
+// closes the statement before the duplicated code, are dropped
+func trimTokenMatch(stream1, stream2 *TokenStream, m tokenMatch) tokenMatch {
+	for m.length > 0 {
+		first1 := stream1.Tokens[m.start1]
+		first2 := stream2.Tokens[m.start2]
+		if !leadingTrimTokens[first1.Type] && first1.Value == first2.Value {
+			break
+		}
+		m.start1++
+		m.start2++
+		m.length--
+	}
+	for m.length > 0 {
+		last1 := stream1.Tokens[m.start1+m.length-1]
+		last2 := stream2.Tokens[m.start2+m.length-1]
+		if !trailingTrimTokens[last1.Type] && last1.Value == last2.Value {
+			break
+		}
+		m.length--
+	}
+	return m
+}
+
+// leadingTrimTokens are tokens that cannot start a meaningful clone
+var leadingTrimTokens = map[string]bool{")": true, "]": true, "}": true, ";": true, ",": true}
+
+// trailingTrimTokens are tokens that cannot end a meaningful clone
+var trailingTrimTokens = map[string]bool{"(": true, "[": true, "{": true, ",": true, ".": true}
+
+// NewTokenFragment creates a code fragment spanning length tokens of a stream.
+// Its Size is the number of tokens; it has no AST node.
+func NewTokenFragment(stream *TokenStream, start, length int) *CodeFragment {
+	location := tokenSpanLocation(stream, start, length)
+	return &CodeFragment{
+		Location:  location,
+		Size:      length,
+		LineCount: location.EndLine - location.StartLine + 1,
+	}
+}
+
+// tokenSpanLocation returns the source location covered by a token span
+func tokenSpanLocation(stream *TokenStream, start, length int) *CodeLocation {
+	first := stream.Tokens[start].Location
+	last := stream.Tokens[start+length-1].Location
+	return &CodeLocation{
+		FilePath:  stream.FilePath,
+		StartLine: first.StartLine,
+		StartCol:  first.StartCol,
+		EndLine:   last.EndLine,
+		EndCol:    last.EndCol,
+	}
+}
+
+// overlappingFragments returns the fragments whose line range overlaps the location
+func overlappingFragments(fragments []*CodeFragment, loc *CodeLocation) []*CodeFragment {
+	var result []*CodeFragment
+	for _, f := range fragments {
+		if f.Location.StartLine <= loc.EndLine && loc.StartLine <= f.Location.EndLine {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// containsLines reports whether outer covers all lines of inner
+func containsLines(outer, inner *CodeLocation) bool {
+	return outer.StartLine <= inner.StartLine && inner.EndLine <= outer.EndLine
+}
+
+// normalizedTokenHash hashes a token with identifiers and literals abstracted away
+func normalizedTokenHash(tok parser.Token) uint64 {
+	h := fnv.New64a()
+	switch tok.Kind {
+	case parser.TokenIdentifier:
+		_, _ = h.Write([]byte("$id"))
+	case parser.TokenLiteral:
+		_, _ = h.Write([]byte("$lit"))
+	default:
+		_, _ = h.Write([]byte(tok.Type))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(tok.Value))
+	}
+	return h.Sum64()
+}
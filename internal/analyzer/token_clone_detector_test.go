@@ -0,0 +1,202 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+const tokenCloneBlock = `
+  const errors = [];
+  if (!input.name || input.name.length < 3) {
+    errors.push("name too short");
+  }
+  if (!input.email || input.email.indexOf("@") < 0) {
+    errors.push("invalid email");
+  }
+  if (input.age < 18) {
+    errors.push("too young");
+  }
+`
+
+func tokenStream(t *testing.T, filePath, code string) *TokenStream {
+	t.Helper()
+	tokens, err := parser.Tokenize(filePath, []byte(code))
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	return &TokenStream{FilePath: filePath, Tokens: tokens}
+}
+
+func TestDetectTokenClones_PartialFunctions(t *testing.T) {
+	// The duplicated block is embedded in otherwise different functions
+	code1 := "function createUser(input) {\n  log('create');" + tokenCloneBlock + "  return save(input, errors);\n}\n"
+	code2 := "function updateUser(input, id) {\n  audit(id);\n  track(id);" + tokenCloneBlock + "  return errors.length === 0 ? update(id, input) : errors;\n}\n"
+
+	config := DefaultCloneDetectorConfig()
+	config.MinTokens = 30
+	detector := NewCloneDetector(config)
+
+	pairs, _ := detector.DetectTokenClones(context.Background(), []*TokenStream{
+		tokenStream(t, "a.js", code1),
+		tokenStream(t, "b.js", code2),
+	})
+
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 clone pair, got %d", len(pairs))
+	}
+	pair := pairs[0]
+	if pair.Type != domain.Type1Clone {
+		t.Errorf("Expected Type-1 clone, got %s", pair.Type)
+	}
+	if pair.Clone1.Location.StartLine != 3 || pair.Clone2.Location.StartLine != 4 {
+		t.Errorf("Expected clone to start at the duplicated block, got lines %d and %d",
+			pair.Clone1.Location.StartLine, pair.Clone2.Location.StartLine)
+	}
+	if pair.Clone1.LineCount < 9 {
+		t.Errorf("Expected maximal match covering the block, got %d lines", pair.Clone1.LineCount)
+	}
+}
+
+func TestDetectTokenClones_RenamedIdentifiers(t *testing.T) {
+	renamed := "function check(payload) {" +
+		"\n  const problems = [];" +
+		"\n  if (!payload.title || payload.title.length < 5) {" +
+		"\n    problems.push(\"title too short\");" +
+		"\n  }" +
+		"\n  if (!payload.mail || payload.mail.indexOf(\"#\") < 0) {" +
+		"\n    problems.push(\"invalid mail\");" +
+		"\n  }" +
+		"\n  if (payload.age < 21) {" +
+		"\n    problems.push(\"too young\");" +
+		"\n  }\n}\n"
+	original := "function validate(input) {" + tokenCloneBlock + "}\n"
+
+	config := DefaultCloneDetectorConfig()
+	config.MinTokens = 30
+	detector := NewCloneDetector(config)
+
+	pairs, _ := detector.DetectTokenClones(context.Background(), []*TokenStream{
+		tokenStream(t, "a.js", original),
+		tokenStream(t, "b.js", renamed),
+	})
+
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 clone pair, got %d", len(pairs))
+	}
+	if pairs[0].Type != domain.Type2Clone {
+		t.Errorf("Expected Type-2 clone, got %s", pairs[0].Type)
+	}
+	if pairs[0].Similarity >= 1.0 {
+		t.Errorf("Expected similarity below 1.0 for renamed clone, got %f", pairs[0].Similarity)
+	}
+}
+
+func TestDetectTokenClones_NoSelfOverlap(t *testing.T) {
+	// A long run of identical statements must not be reported as overlapping with itself
+	code := ""
+	for i := 0; i < 40; i++ {
+		code += "x = x + 1;\n"
+	}
+
+	config := DefaultCloneDetectorConfig()
+	config.MinTokens = 20
+	config.MinLines = 1
+	detector := NewCloneDetector(config)
+
+	pairs, _ := detector.DetectTokenClones(context.Background(), []*TokenStream{tokenStream(t, "a.js", code)})
+	if len(pairs) == 0 {
+		t.Fatal("Expected clones within the repetitive file")
+	}
+	for _, pair := range pairs {
+		if pair.Clone1.Location.EndLine >= pair.Clone2.Location.StartLine {
+			t.Errorf("Expected non-overlapping clone spans, got %s and %s",
+				pair.Clone1.Location.String(), pair.Clone2.Location.String())
+		}
+	}
+}
+
+func TestDetectTokenClones_Empty(t *testing.T) {
+	detector := NewCloneDetector(DefaultCloneDetectorConfig())
+	pairs, groups := detector.DetectTokenClones(context.Background(), nil)
+	if len(pairs) != 0 || len(groups) != 0 {
+		t.Errorf("Expected no clones, got %d pairs and %d groups", len(pairs), len(groups))
+	}
+}
+
+func TestDetectClonesHybrid_VerifiesEnclosingFragments(t *testing.T) {
+	code1 := "function validate(input) {" + tokenCloneBlock + "  return errors;\n}\n"
+	code2 := "function validate2(input) {" + tokenCloneBlock +
+		"  if (input.country === undefined) {\n    errors.push(\"missing country\");\n  }\n  console.log(errors);\n  return errors;\n}\n"
+
+	config := DefaultCloneDetectorConfig()
+	config.MinTokens = 30
+	config.DetectionMode = DetectionModeHybrid
+	config.MinNodes = 5
+	detector := NewCloneDetector(config)
+
+	stream1 := tokenStream(t, "a.js", code1)
+	stream2 := tokenStream(t, "b.js", code2)
+
+	var fragments []*CodeFragment
+	for _, s := range []struct{ path, code string }{{"a.js", code1}, {"b.js", code2}} {
+		ast, err := parser.ParseForLanguage(s.path, []byte(s.code))
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		fragments = append(fragments, detector.ExtractFragments(ast.Body, s.path)...)
+	}
+
+	pairs, _ := detector.DetectClonesHybrid(context.Background(), []*TokenStream{stream1, stream2}, fragments)
+
+	var tokenPair, fragmentPair bool
+	for _, pair := range pairs {
+		if pair.Clone1.Location.StartLine == 1 && pair.Clone1.Location.EndLine == 11 {
+			tokenPair = true
+		}
+		if pair.Clone2.Location.EndLine == 17 {
+			fragmentPair = true
+		}
+	}
+	if !tokenPair {
+		t.Error("Expected the token stage to report the duplicated block")
+	}
+	if !fragmentPair {
+		t.Error("Expected APTED verification to report the extended function")
+	}
+}
+
+func TestDetectTokenClones_OversizedBucket(t *testing.T) {
+	// More copies than maxTokenBucketSize: every copy is still paired with the first one
+	copies := maxTokenBucketSize + 20
+	streams := make([]*TokenStream, copies)
+	for i := range streams {
+		streams[i] = tokenStream(t, fmt.Sprintf("f%03d.js", i), "function validate(input) {"+tokenCloneBlock+"}\n")
+	}
+
+	config := DefaultCloneDetectorConfig()
+	config.MinTokens = 30
+	detector := NewCloneDetector(config)
+
+	pairs, _ := detector.DetectTokenClones(context.Background(), streams)
+	if len(pairs) != copies-1 {
+		t.Fatalf("Expected %d clone pairs, got %d", copies-1, len(pairs))
+	}
+	found := make(map[string]bool)
+	for _, pair := range pairs {
+		if pair.Clone1.Location.FilePath != "f000.js" {
+			t.Errorf("Expected every copy to be paired with the first one, got %s", pair.Clone1.Location.String())
+		}
+		found[pair.Clone2.Location.FilePath] = true
+	}
+	if !found[fmt.Sprintf("f%03d.js", copies-1)] {
+		t.Error("Expected the copies beyond the bucket cap to be reported")
+	}
+	if warnings := detector.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "only compared with the first one") {
+		t.Errorf("Expected a warning about the oversized bucket, got %v", warnings)
+	}
+}
@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// TrendAnalyzer computes metric series and regressions over recorded analysis runs
+type TrendAnalyzer struct {
+	entries []domain.HistoryEntry
+}
+
+// NewTrendAnalyzer creates a trend analyzer. Entries are ordered chronologically.
+func NewTrendAnalyzer(entries []domain.HistoryEntry) *TrendAnalyzer {
+	sorted := append([]domain.HistoryEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+	return &TrendAnalyzer{entries: sorted}
+}
+
+// Entries returns the runs in chronological order
+func (a *TrendAnalyzer) Entries() []domain.HistoryEntry {
+	return a.entries
+}
+
+// Series returns the evolution of every tracked metric
+func (a *TrendAnalyzer) Series() []domain.TrendSeries {
+	series := make([]domain.TrendSeries, 0, len(domain.TrendMetrics))
+	for _, metric := range domain.TrendMetrics {
+		s := domain.TrendSeries{
+			Metric:         metric,
+			Label:          metric.Label(),
+			HigherIsBetter: metric.HigherIsBetter(),
+			Points:         make([]domain.TrendPoint, 0, len(a.entries)),
+		}
+		for i := range a.entries {
+			entry := &a.entries[i]
+			value := metric.Value(&entry.Summary)
+			s.Points = append(s.Points, domain.TrendPoint{
+				Label:     entry.Label(),
+				Commit:    entry.Commit,
+				Timestamp: entry.Timestamp,
+				Value:     value,
+			})
+			if i == 0 || value < s.Min {
+				s.Min = value
+			}
+			if i == 0 || value > s.Max {
+				s.Max = value
+			}
+		}
+		if len(s.Points) > 0 {
+			s.First = s.Points[0].Value
+			s.Last = s.Points[len(s.Points)-1].Value
+			s.Change = s.Last - s.First
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+// Regressions returns the runs whose health score dropped compared to the previous
+// run, biggest drop first. limit <= 0 returns all of them.
+func (a *TrendAnalyzer) Regressions(limit int) []domain.TrendRegression {
+	regressions := []domain.TrendRegression{}
+	for i := 1; i < len(a.entries); i++ {
+		prev, curr := &a.entries[i-1], &a.entries[i]
+		delta := curr.Summary.HealthScore - prev.Summary.HealthScore
+		if delta >= 0 {
+			continue
+		}
+
+		regression := domain.TrendRegression{
+			Label:          curr.Label(),
+			Commit:         curr.Commit,
+			Subject:        curr.Subject,
+			PreviousLabel:  prev.Label(),
+			PreviousCommit: prev.Commit,
+			Timestamp:      curr.Timestamp,
+			ScoreBefore:    prev.Summary.HealthScore,
+			ScoreAfter:     curr.Summary.HealthScore,
+			ScoreDelta:     delta,
+			Changes:        []domain.TrendChange{},
+		}
+		for _, metric := range domain.TrendMetrics {
+			if metric == domain.TrendMetricHealthScore {
+				continue
+			}
+			before, after := metric.Value(&prev.Summary), metric.Value(&curr.Summary)
+			if worsened(metric, before, after) {
+				regression.Changes = append(regression.Changes, domain.TrendChange{
+					Metric: metric,
+					Before: before,
+					After:  after,
+					Delta:  after - before,
+				})
+			}
+		}
+		regressions = append(regressions, regression)
+	}
+
+	sort.SliceStable(regressions, func(i, j int) bool {
+		if regressions[i].ScoreDelta != regressions[j].ScoreDelta {
+			return regressions[i].ScoreDelta < regressions[j].ScoreDelta
+		}
+		return regressions[i].Timestamp.After(regressions[j].Timestamp)
+	})
+	if limit > 0 && len(regressions) > limit {
+		regressions = regressions[:limit]
+	}
+	return regressions
+}
+
+// worsened reports whether a metric got worse between two runs
+func worsened(metric domain.TrendMetric, before, after float64) bool {
+	if metric.HigherIsBetter() {
+		return after < before
+	}
+	return after > before
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func trendEntry(commit string, day int, score int, complexity float64, deadCode int) domain.HistoryEntry {
+	return domain.HistoryEntry{
+		Timestamp: time.Date(2026, 1, day, 12, 0, 0, 0, time.UTC),
+		Commit:    commit,
+		Summary: domain.AnalyzeSummary{
+			HealthScore:       score,
+			AverageComplexity: complexity,
+			DeadCodeCount:     deadCode,
+		},
+	}
+}
+
+func TestTrendAnalyzerSeries(t *testing.T) {
+	// Out of order on purpose: entries are sorted by timestamp
+	analyzer := NewTrendAnalyzer([]domain.HistoryEntry{
+		trendEntry("c3", 3, 80, 3.0, 2),
+		trendEntry("c1", 1, 90, 2.0, 1),
+		trendEntry("c2", 2, 70, 4.0, 5),
+	})
+
+	series := analyzer.Series()
+	if len(series) != len(domain.TrendMetrics) {
+		t.Fatalf("Expected %d series, got %d", len(domain.TrendMetrics), len(series))
+	}
+
+	score := series[0]
+	if score.Metric != domain.TrendMetricHealthScore {
+		t.Fatalf("Expected health score first, got %s", score.Metric)
+	}
+	if len(score.Points) != 3 || score.Points[0].Commit != "c1" || score.Points[2].Commit != "c3" {
+		t.Errorf("Expected chronological points, got %+v", score.Points)
+	}
+	if score.First != 90 || score.Last != 80 || score.Min != 70 || score.Max != 90 || score.Change != -10 {
+		t.Errorf("Unexpected health score series %+v", score)
+	}
+	if score.Improved() {
+		t.Error("A lower health score is not an improvement")
+	}
+
+	complexity := series[1]
+	if complexity.Change != 1 || complexity.Improved() {
+		t.Errorf("Expected complexity to get worse by 1, got %+v", complexity)
+	}
+}
+
+func TestTrendAnalyzerRegressions(t *testing.T) {
+	analyzer := NewTrendAnalyzer([]domain.HistoryEntry{
+		trendEntry("c1", 1, 90, 2.0, 1),
+		trendEntry("c2", 2, 85, 2.0, 3), // -5
+		trendEntry("c3", 3, 88, 2.0, 3),
+		trendEntry("c4", 4, 70, 5.0, 2), // -18
+		trendEntry("c5", 5, 68, 5.0, 2), // -2
+	})
+
+	regressions := analyzer.Regressions(0)
+	if len(regressions) != 3 {
+		t.Fatalf("Expected 3 regressions, got %d", len(regressions))
+	}
+
+	worst := regressions[0]
+	if worst.Commit != "c4" || worst.PreviousCommit != "c3" || worst.ScoreDelta != -18 {
+		t.Errorf("Expected c4 as the biggest regression, got %+v", worst)
+	}
+	if len(worst.Changes) != 1 || worst.Changes[0].Metric != domain.TrendMetricComplexity || worst.Changes[0].Delta != 3 {
+		t.Errorf("Expected only complexity to have worsened, got %+v", worst.Changes)
+	}
+	if regressions[1].Commit != "c2" || regressions[2].Commit != "c5" {
+		t.Errorf("Expected regressions ordered by score drop, got %s, %s", regressions[1].Commit, regressions[2].Commit)
+	}
+
+	if limited := analyzer.Regressions(1); len(limited) != 1 || limited[0].Commit != "c4" {
+		t.Errorf("Expected the limit to keep the biggest regression, got %+v", limited)
+	}
+}
+
+func TestTrendAnalyzerSingleRun(t *testing.T) {
+	analyzer := NewTrendAnalyzer([]domain.HistoryEntry{trendEntry("c1", 1, 90, 2.0, 1)})
+
+	if regressions := analyzer.Regressions(0); regressions == nil || len(regressions) != 0 {
+		t.Errorf("Expected an empty regression list, got %v", regressions)
+	}
+	if series := analyzer.Series(); series[0].Change != 0 || series[0].First != 90 {
+		t.Errorf("Unexpected series for a single run %+v", series[0])
+	}
+}
@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// tsSuppressionPattern matches comments that are @ts-ignore or @ts-expect-error directives
+var tsSuppressionPattern = regexp.MustCompile(`^(//|/\*)\s*@ts-(ignore|expect-error)\b`)
+
+// IsTypeScriptFile reports whether a file is TypeScript source measured by the
+// type-safety analysis; declaration files only hold types and are not
+func IsTypeScriptFile(filePath string) bool {
+	switch filepath.Ext(filePath) {
+	case ".ts", ".tsx", ".mts", ".cts":
+		return !isDeclarationFile(filePath)
+	}
+	return false
+}
+
+// typeSafetyVisitor attributes type-safety metrics to the innermost enclosing function
+type typeSafetyVisitor struct {
+	file       domain.TypeSafetyMetrics // code outside functions
+	functions  []domain.FunctionTypeSafety
+	visited    map[*parser.Node]bool
+	contextual map[*parser.Node]bool // function expressions whose parameters are contextually typed
+}
+
+// AnalyzeTypeSafety measures the explicit any types, casts, non-null assertions,
+// suppression comments and untyped parameters of a TypeScript file. Parameters of
+// callbacks passed as arguments or assigned to annotated variables are typed by
+// their context and are not counted as untyped.
+func AnalyzeTypeSafety(ast *parser.Node, filePath string) *domain.FileTypeSafety {
+	v := &typeSafetyVisitor{
+		visited:    make(map[*parser.Node]bool),
+		contextual: make(map[*parser.Node]bool),
+	}
+	v.walk(ast, &v.file)
+	v.countSuppressions(ast.Comments)
+
+	result := &domain.FileTypeSafety{
+		FilePath:  filePath,
+		Metrics:   v.file,
+		Functions: v.functions,
+	}
+	for i := range result.Functions {
+		fn := &result.Functions[i]
+		fn.TypeCoverage = fn.Metrics.TypeCoverage()
+		result.Metrics.Add(fn.Metrics)
+	}
+	result.TypeCoverage = result.Metrics.TypeCoverage()
+	sort.SliceStable(result.Functions, func(i, j int) bool {
+		return result.Functions[i].StartLine < result.Functions[j].StartLine
+	})
+	return result
+}
+
+// walk counts the nodes under root into metrics, stopping at nested functions
+func (v *typeSafetyVisitor) walk(root *parser.Node, metrics *domain.TypeSafetyMetrics) {
+	root.Walk(func(n *parser.Node) bool {
+		if n != root && n.IsFunction() {
+			v.visitFunction(n)
+			return false
+		}
+		// The program holds its statements in both Children and Body
+		if v.visited[n] {
+			return false
+		}
+		v.visited[n] = true
+		v.count(n, metrics)
+		return true
+	})
+}
+
+// visitFunction records a function with its parameters and body
+func (v *typeSafetyVisitor) visitFunction(n *parser.Node) {
+	if v.visited[n] {
+		return
+	}
+	fn := domain.FunctionTypeSafety{
+		Name:      resolveFunctionName(n),
+		StartLine: n.Location.StartLine,
+		EndLine:   n.Location.EndLine,
+	}
+	for _, param := range n.Params {
+		if isUntypedParam(param) && !v.contextual[n] {
+			fn.Metrics.UntypedParams++
+			fn.Metrics.TypePositions++
+		}
+	}
+	v.walk(n, &fn.Metrics)
+	v.functions = append(v.functions, fn)
+}
+
+// count adds the metrics of a single node
+func (v *typeSafetyVisitor) count(n *parser.Node, metrics *domain.TypeSafetyMetrics) {
+	switch n.Type {
+	case parser.NodeAsExpression:
+		if n.Kind != "const" {
+			metrics.AsCasts++
+		}
+	case parser.NodeNonNullExpression:
+		metrics.NonNullAssertions++
+	case parser.NodeCallExpression, parser.NodeNewExpression:
+		for _, arg := range n.Arguments {
+			if arg.IsFunction() {
+				v.contextual[arg] = true
+			}
+		}
+	case "predefined_type":
+		if isAnyType(n) {
+			metrics.ExplicitAny++
+		}
+	case "type_annotation":
+		metrics.TypePositions++
+		if !isAnyType(annotatedType(n)) {
+			metrics.TypedPositions++
+		}
+	case "variable_declarator":
+		if hasChildOfType(n, "type_annotation") {
+			for _, child := range n.Children {
+				if child.IsFunction() {
+					v.contextual[child] = true
+				}
+			}
+		}
+	}
+}
+
+// countSuppressions attributes each suppression comment to the innermost function
+// spanning its line. Only comment nodes count, not directives inside strings.
+func (v *typeSafetyVisitor) countSuppressions(comments []*parser.Node) {
+	for _, comment := range comments {
+		if !tsSuppressionPattern.MatchString(comment.Raw) {
+			continue
+		}
+		line := comment.Location.StartLine
+		metrics := &v.file
+		span := -1
+		for i := range v.functions {
+			fn := &v.functions[i]
+			if fn.StartLine <= line && line <= fn.EndLine && (span < 0 || fn.EndLine-fn.StartLine < span) {
+				metrics = &fn.Metrics
+				span = fn.EndLine - fn.StartLine
+			}
+		}
+		metrics.TSSuppressions++
+	}
+}
+
+// isUntypedParam reports whether a parameter has neither a type annotation nor a
+// default value to infer its type from
+func isUntypedParam(param *parser.Node) bool {
+	switch param.Type {
+	case parser.NodeIdentifier, "object_pattern", "array_pattern", "rest_pattern":
+		return true
+	case "required_parameter", "optional_parameter":
+		return !hasChildOfType(param, "type_annotation") && !hasChildOfType(param, "=")
+	}
+	return false
+}
+
+// annotatedType returns the type of a type annotation, after the colon
+func annotatedType(annotation *parser.Node) *parser.Node {
+	for _, child := range annotation.Children {
+		if child.Type != ":" {
+			return child
+		}
+	}
+	return nil
+}
+
+// isAnyType reports whether a type node is the predefined type any
+func isAnyType(n *parser.Node) bool {
+	return n != nil && n.Type == "predefined_type" && hasChildOfType(n, "any")
+}
+
+// hasChildOfType reports whether a node has a direct child of the given type
+func hasChildOfType(n *parser.Node, nodeType parser.NodeType) bool {
+	for _, child := range n.Children {
+		if child.Type == nodeType {
+			return true
+		}
+	}
+	return false
+}
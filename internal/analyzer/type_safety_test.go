@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+func analyzeTypeSafetyOf(t *testing.T, source string) *domain.FileTypeSafety {
+	t.Helper()
+	p := parser.NewTypeScriptParser()
+	defer p.Close()
+	ast, err := p.ParseFile("/repo/src/main.ts", []byte(source))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return AnalyzeTypeSafety(ast, "/repo/src/main.ts")
+}
+
+func TestAnalyzeTypeSafety_Functions(t *testing.T) {
+	result := analyzeTypeSafetyOf(t, `function load(id: string, opts, retries = 3): any {
+  // @ts-ignore
+  const raw: any = fetch(id) as Response;
+  const ids = [1, 2].map(n => n * 2);
+  const cfg = { mode: 'strict' } as const;
+  return raw!.body;
+}
+const handler: (e: Event) => void = (e) => {
+  /* @ts-expect-error */
+  e.target!.value = 1;
+};
+`)
+
+	if len(result.Functions) != 3 {
+		t.Fatalf("Expected 3 functions, got %d", len(result.Functions))
+	}
+
+	load := result.Functions[0].Metrics
+	want := domain.TypeSafetyMetrics{
+		ExplicitAny:       2,
+		AsCasts:           1,
+		NonNullAssertions: 1,
+		TSSuppressions:    1,
+		UntypedParams:     1,
+		TypePositions:     4, // id, opts, the return type and raw
+		TypedPositions:    1,
+	}
+	if load != want {
+		t.Errorf("Expected load metrics %+v, got %+v", want, load)
+	}
+
+	if mapper := result.Functions[1].Metrics; mapper.UntypedParams != 0 {
+		t.Errorf("Expected the callback parameter to be contextually typed, got %+v", mapper)
+	}
+
+	handler := result.Functions[2].Metrics
+	if handler.UntypedParams != 0 || handler.NonNullAssertions != 1 || handler.TSSuppressions != 1 {
+		t.Errorf("Unexpected handler metrics %+v", handler)
+	}
+
+	if result.Metrics.AsCasts != 1 || result.Metrics.TSSuppressions != 2 || result.Metrics.NonNullAssertions != 2 {
+		t.Errorf("Unexpected file metrics %+v", result.Metrics)
+	}
+	// Typed: id, the handler annotation and its parameter e
+	if result.Metrics.TypePositions != 6 || result.Metrics.TypedPositions != 3 {
+		t.Errorf("Expected 3 of 6 typed positions, got %+v", result.Metrics)
+	}
+	if result.TypeCoverage != 50 {
+		t.Errorf("Expected type coverage 50, got %.1f", result.TypeCoverage)
+	}
+}
+
+func TestAnalyzeTypeSafety_SuppressionsInStrings(t *testing.T) {
+	result := analyzeTypeSafetyOf(t, "const help = \"add // @ts-ignore above the line\";\n"+
+		"const tip = `\n  /* @ts-expect-error */\n`;\n"+
+		"const re = /\\/\\/ @ts-ignore/;\n"+
+		"// @ts-ignore\n"+
+		"const n: number = help;\n")
+	if result.Metrics.TSSuppressions != 1 {
+		t.Errorf("Expected only the comment to count as a suppression, got %d", result.Metrics.TSSuppressions)
+	}
+}
+
+func TestAnalyzeTypeSafety_FullyTyped(t *testing.T) {
+	result := analyzeTypeSafetyOf(t, `export class Store {
+  constructor(private readonly name: string) {}
+  get(key: string): unknown { return key; }
+}
+`)
+
+	if result.Metrics.Escapes() != 0 {
+		t.Errorf("Expected no type-safety escapes, got %+v", result.Metrics)
+	}
+	if result.TypeCoverage != 100 {
+		t.Errorf("Expected type coverage 100, got %.1f", result.TypeCoverage)
+	}
+}
+
+func TestIsTypeScriptFile(t *testing.T) {
+	tests := map[string]bool{
+		"src/a.ts":      true,
+		"src/a.tsx":     true,
+		"src/a.mts":     true,
+		"src/a.js":      false,
+		"src/a.d.ts":    false,
+		"src/types.cts": true,
+	}
+	for path, want := range tests {
+		if got := IsTypeScriptFile(path); got != want {
+			t.Errorf("IsTypeScriptFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
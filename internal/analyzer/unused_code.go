@@ -52,6 +52,10 @@ type ImportGraph struct {
 	reverseEdges map[string]map[string]bool
 	// forwardEdges maps importing file path → list of resolved file paths it imports.
 	forwardEdges map[string][]string
+
+	// analyzedFiles and idx resolve import sources after the graph is built.
+	analyzedFiles map[string]bool
+	idx           *suffixIndex
 }
 
 // BuildImportGraph constructs the ImportGraph in a single pass over allModuleInfos.
@@ -62,11 +66,14 @@ func BuildImportGraph(allModuleInfos map[string]*domain.ModuleInfo, analyzedFile
 		importedNamesFromFile: make(map[string]map[string]bool),
 		reverseEdges:          make(map[string]map[string]bool),
 		forwardEdges:          make(map[string][]string),
+		analyzedFiles:         analyzedFiles,
+		idx:                   buildSuffixIndex(nil),
 	}
 	if len(allModuleInfos) == 0 {
 		return graph
 	}
 	idx := buildSuffixIndex(analyzedFiles)
+	graph.idx = idx
 	for importingFile, info := range allModuleInfos {
 		for _, imp := range info.Imports {
 			resolvedPaths := resolveImportPaths(importingFile, imp.Source, imp.SourceType, analyzedFiles, idx)
@@ -101,48 +108,85 @@ func BuildImportGraph(allModuleInfos map[string]*domain.ModuleInfo, analyzedFile
 	return graph
 }
 
+// resolve resolves an import or re-export source of importingFile to analyzed files
+func (g *ImportGraph) resolve(importingFile, source string, sourceType domain.ModuleType) []string {
+	return resolveImportPaths(importingFile, source, sourceType, g.analyzedFiles, g.idx)
+}
+
+// UnusedImport is an imported name that is never referenced in its file
+type UnusedImport struct {
+	Import    *domain.Import
+	Specifier domain.ImportSpecifier
+	// TypeOnly is set for `import type` statements and `{ type X }` specifiers
+	TypeOnly bool
+}
+
+// Finding converts the unused import to a dead code finding in filePath
+func (u UnusedImport) Finding(filePath string) *DeadCodeFinding {
+	what := "Imported name '"
+	if u.TypeOnly {
+		what = "Imported type '"
+	}
+	return &DeadCodeFinding{
+		FilePath:  filePath,
+		StartLine: u.Import.Location.StartLine,
+		EndLine:   u.Import.Location.StartLine,
+		Reason:    ReasonUnusedImport,
+		Severity:  SeverityLevelWarning,
+		Description: what + u.Specifier.Local + "' from '" +
+			u.Import.Source + "' is never used",
+	}
+}
+
 // DetectUnusedImports detects imported names that are never referenced in the file.
 // It walks the AST to collect all identifier references (excluding import/export declarations)
 // and compares them against the locally-bound import names.
 func DetectUnusedImports(ast *parser.Node, moduleInfo *domain.ModuleInfo, filePath string) []*DeadCodeFinding {
+	var content []byte
+	if filePath != "" {
+		content, _ = os.ReadFile(filePath)
+	}
+	return DetectUnusedImportsInSource(ast, moduleInfo, filePath, content)
+}
+
+// DetectUnusedImportsInSource is DetectUnusedImports for a file whose content is
+// already in memory, such as an unsaved editor buffer
+func DetectUnusedImportsInSource(ast *parser.Node, moduleInfo *domain.ModuleInfo, filePath string, content []byte) []*DeadCodeFinding {
+	var findings []*DeadCodeFinding
+	for _, unused := range FindUnusedImports(ast, moduleInfo, content) {
+		findings = append(findings, unused.Finding(filePath))
+	}
+	return findings
+}
+
+// FindUnusedImports returns the import specifiers of the file that are never referenced,
+// in import order, including type-only imports. content is the source of the file, used to
+// recognize `import type` lines.
+func FindUnusedImports(ast *parser.Node, moduleInfo *domain.ModuleInfo, content []byte) []UnusedImport {
 	if ast == nil || moduleInfo == nil {
 		return nil
 	}
 
-	// Collect local names from imports (skip side-effect, type-only, dynamic)
-	typeOnlyImportLines := detectTypeOnlyImportLines(filePath)
-	type importEntry struct {
-		localName string
-		line      int
-		source    string
-	}
-	var importedNames []importEntry
+	// Collect local names from imports (skip side-effect, dynamic)
+	typeOnlyImportLines := detectTypeOnlyImportLines(content)
+	var importedNames []UnusedImport
 
 	for _, imp := range moduleInfo.Imports {
 		// Skip side-effect imports (import 'polyfill')
 		if imp.ImportType == domain.ImportTypeSideEffect {
 			continue
 		}
-		// Skip type-only imports (import type { Foo } from 'bar')
-		if imp.IsTypeOnly || imp.ImportType == domain.ImportTypeTypeOnly || typeOnlyImportLines[imp.Location.StartLine] {
-			continue
-		}
 		// Skip dynamic imports (import('foo'))
 		if imp.IsDynamic || imp.ImportType == domain.ImportTypeDynamic {
 			continue
 		}
 
+		// Type-only imports (import type { Foo } from 'bar') are checked like values:
+		// type annotations, type arguments and heritage clauses are in the AST
+		typeOnly := imp.IsTypeOnly || imp.ImportType == domain.ImportTypeTypeOnly || typeOnlyImportLines[imp.Location.StartLine]
 		for _, spec := range imp.Specifiers {
-			// Skip type-only specifiers
-			if spec.IsType {
-				continue
-			}
 			if spec.Local != "" {
-				importedNames = append(importedNames, importEntry{
-					localName: spec.Local,
-					line:      imp.Location.StartLine,
-					source:    imp.Source,
-				})
+				importedNames = append(importedNames, UnusedImport{Import: imp, Specifier: spec, TypeOnly: typeOnly || spec.IsType})
 			}
 		}
 	}
@@ -180,38 +224,21 @@ func DetectUnusedImports(ast *parser.Node, moduleInfo *domain.ModuleInfo, filePa
 		return true
 	})
 
-	// Generate findings for unreferenced imports
-	var findings []*DeadCodeFinding
+	// Keep the unreferenced imports
+	var unused []UnusedImport
 	for _, entry := range importedNames {
-		if !referenced[entry.localName] {
-			findings = append(findings, &DeadCodeFinding{
-				FilePath:  filePath,
-				StartLine: entry.line,
-				EndLine:   entry.line,
-				Reason:    ReasonUnusedImport,
-				Severity:  SeverityLevelWarning,
-				Description: "Imported name '" + entry.localName + "' from '" +
-					entry.source + "' is never used",
-			})
+		if !referenced[entry.Specifier.Local] {
+			unused = append(unused, entry)
 		}
 	}
 
-	return findings
+	return unused
 }
 
 // detectTypeOnlyImportLines returns source line numbers where import declarations are
 // explicitly type-only (e.g. `import type { Foo } from 'bar'`).
-func detectTypeOnlyImportLines(filePath string) map[int]bool {
+func detectTypeOnlyImportLines(content []byte) map[int]bool {
 	lines := make(map[int]bool)
-	if filePath == "" {
-		return lines
-	}
-
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return lines
-	}
-
 	for i, line := range strings.Split(string(content), "\n") {
 		if strings.HasPrefix(strings.TrimSpace(line), "import type ") {
 			lines[i+1] = true
@@ -221,13 +248,15 @@ func detectTypeOnlyImportLines(filePath string) map[int]bool {
 }
 
 // DetectUnusedExports detects exported names that are not imported by any other analyzed file.
-// It uses the precomputed ImportGraph to check each export against the reverse import index.
+// It uses the precomputed ImportGraph to check each export against the reverse import index,
+// following re-exports so that names imported through a barrel count as used in the module
+// defining them.
 func DetectUnusedExports(allModuleInfos map[string]*domain.ModuleInfo, graph *ImportGraph) []*DeadCodeFinding {
 	if len(allModuleInfos) == 0 {
 		return nil
 	}
 
-	importedNamesFromFile := graph.importedNamesFromFile
+	importedNamesFromFile := graph.usedExports(allModuleInfos)
 
 	var findings []*DeadCodeFinding
 
@@ -240,6 +269,10 @@ func DetectUnusedExports(allModuleInfos map[string]*domain.ModuleInfo, graph *Im
 		if isTestFile(filePath) {
 			continue
 		}
+		// Skip declaration files, which describe code defined elsewhere
+		if isDeclarationFile(filePath) {
+			continue
+		}
 
 		importedNames := importedNamesFromFile[filePath]
 
@@ -261,6 +294,10 @@ func DetectUnusedExports(allModuleInfos map[string]*domain.ModuleInfo, graph *Im
 			if exp.ExportType == "all" {
 				continue
 			}
+			// Skip types, interfaces and enums, reported by DetectUnusedTypes
+			if isTypeDeclaration(exp.Declaration) {
+				continue
+			}
 
 			// Determine the exported name(s)
 			exportedNames := getExportedNames(exp)
@@ -286,6 +323,106 @@ func DetectUnusedExports(allModuleInfos map[string]*domain.ModuleInfo, graph *Im
 	return findings
 }
 
+// usedExports returns the names used from each file: the names other files import, and the
+// names re-exported by a file that are used from it. Re-exports of an entry-point file
+// nothing imports are its public API and count as used.
+func (g *ImportGraph) usedExports(allModuleInfos map[string]*domain.ModuleInfo) map[string]map[string]bool {
+	used := make(map[string]map[string]bool, len(g.importedNamesFromFile))
+	mark := func(file, name string) bool {
+		if used[file] == nil {
+			used[file] = make(map[string]bool)
+		}
+		if used[file][name] {
+			return false
+		}
+		used[file][name] = true
+		return true
+	}
+	for file, names := range g.importedNamesFromFile {
+		for name := range names {
+			mark(file, name)
+		}
+	}
+
+	type reExport struct {
+		sources []string
+		local   string // name in the sources; "" for export * and export * as ns
+		star    bool
+	}
+	reExports := make(map[string]map[string][]reExport) // file -> exported name ("" = export *) -> re-exports
+	localNames := make(map[string]map[string]bool)
+	for file, info := range allModuleInfos {
+		for _, exp := range info.Exports {
+			if exp.Source == "" {
+				if localNames[file] == nil {
+					localNames[file] = make(map[string]bool)
+				}
+				for _, name := range getExportedNames(exp) {
+					localNames[file][name] = true
+				}
+				continue
+			}
+			sources := g.resolve(file, exp.Source, exp.SourceType)
+			if len(sources) == 0 {
+				continue
+			}
+			if reExports[file] == nil {
+				reExports[file] = make(map[string][]reExport)
+			}
+			switch {
+			case exp.ExportType == "all":
+				reExports[file][""] = append(reExports[file][""], reExport{sources: sources, star: true})
+			case len(exp.Specifiers) == 0:
+				// export * as ns from './mod' hands the whole module to the importers
+				reExports[file][exp.Name] = append(reExports[file][exp.Name], reExport{sources: sources})
+			default:
+				for _, spec := range exp.Specifiers {
+					reExports[file][spec.Exported] = append(reExports[file][spec.Exported], reExport{sources: sources, local: spec.Local})
+				}
+			}
+		}
+		if isEntryPointFile(file) && len(g.reverseEdges[file]) == 0 && len(reExports[file]) > 0 {
+			mark(file, "*")
+		}
+	}
+
+	// Propagate the used names to the re-exported modules until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for file, byName := range reExports {
+			names := used[file]
+			if len(names) == 0 {
+				continue
+			}
+			all := names["*"]
+			for exported, list := range byName {
+				for _, re := range list {
+					for _, source := range re.sources {
+						switch {
+						case re.star && all:
+							changed = mark(source, "*") || changed
+						case re.star:
+							// export * forwards the names the file neither declares nor re-exports by name
+							for name := range names {
+								if _, named := byName[name]; !named && !localNames[file][name] && name != "default" {
+									changed = mark(source, name) || changed
+								}
+							}
+						case re.local == "":
+							if all || names[exported] || exported == "" {
+								changed = mark(source, "*") || changed
+							}
+						case all || names[exported]:
+							changed = mark(source, re.local) || changed
+						}
+					}
+				}
+			}
+		}
+	}
+	return used
+}
+
 // getExportedNames extracts the exported name(s) from an export declaration.
 func getExportedNames(exp *domain.Export) []string {
 	var names []string
@@ -392,6 +529,28 @@ func isTestFile(filePath string) bool {
 	return false
 }
 
+// isDeclarationFile checks if a file is a TypeScript declaration file (.d.ts),
+// which declares types of code defined elsewhere and is never imported directly.
+func isDeclarationFile(filePath string) bool {
+	base := filepath.Base(filePath)
+	for _, suffix := range []string{".d.ts", ".d.mts", ".d.cts"} {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTypeDeclaration checks if a declaration string represents a TypeScript
+// interface, type alias or enum.
+func isTypeDeclaration(decl string) bool {
+	switch parser.NodeType(decl) {
+	case parser.NodeInterfaceDeclaration, parser.NodeTypeAlias, parser.NodeEnumDeclaration:
+		return true
+	}
+	return false
+}
+
 // isFunctionOrClassDeclaration checks if a declaration string represents
 // a function or class. The parser stores AST node types like
 // "FunctionDeclaration", "AsyncFunctionDeclaration", "ClassDeclaration".
@@ -413,7 +572,7 @@ func isConfigFile(filePath string) bool {
 
 // DetectOrphanFiles detects files that are not reachable from any entry point via import chains.
 // Entry points are: index/main/app/server files, and files not imported by any other file.
-// Test files and config files are skipped.
+// Test files, config files and declaration files are skipped.
 func DetectOrphanFiles(allModuleInfos map[string]*domain.ModuleInfo, graph *ImportGraph) []*DeadCodeFinding {
 	if len(allModuleInfos) == 0 {
 		return nil
@@ -426,7 +585,7 @@ func DetectOrphanFiles(allModuleInfos map[string]*domain.ModuleInfo, graph *Impo
 	// 2. Files not imported by any other file (root files with no reverse edges)
 	entryPoints := make(map[string]bool)
 	for filePath := range allModuleInfos {
-		if isTestFile(filePath) || isConfigFile(filePath) {
+		if isTestFile(filePath) || isConfigFile(filePath) || isDeclarationFile(filePath) {
 			continue
 		}
 		if isEntryPointFile(filePath) {
@@ -464,7 +623,7 @@ func DetectOrphanFiles(allModuleInfos map[string]*domain.ModuleInfo, graph *Impo
 		if reachable[filePath] {
 			continue
 		}
-		if isTestFile(filePath) || isConfigFile(filePath) {
+		if isTestFile(filePath) || isConfigFile(filePath) || isDeclarationFile(filePath) {
 			continue
 		}
 		findings = append(findings, &DeadCodeFinding{
@@ -494,7 +653,7 @@ func DetectUnusedExportedFunctions(allModuleInfos map[string]*domain.ModuleInfo,
 		if isEntryPointFile(filePath) {
 			continue
 		}
-		if isTestFile(filePath) {
+		if isTestFile(filePath) || isDeclarationFile(filePath) {
 			continue
 		}
 
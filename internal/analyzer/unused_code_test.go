@@ -126,10 +126,8 @@ console.log('hello');
 	}
 }
 
-func TestDetectUnusedImports_TypeOnlySkipped(t *testing.T) {
-	// When IsTypeOnly is set on the import, it should be skipped.
-	// We test this with manually constructed ModuleInfo since the parser
-	// does not yet fully detect `import type` syntax.
+func TestDetectUnusedImports_TypeOnlyReported(t *testing.T) {
+	// Type-only imports that are never referenced are reported as type imports.
 	ast := &parser.Node{
 		Type: parser.NodeProgram,
 		Body: []*parser.Node{
@@ -156,11 +154,11 @@ func TestDetectUnusedImports_TypeOnlySkipped(t *testing.T) {
 
 	findings := DetectUnusedImports(ast, info, "test.ts")
 
-	if len(findings) != 0 {
-		t.Errorf("Expected 0 findings for type-only import, got %d", len(findings))
-		for _, f := range findings {
-			t.Logf("  finding: %s", f.Description)
-		}
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding for unused type-only import, got %d", len(findings))
+	}
+	if findings[0].Description != "Imported type 'Foo' from './types' is never used" {
+		t.Errorf("Unexpected description: %s", findings[0].Description)
 	}
 }
 
@@ -209,6 +207,33 @@ export async function getScanResult(id: string): Promise<ScanResult> {
 	}
 }
 
+func TestFindUnusedImports_InSource(t *testing.T) {
+	source := `import type { Props } from "./types";
+import React, { useState, useMemo as memo } from "react";
+
+export function App(p: Props) {
+	return useState(p);
+}`
+	ast, info := parseAndAnalyzeTS(t, source)
+
+	// The content comes from memory; no file is read
+	unused := FindUnusedImports(ast, info, []byte(source))
+	if len(unused) != 2 {
+		t.Fatalf("Expected 2 unused imports, got %+v", unused)
+	}
+	if unused[0].Specifier.Local != "React" || unused[1].Specifier.Imported != "useMemo" || unused[1].Specifier.Local != "memo" {
+		t.Errorf("Unexpected unused specifiers %+v %+v", unused[0].Specifier, unused[1].Specifier)
+	}
+	if unused[0].Import != unused[1].Import || unused[0].Import.Source != "react" {
+		t.Errorf("Expected both specifiers from the react import, got %+v", unused[0].Import)
+	}
+
+	findings := DetectUnusedImportsInSource(ast, info, "app.ts", []byte(source))
+	if len(findings) != 2 || findings[0].StartLine != 2 || findings[1].Description != "Imported name 'memo' from 'react' is never used" {
+		t.Errorf("Unexpected findings %+v", findings)
+	}
+}
+
 func TestDetectUnusedImports_NilInputs(t *testing.T) {
 	findings := DetectUnusedImports(nil, nil, "test.js")
 	if findings != nil {
@@ -339,6 +364,49 @@ func TestDetectUnusedExports_ReExportSkipped(t *testing.T) {
 	}
 }
 
+func TestDetectUnusedExports_ThroughBarrel(t *testing.T) {
+	sources := map[string]string{
+		"/src/lib/index.ts": "export * from './a';\nexport { b } from './b';\nexport { c as renamed } from './c';\n",
+		"/src/lib/a.ts":     "export const a = 1;\nexport const a2 = 2;\n",
+		"/src/lib/b.ts":     "export const b = 1;\n",
+		"/src/lib/c.ts":     "export const c = 1;\n",
+		"/src/app.ts":       "import { a, renamed } from './lib';\nconsole.log(a, renamed);\n",
+	}
+	allInfos := make(map[string]*domain.ModuleInfo)
+	analyzedFiles := make(map[string]bool)
+	for path, source := range sources {
+		ast, err := parser.ParseForLanguage(path, []byte(source))
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", path, err)
+		}
+		info, err := NewModuleAnalyzer(DefaultModuleAnalyzerConfig()).AnalyzeFile(ast, path)
+		if err != nil {
+			t.Fatalf("Failed to analyze %s: %v", path, err)
+		}
+		allInfos[path] = info
+		analyzedFiles[path] = true
+	}
+
+	findings := DetectUnusedExports(allInfos, BuildImportGraph(allInfos, analyzedFiles))
+
+	reported := make(map[string]string)
+	for _, f := range findings {
+		reported[f.Description] = f.FilePath
+	}
+	if len(findings) != 2 ||
+		reported["Export 'b' is not imported by any other analyzed file"] != "/src/lib/b.ts" ||
+		reported["Export 'a2' is not imported by any other analyzed file"] != "/src/lib/a.ts" {
+		t.Errorf("Expected only b and a2 to be unused, got %v", reported)
+	}
+
+	// Re-exports of an index file nothing imports are its public API
+	delete(allInfos, "/src/app.ts")
+	delete(analyzedFiles, "/src/app.ts")
+	if findings := DetectUnusedExports(allInfos, BuildImportGraph(allInfos, analyzedFiles)); len(findings) != 0 {
+		t.Errorf("Expected the re-exports of a public barrel to be used, got %d findings", len(findings))
+	}
+}
+
 func TestDetectUnusedExports_IndexFileSkipped(t *testing.T) {
 	allInfos := map[string]*domain.ModuleInfo{
 		"/src/index.js": {
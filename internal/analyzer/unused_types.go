@@ -0,0 +1,432 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// TypeDeclaration is a TypeScript interface, type alias or enum declared at the top
+// level of a file. Declarations merged by name, such as an interface declared twice,
+// are one TypeDeclaration located at the first of them.
+type TypeDeclaration struct {
+	Name     string
+	Kind     parser.NodeType
+	Location parser.Location
+	// Members are the EnumMember nodes of an enum, from all of its declarations
+	Members []*parser.Node
+}
+
+// TypeUsage records the TypeScript types a file declares and the names it references.
+// Build it with CollectTypeUsage and pass the usages of all files to DetectUnusedTypes.
+type TypeUsage struct {
+	Declarations []*TypeDeclaration
+
+	// references holds names referenced outside their own declaration
+	references map[string]bool
+	// memberReads maps a name to the members read through it (E.A, E['A'])
+	memberReads map[string]map[string]bool
+	// wholeUses holds names used as values other than to read a member,
+	// such as Object.values(E)
+	wholeUses map[string]bool
+	// exportedNames holds the local names of export { X } specifiers
+	exportedNames map[string]bool
+	// valueNames holds top-level functions, classes, variables and namespaces;
+	// a type merged with a value of the same name is left to the value analyses
+	valueNames map[string]bool
+}
+
+// CollectTypeUsage collects the type declarations and the references of a file.
+// Declarations inside namespaces and `declare` blocks are not collected.
+func CollectTypeUsage(ast *parser.Node) *TypeUsage {
+	usage := &TypeUsage{
+		references:    make(map[string]bool),
+		memberReads:   make(map[string]map[string]bool),
+		wholeUses:     make(map[string]bool),
+		exportedNames: make(map[string]bool),
+		valueNames:    make(map[string]bool),
+	}
+	if ast == nil {
+		return usage
+	}
+
+	byName := make(map[string]*TypeDeclaration)
+	for _, stmt := range ast.Body {
+		decl := stmt
+		if (stmt.Type == parser.NodeExportNamedDeclaration || stmt.Type == parser.NodeExportDefaultDeclaration) && stmt.Declaration != nil {
+			decl = stmt.Declaration
+		}
+
+		switch decl.Type {
+		case parser.NodeInterfaceDeclaration, parser.NodeTypeAlias, parser.NodeEnumDeclaration:
+			if decl.Name == "" {
+				break
+			}
+			td := byName[decl.Name]
+			if td == nil {
+				td = &TypeDeclaration{Name: decl.Name, Kind: decl.Type, Location: decl.Location}
+				byName[decl.Name] = td
+				usage.Declarations = append(usage.Declarations, td)
+			}
+			if decl.Type == parser.NodeEnumDeclaration {
+				td.Members = append(td.Members, decl.Body...)
+			}
+			usage.collectDeclaration(decl)
+			continue
+
+		default:
+			usage.collectValueNames(decl)
+		}
+
+		usage.collect([]*parser.Node{stmt}, "")
+	}
+
+	return usage
+}
+
+// collectDeclaration collects the references of a type declaration; references to
+// the declaration itself do not count. Bare member names in the initializers of an
+// enum read those members.
+func (u *TypeUsage) collectDeclaration(decl *parser.Node) {
+	u.collect(decl.Children, decl.Name)
+	u.collect(decl.TypeParameters, decl.Name)
+	u.collect([]*parser.Node{decl.TypeAnnotation}, decl.Name)
+
+	if decl.Type != parser.NodeEnumDeclaration {
+		return
+	}
+	members := make(map[string]bool, len(decl.Body))
+	for _, member := range decl.Body {
+		members[member.Name] = true
+	}
+	for _, member := range decl.Body {
+		u.collect(member.Children, decl.Name)
+		for _, init := range member.Children {
+			init.Walk(func(n *parser.Node) bool {
+				if n.Type == parser.NodeIdentifier && members[n.Name] {
+					u.readMember(decl.Name, n.Name, decl.Name)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// collectValueNames records the value names a top-level statement declares
+func (u *TypeUsage) collectValueNames(stmt *parser.Node) {
+	switch stmt.Type {
+	case parser.NodeFunction, parser.NodeAsyncFunction, parser.NodeGeneratorFunction, parser.NodeClass:
+		if stmt.Name != "" {
+			u.valueNames[stmt.Name] = true
+		}
+	case parser.NodeVariableDeclaration:
+		// const X = ..., one declarator per name
+		for _, declarators := range [][]*parser.Node{stmt.Declarations, stmt.Children} {
+			for _, declarator := range declarators {
+				if len(declarator.Children) > 0 && declarator.Children[0].Type == parser.NodeIdentifier {
+					u.valueNames[declarator.Children[0].Name] = true
+				}
+			}
+		}
+	case "abstract_class_declaration", "internal_module":
+		// abstract class X, namespace X
+		for _, child := range stmt.Children {
+			if child.Type == parser.NodeIdentifier {
+				u.valueNames[child.Name] = true
+				break
+			}
+		}
+	case parser.NodeExpressionStatement:
+		for _, child := range stmt.Children {
+			u.collectValueNames(child)
+		}
+	}
+}
+
+// collect records the references in nodes. self is the name of the declaration the
+// nodes belong to, if any.
+func (u *TypeUsage) collect(nodes []*parser.Node, self string) {
+	for _, node := range nodes {
+		node.Walk(func(n *parser.Node) bool {
+			switch n.Type {
+			case parser.NodeImportDeclaration:
+				// Import declarations bind names rather than reference them
+				return false
+
+			case parser.NodeIdentifier:
+				u.reference(n.Name, n.Kind != "type", self)
+
+			case parser.NodeExportSpecifier:
+				name := n.Name
+				if n.Local != nil && n.Local.Name != "" {
+					name = n.Local.Name
+				}
+				u.reference(name, false, self)
+				u.exportedNames[name] = true
+
+			case parser.NodeMemberExpression:
+				// E.A, or Ns.Type in a type; the property is not a reference
+				if n.Object != nil && n.Object.Type == parser.NodeIdentifier && n.Property != nil {
+					u.readMember(n.Object.Name, n.Property.Name, self)
+				} else {
+					u.collect([]*parser.Node{n.Object}, self)
+				}
+				return false
+
+			case "subscript_expression":
+				// E['A'] reads a member; E[key] uses the whole object
+				if len(n.Children) > 0 && n.Children[0].Type == parser.NodeIdentifier {
+					if index := subscriptIndex(n); index != nil && index.Type == parser.NodeStringLiteral {
+						u.readMember(n.Children[0].Name, unquote(index.Raw), self)
+						return false
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// reference records a reference to name; value is set for references outside types
+func (u *TypeUsage) reference(name string, value bool, self string) {
+	if name == "" || name == self {
+		return
+	}
+	u.references[name] = true
+	if value {
+		u.wholeUses[name] = true
+	}
+}
+
+// readMember records a read of member through name
+func (u *TypeUsage) readMember(name, member, self string) {
+	if name == "" || member == "" {
+		return
+	}
+	if name != self {
+		u.references[name] = true
+	}
+	if u.memberReads[name] == nil {
+		u.memberReads[name] = make(map[string]bool)
+	}
+	u.memberReads[name][member] = true
+}
+
+// subscriptIndex returns the index expression of a subscript_expression node
+func subscriptIndex(n *parser.Node) *parser.Node {
+	var index *parser.Node
+	for _, child := range n.Children[1:] {
+		switch child.Type {
+		case "[", "]", "?.":
+			continue
+		}
+		if index != nil {
+			return nil
+		}
+		index = child
+	}
+	return index
+}
+
+// unquote strips the quotes of a string literal
+func unquote(raw string) string {
+	if len(raw) >= 2 && (raw[0] == '\'' || raw[0] == '"' || raw[0] == '`') && raw[len(raw)-1] == raw[0] {
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
+
+// DetectUnusedTypes detects TypeScript types, interfaces and enums that are never used:
+// exported ones no analyzed file imports or re-exports, non-exported ones the file never
+// references, and enum members never read in the file or in the files importing the enum.
+// Types merged with a value of the same name, declaration files (.d.ts) and enums used
+// as a whole, such as Object.values(E), are skipped.
+func DetectUnusedTypes(allModuleInfos map[string]*domain.ModuleInfo, usages map[string]*TypeUsage, graph *ImportGraph) []*DeadCodeFinding {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	// Uses of exported names by other files, keyed by resolved file path and exported name
+	reexported := make(map[string]map[string]bool)
+	memberReads := make(map[string]map[string]map[string]bool)
+	wholeUses := make(map[string]map[string]bool)
+	mark := func(m map[string]map[string]bool, file, name string) {
+		if m[file] == nil {
+			m[file] = make(map[string]bool)
+		}
+		m[file][name] = true
+	}
+
+	for importingFile, info := range allModuleInfos {
+		usage := usages[importingFile]
+		for _, imp := range info.Imports {
+			if imp.IsDynamic || usage == nil {
+				continue
+			}
+			for _, path := range graph.resolve(importingFile, imp.Source, imp.SourceType) {
+				for _, spec := range imp.Specifiers {
+					name := spec.Imported
+					if name == "" {
+						name = spec.Local
+					}
+					// Re-exporting an imported binding hands all of it to other files
+					if usage.wholeUses[spec.Local] || usage.exportedNames[spec.Local] {
+						mark(wholeUses, path, name)
+					}
+					for member := range usage.memberReads[spec.Local] {
+						if memberReads[path] == nil {
+							memberReads[path] = make(map[string]map[string]bool)
+						}
+						if memberReads[path][name] == nil {
+							memberReads[path][name] = make(map[string]bool)
+						}
+						memberReads[path][name][member] = true
+					}
+				}
+			}
+		}
+		for _, exp := range info.Exports {
+			if exp.Source == "" {
+				continue
+			}
+			for _, path := range graph.resolve(importingFile, exp.Source, exp.SourceType) {
+				if exp.ExportType == "all" {
+					mark(reexported, path, "*")
+				}
+				for _, spec := range exp.Specifiers {
+					mark(reexported, path, spec.Local)
+				}
+			}
+		}
+	}
+
+	var findings []*DeadCodeFinding
+	for filePath, usage := range usages {
+		if isDeclarationFile(filePath) {
+			continue
+		}
+
+		// Names each local declaration is exported as
+		exportedAs := make(map[string][]string)
+		if info := allModuleInfos[filePath]; info != nil {
+			for _, exp := range info.Exports {
+				if exp.Source != "" || exp.ExportType == "all" {
+					continue
+				}
+				if exp.ExportType == "default" && exp.Name != "" {
+					exportedAs[exp.Name] = append(exportedAs[exp.Name], "default")
+					continue
+				}
+				for _, spec := range exp.Specifiers {
+					exported := spec.Exported
+					if exported == "" {
+						exported = spec.Local
+					}
+					exportedAs[spec.Local] = append(exportedAs[spec.Local], exported)
+				}
+			}
+		}
+
+		imported := graph.importedNamesFromFile[filePath]
+		public := isEntryPointFile(filePath) || imported["*"] || reexported[filePath]["*"]
+
+		for _, decl := range usage.Declarations {
+			if usage.valueNames[decl.Name] {
+				continue
+			}
+			names := exportedAs[decl.Name]
+
+			if len(names) == 0 {
+				if !usage.references[decl.Name] {
+					findings = append(findings, typeFinding(filePath, decl, ReasonUnusedType, SeverityLevelWarning,
+						"Non-exported "+typeKindLabel(decl.Kind)+" '"+decl.Name+"' is never referenced"))
+					continue
+				}
+			} else {
+				if public {
+					continue
+				}
+				used := false
+				for _, name := range names {
+					if imported[name] || reexported[filePath][name] || isFrameworkReservedExport(filePath, nil, name) {
+						used = true
+					}
+				}
+				if !used && !isTestFile(filePath) {
+					findings = append(findings, typeFinding(filePath, decl, ReasonUnusedExportedType, SeverityLevelInfo,
+						"Exported "+typeKindLabel(decl.Kind)+" '"+decl.Name+"' is not imported by any other analyzed file"))
+					continue
+				}
+			}
+
+			if decl.Kind != parser.NodeEnumDeclaration || usage.wholeUses[decl.Name] {
+				continue
+			}
+			read := usage.memberReads[decl.Name]
+			wholeUse := false
+			for _, name := range names {
+				if reexported[filePath][name] || wholeUses[filePath][name] {
+					wholeUse = true
+				}
+			}
+			if wholeUse {
+				continue
+			}
+			for _, member := range decl.Members {
+				if read[member.Name] || importedMemberRead(memberReads[filePath], names, member.Name) {
+					continue
+				}
+				findings = append(findings, &DeadCodeFinding{
+					FilePath:    filePath,
+					StartLine:   member.Location.StartLine,
+					EndLine:     member.Location.EndLine,
+					Reason:      ReasonUnusedEnumMember,
+					Severity:    SeverityLevelInfo,
+					Description: "Enum member '" + decl.Name + "." + member.Name + "' is never read",
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].FilePath != findings[j].FilePath {
+			return findings[i].FilePath < findings[j].FilePath
+		}
+		return findings[i].StartLine < findings[j].StartLine
+	})
+	return findings
+}
+
+// importedMemberRead reports whether another file reads member of the enum exported as names
+func importedMemberRead(reads map[string]map[string]bool, names []string, member string) bool {
+	for _, name := range names {
+		if reads[name][member] {
+			return true
+		}
+	}
+	return false
+}
+
+func typeFinding(filePath string, decl *TypeDeclaration, reason DeadCodeReason, severity SeverityLevel, description string) *DeadCodeFinding {
+	return &DeadCodeFinding{
+		FilePath:    filePath,
+		StartLine:   decl.Location.StartLine,
+		EndLine:     decl.Location.EndLine,
+		Reason:      reason,
+		Severity:    severity,
+		Description: description,
+	}
+}
+
+// typeKindLabel names the kind of a type declaration for descriptions
+func typeKindLabel(kind parser.NodeType) string {
+	switch kind {
+	case parser.NodeInterfaceDeclaration:
+		return "interface"
+	case parser.NodeEnumDeclaration:
+		return "enum"
+	default:
+		return "type"
+	}
+}
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// detectUnusedTypesIn runs DetectUnusedTypes on TS sources keyed by absolute file path
+// and returns "file:line reason" for each finding, sorted
+func detectUnusedTypesIn(t *testing.T, sources map[string]string) []string {
+	t.Helper()
+	allInfos := make(map[string]*domain.ModuleInfo)
+	usages := make(map[string]*TypeUsage)
+	analyzedFiles := make(map[string]bool)
+
+	ma := NewModuleAnalyzer(DefaultModuleAnalyzerConfig())
+	for path, source := range sources {
+		p := parser.NewTypeScriptParser()
+		ast, err := p.ParseFile(path, []byte(source))
+		p.Close()
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", path, err)
+		}
+		info, err := ma.AnalyzeFile(ast, path)
+		if err != nil {
+			t.Fatalf("Failed to analyze %s: %v", path, err)
+		}
+		allInfos[path] = info
+		usages[path] = CollectTypeUsage(ast)
+		analyzedFiles[path] = true
+	}
+
+	graph := BuildImportGraph(allInfos, analyzedFiles)
+	var got []string
+	for _, f := range DetectUnusedTypes(allInfos, usages, graph) {
+		got = append(got, fmt.Sprintf("%s:%d %s", f.FilePath, f.StartLine, f.Reason))
+	}
+	sort.Strings(got)
+	return got
+}
+
+func assertFindings(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Expected findings %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected finding %q, got %q", want[i], got[i])
+		}
+	}
+}
+
+func TestDetectUnusedTypes_NonExported(t *testing.T) {
+	got := detectUnusedTypesIn(t, map[string]string{
+		"/repo/src/main.ts": `interface Used { a: number }
+interface Unused { b: Used }
+type Self = { next: Self };
+interface Merged { x: string }
+interface Merged { y: string }
+interface WithValue { z: string }
+class WithValue {}
+function f(m: Merged) { return m; }
+f({ x: '', y: '' });
+`,
+	})
+
+	assertFindings(t, got, []string{
+		"/repo/src/main.ts:2 unused_type",
+		"/repo/src/main.ts:3 unused_type",
+	})
+}
+
+func TestDetectUnusedTypes_Exported(t *testing.T) {
+	got := detectUnusedTypesIn(t, map[string]string{
+		"/repo/src/main.ts": `import type { Imported } from './types';
+import { Barrel } from './barrel';
+let a: Imported;
+let b: Barrel;
+`,
+		"/repo/src/types.ts": `export interface Imported { a: number }
+export type Never = string;
+interface Local { b: number }
+export type { Local as Renamed };
+`,
+		"/repo/src/barrel.ts": `export { Barrel } from './shapes';
+`,
+		"/repo/src/shapes.ts": `export interface Barrel { c: number }
+`,
+		"/repo/src/index.ts": `export interface Public { d: number }
+`,
+	})
+
+	assertFindings(t, got, []string{
+		"/repo/src/types.ts:2 unused_exported_type",
+		"/repo/src/types.ts:3 unused_exported_type",
+	})
+}
+
+func TestDetectUnusedTypes_EnumMembers(t *testing.T) {
+	got := detectUnusedTypesIn(t, map[string]string{
+		"/repo/src/main.ts": `import { Color as C, Mode, Listed } from './enums';
+console.log(C.Red, C['Blue'], Mode.On, Object.values(Listed));
+enum Local { A = 1, B = A << 1, C }
+console.log(Local.B);
+`,
+		"/repo/src/enums.ts": `export enum Color { Red, Green, Blue }
+export enum Mode { On, Off }
+export enum Listed { X, Y }
+`,
+	})
+
+	assertFindings(t, got, []string{
+		"/repo/src/enums.ts:1 unused_enum_member",
+		"/repo/src/enums.ts:2 unused_enum_member",
+		"/repo/src/main.ts:3 unused_enum_member",
+	})
+}
+
+func TestDetectUnusedTypes_DeclarationFileSkipped(t *testing.T) {
+	got := detectUnusedTypesIn(t, map[string]string{
+		"/repo/src/globals.d.ts": `interface Window { app: string }
+export type Id = string;
+`,
+		"/repo/src/main.ts": `declare global { interface Ambient { x: number } }
+namespace Ns { export type Inner = string }
+`,
+	})
+
+	assertFindings(t, got, nil)
+}
+
+func TestDetectUnusedExports_DeclarationFileSkipped(t *testing.T) {
+	allInfos := map[string]*domain.ModuleInfo{
+		"/repo/src/types.d.ts": {
+			FilePath: "/repo/src/types.d.ts",
+			Exports: []*domain.Export{
+				{ExportType: "named", Name: "helper", Declaration: "FunctionDeclaration"},
+			},
+		},
+	}
+	analyzedFiles := map[string]bool{"/repo/src/types.d.ts": true}
+
+	graph := BuildImportGraph(allInfos, analyzedFiles)
+	if findings := DetectUnusedExports(allInfos, graph); len(findings) != 0 {
+		t.Errorf("Expected no unused exports in declaration files, got %d", len(findings))
+	}
+	if findings := DetectOrphanFiles(allInfos, graph); len(findings) != 0 {
+		t.Errorf("Expected declaration files not to be orphans, got %d", len(findings))
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -39,6 +42,13 @@ const (
 	DefaultDeadCodeSortBy = "severity"
 )
 
+// DefaultHistoryPath is the analysis history file, relative to the working directory
+const DefaultHistoryPath = ".jscan/history.jsonl"
+
+// DefaultMarkdownMaxBytes keeps Markdown reports below the 65536 character limit
+// of GitHub comments, with room for text added by the posting bot
+const DefaultMarkdownMaxBytes = 60000
+
 // Config represents the main configuration structure
 type Config struct {
 	// Complexity holds complexity analysis configuration
@@ -67,6 +77,275 @@ type Config struct {
 
 	// Analysis holds general analysis configuration
 	Analysis AnalysisConfig `json:"analysis,omitempty" mapstructure:"analysis" yaml:"analysis"`
+
+	// History holds analysis history recording configuration
+	History HistoryConfig `json:"history" mapstructure:"history" yaml:"history"`
+
+	// Scoring holds the health score weights, saturation points and grade boundaries
+	Scoring ScoringConfig `json:"scoring" mapstructure:"scoring" yaml:"scoring"`
+
+	// Check holds the quality gates of jscan check
+	Check CheckConfig `json:"check" mapstructure:"check" yaml:"check"`
+
+	// Hotspots holds the git history windows of jscan hotspots
+	Hotspots HotspotsConfig `json:"hotspots" mapstructure:"hotspots" yaml:"hotspots"`
+
+	// TemporalCoupling holds the thresholds of jscan deps --temporal-coupling
+	TemporalCoupling TemporalCouplingConfig `json:"temporal_coupling" mapstructure:"temporal_coupling" yaml:"temporal_coupling"`
+
+	// React holds the component thresholds of the React analysis
+	React ReactConfig `json:"react" mapstructure:"react" yaml:"react"`
+
+	// Packages holds the exemptions of jscan deps --packages
+	Packages PackagesConfig `json:"packages" mapstructure:"packages" yaml:"packages"`
+}
+
+// HotspotsConfig holds the git history windows used to rank hotspots. Windows are a
+// number followed by d (days), w (weeks), m (months) or y (years), or "all".
+type HotspotsConfig struct {
+	// Since is how far back the git history is read
+	Since string `json:"since" mapstructure:"since" yaml:"since"`
+
+	// Recent is the window whose changes count twice, so that code changing now ranks higher
+	Recent string `json:"recent" mapstructure:"recent" yaml:"recent"`
+
+	// Top is the number of files and functions reported (0 = all)
+	Top int `json:"top" mapstructure:"top" yaml:"top"`
+}
+
+// Validate checks the hotspot windows
+func (c *HotspotsConfig) Validate() error {
+	if _, err := ParseTimeWindow(c.Since); err != nil {
+		return fmt.Errorf("invalid hotspots.since: %w", err)
+	}
+	if _, err := ParseTimeWindow(c.Recent); err != nil {
+		return fmt.Errorf("invalid hotspots.recent: %w", err)
+	}
+	if c.Top < 0 {
+		return fmt.Errorf("hotspots.top must be >= 0, got %d", c.Top)
+	}
+	return nil
+}
+
+// TemporalCouplingConfig holds the thresholds used to compare co-changes in the git
+// history with the dependency graph
+type TemporalCouplingConfig struct {
+	// Since is how far back the git history is read (see ParseTimeWindow)
+	Since string `json:"since" mapstructure:"since" yaml:"since"`
+
+	// MinSharedCommits is the minimum number of commits changing both modules of a pair
+	MinSharedCommits int `json:"min_shared_commits" mapstructure:"min_shared_commits" yaml:"min_shared_commits"`
+
+	// MinDegree is the share of commits (0-1) from which two modules are logically coupled
+	MinDegree float64 `json:"min_degree" mapstructure:"min_degree" yaml:"min_degree"`
+
+	// MaxCommitFiles skips commits changing more modules, such as formatting runs
+	MaxCommitFiles int `json:"max_commit_files" mapstructure:"max_commit_files" yaml:"max_commit_files"`
+}
+
+// Validate checks the temporal coupling thresholds
+func (c *TemporalCouplingConfig) Validate() error {
+	if _, err := ParseTimeWindow(c.Since); err != nil {
+		return fmt.Errorf("invalid temporal_coupling.since: %w", err)
+	}
+	if c.MinSharedCommits < 1 {
+		return fmt.Errorf("temporal_coupling.min_shared_commits must be >= 1, got %d", c.MinSharedCommits)
+	}
+	if c.MinDegree <= 0 || c.MinDegree > 1 {
+		return fmt.Errorf("temporal_coupling.min_degree must be in (0, 1], got %g", c.MinDegree)
+	}
+	if c.MaxCommitFiles < 2 {
+		return fmt.Errorf("temporal_coupling.max_commit_files must be >= 2, got %d", c.MaxCommitFiles)
+	}
+	return nil
+}
+
+// ReactConfig holds the thresholds above which a React function component is
+// reported; 0 disables a threshold
+type ReactConfig struct {
+	// MaxJSXDepth is the maximum nesting depth of the JSX a component renders
+	MaxJSXDepth int `json:"max_jsx_depth" mapstructure:"max_jsx_depth" yaml:"max_jsx_depth"`
+
+	// MaxProps is the maximum number of props a component reads
+	MaxProps int `json:"max_props" mapstructure:"max_props" yaml:"max_props"`
+
+	// MaxHooks is the maximum number of hook calls in a component
+	MaxHooks int `json:"max_hooks" mapstructure:"max_hooks" yaml:"max_hooks"`
+
+	// MaxConditionalRenders is the maximum number of conditionally rendered branches
+	MaxConditionalRenders int `json:"max_conditional_renders" mapstructure:"max_conditional_renders" yaml:"max_conditional_renders"`
+}
+
+// Validate checks the React thresholds
+func (c *ReactConfig) Validate() error {
+	if c.MaxJSXDepth < 0 {
+		return fmt.Errorf("react.max_jsx_depth must be >= 0, got %d", c.MaxJSXDepth)
+	}
+	if c.MaxProps < 0 {
+		return fmt.Errorf("react.max_props must be >= 0, got %d", c.MaxProps)
+	}
+	if c.MaxHooks < 0 {
+		return fmt.Errorf("react.max_hooks must be >= 0, got %d", c.MaxHooks)
+	}
+	if c.MaxConditionalRenders < 0 {
+		return fmt.Errorf("react.max_conditional_renders must be >= 0, got %d", c.MaxConditionalRenders)
+	}
+	return nil
+}
+
+// PackagesConfig holds the exemptions used when comparing package imports with
+// package.json
+type PackagesConfig struct {
+	// DevFilePatterns match config, tooling and test files allowed to import
+	// devDependencies, in addition to the built-in patterns (*.config.*, tests, scripts/...)
+	DevFilePatterns []string `json:"dev_file_patterns" mapstructure:"dev_file_patterns" yaml:"dev_file_patterns"`
+
+	// IgnorePackages are never reported, e.g. packages loaded by a framework or
+	// specifiers resolved by a bundler alias
+	IgnorePackages []string `json:"ignore_packages" mapstructure:"ignore_packages" yaml:"ignore_packages"`
+}
+
+// Validate checks the package exemptions
+func (c *PackagesConfig) Validate() error {
+	for _, pattern := range c.DevFilePatterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("packages.dev_file_patterns must not contain empty patterns")
+		}
+	}
+	for _, name := range c.IgnorePackages {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("packages.ignore_packages must not contain empty names")
+		}
+	}
+	return nil
+}
+
+// ParseTimeWindow parses a history window such as 90d, 12w, 6m or 1y (a month is 30
+// days and a year 365 days); "all" and the empty string mean no limit and return 0
+func ParseTimeWindow(window string) (time.Duration, error) {
+	window = strings.TrimSpace(strings.ToLower(window))
+	if window == "" || window == "all" {
+		return 0, nil
+	}
+
+	days := map[byte]int{'d': 1, 'w': 7, 'm': 30, 'y': 365}[window[len(window)-1]]
+	n, err := strconv.Atoi(window[:len(window)-1])
+	if days == 0 || err != nil || n <= 0 {
+		return 0, fmt.Errorf("window %q must be a positive number followed by d, w, m or y, or all", window)
+	}
+	return time.Duration(n*days) * 24 * time.Hour, nil
+}
+
+// Quality gates of jscan check, as used in check.severities
+const (
+	CheckGateHealthScore          = "health_score"
+	CheckGateGrade                = "grade"
+	CheckGateDuplication          = "duplication"
+	CheckGateCBO                  = "cbo"
+	CheckGateNestingDepth         = "nesting_depth"
+	CheckGateDependencyDepth      = "dependency_depth"
+	CheckGateMainSequenceDistance = "main_sequence_distance"
+	CheckGateTypeCoverage         = "type_coverage"
+	CheckGateRulesOfHooks         = "rules_of_hooks"
+	CheckGateReactComponent       = "react_component"
+)
+
+// CheckConfig holds the quality gates of jscan check. A zero value disables a gate;
+// CLI flags override these values.
+type CheckConfig struct {
+	// MinHealthScore is the minimum health score (0-100) of the analyses that ran
+	MinHealthScore int `json:"min_health_score" mapstructure:"min_health_score" yaml:"min_health_score"`
+
+	// MinGrade is the minimum grade: A, B, C or D
+	MinGrade string `json:"min_grade" mapstructure:"min_grade" yaml:"min_grade"`
+
+	// MaxDuplication is the maximum percentage of duplicated code
+	MaxDuplication float64 `json:"max_duplication" mapstructure:"max_duplication" yaml:"max_duplication"`
+
+	// MaxCBO is the maximum coupling (CBO) of a class
+	MaxCBO int `json:"max_cbo" mapstructure:"max_cbo" yaml:"max_cbo"`
+
+	// MaxNestingDepth is the maximum nesting depth of a function
+	MaxNestingDepth int `json:"max_nesting_depth" mapstructure:"max_nesting_depth" yaml:"max_nesting_depth"`
+
+	// MaxDependencyDepth is the maximum length of a module dependency chain
+	MaxDependencyDepth int `json:"max_dependency_depth" mapstructure:"max_dependency_depth" yaml:"max_dependency_depth"`
+
+	// MaxMainSequenceDistance is the maximum average distance of modules from the main sequence (0-1)
+	MaxMainSequenceDistance float64 `json:"max_main_sequence_distance" mapstructure:"max_main_sequence_distance" yaml:"max_main_sequence_distance"`
+
+	// MinTypeCoverage is the minimum TypeScript type coverage percentage
+	MinTypeCoverage float64 `json:"min_type_coverage" mapstructure:"min_type_coverage" yaml:"min_type_coverage"`
+
+	// DeadCodeSeverity is the lowest dead code severity that fails the check: info
+	// (any finding, the default), warning or critical
+	DeadCodeSeverity string `json:"dead_code_severity" mapstructure:"dead_code_severity" yaml:"dead_code_severity"`
+
+	// Severities maps gates to "error" (fails the check with exit code 1, the default)
+	// or "warning" (reported without failing the check)
+	Severities map[string]string `json:"severities" mapstructure:"severities" yaml:"severities"`
+}
+
+// Severity returns the configured severity of a gate
+func (c *CheckConfig) Severity(gate string) string {
+	if severity := c.Severities[gate]; severity != "" {
+		return severity
+	}
+	return "error"
+}
+
+// ScoringConfig tunes the health score. The defaults reproduce the built-in scoring.
+type ScoringConfig struct {
+	// Weights is the maximum penalty each category deducts from the health score
+	Weights ScoringWeightsConfig `json:"weights" mapstructure:"weights" yaml:"weights"`
+
+	// Saturation is the metric value at which each category reaches its maximum penalty
+	Saturation ScoringSaturationConfig `json:"saturation" mapstructure:"saturation" yaml:"saturation"`
+
+	// Grades holds the minimum health score of each grade
+	Grades ScoringGradesConfig `json:"grades" mapstructure:"grades" yaml:"grades"`
+}
+
+// ScoringWeightsConfig holds the maximum penalty of each category (0 ignores the category)
+type ScoringWeightsConfig struct {
+	Complexity   int `json:"complexity" mapstructure:"complexity" yaml:"complexity"`
+	DeadCode     int `json:"dead_code" mapstructure:"dead_code" yaml:"dead_code"`
+	Duplication  int `json:"duplication" mapstructure:"duplication" yaml:"duplication"`
+	Coupling     int `json:"coupling" mapstructure:"coupling" yaml:"coupling"`
+	Dependencies int `json:"dependencies" mapstructure:"dependencies" yaml:"dependencies"`
+	Architecture int `json:"architecture" mapstructure:"architecture" yaml:"architecture"`
+}
+
+// ScoringSaturationConfig holds the saturation point of each category
+type ScoringSaturationConfig struct {
+	// Complexity is the weighted ratio of high/medium complexity functions (0-1)
+	Complexity float64 `json:"complexity" mapstructure:"complexity" yaml:"complexity"`
+
+	// DeadCode is the number of weighted dead code findings per file
+	DeadCode float64 `json:"dead_code" mapstructure:"dead_code" yaml:"dead_code"`
+
+	// Duplication is the percentage of duplicated code (penalties start at 1%)
+	Duplication float64 `json:"duplication" mapstructure:"duplication" yaml:"duplication"`
+
+	// Coupling is the weighted ratio of high/medium coupling classes (0-1)
+	Coupling float64 `json:"coupling" mapstructure:"coupling" yaml:"coupling"`
+}
+
+// ScoringGradesConfig holds the minimum health score of grades A to D; lower scores get F
+type ScoringGradesConfig struct {
+	A int `json:"a" mapstructure:"a" yaml:"a"`
+	B int `json:"b" mapstructure:"b" yaml:"b"`
+	C int `json:"c" mapstructure:"c" yaml:"c"`
+	D int `json:"d" mapstructure:"d" yaml:"d"`
+}
+
+// HistoryConfig holds configuration for recording analysis runs for trend reports
+type HistoryConfig struct {
+	// Enabled records every analyze run in the history file
+	Enabled bool `json:"enabled" mapstructure:"enabled" yaml:"enabled"`
+
+	// Path is the JSON Lines history file
+	Path string `json:"path" mapstructure:"path" yaml:"path"`
 }
 
 // ComplexityConfig holds configuration for cyclomatic complexity analysis
@@ -91,7 +370,7 @@ type ComplexityConfig struct {
 
 // OutputConfig holds configuration for output formatting
 type OutputConfig struct {
-	// Format specifies the output format: json, yaml, text, csv
+	// Format specifies the output format: json, yaml, text, csv, html, markdown, codeclimate
 	Format string `json:"format" mapstructure:"format" yaml:"format"`
 
 	// ShowDetails controls whether to show detailed breakdown
@@ -105,6 +384,9 @@ type OutputConfig struct {
 
 	// Directory specifies the output directory for reports (empty = tool default, e.g., ".pyscn/reports" under current working directory)
 	Directory string `json:"directory" mapstructure:"directory" yaml:"directory"`
+
+	// MarkdownMaxBytes is the size budget of Markdown reports (0 = unlimited)
+	MarkdownMaxBytes int `json:"markdown_max_bytes" mapstructure:"markdown_max_bytes" yaml:"markdown_max_bytes"`
 }
 
 // DeadCodeConfig holds configuration for dead code detection
@@ -148,6 +430,10 @@ type AnalysisConfig struct {
 
 	// FollowSymlinks controls whether to follow symbolic links
 	FollowSymlinks bool `json:"follow_symlinks" mapstructure:"follow_symlinks" yaml:"follow_symlinks"`
+
+	// TestPatterns specifies additional glob patterns for test files
+	// (*.test.*, *.spec.* and __tests__ are always treated as tests)
+	TestPatterns []string `json:"test_patterns" mapstructure:"test_patterns" yaml:"test_patterns"`
 }
 
 // DefaultConfig returns the default configuration
@@ -245,10 +531,11 @@ func DefaultConfig() *Config {
 		},
 
 		Output: OutputConfig{
-			Format:        "text",
-			ShowDetails:   false,
-			SortBy:        "complexity",
-			MinComplexity: DefaultMinComplexityFilter,
+			Format:           "text",
+			ShowDetails:      false,
+			SortBy:           "complexity",
+			MinComplexity:    DefaultMinComplexityFilter,
+			MarkdownMaxBytes: DefaultMarkdownMaxBytes,
 		},
 		Analysis: AnalysisConfig{
 			IncludePatterns: []string{
@@ -293,6 +580,53 @@ func DefaultConfig() *Config {
 			},
 			Recursive:      true,
 			FollowSymlinks: false,
+			TestPatterns:   []string{},
+		},
+		History: HistoryConfig{
+			Enabled: false,
+			Path:    DefaultHistoryPath,
+		},
+		Scoring: ScoringConfig{
+			Weights: ScoringWeightsConfig{
+				Complexity:   20,
+				DeadCode:     20,
+				Duplication:  20,
+				Coupling:     20,
+				Dependencies: 16,
+				Architecture: 12,
+			},
+			Saturation: ScoringSaturationConfig{
+				Complexity:  0.05,
+				DeadCode:    3.0,
+				Duplication: 20.0,
+				Coupling:    0.5,
+			},
+			Grades: ScoringGradesConfig{A: 90, B: 75, C: 60, D: 45},
+		},
+		Check: CheckConfig{
+			DeadCodeSeverity: "info",
+			Severities:       map[string]string{},
+		},
+		Hotspots: HotspotsConfig{
+			Since:  "1y",
+			Recent: "30d",
+			Top:    20,
+		},
+		TemporalCoupling: TemporalCouplingConfig{
+			Since:            "1y",
+			MinSharedCommits: 3,
+			MinDegree:        0.5,
+			MaxCommitFiles:   30,
+		},
+		React: ReactConfig{
+			MaxJSXDepth:           8,
+			MaxProps:              10,
+			MaxHooks:              10,
+			MaxConditionalRenders: 8,
+		},
+		Packages: PackagesConfig{
+			DevFilePatterns: []string{},
+			IgnorePackages:  []string{},
 		},
 	}
 
@@ -304,6 +638,12 @@ func LoadConfig(configPath string) (*Config, error) {
 	return LoadConfigWithTarget(configPath, "")
 }
 
+// DiscoverConfigFile returns the config file LoadConfigWithTarget uses for targetPath
+// when no config path is given, or "" if there is none
+func DiscoverConfigFile(targetPath string) string {
+	return discoverConfigFile(targetPath)
+}
+
 // discoverConfigFile finds the appropriate config file path
 // Single responsibility: configuration file discovery only
 func discoverConfigFile(targetPath string) string {
@@ -489,15 +829,21 @@ func (c *Config) Validate() error {
 
 	// Validate output format
 	validFormats := map[string]bool{
-		"text": true,
-		"json": true,
-		"yaml": true,
-		"csv":  true,
-		"html": true,
+		"text":        true,
+		"json":        true,
+		"yaml":        true,
+		"csv":         true,
+		"html":        true,
+		"markdown":    true,
+		"codeclimate": true,
 	}
 
 	if !validFormats[c.Output.Format] {
-		return fmt.Errorf("invalid output.format '%s', must be one of: text, json, yaml, csv, html", c.Output.Format)
+		return fmt.Errorf("invalid output.format '%s', must be one of: text, json, yaml, csv, html, markdown, codeclimate", c.Output.Format)
+	}
+
+	if c.Output.MarkdownMaxBytes < 0 {
+		return fmt.Errorf("output.markdown_max_bytes must be >= 0, got %d", c.Output.MarkdownMaxBytes)
 	}
 
 	// Validate sort options
@@ -520,11 +866,36 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("analysis.include_patterns cannot be empty")
 	}
 
+	if c.History.Path == "" {
+		return fmt.Errorf("history.path cannot be empty")
+	}
+
 	// Validate dead code configuration
 	if err := c.validateDeadCodeConfig(); err != nil {
 		return err
 	}
 
+	if err := c.Scoring.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Check.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Hotspots.Validate(); err != nil {
+		return err
+	}
+	if err := c.TemporalCoupling.Validate(); err != nil {
+		return err
+	}
+	if err := c.React.Validate(); err != nil {
+		return err
+	}
+	if err := c.Packages.Validate(); err != nil {
+		return err
+	}
+
 	// Validate clone detection configuration
 	if c.Clones != nil {
 		if err := c.Clones.Validate(); err != nil {
@@ -769,3 +1140,110 @@ type LayerRule struct {
 	Deny        []string `json:"deny" mapstructure:"deny" yaml:"deny"`
 	Description string   `json:"description" mapstructure:"description" yaml:"description"`
 }
+
+// Validate checks that weights, saturation points and grade boundaries are in range,
+// that grades are strictly decreasing, and that the weights add up to enough for the
+// worst possible code to get an F
+func (c *ScoringConfig) Validate() error {
+	weights := []struct {
+		name  string
+		value int
+	}{
+		{"complexity", c.Weights.Complexity},
+		{"dead_code", c.Weights.DeadCode},
+		{"duplication", c.Weights.Duplication},
+		{"coupling", c.Weights.Coupling},
+		{"dependencies", c.Weights.Dependencies},
+		{"architecture", c.Weights.Architecture},
+	}
+	total := 0
+	for _, w := range weights {
+		if w.value < 0 || w.value > 100 {
+			return fmt.Errorf("scoring.weights.%s must be between 0 and 100, got %d", w.name, w.value)
+		}
+		total += w.value
+	}
+
+	if c.Saturation.Complexity <= 0 || c.Saturation.Complexity > 1 {
+		return fmt.Errorf("scoring.saturation.complexity must be in (0, 1], got %g", c.Saturation.Complexity)
+	}
+	if c.Saturation.DeadCode <= 0 {
+		return fmt.Errorf("scoring.saturation.dead_code must be > 0, got %g", c.Saturation.DeadCode)
+	}
+	if c.Saturation.Duplication <= 1 || c.Saturation.Duplication > 100 {
+		return fmt.Errorf("scoring.saturation.duplication must be in (1, 100], got %g", c.Saturation.Duplication)
+	}
+	if c.Saturation.Coupling <= 0 || c.Saturation.Coupling > 1 {
+		return fmt.Errorf("scoring.saturation.coupling must be in (0, 1], got %g", c.Saturation.Coupling)
+	}
+
+	g := c.Grades
+	if g.A > 100 || g.A <= g.B || g.B <= g.C || g.C <= g.D || g.D <= 0 {
+		return fmt.Errorf("scoring.grades must satisfy 100 >= a > b > c > d > 0, got a=%d b=%d c=%d d=%d", g.A, g.B, g.C, g.D)
+	}
+
+	if 100-total >= g.D {
+		return fmt.Errorf("scoring.weights add up to %d, so the lowest possible score %d never falls below grade D (%d); increase the weights or scoring.grades.d",
+			total, 100-total, g.D)
+	}
+
+	return nil
+}
+
+// Validate checks the gate thresholds and severities
+func (c *CheckConfig) Validate() error {
+	if c.MinHealthScore < 0 || c.MinHealthScore > 100 {
+		return fmt.Errorf("check.min_health_score must be between 0 and 100, got %d", c.MinHealthScore)
+	}
+	switch c.MinGrade {
+	case "", "A", "B", "C", "D":
+	default:
+		return fmt.Errorf("invalid check.min_grade '%s', must be one of: A, B, C, D", c.MinGrade)
+	}
+	if c.MaxDuplication < 0 || c.MaxDuplication > 100 {
+		return fmt.Errorf("check.max_duplication must be between 0 and 100, got %g", c.MaxDuplication)
+	}
+	if c.MaxCBO < 0 {
+		return fmt.Errorf("check.max_cbo must be >= 0, got %d", c.MaxCBO)
+	}
+	if c.MaxNestingDepth < 0 {
+		return fmt.Errorf("check.max_nesting_depth must be >= 0, got %d", c.MaxNestingDepth)
+	}
+	if c.MaxDependencyDepth < 0 {
+		return fmt.Errorf("check.max_dependency_depth must be >= 0, got %d", c.MaxDependencyDepth)
+	}
+	if c.MaxMainSequenceDistance < 0 || c.MaxMainSequenceDistance > 1 {
+		return fmt.Errorf("check.max_main_sequence_distance must be between 0 and 1, got %g", c.MaxMainSequenceDistance)
+	}
+	if c.MinTypeCoverage < 0 || c.MinTypeCoverage > 100 {
+		return fmt.Errorf("check.min_type_coverage must be between 0 and 100, got %g", c.MinTypeCoverage)
+	}
+	switch c.DeadCodeSeverity {
+	case "", "info", "warning", "critical":
+	default:
+		return fmt.Errorf("invalid check.dead_code_severity '%s', must be one of: info, warning, critical", c.DeadCodeSeverity)
+	}
+
+	validGates := map[string]bool{
+		CheckGateHealthScore:          true,
+		CheckGateGrade:                true,
+		CheckGateDuplication:          true,
+		CheckGateCBO:                  true,
+		CheckGateNestingDepth:         true,
+		CheckGateDependencyDepth:      true,
+		CheckGateMainSequenceDistance: true,
+		CheckGateTypeCoverage:         true,
+		CheckGateRulesOfHooks:         true,
+		CheckGateReactComponent:       true,
+	}
+	for gate, severity := range c.Severities {
+		if !validGates[gate] {
+			return fmt.Errorf("invalid check.severities gate '%s', must be one of: health_score, grade, duplication, cbo, nesting_depth, dependency_depth, main_sequence_distance, type_coverage, rules_of_hooks, react_component", gate)
+		}
+		if severity != "error" && severity != "warning" {
+			return fmt.Errorf("invalid check.severities.%s '%s', must be one of: error, warning", gate, severity)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -117,6 +118,16 @@ func TestConfig_Validate_InvalidOutputFormat(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_NegativeMarkdownMaxBytes(t *testing.T) {
+	config := DefaultConfig()
+	config.Output.MarkdownMaxBytes = -1
+
+	err := config.Validate()
+	if err == nil {
+		t.Error("Expected error for negative markdown_max_bytes")
+	}
+}
+
 func TestConfig_Validate_InvalidSortBy(t *testing.T) {
 	config := DefaultConfig()
 	config.Output.SortBy = "invalid"
@@ -384,7 +395,7 @@ func TestDefaultConstants(t *testing.T) {
 
 func TestConfig_ValidOutputFormats(t *testing.T) {
 	config := DefaultConfig()
-	validFormats := []string{"text", "json", "yaml", "csv", "html"}
+	validFormats := []string{"text", "json", "yaml", "csv", "html", "markdown", "codeclimate"}
 
 	for _, format := range validFormats {
 		config.Output.Format = format
@@ -472,3 +483,199 @@ func TestAnalysisConfig_Defaults(t *testing.T) {
 		t.Error("Exclude patterns should contain node_modules")
 	}
 }
+
+func TestConfig_Validate_Scoring(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(s *ScoringConfig)
+	}{
+		{"negative weight", func(s *ScoringConfig) { s.Weights.Coupling = -1 }},
+		{"weight above 100", func(s *ScoringConfig) { s.Weights.Complexity = 101 }},
+		{"complexity saturation above 1", func(s *ScoringConfig) { s.Saturation.Complexity = 1.5 }},
+		{"zero dead code saturation", func(s *ScoringConfig) { s.Saturation.DeadCode = 0 }},
+		{"duplication saturation at the 1% start", func(s *ScoringConfig) { s.Saturation.Duplication = 1 }},
+		{"grades not decreasing", func(s *ScoringConfig) { s.Grades.B = 95 }},
+		{"zero grade d", func(s *ScoringConfig) { s.Grades.D = 0 }},
+		{"weights too low to reach F", func(s *ScoringConfig) {
+			s.Weights = ScoringWeightsConfig{Complexity: 10, DeadCode: 10, Duplication: 10, Coupling: 10, Dependencies: 10, Architecture: 5}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.configure(&config.Scoring)
+			if err := config.Validate(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ScoringOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jscan.yaml")
+	content := "scoring:\n  weights:\n    complexity: 40\n  grades:\n    a: 95\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	// Overridden values are applied, the rest keeps the defaults
+	defaults := DefaultConfig().Scoring
+	if config.Scoring.Weights.Complexity != 40 || config.Scoring.Grades.A != 95 {
+		t.Errorf("Expected overrides to apply, got %+v", config.Scoring)
+	}
+	if config.Scoring.Weights.DeadCode != defaults.Weights.DeadCode || config.Scoring.Grades.B != defaults.Grades.B ||
+		config.Scoring.Saturation != defaults.Saturation {
+		t.Errorf("Expected other scoring values to keep defaults, got %+v", config.Scoring)
+	}
+}
+
+func TestCheckConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(c *CheckConfig)
+		wantErr   bool
+	}{
+		{"defaults", func(c *CheckConfig) {}, false},
+		{"all gates", func(c *CheckConfig) {
+			*c = CheckConfig{MinHealthScore: 70, MinGrade: "B", MaxDuplication: 5, MaxCBO: 10, MaxNestingDepth: 4,
+				MaxDependencyDepth: 8, MaxMainSequenceDistance: 0.4, MinTypeCoverage: 90, DeadCodeSeverity: "warning", Severities: map[string]string{CheckGateCBO: "warning"}}
+		}, false},
+		{"health score above 100", func(c *CheckConfig) { c.MinHealthScore = 101 }, true},
+		{"invalid grade", func(c *CheckConfig) { c.MinGrade = "F" }, true},
+		{"negative cbo", func(c *CheckConfig) { c.MaxCBO = -1 }, true},
+		{"main sequence distance above 1", func(c *CheckConfig) { c.MaxMainSequenceDistance = 1.5 }, true},
+		{"type coverage above 100", func(c *CheckConfig) { c.MinTypeCoverage = 120 }, true},
+		{"invalid dead code severity", func(c *CheckConfig) { c.DeadCodeSeverity = "error" }, true},
+		{"unknown gate", func(c *CheckConfig) { c.Severities = map[string]string{"complexity": "warning"} }, true},
+		{"invalid severity", func(c *CheckConfig) { c.Severities = map[string]string{CheckGateGrade: "info"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.configure(&config.Check)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	check := DefaultConfig().Check
+	check.Severities[CheckGateCBO] = "warning"
+	if check.Severity(CheckGateCBO) != "warning" || check.Severity(CheckGateGrade) != "error" {
+		t.Error("Expected configured severity and error by default")
+	}
+}
+
+func TestParseTimeWindow(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		window  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"all", 0, false},
+		{"90d", 90 * day, false},
+		{"2w", 14 * day, false},
+		{"6M", 180 * day, false},
+		{"1y", 365 * day, false},
+		{"0d", 0, true},
+		{"d", 0, true},
+		{"10h", 0, true},
+		{"ten days", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeWindow(tt.window)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTimeWindow(%q) = %v, %v; want %v, error %v", tt.window, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestHotspotsConfig_Validate(t *testing.T) {
+	config := DefaultConfig()
+	if config.Hotspots.Since != "1y" || config.Hotspots.Recent != "30d" || config.Hotspots.Top != 20 {
+		t.Errorf("Unexpected hotspot defaults %+v", config.Hotspots)
+	}
+
+	config.Hotspots.Recent = "soon"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an error for an invalid recent window")
+	}
+
+	config = DefaultConfig()
+	config.Hotspots.Top = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an error for a negative top")
+	}
+}
+
+func TestTemporalCouplingConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(c *TemporalCouplingConfig)
+		wantErr   bool
+	}{
+		{"defaults", func(c *TemporalCouplingConfig) {}, false},
+		{"whole history", func(c *TemporalCouplingConfig) { c.Since = "all" }, false},
+		{"invalid window", func(c *TemporalCouplingConfig) { c.Since = "last year" }, true},
+		{"no shared commits", func(c *TemporalCouplingConfig) { c.MinSharedCommits = 0 }, true},
+		{"degree above 1", func(c *TemporalCouplingConfig) { c.MinDegree = 1.5 }, true},
+		{"single-file commits", func(c *TemporalCouplingConfig) { c.MaxCommitFiles = 1 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.configure(&config.TemporalCoupling)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReactConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(c *ReactConfig)
+		wantErr   bool
+	}{
+		{"defaults", func(c *ReactConfig) {}, false},
+		{"disabled thresholds", func(c *ReactConfig) { *c = ReactConfig{} }, false},
+		{"negative depth", func(c *ReactConfig) { c.MaxJSXDepth = -1 }, true},
+		{"negative hooks", func(c *ReactConfig) { c.MaxHooks = -2 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.configure(&config.React)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPackagesConfig_Validate(t *testing.T) {
+	config := DefaultConfig()
+	config.Packages.DevFilePatterns = []string{"**/tools/**"}
+	config.Packages.IgnorePackages = []string{"@vitejs/plugin-react"}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid package exemptions, got %v", err)
+	}
+
+	config.Packages.IgnorePackages = []string{" "}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an error for an empty ignored package")
+	}
+}
@@ -142,6 +142,16 @@ type CloneAnalysisConfig struct {
 
 	// Cost model configuration
 	CostModelType string `mapstructure:"cost_model_type" yaml:"cost_model_type" json:"cost_model_type"`
+
+	// Detection algorithm: tree (APTED), token (rolling hash) or hybrid
+	DetectionMode string `mapstructure:"detection_mode" yaml:"detection_mode" json:"detection_mode"`
+
+	// Minimum number of tokens for token-based clones
+	MinTokens int `mapstructure:"min_tokens" yaml:"min_tokens" json:"min_tokens"`
+
+	// Statement sequence clones within blocks
+	StatementSequences *bool `mapstructure:"statement_sequences" yaml:"statement_sequences" json:"statement_sequences"`
+	MinStatements      int   `mapstructure:"min_statements" yaml:"min_statements" json:"min_statements"`
 }
 
 // ThresholdConfig holds similarity thresholds for different clone types
@@ -263,6 +273,11 @@ func DefaultPyscnConfig() *PyscnConfig {
 			IgnoreLiterals:    BoolPtr(false),
 			IgnoreIdentifiers: BoolPtr(false),
 			CostModelType:     "python",
+			DetectionMode:     "tree",
+			MinTokens:         50,
+			// Statement sequence defaults
+			StatementSequences: BoolPtr(false),
+			MinStatements:      3,
 		},
 		Thresholds: ThresholdConfig{
 			Type1Threshold:      constants.DefaultType1CloneThreshold,
@@ -464,6 +479,24 @@ func (a *CloneAnalysisConfig) Validate() error {
 		return fmt.Errorf("cost_model_type must be one of %v, got %s", validCostModels, a.CostModelType)
 	}
 
+	validDetectionModes := []string{"", "tree", "token", "hybrid"}
+	valid = false
+	for _, mode := range validDetectionModes {
+		if a.DetectionMode == mode {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("detection_mode must be one of tree, token, hybrid, got %s", a.DetectionMode)
+	}
+	if a.MinTokens < 0 {
+		return fmt.Errorf("min_tokens must be >= 0, got %d", a.MinTokens)
+	}
+	if a.MinStatements < 0 {
+		return fmt.Errorf("min_statements must be >= 0, got %d", a.MinStatements)
+	}
+
 	return nil
 }
 
@@ -25,6 +25,9 @@ const (
 type ProjectPreset struct {
 	IncludePatterns []string
 	ExcludePatterns []string
+
+	// React holds the component thresholds of React projects, nil for the others
+	React *ReactConfig
 }
 
 // StrictnessPreset holds threshold values for different strictness levels
@@ -68,6 +71,12 @@ func GetProjectPresets() map[ProjectType]ProjectPreset {
 				"*.min.js",
 				"*.bundle.js",
 			},
+			React: &ReactConfig{
+				MaxJSXDepth:           6,
+				MaxProps:              7,
+				MaxHooks:              8,
+				MaxConditionalRenders: 5,
+			},
 		},
 		ProjectTypeVue: {
 			IncludePatterns: []string{
@@ -141,6 +150,17 @@ func GetFullConfigTemplate(projectType ProjectType, strictness Strictness) strin
 	includePatterns := formatJSONArray(preset.IncludePatterns)
 	excludePatterns := formatJSONArray(preset.ExcludePatterns)
 
+	reactSection := ""
+	if preset.React != nil {
+		reactSection = `,
+  "react": {
+    "max_jsx_depth": ` + strconv.Itoa(preset.React.MaxJSXDepth) + `,
+    "max_props": ` + strconv.Itoa(preset.React.MaxProps) + `,
+    "max_hooks": ` + strconv.Itoa(preset.React.MaxHooks) + `,
+    "max_conditional_renders": ` + strconv.Itoa(preset.React.MaxConditionalRenders) + `
+  }`
+	}
+
 	return `{
   "complexity": {
     "enabled": true,
@@ -173,7 +193,7 @@ func GetFullConfigTemplate(projectType ProjectType, strictness Strictness) strin
     "exclude_patterns": ` + excludePatterns + `,
     "recursive": true,
     "follow_symlinks": false
-  }
+  }` + reactSection + `
 }
 `
 }
@@ -0,0 +1,111 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Conn reads and writes messages over a stream. Writes are serialized, so that
+// notifications and responses can be sent from several goroutines.
+type Conn struct {
+	reader *bufio.Reader
+	mu     sync.Mutex
+	writer io.Writer
+
+	// headers selects Content-Length framing instead of one message per line
+	headers bool
+}
+
+// NewHeaderConn creates a connection framing messages with Content-Length headers,
+// as LSP does over stdio
+func NewHeaderConn(r io.Reader, w io.Writer) *Conn {
+	return &Conn{reader: bufio.NewReader(r), writer: w, headers: true}
+}
+
+// NewLineConn creates a connection delimiting messages by newlines, as the MCP stdio
+// transport does
+func NewLineConn(r io.Reader, w io.Writer) *Conn {
+	return &Conn{reader: bufio.NewReader(r), writer: w}
+}
+
+// Read returns the body of the next message; io.EOF means the peer closed the stream
+func (c *Conn) Read() ([]byte, error) {
+	if c.headers {
+		return c.readFramed()
+	}
+	return c.readLine()
+}
+
+// readFramed reads a message preceded by its Content-Length header
+func (c *Conn) readFramed() ([]byte, error) {
+	headers, err := textproto.NewReader(c.reader).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF && len(headers) == 0 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read message header: %w", err)
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(headers.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header %q", headers.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+	return body, nil
+}
+
+// readLine returns the next non-empty line
+func (c *Conn) readLine() ([]byte, error) {
+	for {
+		line, err := c.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+	}
+}
+
+// Write sends v as one message. In line framing encoding/json escapes the newlines
+// inside strings, so a message never spans lines.
+func (c *Conn) Write(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.headers {
+		_, err = c.writer.Write(append(body, '\n'))
+		return err
+	}
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.writer.Write(body)
+	return err
+}
+
+// Reply answers the request id with result, or with rpcErr when it is not nil
+func (c *Conn) Reply(id json.RawMessage, result interface{}, rpcErr *Error) error {
+	return c.Write(NewResponse(id, result, rpcErr))
+}
+
+// Notify sends a notification to the peer
+func (c *Conn) Notify(method string, params interface{}) error {
+	return c.Write(Notification{JSONRPC: Version, Method: method, Params: params})
+}
@@ -0,0 +1,65 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHeaderConnFraming(t *testing.T) {
+	in := strings.NewReader("Content-Length: 17\r\nContent-Type: application/vscode-jsonrpc\r\n\r\n{\"method\":\"exit\"}")
+	var out strings.Builder
+	c := NewHeaderConn(in, &out)
+
+	body, err := c.Read()
+	if err != nil || string(body) != `{"method":"exit"}` {
+		t.Fatalf("Unexpected message %q: %v", body, err)
+	}
+	if _, err := c.Read(); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	if err := c.Reply(json.RawMessage("7"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"jsonrpc":"2.0","id":7,"result":null}`
+	if out.String() != "Content-Length: 38\r\n\r\n"+want {
+		t.Errorf("Unexpected framing %q", out.String())
+	}
+}
+
+func TestLineConnFraming(t *testing.T) {
+	in := strings.NewReader("\n{\"method\":\"ping\"}\r\n\n{\"method\":\"exit\"}")
+	var out strings.Builder
+	c := NewLineConn(in, &out)
+
+	for _, want := range []string{`{"method":"ping"}`, `{"method":"exit"}`} {
+		body, err := c.Read()
+		if err != nil || string(body) != want {
+			t.Fatalf("Expected %s, got %q: %v", want, body, err)
+		}
+	}
+	if _, err := c.Read(); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	if err := c.Reply(json.RawMessage("1"), nil, &Error{Code: CodeMethodNotFound, Message: "a\nb"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Notify("log", "done"); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"a\nb"}}` + "\n" +
+		`{"jsonrpc":"2.0","method":"log","params":"done"}` + "\n"
+	if out.String() != want {
+		t.Errorf("Unexpected framing %q", out.String())
+	}
+}
+
+func TestNewResponseEncodingError(t *testing.T) {
+	resp := NewResponse(json.RawMessage("2"), make(chan int), nil)
+	if resp.Error == nil || resp.Error.Code != CodeInternalError || resp.Result != nil {
+		t.Errorf("Expected an internal error, got %+v", resp)
+	}
+}
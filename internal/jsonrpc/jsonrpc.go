@@ -0,0 +1,95 @@
+// Package jsonrpc implements the JSON-RPC 2.0 messages shared by the LSP, MCP and
+// HTTP servers, and the stream transports of the stdio servers.
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Version is the protocol version of every message
+const Version = "2.0"
+
+// JSON-RPC error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	// CodeServerError is the implementation-defined code of failed requests
+	CodeServerError = -32000
+
+	// CodeServerNotInitialized answers requests sent before initialize (LSP, MCP)
+	CodeServerNotInitialized = -32002
+)
+
+// Message is an incoming request or notification; notifications have no ID
+type Message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+// IsNotification reports whether the message expects no response
+func (m *Message) IsNotification() bool {
+	return m.ID == nil
+}
+
+// Response is an outgoing response; Result is always present on success, as null
+// when the handler has nothing to return
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// NewResponse answers the request id with result, or with rpcErr when it is not nil.
+// A result that cannot be encoded is answered with an internal error.
+func NewResponse(id json.RawMessage, result interface{}, rpcErr *Error) Response {
+	resp := Response{JSONRPC: Version, ID: id}
+	if rpcErr != nil {
+		resp.Error = rpcErr
+		return resp
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &Error{Code: CodeInternalError, Message: err.Error()}
+		return resp
+	}
+	resp.Result = data
+	return resp
+}
+
+// Notification is an outgoing notification
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// Error is the error object of a failed request
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// UnmarshalParams decodes the params of a message into v; missing params leave v
+// unchanged
+func UnmarshalParams(params json.RawMessage, v interface{}) *Error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package lsp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/jscan/app"
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// fileAnalysis holds the per-file results computed from a document's content
+type fileAnalysis struct {
+	content       []byte
+	ast           *parser.Node
+	module        *domain.ModuleInfo
+	functions     []*analyzer.ComplexityResult
+	deadCode      []*analyzer.DeadCodeFinding
+	unusedImports []analyzer.UnusedImport
+}
+
+// analyzeFile parses content as path and runs the single-file analyzers on it
+func analyzeFile(cfg *config.Config, path string, content []byte) (*fileAnalysis, error) {
+	ast, err := parser.ParseForLanguage(path, content)
+	if err != nil {
+		return nil, err
+	}
+	result := &fileAnalysis{content: content, ast: ast}
+
+	cfgs, err := analyzer.NewCFGBuilder().BuildAll(ast)
+	if err != nil {
+		return nil, err
+	}
+	for name, c := range cfgs {
+		if name == "__main__" {
+			continue
+		}
+		fn := analyzer.CalculateComplexityWithConfig(c, &cfg.Complexity)
+		fn.FunctionName = name
+		result.functions = append(result.functions, fn)
+	}
+	sort.Slice(result.functions, func(i, j int) bool {
+		x, y := result.functions[i], result.functions[j]
+		if x.StartLine != y.StartLine {
+			return x.StartLine < y.StartLine
+		}
+		return x.StartCol < y.StartCol
+	})
+
+	if cfg.DeadCode.Enabled {
+		minSeverity := domain.DeadCodeSeverity(cfg.DeadCode.MinSeverity)
+		for name, res := range analyzer.DetectAll(cfgs, path) {
+			if name == "__main__" {
+				continue
+			}
+			for _, finding := range res.Findings {
+				if domain.DeadCodeSeverity(finding.Severity).IsAtLeast(minSeverity) {
+					result.deadCode = append(result.deadCode, finding)
+				}
+			}
+		}
+		sort.Slice(result.deadCode, func(i, j int) bool {
+			return result.deadCode[i].StartLine < result.deadCode[j].StartLine
+		})
+	}
+
+	// Module analysis only fails on a nil AST; unused imports are skipped then
+	if module, err := analyzer.NewModuleAnalyzer(nil).AnalyzeFile(ast, path); err == nil {
+		result.module = module
+		result.unusedImports = analyzer.FindUnusedImports(ast, module, content)
+	}
+
+	return result, nil
+}
+
+// cachedFile is a parsed file on disk, reused while its modification time and size
+// are unchanged
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	ast     *parser.Node
+}
+
+// workspace analyzes the files of the workspace root together with the open
+// documents, whose content replaces the files on disk
+type workspace struct {
+	root  string
+	cfg   *config.Config
+	docs  *documents
+	cache map[string]*cachedFile
+}
+
+func newWorkspace(root string, cfg *config.Config, docs *documents) *workspace {
+	return &workspace{root: root, cfg: cfg, docs: docs, cache: make(map[string]*cachedFile)}
+}
+
+// workspaceAnalysis holds the cross-file results: the import graph with its
+// coupling metrics and cycles, and the clones
+type workspaceAnalysis struct {
+	asts    map[string]*parser.Node
+	graph   *domain.DependencyGraph
+	ids     map[string]string // file path -> module ID
+	metrics map[string]*domain.ModuleDependencyMetrics
+	cycles  []domain.CircularDependency
+	clones  []*domain.ClonePair
+}
+
+// files returns the JavaScript/TypeScript files of the workspace and the open documents
+func (w *workspace) files() []string {
+	seen := make(map[string]bool)
+	var files []string
+	if w.root != "" {
+		found, err := app.NewFileHelper().CollectJSFiles([]string{w.root}, true, nil, w.cfg.Analysis.ExcludePatterns)
+		if err == nil {
+			for _, file := range found {
+				file = filepath.Clean(file)
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	for _, doc := range w.docs.all() {
+		if !seen[doc.path] {
+			seen[doc.path] = true
+			files = append(files, doc.path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// parse returns the AST of path from the overlay or the cache, parsing the file on
+// disk when it changed
+func (w *workspace) parse(path string) *parser.Node {
+	if content, ok := w.docs.content(path); ok {
+		ast, err := parser.ParseForLanguage(path, content)
+		if err != nil {
+			return nil
+		}
+		return ast
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		delete(w.cache, path)
+		return nil
+	}
+	if cached, ok := w.cache[path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.ast
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	ast, err := parser.ParseForLanguage(path, content)
+	if err != nil {
+		delete(w.cache, path)
+		return nil
+	}
+	w.cache[path] = &cachedFile{modTime: info.ModTime(), size: info.Size(), ast: ast}
+	return ast
+}
+
+// analyze runs the cross-file analyzers over the workspace
+func (w *workspace) analyze(ctx context.Context) *workspaceAnalysis {
+	result := &workspaceAnalysis{
+		asts: make(map[string]*parser.Node),
+		ids:  make(map[string]string),
+	}
+	files := w.files()
+	live := make(map[string]bool, len(files))
+	for _, file := range files {
+		live[file] = true
+		if ast := w.parse(file); ast != nil {
+			result.asts[file] = ast
+		}
+	}
+	// Forget deleted files
+	for path := range w.cache {
+		if !live[path] {
+			delete(w.cache, path)
+		}
+	}
+
+	builderConfig := analyzer.DefaultDependencyGraphBuilderConfig()
+	builderConfig.ProjectRoot = w.root
+	graph, err := analyzer.NewDependencyGraphBuilder(builderConfig).BuildGraphFromASTs(result.asts)
+	if err == nil {
+		result.graph = graph
+		for id, node := range graph.Nodes {
+			if !node.IsExternal && node.FilePath != "" {
+				result.ids[filepath.Clean(node.FilePath)] = id
+			}
+		}
+		result.metrics = analyzer.NewCouplingMetricsCalculator(analyzer.DefaultCouplingMetricsConfig()).CalculateMetrics(graph)
+		if cycles := analyzer.NewCircularDependencyDetector().DetectCycles(graph); cycles != nil {
+			result.cycles = cycles.CircularDependencies
+		}
+	}
+
+	result.clones = w.detectClones(ctx, result.asts)
+	return result
+}
+
+// detectClones runs clone detection over the parsed files and keeps the pairs with
+// a clone in an open document
+func (w *workspace) detectClones(ctx context.Context, asts map[string]*parser.Node) []*domain.ClonePair {
+	detector := analyzer.NewCloneDetector(analyzer.DefaultCloneDetectorConfig())
+	paths := make([]string, 0, len(asts))
+	for path := range asts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var fragments []*analyzer.CodeFragment
+	var blocks []*analyzer.StatementBlock
+	for _, path := range paths {
+		fragments = append(fragments, detector.ExtractFragments(asts[path].Body, path)...)
+		blocks = append(blocks, detector.ExtractStatementBlocks(asts[path].Body, path)...)
+	}
+
+	var pairs []*domain.ClonePair
+	if domain.ShouldUseLSH("auto", len(fragments), 0) {
+		detector.SetUseLSH(true)
+		pairs, _ = detector.DetectClonesWithLSH(ctx, fragments)
+	} else {
+		pairs, _ = detector.DetectClonesWithContext(ctx, fragments)
+	}
+	// Duplicated statement sequences inside otherwise different functions
+	if len(blocks) > 0 {
+		pairs, _ = detector.DetectStatementSequenceClones(ctx, blocks, pairs)
+	}
+
+	var open []*domain.ClonePair
+	for _, pair := range pairs {
+		if pair.Clone1 == nil || pair.Clone2 == nil || pair.Clone1.Location == nil || pair.Clone2.Location == nil {
+			continue
+		}
+		if _, ok := w.docs.content(pair.Clone1.Location.FilePath); ok {
+			open = append(open, pair)
+		} else if _, ok := w.docs.content(pair.Clone2.Location.FilePath); ok {
+			open = append(open, pair)
+		}
+	}
+	return open
+}
+
+// cbo returns the CBO coupling of path from the workspace ASTs
+func (a *workspaceAnalysis) cbo(path string) *domain.ClassCoupling {
+	ast, ok := a.asts[path]
+	if !ok {
+		return nil
+	}
+	coupling, err := analyzer.NewCBOAnalyzer(analyzer.DefaultCBOAnalyzerConfig()).AnalyzeFile(ast, path)
+	if err != nil {
+		return nil
+	}
+	return coupling
+}
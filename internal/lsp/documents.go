@@ -0,0 +1,164 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is an open editor buffer
+type document struct {
+	uri     string
+	path    string
+	version int
+	content []byte
+}
+
+// documents is the in-memory overlay of open documents: analyzers read their
+// content instead of the files on disk
+type documents struct {
+	byURI  map[string]*document
+	byPath map[string]*document
+}
+
+func newDocuments() *documents {
+	return &documents{
+		byURI:  make(map[string]*document),
+		byPath: make(map[string]*document),
+	}
+}
+
+// open adds or replaces a document
+func (d *documents) open(uri string, version int, text string) (*document, error) {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{uri: uri, path: path, version: version, content: []byte(text)}
+	d.byURI[uri] = doc
+	d.byPath[path] = doc
+	return doc, nil
+}
+
+// update replaces the content of an open document
+func (d *documents) update(uri string, version int, text string) (*document, error) {
+	doc, ok := d.byURI[uri]
+	if !ok {
+		return nil, fmt.Errorf("document %s is not open", uri)
+	}
+	doc.version = version
+	doc.content = []byte(text)
+	return doc, nil
+}
+
+// close removes a document from the overlay
+func (d *documents) close(uri string) {
+	if doc, ok := d.byURI[uri]; ok {
+		delete(d.byPath, doc.path)
+		delete(d.byURI, uri)
+	}
+}
+
+func (d *documents) get(uri string) (*document, bool) {
+	doc, ok := d.byURI[uri]
+	return doc, ok
+}
+
+// content returns the overlay content of path, if it is open
+func (d *documents) content(path string) ([]byte, bool) {
+	if doc, ok := d.byPath[path]; ok {
+		return doc.content, true
+	}
+	return nil, false
+}
+
+// all returns the open documents ordered by URI
+func (d *documents) all() []*document {
+	docs := make([]*document, 0, len(d.byURI))
+	for _, doc := range d.byURI {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].uri < docs[j].uri })
+	return docs
+}
+
+// uriToPath converts a file URI to a clean local path
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid document URI %q: %w", uri, err)
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported document URI %q: only file URIs are analyzed", uri)
+	}
+	path := u.Path
+	// file:///C:/x is the Windows path C:/x
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(path)), nil
+}
+
+// pathToURI converts a local path to a file URI
+func pathToURI(path string) string {
+	slashed := filepath.ToSlash(path)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
+// lineRange returns the range of the 1-based lines from start to end, up to the end
+// of the last line
+func lineRange(content []byte, start, end int) Range {
+	lines := splitLines(content)
+	if end < start {
+		end = start
+	}
+	endChar := 0
+	if end >= 1 && end <= len(lines) {
+		endChar = utf16Length(lines[end-1])
+	}
+	return Range{
+		Start: Position{Line: max(start-1, 0), Character: 0},
+		End:   Position{Line: max(end-1, 0), Character: endChar},
+	}
+}
+
+// position converts a 1-based line and a 0-based byte column, as reported by the
+// parser, to an LSP position
+func position(content []byte, line, byteCol int) Position {
+	lines := splitLines(content)
+	pos := Position{Line: max(line-1, 0)}
+	if line >= 1 && line <= len(lines) {
+		text := lines[line-1]
+		if byteCol > len(text) {
+			byteCol = len(text)
+		}
+		pos.Character = utf16Length(text[:max(byteCol, 0)])
+	}
+	return pos
+}
+
+func splitLines(content []byte) []string {
+	return strings.Split(string(content), "\n")
+}
+
+// utf16Length returns the length of s in UTF-16 code units, the unit of LSP characters
+func utf16Length(s string) int {
+	n := 0
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+		s = s[size:]
+	}
+	return n
+}
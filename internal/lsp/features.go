@@ -0,0 +1,490 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/config"
+)
+
+const diagnosticSource = "jscan"
+
+// Diagnostic codes
+const (
+	codeComplexity   = "complexity"
+	codeUnusedImport = "unused-import"
+	codeClone        = "clone"
+	codeCycle        = "circular-dependency"
+)
+
+// diagnostics converts the findings of a document to LSP diagnostics; ws may be nil
+// before the first workspace analysis
+func diagnostics(cfg *config.Config, root string, doc *document, fa *fileAnalysis, ws *workspaceAnalysis) []Diagnostic {
+	result := []Diagnostic{}
+
+	if cfg.Complexity.Enabled {
+		for _, fn := range fa.functions {
+			severity := 0
+			switch {
+			case cfg.Complexity.ExceedsMaxComplexity(fn.Complexity):
+				severity = SeverityError
+			case fn.RiskLevel == "high":
+				severity = SeverityWarning
+			case fn.RiskLevel == "medium":
+				severity = SeverityInformation
+			default:
+				continue
+			}
+			message := fmt.Sprintf("Function '%s' has cyclomatic complexity %d (%s risk)", fn.FunctionName, fn.Complexity, fn.RiskLevel)
+			if severity == SeverityError {
+				message += fmt.Sprintf(", above the maximum of %d", cfg.Complexity.MaxComplexity)
+			}
+			start := position(fa.content, fn.StartLine, fn.StartCol)
+			result = append(result, Diagnostic{
+				Range:    Range{Start: start, End: lineRange(fa.content, fn.StartLine, fn.StartLine).End},
+				Severity: severity,
+				Code:     codeComplexity,
+				Source:   diagnosticSource,
+				Message:  message,
+			})
+		}
+	}
+
+	for _, finding := range fa.deadCode {
+		severity := SeverityWarning
+		if finding.Severity == analyzer.SeverityLevelInfo {
+			severity = SeverityHint
+		}
+		result = append(result, Diagnostic{
+			Range:    lineRange(fa.content, finding.StartLine, finding.EndLine),
+			Severity: severity,
+			Code:     string(finding.Reason),
+			Source:   diagnosticSource,
+			Message:  finding.Description,
+			Tags:     []int{DiagnosticTagUnnecessary},
+		})
+	}
+
+	for _, unused := range fa.unusedImports {
+		result = append(result, Diagnostic{
+			Range:    importRange(fa.content, unused.Import),
+			Severity: SeverityWarning,
+			Code:     codeUnusedImport,
+			Source:   diagnosticSource,
+			Message:  unused.Finding(doc.path).Description,
+			Tags:     []int{DiagnosticTagUnnecessary},
+		})
+	}
+
+	if ws == nil {
+		return result
+	}
+
+	for _, pair := range ws.clones {
+		sides := [2][2]*domain.Clone{{pair.Clone1, pair.Clone2}, {pair.Clone2, pair.Clone1}}
+		for _, side := range sides {
+			clone, other := side[0], side[1]
+			if clone.Location.FilePath != doc.path {
+				continue
+			}
+			result = append(result, Diagnostic{
+				Range:    lineRange(fa.content, clone.Location.StartLine, clone.Location.StartLine),
+				Severity: SeverityInformation,
+				Code:     codeClone,
+				Source:   diagnosticSource,
+				Message: fmt.Sprintf("%s clone of %s:%d-%d (%.0f%% similar)", pair.Type, displayPath(root, other.Location.FilePath),
+					other.Location.StartLine, other.Location.EndLine, pair.Similarity*100),
+				RelatedInformation: []DiagnosticRelatedInformation{{
+					Location: Location{
+						URI: pathToURI(other.Location.FilePath),
+						Range: Range{
+							Start: Position{Line: other.Location.StartLine - 1},
+							End:   Position{Line: other.Location.EndLine - 1},
+						},
+					},
+					Message: "Other clone",
+				}},
+			})
+		}
+	}
+
+	id, ok := ws.ids[doc.path]
+	if !ok || ws.graph == nil {
+		return result
+	}
+	for _, cycle := range ws.cycles {
+		members := make(map[string]bool, len(cycle.Modules))
+		for _, module := range cycle.Modules {
+			members[module] = true
+		}
+		if !members[id] {
+			continue
+		}
+		breaking := make(map[string]string)
+		for _, edge := range cycle.BreakingEdges {
+			if edge.From == id {
+				breaking[edge.To] = edge.Suggestion
+			}
+		}
+		for _, edge := range ws.graph.GetOutgoingEdges(id) {
+			if !members[edge.To] || edge.Location == nil {
+				continue
+			}
+			message := "Circular dependency: " + strings.Join(cyclePath(ws.graph, members, id, edge.To), " -> ")
+			if suggestion, ok := breaking[edge.To]; ok && suggestion != "" {
+				message += ". " + suggestion
+			}
+			result = append(result, Diagnostic{
+				Range:    importRange(fa.content, &domain.Import{Location: *edge.Location}),
+				Severity: SeverityWarning,
+				Code:     codeCycle,
+				Source:   diagnosticSource,
+				Message:  message,
+			})
+		}
+	}
+
+	return result
+}
+
+// cyclePath returns the shortest import path from -> to -> ... -> from within the
+// modules of a cycle
+func cyclePath(graph *domain.DependencyGraph, members map[string]bool, from, to string) []string {
+	if from == to {
+		return []string{from, from}
+	}
+	previous := map[string]string{to: to}
+	queue := []string{to}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == from {
+			break
+		}
+		for _, edge := range graph.GetOutgoingEdges(current) {
+			if _, seen := previous[edge.To]; seen || !members[edge.To] {
+				continue
+			}
+			previous[edge.To] = current
+			queue = append(queue, edge.To)
+		}
+	}
+	if _, ok := previous[from]; !ok {
+		return []string{from, to}
+	}
+
+	// Walk back from `from` to `to`, then reverse
+	var back []string
+	for current := from; current != to; current = previous[current] {
+		back = append(back, current)
+	}
+	path := []string{from, to}
+	for i := len(back) - 1; i >= 0; i-- {
+		path = append(path, back[i])
+	}
+	return path
+}
+
+// codeLenses shows the complexity of each function above it
+func codeLenses(cfg *config.Config, fa *fileAnalysis) []CodeLens {
+	lenses := []CodeLens{}
+	if !cfg.Complexity.Enabled {
+		return lenses
+	}
+	for _, fn := range fa.functions {
+		title := fmt.Sprintf("complexity %d · %s risk", fn.Complexity, fn.RiskLevel)
+		if cfg.Complexity.ExceedsMaxComplexity(fn.Complexity) {
+			title += fmt.Sprintf(" (max %d)", cfg.Complexity.MaxComplexity)
+		}
+		start := position(fa.content, fn.StartLine, fn.StartCol)
+		lenses = append(lenses, CodeLens{
+			Range:   Range{Start: start, End: start},
+			Command: &Command{Title: title},
+		})
+	}
+	return lenses
+}
+
+// hover shows coupling metrics: over an import, of the imported module; over an
+// export, of the document's module and its dependents
+func hover(root string, doc *document, fa *fileAnalysis, ws *workspaceAnalysis, pos Position) *Hover {
+	if ws == nil || ws.graph == nil || fa.module == nil {
+		return nil
+	}
+	id, ok := ws.ids[doc.path]
+	if !ok {
+		return nil
+	}
+	line := pos.Line + 1
+
+	for _, imp := range fa.module.Imports {
+		if line < imp.Location.StartLine || line > imp.Location.EndLine {
+			continue
+		}
+		for _, edge := range ws.graph.GetOutgoingEdges(id) {
+			if edge.Location == nil || edge.Location.StartLine != imp.Location.StartLine {
+				continue
+			}
+			node := ws.graph.GetNode(edge.To)
+			if node == nil || node.IsExternal {
+				return nil
+			}
+			var b strings.Builder
+			writeCoupling(&b, root, ws, edge.To)
+			b.WriteString("\n---\n\n")
+			fmt.Fprintf(&b, "Imported here: %s\n\n", edgeSummary(edge))
+			writeModuleLine(&b, root, ws, id)
+			r := importRange(fa.content, imp)
+			return &Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}, Range: &r}
+		}
+		return nil
+	}
+
+	for _, exp := range fa.module.Exports {
+		if line != exp.Location.StartLine {
+			continue
+		}
+		var b strings.Builder
+		writeCoupling(&b, root, ws, id)
+		r := lineRange(fa.content, exp.Location.StartLine, exp.Location.StartLine)
+		return &Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}, Range: &r}
+	}
+	return nil
+}
+
+// writeCoupling writes the coupling metrics of a module and its dependents
+func writeCoupling(b *strings.Builder, root string, ws *workspaceAnalysis, id string) {
+	node := ws.graph.GetNode(id)
+	fmt.Fprintf(b, "**Coupling of `%s`**\n\n", displayPath(root, node.FilePath))
+	if m, ok := ws.metrics[id]; ok {
+		fmt.Fprintf(b, "- Afferent coupling (Ca): %d\n", m.AfferentCoupling)
+		fmt.Fprintf(b, "- Efferent coupling (Ce): %d\n", m.EfferentCoupling)
+		fmt.Fprintf(b, "- Instability: %.2f\n", m.Instability)
+		fmt.Fprintf(b, "- Distance from main sequence: %.2f\n", m.Distance)
+	}
+	if cbo := ws.cbo(node.FilePath); cbo != nil {
+		fmt.Fprintf(b, "- CBO: %d (%s risk)\n", cbo.Metrics.CouplingCount, cbo.RiskLevel)
+	}
+
+	var dependents []string
+	for _, edge := range ws.graph.GetIncomingEdges(id) {
+		if from := ws.graph.GetNode(edge.From); from != nil {
+			dependents = append(dependents, displayPath(root, from.FilePath))
+		}
+	}
+	sort.Strings(dependents)
+	dependents = uniqueStrings(dependents)
+	if len(dependents) > 0 {
+		const shown = 5
+		list := dependents
+		if len(list) > shown {
+			list = list[:shown]
+		}
+		fmt.Fprintf(b, "\nImported by %s", strings.Join(list, ", "))
+		if len(dependents) > shown {
+			fmt.Fprintf(b, " and %d more", len(dependents)-shown)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeModuleLine writes a one-line coupling summary of a module
+func writeModuleLine(b *strings.Builder, root string, ws *workspaceAnalysis, id string) {
+	node := ws.graph.GetNode(id)
+	fmt.Fprintf(b, "This module `%s`", displayPath(root, node.FilePath))
+	if m, ok := ws.metrics[id]; ok {
+		fmt.Fprintf(b, ": Ca %d · Ce %d · instability %.2f", m.AfferentCoupling, m.EfferentCoupling, m.Instability)
+	}
+	b.WriteString("\n")
+}
+
+// edgeSummary describes the symbols imported through an edge
+func edgeSummary(edge *domain.DependencyEdge) string {
+	kind := string(edge.EdgeType)
+	if len(edge.Specifiers) == 0 {
+		return kind
+	}
+	return fmt.Sprintf("%s of %s", kind, strings.Join(edge.Specifiers, ", "))
+}
+
+// codeActions offers quick fixes removing the unused imports of the statements in rng
+func codeActions(doc *document, fa *fileAnalysis, rng Range, diags []Diagnostic) []CodeAction {
+	actions := []CodeAction{}
+	if len(fa.unusedImports) == 0 {
+		return actions
+	}
+
+	// Group the unused specifiers by import statement, in statement order
+	var imports []*domain.Import
+	unused := make(map[*domain.Import][]domain.ImportSpecifier)
+	for _, u := range fa.unusedImports {
+		if _, ok := unused[u.Import]; !ok {
+			imports = append(imports, u.Import)
+		}
+		unused[u.Import] = append(unused[u.Import], u.Specifier)
+	}
+
+	var all []TextEdit
+	for _, imp := range imports {
+		edit := removeUnusedImportEdit(fa.content, imp, unused[imp])
+		all = append(all, edit)
+
+		r := importRange(fa.content, imp)
+		if r.End.Line < rng.Start.Line || r.Start.Line > rng.End.Line {
+			continue
+		}
+		names := make([]string, len(unused[imp]))
+		for i, spec := range unused[imp] {
+			names[i] = "'" + spec.Local + "'"
+		}
+		title := "Remove unused import " + names[0]
+		if len(names) > 1 {
+			title = "Remove unused imports " + strings.Join(names, ", ")
+		}
+		var fixed []Diagnostic
+		for _, d := range diags {
+			if d.Code == codeUnusedImport && d.Range.Start.Line >= r.Start.Line && d.Range.Start.Line <= r.End.Line {
+				fixed = append(fixed, d)
+			}
+		}
+		actions = append(actions, CodeAction{
+			Title:       title,
+			Kind:        CodeActionKindQuickFix,
+			Diagnostics: fixed,
+			IsPreferred: true,
+			Edit:        &WorkspaceEdit{Changes: map[string][]TextEdit{doc.uri: {edit}}},
+		})
+	}
+
+	if len(actions) > 0 && len(imports) > 1 {
+		actions = append(actions, CodeAction{
+			Title: "Remove all unused imports",
+			Kind:  CodeActionKindQuickFix,
+			Edit:  &WorkspaceEdit{Changes: map[string][]TextEdit{doc.uri: all}},
+		})
+	}
+	return actions
+}
+
+// removeUnusedImportEdit rewrites an import statement without the unused specifiers,
+// or deletes it, with its line, when nothing is left
+func removeUnusedImportEdit(content []byte, imp *domain.Import, unused []domain.ImportSpecifier) TextEdit {
+	drop := make(map[string]bool, len(unused))
+	for _, spec := range unused {
+		drop[spec.Local] = true
+	}
+	var kept []domain.ImportSpecifier
+	for _, spec := range imp.Specifiers {
+		if !drop[spec.Local] {
+			kept = append(kept, spec)
+		}
+	}
+
+	r := importRange(content, imp)
+	if len(kept) > 0 {
+		return TextEdit{Range: r, NewText: importStatement(statementText(content, imp), imp.Source, kept)}
+	}
+
+	// Delete whole lines when the statement is alone on them
+	lines := splitLines(content)
+	start, end := imp.Location.StartLine, imp.Location.EndLine
+	if start >= 1 && end <= len(lines) &&
+		strings.TrimSpace(lines[start-1][:min(imp.Location.StartCol, len(lines[start-1]))]) == "" &&
+		strings.TrimSpace(lines[end-1][min(imp.Location.EndCol, len(lines[end-1])):]) == "" {
+		if end < len(lines) {
+			return TextEdit{Range: Range{Start: Position{Line: start - 1}, End: Position{Line: end}}}
+		}
+		r.Start.Character = 0
+	}
+	return TextEdit{Range: r, NewText: ""}
+}
+
+// importStatement builds `import Default, { a, b as c } from 'source';` with the quote,
+// semicolon and `import type` style of the original statement
+func importStatement(original, source string, specs []domain.ImportSpecifier) string {
+	quote := "'"
+	if i := strings.Index(original, source); i > 0 && original[i-1] == '"' {
+		quote = `"`
+	}
+
+	var clauses, named []string
+	for _, spec := range specs {
+		switch spec.Imported {
+		case "default":
+			clauses = append(clauses, spec.Local)
+		case "*":
+			clauses = append(clauses, "* as "+spec.Local)
+		default:
+			name := spec.Local
+			if spec.Imported != "" && spec.Imported != spec.Local {
+				name = spec.Imported + " as " + spec.Local
+			}
+			if spec.IsType {
+				name = "type " + name
+			}
+			named = append(named, name)
+		}
+	}
+	if len(named) > 0 {
+		clauses = append(clauses, "{ "+strings.Join(named, ", ")+" }")
+	}
+
+	keyword := "import "
+	if strings.HasPrefix(strings.TrimSpace(original), "import type ") {
+		keyword = "import type "
+	}
+	statement := keyword + strings.Join(clauses, ", ") + " from " + quote + source + quote
+	if strings.HasSuffix(strings.TrimSpace(original), ";") {
+		statement += ";"
+	}
+	return statement
+}
+
+// statementText returns the source text of an import statement
+func statementText(content []byte, imp *domain.Import) string {
+	lines := splitLines(content)
+	start, end := imp.Location.StartLine, imp.Location.EndLine
+	if start < 1 || end > len(lines) || end < start {
+		return ""
+	}
+	if start == end {
+		line := lines[start-1]
+		return line[min(imp.Location.StartCol, len(line)):min(imp.Location.EndCol, len(line))]
+	}
+	parts := []string{lines[start-1][min(imp.Location.StartCol, len(lines[start-1])):]}
+	parts = append(parts, lines[start:end-1]...)
+	parts = append(parts, lines[end-1][:min(imp.Location.EndCol, len(lines[end-1]))])
+	return strings.Join(parts, "\n")
+}
+
+// importRange returns the range of an import statement
+func importRange(content []byte, imp *domain.Import) Range {
+	return Range{
+		Start: position(content, imp.Location.StartLine, imp.Location.StartCol),
+		End:   position(content, imp.Location.EndLine, imp.Location.EndCol),
+	}
+}
+
+// displayPath returns path relative to the workspace root when it is inside it
+func displayPath(root, path string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+func uniqueStrings(sorted []string) []string {
+	var result []string
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
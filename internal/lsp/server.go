@@ -0,0 +1,312 @@
+// Package lsp implements a Language Server Protocol server over stdio. It publishes
+// jscan findings as diagnostics, shows function complexity in code lenses and
+// coupling metrics on hover, and offers quick fixes for unused imports.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/jsonrpc"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// Server is a language server for one client connection. Requests are handled one
+// at a time in the order they arrive.
+type Server struct {
+	conn       *jsonrpc.Conn
+	configPath string
+
+	cfg       *config.Config
+	root      string
+	docs      *documents
+	workspace *workspace
+	analysis  *workspaceAnalysis
+
+	initialized bool
+	shutdown    bool
+}
+
+// NewServer creates a server reading requests from in and writing to out;
+// configPath is an optional config file, otherwise the one of the workspace is used
+func NewServer(in io.Reader, out io.Writer, configPath string) *Server {
+	return &Server{
+		conn:       jsonrpc.NewHeaderConn(in, out),
+		configPath: configPath,
+		docs:       newDocuments(),
+	}
+}
+
+// errExit stops the server after the exit notification
+var errExit = errors.New("exit")
+
+// Run serves requests until the client sends exit or closes the stream. It returns
+// nil after an orderly shutdown.
+func (s *Server) Run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		body, err := s.conn.Read()
+		if err == io.EOF {
+			if s.shutdown {
+				return nil
+			}
+			return fmt.Errorf("client closed the connection without shutdown")
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.handle(ctx, body); err != nil {
+			if err == errExit {
+				if s.shutdown {
+					return nil
+				}
+				return fmt.Errorf("exit without shutdown")
+			}
+			return err
+		}
+	}
+}
+
+// handle dispatches one message; only transport errors are returned
+func (s *Server) handle(ctx context.Context, body []byte) error {
+	var msg jsonrpc.Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return s.conn.Reply(json.RawMessage("null"), nil, &jsonrpc.Error{Code: jsonrpc.CodeParseError, Message: err.Error()})
+	}
+
+	// Notifications have no ID and get no response
+	if msg.ID == nil {
+		return s.notification(ctx, msg)
+	}
+	id := *msg.ID
+
+	if msg.Method == "" {
+		return s.conn.Reply(id, nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidRequest, Message: "missing method"})
+	}
+	if !s.initialized && msg.Method != "initialize" {
+		return s.conn.Reply(id, nil, &jsonrpc.Error{Code: jsonrpc.CodeServerNotInitialized, Message: "server not initialized"})
+	}
+
+	result, rpcErr := s.request(ctx, msg)
+	return s.conn.Reply(id, result, rpcErr)
+}
+
+// request handles a request and returns its result
+func (s *Server) request(ctx context.Context, msg jsonrpc.Message) (interface{}, *jsonrpc.Error) {
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/codeLens":
+		var params CodeLensParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		doc, fa := s.analyzeDocument(params.TextDocument.URI)
+		if doc == nil {
+			return []CodeLens{}, nil
+		}
+		return codeLenses(s.cfg, fa), nil
+
+	case "textDocument/hover":
+		var params HoverParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		doc, fa := s.analyzeDocument(params.TextDocument.URI)
+		if doc == nil {
+			return nil, nil
+		}
+		if s.analysis == nil {
+			s.analysis = s.workspace.analyze(ctx)
+		}
+		return hover(s.root, doc, fa, s.analysis, params.Position), nil
+
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		doc, fa := s.analyzeDocument(params.TextDocument.URI)
+		if doc == nil || !wantsQuickFix(params.Context.Only) {
+			return []CodeAction{}, nil
+		}
+		return codeActions(doc, fa, params.Range, params.Context.Diagnostics), nil
+	}
+
+	return nil, &jsonrpc.Error{Code: jsonrpc.CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+}
+
+// notification handles a notification; unknown ones are ignored
+func (s *Server) notification(ctx context.Context, msg jsonrpc.Message) error {
+	if msg.Method == "exit" {
+		return errExit
+	}
+	if !s.initialized {
+		return nil
+	}
+
+	switch msg.Method {
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return s.log(MessageTypeError, err.Message)
+		}
+		td := params.TextDocument
+		if _, err := s.docs.open(td.URI, td.Version, td.Text); err != nil {
+			return s.log(MessageTypeWarning, err.Error())
+		}
+		return s.refresh(ctx)
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return s.log(MessageTypeError, err.Message)
+		}
+		// Full sync: the last change holds the whole document
+		if n := len(params.ContentChanges); n > 0 {
+			td := params.TextDocument
+			if _, err := s.docs.update(td.URI, td.Version, params.ContentChanges[n-1].Text); err != nil {
+				return s.log(MessageTypeWarning, err.Error())
+			}
+		}
+		return nil
+
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return s.log(MessageTypeError, err.Message)
+		}
+		if doc, ok := s.docs.get(params.TextDocument.URI); ok && params.Text != nil {
+			doc.content = []byte(*params.Text)
+		}
+		return s.refresh(ctx)
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return s.log(MessageTypeError, err.Message)
+		}
+		s.docs.close(params.TextDocument.URI)
+		return s.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	}
+	return nil
+}
+
+// initialize loads the configuration of the workspace root and announces the capabilities
+func (s *Server) initialize(params InitializeParams) InitializeResult {
+	switch {
+	case params.RootURI != "":
+		if root, err := uriToPath(params.RootURI); err == nil {
+			s.root = root
+		}
+	case len(params.WorkspaceFolders) > 0:
+		if root, err := uriToPath(params.WorkspaceFolders[0].URI); err == nil {
+			s.root = root
+		}
+	case params.RootPath != "":
+		s.root = filepath.Clean(params.RootPath)
+	}
+
+	target := s.root
+	if target == "" {
+		target, _ = os.Getwd()
+	}
+	cfg, err := config.LoadConfigWithTarget(s.configPath, target)
+	if err != nil {
+		_ = s.log(MessageTypeWarning, fmt.Sprintf("failed to load configuration, using defaults: %v", err))
+		cfg = config.DefaultConfig()
+	}
+	s.cfg = cfg
+	s.workspace = newWorkspace(s.root, cfg, s.docs)
+	s.initialized = true
+
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncKindFull,
+				Save:      SaveOptions{IncludeText: false},
+			},
+			HoverProvider:      true,
+			CodeLensProvider:   &CodeLensOptions{},
+			CodeActionProvider: &CodeActionOptions{CodeActionKinds: []string{CodeActionKindQuickFix}},
+		},
+		ServerInfo: ServerInfo{Name: "jscan", Version: version.GetVersion()},
+	}
+}
+
+// refresh reruns the workspace analysis and republishes the diagnostics of every
+// open document, since clones and cycles span files
+func (s *Server) refresh(ctx context.Context) error {
+	s.analysis = s.workspace.analyze(ctx)
+	for _, doc := range s.docs.all() {
+		fa, err := analyzeFile(s.cfg, doc.path, doc.content)
+		if err != nil {
+			if logErr := s.log(MessageTypeWarning, fmt.Sprintf("[%s] analysis failed: %v", doc.path, err)); logErr != nil {
+				return logErr
+			}
+			continue
+		}
+		version := doc.version
+		if err := s.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         doc.uri,
+			Version:     &version,
+			Diagnostics: diagnostics(s.cfg, s.root, doc, fa, s.analysis),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// analyzeDocument analyzes the current content of an open document; it returns nils
+// for documents that are not open or cannot be parsed
+func (s *Server) analyzeDocument(uri string) (*document, *fileAnalysis) {
+	doc, ok := s.docs.get(uri)
+	if !ok {
+		return nil, nil
+	}
+	fa, err := analyzeFile(s.cfg, doc.path, doc.content)
+	if err != nil {
+		return nil, nil
+	}
+	return doc, fa
+}
+
+// log writes a message to the client's output
+func (s *Server) log(messageType int, text string) error {
+	return s.conn.Notify("window/logMessage", LogMessageParams{Type: messageType, Message: text})
+}
+
+// wantsQuickFix reports whether a code action request filtered by only accepts quick fixes
+func wantsQuickFix(only []string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, kind := range only {
+		if kind == CodeActionKindQuickFix {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,396 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/jsonrpc"
+)
+
+// testClient drives a server over in-memory pipes
+type testClient struct {
+	t        *testing.T
+	conn     *jsonrpc.Conn
+	nextID   int
+	messages chan []byte
+	done     chan error
+
+	// diagnostics holds the last published diagnostics per URI
+	diagnostics map[string][]Diagnostic
+}
+
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+	clientToServer, serverIn := io.Pipe()
+	serverOut, serverToClient := io.Pipe()
+	server := NewServer(clientToServer, serverToClient, "")
+
+	c := &testClient{
+		t:           t,
+		conn:        jsonrpc.NewHeaderConn(serverOut, serverIn),
+		messages:    make(chan []byte, 100),
+		done:        make(chan error, 1),
+		diagnostics: make(map[string][]Diagnostic),
+	}
+	go func() {
+		err := server.Run(context.Background())
+		_ = serverToClient.Close()
+		c.done <- err
+	}()
+	// Pipes are synchronous: keep reading so the server never blocks on a write
+	go func() {
+		defer close(c.messages)
+		for {
+			body, err := c.conn.Read()
+			if err != nil {
+				return
+			}
+			c.messages <- body
+		}
+	}()
+	t.Cleanup(func() {
+		_ = serverIn.Close()
+	})
+	return c
+}
+
+// call sends a request and returns its response, recording the notifications
+// received meanwhile
+func (c *testClient) call(method string, params interface{}) jsonrpc.Response {
+	c.t.Helper()
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+	if err := c.conn.Write(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params}); err != nil {
+		c.t.Fatalf("failed to send %s: %v", method, err)
+	}
+	for {
+		body := c.read()
+		var resp jsonrpc.Response
+		if err := json.Unmarshal(body, &resp); err == nil && string(resp.ID) == string(id) {
+			return resp
+		}
+		c.record(body)
+	}
+}
+
+// notify sends a notification
+func (c *testClient) notify(method string, params interface{}) {
+	c.t.Helper()
+	if err := c.conn.Write(map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}); err != nil {
+		c.t.Fatalf("failed to send %s: %v", method, err)
+	}
+}
+
+// sync waits until the server handled the preceding notifications
+func (c *testClient) sync() {
+	c.t.Helper()
+	c.call("textDocument/codeLens", CodeLensParams{TextDocument: TextDocumentIdentifier{URI: "file:///nowhere.js"}})
+}
+
+func (c *testClient) read() []byte {
+	c.t.Helper()
+	select {
+	case body, ok := <-c.messages:
+		if !ok {
+			c.t.Fatal("server closed the connection")
+		}
+		return body
+	case <-time.After(30 * time.Second):
+		c.t.Fatal("timed out waiting for the server")
+		return nil
+	}
+}
+
+func (c *testClient) record(body []byte) {
+	var msg struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil || msg.Method != "textDocument/publishDiagnostics" {
+		return
+	}
+	var params PublishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		c.t.Fatalf("invalid diagnostics: %v", err)
+	}
+	c.diagnostics[params.URI] = params.Diagnostics
+}
+
+func findDiagnostic(diags []Diagnostic, code string, contains string) *Diagnostic {
+	for i := range diags {
+		if (code == "" || diags[i].Code == code) && strings.Contains(diags[i].Message, contains) {
+			return &diags[i]
+		}
+	}
+	return nil
+}
+
+const lspFileA = `import { helper } from './b.js';
+import { unusedName, used } from './c.js';
+
+export function route(x) {
+  if (x > 1) {
+    return helper(x);
+  }
+  if (x < 0) {
+    return used(x);
+  }
+  if (x === 7 || x === 8) {
+    return 7;
+  }
+  if (x === 9) {
+    return 9;
+  }
+  for (let i = 0; i < x; i++) {
+    if (i % 2 === 0 && x > 3) {
+      continue;
+    }
+  }
+  switch (x) {
+    case 0:
+      return 0;
+    case 1:
+      return 1;
+    case 2:
+      return 2;
+  }
+  return x || 2;
+}
+
+export function stop() {
+  return 1;
+  console.log('never');
+}
+`
+
+const lspFileB = `import { route } from './a.js';
+
+export function helper(v) {
+  return route(v - 1);
+}
+
+export function sum(items) {
+  let total = 0;
+  for (const item of items) {
+    if (item > 0) {
+      total += item;
+    }
+  }
+  return total;
+}
+`
+
+const lspFileC = `export function used(v) {
+  return v;
+}
+
+export function unusedName() {}
+
+export function add(values) {
+  let total = 0;
+  for (const value of values) {
+    if (value > 0) {
+      total += value;
+    }
+  }
+  return total;
+}
+`
+
+func TestServerSession(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{"a.js": lspFileA, "b.js": lspFileB, "c.js": lspFileC}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Resolve symlinked temp directories so URIs match the collected paths
+	root, _ = filepath.EvalSymlinks(root)
+	uriA := pathToURI(filepath.Join(root, "a.js"))
+	uriB := pathToURI(filepath.Join(root, "b.js"))
+
+	c := newTestClient(t)
+
+	// Requests before initialize are rejected
+	if resp := c.call("textDocument/codeLens", CodeLensParams{TextDocument: TextDocumentIdentifier{URI: uriA}}); resp.Error == nil || resp.Error.Code != jsonrpc.CodeServerNotInitialized {
+		t.Fatalf("Expected a not initialized error, got %+v", resp)
+	}
+
+	resp := c.call("initialize", InitializeParams{RootURI: pathToURI(root)})
+	var init InitializeResult
+	if err := json.Unmarshal(resp.Result, &init); err != nil {
+		t.Fatalf("invalid initialize result %s: %v", resp.Result, err)
+	}
+	if !init.Capabilities.HoverProvider || init.Capabilities.CodeLensProvider == nil ||
+		init.Capabilities.TextDocumentSync.Change != TextDocumentSyncKindFull || init.ServerInfo.Name != "jscan" {
+		t.Errorf("Unexpected capabilities %+v", init)
+	}
+	c.notify("initialized", struct{}{})
+
+	// The unsaved buffer of b.js has an extra unused import: the overlay is analyzed, not the file
+	bufferB := "import { add } from './c.js';\n" + lspFileB
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uriA, LanguageID: "javascript", Version: 1, Text: lspFileA}})
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uriB, LanguageID: "javascript", Version: 1, Text: bufferB}})
+	c.sync()
+
+	diagsA := c.diagnostics[uriA]
+	if d := findDiagnostic(diagsA, codeComplexity, "'route' has cyclomatic complexity"); d == nil || d.Range.Start.Line != 3 || d.Severity != SeverityInformation {
+		t.Errorf("Expected a complexity diagnostic on route, got %+v", diagsA)
+	}
+	if d := findDiagnostic(diagsA, codeUnusedImport, "'unusedName'"); d == nil || d.Range.Start.Line != 1 || len(d.Tags) != 1 {
+		t.Errorf("Expected an unused import diagnostic on line 2, got %+v", diagsA)
+	}
+	if d := findDiagnostic(diagsA, "", "unreachable"); d == nil {
+		t.Errorf("Expected a dead code diagnostic, got %+v", diagsA)
+	}
+	if d := findDiagnostic(diagsA, codeCycle, "a.js -> b.js -> a.js"); d == nil || d.Range.Start.Line != 0 {
+		t.Errorf("Expected a cycle diagnostic on the import of b.js, got %+v", diagsA)
+	}
+	diagsB := c.diagnostics[uriB]
+	if d := findDiagnostic(diagsB, codeUnusedImport, "'add'"); d == nil || d.Range.Start.Line != 0 {
+		t.Errorf("Expected the unsaved unused import of b.js, got %+v", diagsB)
+	}
+	if d := findDiagnostic(diagsB, codeClone, "c.js:8-14"); d == nil || len(d.RelatedInformation) != 1 {
+		t.Errorf("Expected sum() to be reported as a clone of add(), got %+v", diagsB)
+	}
+
+	// Code lenses above each function
+	resp = c.call("textDocument/codeLens", CodeLensParams{TextDocument: TextDocumentIdentifier{URI: uriA}})
+	var lenses []CodeLens
+	if err := json.Unmarshal(resp.Result, &lenses); err != nil {
+		t.Fatalf("invalid code lenses %s: %v", resp.Result, err)
+	}
+	if len(lenses) != 2 || lenses[0].Range.Start.Line != 3 || !strings.HasPrefix(lenses[0].Command.Title, "complexity ") ||
+		lenses[1].Command.Title != "complexity 1 · low risk" {
+		t.Errorf("Unexpected code lenses %+v", lenses)
+	}
+
+	// Hover over the import of b.js shows its coupling
+	resp = c.call("textDocument/hover", HoverParams{TextDocument: TextDocumentIdentifier{URI: uriA}, Position: Position{Line: 0, Character: 10}})
+	var h Hover
+	if err := json.Unmarshal(resp.Result, &h); err != nil {
+		t.Fatalf("invalid hover %s: %v", resp.Result, err)
+	}
+	for _, want := range []string{"Coupling of `b.js`", "Afferent coupling (Ca): 1", "Efferent coupling (Ce): 2", "Imported by a.js", "This module `a.js`"} {
+		if !strings.Contains(h.Contents.Value, want) {
+			t.Errorf("Hover missing %q:\n%s", want, h.Contents.Value)
+		}
+	}
+	resp = c.call("textDocument/hover", HoverParams{TextDocument: TextDocumentIdentifier{URI: uriA}, Position: Position{Line: 5}})
+	if string(resp.Result) != "null" {
+		t.Errorf("Expected no hover inside a function, got %s", resp.Result)
+	}
+
+	// Quick fix rewrites the import without the unused name
+	resp = c.call("textDocument/codeAction", CodeActionParams{
+		TextDocument: TextDocumentIdentifier{URI: uriA},
+		Range:        Range{Start: Position{Line: 1}, End: Position{Line: 1}},
+		Context:      CodeActionContext{Diagnostics: diagsA},
+	})
+	var actions []CodeAction
+	if err := json.Unmarshal(resp.Result, &actions); err != nil {
+		t.Fatalf("invalid code actions %s: %v", resp.Result, err)
+	}
+	if len(actions) != 1 || actions[0].Title != "Remove unused import 'unusedName'" || len(actions[0].Diagnostics) != 1 {
+		t.Fatalf("Unexpected code actions %+v", actions)
+	}
+	edits := actions[0].Edit.Changes[uriA]
+	if len(edits) != 1 || edits[0].NewText != "import { used } from './c.js';" || edits[0].Range.Start != (Position{Line: 1}) {
+		t.Errorf("Unexpected edits %+v", edits)
+	}
+
+	// Fixing the buffer and saving clears the diagnostic
+	fixed := strings.Replace(lspFileA, "unusedName, ", "", 1)
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uriA, Version: 2},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: fixed}},
+	})
+	c.notify("textDocument/didSave", DidSaveTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uriA}})
+	c.sync()
+	if d := findDiagnostic(c.diagnostics[uriA], codeUnusedImport, ""); d != nil {
+		t.Errorf("Expected the unused import to be gone after save, got %+v", d)
+	}
+
+	c.notify("textDocument/didClose", DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uriB}})
+	c.sync()
+	if len(c.diagnostics[uriB]) != 0 {
+		t.Errorf("Expected diagnostics cleared on close, got %+v", c.diagnostics[uriB])
+	}
+
+	if resp := c.call("workspace/symbol", struct{}{}); resp.Error == nil || resp.Error.Code != jsonrpc.CodeMethodNotFound {
+		t.Errorf("Expected method not found, got %+v", resp)
+	}
+
+	c.call("shutdown", nil)
+	c.notify("exit", nil)
+	select {
+	case err := <-c.done:
+		if err != nil {
+			t.Errorf("Expected a clean exit, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not exit")
+	}
+}
+
+func TestRemoveUnusedImportEdit(t *testing.T) {
+	content := "import React, { useState as state, type Props } from \"react\"\nimport * as fs from 'fs';\nconst x = 1;\n"
+	fa, err := analyzeFile(config.DefaultConfig(), "app.tsx", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imports := fa.module.Imports
+
+	// Quotes and the missing semicolon are kept
+	edit := removeUnusedImportEdit([]byte(content), imports[0], imports[0].Specifiers[1:2])
+	if edit.NewText != `import React, { type Props } from "react"` {
+		t.Errorf("Unexpected rewrite %q", edit.NewText)
+	}
+
+	// The last specifier removes the whole line
+	edit = removeUnusedImportEdit([]byte(content), imports[1], imports[1].Specifiers)
+	if edit.NewText != "" || edit.Range.Start != (Position{Line: 1}) || edit.Range.End != (Position{Line: 2}) {
+		t.Errorf("Expected the line to be deleted, got %+v", edit)
+	}
+
+	// Type-only statements stay type-only
+	content = "import type { A, B } from './types';\nlet a: A;\n"
+	fa, err = analyzeFile(config.DefaultConfig(), "app.ts", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imp := fa.module.Imports[0]
+	edit = removeUnusedImportEdit([]byte(content), imp, imp.Specifiers[1:])
+	if edit.NewText != "import type { A } from './types';" {
+		t.Errorf("Unexpected rewrite %q", edit.NewText)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	content := []byte("const s = '😀é'; foo();\nnext")
+	// The emoji takes 4 bytes and 2 UTF-16 units, é 2 bytes and 1 unit
+	if pos := position(content, 1, strings.Index(string(content), "foo")); pos != (Position{Line: 0, Character: 17}) {
+		t.Errorf("Unexpected position %+v", pos)
+	}
+	if r := lineRange(content, 2, 2); r.End != (Position{Line: 1, Character: 4}) {
+		t.Errorf("Unexpected line range %+v", r)
+	}
+
+	uri := pathToURI(filepath.Join(string(filepath.Separator)+"tmp", "my dir", "a.js"))
+	path, err := uriToPath(uri)
+	if err != nil || filepath.Base(path) != "a.js" || !strings.Contains(uri, "my%20dir") {
+		t.Errorf("Unexpected URI round trip %q -> %q (%v)", uri, path, err)
+	}
+	if _, err := uriToPath("untitled:Untitled-1"); err == nil {
+		t.Error("Expected an error for non-file URIs")
+	}
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import "encoding/json"
+
+// Protocol revisions the server speaks, newest first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// InitializeParams is the part of the initialize request used by the server
+type InitializeParams struct {
+	ProtocolVersion string          `json:"protocolVersion"`
+	ClientInfo      *Implementation `json:"clientInfo,omitempty"`
+}
+
+// Implementation names a client or server
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeResult announces the protocol revision and the capabilities of the server
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// ServerCapabilities lists the features of the server; only tools are offered
+type ServerCapabilities struct {
+	Tools *ToolsCapability `json:"tools,omitempty"`
+}
+
+// ToolsCapability describes the tools feature
+type ToolsCapability struct {
+	ListChanged bool `json:"listChanged"`
+}
+
+// Tool describes a tool and the JSON schema of its arguments
+type Tool struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description"`
+	InputSchema json.RawMessage  `json:"inputSchema"`
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are hints about the behavior of a tool
+type ToolAnnotations struct {
+	ReadOnlyHint   bool `json:"readOnlyHint"`
+	IdempotentHint bool `json:"idempotentHint"`
+	OpenWorldHint  bool `json:"openWorldHint"`
+}
+
+// ListToolsResult is the result of tools/list; all tools fit on one page
+type ListToolsResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// CallToolParams is the tools/call request
+type CallToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CallToolResult is the result of tools/call. The text content carries the same
+// JSON as StructuredContent for clients that predate structured output.
+type CallToolResult struct {
+	Content           []Content   `json:"content"`
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+	IsError           bool        `json:"isError,omitempty"`
+}
+
+// Content is a text content block
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
@@ -0,0 +1,179 @@
+// Package mcp implements a Model Context Protocol server over stdio. It exposes
+// jscan analyses as tools returning structured JSON built from the domain
+// responses, so that AI assistants can check the code they edit without scraping
+// CLI output.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/jsonrpc"
+	"github.com/ludo-technologies/jscan/internal/server"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// instructions tell the assistant how to use the tools
+const instructions = `jscan analyzes the JavaScript/TypeScript project at the server root. ` +
+	`Paths are relative to the root; modules are root-relative file paths such as src/api.ts. ` +
+	`Pass the unsaved content of a file to analyze_file or find_clones_of_function to check an edit before writing it.`
+
+// Server is an MCP server for one client connection. Requests are handled one at a
+// time in the order they arrive. Files are read through the workspace cache of
+// internal/server, so only changed files are reread between tool calls.
+type Server struct {
+	conn      *jsonrpc.Conn
+	workspace *server.Server
+
+	initialized bool
+}
+
+// NewServer creates a server for the project at root reading requests from in and
+// writing to out; configPath is an optional config file, otherwise the one of root
+// is used
+func NewServer(in io.Reader, out io.Writer, root, configPath string) (*Server, error) {
+	workspace, err := server.New(root, configPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{conn: jsonrpc.NewLineConn(in, out), workspace: workspace}, nil
+}
+
+// Run serves requests until the client closes the stream
+func (s *Server) Run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		body, err := s.conn.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.handle(ctx, body); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches one message; only transport errors are returned
+func (s *Server) handle(ctx context.Context, body []byte) error {
+	var msg jsonrpc.Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return s.conn.Reply(json.RawMessage("null"), nil, &jsonrpc.Error{Code: jsonrpc.CodeParseError, Message: err.Error()})
+	}
+
+	// Notifications (initialized, cancelled) have no ID and need no response
+	if msg.ID == nil {
+		if msg.Method == "notifications/initialized" {
+			s.initialized = true
+		}
+		return nil
+	}
+	id := *msg.ID
+
+	if msg.JSONRPC != "2.0" || msg.Method == "" {
+		return s.conn.Reply(id, nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidRequest, Message: "not a JSON-RPC 2.0 request"})
+	}
+	if !s.initialized && msg.Method != "initialize" && msg.Method != "ping" {
+		return s.conn.Reply(id, nil, &jsonrpc.Error{Code: jsonrpc.CodeServerNotInitialized, Message: "server not initialized"})
+	}
+
+	result, rpcErr := s.request(ctx, msg)
+	return s.conn.Reply(id, result, rpcErr)
+}
+
+// request handles a request and returns its result
+func (s *Server) request(ctx context.Context, msg jsonrpc.Message) (interface{}, *jsonrpc.Error) {
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+
+	case "ping":
+		return struct{}{}, nil
+
+	case "tools/list":
+		return ListToolsResult{Tools: toolList()}, nil
+
+	case "tools/call":
+		var params CallToolParams
+		if err := jsonrpc.UnmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params)
+	}
+
+	return nil, &jsonrpc.Error{Code: jsonrpc.CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+}
+
+// initialize picks the protocol revision and announces the tools. The server
+// accepts requests from then on; the initialized notification is not required.
+func (s *Server) initialize(params InitializeParams) InitializeResult {
+	s.initialized = true
+
+	protocolVersion := protocolVersions[0]
+	for _, supported := range protocolVersions {
+		if params.ProtocolVersion == supported {
+			protocolVersion = supported
+			break
+		}
+	}
+	return InitializeResult{
+		ProtocolVersion: protocolVersion,
+		Capabilities:    ServerCapabilities{Tools: &ToolsCapability{}},
+		ServerInfo:      Implementation{Name: "jscan", Version: version.GetVersion()},
+		Instructions:    instructions,
+	}
+}
+
+// callTool runs a tool. Unknown tools and malformed arguments are protocol errors;
+// failures of the tool itself, such as a path outside the root, are reported in the
+// result so the assistant can correct its call.
+func (s *Server) callTool(ctx context.Context, params CallToolParams) (interface{}, *jsonrpc.Error) {
+	t, ok := findTool(params.Name)
+	if !ok {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+	}
+	result, err := t.run(ctx, s, params.Arguments)
+	if err != nil {
+		var argErr *argumentsError
+		if errors.As(err, &argErr) {
+			return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: err.Error()}
+		}
+		return toolError(err), nil
+	}
+
+	text, err := json.Marshal(result)
+	if err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInternalError, Message: err.Error()}
+	}
+	return CallToolResult{
+		Content:           []Content{{Type: "text", Text: string(text)}},
+		StructuredContent: result,
+	}, nil
+}
+
+// toolError reports a failed tool call with its domain error code
+func toolError(err error) CallToolResult {
+	code := domain.ErrCodeAnalysisError
+	var domainErr domain.DomainError
+	if errors.As(err, &domainErr) {
+		code = domainErr.Code
+	}
+	body := map[string]string{"code": code, "message": err.Error()}
+	text, _ := json.Marshal(body)
+	return CallToolResult{
+		Content: []Content{{Type: "text", Text: string(text)}},
+		IsError: true,
+	}
+}
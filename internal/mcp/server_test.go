@@ -0,0 +1,376 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/jsonrpc"
+)
+
+const (
+	mcpFileA = `import { b } from './b.js';
+
+export function a(x) {
+  if (x) {
+    return b();
+  }
+  return 0;
+}
+`
+	mcpFileB = `import { a } from './a.js';
+
+export function b() {
+  return a(1);
+}
+`
+	// total is duplicated in c.js and d.js
+	mcpTotal = `export function total(items) {
+  let sum = 0;
+  for (const item of items) {
+    if (item.price > 0 && item.quantity > 0) {
+      sum += item.price * item.quantity;
+    } else {
+      sum += 0;
+    }
+  }
+  const tax = sum * 0.2;
+  return sum + tax;
+}
+`
+)
+
+func newTestProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "src", "a.js"), mcpFileA)
+	writeTestFile(t, filepath.Join(root, "src", "b.js"), mcpFileB)
+	writeTestFile(t, filepath.Join(root, "src", "c.js"), mcpTotal)
+	writeTestFile(t, filepath.Join(root, "src", "d.js"), strings.Replace(mcpTotal, "total", "sumOrder", 1))
+	return root
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// session runs the server on the requests, after an initialize request unless
+// raw is set, and returns the responses by ID
+func session(t *testing.T, root string, raw bool, requests ...string) map[string]jsonrpc.Response {
+	t.Helper()
+	var in bytes.Buffer
+	if !raw {
+		in.WriteString(`{"jsonrpc": "2.0", "id": "init", "method": "initialize", "params": {"protocolVersion": "2025-06-18"}}` + "\n")
+		in.WriteString(`{"jsonrpc": "2.0", "method": "notifications/initialized"}` + "\n")
+	}
+	for _, req := range requests {
+		in.WriteString(req + "\n")
+	}
+
+	var out bytes.Buffer
+	server, err := NewServer(&in, &out, root, "")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	if err := server.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	responses := make(map[string]jsonrpc.Response)
+	scanner := bufio.NewScanner(&out)
+	scanner.Buffer(make([]byte, 1<<20), 1<<24)
+	for scanner.Scan() {
+		var resp jsonrpc.Response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response line %s: %v", scanner.Text(), err)
+		}
+		var id interface{}
+		_ = json.Unmarshal(resp.ID, &id)
+		responses[fmt.Sprint(id)] = resp
+	}
+	return responses
+}
+
+// call builds a tools/call request
+func call(id int, name string, args interface{}) string {
+	data, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  "tools/call",
+		"params":  map[string]interface{}{"name": name, "arguments": args},
+	})
+	return string(data)
+}
+
+// toolResult decodes a successful tool result into v and checks that the text
+// content matches the structured content
+func toolResult(t *testing.T, resp jsonrpc.Response, v interface{}) {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("unexpected error %+v", resp.Error)
+	}
+	var result struct {
+		Content           []Content       `json:"content"`
+		StructuredContent json.RawMessage `json:"structuredContent"`
+		IsError           bool            `json:"isError"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError || len(result.Content) != 1 {
+		t.Fatalf("unexpected tool result %s", resp.Result)
+	}
+	if result.Content[0].Text != string(result.StructuredContent) {
+		t.Errorf("text content differs from structured content")
+	}
+	if err := json.Unmarshal(result.StructuredContent, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, false,
+		`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "ping"}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "resources/list"}`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "lint"}}`,
+		`{"jsonrpc": `,
+	)
+
+	var init InitializeResult
+	if err := json.Unmarshal(responses["init"].Result, &init); err != nil {
+		t.Fatal(err)
+	}
+	if init.ProtocolVersion != "2025-06-18" || init.Capabilities.Tools == nil || init.ServerInfo.Name != "jscan" {
+		t.Errorf("unexpected initialize result %+v", init)
+	}
+
+	var list ListToolsResult
+	if err := json.Unmarshal(responses["1"].Result, &list); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+		var schema map[string]interface{}
+		if err := json.Unmarshal(tool.InputSchema, &schema); err != nil || schema["type"] != "object" {
+			t.Errorf("%s: invalid input schema %s", tool.Name, tool.InputSchema)
+		}
+	}
+	want := "analyze_file get_complexity find_clones_of_function find_dead_code explain_cycle get_dependents"
+	if strings.Join(names, " ") != want {
+		t.Errorf("expected tools %s, got %v", want, names)
+	}
+
+	if string(responses["2"].Result) != "{}" {
+		t.Errorf("expected an empty ping result, got %s", responses["2"].Result)
+	}
+	for id, code := range map[string]int{"3": jsonrpc.CodeMethodNotFound, "4": jsonrpc.CodeInvalidParams, "<nil>": jsonrpc.CodeParseError} {
+		if responses[id].Error == nil || responses[id].Error.Code != code {
+			t.Errorf("request %s: expected error %d, got %+v", id, code, responses[id].Error)
+		}
+	}
+}
+
+func TestLifecycle_NotInitialized(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, true,
+		`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "initialize", "params": {"protocolVersion": "2024-11-05"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "initialize", "params": {"protocolVersion": "1999-01-01"}}`,
+	)
+	if responses["1"].Error == nil || responses["1"].Error.Code != jsonrpc.CodeServerNotInitialized {
+		t.Errorf("expected a not initialized error, got %+v", responses["1"])
+	}
+	for id, version := range map[string]string{"2": "2024-11-05", "3": protocolVersions[0]} {
+		var init InitializeResult
+		if err := json.Unmarshal(responses[id].Result, &init); err != nil || init.ProtocolVersion != version {
+			t.Errorf("request %s: expected protocol version %s, got %s", id, version, responses[id].Result)
+		}
+	}
+}
+
+func TestAnalyzeFile(t *testing.T) {
+	root := newTestProject(t)
+	unsaved := mcpFileA + "\nexport function c() {\n  return 1;\n  console.log('never');\n}\n"
+	responses := session(t, root, false,
+		call(1, "analyze_file", map[string]string{"path": "src/a.js"}),
+		call(2, "analyze_file", map[string]string{"path": "src/a.js", "content": unsaved}),
+	)
+
+	var saved FileReport
+	toolResult(t, responses["1"], &saved)
+	if saved.Module != "src/a.js" || saved.File != filepath.Join(root, "src", "a.js") {
+		t.Errorf("unexpected file %s (module %s)", saved.File, saved.Module)
+	}
+	if len(saved.Functions) != 1 || saved.Functions[0].Name != "a" || saved.Functions[0].Metrics.Complexity != 2 {
+		t.Errorf("unexpected functions %+v", saved.Functions)
+	}
+	if len(saved.Cycles) != 1 || len(saved.Cycles[0].Modules) != 2 {
+		t.Errorf("expected the a.js <-> b.js cycle, got %+v", saved.Cycles)
+	}
+	for _, finding := range saved.DeadCode {
+		if finding.Reason == "unused_exported_function" {
+			t.Errorf("a is imported by b.js and should not be reported: %+v", finding)
+		}
+	}
+
+	var edited FileReport
+	toolResult(t, responses["2"], &edited)
+	if len(edited.Functions) != 2 {
+		t.Errorf("expected the unsaved function to be analyzed, got %+v", edited.Functions)
+	}
+	unreachable := false
+	for _, finding := range edited.DeadCode {
+		if finding.FunctionName == "c" && finding.Location.StartLine == 12 {
+			unreachable = true
+		}
+	}
+	if !unreachable {
+		t.Errorf("expected the unreachable statement of the unsaved content, got %+v", edited.DeadCode)
+	}
+}
+
+func TestGetComplexity(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, false,
+		call(1, "get_complexity", map[string]string{"path": "src"}),
+		call(2, "get_complexity", map[string]string{"path": "src", "function": "total"}),
+		call(3, "get_complexity", map[string]string{"path": "src", "function": "missing"}),
+	)
+
+	var all ComplexityReport
+	toolResult(t, responses["1"], &all)
+	if len(all.Functions) != 4 || all.Summary.FilesAnalyzed != 4 {
+		t.Errorf("expected 4 functions in 4 files, got %+v", all)
+	}
+	var one ComplexityReport
+	toolResult(t, responses["2"], &one)
+	if len(one.Functions) != 1 || one.Functions[0].FilePath != filepath.Join(root, "src", "c.js") {
+		t.Errorf("expected total in c.js, got %+v", one.Functions)
+	}
+	assertToolError(t, responses["3"], domain.ErrCodeInvalidInput)
+}
+
+func TestFindClonesOfFunction(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, false,
+		call(1, "find_clones_of_function", map[string]string{"path": "src/c.js", "function": "total"}),
+		call(2, "find_clones_of_function", map[string]string{"path": "src/a.js", "function": "a"}),
+	)
+
+	var report CloneReport
+	toolResult(t, responses["1"], &report)
+	if report.Function.Name != "total" || len(report.Clones) == 0 {
+		t.Fatalf("expected clones of total, got %+v", report)
+	}
+	for _, clone := range report.Clones {
+		if clone.Location.FilePath != filepath.Join(root, "src", "d.js") || clone.Similarity <= 0 {
+			t.Errorf("expected a clone in d.js, got %+v", clone.Location)
+		}
+		if clone.Fragment.StartLine < report.Function.StartLine || clone.Fragment.EndLine > report.Function.EndLine {
+			t.Errorf("fragment %+v is outside the function", clone.Fragment)
+		}
+	}
+
+	var none CloneReport
+	toolResult(t, responses["2"], &none)
+	if len(none.Clones) != 0 {
+		t.Errorf("expected no clones of a, got %+v", none.Clones)
+	}
+}
+
+func TestFindDeadCode(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, false,
+		call(1, "find_dead_code", map[string]string{"path": "src/c.js"}),
+		call(2, "find_dead_code", map[string]string{"path": "src/a.js"}),
+	)
+
+	var report DeadCodeReport
+	toolResult(t, responses["1"], &report)
+	if report.TotalFindings == 0 || len(report.Files) != 1 || report.Files[0].FilePath != filepath.Join(root, "src", "c.js") {
+		t.Errorf("expected findings for the unimported c.js only, got %+v", report)
+	}
+
+	var imported DeadCodeReport
+	toolResult(t, responses["2"], &imported)
+	for _, f := range imported.Files {
+		for _, finding := range f.FileLevelFindings {
+			if finding.Reason == "unused_exported_function" {
+				t.Errorf("a is imported by b.js and should not be reported: %+v", finding)
+			}
+		}
+	}
+}
+
+func TestModuleTools(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, false,
+		call(1, "explain_cycle", map[string]string{"module": "src/a.js"}),
+		call(2, "explain_cycle", map[string]string{"module": filepath.Join(root, "src", "c.js")}),
+		call(3, "get_dependents", map[string]interface{}{"module": "src/a.js"}),
+		call(4, "explain_cycle", map[string]string{"module": "src/missing.js"}),
+	)
+
+	var cycle CycleReport
+	toolResult(t, responses["1"], &cycle)
+	if !cycle.InCycle || len(cycle.Cycles) != 1 || len(cycle.Explanation) != 1 || len(cycle.Cycles[0].BreakingEdges) == 0 {
+		t.Errorf("expected an explained cycle, got %+v", cycle)
+	}
+	var acyclic CycleReport
+	toolResult(t, responses["2"], &acyclic)
+	if acyclic.InCycle || len(acyclic.Modules) != 1 || acyclic.Modules[0] != "src/c.js" {
+		t.Errorf("expected c.js outside any cycle, got %+v", acyclic)
+	}
+
+	var dependents domain.DependencyQueryResult
+	toolResult(t, responses["3"], &dependents)
+	if len(dependents.Edges) != 1 || dependents.Edges[0].From != "src/b.js" {
+		t.Errorf("expected b.js to depend on a.js, got %+v", dependents)
+	}
+
+	assertToolError(t, responses["4"], domain.ErrCodeInvalidInput)
+}
+
+func TestToolErrors(t *testing.T) {
+	root := newTestProject(t)
+	responses := session(t, root, false,
+		call(1, "analyze_file", map[string]string{"path": "/etc/passwd"}),
+		call(2, "analyze_file", map[string]string{}),
+		call(3, "analyze_file", map[string]string{"file": "src/a.js"}),
+		call(4, "get_dependents", map[string]interface{}{"module": "src/a.js", "transitive": "yes"}),
+	)
+	assertToolError(t, responses["1"], domain.ErrCodeInvalidInput)
+	assertToolError(t, responses["2"], domain.ErrCodeInvalidInput)
+	for _, id := range []string{"3", "4"} {
+		if responses[id].Error == nil || responses[id].Error.Code != jsonrpc.CodeInvalidParams {
+			t.Errorf("request %s: expected invalid params, got %+v", id, responses[id])
+		}
+	}
+}
+
+func assertToolError(t *testing.T, resp jsonrpc.Response, code string) {
+	t.Helper()
+	var result CallToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil || resp.Error != nil {
+		t.Fatalf("expected a tool result, got %+v", resp)
+	}
+	if !result.IsError || len(result.Content) != 1 || !strings.Contains(result.Content[0].Text, `"code":"`+code+`"`) {
+		t.Errorf("expected a %s tool error, got %s", code, resp.Result)
+	}
+}
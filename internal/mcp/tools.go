@@ -0,0 +1,607 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/server"
+	"github.com/ludo-technologies/jscan/pkg/jscan"
+)
+
+// tool is a tool definition with its handler
+type tool struct {
+	Tool
+	run func(ctx context.Context, s *Server, args json.RawMessage) (interface{}, error)
+}
+
+var tools = []tool{
+	{
+		Tool: Tool{
+			Name:  "analyze_file",
+			Title: "Analyze file",
+			Description: "Analyze one JavaScript/TypeScript file: complexity of every function, dead code " +
+				"(unreachable code, unused imports and exports), class coupling (CBO) and the import cycles " +
+				"the file takes part in. Pass content to analyze an unsaved version of the file.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "path": {"type": "string", "description": "File path, relative to the project root"},
+    "content": {"type": "string", "description": "Content to analyze instead of the file on disk"}
+  },
+  "required": ["path"]
+}`),
+		},
+		run: decoded(analyzeFile),
+	},
+	{
+		Tool: Tool{
+			Name:  "get_complexity",
+			Title: "Get complexity",
+			Description: "Cyclomatic and cognitive complexity, nesting depth and risk level of the functions " +
+				"in a file or directory, optionally restricted to the functions with a given name.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "path": {"type": "string", "description": "File or directory, relative to the project root"},
+    "function": {"type": "string", "description": "Only report functions with this name"}
+  },
+  "required": ["path"]
+}`),
+		},
+		run: decoded(getComplexity),
+	},
+	{
+		Tool: Tool{
+			Name:  "find_clones_of_function",
+			Title: "Find clones of function",
+			Description: "Find code elsewhere in the project that duplicates (part of) a function, with the " +
+				"clone type and similarity. Pass content to check an unsaved version of the file.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "path": {"type": "string", "description": "File declaring the function, relative to the project root"},
+    "function": {"type": "string", "description": "Function name, as reported by get_complexity"},
+    "line": {"type": "integer", "description": "A line inside the function, when several functions share the name"},
+    "content": {"type": "string", "description": "Content to analyze instead of the file on disk"}
+  },
+  "required": ["path", "function"]
+}`),
+		},
+		run: decoded(findClonesOfFunction),
+	},
+	{
+		Tool: Tool{
+			Name:  "find_dead_code",
+			Title: "Find dead code",
+			Description: "Unreachable code, unused functions, imports and exports, and orphan files in a file " +
+				"or directory. Usage is resolved across the whole project.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "path": {"type": "string", "description": "File or directory, relative to the project root"}
+  },
+  "required": ["path"]
+}`),
+		},
+		run: decoded(findDeadCode),
+	},
+	{
+		Tool: Tool{
+			Name:  "explain_cycle",
+			Title: "Explain import cycle",
+			Description: "Explain the circular imports a module takes part in: the modules and import paths " +
+				"forming each cycle, and the cheapest imports to remove or convert to import type to break it.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "module": {"type": "string", "description": "Module path relative to the project root, or a glob"}
+  },
+  "required": ["module"]
+}`),
+		},
+		run: decoded(explainCycle),
+	},
+	{
+		Tool: Tool{
+			Name:  "get_dependents",
+			Title: "Get dependents",
+			Description: "Modules importing a module, with the import edges, to assess the impact of " +
+				"changing its exports.",
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "module": {"type": "string", "description": "Module path relative to the project root, or a glob"},
+    "transitive": {"type": "boolean", "description": "Also include modules importing the dependents"}
+  },
+  "required": ["module"]
+}`),
+		},
+		run: decoded(getDependents),
+	},
+}
+
+func toolList() []Tool {
+	list := make([]Tool, len(tools))
+	for i, t := range tools {
+		list[i] = t.Tool
+		list[i].Annotations = &ToolAnnotations{ReadOnlyHint: true, IdempotentHint: true}
+	}
+	return list
+}
+
+func findTool(name string) (tool, bool) {
+	for _, t := range tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return tool{}, false
+}
+
+// argumentsError reports tool arguments that do not match the input schema
+type argumentsError struct {
+	err error
+}
+
+func (e *argumentsError) Error() string {
+	return fmt.Sprintf("invalid arguments: %v", e.err)
+}
+
+// decoded adapts a handler taking typed arguments
+func decoded[A any](handler func(ctx context.Context, s *Server, args A) (interface{}, error)) func(context.Context, *Server, json.RawMessage) (interface{}, error) {
+	return func(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+		var args A
+		if len(raw) > 0 && string(raw) != "null" {
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&args); err != nil {
+				return nil, &argumentsError{err: err}
+			}
+		}
+		return handler(ctx, s, args)
+	}
+}
+
+// FileReport is the result of analyze_file
+type FileReport struct {
+	File      string                      `json:"file"`
+	Module    string                      `json:"module"`
+	Functions []domain.FunctionComplexity `json:"functions"`
+	DeadCode  []domain.DeadCodeFinding    `json:"dead_code"`
+	Classes   []domain.ClassCoupling      `json:"classes"`
+	Cycles    []domain.CircularDependency `json:"cycles"`
+}
+
+// ComplexityReport is the result of get_complexity
+type ComplexityReport struct {
+	Path      string                      `json:"path"`
+	Functions []domain.FunctionComplexity `json:"functions"`
+	Summary   domain.ComplexitySummary    `json:"summary"`
+}
+
+// CloneReport is the result of find_clones_of_function
+type CloneReport struct {
+	Function domain.FunctionComplexity `json:"function"`
+	Clones   []CloneMatch              `json:"clones"`
+}
+
+// CloneMatch is code duplicating a fragment of the function
+type CloneMatch struct {
+	Location   *domain.CloneLocation `json:"location"`
+	Fragment   *domain.CloneLocation `json:"fragment"`
+	Type       string                `json:"type"`
+	Similarity float64               `json:"similarity"`
+}
+
+// DeadCodeReport is the result of find_dead_code
+type DeadCodeReport struct {
+	Path          string                `json:"path"`
+	TotalFindings int                   `json:"total_findings"`
+	Files         []domain.FileDeadCode `json:"files"`
+}
+
+// CycleReport is the result of explain_cycle
+type CycleReport struct {
+	Modules     []string                    `json:"modules"`
+	InCycle     bool                        `json:"in_cycle"`
+	Cycles      []domain.CircularDependency `json:"cycles"`
+	Explanation []string                    `json:"explanation"`
+}
+
+type fileArgs struct {
+	Path    string  `json:"path"`
+	Content *string `json:"content"`
+}
+
+func analyzeFile(ctx context.Context, s *Server, args fileArgs) (interface{}, error) {
+	file, err := s.file(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.fileSources(file, args.Content)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.analyze(ctx, sources, jscan.AnalysisComplexity, jscan.AnalysisCBO)
+	if err != nil {
+		return nil, err
+	}
+	// Unused exports and orphan files depend on the importers of the file
+	project, err := s.projectSources(file, args.Content)
+	if err != nil {
+		return nil, err
+	}
+	dead, err := s.analyze(ctx, project, jscan.AnalysisDeadCode)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FileReport{
+		File:      file,
+		Module:    s.moduleID(file),
+		Functions: r.Complexity.Functions,
+		DeadCode:  []domain.DeadCodeFinding{},
+		Classes:   r.CBO.Classes,
+		Cycles:    []domain.CircularDependency{},
+	}
+	for _, f := range dead.DeadCode.Files {
+		if f.FilePath != file {
+			continue
+		}
+		for _, fn := range f.Functions {
+			report.DeadCode = append(report.DeadCode, fn.Findings...)
+		}
+		report.DeadCode = append(report.DeadCode, f.FileLevelFindings...)
+	}
+	graph, err := s.workspace.Graph(server.GraphParams{})
+	if err != nil {
+		return nil, err
+	}
+	report.Cycles = cyclesOf(graph.Cycles, []string{report.Module})
+	return report, nil
+}
+
+type complexityArgs struct {
+	Path     string `json:"path"`
+	Function string `json:"function"`
+}
+
+func getComplexity(ctx context.Context, s *Server, args complexityArgs) (interface{}, error) {
+	if args.Path == "" {
+		return nil, domain.NewInvalidInputError("path is required", nil)
+	}
+	sources, err := s.workspace.Sources(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("no JavaScript/TypeScript files found in %s", args.Path), nil)
+	}
+	r, err := s.analyze(ctx, sources, jscan.AnalysisComplexity)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ComplexityReport{
+		Path:      args.Path,
+		Functions: r.Complexity.Functions,
+		Summary:   r.Complexity.Summary,
+	}
+	if args.Function != "" {
+		report.Functions = []domain.FunctionComplexity{}
+		for _, fn := range r.Complexity.Functions {
+			if fn.Name == args.Function {
+				report.Functions = append(report.Functions, fn)
+			}
+		}
+		if len(report.Functions) == 0 {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("function %q not found in %s", args.Function, args.Path), nil)
+		}
+	}
+	return report, nil
+}
+
+type cloneArgs struct {
+	Path     string  `json:"path"`
+	Function string  `json:"function"`
+	Line     int     `json:"line"`
+	Content  *string `json:"content"`
+}
+
+func findClonesOfFunction(ctx context.Context, s *Server, args cloneArgs) (interface{}, error) {
+	file, err := s.file(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	if args.Function == "" {
+		return nil, domain.NewInvalidInputError("function is required", nil)
+	}
+	sources, err := s.fileSources(file, args.Content)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.analyze(ctx, sources, jscan.AnalysisComplexity)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := findFunction(r.Complexity.Functions, args.Function, args.Line)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectSources(file, args.Content)
+	if err != nil {
+		return nil, err
+	}
+	// Clones of part of the function's body are statement sequences
+	clones, err := s.analyzeWith(ctx, project, jscan.Options{
+		Analyses:           []jscan.Analysis{jscan.AnalysisClone},
+		StatementSequences: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CloneReport{Function: fn, Clones: []CloneMatch{}}
+	seen := make(map[string]bool)
+	inFunction := func(c *domain.Clone) bool {
+		return c != nil && c.Location != nil && c.Location.FilePath == file &&
+			c.Location.StartLine >= fn.StartLine && c.Location.EndLine <= fn.EndLine
+	}
+	for _, pair := range clones.Clone.ClonePairs {
+		for _, side := range [][2]*domain.Clone{{pair.Clone1, pair.Clone2}, {pair.Clone2, pair.Clone1}} {
+			fragment, other := side[0], side[1]
+			if !inFunction(fragment) || other == nil || other.Location == nil {
+				continue
+			}
+			key := fragment.Location.String() + "|" + other.Location.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			report.Clones = append(report.Clones, CloneMatch{
+				Location:   other.Location,
+				Fragment:   fragment.Location,
+				Type:       pair.Type.String(),
+				Similarity: pair.Similarity,
+			})
+		}
+	}
+	sort.SliceStable(report.Clones, func(i, j int) bool {
+		return report.Clones[i].Similarity > report.Clones[j].Similarity
+	})
+	return report, nil
+}
+
+// findFunction returns the function with the given name, using line to choose
+// among functions sharing it
+func findFunction(functions []domain.FunctionComplexity, name string, line int) (domain.FunctionComplexity, error) {
+	var matches []domain.FunctionComplexity
+	for _, fn := range functions {
+		if fn.Name != name {
+			continue
+		}
+		if line > 0 && (line < fn.StartLine || line > fn.EndLine) {
+			continue
+		}
+		matches = append(matches, fn)
+	}
+	switch {
+	case len(matches) == 0:
+		return domain.FunctionComplexity{}, domain.NewInvalidInputError(fmt.Sprintf("function %q not found", name), nil)
+	case len(matches) > 1 && line == 0:
+		lines := make([]string, len(matches))
+		for i, fn := range matches {
+			lines[i] = fmt.Sprint(fn.StartLine)
+		}
+		return domain.FunctionComplexity{}, domain.NewInvalidInputError(
+			fmt.Sprintf("%d functions are named %q (lines %s); pass line to choose one", len(matches), name, strings.Join(lines, ", ")), nil)
+	}
+	// The innermost function containing line
+	fn := matches[0]
+	for _, m := range matches[1:] {
+		if m.StartLine >= fn.StartLine && m.EndLine <= fn.EndLine {
+			fn = m
+		}
+	}
+	return fn, nil
+}
+
+type pathArgs struct {
+	Path string `json:"path"`
+}
+
+func findDeadCode(ctx context.Context, s *Server, args pathArgs) (interface{}, error) {
+	if args.Path == "" {
+		return nil, domain.NewInvalidInputError("path is required", nil)
+	}
+	targets, err := s.workspace.Sources(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("no JavaScript/TypeScript files found in %s", args.Path), nil)
+	}
+	project, err := s.workspace.Sources(".")
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.analyze(ctx, project, jscan.AnalysisDeadCode)
+	if err != nil {
+		return nil, err
+	}
+
+	inTarget := make(map[string]bool, len(targets))
+	for _, source := range targets {
+		inTarget[source.Path] = true
+	}
+	report := &DeadCodeReport{Path: args.Path, Files: []domain.FileDeadCode{}}
+	for _, f := range r.DeadCode.Files {
+		if inTarget[f.FilePath] && f.TotalFindings > 0 {
+			report.Files = append(report.Files, f)
+			report.TotalFindings += f.TotalFindings
+		}
+	}
+	return report, nil
+}
+
+type moduleArgs struct {
+	Module     string `json:"module"`
+	Transitive bool   `json:"transitive"`
+}
+
+func explainCycle(ctx context.Context, s *Server, args moduleArgs) (interface{}, error) {
+	if args.Module == "" {
+		return nil, domain.NewInvalidInputError("module is required", nil)
+	}
+	graph, err := s.workspace.Graph(server.GraphParams{})
+	if err != nil {
+		return nil, err
+	}
+	modules := analyzer.NewDependencyQueryEngine(graph.Graph).ResolveModules(s.modulePattern(args.Module))
+	if len(modules) == 0 {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("no module matches %q", args.Module), nil)
+	}
+
+	report := &CycleReport{
+		Modules:     modules,
+		Cycles:      cyclesOf(graph.Cycles, modules),
+		Explanation: []string{},
+	}
+	report.InCycle = len(report.Cycles) > 0
+	for _, cycle := range report.Cycles {
+		explanation := fmt.Sprintf("%s (%s severity)", cycle.Description, cycle.Severity)
+		var suggestions []string
+		for _, edge := range cycle.BreakingEdges {
+			suggestions = append(suggestions, edge.Suggestion)
+		}
+		if len(suggestions) > 0 {
+			explanation += ". To break it: " + strings.Join(suggestions, "; ")
+		}
+		report.Explanation = append(report.Explanation, explanation)
+	}
+	return report, nil
+}
+
+func getDependents(ctx context.Context, s *Server, args moduleArgs) (interface{}, error) {
+	if args.Module == "" {
+		return nil, domain.NewInvalidInputError("module is required", nil)
+	}
+	res, err := s.workspace.Graph(server.GraphParams{
+		Kind:       string(domain.QueryDependentsOf),
+		Source:     s.modulePattern(args.Module),
+		Transitive: args.Transitive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Query, nil
+}
+
+// cyclesOf returns the cycles containing any of the modules
+func cyclesOf(analysis *domain.CircularDependencyAnalysis, modules []string) []domain.CircularDependency {
+	cycles := []domain.CircularDependency{}
+	if analysis == nil {
+		return cycles
+	}
+	wanted := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		wanted[m] = true
+	}
+	for _, cycle := range analysis.CircularDependencies {
+		for _, m := range cycle.Modules {
+			if wanted[m] {
+				cycles = append(cycles, cycle)
+				break
+			}
+		}
+	}
+	return cycles
+}
+
+// file resolves a required file path inside the root
+func (s *Server) file(path string) (string, error) {
+	if path == "" {
+		return "", domain.NewInvalidInputError("path is required", nil)
+	}
+	return s.workspace.Resolve(path)
+}
+
+// fileSources returns the file with content, or as cached from disk
+func (s *Server) fileSources(file string, content *string) ([]jscan.Source, error) {
+	if content != nil {
+		return []jscan.Source{{Path: file, Content: []byte(*content)}}, nil
+	}
+	sources, err := s.workspace.Sources(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) != 1 || sources[0].Path != file {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("%s is not an analyzed JavaScript/TypeScript file", s.moduleID(file)), nil)
+	}
+	return sources, nil
+}
+
+// projectSources returns every file of the root, with content replacing the
+// cached content of file when given
+func (s *Server) projectSources(file string, content *string) ([]jscan.Source, error) {
+	sources, err := s.workspace.Sources(".")
+	if err != nil || content == nil {
+		return sources, err
+	}
+	for i := range sources {
+		if sources[i].Path == file {
+			sources[i].Content = []byte(*content)
+			return sources, nil
+		}
+	}
+	return append(sources, jscan.Source{Path: file, Content: []byte(*content)}), nil
+}
+
+// analyze runs the analyses on sources; any failure fails the tool call
+func (s *Server) analyze(ctx context.Context, sources []jscan.Source, analyses ...jscan.Analysis) (*jscan.Result, error) {
+	return s.analyzeWith(ctx, sources, jscan.Options{Analyses: analyses})
+}
+
+// analyzeWith runs the analyses selected by opts on sources with the workspace
+// configuration; any failure fails the tool call
+func (s *Server) analyzeWith(ctx context.Context, sources []jscan.Source, opts jscan.Options) (*jscan.Result, error) {
+	opts.ConfigPath = s.workspace.ConfigPath()
+	a, err := jscan.New(opts)
+	if err != nil {
+		return nil, err
+	}
+	r, err := a.AnalyzeSources(ctx, sources...)
+	if err != nil {
+		return nil, err
+	}
+	for _, analysis := range opts.Analyses {
+		if err := r.Errors[analysis]; err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// moduleID returns the root-relative module ID of file, as used by the import graph
+func (s *Server) moduleID(file string) string {
+	if rel, err := filepath.Rel(s.workspace.Root(), file); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return file
+}
+
+// modulePattern makes absolute module paths root-relative
+func (s *Server) modulePattern(module string) string {
+	if filepath.IsAbs(module) {
+		return s.moduleID(filepath.Clean(module))
+	}
+	return module
+}
@@ -9,6 +9,7 @@ type NodeType string
 const (
 	// Program and structure
 	NodeProgram NodeType = "Program"
+	NodeComment NodeType = "Comment"
 	NodeScript  NodeType = "Script"
 
 	// Function declarations
@@ -102,6 +103,7 @@ const (
 	NodeInterfaceDeclaration NodeType = "InterfaceDeclaration"
 	NodeTypeAlias            NodeType = "TypeAliasDeclaration"
 	NodeEnumDeclaration      NodeType = "EnumDeclaration"
+	NodeEnumMember           NodeType = "EnumMember"
 	NodeTypeAnnotation       NodeType = "TypeAnnotation"
 	NodeTypeParameter        NodeType = "TypeParameter"
 	NodeImportType           NodeType = "ImportType"
@@ -173,7 +175,7 @@ type Node struct {
 	Property  *Node   // Property in member expression
 
 	// Variable declaration fields
-	Kind         string  // var, let, const
+	Kind         string  // var, let, const; "type" for TypeScript type-only imports/exports and type references; "const" for const enums
 	Declarations []*Node // Variable declarators
 
 	// Import/Export fields
@@ -184,13 +186,16 @@ type Node struct {
 	Local       *Node   // Local binding
 
 	// TypeScript fields
-	TypeAnnotation *Node   // Type annotation
-	TypeParameters []*Node // Generic type parameters
+	TypeAnnotation *Node   // Type annotation, function return type or aliased type
+	TypeParameters []*Node // Generic type parameters, or type arguments of a call
 
 	// Utility fields
 	Computed bool   // Computed property
 	Optional bool   // Optional chaining
-	Raw      string // Raw literal value
+	Raw      string // Raw literal value, or the text of a comment
+
+	// Comments holds the comments of the file, in source order, on the program node
+	Comments []*Node
 }
 
 // NewNode creates a new AST node
@@ -253,6 +258,9 @@ func (n *Node) Walk(visitor func(*Node) bool) {
 	for _, spec := range n.Specifiers {
 		spec.Walk(visitor)
 	}
+	for _, typeParam := range n.TypeParameters {
+		typeParam.Walk(visitor)
+	}
 
 	// Walk individual nodes
 	if n.Test != nil {
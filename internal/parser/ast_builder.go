@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"strings"
+
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
@@ -62,6 +64,20 @@ func (b *ASTBuilder) buildNode(tsNode *sitter.Node) *Node {
 		return b.buildMethodDefinition(tsNode)
 	case "class_declaration":
 		return b.buildClassDeclaration(tsNode)
+	case "interface_declaration":
+		return b.buildInterfaceDeclaration(tsNode)
+	case "type_alias_declaration":
+		return b.buildTypeAlias(tsNode)
+	case "enum_declaration":
+		return b.buildEnumDeclaration(tsNode)
+	case "nested_type_identifier":
+		return b.buildNestedTypeIdentifier(tsNode)
+	case "as_expression":
+		return b.buildAsExpression(tsNode)
+	case "non_null_expression":
+		return b.buildNonNullExpression(tsNode)
+	case "jsx_element", "jsx_self_closing_element":
+		return b.buildJSXElement(tsNode)
 	case "if_statement":
 		return b.buildIfStatement(tsNode)
 	case "switch_statement":
@@ -149,10 +165,30 @@ func (b *ASTBuilder) buildProgram(tsNode *sitter.Node) *Node {
 			}
 		}
 	}
+	b.collectComments(tsNode, &node.Comments)
 
 	return node
 }
 
+// collectComments appends the comments under tsNode, which are trivia left out of
+// the tree, in source order
+func (b *ASTBuilder) collectComments(tsNode *sitter.Node, comments *[]*Node) {
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child == nil {
+			continue
+		}
+		if child.Type() == "comment" {
+			comment := NewNode(NodeComment)
+			comment.Location = b.getLocation(child)
+			comment.Raw = child.Content(b.source)
+			*comments = append(*comments, comment)
+			continue
+		}
+		b.collectComments(child, comments)
+	}
+}
+
 // buildFunctionDeclaration builds a function declaration node
 func (b *ASTBuilder) buildFunctionDeclaration(tsNode *sitter.Node) *Node {
 	node := NewNode(NodeFunction)
@@ -168,6 +204,8 @@ func (b *ASTBuilder) buildFunctionDeclaration(tsNode *sitter.Node) *Node {
 		node.Params = b.buildParameters(paramsNode)
 	}
 
+	b.extractTypeSignature(tsNode, node)
+
 	// Extract body
 	if bodyNode := b.getChildByFieldName(tsNode, "body"); bodyNode != nil {
 		bodyAST := b.buildNode(bodyNode)
@@ -196,6 +234,8 @@ func (b *ASTBuilder) buildArrowFunction(tsNode *sitter.Node) *Node {
 		node.Params = b.buildParameters(paramsNode)
 	}
 
+	b.extractTypeSignature(tsNode, node)
+
 	// Extract body
 	if bodyNode := b.getChildByFieldName(tsNode, "body"); bodyNode != nil {
 		bodyAST := b.buildNode(bodyNode)
@@ -227,6 +267,8 @@ func (b *ASTBuilder) buildFunctionExpression(tsNode *sitter.Node) *Node {
 		node.Params = b.buildParameters(paramsNode)
 	}
 
+	b.extractTypeSignature(tsNode, node)
+
 	// Extract body
 	if bodyNode := b.getChildByFieldName(tsNode, "body"); bodyNode != nil {
 		bodyAST := b.buildNode(bodyNode)
@@ -254,6 +296,8 @@ func (b *ASTBuilder) buildGeneratorFunction(tsNode *sitter.Node) *Node {
 		node.Params = b.buildParameters(paramsNode)
 	}
 
+	b.extractTypeSignature(tsNode, node)
+
 	// Extract body
 	if bodyNode := b.getChildByFieldName(tsNode, "body"); bodyNode != nil {
 		bodyAST := b.buildNode(bodyNode)
@@ -280,6 +324,8 @@ func (b *ASTBuilder) buildMethodDefinition(tsNode *sitter.Node) *Node {
 		node.Params = b.buildParameters(paramsNode)
 	}
 
+	b.extractTypeSignature(tsNode, node)
+
 	// Extract body
 	if bodyNode := b.getChildByFieldName(tsNode, "body"); bodyNode != nil {
 		bodyAST := b.buildNode(bodyNode)
@@ -301,6 +347,15 @@ func (b *ASTBuilder) buildClassDeclaration(tsNode *sitter.Node) *Node {
 		node.Name = nameNode.Content(b.source)
 	}
 
+	b.extractTypeSignature(tsNode, node)
+
+	// Extract extends/implements clauses
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		if child := tsNode.Child(i); child != nil && child.Type() == "class_heritage" {
+			node.AddChild(b.buildNode(child))
+		}
+	}
+
 	// Extract class body
 	if bodyNode := b.getChildByFieldName(tsNode, "body"); bodyNode != nil {
 		for i := 0; i < int(bodyNode.ChildCount()); i++ {
@@ -317,6 +372,271 @@ func (b *ASTBuilder) buildClassDeclaration(tsNode *sitter.Node) *Node {
 	return node
 }
 
+// buildInterfaceDeclaration builds a TypeScript interface declaration node; the
+// extends clause and the body are its children
+func (b *ASTBuilder) buildInterfaceDeclaration(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeInterfaceDeclaration)
+	node.Location = b.getLocation(tsNode)
+
+	if nameNode := b.getChildByFieldName(tsNode, "name"); nameNode != nil {
+		node.Name = nameNode.Content(b.source)
+	}
+	b.extractTypeSignature(tsNode, node)
+
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child == nil {
+			continue
+		}
+		switch child.Type() {
+		case "extends_type_clause", "interface_body", "object_type":
+			node.AddChild(b.buildNode(child))
+		}
+	}
+
+	return node
+}
+
+// buildTypeAlias builds a TypeScript type alias node; the aliased type is its
+// TypeAnnotation
+func (b *ASTBuilder) buildTypeAlias(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeTypeAlias)
+	node.Location = b.getLocation(tsNode)
+
+	if nameNode := b.getChildByFieldName(tsNode, "name"); nameNode != nil {
+		node.Name = nameNode.Content(b.source)
+	}
+	b.extractTypeSignature(tsNode, node)
+	if valueNode := b.getChildByFieldName(tsNode, "value"); valueNode != nil {
+		node.TypeAnnotation = b.buildNode(valueNode)
+	}
+
+	return node
+}
+
+// buildEnumDeclaration builds a TypeScript enum declaration node with one
+// EnumMember per member in Body; Kind is "const" for const enums
+func (b *ASTBuilder) buildEnumDeclaration(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeEnumDeclaration)
+	node.Location = b.getLocation(tsNode)
+
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		if child := tsNode.Child(i); child != nil && child.Type() == "const" {
+			node.Kind = "const"
+		}
+	}
+	if nameNode := b.getChildByFieldName(tsNode, "name"); nameNode != nil {
+		node.Name = nameNode.Content(b.source)
+	}
+
+	bodyNode := b.getChildByFieldName(tsNode, "body")
+	if bodyNode == nil {
+		return node
+	}
+	for i := 0; i < int(bodyNode.ChildCount()); i++ {
+		child := bodyNode.Child(i)
+		if child == nil {
+			continue
+		}
+		switch child.Type() {
+		case "property_identifier", "string", "number":
+			// Member without initializer: enum E { A, 'B' }
+			member := NewNode(NodeEnumMember)
+			member.Location = b.getLocation(child)
+			member.Name = enumMemberName(child.Content(b.source))
+			node.Body = append(node.Body, member)
+		case "enum_assignment":
+			// Member with initializer: enum E { A = 1 }
+			member := NewNode(NodeEnumMember)
+			member.Location = b.getLocation(child)
+			if nameNode := b.getChildByFieldName(child, "name"); nameNode != nil {
+				member.Name = enumMemberName(nameNode.Content(b.source))
+			}
+			if valueNode := b.getChildByFieldName(child, "value"); valueNode != nil {
+				member.AddChild(b.buildNode(valueNode))
+			}
+			node.Body = append(node.Body, member)
+		}
+	}
+
+	return node
+}
+
+// enumMemberName strips the quotes of string enum member names
+func enumMemberName(raw string) string {
+	if len(raw) >= 2 && (raw[0] == '\'' || raw[0] == '"') && raw[len(raw)-1] == raw[0] {
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
+
+// buildNestedTypeIdentifier builds a qualified type name such as Ns.Type or
+// Enum.Member as a member expression
+func (b *ASTBuilder) buildNestedTypeIdentifier(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeMemberExpression)
+	node.Location = b.getLocation(tsNode)
+
+	if moduleNode := b.getChildByFieldName(tsNode, "module"); moduleNode != nil {
+		node.Object = b.buildNode(moduleNode)
+	}
+	if nameNode := b.getChildByFieldName(tsNode, "name"); nameNode != nil {
+		node.Property = b.buildNode(nameNode)
+	}
+
+	return node
+}
+
+// buildAsExpression builds a TypeScript type cast `x as T`: the expression is the
+// Argument and the target type the TypeAnnotation; Kind is "const" for `x as const`
+func (b *ASTBuilder) buildAsExpression(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeAsExpression)
+	node.Location = b.getLocation(tsNode)
+
+	var operands []*sitter.Node
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		if child := tsNode.Child(i); child != nil && !b.isTrivia(child) && child.Type() != "as" {
+			operands = append(operands, child)
+		}
+	}
+	if len(operands) > 0 {
+		node.Argument = b.buildNode(operands[0])
+	}
+	if len(operands) > 1 {
+		if operands[1].Type() == "const" {
+			node.Kind = "const"
+		} else {
+			node.TypeAnnotation = b.buildNode(operands[1])
+		}
+	}
+
+	return node
+}
+
+// buildNonNullExpression builds a TypeScript non-null assertion `x!` with the
+// expression as Argument
+func (b *ASTBuilder) buildNonNullExpression(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeNonNullExpression)
+	node.Location = b.getLocation(tsNode)
+
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		if child := tsNode.Child(i); child != nil && !b.isTrivia(child) && child.Type() != "!" {
+			node.Argument = b.buildNode(child)
+			break
+		}
+	}
+
+	return node
+}
+
+// buildJSXElement builds a JSX element or fragment. Name is the tag text ("div",
+// "Foo.Bar") and Children hold the tag, the attributes and the element children,
+// with expression containers unwrapped and whitespace-only text dropped.
+func (b *ASTBuilder) buildJSXElement(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeJSXElement)
+	node.Location = b.getLocation(tsNode)
+
+	if tsNode.Type() == "jsx_self_closing_element" {
+		b.buildJSXTag(tsNode, node)
+		return node
+	}
+
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child == nil || b.isTrivia(child) {
+			continue
+		}
+		switch child.Type() {
+		case "jsx_opening_element":
+			b.buildJSXTag(child, node)
+		case "jsx_closing_element":
+		case "jsx_text":
+			if strings.TrimSpace(child.Content(b.source)) != "" {
+				node.AddChild(b.buildNode(child))
+			}
+		case "jsx_expression":
+			if expr := b.buildJSXExpression(child); expr != nil {
+				node.AddChild(expr)
+			}
+		default:
+			node.AddChild(b.buildNode(child))
+		}
+	}
+	if node.Name == "" {
+		node.Type = NodeJSXFragment
+	}
+
+	return node
+}
+
+// buildJSXTag adds the tag name and the attributes of an opening or self-closing
+// element to node
+func (b *ASTBuilder) buildJSXTag(tsNode *sitter.Node, node *Node) {
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child == nil || !child.IsNamed() || b.isTrivia(child) {
+			continue
+		}
+		switch child.Type() {
+		case "jsx_attribute":
+			node.AddChild(b.buildJSXAttribute(child))
+		case "jsx_expression":
+			// {...props}
+			attr := NewNode(NodeJSXAttribute)
+			attr.Location = b.getLocation(child)
+			attr.Kind = "spread"
+			if expr := b.buildJSXExpression(child); expr != nil {
+				attr.AddChild(expr)
+			}
+			node.AddChild(attr)
+		default:
+			if node.Name == "" {
+				node.Name = child.Content(b.source)
+				node.AddChild(b.buildNode(child))
+			}
+		}
+	}
+}
+
+// buildJSXAttribute builds a JSX attribute whose children are its value
+func (b *ASTBuilder) buildJSXAttribute(tsNode *sitter.Node) *Node {
+	node := NewNode(NodeJSXAttribute)
+	node.Location = b.getLocation(tsNode)
+
+	named := 0
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child == nil || !child.IsNamed() || b.isTrivia(child) {
+			continue
+		}
+		named++
+		if named == 1 {
+			node.Name = child.Content(b.source)
+			continue
+		}
+		if child.Type() == "jsx_expression" {
+			if expr := b.buildJSXExpression(child); expr != nil {
+				node.AddChild(expr)
+			}
+			continue
+		}
+		node.AddChild(b.buildNode(child))
+	}
+
+	return node
+}
+
+// buildJSXExpression returns the expression of a {...} container, or nil for an
+// empty one
+func (b *ASTBuilder) buildJSXExpression(tsNode *sitter.Node) *Node {
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child != nil && child.IsNamed() && !b.isTrivia(child) {
+			return b.buildNode(child)
+		}
+	}
+	return nil
+}
+
 // buildIfStatement builds an if statement node
 func (b *ASTBuilder) buildIfStatement(tsNode *sitter.Node) *Node {
 	node := NewNode(NodeIfStatement)
@@ -696,6 +1016,11 @@ func (b *ASTBuilder) buildCallExpression(tsNode *sitter.Node) *Node {
 		node.Callee = b.buildNode(funcNode)
 	}
 
+	// Extract type arguments: f<T>(x)
+	if typeArgs := b.getChildByFieldName(tsNode, "type_arguments"); typeArgs != nil {
+		node.TypeParameters = b.buildTypeParameters(typeArgs)
+	}
+
 	// Extract arguments
 	if argsNode := b.getChildByFieldName(tsNode, "arguments"); argsNode != nil {
 		for i := 0; i < int(argsNode.ChildCount()); i++ {
@@ -888,6 +1213,9 @@ func (b *ASTBuilder) buildIdentifier(tsNode *sitter.Node) *Node {
 	node := NewNode(NodeIdentifier)
 	node.Location = b.getLocation(tsNode)
 	node.Name = tsNode.Content(b.source)
+	if tsNode.Type() == "type_identifier" {
+		node.Kind = "type"
+	}
 	return node
 }
 
@@ -930,6 +1258,10 @@ func (b *ASTBuilder) buildImportStatement(tsNode *sitter.Node) *Node {
 		}
 
 		switch child.Type() {
+		case "type":
+			// TypeScript: import type { X } from 'module'
+			node.Kind = "type"
+
 		case "import_clause":
 			// Handle import clause (contains default import and/or named imports)
 			b.extractImportClause(child, node)
@@ -1023,8 +1355,15 @@ func (b *ASTBuilder) buildImportSpecifier(tsNode *sitter.Node) *Node {
 	identifiers := []*sitter.Node{}
 	for i := 0; i < int(tsNode.ChildCount()); i++ {
 		child := tsNode.Child(i)
-		if child != nil && child.Type() == "identifier" {
+		if child == nil {
+			continue
+		}
+		switch child.Type() {
+		case "identifier":
 			identifiers = append(identifiers, child)
+		case "type":
+			// TypeScript: import { type X } from 'module'
+			specNode.Kind = "type"
 		}
 	}
 
@@ -1062,6 +1401,9 @@ func (b *ASTBuilder) buildExportStatement(tsNode *sitter.Node) *Node {
 			hasDefault = true
 		case "*":
 			hasWildcard = true
+		case "type":
+			// TypeScript: export type { X }
+			node.Kind = "type"
 		case "export_clause":
 			// Handle: export { foo, bar } or export { foo as bar }
 			b.extractExportClause(child, node)
@@ -1109,8 +1451,15 @@ func (b *ASTBuilder) extractExportClause(clauseNode *sitter.Node, node *Node) {
 			identifiers := []*sitter.Node{}
 			for j := 0; j < int(child.ChildCount()); j++ {
 				grandchild := child.Child(j)
-				if grandchild != nil && grandchild.Type() == "identifier" {
+				if grandchild == nil {
+					continue
+				}
+				switch grandchild.Type() {
+				case "identifier":
 					identifiers = append(identifiers, grandchild)
+				case "type":
+					// TypeScript: export { type X }
+					specNode.Kind = "type"
 				}
 			}
 
@@ -1167,6 +1516,33 @@ func (b *ASTBuilder) buildGenericNode(tsNode *sitter.Node) *Node {
 	return node
 }
 
+// extractTypeSignature sets the generic type parameters and the return type of
+// functions, classes and type declarations
+func (b *ASTBuilder) extractTypeSignature(tsNode *sitter.Node, node *Node) {
+	if typeParams := b.getChildByFieldName(tsNode, "type_parameters"); typeParams != nil {
+		node.TypeParameters = b.buildTypeParameters(typeParams)
+	}
+	if returnType := b.getChildByFieldName(tsNode, "return_type"); returnType != nil {
+		node.TypeAnnotation = b.buildNode(returnType)
+	}
+}
+
+// buildTypeParameters builds the entries of a type_parameters or type_arguments node
+func (b *ASTBuilder) buildTypeParameters(tsNode *sitter.Node) []*Node {
+	var params []*Node
+
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child != nil && !b.isTrivia(child) && child.Type() != "<" && child.Type() != ">" && child.Type() != "," {
+			if paramNode := b.buildNode(child); paramNode != nil {
+				params = append(params, paramNode)
+			}
+		}
+	}
+
+	return params
+}
+
 // buildParameters builds parameter list from formal_parameters node
 func (b *ASTBuilder) buildParameters(tsNode *sitter.Node) []*Node {
 	var params []*Node
@@ -86,6 +86,14 @@ func (p *Parser) Close() {
 
 // ParseForLanguage automatically selects JavaScript or TypeScript parser based on file extension
 func ParseForLanguage(filename string, source []byte) (*Node, error) {
+	parser := parserForFile(filename)
+	defer parser.Close()
+
+	return parser.ParseFile(filename, source)
+}
+
+// parserForFile creates a JavaScript or TypeScript parser based on file extension
+func parserForFile(filename string) *Parser {
 	// Determine language from file extension
 	isTS := false
 	if len(filename) > 3 {
@@ -101,13 +109,8 @@ func ParseForLanguage(filename string, source []byte) (*Node, error) {
 		}
 	}
 
-	var parser *Parser
 	if isTS {
-		parser = NewTypeScriptParser()
-	} else {
-		parser = NewParser()
+		return NewTypeScriptParser()
 	}
-	defer parser.Close()
-
-	return parser.ParseFile(filename, source)
+	return NewParser()
 }
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -340,6 +341,52 @@ func TestParseForLanguage_MTS(t *testing.T) {
 	}
 }
 
+func TestParseJSX(t *testing.T) {
+	code := `const App = (props) => (
+  <Layout.Page title="home" {...props}>
+    {props.open && <Header />}
+    <>text</>
+  </Layout.Page>
+);`
+
+	parser := NewParser()
+	defer parser.Close()
+
+	ast, err := parser.ParseString(code)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var elements, attributes []*Node
+	fragments := 0
+	seen := make(map[*Node]bool) // the program holds its statements in Children and Body
+	ast.Walk(func(n *Node) bool {
+		if seen[n] {
+			return false
+		}
+		seen[n] = true
+		switch n.Type {
+		case NodeJSXElement:
+			elements = append(elements, n)
+		case NodeJSXAttribute:
+			attributes = append(attributes, n)
+		case NodeJSXFragment:
+			fragments++
+		}
+		return true
+	})
+
+	if len(elements) != 2 || elements[0].Name != "Layout.Page" || elements[1].Name != "Header" {
+		t.Fatalf("Expected the Layout.Page and Header elements, got %v", elements)
+	}
+	if fragments != 1 {
+		t.Errorf("Expected 1 fragment, got %d", fragments)
+	}
+	if len(attributes) != 2 || attributes[0].Name != "title" || attributes[1].Kind != "spread" {
+		t.Errorf("Expected a title and a spread attribute, got %v", attributes)
+	}
+}
+
 func TestParseWhileLoop(t *testing.T) {
 	code := `
 	let i = 0;
@@ -544,6 +591,65 @@ func TestParseClass(t *testing.T) {
 	}
 }
 
+func TestParseTypeScriptDeclarations(t *testing.T) {
+	code := `
+	interface Shape<T extends Base> extends Named { size: T }
+	type Pair = [Shape<number>, Shape<string>];
+	const enum Color { Red, Green = Red + 1, 'Blue' }
+	function area(s: Shape<number>): Result { return s.size; }
+	`
+
+	parser := NewTypeScriptParser()
+	defer parser.Close()
+
+	ast, err := parser.ParseString(code)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	declarations := make(map[NodeType]*Node)
+	typeRefs := make(map[string]bool)
+	ast.Walk(func(n *Node) bool {
+		switch n.Type {
+		case NodeInterfaceDeclaration, NodeTypeAlias, NodeEnumDeclaration:
+			declarations[n.Type] = n
+		case NodeIdentifier:
+			if n.Kind == "type" {
+				typeRefs[n.Name] = true
+			}
+		}
+		return true
+	})
+
+	if iface := declarations[NodeInterfaceDeclaration]; iface == nil || iface.Name != "Shape" || len(iface.TypeParameters) != 1 {
+		t.Errorf("Expected interface 'Shape' with one type parameter, got %v", iface)
+	}
+	if alias := declarations[NodeTypeAlias]; alias == nil || alias.Name != "Pair" || alias.TypeAnnotation == nil {
+		t.Errorf("Expected type alias 'Pair' with its aliased type, got %v", alias)
+	}
+	enum := declarations[NodeEnumDeclaration]
+	if enum == nil || enum.Name != "Color" || enum.Kind != "const" {
+		t.Fatalf("Expected const enum 'Color', got %v", enum)
+	}
+	var members []string
+	for _, member := range enum.Body {
+		members = append(members, member.Name)
+	}
+	if strings.Join(members, ",") != "Red,Green,Blue" {
+		t.Errorf("Expected members Red,Green,Blue, got %v", members)
+	}
+
+	// Heritage clauses, constraints and return types are walked as type references
+	for _, name := range []string{"Base", "Named", "Shape", "Result"} {
+		if !typeRefs[name] {
+			t.Errorf("Expected type reference %q", name)
+		}
+	}
+	if typeRefs["size"] {
+		t.Error("Property names are not type references")
+	}
+}
+
 func TestParseAsyncFunction(t *testing.T) {
 	code := `
 	async function fetchData() {
@@ -958,3 +1064,68 @@ func TestLocation_String(t *testing.T) {
 		t.Errorf("Expected 'src/index.js:42:10', got '%s'", str)
 	}
 }
+
+func TestParseComments(t *testing.T) {
+	code := "// first\nfunction f() {\n  /* inner */ return '// not a comment';\n}\n"
+
+	parser := NewParser()
+	defer parser.Close()
+
+	ast, err := parser.ParseString(code)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(ast.Comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(ast.Comments))
+	}
+	if c := ast.Comments[1]; c.Type != NodeComment || c.Raw != "/* inner */" || c.Location.StartLine != 3 {
+		t.Errorf("Unexpected comment %+v", c)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	code := `// comment
+const total = price * 2 + "x";`
+
+	tokens, err := Tokenize("example.js", []byte(code))
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+
+	expected := []struct {
+		value string
+		kind  TokenKind
+	}{
+		{"const", TokenOther},
+		{"total", TokenIdentifier},
+		{"=", TokenOther},
+		{"price", TokenIdentifier},
+		{"*", TokenOther},
+		{"2", TokenLiteral},
+		{"+", TokenOther},
+		{`"x"`, TokenLiteral},
+		{";", TokenOther},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %+v", len(expected), len(tokens), tokens)
+	}
+	for i, exp := range expected {
+		if tokens[i].Value != exp.value || tokens[i].Kind != exp.kind {
+			t.Errorf("Token %d: expected %q (kind %d), got %q (kind %d)", i, exp.value, exp.kind, tokens[i].Value, tokens[i].Kind)
+		}
+	}
+	if tokens[0].Location.StartLine != 2 {
+		t.Errorf("Expected first token on line 2, got %d", tokens[0].Location.StartLine)
+	}
+}
+
+func TestTokenizeTypeScript(t *testing.T) {
+	tokens, err := Tokenize("example.ts", []byte(`let n: number = 1;`))
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	if len(tokens) == 0 {
+		t.Fatal("Expected tokens for TypeScript source")
+	}
+}
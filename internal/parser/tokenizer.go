@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// TokenKind classifies a token for clone normalization
+type TokenKind int
+
+const (
+	// TokenOther covers keywords, operators and punctuation
+	TokenOther TokenKind = iota
+	// TokenIdentifier covers variable, property and type names
+	TokenIdentifier
+	// TokenLiteral covers string, template, number and regex literals
+	TokenLiteral
+)
+
+// Token represents a single lexical token of a source file
+type Token struct {
+	Type     string // tree-sitter node type (e.g. "identifier", "if", "(")
+	Value    string // Source text of the token
+	Kind     TokenKind
+	Location Location
+}
+
+// identifierTokenTypes lists tree-sitter leaf types that name something
+var identifierTokenTypes = map[string]bool{
+	"identifier":                            true,
+	"property_identifier":                   true,
+	"shorthand_property_identifier":         true,
+	"shorthand_property_identifier_pattern": true,
+	"private_property_identifier":           true,
+	"statement_identifier":                  true,
+	"type_identifier":                       true,
+}
+
+// literalTokenTypes lists tree-sitter types that are emitted as a single literal token,
+// even though the grammar gives them child nodes (e.g. string quotes and fragments)
+var literalTokenTypes = map[string]bool{
+	"string":          true,
+	"template_string": true,
+	"number":          true,
+	"regex":           true,
+}
+
+// Tokenize returns the token stream of a JavaScript/TypeScript file, skipping comments.
+// The grammar is selected from the file extension like ParseForLanguage.
+func Tokenize(filename string, source []byte) ([]Token, error) {
+	parser := parserForFile(filename)
+	defer parser.Close()
+
+	return parser.TokenizeFile(filename, source)
+}
+
+// TokenizeFile returns the token stream of the given source, skipping comments
+func (p *Parser) TokenizeFile(filename string, source []byte) ([]Token, error) {
+	tree, err := p.parser.ParseCtx(context.Background(), nil, source)
+	if tree == nil {
+		return nil, fmt.Errorf("failed to parse file %s: %v", filename, err)
+	}
+	defer tree.Close()
+
+	rootNode := tree.RootNode()
+	if rootNode == nil {
+		return nil, fmt.Errorf("no root node in parse tree for %s", filename)
+	}
+
+	var tokens []Token
+	collectTokens(rootNode, filename, source, &tokens)
+	return tokens, nil
+}
+
+// collectTokens appends the leaf tokens below tsNode in source order
+func collectTokens(tsNode *sitter.Node, filename string, source []byte, tokens *[]Token) {
+	nodeType := tsNode.Type()
+	if nodeType == "comment" || nodeType == "hash_bang_line" {
+		return
+	}
+
+	if tsNode.ChildCount() == 0 || literalTokenTypes[nodeType] {
+		value := tsNode.Content(source)
+		if value == "" {
+			return
+		}
+
+		kind := TokenOther
+		if identifierTokenTypes[nodeType] {
+			kind = TokenIdentifier
+		} else if literalTokenTypes[nodeType] {
+			kind = TokenLiteral
+		}
+
+		*tokens = append(*tokens, Token{
+			Type:  nodeType,
+			Value: value,
+			Kind:  kind,
+			Location: Location{
+				File:      filename,
+				StartLine: int(tsNode.StartPoint().Row) + 1,
+				StartCol:  int(tsNode.StartPoint().Column),
+				EndLine:   int(tsNode.EndPoint().Row) + 1,
+				EndCol:    int(tsNode.EndPoint().Column),
+			},
+		})
+		return
+	}
+
+	for i := 0; i < int(tsNode.ChildCount()); i++ {
+		child := tsNode.Child(i)
+		if child != nil {
+			collectTokens(child, filename, source, tokens)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/jsonrpc"
+)
+
+// maxBodyBytes limits request bodies, which carry posted sources
+const maxBodyBytes = 32 << 20
+
+// Handler returns the HTTP handler of the server:
+//
+//	GET  /v1/health         server state
+//	POST /v1/analyze        analyze a path or posted sources (AnalyzeParams)
+//	GET  /v1/graph          import graph, or a dependency query (kind, source, target, transitive, max_paths)
+//	GET  /v1/results        cached results
+//	GET  /v1/results/{id}   one cached result
+//	POST /rpc               JSON-RPC 2.0: analyze, graph, results, result, health
+//
+// Requests whose Host header is not a loopback address are rejected, so that web
+// pages cannot reach the server through DNS rebinding.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.Health())
+	})
+	mux.HandleFunc("POST /v1/analyze", func(w http.ResponseWriter, r *http.Request) {
+		var params AnalyzeParams
+		if err := decodeBody(r, &params); err != nil {
+			writeError(w, err)
+			return
+		}
+		s.respond(w)(s.Analyze(r.Context(), params))
+	})
+	mux.HandleFunc("GET /v1/graph", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := GraphParams{
+			Kind:   query.Get("kind"),
+			Source: query.Get("source"),
+			Target: query.Get("target"),
+		}
+		var err error
+		if v := query.Get("transitive"); v != "" {
+			if params.Transitive, err = strconv.ParseBool(v); err != nil {
+				writeError(w, domain.NewInvalidInputError("invalid transitive parameter", err))
+				return
+			}
+		}
+		if v := query.Get("max_paths"); v != "" {
+			if params.MaxPaths, err = strconv.Atoi(v); err != nil {
+				writeError(w, domain.NewInvalidInputError("invalid max_paths parameter", err))
+				return
+			}
+		}
+		s.respond(w)(s.Graph(params))
+	})
+	mux.HandleFunc("GET /v1/results", func(w http.ResponseWriter, r *http.Request) {
+		s.respond(w)(s.Results())
+	})
+	mux.HandleFunc("GET /v1/results/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.respond(w)(s.Result(r.PathValue("id")))
+	})
+	mux.HandleFunc("POST /rpc", s.serveRPC)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			writeError(w, domain.NewDomainError(errCodeForbidden, fmt.Sprintf("host %q is not a loopback address", r.Host), nil))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether the host of a Host header is localhost or a
+// loopback IP address
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// respond writes a result or its error
+func (s *Server) respond(w http.ResponseWriter) func(interface{}, error) {
+	return func(result interface{}, err error) {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return domain.NewInvalidInputError("invalid request body", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// httpError is the body of failed HTTP requests
+type httpError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var body httpError
+	body.Error.Code = errorCode(err)
+	body.Error.Message = err.Error()
+	writeJSON(w, httpStatus(body.Error.Code), body)
+}
+
+// httpStatus maps domain error codes to HTTP status codes
+func httpStatus(code string) int {
+	switch code {
+	case domain.ErrCodeInvalidInput, domain.ErrCodeUnsupportedFormat:
+		return http.StatusBadRequest
+	case domain.ErrCodeFileNotFound, errCodeNotFound:
+		return http.StatusNotFound
+	case errCodeForbidden:
+		return http.StatusForbidden
+	case "CANCELLED":
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// serveRPC handles one JSON-RPC 2.0 request; notifications get no content
+func (s *Server) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var req jsonrpc.Message
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		rpcErr := &jsonrpc.Error{Code: jsonrpc.CodeParseError, Message: err.Error()}
+		writeJSON(w, http.StatusOK, jsonrpc.NewResponse(json.RawMessage("null"), nil, rpcErr))
+		return
+	}
+
+	result, rpcErr := s.call(r.Context(), req)
+	if req.IsNotification() {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, jsonrpc.NewResponse(*req.ID, result, rpcErr))
+}
+
+// call dispatches a JSON-RPC method to the server
+func (s *Server) call(ctx context.Context, req jsonrpc.Message) (interface{}, *jsonrpc.Error) {
+	if req.JSONRPC != jsonrpc.Version || req.Method == "" {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidRequest, Message: "not a JSON-RPC 2.0 request"}
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch req.Method {
+	case "analyze":
+		var params AnalyzeParams
+		if rpcErr := jsonrpc.UnmarshalParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		result, err = s.Analyze(ctx, params)
+	case "graph":
+		var params GraphParams
+		if rpcErr := jsonrpc.UnmarshalParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		result, err = s.Graph(params)
+	case "results":
+		result, err = s.Results()
+	case "result":
+		var params struct {
+			ID string `json:"id"`
+		}
+		if rpcErr := jsonrpc.UnmarshalParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		result, err = s.Result(params.ID)
+	case "health":
+		result = s.Health()
+	default:
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+
+	if err != nil {
+		code := jsonrpc.CodeServerError
+		if errorCode(err) == domain.ErrCodeInvalidInput {
+			code = jsonrpc.CodeInvalidParams
+		}
+		return nil, &jsonrpc.Error{Code: code, Message: err.Error(), Data: map[string]string{"code": errorCode(err)}}
+	}
+	return result, nil
+}
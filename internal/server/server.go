@@ -0,0 +1,562 @@
+// Package server implements `jscan serve`, a long-running analysis server for one
+// project root. It keeps the file contents and the import graph in memory, refreshes
+// them when files change on disk and caches analysis results, so that clients avoid
+// paying process start-up, disk reads and repeated analyses per request. The import
+// graph and the analyses reuse the ASTs cached per file, so that requests on
+// unchanged files do no parsing. The same operations are exposed as HTTP endpoints
+// and as JSON-RPC 2.0 methods.
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ludo-technologies/jscan/app"
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/parser"
+	"github.com/ludo-technologies/jscan/internal/version"
+	"github.com/ludo-technologies/jscan/pkg/jscan"
+	"github.com/ludo-technologies/jscan/service"
+)
+
+// maxResults is the number of cached analysis results; the least recently used one
+// is evicted beyond it
+const maxResults = 64
+
+// Server analyzes the JavaScript/TypeScript files under a root directory
+type Server struct {
+	root       string
+	configPath string
+	cfg        *config.Config
+	workspace  *workspace
+
+	mu      sync.Mutex
+	results map[string]*result // key -> result
+	byID    map[string]*result
+	nextID  int
+	clock   uint64 // advanced on every cache access, for LRU eviction
+}
+
+// New creates a server for root. configPath is an optional config file; otherwise
+// the one of root is discovered.
+func New(root, configPath string) (*Server, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid root: %s", root), err)
+	}
+	if configPath == "" {
+		configPath = config.DiscoverConfigFile(abs)
+	}
+	cfg, err := config.LoadConfigWithTarget(configPath, abs)
+	if err != nil {
+		return nil, domain.NewConfigError("failed to load configuration", err)
+	}
+	return &Server{
+		root:       abs,
+		configPath: configPath,
+		cfg:        cfg,
+		workspace:  newWorkspace(abs, cfg.Analysis.ExcludePatterns),
+		results:    make(map[string]*result),
+		byID:       make(map[string]*result),
+	}, nil
+}
+
+// Root returns the absolute project root
+func (s *Server) Root() string {
+	return s.root
+}
+
+// ConfigPath returns the config file of the server, or "" when the defaults are used
+func (s *Server) ConfigPath() string {
+	return s.configPath
+}
+
+// Sources returns the cached contents of the files under path, which must be
+// inside the root, sorted by path
+func (s *Server) Sources(path string) ([]jscan.Source, error) {
+	target, err := s.Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Refresh(); err != nil {
+		return nil, domain.NewAnalysisError("failed to refresh workspace", err)
+	}
+	snap := s.workspace.snapshot(target)
+	sources := make([]jscan.Source, 0, len(snap.files))
+	for _, file := range snap.files {
+		sources = append(sources, jscan.Source{Path: file, Content: snap.contents[file]})
+	}
+	return sources, nil
+}
+
+// Refresh rereads the files that changed on disk and rebuilds the import graph
+func (s *Server) Refresh() error {
+	_, err := s.workspace.refresh()
+	return err
+}
+
+// Watch refreshes the workspace every interval until ctx is done, keeping the
+// parsed files and the import graph warm between requests
+func (s *Server) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.Refresh()
+		}
+	}
+}
+
+// SourceParams is an in-memory file posted for analysis
+type SourceParams struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// AnalyzeParams selects what to analyze: a file or directory under the root, or
+// posted sources
+type AnalyzeParams struct {
+	Path     string         `json:"path,omitempty"`
+	Sources  []SourceParams `json:"sources,omitempty"`
+	Analyses []string       `json:"analyses,omitempty"`
+}
+
+// AnalyzeResult is an analysis report with its cache entry. Report has the format
+// of `jscan analyze --json`.
+type AnalyzeResult struct {
+	ID         string            `json:"id"`
+	Target     string            `json:"target,omitempty"`
+	Analyses   []string          `json:"analyses"`
+	Files      int               `json:"files"`
+	Cached     bool              `json:"cached"`
+	AnalyzedAt string            `json:"analyzed_at"`
+	Errors     map[string]string `json:"errors,omitempty"`
+	Report     json.RawMessage   `json:"report"`
+}
+
+// ResultInfo describes a cached result; Stale results are recomputed on the next
+// analysis of their target
+type ResultInfo struct {
+	ID         string   `json:"id"`
+	Target     string   `json:"target,omitempty"`
+	Analyses   []string `json:"analyses"`
+	Files      int      `json:"files"`
+	AnalyzedAt string   `json:"analyzed_at"`
+	Stale      bool     `json:"stale"`
+}
+
+// GraphParams is an optional dependency query; without a kind the whole graph is
+// returned
+type GraphParams struct {
+	Kind       string `json:"kind,omitempty"`
+	Source     string `json:"source,omitempty"`
+	Target     string `json:"target,omitempty"`
+	Transitive bool   `json:"transitive,omitempty"`
+	MaxPaths   int    `json:"max_paths,omitempty"`
+}
+
+// GraphResult is the import graph of the root, or the answer to a query on it
+type GraphResult struct {
+	Generation uint64                             `json:"generation"`
+	Modules    int                                `json:"modules"`
+	Graph      *domain.DependencyGraph            `json:"graph,omitempty"`
+	Cycles     *domain.CircularDependencyAnalysis `json:"cycles,omitempty"`
+	Query      *domain.DependencyQueryResult      `json:"query,omitempty"`
+}
+
+// HealthResult describes the state of the server
+type HealthResult struct {
+	Status      string `json:"status"`
+	Version     string `json:"version"`
+	Root        string `json:"root"`
+	Config      string `json:"config,omitempty"`
+	Files       int    `json:"files"`
+	Generation  uint64 `json:"generation"`
+	RefreshedAt string `json:"refreshed_at,omitempty"`
+	Results     int    `json:"results"`
+}
+
+// result is a cached analysis
+type result struct {
+	AnalyzeResult
+	key    string
+	target string
+	files  []string
+	// used is the clock value of the last access
+	used uint64
+	// version is the generation of the newest analyzed file; sources results never go stale
+	version uint64
+}
+
+// Analyze analyzes a path under the root or posted sources, returning the cached
+// result when none of the analyzed files changed since
+func (s *Server) Analyze(ctx context.Context, params AnalyzeParams) (*AnalyzeResult, error) {
+	analyses, err := parseAnalyses(params.Analyses)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case params.Path != "" && len(params.Sources) > 0:
+		return nil, domain.NewInvalidInputError("path and sources are mutually exclusive", nil)
+	case len(params.Sources) > 0:
+		return s.analyzeSources(ctx, params.Sources, analyses)
+	case params.Path != "":
+		return s.analyzePath(ctx, params.Path, analyses)
+	default:
+		return nil, domain.NewInvalidInputError("either path or sources is required", nil)
+	}
+}
+
+func (s *Server) analyzePath(ctx context.Context, path string, analyses []jscan.Analysis) (*AnalyzeResult, error) {
+	target, err := s.Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Refresh(); err != nil {
+		return nil, domain.NewAnalysisError("failed to refresh workspace", err)
+	}
+	snap := s.workspace.snapshot(target)
+	if len(snap.files) == 0 {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("no JavaScript/TypeScript files found in %s", path), nil)
+	}
+
+	key := "path:" + target + "|" + joinAnalyses(analyses)
+	if cached := s.cached(key, snap); cached != nil {
+		return cached, nil
+	}
+
+	res, err := s.run(ctx, snap.files, snap.contents, snap.asts, analyses)
+	if err != nil {
+		return nil, err
+	}
+	res.target = target
+	res.files = snap.files
+	res.version = snap.version
+	res.Target = s.displayPath(target)
+	return s.store(key, res), nil
+}
+
+func (s *Server) analyzeSources(ctx context.Context, params []SourceParams, analyses []jscan.Analysis) (*AnalyzeResult, error) {
+	// Identical posted sources hit the cache
+	hash := sha256.New()
+	helper := app.NewFileHelper()
+	contents := make(map[string][]byte, len(params))
+	files := make([]string, 0, len(params))
+	for _, source := range params {
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(source.Path), source.Path, len(source.Content), source.Content)
+		file, err := helper.AddSource(contents, source.Path, []byte(source.Content))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	key := "sources:" + hex.EncodeToString(hash.Sum(nil)) + "|" + joinAnalyses(analyses)
+	if cached := s.cached(key, nil); cached != nil {
+		return cached, nil
+	}
+
+	res, err := s.run(ctx, files, contents, nil, analyses)
+	if err != nil {
+		return nil, err
+	}
+	return s.store(key, res), nil
+}
+
+// run analyzes files, reading them from contents and reusing the ASTs of the ones in
+// asts, and renders the JSON report
+func (s *Server) run(ctx context.Context, files []string, contents map[string][]byte, asts map[string]*parser.Node,
+	analyses []jscan.Analysis) (*result, error) {
+	start := time.Now()
+	names := analysisNames(analyses)
+	r := app.RunAnalyses(ctx, s.cfg, app.AnalysisRequest{Analyses: names, Files: files, Sources: contents, ASTs: asts})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(r.Errors) == len(names) {
+		return nil, domain.NewAnalysisError("all analyses failed", r.Errors[names[0]])
+	}
+
+	var buf bytes.Buffer
+	formatter := service.NewOutputFormatterWithScoring(service.ScoringFromConfig(&s.cfg.Scoring))
+	formatter.SetTypeSafety(r.TypeSafety)
+	formatter.SetReact(r.React)
+	if err := formatter.WriteAnalyze(r.Complexity, r.DeadCode, r.Clone, r.CBO, r.Dependencies,
+		domain.OutputFormatJSON, &buf, time.Since(start)); err != nil {
+		return nil, domain.NewOutputError("failed to render report", err)
+	}
+
+	res := &result{AnalyzeResult: AnalyzeResult{
+		Analyses:   names,
+		Files:      len(files),
+		AnalyzedAt: time.Now().Format(time.RFC3339),
+		Report:     json.RawMessage(bytes.TrimSpace(buf.Bytes())),
+	}}
+	for analysis, err := range r.Errors {
+		if res.Errors == nil {
+			res.Errors = make(map[string]string)
+		}
+		res.Errors[analysis] = err.Error()
+	}
+	return res, nil
+}
+
+// cached returns the result stored under key if it is still valid for snap
+func (s *Server) cached(key string, snap *snapshot) *AnalyzeResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.results[key]
+	if !ok || (snap != nil && !res.validFor(snap)) {
+		return nil
+	}
+	s.clock++
+	res.used = s.clock
+	hit := res.AnalyzeResult
+	hit.Cached = true
+	return &hit
+}
+
+// store caches res under key, keeping the ID of the result it replaces and evicting
+// the least recently used result when the cache is full
+func (s *Server) store(key string, res *result) *AnalyzeResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if previous, ok := s.results[key]; ok {
+		res.ID = previous.ID
+	} else {
+		s.nextID++
+		res.ID = strconv.Itoa(s.nextID)
+	}
+	s.clock++
+	res.key = key
+	res.used = s.clock
+	s.results[key] = res
+	s.byID[res.ID] = res
+
+	for len(s.results) > maxResults {
+		var oldest *result
+		for _, cached := range s.results {
+			if oldest == nil || cached.used < oldest.used {
+				oldest = cached
+			}
+		}
+		delete(s.results, oldest.key)
+		delete(s.byID, oldest.ID)
+	}
+
+	out := res.AnalyzeResult
+	return &out
+}
+
+func (r *result) validFor(snap *snapshot) bool {
+	if r.version != snap.version || len(r.files) != len(snap.files) {
+		return false
+	}
+	for i := range r.files {
+		if r.files[i] != snap.files[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Result returns a cached result by ID
+func (s *Server) Result(id string) (*AnalyzeResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.byID[id]
+	if !ok {
+		return nil, domain.NewDomainError(errCodeNotFound, fmt.Sprintf("no cached result %q", id), nil)
+	}
+	s.clock++
+	res.used = s.clock
+	out := res.AnalyzeResult
+	out.Cached = true
+	return &out, nil
+}
+
+// Results lists the cached results ordered by ID
+func (s *Server) Results() ([]ResultInfo, error) {
+	if err := s.Refresh(); err != nil {
+		return nil, domain.NewAnalysisError("failed to refresh workspace", err)
+	}
+
+	s.mu.Lock()
+	entries := make([]*result, 0, len(s.byID))
+	for _, res := range s.byID {
+		entries = append(entries, res)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		x, _ := strconv.Atoi(entries[i].ID)
+		y, _ := strconv.Atoi(entries[j].ID)
+		return x < y
+	})
+	infos := make([]ResultInfo, 0, len(entries))
+	for _, res := range entries {
+		info := ResultInfo{
+			ID:         res.ID,
+			Target:     res.Target,
+			Analyses:   res.Analyses,
+			Files:      res.AnalyzeResult.Files,
+			AnalyzedAt: res.AnalyzedAt,
+		}
+		if res.target != "" {
+			info.Stale = !res.validFor(s.workspace.snapshot(res.target))
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Graph returns the import graph of the root with its cycles, or evaluates a
+// dependency query on it
+func (s *Server) Graph(params GraphParams) (*GraphResult, error) {
+	if err := s.Refresh(); err != nil {
+		return nil, domain.NewAnalysisError("failed to refresh workspace", err)
+	}
+	graph, cycles, generation := s.workspace.graphState()
+	res := &GraphResult{Generation: generation, Modules: len(graph.Nodes)}
+	if params.Kind == "" {
+		res.Graph = graph
+		res.Cycles = cycles
+		return res, nil
+	}
+
+	query := domain.DependencyQuery{
+		Kind:       domain.DependencyQueryKind(params.Kind),
+		Source:     params.Source,
+		Target:     params.Target,
+		Transitive: params.Transitive,
+		MaxPaths:   params.MaxPaths,
+	}
+	queryResult, err := analyzer.NewDependencyQueryEngine(graph).Evaluate(query)
+	if err != nil {
+		return nil, err
+	}
+	res.Query = queryResult
+	return res, nil
+}
+
+// Health describes the server and its cache
+func (s *Server) Health() *HealthResult {
+	files, generation, refreshed := s.workspace.stats()
+	s.mu.Lock()
+	results := len(s.byID)
+	s.mu.Unlock()
+
+	health := &HealthResult{
+		Status:     "ok",
+		Version:    version.GetVersion(),
+		Root:       s.root,
+		Config:     s.configPath,
+		Files:      files,
+		Generation: generation,
+		Results:    results,
+	}
+	if !refreshed.IsZero() {
+		health.RefreshedAt = refreshed.Format(time.RFC3339)
+	}
+	return health
+}
+
+// Resolve returns the absolute path of a file or directory, which must be inside
+// the root: the server does not read files elsewhere
+func (s *Server) Resolve(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.root, path)
+	}
+	path = filepath.Clean(path)
+	if !within(s.root, path) {
+		return "", domain.NewInvalidInputError(fmt.Sprintf("path %s is outside the served root %s", path, s.root), nil)
+	}
+	return path, nil
+}
+
+// displayPath returns path relative to the root
+func (s *Server) displayPath(path string) string {
+	if rel, err := filepath.Rel(s.root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func parseAnalyses(names []string) ([]jscan.Analysis, error) {
+	if len(names) == 0 {
+		names = analysisNames(jscan.AllAnalyses())
+	}
+	seen := make(map[jscan.Analysis]bool)
+	var analyses []jscan.Analysis
+	for _, name := range names {
+		analysis := jscan.Analysis(strings.TrimSpace(name))
+		if !isAnalysis(analysis) {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("unknown analysis %q", name), nil)
+		}
+		if !seen[analysis] {
+			seen[analysis] = true
+			analyses = append(analyses, analysis)
+		}
+	}
+	// The cache key does not depend on the order of the analyses
+	sort.Slice(analyses, func(i, j int) bool { return analyses[i] < analyses[j] })
+	return analyses, nil
+}
+
+func isAnalysis(analysis jscan.Analysis) bool {
+	for _, known := range jscan.AllAnalyses() {
+		if analysis == known {
+			return true
+		}
+	}
+	return false
+}
+
+func analysisNames(analyses []jscan.Analysis) []string {
+	names := make([]string, len(analyses))
+	for i, analysis := range analyses {
+		names[i] = string(analysis)
+	}
+	return names
+}
+
+func joinAnalyses(analyses []jscan.Analysis) string {
+	return strings.Join(analysisNames(analyses), ",")
+}
+
+// Domain error codes of the server
+const (
+	// errCodeNotFound is the code of unknown cached results
+	errCodeNotFound = "NOT_FOUND"
+
+	// errCodeForbidden is the code of requests addressed to a non-loopback host
+	errCodeForbidden = "FORBIDDEN"
+)
+
+// errorCode returns the domain error code of err
+func errorCode(err error) string {
+	var domainErr domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "CANCELLED"
+	}
+	return domain.ErrCodeAnalysisError
+}
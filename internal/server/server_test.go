@@ -0,0 +1,401 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/internal/jsonrpc"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+const (
+	serverFileA = `import { b } from './b.js';
+
+export function a(x) {
+  if (x) {
+    return b();
+  }
+  return 0;
+}
+`
+	serverFileB = `import { a } from './a.js';
+
+export function b() {
+  return a(1);
+}
+`
+)
+
+func newTestServer(t *testing.T) (*Server, string, *httptest.Server) {
+	t.Helper()
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "src", "a.js"), serverFileA)
+	writeTestFile(t, filepath.Join(root, "src", "b.js"), serverFileB)
+
+	srv, err := New(root, "")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := srv.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	httpServer := httptest.NewServer(srv.Handler())
+	t.Cleanup(httpServer.Close)
+	return srv, root, httpServer
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// do sends a request and decodes the JSON response into v
+func do(t *testing.T, method, url, body string, v interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("failed to decode %s %s response: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestAnalyzePath_Cache(t *testing.T) {
+	_, root, httpServer := newTestServer(t)
+	url := httpServer.URL + "/v1/analyze"
+	body := `{"path": "src", "analyses": ["complexity", "deps"]}`
+
+	var first AnalyzeResult
+	if status := do(t, "POST", url, body, &first); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if first.Cached || first.Files != 2 || first.Target != "src" {
+		t.Fatalf("unexpected first result %+v", first)
+	}
+	var report struct {
+		Complexity struct {
+			Functions []struct {
+				Name string `json:"name"`
+			} `json:"functions"`
+		} `json:"complexity"`
+		Deps struct {
+			Analysis struct {
+				CircularDependencies struct {
+					HasCircularDependencies bool
+				}
+			} `json:"analysis"`
+		} `json:"deps"`
+		Summary json.RawMessage `json:"summary"`
+	}
+	if err := json.Unmarshal(first.Report, &report); err != nil {
+		t.Fatalf("report is not the analyze JSON: %v", err)
+	}
+	if len(report.Complexity.Functions) != 2 || !report.Deps.Analysis.CircularDependencies.HasCircularDependencies {
+		t.Errorf("unexpected report %s", first.Report)
+	}
+
+	// The same request, with the analyses in another order, is served from the cache
+	var second AnalyzeResult
+	do(t, "POST", url, `{"path": "src/", "analyses": ["deps", "complexity"]}`, &second)
+	if !second.Cached || second.ID != first.ID {
+		t.Errorf("expected cached result %s, got %+v", first.ID, second)
+	}
+
+	// A changed file invalidates the result
+	writeTestFile(t, filepath.Join(root, "src", "b.js"), serverFileB+"\nexport function c() { return 3; }\n")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(root, "src", "b.js"), future, future); err != nil {
+		t.Fatal(err)
+	}
+	var results []ResultInfo
+	do(t, "GET", httpServer.URL+"/v1/results", "", &results)
+	if len(results) != 1 || !results[0].Stale {
+		t.Errorf("expected one stale result, got %+v", results)
+	}
+
+	var third AnalyzeResult
+	do(t, "POST", url, body, &third)
+	if third.Cached || third.ID != first.ID {
+		t.Errorf("expected a recomputed result with ID %s, got %+v", first.ID, third)
+	}
+	if err := json.Unmarshal(third.Report, &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Complexity.Functions) != 3 {
+		t.Errorf("expected the new function to be analyzed, got %d functions", len(report.Complexity.Functions))
+	}
+
+	var fetched AnalyzeResult
+	if status := do(t, "GET", httpServer.URL+"/v1/results/"+first.ID, "", &fetched); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if !bytes.Equal(fetched.Report, third.Report) {
+		t.Error("expected the fetched result to be the latest report")
+	}
+}
+
+func TestAnalyzePath_CachedASTs(t *testing.T) {
+	srv, root, _ := newTestServer(t)
+
+	// Replace the cached AST of a.js: the analyses must use it instead of parsing
+	ast, err := parser.ParseForLanguage("a.js", []byte("function cached() {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.workspace.mu.Lock()
+	srv.workspace.files[filepath.Join(root, "src", "a.js")].ast = ast
+	srv.workspace.mu.Unlock()
+
+	res, err := srv.Analyze(context.Background(), AnalyzeParams{Path: "src/a.js", Analyses: []string{"complexity"}})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	var report struct {
+		Complexity struct {
+			Functions []struct {
+				Name string `json:"name"`
+			} `json:"functions"`
+		} `json:"complexity"`
+	}
+	if err := json.Unmarshal(res.Report, &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Complexity.Functions) != 1 || report.Complexity.Functions[0].Name != "cached" {
+		t.Errorf("Expected the function of the cached AST, got %s", res.Report)
+	}
+}
+
+func TestAnalyzeSources(t *testing.T) {
+	_, _, httpServer := newTestServer(t)
+	body := `{"sources": [{"path": "x.ts", "content": "export const f = (a: number) => { if (a) { return 1; } return 2; };"}], "analyses": ["complexity"]}`
+
+	var res AnalyzeResult
+	if status := do(t, "POST", httpServer.URL+"/v1/analyze", body, &res); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if res.Cached || res.Files != 1 || res.Target != "" {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	var again AnalyzeResult
+	do(t, "POST", httpServer.URL+"/v1/analyze", body, &again)
+	if !again.Cached || again.ID != res.ID {
+		t.Errorf("expected identical sources to hit the cache, got %+v", again)
+	}
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	_, _, httpServer := newTestServer(t)
+	url := httpServer.URL + "/v1/analyze"
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"outside root", `{"path": "/etc"}`, http.StatusBadRequest},
+		{"parent directory", `{"path": "../"}`, http.StatusBadRequest},
+		{"nothing to analyze", `{}`, http.StatusBadRequest},
+		{"unknown analysis", `{"path": "src", "analyses": ["lint"]}`, http.StatusBadRequest},
+		{"unknown field", `{"paths": ["src"]}`, http.StatusBadRequest},
+		{"both inputs", `{"path": "src", "sources": [{"path": "a.js", "content": ""}]}`, http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		var body httpError
+		if status := do(t, "POST", url, tc.body, &body); status != tc.status {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.status, status)
+		}
+		if body.Error.Code == "" || body.Error.Message == "" {
+			t.Errorf("%s: expected an error body, got %+v", tc.name, body)
+		}
+	}
+
+	if status := do(t, "GET", httpServer.URL+"/v1/results/42", "", nil); status != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown result, got %d", status)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	_, root, httpServer := newTestServer(t)
+
+	var graph GraphResult
+	do(t, "GET", httpServer.URL+"/v1/graph", "", &graph)
+	if graph.Modules != 2 || graph.Graph == nil || graph.Cycles == nil || !graph.Cycles.HasCircularDependencies {
+		t.Errorf("expected 2 modules in a cycle, got %+v", graph)
+	}
+
+	var query GraphResult
+	do(t, "GET", httpServer.URL+"/v1/graph?kind=dependents_of&source=src/a.js", "", &query)
+	if query.Query == nil || len(query.Query.Edges) != 1 || query.Query.Edges[0].From != "src/b.js" {
+		t.Errorf("expected b.js to depend on a.js, got %+v", query.Query)
+	}
+	if query.Graph != nil {
+		t.Error("expected the graph to be omitted from query results")
+	}
+
+	// New files are picked up on the next request
+	writeTestFile(t, filepath.Join(root, "src", "c.js"), "import { a } from './a.js';\nexport const c = a;\n")
+	do(t, "GET", httpServer.URL+"/v1/graph?kind=dependents_of&source=src/a.js", "", &query)
+	if len(query.Query.Edges) != 2 || query.Generation != graph.Generation+1 {
+		t.Errorf("expected c.js in a new generation, got %+v", query)
+	}
+
+	var body httpError
+	if status := do(t, "GET", httpServer.URL+"/v1/graph?kind=sideways&source=src/a.js", "", &body); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown query kind, got %d", status)
+	}
+}
+
+func TestRPC(t *testing.T) {
+	_, _, httpServer := newTestServer(t)
+	url := httpServer.URL + "/rpc"
+
+	var resp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpc.Error  `json:"error"`
+	}
+	do(t, "POST", url, `{"jsonrpc": "2.0", "id": 1, "method": "analyze", "params": {"path": "src", "analyses": ["complexity"]}}`, &resp)
+	if resp.ID != 1 || resp.Error != nil {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	var res AnalyzeResult
+	if err := json.Unmarshal(resp.Result, &res); err != nil || res.Files != 2 {
+		t.Errorf("unexpected analyze result %s", resp.Result)
+	}
+
+	resp.Result, resp.Error = nil, nil
+	do(t, "POST", url, `{"jsonrpc": "2.0", "id": 2, "method": "result", "params": {"id": "`+res.ID+`"}}`, &resp)
+	if resp.Error != nil || !strings.Contains(string(resp.Result), `"cached":true`) {
+		t.Errorf("expected the cached result, got %+v", resp)
+	}
+
+	resp.Result, resp.Error = nil, nil
+	do(t, "POST", url, `{"jsonrpc": "2.0", "id": 3, "method": "graph", "params": {"kind": "dependencies_of", "source": "src/a.js"}}`, &resp)
+	if resp.Error != nil || !strings.Contains(string(resp.Result), `"src/b.js"`) {
+		t.Errorf("expected a.js to depend on b.js, got %+v", resp)
+	}
+
+	errorCases := []struct {
+		body string
+		code int
+	}{
+		{`{"jsonrpc": "2.0", "id": 4, "method": "lint"}`, jsonrpc.CodeMethodNotFound},
+		{`{"jsonrpc": "2.0", "id": 5, "method": "analyze", "params": {"path": "/etc"}}`, jsonrpc.CodeInvalidParams},
+		{`{"jsonrpc": "2.0", "id": 6, "method": "analyze", "params": []}`, jsonrpc.CodeInvalidParams},
+		{`{"id": 7, "method": "health"}`, jsonrpc.CodeInvalidRequest},
+		{`{"jsonrpc": `, jsonrpc.CodeParseError},
+	}
+	for _, tc := range errorCases {
+		resp.Result, resp.Error = nil, nil
+		do(t, "POST", url, tc.body, &resp)
+		if resp.Error == nil || resp.Error.Code != tc.code {
+			t.Errorf("%s: expected error %d, got %+v", tc.body, tc.code, resp.Error)
+		}
+	}
+
+	// Notifications get no response
+	if status := do(t, "POST", url, `{"jsonrpc": "2.0", "method": "health"}`, nil); status != http.StatusNoContent {
+		t.Errorf("expected 204 for a notification, got %d", status)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	_, root, httpServer := newTestServer(t)
+
+	var health HealthResult
+	do(t, "GET", httpServer.URL+"/v1/health", "", &health)
+	if health.Status != "ok" || health.Files != 2 || health.Generation != 1 || health.Root != root {
+		t.Errorf("unexpected health %+v", health)
+	}
+}
+
+func TestResultCacheEviction(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	first := srv.store("first", &result{})
+	for i := 0; i < maxResults; i++ {
+		if i == maxResults/2 {
+			// A lookup keeps the first result recently used
+			if _, err := srv.Result(first.ID); err != nil {
+				t.Fatalf("expected result %s to be cached: %v", first.ID, err)
+			}
+		}
+		srv.store(fmt.Sprintf("key-%d", i), &result{})
+	}
+
+	if len(srv.results) != maxResults || len(srv.byID) != maxResults {
+		t.Fatalf("expected %d cached results, got %d/%d", maxResults, len(srv.results), len(srv.byID))
+	}
+	if _, err := srv.Result(first.ID); err != nil {
+		t.Errorf("expected the recently used result to be kept: %v", err)
+	}
+	if _, ok := srv.results["key-0"]; ok {
+		t.Error("expected the least recently used result to be evicted")
+	}
+}
+
+func TestHandler_RejectsNonLoopbackHost(t *testing.T) {
+	_, _, httpServer := newTestServer(t)
+
+	req, err := http.NewRequest("GET", httpServer.URL+"/v1/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "attacker.example:7878"
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for a rebound host, got %d", resp.StatusCode)
+	}
+
+	for _, host := range []string{"localhost:7878", "127.0.0.1", "[::1]:7878"} {
+		if !isLoopbackHost(host) {
+			t.Errorf("expected %s to be accepted", host)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	root := filepath.Join("/", "project")
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{root, true},
+		{filepath.Join(root, "src", "a.js"), true},
+		{filepath.Join(root, "..folder", "a.js"), true},
+		{filepath.Join("/", "projects"), false},
+		{filepath.Join("/", "etc"), false},
+	}
+	for _, tc := range tests {
+		if got := within(root, tc.path); got != tc.want {
+			t.Errorf("within(%s, %s) = %v, want %v", root, tc.path, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ludo-technologies/jscan/app"
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// cachedFile is a file of the workspace with its parsed AST, reused while its
+// modification time and size are unchanged. ASTs are shared by the import graph
+// and the analyses, which only read them.
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	content []byte
+	ast     *parser.Node
+
+	// changed is the generation in which the file was last read
+	changed uint64
+}
+
+// workspace keeps the files of the root directory parsed in memory together with
+// their import graph. refresh rereads the files that changed on disk and rebuilds
+// the graph when any did.
+type workspace struct {
+	root    string
+	exclude []string
+
+	mu         sync.Mutex
+	files      map[string]*cachedFile
+	generation uint64
+	graph      *domain.DependencyGraph
+	cycles     *domain.CircularDependencyAnalysis
+	refreshed  time.Time
+}
+
+func newWorkspace(root string, exclude []string) *workspace {
+	return &workspace{
+		root:    root,
+		exclude: exclude,
+		files:   make(map[string]*cachedFile),
+		graph:   domain.NewDependencyGraph(),
+	}
+}
+
+// refresh brings the cache up to date with the files on disk and reports whether
+// anything changed
+func (w *workspace) refresh() (bool, error) {
+	paths, err := app.NewFileHelper().CollectJSFiles([]string{w.root}, true, nil, w.exclude)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next := w.generation + 1
+	changed := false
+	live := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		live[path] = true
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if cached, ok := w.files[path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// Files that fail to parse are kept without an AST; the analyses report them
+		ast, _ := parser.ParseForLanguage(path, content)
+		w.files[path] = &cachedFile{modTime: info.ModTime(), size: info.Size(), content: content, ast: ast, changed: next}
+		changed = true
+	}
+	for path := range w.files {
+		if !live[path] {
+			delete(w.files, path)
+			changed = true
+		}
+	}
+
+	w.refreshed = time.Now()
+	if !changed {
+		return false, nil
+	}
+	w.generation = next
+	w.rebuildGraph()
+	return true, nil
+}
+
+// rebuildGraph builds the import graph from the cached ASTs; the caller holds mu
+func (w *workspace) rebuildGraph() {
+	asts := make(map[string]*parser.Node, len(w.files))
+	for path, file := range w.files {
+		if file.ast != nil {
+			asts[path] = file.ast
+		}
+	}
+
+	builderConfig := analyzer.DefaultDependencyGraphBuilderConfig()
+	builderConfig.ProjectRoot = w.root
+	graph, err := analyzer.NewDependencyGraphBuilder(builderConfig).BuildGraphFromASTs(asts)
+	if err != nil {
+		w.graph = domain.NewDependencyGraph()
+		w.cycles = nil
+		return
+	}
+	w.graph = graph
+	w.cycles = analyzer.NewCircularDependencyDetector().DetectCycles(graph)
+}
+
+// snapshot is the state of the files under a path at one point in time
+type snapshot struct {
+	files    []string
+	contents map[string][]byte
+
+	// asts holds the files that parsed
+	asts map[string]*parser.Node
+
+	// version is the latest generation in which one of the files changed
+	version uint64
+}
+
+// snapshot returns the cached files under target, a file or directory inside root
+func (w *workspace) snapshot(target string) *snapshot {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s := &snapshot{contents: make(map[string][]byte), asts: make(map[string]*parser.Node)}
+	for path, file := range w.files {
+		if !within(target, path) {
+			continue
+		}
+		s.files = append(s.files, path)
+		s.contents[path] = file.content
+		if file.ast != nil {
+			s.asts[path] = file.ast
+		}
+		s.version = max(s.version, file.changed)
+	}
+	sort.Strings(s.files)
+	return s
+}
+
+// graphState returns the current import graph, its cycles and generation
+func (w *workspace) graphState() (*domain.DependencyGraph, *domain.CircularDependencyAnalysis, uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.graph, w.cycles, w.generation
+}
+
+// stats returns the number of cached files, the generation and the time of the
+// last refresh
+func (w *workspace) stats() (int, uint64, time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.files), w.generation, w.refreshed
+}
+
+// within reports whether path is dir or inside it
+func within(dir, path string) bool {
+	if path == dir {
+		return true
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
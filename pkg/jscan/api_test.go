@@ -0,0 +1,200 @@
+package jscan_test
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/pkg/jscan"
+)
+
+// apiFile records the exported API of the current major version. Within a major
+// version, lines may only be added to it.
+const apiFile = "testdata/api.txt"
+
+// TestAPICompatibility fails when an identifier of the recorded API was removed or
+// changed, which requires a new major version, and when new API is not recorded yet
+func TestAPICompatibility(t *testing.T) {
+	data, err := os.ReadFile(apiFile)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", apiFile, err)
+	}
+	recorded := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			recorded[line] = true
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, line := range exportedAPI(t) {
+		current[line] = true
+	}
+
+	var removed, added []string
+	for line := range recorded {
+		if !current[line] {
+			removed = append(removed, line)
+		}
+	}
+	for line := range current {
+		if !recorded[line] {
+			added = append(added, line)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	if len(removed) > 0 {
+		t.Errorf("breaking change: the following API was removed or changed, which needs a new major version:\n%s",
+			strings.Join(removed, "\n"))
+	}
+	if len(added) > 0 {
+		t.Errorf("new API is not recorded; append it to %s:\n%s", apiFile, strings.Join(added, "\n"))
+	}
+}
+
+// exportedAPI returns one line per exported identifier of the package: functions
+// and methods with their signature, types, struct fields and constants with their value
+func exportedAPI(t *testing.T) []string {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("failed to parse package: %v", err)
+	}
+
+	print := func(node interface{}) string {
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fset, node); err != nil {
+			t.Fatalf("failed to print node: %v", err)
+		}
+		return strings.Join(strings.Fields(buf.String()), " ")
+	}
+
+	var lines []string
+	for _, file := range pkgs["jscan"].Files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if !d.Name.IsExported() || (d.Recv != nil && !ast.IsExported(receiverName(d.Recv))) {
+					continue
+				}
+				// Parameter names are not part of the API
+				lines = append(lines, print(&ast.FuncDecl{
+					Recv: unnamed(d.Recv),
+					Name: d.Name,
+					Type: &ast.FuncType{Params: unnamed(d.Type.Params), Results: unnamed(d.Type.Results)},
+				}))
+
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						if !s.Name.IsExported() {
+							continue
+						}
+						st, ok := s.Type.(*ast.StructType)
+						if !ok {
+							lines = append(lines, "type "+s.Name.Name+" "+print(s.Type))
+							continue
+						}
+						lines = append(lines, "type "+s.Name.Name+" struct")
+						for _, field := range st.Fields.List {
+							for _, name := range field.Names {
+								if name.IsExported() {
+									lines = append(lines, s.Name.Name+"."+name.Name+" "+print(field.Type))
+								}
+							}
+						}
+					case *ast.ValueSpec:
+						for i, name := range s.Names {
+							if !name.IsExported() {
+								continue
+							}
+							line := d.Tok.String() + " " + name.Name
+							if s.Type != nil {
+								line += " " + print(s.Type)
+							}
+							if i < len(s.Values) {
+								line += " = " + print(s.Values[i])
+							}
+							lines = append(lines, line)
+						}
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// unnamed returns fields with one unnamed entry per name
+func unnamed(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	result := &ast.FieldList{}
+	for _, field := range fields.List {
+		for range max(len(field.Names), 1) {
+			result.List = append(result.List, &ast.Field{Type: field.Type})
+		}
+	}
+	return result
+}
+
+func receiverName(recv *ast.FieldList) string {
+	expr := recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// TestAPIUsage compiles against the API the way embedders use it, so that
+// incompatible signature changes also break the build
+func TestAPIUsage(t *testing.T) {
+	var (
+		_ func(jscan.Options) (*jscan.Analyzer, error)                                   = jscan.New
+		_ func() []jscan.Analysis                                                        = jscan.AllAnalyses
+		_ func(*jscan.Analyzer, context.Context, ...string) (*jscan.Result, error)       = (*jscan.Analyzer).AnalyzePaths
+		_ func(*jscan.Analyzer, context.Context, ...jscan.Source) (*jscan.Result, error) = (*jscan.Analyzer).AnalyzeSources
+	)
+
+	result := jscan.Result{}
+	var (
+		_ *domain.ComplexityResponse      = result.Complexity
+		_ *domain.DeadCodeResponse        = result.DeadCode
+		_ *domain.CloneResponse           = result.Clone
+		_ *domain.CBOResponse             = result.CBO
+		_ *domain.DependencyGraphResponse = result.Dependencies
+		_ *domain.AnalyzeSummary          = result.Summary
+		_ []string                        = result.Files
+		_ map[jscan.Analysis]error        = result.Errors
+		_ time.Duration                   = result.Duration
+	)
+
+	_ = jscan.Options{
+		Analyses:        []jscan.Analysis{jscan.AnalysisComplexity},
+		ConfigPath:      "",
+		ExcludePatterns: []string{"*.spec.js"},
+		Progress:        func(p jscan.Progress) { _, _, _ = p.Analysis, p.Done, p.Total },
+	}
+	_ = jscan.Source{Path: filepath.Join("src", "a.js"), Content: []byte("")}
+}
@@ -0,0 +1,254 @@
+// Package jscan is the supported Go API for embedding jscan. An Analyzer runs the
+// same analyses as `jscan analyze` on files on disk or on in-memory sources and
+// returns the domain response types.
+//
+// The exported API of this package follows semantic versioning: within a major
+// version, identifiers are only ever added, never removed or changed.
+package jscan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/jscan/app"
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/service"
+)
+
+// Analysis names one of the analyses an Analyzer runs
+type Analysis string
+
+const (
+	AnalysisComplexity   Analysis = "complexity"
+	AnalysisDeadCode     Analysis = "deadcode"
+	AnalysisClone        Analysis = "clone"
+	AnalysisCBO          Analysis = "cbo"
+	AnalysisDependencies Analysis = "deps"
+	AnalysisTypeSafety   Analysis = "typesafety"
+	AnalysisReact        Analysis = "react"
+)
+
+// AllAnalyses returns every analysis, in the order they are reported
+func AllAnalyses() []Analysis {
+	return []Analysis{AnalysisComplexity, AnalysisDeadCode, AnalysisClone, AnalysisCBO, AnalysisDependencies, AnalysisTypeSafety, AnalysisReact}
+}
+
+// Source is an in-memory JavaScript/TypeScript file. Path decides the grammar by
+// its extension and is used to resolve relative imports between sources.
+type Source struct {
+	Path    string
+	Content []byte
+}
+
+// Progress reports how far an analysis is. Analyses without per-file progress
+// report once when they start and once when they finish.
+type Progress struct {
+	Analysis Analysis
+	Done     int
+	Total    int
+}
+
+// ProgressFunc receives progress updates. Calls are serialized, but come from the
+// goroutines running the analyses.
+type ProgressFunc func(Progress)
+
+// Options configures an Analyzer
+type Options struct {
+	// Analyses selects the analyses to run; all of them when empty
+	Analyses []Analysis
+
+	// ConfigPath is a jscan configuration file. When empty, the configuration of the
+	// first analyzed path is discovered as the CLI does; in-memory sources use the
+	// defaults.
+	ConfigPath string
+
+	// ExcludePatterns are file name globs or path fragments of files to skip, in
+	// addition to the configured ones
+	ExcludePatterns []string
+
+	// Progress, if set, is called as the analyses advance
+	Progress ProgressFunc
+
+	// StatementSequences turns on the detection of duplicated statement sequences
+	// inside functions, which is off unless the configuration enables it
+	StatementSequences bool
+}
+
+// Result holds the responses of the analyses that ran. The response of an
+// analysis that was not selected or failed is nil; failures are listed in Errors.
+type Result struct {
+	Complexity   *domain.ComplexityResponse
+	DeadCode     *domain.DeadCodeResponse
+	Clone        *domain.CloneResponse
+	CBO          *domain.CBOResponse
+	Dependencies *domain.DependencyGraphResponse
+	TypeSafety   *domain.TypeSafetyResponse
+	React        *domain.ReactResponse
+
+	// Summary aggregates the responses and holds the health score
+	Summary *domain.AnalyzeSummary
+
+	// Files are the analyzed files
+	Files []string
+
+	// Errors maps the analyses that failed to their error
+	Errors map[Analysis]error
+
+	Duration time.Duration
+}
+
+// Analyzer runs jscan analyses. It is safe for concurrent use.
+type Analyzer struct {
+	analyses        []Analysis
+	cfg             *config.Config
+	excludePatterns []string
+	progress        ProgressFunc
+
+	statementSequences bool
+}
+
+// New creates an Analyzer. It fails on unknown analyses or an unreadable
+// configuration file.
+func New(opts Options) (*Analyzer, error) {
+	analyses := opts.Analyses
+	if len(analyses) == 0 {
+		analyses = AllAnalyses()
+	}
+	for _, analysis := range analyses {
+		if !analysis.valid() {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("unknown analysis %q", analysis), nil)
+		}
+	}
+
+	a := &Analyzer{
+		analyses:        analyses,
+		excludePatterns: opts.ExcludePatterns,
+		progress:        opts.Progress,
+
+		statementSequences: opts.StatementSequences,
+	}
+	if opts.ConfigPath != "" {
+		cfg, err := config.LoadConfigWithTarget(opts.ConfigPath, "")
+		if err != nil {
+			return nil, domain.NewConfigError("failed to load configuration", err)
+		}
+		a.cfg = cfg
+	}
+	return a, nil
+}
+
+// AnalyzePaths analyzes the JavaScript/TypeScript files under paths
+func (a *Analyzer) AnalyzePaths(ctx context.Context, paths ...string) (*Result, error) {
+	if len(paths) == 0 {
+		return nil, domain.NewInvalidInputError("no paths specified", nil)
+	}
+
+	cfg := a.cfg
+	if cfg == nil {
+		var err error
+		cfg, err = config.LoadConfigWithTarget("", paths[0])
+		if err != nil {
+			return nil, domain.NewConfigError("failed to load configuration", err)
+		}
+	}
+
+	exclude := append(append([]string(nil), cfg.Analysis.ExcludePatterns...), a.excludePatterns...)
+	files, err := app.NewFileHelper().CollectJSFiles(paths, true, nil, exclude)
+	if err != nil {
+		return nil, domain.NewFileNotFoundError("failed to collect files", err)
+	}
+	if len(files) == 0 {
+		return nil, domain.NewInvalidInputError("no JavaScript/TypeScript files found in the specified paths", nil)
+	}
+
+	return a.run(ctx, cfg, files, nil)
+}
+
+// AnalyzeSources analyzes in-memory sources without reading them from disk
+func (a *Analyzer) AnalyzeSources(ctx context.Context, sources ...Source) (*Result, error) {
+	if len(sources) == 0 {
+		return nil, domain.NewInvalidInputError("no sources specified", nil)
+	}
+
+	helper := app.NewFileHelper()
+	contents := make(map[string][]byte, len(sources))
+	files := make([]string, 0, len(sources))
+	for _, source := range sources {
+		path, err := helper.AddSource(contents, source.Path, source.Content)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+
+	cfg := a.cfg
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	return a.run(ctx, cfg, files, contents)
+}
+
+// run executes the selected analyses in parallel on files, reading the ones in sources
+// from memory
+func (a *Analyzer) run(ctx context.Context, cfg *config.Config, files []string, sources map[string][]byte) (*Result, error) {
+	start := time.Now()
+	progress := newProgressReporter(a.progress)
+
+	analyses := make([]string, 0, len(a.analyses))
+	for _, analysis := range a.analyses {
+		analyses = append(analyses, string(analysis))
+	}
+	r := app.RunAnalyses(ctx, cfg, app.AnalysisRequest{
+		Analyses:           analyses,
+		Files:              files,
+		Sources:            sources,
+		StatementSequences: a.statementSequences,
+		Progress: func(analysis string) domain.ProgressManager {
+			return progress.manager(Analysis(analysis))
+		},
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(r.Errors) == len(a.analyses) {
+		return nil, domain.NewAnalysisError("all analyses failed", r.Errors[analyses[0]])
+	}
+
+	result := &Result{
+		Complexity:   r.Complexity,
+		DeadCode:     r.DeadCode,
+		Clone:        r.Clone,
+		CBO:          r.CBO,
+		Dependencies: r.Dependencies,
+		TypeSafety:   r.TypeSafety,
+		React:        r.React,
+		Files:        files,
+	}
+	for analysis, err := range r.Errors {
+		if result.Errors == nil {
+			result.Errors = make(map[Analysis]error)
+		}
+		result.Errors[Analysis(analysis)] = err
+	}
+
+	result.Summary = service.BuildAnalyzeSummary(result.Complexity, result.DeadCode, result.Clone,
+		result.CBO, result.Dependencies, service.ScoringFromConfig(&cfg.Scoring))
+	service.AddTypeSafetySummary(result.Summary, result.TypeSafety)
+	service.AddReactSummary(result.Summary, result.React)
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func (a Analysis) valid() bool {
+	for _, analysis := range AllAnalyses() {
+		if a == analysis {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package jscan_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/pkg/jscan"
+)
+
+var testSources = []jscan.Source{
+	{Path: "src/a.ts", Content: []byte(`import { b } from './b';
+
+export function a(x: number): number {
+  if (x > 0) {
+    return b();
+  }
+  return 0;
+}
+`)},
+	{Path: "src/b.ts", Content: []byte(`import { a } from './a';
+
+export function b(): number {
+  return a(1);
+  console.log('unreachable');
+}
+`)},
+}
+
+func TestAnalyzeSources(t *testing.T) {
+	analyzer, err := jscan.New(jscan.Options{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	result, err := analyzer.AnalyzeSources(context.Background(), testSources...)
+	if err != nil {
+		t.Fatalf("AnalyzeSources failed: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected analysis errors: %v", result.Errors)
+	}
+	if len(result.Files) != 2 {
+		t.Errorf("expected 2 files, got %v", result.Files)
+	}
+
+	if result.Complexity == nil || len(result.Complexity.Functions) != 2 {
+		t.Fatalf("expected 2 functions, got %+v", result.Complexity)
+	}
+	if fn := result.Complexity.Functions[0]; fn.Name != "a" || fn.Metrics.Complexity != 2 {
+		t.Errorf("expected a with complexity 2 first, got %s with %d", fn.Name, fn.Metrics.Complexity)
+	}
+
+	if result.DeadCode == nil || result.DeadCode.Summary.TotalFindings == 0 {
+		t.Errorf("expected the code after return to be reported, got %+v", result.DeadCode)
+	}
+
+	// Imports between in-memory sources are resolved
+	deps := result.Dependencies
+	if deps == nil || deps.Analysis == nil || deps.Analysis.CircularDependencies == nil ||
+		!deps.Analysis.CircularDependencies.HasCircularDependencies {
+		t.Errorf("expected the cycle between a.ts and b.ts, got %+v", deps)
+	}
+
+	if result.Clone == nil || result.CBO == nil {
+		t.Errorf("expected clone and CBO responses")
+	}
+	if result.Summary == nil || result.Summary.HealthScore <= 0 {
+		t.Errorf("expected a health score, got %+v", result.Summary)
+	}
+
+	if result.TypeSafety == nil || result.TypeSafety.Summary.FilesAnalyzed != 2 {
+		t.Fatalf("expected both TypeScript sources measured, got %+v", result.TypeSafety)
+	}
+	if !result.Summary.TypeSafetyEnabled {
+		t.Errorf("expected the type-safety totals in the summary")
+	}
+}
+
+func TestAnalyzeSources_React(t *testing.T) {
+	analyzer, err := jscan.New(jscan.Options{Analyses: []jscan.Analysis{jscan.AnalysisReact}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	result, err := analyzer.AnalyzeSources(context.Background(), jscan.Source{
+		Path: "src/Counter.tsx",
+		Content: []byte(`import { useState } from 'react';
+
+export function Counter({ enabled }: { enabled: boolean }) {
+  if (enabled) {
+    const [count] = useState(0);
+    return <span>{count}</span>;
+  }
+  return <span />;
+}
+`),
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeSources failed: %v", err)
+	}
+	if result.React == nil || result.React.Summary.Components != 1 {
+		t.Fatalf("expected 1 component, got %+v", result.React)
+	}
+	if result.React.Summary.HookViolations == 0 {
+		t.Errorf("expected the conditional hook call to be reported")
+	}
+	if !result.Summary.ReactEnabled || result.Summary.HookViolationCount != result.React.Summary.HookViolations {
+		t.Errorf("expected the React totals in the summary, got %+v", result.Summary)
+	}
+}
+
+func TestAnalyzePaths(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "src", "main.js"), "export function main(a) { return a ? 1 : 2; }\n")
+	writeFile(t, filepath.Join(dir, "src", "main.spec.js"), "export function spec() { return 1; }\n")
+
+	analyzer, err := jscan.New(jscan.Options{
+		Analyses:        []jscan.Analysis{jscan.AnalysisComplexity},
+		ExcludePatterns: []string{"*.spec.js"},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	result, err := analyzer.AnalyzePaths(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("AnalyzePaths failed: %v", err)
+	}
+	if len(result.Files) != 1 || filepath.Base(result.Files[0]) != "main.js" {
+		t.Errorf("expected only main.js, got %v", result.Files)
+	}
+	if result.Complexity == nil || len(result.Complexity.Functions) != 1 {
+		t.Fatalf("expected 1 function, got %+v", result.Complexity)
+	}
+	// Only the selected analysis runs
+	if result.DeadCode != nil || result.Clone != nil || result.CBO != nil || result.Dependencies != nil {
+		t.Errorf("expected only complexity to run")
+	}
+}
+
+func TestAnalyzePaths_NoFiles(t *testing.T) {
+	analyzer, err := jscan.New(jscan.Options{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := analyzer.AnalyzePaths(context.Background(), t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without JavaScript files")
+	}
+	if _, err := analyzer.AnalyzePaths(context.Background()); err == nil {
+		t.Error("expected an error without paths")
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	if _, err := jscan.New(jscan.Options{Analyses: []jscan.Analysis{"lint"}}); err == nil {
+		t.Error("expected an error for an unknown analysis")
+	}
+	if _, err := jscan.New(jscan.Options{ConfigPath: filepath.Join(t.TempDir(), "missing.toml")}); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+func TestAnalyzeSources_InvalidSources(t *testing.T) {
+	analyzer, err := jscan.New(jscan.Options{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := analyzer.AnalyzeSources(ctx, jscan.Source{Path: "README.md"}); err == nil {
+		t.Error("expected an error for a non-JavaScript source")
+	}
+	if _, err := analyzer.AnalyzeSources(ctx, testSources[0], testSources[0]); err == nil {
+		t.Error("expected an error for duplicate sources")
+	}
+	if _, err := analyzer.AnalyzeSources(ctx); err == nil {
+		t.Error("expected an error without sources")
+	}
+}
+
+func TestAnalyzeSources_Cancelled(t *testing.T) {
+	analyzer, err := jscan.New(jscan.Options{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = analyzer.AnalyzeSources(ctx, testSources...)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestAnalyzeSources_Progress(t *testing.T) {
+	var mu sync.Mutex
+	last := make(map[jscan.Analysis]jscan.Progress)
+	analyzer, err := jscan.New(jscan.Options{
+		Progress: func(p jscan.Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			if prev, ok := last[p.Analysis]; ok && p.Done < prev.Done {
+				t.Errorf("%s progress went backwards: %d after %d", p.Analysis, p.Done, prev.Done)
+			}
+			last[p.Analysis] = p
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if _, err := analyzer.AnalyzeSources(context.Background(), testSources...); err != nil {
+		t.Fatalf("AnalyzeSources failed: %v", err)
+	}
+	for _, analysis := range jscan.AllAnalyses() {
+		p, ok := last[analysis]
+		if !ok {
+			t.Errorf("no progress reported for %s", analysis)
+			continue
+		}
+		if p.Total != len(testSources) || p.Done != p.Total {
+			t.Errorf("expected %s to finish at %d/%d, got %d/%d", analysis, len(testSources), len(testSources), p.Done, p.Total)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,93 @@
+package jscan
+
+import (
+	"sync"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// progressReporter forwards the progress of the services to a ProgressFunc,
+// serializing the calls of the concurrently running analyses
+type progressReporter struct {
+	mu sync.Mutex
+	fn ProgressFunc
+}
+
+func newProgressReporter(fn ProgressFunc) *progressReporter {
+	return &progressReporter{fn: fn}
+}
+
+// manager returns a progress manager reporting the tasks it starts as analysis
+func (r *progressReporter) manager(analysis Analysis) domain.ProgressManager {
+	return &progressManager{reporter: r, analysis: analysis}
+}
+
+func (r *progressReporter) report(p Progress) {
+	if r.fn == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fn(p)
+}
+
+// progressManager implements domain.ProgressManager for one analysis
+type progressManager struct {
+	reporter *progressReporter
+	analysis Analysis
+}
+
+func (m *progressManager) StartTask(_ string, total int) domain.TaskProgress {
+	task := &taskProgress{reporter: m.reporter, analysis: m.analysis, total: total}
+	m.reporter.report(Progress{Analysis: m.analysis, Total: total})
+	return task
+}
+
+func (m *progressManager) IsInteractive() bool {
+	return false
+}
+
+func (m *progressManager) Close() {}
+
+// taskProgress implements domain.TaskProgress; Complete reports the task as done
+// when the service did not increment it per file
+type taskProgress struct {
+	reporter *progressReporter
+	analysis Analysis
+
+	mu        sync.Mutex
+	done      int
+	total     int
+	completed bool
+}
+
+func (t *taskProgress) Increment(n int) {
+	t.mu.Lock()
+	if t.completed {
+		t.mu.Unlock()
+		return
+	}
+	t.done = min(t.done+n, t.total)
+	p := Progress{Analysis: t.analysis, Done: t.done, Total: t.total}
+	t.mu.Unlock()
+	t.reporter.report(p)
+}
+
+func (t *taskProgress) Describe(_ string) {}
+
+func (t *taskProgress) Complete() {
+	t.mu.Lock()
+	if t.completed {
+		t.mu.Unlock()
+		return
+	}
+	t.completed = true
+	if t.done == t.total {
+		t.mu.Unlock()
+		return
+	}
+	t.done = t.total
+	p := Progress{Analysis: t.analysis, Done: t.done, Total: t.total}
+	t.mu.Unlock()
+	t.reporter.report(p)
+}
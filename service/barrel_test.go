@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestDependencyGraphServiceBarrels(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/components/index.ts":   "export { Button } from './Button';\nexport { default as Modal } from './Modal';\nexport * from './Icon';\n",
+		"src/components/Button.tsx": "export const Button = () => null;\n",
+		"src/components/Modal.tsx":  "import { Button } from './index';\nexport default function Modal() { return Button(); }\n",
+		"src/components/Icon.tsx":   "export const Icon = 1;\nexport const IconSet = 2;\n",
+		"src/main.ts":               "import { Modal, Icon } from './components';\nModal(); Icon;\n",
+	}
+	writeTestFiles(t, dir, files)
+	var paths []string
+	for name := range files {
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	resp, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:   paths,
+		Barrels: &domain.BarrelOptions{Suggest: true},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	barrels := resp.Barrels
+	if barrels == nil {
+		t.Fatal("Expected a barrel result")
+	}
+	if barrels.Options.MinReExports != domain.DefaultBarrelMinReExports {
+		t.Errorf("Expected the default options to apply, got %+v", barrels.Options)
+	}
+	if len(barrels.Barrels) != 1 || !strings.HasSuffix(barrels.Barrels[0].Module, "components/index.ts") {
+		t.Fatalf("Expected components/index.ts to be a barrel, got %+v", barrels.Barrels)
+	}
+	if len(barrels.Imports) != 3 || len(barrels.Cycles) != 1 || len(barrels.Suggestions) != 2 {
+		t.Errorf("Expected 3 imports, 1 cycle and 2 suggestions, got %+v", barrels)
+	}
+	if len(barrels.UnusedReExports) != 1 || barrels.UnusedReExports[0].Symbol != "IconSet" {
+		t.Errorf("Expected IconSet to be an unused re-export, got %+v", barrels.UnusedReExports)
+	}
+
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteDependencyGraph(resp, domain.OutputFormatText, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	for _, want := range []string{"Barrel files (>= 3 re-exports): 1", "Cycles caused by barrels: 1", "IconSet (from ", "import Modal from './components/Modal'"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the text report to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := NewMarkdownFormatter(nil).WriteDependencyGraph(resp, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<b>Barrels</b>: 1 files, 3 imports through them") || !strings.Contains(buf.String(), "<b>Barrel Cycles</b>") {
+		t.Errorf("Expected Markdown barrel sections, got:\n%s", buf.String())
+	}
+}
+
+func TestDependencyGraphServiceBarrels_InvalidOptions(t *testing.T) {
+	_, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:   []string{"/repo/src/a.ts"},
+		Barrels: &domain.BarrelOptions{MinReExports: -1},
+	})
+	if err == nil {
+		t.Error("Expected an error for a negative min_re_exports")
+	}
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// BuildDirectoryBreakdown aggregates the analysis results per directory subtree. Every
+// directory between the deepest common directory of the files and the files themselves
+// gets a group covering all the files below it.
+func BuildDirectoryBreakdown(
+	files []string,
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+	scoring *domain.ScoringConfig,
+) *domain.QualityBreakdown {
+	dirs := make([][]string, 0, len(files))
+	for _, file := range files {
+		dirs = append(dirs, directoryParts(path.Dir(breakdownKey(file))))
+	}
+	root := commonDirectoryParts(dirs)
+
+	parents := make(map[string]string)
+	depths := make(map[string]int)
+	groupsByFile := make(map[string][]string, len(files))
+	for i, file := range files {
+		var groups []string
+		for n := len(dirs[i]); n >= len(root); n-- {
+			name := directoryName(dirs[i][:n])
+			groups = append(groups, name)
+			depths[name] = n - len(root)
+			if n > len(root) {
+				parents[name] = directoryName(dirs[i][:n-1])
+			}
+		}
+		groupsByFile[breakdownKey(file)] = groups
+	}
+
+	breakdown := buildBreakdown(domain.GroupByDirectory, files, groupsByFile,
+		complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, scoring)
+	for i := range breakdown.Groups {
+		breakdown.Groups[i].Parent = parents[breakdown.Groups[i].Name]
+		breakdown.Groups[i].Depth = depths[breakdown.Groups[i].Name]
+	}
+	return breakdown
+}
+
+// BuildOwnerBreakdown aggregates the analysis results per CODEOWNERS owner. A file with
+// several owners counts for each of them; files without an owner are grouped under
+// domain.UnownedGroup.
+func BuildOwnerBreakdown(
+	files []string,
+	owners *CodeOwners,
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+	scoring *domain.ScoringConfig,
+) *domain.QualityBreakdown {
+	groupsByFile := make(map[string][]string, len(files))
+	for _, file := range files {
+		var groups []string
+		if owners != nil {
+			groups = owners.Owners(file)
+		}
+		if len(groups) == 0 {
+			groups = []string{domain.UnownedGroup}
+		}
+		groupsByFile[breakdownKey(file)] = groups
+	}
+
+	return buildBreakdown(domain.GroupByOwner, files, groupsByFile,
+		complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, scoring)
+}
+
+// breakdownAccumulator collects the metrics of one group before it is scored
+type breakdownAccumulator struct {
+	group           domain.QualityGroup
+	complexitySum   int
+	couplingSum     int
+	distanceSum     float64
+	distanceCount   int
+	duplicatedLines int
+	cloneLocations  map[string]bool
+	modulesInCycles map[string]bool
+}
+
+// buildBreakdown attributes every finding to the groups of its file and scores each
+// group the same way BuildAnalyzeSummary scores the whole project
+func buildBreakdown(
+	groupBy domain.GroupBy,
+	files []string,
+	groupsByFile map[string][]string,
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+	scoring *domain.ScoringConfig,
+) *domain.QualityBreakdown {
+	accumulators := make(map[string]*breakdownAccumulator)
+	groupsOf := func(file string) []*breakdownAccumulator {
+		var result []*breakdownAccumulator
+		for _, name := range groupsByFile[breakdownKey(file)] {
+			acc, ok := accumulators[name]
+			if !ok {
+				acc = &breakdownAccumulator{
+					group:           domain.QualityGroup{Name: name},
+					cloneLocations:  make(map[string]bool),
+					modulesInCycles: make(map[string]bool),
+				}
+				acc.group.Summary.ComplexityEnabled = complexityResponse != nil
+				acc.group.Summary.DeadCodeEnabled = deadCodeResponse != nil
+				acc.group.Summary.CloneEnabled = cloneResponse != nil
+				acc.group.Summary.CBOEnabled = cboResponse != nil
+				acc.group.Summary.DepsEnabled = depsResponse != nil
+				accumulators[name] = acc
+			}
+			result = append(result, acc)
+		}
+		return result
+	}
+
+	for _, file := range files {
+		lines := 0
+		if content, err := os.ReadFile(file); err == nil {
+			lines = countLines(content)
+		}
+		for _, acc := range groupsOf(file) {
+			acc.group.Files++
+			acc.group.Lines += lines
+		}
+	}
+
+	if complexityResponse != nil {
+		for _, fn := range complexityResponse.Functions {
+			for _, acc := range groupsOf(fn.FilePath) {
+				acc.group.Summary.TotalFunctions++
+				acc.complexitySum += fn.Metrics.Complexity
+				switch fn.RiskLevel {
+				case domain.RiskLevelHigh:
+					acc.group.Summary.HighComplexityCount++
+				case domain.RiskLevelMedium:
+					acc.group.Summary.MediumComplexityCount++
+				default:
+					acc.group.LowComplexityCount++
+				}
+			}
+		}
+	}
+
+	if deadCodeResponse != nil {
+		for _, file := range deadCodeResponse.Files {
+			findings := append([]domain.DeadCodeFinding(nil), file.FileLevelFindings...)
+			for _, fn := range file.Functions {
+				findings = append(findings, fn.Findings...)
+			}
+			for _, acc := range groupsOf(file.FilePath) {
+				for _, finding := range findings {
+					acc.group.Summary.DeadCodeCount++
+					switch finding.Severity {
+					case domain.DeadCodeSeverityCritical:
+						acc.group.Summary.CriticalDeadCode++
+					case domain.DeadCodeSeverityWarning:
+						acc.group.Summary.WarningDeadCode++
+					default:
+						acc.group.Summary.InfoDeadCode++
+					}
+				}
+			}
+		}
+	}
+
+	if cloneResponse != nil {
+		for _, pair := range cloneResponse.ClonePairs {
+			if pair == nil {
+				continue
+			}
+			touched := make(map[*breakdownAccumulator]bool)
+			for _, clone := range []*domain.Clone{pair.Clone1, pair.Clone2} {
+				if clone == nil || clone.Location == nil {
+					continue
+				}
+				key := clone.Location.String()
+				for _, acc := range groupsOf(clone.Location.FilePath) {
+					touched[acc] = true
+					if !acc.cloneLocations[key] {
+						acc.cloneLocations[key] = true
+						acc.duplicatedLines += clone.LineCount
+					}
+				}
+			}
+			for acc := range touched {
+				acc.group.Summary.ClonePairs++
+			}
+		}
+		for _, group := range cloneResponse.CloneGroups {
+			if group == nil {
+				continue
+			}
+			touched := make(map[*breakdownAccumulator]bool)
+			for _, clone := range group.Clones {
+				if clone != nil && clone.Location != nil {
+					for _, acc := range groupsOf(clone.Location.FilePath) {
+						touched[acc] = true
+					}
+				}
+			}
+			for acc := range touched {
+				acc.group.Summary.CloneGroups++
+			}
+		}
+	}
+
+	if cboResponse != nil {
+		for _, class := range cboResponse.Classes {
+			for _, acc := range groupsOf(class.FilePath) {
+				acc.group.Summary.CBOClasses++
+				acc.couplingSum += class.Metrics.CouplingCount
+				switch class.RiskLevel {
+				case domain.RiskLevelHigh:
+					acc.group.Summary.HighCouplingClasses++
+				case domain.RiskLevelMedium:
+					acc.group.Summary.MediumCouplingClasses++
+				}
+			}
+		}
+	}
+
+	if depsResponse != nil && depsResponse.Graph != nil {
+		// Only analyzed files are modules of a group; external packages have no owner
+		moduleFile := func(id string) string {
+			node, ok := depsResponse.Graph.Nodes[id]
+			if !ok || node.FilePath == "" {
+				return ""
+			}
+			if _, analyzed := groupsByFile[breakdownKey(node.FilePath)]; !analyzed {
+				return ""
+			}
+			return node.FilePath
+		}
+
+		for id := range depsResponse.Graph.Nodes {
+			file := moduleFile(id)
+			if file == "" {
+				continue
+			}
+			for _, acc := range groupsOf(file) {
+				acc.group.Summary.DepsTotalModules++
+				if depsResponse.Analysis != nil {
+					if metrics, ok := depsResponse.Analysis.ModuleMetrics[id]; ok && metrics != nil {
+						acc.distanceSum += metrics.Distance
+						acc.distanceCount++
+					}
+				}
+			}
+		}
+
+		if depsResponse.Analysis != nil && depsResponse.Analysis.CircularDependencies != nil {
+			for _, cycle := range depsResponse.Analysis.CircularDependencies.CircularDependencies {
+				touched := make(map[*breakdownAccumulator]bool)
+				for _, id := range cycle.Modules {
+					file := moduleFile(id)
+					if file == "" {
+						continue
+					}
+					for _, acc := range groupsOf(file) {
+						touched[acc] = true
+						acc.modulesInCycles[id] = true
+					}
+				}
+				for acc := range touched {
+					acc.group.Cycles++
+				}
+			}
+		}
+	}
+
+	breakdown := &domain.QualityBreakdown{GroupBy: groupBy, Groups: make([]domain.QualityGroup, 0, len(accumulators))}
+	for _, acc := range accumulators {
+		summary := &acc.group.Summary
+		summary.TotalFiles = acc.group.Files
+		summary.AnalyzedFiles = acc.group.Files
+		if summary.TotalFunctions > 0 {
+			summary.AverageComplexity = float64(acc.complexitySum) / float64(summary.TotalFunctions)
+		}
+		summary.TotalClones = len(acc.cloneLocations)
+		if acc.group.Lines > 0 {
+			summary.CodeDuplication = float64(acc.duplicatedLines) / float64(acc.group.Lines) * 100.0
+			if summary.CodeDuplication > 100.0 {
+				summary.CodeDuplication = 100.0
+			}
+		}
+		if summary.CBOClasses > 0 {
+			summary.AverageCoupling = float64(acc.couplingSum) / float64(summary.CBOClasses)
+		}
+		summary.DepsModulesInCycles = len(acc.modulesInCycles)
+		if acc.distanceCount > 0 {
+			summary.DepsMainSequenceDeviation = acc.distanceSum / float64(acc.distanceCount)
+		}
+
+		_ = summary.CalculateHealthScoreWith(scoring)
+		breakdown.Groups = append(breakdown.Groups, acc.group)
+	}
+
+	sort.Slice(breakdown.Groups, func(i, j int) bool {
+		return breakdown.Groups[i].Name < breakdown.Groups[j].Name
+	})
+	return breakdown
+}
+
+// breakdownKey normalizes a file path so that paths reported by different analyses match
+func breakdownKey(file string) string {
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// directoryParts splits a slash-separated directory into its components; "." has none
+func directoryParts(dir string) []string {
+	if dir == "." {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
+
+// directoryName joins directory components back into a path
+func directoryName(parts []string) string {
+	switch {
+	case len(parts) == 0:
+		return "."
+	case len(parts) == 1 && parts[0] == "":
+		return "/"
+	default:
+		return strings.Join(parts, "/")
+	}
+}
+
+// commonDirectoryParts returns the longest directory prefix shared by all dirs
+func commonDirectoryParts(dirs [][]string) []string {
+	if len(dirs) == 0 {
+		return nil
+	}
+	common := dirs[0]
+	for _, dir := range dirs[1:] {
+		n := 0
+		for n < len(common) && n < len(dir) && common[n] == dir[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	return common
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// breakdownTestFiles writes src/a/x.js, src/b/y.js and src/b/c/z.js of ten lines each
+func breakdownTestFiles(t *testing.T) (string, []string) {
+	t.Helper()
+	root := t.TempDir()
+	var files []string
+	for _, rel := range []string{"src/a/x.js", "src/b/y.js", "src/b/c/z.js"} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x;\n", 9)+"x;"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, path)
+	}
+	return root, files
+}
+
+func breakdownTestResponses(files []string) (*domain.ComplexityResponse, *domain.DeadCodeResponse, *domain.CloneResponse, *domain.CBOResponse, *domain.DependencyGraphResponse) {
+	x, y, z := files[0], files[1], files[2]
+
+	complexity := &domain.ComplexityResponse{Functions: []domain.FunctionComplexity{
+		{Name: "fx", FilePath: x, Metrics: domain.ComplexityMetrics{Complexity: 25}, RiskLevel: domain.RiskLevelHigh},
+		{Name: "fy", FilePath: y, Metrics: domain.ComplexityMetrics{Complexity: 12}, RiskLevel: domain.RiskLevelMedium},
+		{Name: "fz", FilePath: z, Metrics: domain.ComplexityMetrics{Complexity: 2}, RiskLevel: domain.RiskLevelLow},
+	}}
+	deadCode := &domain.DeadCodeResponse{Files: []domain.FileDeadCode{{
+		FilePath: y,
+		Functions: []domain.FunctionDeadCode{{Name: "fy", Findings: []domain.DeadCodeFinding{
+			{Severity: domain.DeadCodeSeverityCritical},
+		}}},
+	}}}
+	clones := &domain.CloneResponse{ClonePairs: []*domain.ClonePair{{
+		Clone1: &domain.Clone{Location: &domain.CloneLocation{FilePath: x, StartLine: 1, EndLine: 5}, LineCount: 5},
+		Clone2: &domain.Clone{Location: &domain.CloneLocation{FilePath: z, StartLine: 1, EndLine: 5}, LineCount: 5},
+	}}}
+	cbo := &domain.CBOResponse{Classes: []domain.ClassCoupling{
+		{Name: "X", FilePath: x, Metrics: domain.CBOMetrics{CouplingCount: 9}, RiskLevel: domain.RiskLevelHigh},
+	}}
+
+	graph := domain.NewDependencyGraph()
+	graph.AddNode(&domain.ModuleNode{ID: x, FilePath: x})
+	graph.AddNode(&domain.ModuleNode{ID: y, FilePath: y})
+	graph.AddNode(&domain.ModuleNode{ID: "react", ModuleType: domain.ModuleTypePackage})
+	deps := &domain.DependencyGraphResponse{
+		Graph: graph,
+		Analysis: &domain.DependencyAnalysisResult{
+			ModuleMetrics: map[string]*domain.ModuleDependencyMetrics{
+				x: {Distance: 0.2},
+				y: {Distance: 0.6},
+			},
+			CircularDependencies: &domain.CircularDependencyAnalysis{CircularDependencies: []domain.CircularDependency{
+				{Modules: []string{x, y}},
+			}},
+		},
+	}
+	return complexity, deadCode, clones, cbo, deps
+}
+
+func TestBuildDirectoryBreakdown(t *testing.T) {
+	root, files := breakdownTestFiles(t)
+	complexity, deadCode, clones, cbo, deps := breakdownTestResponses(files)
+
+	breakdown := BuildDirectoryBreakdown(files, complexity, deadCode, clones, cbo, deps, nil)
+	if breakdown.GroupBy != domain.GroupByDirectory {
+		t.Errorf("Expected dir grouping, got %s", breakdown.GroupBy)
+	}
+
+	src := filepath.ToSlash(filepath.Join(root, "src"))
+	groups := make(map[string]domain.QualityGroup)
+	var names []string
+	for _, group := range breakdown.Groups {
+		groups[group.Name] = group
+		names = append(names, strings.TrimPrefix(group.Name, src))
+	}
+	if strings.Join(names, ",") != ",/a,/b,/b/c" {
+		t.Fatalf("Unexpected groups %v", names)
+	}
+
+	// The root group covers every file, like the project summary
+	top := groups[src]
+	if top.Depth != 0 || top.Parent != "" || top.Files != 3 || top.Lines != 30 {
+		t.Errorf("Unexpected root group %+v", top)
+	}
+	if top.Summary.TotalFunctions != 3 || top.Summary.ClonePairs != 1 || top.Cycles != 1 || top.Summary.DepsTotalModules != 2 {
+		t.Errorf("Unexpected root summary %+v", top.Summary)
+	}
+
+	// A subtree includes its subdirectories
+	b := groups[src+"/b"]
+	if b.Depth != 1 || b.Parent != src || b.Files != 2 {
+		t.Errorf("Unexpected src/b group %+v", b)
+	}
+	if b.Summary.MediumComplexityCount != 1 || b.LowComplexityCount != 1 || b.Summary.CriticalDeadCode != 1 {
+		t.Errorf("Unexpected src/b metrics %+v", b)
+	}
+	if b.Summary.ClonePairs != 1 || b.Summary.TotalClones != 1 || b.Summary.CodeDuplication != 25.0 {
+		t.Errorf("Expected one of the two copies in src/b (5 of 20 lines), got %+v", b.Summary)
+	}
+	if b.Cycles != 1 || b.Summary.DepsModulesInCycles != 1 || b.Summary.DepsMainSequenceDeviation != 0.6 {
+		t.Errorf("Unexpected src/b dependency metrics %+v", b.Summary)
+	}
+
+	c := groups[src+"/b/c"]
+	if c.Depth != 2 || c.Parent != src+"/b" || c.Cycles != 0 || c.Summary.DepsTotalModules != 0 {
+		t.Errorf("Unexpected src/b/c group %+v", c)
+	}
+
+	// Groups are scored like the project
+	a := groups[src+"/a"]
+	if a.Summary.HighComplexityCount != 1 || a.Summary.HighCouplingClasses != 1 || a.Summary.AverageCoupling != 9 {
+		t.Errorf("Unexpected src/a metrics %+v", a.Summary)
+	}
+	if a.Summary.Grade == "" || a.Summary.HealthScore >= c.Summary.HealthScore {
+		t.Errorf("Expected src/a (%d) to score below src/b/c (%d)", a.Summary.HealthScore, c.Summary.HealthScore)
+	}
+}
+
+func TestBuildOwnerBreakdown(t *testing.T) {
+	root, files := breakdownTestFiles(t)
+	complexity, deadCode, clones, cbo, deps := breakdownTestResponses(files)
+
+	owners, err := ParseCodeOwners(strings.NewReader("/src/a/ @org/web @alice\n/src/b/c/ @org/web\n"), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdown := BuildOwnerBreakdown(files, owners, complexity, deadCode, clones, cbo, deps, nil)
+
+	groups := make(map[string]domain.QualityGroup)
+	for _, group := range breakdown.Groups {
+		groups[group.Name] = group
+	}
+	if len(groups) != 3 {
+		t.Fatalf("Expected @org/web, @alice and unowned groups, got %+v", breakdown.Groups)
+	}
+	if web := groups["@org/web"]; web.Files != 2 || web.Summary.TotalClones != 2 || web.Summary.CodeDuplication != 50.0 {
+		t.Errorf("Unexpected @org/web group %+v", web)
+	}
+	if alice := groups["@alice"]; alice.Files != 1 || alice.Summary.HighComplexityCount != 1 {
+		t.Errorf("Unexpected @alice group %+v", alice)
+	}
+	if unowned := groups[domain.UnownedGroup]; unowned.Files != 1 || unowned.Summary.DeadCodeCount != 1 {
+		t.Errorf("Unexpected unowned group %+v", unowned)
+	}
+}
+
+func TestOutputFormatterWriteAnalyze_Breakdown(t *testing.T) {
+	_, files := breakdownTestFiles(t)
+	complexity, _, _, _, _ := breakdownTestResponses(files)
+
+	formatter := NewOutputFormatter()
+	formatter.SetBreakdown(BuildOwnerBreakdown(files, nil, complexity, nil, nil, nil, nil, nil))
+
+	var jsonBuf bytes.Buffer
+	if err := formatter.WriteAnalyze(complexity, nil, nil, nil, nil, domain.OutputFormatJSON, &jsonBuf, 0); err != nil {
+		t.Fatalf("WriteAnalyze failed: %v", err)
+	}
+	if !strings.Contains(jsonBuf.String(), `"group_by": "owner"`) || !strings.Contains(jsonBuf.String(), `"name": "(unowned)"`) {
+		t.Errorf("Expected the breakdown in JSON output, got %s", jsonBuf.String())
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := formatter.WriteAnalyze(complexity, nil, nil, nil, nil, domain.OutputFormatHTML, &htmlBuf, 0); err != nil {
+		t.Fatalf("WriteAnalyze failed: %v", err)
+	}
+	if !strings.Contains(htmlBuf.String(), "Breakdown by Owner") || !strings.Contains(htmlBuf.String(), "treemap-cell") {
+		t.Error("Expected the breakdown tab in HTML output")
+	}
+
+	var textBuf bytes.Buffer
+	if err := formatter.WriteAnalyze(complexity, nil, nil, nil, nil, domain.OutputFormatText, &textBuf, 0); err != nil {
+		t.Fatalf("WriteAnalyze failed: %v", err)
+	}
+	if !strings.Contains(textBuf.String(), "=== Breakdown by owner ===") {
+		t.Error("Expected the breakdown table in text output")
+	}
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"os"
 	"sort"
 	"time"
 
@@ -67,7 +66,7 @@ func (s *CBOServiceImpl) Analyze(ctx context.Context, req domain.CBORequest) (*d
 		}
 
 		// Analyze single file
-		classCoupling, fileWarnings, fileErrors := s.analyzeFile(ctx, cboAnalyzer, filePath)
+		classCoupling, fileWarnings, fileErrors := s.analyzeFile(ctx, cboAnalyzer, filePath, req.Sources)
 
 		if len(fileErrors) > 0 {
 			errors = append(errors, fileErrors...)
@@ -111,12 +110,12 @@ func (s *CBOServiceImpl) AnalyzeFile(ctx context.Context, filePath string, req d
 }
 
 // analyzeFile performs CBO analysis on a single file
-func (s *CBOServiceImpl) analyzeFile(ctx context.Context, cboAnalyzer *analyzer.CBOAnalyzer, filePath string) (*domain.ClassCoupling, []string, []string) {
+func (s *CBOServiceImpl) analyzeFile(ctx context.Context, cboAnalyzer *analyzer.CBOAnalyzer, filePath string, sources map[string][]byte) (*domain.ClassCoupling, []string, []string) {
 	var warnings []string
 	var errors []string
 
 	// Read the file
-	content, err := os.ReadFile(filePath)
+	content, err := readSource(sources, filePath)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
 		return nil, warnings, errors
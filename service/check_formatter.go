@@ -0,0 +1,261 @@
+package service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// checkProjectSuite names the JUnit suite of project-level results, such as
+// violations without a location and categories that passed
+const checkProjectSuite = "jscan"
+
+// checkProjectFile is the Checkstyle file of violations without a location
+const checkProjectFile = "."
+
+// WriteCheck writes a quality gate result in the specified format
+func (f *OutputFormatterImpl) WriteCheck(result *domain.CheckResult, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatJSON:
+		return WriteJSON(writer, result)
+	case domain.OutputFormatMarkdown:
+		markdownFormatter := NewMarkdownFormatter(nil)
+		return markdownFormatter.WriteCheck(result, writer)
+	case domain.OutputFormatJUnit:
+		return f.writeCheckJUnit(result, writer)
+	case domain.OutputFormatCheckstyle:
+		return f.writeCheckCheckstyle(result, writer)
+	default:
+		return fmt.Errorf("unsupported output format for check: %s", format)
+	}
+}
+
+// checkViolationsByFile groups violations by file in path order, keeping the
+// order of violations within a file. Project-level violations use the "" key.
+func checkViolationsByFile(violations []domain.CheckViolation) ([]string, map[string][]domain.CheckViolation) {
+	byFile := make(map[string][]domain.CheckViolation)
+	var files []string
+	for _, v := range violations {
+		file, _ := v.FileLine()
+		if _, ok := byFile[file]; !ok {
+			files = append(files, file)
+		}
+		byFile[file] = append(byFile[file], v)
+	}
+	sort.Strings(files)
+	return files, byFile
+}
+
+// checkedCategories returns the categories the check ran, in execution order
+func checkedCategories(summary domain.CheckSummary) []string {
+	var categories []string
+	if summary.ComplexityChecked {
+		categories = append(categories, "complexity")
+	}
+	if summary.DeadCodeChecked {
+		categories = append(categories, "deadcode")
+	}
+	if summary.CloneChecked {
+		categories = append(categories, "clone")
+	}
+	if summary.CBOChecked {
+		categories = append(categories, "cbo")
+	}
+	if summary.DepsChecked {
+		categories = append(categories, "deps")
+	}
+	if summary.TypeSafetyChecked {
+		categories = append(categories, "typesafety")
+	}
+	if summary.ReactChecked {
+		categories = append(categories, "react")
+	}
+	if summary.HealthScoreChecked {
+		categories = append(categories, "health")
+	}
+	return categories
+}
+
+// JUnit XML report structure
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",cdata"`
+}
+
+// writeCheckJUnit writes the check result as JUnit XML: one suite per file with
+// a failed test case per violation, and a project suite with a passing test
+// case per category without violations
+func (f *OutputFormatterImpl) writeCheckJUnit(result *domain.CheckResult, writer io.Writer) error {
+	files, byFile := checkViolationsByFile(result.Violations)
+
+	failedCategories := make(map[string]bool)
+	for _, v := range result.Violations {
+		failedCategories[v.Category] = true
+	}
+	project := junitTestSuite{Name: checkProjectSuite}
+	for _, category := range checkedCategories(result.Summary) {
+		if !failedCategories[category] {
+			project.TestCases = append(project.TestCases, junitTestCase{Name: category, ClassName: checkProjectSuite + "." + category})
+		}
+	}
+
+	suites := []junitTestSuite{project}
+	for _, file := range files {
+		suite := &suites[0]
+		if file != "" {
+			suites = append(suites, junitTestSuite{Name: file})
+			suite = &suites[len(suites)-1]
+		}
+		for _, v := range byFile[file] {
+			_, line := v.FileLine()
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      fmt.Sprintf("%s: %s", v.Rule, v.Message),
+				ClassName: checkProjectSuite + "." + v.Category,
+				File:      file,
+				Line:      line,
+				Failure: &junitFailure{
+					Message: v.Message,
+					Type:    v.Rule,
+					Body:    checkViolationDetails(v),
+				},
+			})
+		}
+	}
+	if len(suites[0].TestCases) == 0 {
+		suites = suites[1:]
+	}
+
+	report := junitTestSuites{
+		Name:   checkProjectSuite,
+		Time:   fmt.Sprintf("%.3f", float64(result.Duration)/1000),
+		Suites: suites,
+	}
+	for i := range report.Suites {
+		suite := &report.Suites[i]
+		suite.Tests = len(suite.TestCases)
+		for _, tc := range suite.TestCases {
+			if tc.Failure != nil {
+				suite.Failures++
+			}
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+
+	return writeXML(writer, report)
+}
+
+// checkViolationDetails describes a violation for JUnit failure bodies
+func checkViolationDetails(v domain.CheckViolation) string {
+	lines := []string{"Severity: " + v.Severity}
+	if v.Location != "" {
+		lines = append(lines, "Location: "+v.Location)
+	}
+	if v.Threshold != "" {
+		lines = append(lines, fmt.Sprintf("Actual: %s (threshold: %s)", v.Actual, v.Threshold))
+	} else if v.Actual != "" {
+		lines = append(lines, "Actual: "+v.Actual)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Checkstyle XML report structure
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// writeCheckCheckstyle writes the check result as Checkstyle XML, one error per
+// violation grouped by file
+func (f *OutputFormatterImpl) writeCheckCheckstyle(result *domain.CheckResult, writer io.Writer) error {
+	files, byFile := checkViolationsByFile(result.Violations)
+
+	report := checkstyleReport{Version: "4.3"}
+	for _, file := range files {
+		entry := checkstyleFile{Name: file}
+		if file == "" {
+			entry.Name = checkProjectFile
+		}
+		for _, v := range byFile[file] {
+			_, line := v.FileLine()
+			entry.Errors = append(entry.Errors, checkstyleError{
+				Line:     line,
+				Severity: checkstyleSeverity(v.Severity),
+				Message:  v.Message,
+				Source:   fmt.Sprintf("jscan.%s.%s", v.Category, v.Rule),
+			})
+		}
+		report.Files = append(report.Files, entry)
+	}
+
+	return writeXML(writer, report)
+}
+
+// checkstyleSeverity maps violation severities, including cycle severities, to
+// the Checkstyle levels error, warning and info
+func checkstyleSeverity(severity string) string {
+	switch severity {
+	case "warning", string(domain.CycleSeverityMedium):
+		return "warning"
+	case "info", string(domain.CycleSeverityLow):
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// writeXML writes an indented XML document with its header
+func writeXML(writer io.Writer, data interface{}) error {
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
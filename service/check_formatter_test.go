@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func testCheckResult() *domain.CheckResult {
+	return &domain.CheckResult{
+		Passed: false,
+		Violations: []domain.CheckViolation{
+			{Category: "complexity", Rule: "max-complexity", Severity: "error", Message: "Function 'b' has complexity 12", Location: "src/b.ts:8", Actual: "12", Threshold: "10"},
+			{Category: "complexity", Rule: "max-complexity", Severity: "error", Message: "Function 'a' has complexity 15", Location: "src/a.ts:3", Actual: "15", Threshold: "10"},
+			{Category: "complexity", Rule: "max-complexity", Severity: "error", Message: "Function 'c' has complexity 11", Location: "src/a.ts:20", Actual: "11", Threshold: "10"},
+			{Category: "deadcode", Rule: "no-dead-code", Severity: "warning", Message: "Found 2 warning-level dead code issues", Actual: "2", Threshold: "0"},
+		},
+		Summary: domain.CheckSummary{
+			FilesAnalyzed:     2,
+			TotalViolations:   4,
+			ComplexityChecked: true,
+			DeadCodeChecked:   true,
+			DepsChecked:       true,
+		},
+		Duration: 1500,
+	}
+}
+
+func TestOutputFormatterWriteCheckJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteCheck(testCheckResult(), domain.OutputFormatJUnit, &buf); err != nil {
+		t.Fatalf("WriteCheck failed: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JUnit XML: %v\n%s", err, buf.String())
+	}
+
+	if report.Tests != 5 || report.Failures != 4 || report.Time != "1.500" {
+		t.Errorf("Expected 5 tests, 4 failures in 1.500s, got %d, %d in %s", report.Tests, report.Failures, report.Time)
+	}
+
+	var names []string
+	for _, suite := range report.Suites {
+		names = append(names, suite.Name)
+	}
+	if strings.Join(names, ",") != "jscan,src/a.ts,src/b.ts" {
+		t.Fatalf("Expected project suite then one suite per file, got %v", names)
+	}
+
+	// The project suite holds the dead code violation and the passing deps check
+	project := report.Suites[0]
+	if project.Tests != 2 || project.Failures != 1 {
+		t.Errorf("Expected project suite with 2 tests and 1 failure, got %d and %d", project.Tests, project.Failures)
+	}
+	if project.TestCases[0].Name != "deps" || project.TestCases[0].Failure != nil {
+		t.Errorf("Expected passing deps test case, got %+v", project.TestCases[0])
+	}
+
+	fileSuite := report.Suites[1]
+	if fileSuite.Tests != 2 || fileSuite.Failures != 2 {
+		t.Errorf("Expected src/a.ts suite with 2 failures, got %d tests and %d failures", fileSuite.Tests, fileSuite.Failures)
+	}
+	tc := fileSuite.TestCases[0]
+	if tc.ClassName != "jscan.complexity" || tc.File != "src/a.ts" || tc.Line != 3 || tc.Failure == nil || tc.Failure.Type != "max-complexity" {
+		t.Errorf("Unexpected test case %+v", tc)
+	}
+	if !strings.Contains(tc.Failure.Body, "Actual: 15 (threshold: 10)") {
+		t.Errorf("Expected failure details, got %q", tc.Failure.Body)
+	}
+}
+
+func TestOutputFormatterWriteCheckCheckstyle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteCheck(testCheckResult(), domain.OutputFormatCheckstyle, &buf); err != nil {
+		t.Fatalf("WriteCheck failed: %v", err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid Checkstyle XML: %v\n%s", err, buf.String())
+	}
+
+	if len(report.Files) != 3 || report.Files[0].Name != "." || report.Files[1].Name != "src/a.ts" || report.Files[2].Name != "src/b.ts" {
+		t.Fatalf("Expected violations grouped by file, got %+v", report.Files)
+	}
+	if e := report.Files[0].Errors[0]; e.Severity != "warning" || e.Line != 0 || e.Source != "jscan.deadcode.no-dead-code" {
+		t.Errorf("Unexpected project-level error %+v", e)
+	}
+	errs := report.Files[1].Errors
+	if len(errs) != 2 || errs[0].Line != 3 || errs[1].Line != 20 || errs[0].Severity != "error" || errs[0].Source != "jscan.complexity.max-complexity" {
+		t.Errorf("Unexpected errors for src/a.ts: %+v", errs)
+	}
+}
+
+func TestOutputFormatterWriteCheckPassed(t *testing.T) {
+	result := &domain.CheckResult{Passed: true, Summary: domain.CheckSummary{ComplexityChecked: true}}
+
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteCheck(result, domain.OutputFormatCheckstyle, &buf); err != nil {
+		t.Fatalf("WriteCheck failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<checkstyle version="4.3"></checkstyle>`) {
+		t.Errorf("Expected empty Checkstyle report, got:\n%s", buf.String())
+	}
+
+	if err := NewOutputFormatter().WriteCheck(result, domain.OutputFormatCSV, &buf); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestCheckstyleSeverity(t *testing.T) {
+	tests := map[string]string{
+		"error":    "error",
+		"warning":  "warning",
+		"critical": "error",
+		"high":     "error",
+		"medium":   "warning",
+		"low":      "info",
+	}
+	for severity, expected := range tests {
+		if result := checkstyleSeverity(severity); result != expected {
+			t.Errorf("checkstyleSeverity(%q) = %q, want %q", severity, result, expected)
+		}
+	}
+}
@@ -3,13 +3,13 @@ package service
 import (
 	"context"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/ludo-technologies/jscan/domain"
 	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/config"
 	"github.com/ludo-technologies/jscan/internal/parser"
 	"github.com/ludo-technologies/jscan/internal/version"
 )
@@ -33,6 +33,28 @@ func NewCloneServiceWithDefaults() *CloneServiceImpl {
 	}
 }
 
+// CloneRequestFromConfig returns the default clone request for paths with the
+// clones section of the configuration applied
+func CloneRequestFromConfig(cfg *config.Config, paths []string) *domain.CloneRequest {
+	req := domain.DefaultCloneRequest()
+	req.Paths = paths
+	if cfg.Clones == nil {
+		return req
+	}
+	analysis := cfg.Clones.Analysis
+	if analysis.DetectionMode != "" {
+		req.DetectionMode = analysis.DetectionMode
+	}
+	if analysis.MinTokens > 0 {
+		req.MinTokens = analysis.MinTokens
+	}
+	req.StatementSequences = config.BoolValue(analysis.StatementSequences, req.StatementSequences)
+	if analysis.MinStatements > 0 {
+		req.MinStatements = analysis.MinStatements
+	}
+	return req
+}
+
 // DetectClones performs clone detection on the given request
 func (s *CloneServiceImpl) DetectClones(ctx context.Context, req *domain.CloneRequest) (*domain.CloneResponse, error) {
 	startTime := time.Now()
@@ -62,12 +84,26 @@ func (s *CloneServiceImpl) DetectClones(ctx context.Context, req *domain.CloneRe
 	}
 	config.IgnoreLiterals = req.IgnoreLiterals
 	config.IgnoreIdentifiers = req.IgnoreIdentifiers
+	if req.DetectionMode != "" {
+		config.DetectionMode = analyzer.DetectionMode(req.DetectionMode)
+	}
+	if req.MinTokens > 0 {
+		config.MinTokens = req.MinTokens
+	}
+	config.DetectStatementSequences = req.StatementSequences
+	if req.MinStatements > 0 {
+		config.MinStatements = req.MinStatements
+	}
+	useTokens := config.DetectionMode == analyzer.DetectionModeToken || config.DetectionMode == analyzer.DetectionModeHybrid
+	useFragments := config.DetectionMode != analyzer.DetectionModeToken
 
 	// Create clone detector with configured settings
 	detector := analyzer.NewCloneDetector(&config)
 
 	// Extract fragments from all files
 	var allFragments []*analyzer.CodeFragment
+	var allStreams []*analyzer.TokenStream
+	var allBlocks []*analyzer.StatementBlock
 	filesAnalyzed := 0
 	linesAnalyzed := 0
 	var errors []string
@@ -81,28 +117,48 @@ func (s *CloneServiceImpl) DetectClones(ctx context.Context, req *domain.CloneRe
 		}
 
 		// Read file
-		content, err := os.ReadFile(filePath)
+		content, err := readSource(req.Sources, filePath)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
 			continue
 		}
 
-		// Parse file
-		ast, err := parser.ParseForLanguage(filePath, content)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("[%s] Failed to parse: %v", filePath, err))
-			continue
+		// Tokenize file for token-based detection; in hybrid mode a file that fails to
+		// tokenize still has its fragments compared
+		if useTokens {
+			tokens, err := parser.Tokenize(filePath, content)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("[%s] Failed to tokenize: %v", filePath, err))
+				if !useFragments {
+					continue
+				}
+			} else {
+				allStreams = append(allStreams, &analyzer.TokenStream{FilePath: filePath, Tokens: tokens})
+			}
 		}
 
-		// Extract fragments from the AST
-		fragments := detector.ExtractFragments(ast.Body, filePath)
-		allFragments = append(allFragments, fragments...)
+		if useFragments {
+			// Parse file
+			ast, err := parseSource(req.ASTs, filePath, content)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("[%s] Failed to parse: %v", filePath, err))
+				continue
+			}
+
+			// Extract fragments from the AST
+			fragments := detector.ExtractFragments(ast.Body, filePath)
+			allFragments = append(allFragments, fragments...)
+
+			if config.DetectStatementSequences {
+				allBlocks = append(allBlocks, detector.ExtractStatementBlocks(ast.Body, filePath)...)
+			}
+		}
 
 		filesAnalyzed++
 		linesAnalyzed += countLines(content)
 	}
 
-	if len(allFragments) == 0 {
+	if len(allFragments) == 0 && len(allStreams) == 0 && len(allBlocks) == 0 {
 		// No fragments found, return empty response (or partial failure details)
 		response := &domain.CloneResponse{
 			Clones:      []*domain.Clone{},
@@ -127,20 +183,29 @@ func (s *CloneServiceImpl) DetectClones(ctx context.Context, req *domain.CloneRe
 		return response, nil
 	}
 
-	// Determine whether to use LSH acceleration
-	useLSH := domain.ShouldUseLSH(req.LSHEnabled, len(allFragments), req.LSHAutoThreshold)
-	if useLSH {
-		detector.SetUseLSH(true)
-	}
-
 	// Detect clones
 	var clonePairs []*domain.ClonePair
 	var cloneGroups []*domain.CloneGroup
 
-	if useLSH {
-		clonePairs, cloneGroups = detector.DetectClonesWithLSH(ctx, allFragments)
-	} else {
-		clonePairs, cloneGroups = detector.DetectClonesWithContext(ctx, allFragments)
+	switch config.DetectionMode {
+	case analyzer.DetectionModeToken:
+		clonePairs, cloneGroups = detector.DetectTokenClones(ctx, allStreams)
+	case analyzer.DetectionModeHybrid:
+		clonePairs, cloneGroups = detector.DetectClonesHybrid(ctx, allStreams, allFragments)
+	default:
+		// Determine whether to use LSH acceleration
+		useLSH := domain.ShouldUseLSH(req.LSHEnabled, len(allFragments), req.LSHAutoThreshold)
+		if useLSH {
+			detector.SetUseLSH(true)
+			clonePairs, cloneGroups = detector.DetectClonesWithLSH(ctx, allFragments)
+		} else {
+			clonePairs, cloneGroups = detector.DetectClonesWithContext(ctx, allFragments)
+		}
+	}
+
+	// Detect duplicated statement sequences inside otherwise different functions
+	if config.DetectStatementSequences && len(allBlocks) > 0 {
+		clonePairs, cloneGroups = detector.DetectStatementSequenceClones(ctx, allBlocks, clonePairs)
 	}
 
 	// Build statistics
@@ -161,6 +226,7 @@ func (s *CloneServiceImpl) DetectClones(ctx context.Context, req *domain.CloneRe
 		Statistics:  statistics,
 		Duration:    time.Since(startTime).Milliseconds(),
 		Success:     len(errors) == 0,
+		Warnings:    detector.Warnings(),
 	}
 	if len(errors) > 0 {
 		response.Error = strings.Join(errors, "; ")
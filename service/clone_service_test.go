@@ -8,8 +8,26 @@ import (
 	"testing"
 
 	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
 )
 
+func TestCloneRequestFromConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	req := CloneRequestFromConfig(cfg, []string{"a.js"})
+	if len(req.Paths) != 1 || req.StatementSequences || req.DetectionMode != domain.DefaultCloneRequest().DetectionMode {
+		t.Errorf("Expected the default request, got %+v", req)
+	}
+
+	cfg.Clones.Analysis.DetectionMode = "hybrid"
+	cfg.Clones.Analysis.MinTokens = 80
+	cfg.Clones.Analysis.StatementSequences = config.BoolPtr(true)
+	cfg.Clones.Analysis.MinStatements = 4
+	req = CloneRequestFromConfig(cfg, nil)
+	if req.DetectionMode != "hybrid" || req.MinTokens != 80 || !req.StatementSequences || req.MinStatements != 4 {
+		t.Errorf("Expected the configured clone analysis, got %+v", req)
+	}
+}
+
 func TestCloneServiceDetectClones_AllFilesFailReturnsError(t *testing.T) {
 	svc := NewCloneServiceWithDefaults()
 	req := domain.DefaultCloneRequest()
@@ -66,3 +84,42 @@ func TestCloneServiceDetectClones_PartialFailureReturnsResponseAndError(t *testi
 		t.Fatalf("expected response error to mention failing file, got: %q", resp.Error)
 	}
 }
+
+func TestCloneServiceDetectClones_TokenMode(t *testing.T) {
+	svc := NewCloneServiceWithDefaults()
+	req := domain.DefaultCloneRequest()
+	req.DetectionMode = "token"
+	req.MinTokens = 20
+
+	block := `
+  const result = {};
+  result.id = source.id;
+  result.name = source.firstName + " " + source.lastName;
+  result.email = source.email.toLowerCase();
+  result.active = source.status === "active";
+`
+	tempDir := t.TempDir()
+	file1 := filepath.Join(tempDir, "a.js")
+	file2 := filepath.Join(tempDir, "b.js")
+	if err := os.WriteFile(file1, []byte("function toDto(source) {"+block+"  return result;\n}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+	if err := os.WriteFile(file2, []byte("function handler(req, source) {\n  check(req);"+block+"  send(req, result);\n}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture file: %v", err)
+	}
+	req.Paths = []string{file1, file2}
+
+	resp, err := svc.DetectClones(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.ClonePairs) != 1 {
+		t.Fatalf("expected 1 clone pair, got %d", len(resp.ClonePairs))
+	}
+	if resp.ClonePairs[0].Type != domain.Type1Clone {
+		t.Errorf("expected Type-1 clone, got %s", resp.ClonePairs[0].Type)
+	}
+	if resp.Statistics.FilesAnalyzed != 2 {
+		t.Errorf("expected 2 files analyzed, got %d", resp.Statistics.FilesAnalyzed)
+	}
+}
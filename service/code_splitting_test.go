@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestDependencyGraphServiceCodeSplitting(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/main.ts":        "import { go } from './nav';\nimport { Admin } from './pages/Admin';\ngo(Admin);\n",
+		"src/nav.ts":         "import { format } from './format';\nexport const go = (x: unknown) => format(String(x));\nexport const settings = () => import('./pages/Settings');\nexport const admin = () => import('./pages/Admin');\n",
+		"src/format.ts":      "export const format = (s: string) => s;\n",
+		"src/pages/Admin.ts": "export const Admin = 1;\n",
+		"src/pages/Settings.ts": "import { format } from '../format';\nimport { chart } from './chart';\n" +
+			"export default function Settings() { return format(chart()); }\n",
+		"src/pages/chart.ts": "export const chart = () => '" + strings.Repeat("x", 2000) + "';\n",
+	}
+	writeTestFiles(t, dir, files)
+	var paths []string
+	for name := range files {
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	resp, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:         paths,
+		CodeSplitting: &domain.CodeSplittingOptions{},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	splitting := resp.CodeSplitting
+	if splitting == nil {
+		t.Fatal("Expected a code-splitting result")
+	}
+	if len(splitting.Chunks) != 3 || splitting.EntryChunks() != 1 || !strings.HasSuffix(splitting.Chunks[0].Root, "src/main.ts") {
+		t.Fatalf("Expected the main entry chunk and 2 lazy chunks, got %+v", splitting.Chunks)
+	}
+	settings := splitting.Chunks[1]
+	if !strings.HasSuffix(settings.Root, "pages/Settings.ts") || settings.OwnModules != 2 || settings.OwnSize < 2000 {
+		t.Errorf("Expected the settings chunk to defer the chart module, got %+v", settings)
+	}
+	if len(splitting.Mixed) != 2 || splitting.DefeatedSplits() != 1 || !strings.HasSuffix(splitting.Mixed[0].Module, "pages/Admin.ts") {
+		t.Errorf("Expected the Admin dynamic import to be defeated and format.ts to be shared, got %+v", splitting.Mixed)
+	}
+
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteDependencyGraph(resp, domain.OutputFormatText, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	for _, want := range []string{"Entry chunks: 1", "Lazy chunks: 2", "(1 dynamic imports defeated)", "dynamic import defeated, loaded statically via ", "KB deferred"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the text report to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := NewMarkdownFormatter(nil).WriteDependencyGraph(resp, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<b>Chunks</b>: 1 entry, 2 lazy") || !strings.Contains(buf.String(), "❌ dynamic import defeated") {
+		t.Errorf("Expected Markdown code-splitting sections, got:\n%s", buf.String())
+	}
+}
+
+func TestDependencyGraphServiceCodeSplitting_InvalidOptions(t *testing.T) {
+	_, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:         []string{"/repo/src/a.ts"},
+		CodeSplitting: &domain.CodeSplittingOptions{Entries: []string{" "}},
+	})
+	if err == nil {
+		t.Error("Expected an error for an empty entry pattern")
+	}
+}
+
+func TestFormatSourceSize(t *testing.T) {
+	tests := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KB", 3 * 1024 * 1024: "3.0 MB"}
+	for size, want := range tests {
+		if got := formatSourceSize(size); got != want {
+			t.Errorf("formatSourceSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// codeClimateEntry is an issue with the identity its fingerprint is derived from
+type codeClimateEntry struct {
+	issue domain.CodeClimateIssue
+	key   string
+}
+
+// writeAnalyzeCodeClimate writes the analysis findings as a Code Climate issue list
+// for the GitLab Code Quality report
+func (f *OutputFormatterImpl) writeAnalyzeCodeClimate(
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+	writer io.Writer,
+) error {
+	issues := BuildCodeClimateIssues(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+	return WriteJSON(writer, issues)
+}
+
+// BuildCodeClimateIssues converts analysis results into Code Climate issues, ordered
+// by path and line. Low risk functions and classes are not reported.
+func BuildCodeClimateIssues(
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+) []domain.CodeClimateIssue {
+	var entries []codeClimateEntry
+	if complexityResponse != nil {
+		entries = append(entries, complexityCodeClimateEntries(complexityResponse)...)
+	}
+	if deadCodeResponse != nil {
+		entries = append(entries, deadCodeCodeClimateEntries(deadCodeResponse)...)
+	}
+	if cloneResponse != nil {
+		entries = append(entries, cloneCodeClimateEntries(cloneResponse)...)
+	}
+	if cboResponse != nil {
+		entries = append(entries, couplingCodeClimateEntries(cboResponse)...)
+	}
+	if depsResponse != nil {
+		entries = append(entries, cycleCodeClimateEntries(depsResponse)...)
+	}
+
+	assignCodeClimateFingerprints(entries)
+
+	issues := make([]domain.CodeClimateIssue, 0, len(entries))
+	for _, entry := range entries {
+		issues = append(issues, entry.issue)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Location, issues[j].Location
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Lines.Begin != b.Lines.Begin {
+			return a.Lines.Begin < b.Lines.Begin
+		}
+		return issues[i].CheckName < issues[j].CheckName
+	})
+	return issues
+}
+
+// assignCodeClimateFingerprints hashes the check name and identity of each issue.
+// Repeated identities (e.g. several anonymous functions in one file) are told
+// apart by their order in the file, like report keys in jscan diff.
+func assignCodeClimateFingerprints(entries []codeClimateEntry) {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if a.issue.CheckName != b.issue.CheckName {
+			return a.issue.CheckName < b.issue.CheckName
+		}
+		if a.key != b.key {
+			return a.key < b.key
+		}
+		return a.issue.Location.Lines.Begin < b.issue.Location.Lines.Begin
+	})
+
+	occurrences := make(map[string]int, len(entries))
+	for _, i := range order {
+		identity := entries[i].issue.CheckName + "\x00" + entries[i].key
+		occurrences[identity]++
+		if n := occurrences[identity]; n > 1 {
+			identity = fmt.Sprintf("%s#%d", identity, n)
+		}
+		sum := sha256.Sum256([]byte(identity))
+		entries[i].issue.Fingerprint = hex.EncodeToString(sum[:16])
+	}
+}
+
+// newCodeClimateIssue creates an issue with the remediation points of a health score penalty
+func newCodeClimateIssue(checkName, description, category, severity string, location domain.CodeClimateLocation, penalty int) domain.CodeClimateIssue {
+	return domain.CodeClimateIssue{
+		Type:              "issue",
+		CheckName:         checkName,
+		Description:       description,
+		Categories:        []string{category},
+		Location:          location,
+		Severity:          severity,
+		RemediationPoints: penalty * domain.CodeClimateRemediationPointsPerPenalty,
+	}
+}
+
+// codeClimateLocation creates a location relative to the repository root; Code
+// Climate lines start at 1
+func codeClimateLocation(path string, begin, end int) domain.CodeClimateLocation {
+	begin = max(begin, 1)
+	return domain.CodeClimateLocation{
+		Path:  relativeReportPath(path),
+		Lines: domain.CodeClimateLines{Begin: begin, End: max(end, begin)},
+	}
+}
+
+// riskSeverity maps a risk level to a Code Climate severity
+func riskSeverity(risk domain.RiskLevel) string {
+	if risk == domain.RiskLevelHigh {
+		return domain.CodeClimateSeverityMajor
+	}
+	return domain.CodeClimateSeverityMinor
+}
+
+func complexityCodeClimateEntries(response *domain.ComplexityResponse) []codeClimateEntry {
+	var entries []codeClimateEntry
+	for _, fn := range response.Functions {
+		penalty := domain.ComplexityIssuePenalty(fn.RiskLevel)
+		if penalty == 0 {
+			continue
+		}
+		issue := newCodeClimateIssue("jscan/complexity",
+			fmt.Sprintf("Function '%s' has a cyclomatic complexity of %d", fn.Name, fn.Metrics.Complexity),
+			domain.CodeClimateCategoryComplexity, riskSeverity(fn.RiskLevel),
+			codeClimateLocation(fn.FilePath, fn.StartLine, fn.EndLine), penalty)
+		entries = append(entries, codeClimateEntry{issue: issue, key: issue.Location.Path + "::" + stableFunctionName(fn.Name)})
+	}
+	return entries
+}
+
+func deadCodeCodeClimateEntries(response *domain.DeadCodeResponse) []codeClimateEntry {
+	var entries []codeClimateEntry
+	add := func(filePath string, finding domain.DeadCodeFinding) {
+		category := domain.CodeClimateCategoryClarity
+		if strings.HasPrefix(finding.Reason, "unreachable") {
+			category = domain.CodeClimateCategoryBugRisk
+		}
+		severity := domain.CodeClimateSeverityInfo
+		switch finding.Severity {
+		case domain.DeadCodeSeverityCritical:
+			severity = domain.CodeClimateSeverityMajor
+		case domain.DeadCodeSeverityWarning:
+			severity = domain.CodeClimateSeverityMinor
+		}
+		description := finding.Description
+		if description == "" {
+			description = finding.Reason
+		}
+		issue := newCodeClimateIssue("jscan/dead-code/"+finding.Reason, description, category, severity,
+			codeClimateLocation(filePath, finding.Location.StartLine, finding.Location.EndLine),
+			domain.DeadCodeIssuePenalty(finding.Severity))
+		entries = append(entries, codeClimateEntry{issue: issue, key: deadCodeDiffEntry(relativeReportPath(filePath), finding).key})
+	}
+
+	for _, file := range response.Files {
+		for _, finding := range file.FileLevelFindings {
+			add(file.FilePath, finding)
+		}
+		for _, fn := range file.Functions {
+			for _, finding := range fn.Findings {
+				add(file.FilePath, finding)
+			}
+		}
+	}
+	return entries
+}
+
+// cloneCodeClimateEntries reports each copy of a clone group, with the other
+// copies as other locations. Clone pairs are reported when nothing was grouped.
+func cloneCodeClimateEntries(response *domain.CloneResponse) []codeClimateEntry {
+	groups := response.CloneGroups
+	if len(groups) == 0 {
+		for _, pair := range response.ClonePairs {
+			if pair != nil {
+				groups = append(groups, &domain.CloneGroup{
+					Clones:     []*domain.Clone{pair.Clone1, pair.Clone2},
+					Type:       pair.Type,
+					Similarity: pair.Similarity,
+				})
+			}
+		}
+	}
+
+	var entries []codeClimateEntry
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		var clones []*domain.Clone
+		for _, clone := range group.Clones {
+			if clone != nil && clone.Location != nil {
+				clones = append(clones, clone)
+			}
+		}
+		if len(clones) < 2 {
+			continue
+		}
+
+		// The group is identified by the files of its copies, like in jscan diff
+		var files []string
+		for _, clone := range clones {
+			files = append(files, relativeReportPath(clone.Location.FilePath))
+		}
+		sort.Strings(files)
+		groupKey := strings.Join(slices.Compact(files), ", ")
+
+		checkName := "jscan/duplication/" + strings.ToLower(group.Type.String())
+		others := "1 other location"
+		if len(clones) > 2 {
+			others = fmt.Sprintf("%d other locations", len(clones)-1)
+		}
+		for i, clone := range clones {
+			loc := clone.Location
+			issue := newCodeClimateIssue(checkName,
+				fmt.Sprintf("%d lines of similar code found in %s (%s, %.0f%% similar)",
+					loc.LineCount(), others, group.Type, group.Similarity*100),
+				domain.CodeClimateCategoryDuplication, domain.CodeClimateSeverityMinor,
+				codeClimateLocation(loc.FilePath, loc.StartLine, loc.EndLine), domain.CloneIssuePenalty(loc.LineCount()))
+			for j, other := range clones {
+				if j != i {
+					issue.OtherLocations = append(issue.OtherLocations,
+						codeClimateLocation(other.Location.FilePath, other.Location.StartLine, other.Location.EndLine))
+				}
+			}
+			entries = append(entries, codeClimateEntry{issue: issue, key: groupKey + "::" + issue.Location.Path})
+		}
+	}
+	return entries
+}
+
+func couplingCodeClimateEntries(response *domain.CBOResponse) []codeClimateEntry {
+	var entries []codeClimateEntry
+	for _, class := range response.Classes {
+		penalty := domain.CouplingIssuePenalty(class.RiskLevel)
+		if penalty == 0 {
+			continue
+		}
+		issue := newCodeClimateIssue("jscan/coupling",
+			fmt.Sprintf("Class '%s' depends on %d other classes (CBO)", class.Name, class.Metrics.CouplingCount),
+			domain.CodeClimateCategoryComplexity, riskSeverity(class.RiskLevel),
+			codeClimateLocation(class.FilePath, class.StartLine, class.EndLine), penalty)
+		entries = append(entries, codeClimateEntry{issue: issue, key: issue.Location.Path + "::" + class.Name})
+	}
+	return entries
+}
+
+// cycleCodeClimateEntries reports each circular dependency at the first import
+// recommended for removal, or at its first module
+func cycleCodeClimateEntries(response *domain.DependencyGraphResponse) []codeClimateEntry {
+	if response.Analysis == nil || response.Analysis.CircularDependencies == nil {
+		return nil
+	}
+
+	modulePath := func(id string) string {
+		if response.Graph != nil {
+			if node := response.Graph.GetNode(id); node != nil && node.FilePath != "" {
+				return node.FilePath
+			}
+		}
+		return id
+	}
+
+	var entries []codeClimateEntry
+	for _, cycle := range response.Analysis.CircularDependencies.CircularDependencies {
+		if len(cycle.Modules) == 0 {
+			continue
+		}
+
+		location := codeClimateLocation(modulePath(cycle.Modules[0]), 1, 1)
+		var suggestions []string
+		for i, edge := range cycle.BreakingEdges {
+			if i == 0 {
+				line := importLine(response.Graph, edge.From, edge.To)
+				location = codeClimateLocation(modulePath(edge.From), line, line)
+			}
+			suggestion := edge.Suggestion
+			if suggestion == "" {
+				suggestion = fmt.Sprintf("Remove the import of %s from %s", edge.To, edge.From)
+			}
+			suggestions = append(suggestions, "- "+suggestion)
+		}
+
+		severity := domain.CodeClimateSeverityInfo
+		switch cycle.Severity {
+		case domain.CycleSeverityCritical:
+			severity = domain.CodeClimateSeverityCritical
+		case domain.CycleSeverityHigh:
+			severity = domain.CodeClimateSeverityMajor
+		case domain.CycleSeverityMedium:
+			severity = domain.CodeClimateSeverityMinor
+		}
+
+		issue := newCodeClimateIssue("jscan/circular-dependency",
+			fmt.Sprintf("Circular dependency between %d modules: %s", len(cycle.Modules), strings.Join(cycle.Modules, " -> ")),
+			domain.CodeClimateCategoryBugRisk, severity, location, domain.MaxCyclesPenalty)
+		if len(suggestions) > 0 {
+			issue.Content = &domain.CodeClimateContent{
+				Body: "Break the cycle:\n\n" + strings.Join(suggestions, "\n"),
+			}
+		}
+
+		members := append([]string(nil), cycle.Modules...)
+		sort.Strings(members)
+		entries = append(entries, codeClimateEntry{issue: issue, key: strings.Join(members, ", ")})
+	}
+	return entries
+}
+
+// importLine returns the line of the import of to in from, or 0 if unknown
+func importLine(graph *domain.DependencyGraph, from, to string) int {
+	if graph == nil {
+		return 0
+	}
+	for _, edge := range graph.GetOutgoingEdges(from) {
+		if edge.To == to && edge.Location != nil {
+			return edge.Location.StartLine
+		}
+	}
+	return 0
+}
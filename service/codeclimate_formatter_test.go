@@ -0,0 +1,148 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func codeClimateTestComplexity(offset int) *domain.ComplexityResponse {
+	return &domain.ComplexityResponse{Functions: []domain.FunctionComplexity{
+		{Name: "render", FilePath: "src/a.ts", StartLine: 10 + offset, EndLine: 40 + offset, Metrics: domain.ComplexityMetrics{Complexity: 25}, RiskLevel: domain.RiskLevelHigh},
+		{Name: "anonymous_50", FilePath: "src/a.ts", StartLine: 50 + offset, EndLine: 60 + offset, Metrics: domain.ComplexityMetrics{Complexity: 12}, RiskLevel: domain.RiskLevelMedium},
+		{Name: "anonymous_70", FilePath: "src/a.ts", StartLine: 70 + offset, EndLine: 80 + offset, Metrics: domain.ComplexityMetrics{Complexity: 12}, RiskLevel: domain.RiskLevelMedium},
+		{Name: "simple", FilePath: "src/a.ts", StartLine: 90 + offset, EndLine: 92 + offset, Metrics: domain.ComplexityMetrics{Complexity: 1}, RiskLevel: domain.RiskLevelLow},
+	}}
+}
+
+func TestBuildCodeClimateIssues_Complexity(t *testing.T) {
+	issues := BuildCodeClimateIssues(codeClimateTestComplexity(0), nil, nil, nil, nil)
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues (low risk skipped), got %d", len(issues))
+	}
+
+	render := issues[0]
+	if render.Type != "issue" || render.CheckName != "jscan/complexity" || render.Severity != domain.CodeClimateSeverityMajor {
+		t.Errorf("Unexpected issue %+v", render)
+	}
+	if render.Categories[0] != domain.CodeClimateCategoryComplexity {
+		t.Errorf("Expected Complexity category, got %v", render.Categories)
+	}
+	if render.Location.Path != "src/a.ts" || render.Location.Lines.Begin != 10 || render.Location.Lines.End != 40 {
+		t.Errorf("Unexpected location %+v", render.Location)
+	}
+	if expected := domain.ComplexityPenaltyHigh * domain.CodeClimateRemediationPointsPerPenalty; render.RemediationPoints != expected {
+		t.Errorf("Expected %d remediation points, got %d", expected, render.RemediationPoints)
+	}
+
+	// Fingerprints are unique, and survive code moving down the file
+	moved := BuildCodeClimateIssues(codeClimateTestComplexity(5), nil, nil, nil, nil)
+	seen := make(map[string]bool)
+	for i, issue := range issues {
+		if seen[issue.Fingerprint] {
+			t.Errorf("Duplicate fingerprint %s", issue.Fingerprint)
+		}
+		seen[issue.Fingerprint] = true
+		if moved[i].Fingerprint != issue.Fingerprint {
+			t.Errorf("Fingerprint of %s changed after lines moved", issue.Description)
+		}
+	}
+}
+
+func TestBuildCodeClimateIssues_DeadCodeAndCycles(t *testing.T) {
+	deadCode := &domain.DeadCodeResponse{Files: []domain.FileDeadCode{{
+		FilePath: "src/b.ts",
+		FileLevelFindings: []domain.DeadCodeFinding{
+			{Location: domain.DeadCodeLocation{FilePath: "src/b.ts"}, Reason: "unused_import", Severity: domain.DeadCodeSeverityWarning, Description: "Imported name 'x' is never used"},
+		},
+		Functions: []domain.FunctionDeadCode{{Name: "run", Findings: []domain.DeadCodeFinding{
+			{Location: domain.DeadCodeLocation{FilePath: "src/b.ts", StartLine: 8, EndLine: 9}, FunctionName: "run", Reason: "unreachable_after_return", Severity: domain.DeadCodeSeverityCritical, Description: "Code after return"},
+		}}},
+	}}}
+
+	graph := domain.NewDependencyGraph()
+	graph.AddNode(&domain.ModuleNode{ID: "src/a.ts", FilePath: "src/a.ts"})
+	graph.AddNode(&domain.ModuleNode{ID: "src/b.ts", FilePath: "src/b.ts"})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/a.ts", To: "src/b.ts", Location: &domain.SourceLocation{FilePath: "src/a.ts", StartLine: 3}})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/b.ts", To: "src/a.ts", Location: &domain.SourceLocation{FilePath: "src/b.ts", StartLine: 2}})
+	deps := &domain.DependencyGraphResponse{
+		Graph: graph,
+		Analysis: &domain.DependencyAnalysisResult{CircularDependencies: &domain.CircularDependencyAnalysis{
+			CircularDependencies: []domain.CircularDependency{{
+				Modules:       []string{"src/a.ts", "src/b.ts"},
+				Severity:      domain.CycleSeverityHigh,
+				BreakingEdges: []domain.CycleBreakingEdge{{From: "src/b.ts", To: "src/a.ts", Suggestion: "Remove the import"}},
+			}},
+		}},
+	}
+
+	issues := BuildCodeClimateIssues(nil, deadCode, nil, nil, deps)
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d", len(issues))
+	}
+
+	byCheck := make(map[string]domain.CodeClimateIssue)
+	for _, issue := range issues {
+		byCheck[issue.CheckName] = issue
+	}
+
+	unused := byCheck["jscan/dead-code/unused_import"]
+	if unused.Categories[0] != domain.CodeClimateCategoryClarity || unused.Severity != domain.CodeClimateSeverityMinor || unused.Location.Lines.Begin != 1 {
+		t.Errorf("Unexpected unused import issue %+v", unused)
+	}
+	unreachable := byCheck["jscan/dead-code/unreachable_after_return"]
+	if unreachable.Categories[0] != domain.CodeClimateCategoryBugRisk || unreachable.Severity != domain.CodeClimateSeverityMajor {
+		t.Errorf("Unexpected unreachable code issue %+v", unreachable)
+	}
+	if expected := domain.MaxCriticalPenalty * domain.CodeClimateRemediationPointsPerPenalty; unreachable.RemediationPoints != expected {
+		t.Errorf("Expected %d remediation points, got %d", expected, unreachable.RemediationPoints)
+	}
+
+	// Cycles are reported at the import to remove
+	cycle := byCheck["jscan/circular-dependency"]
+	if cycle.Location.Path != "src/b.ts" || cycle.Location.Lines.Begin != 2 || cycle.Severity != domain.CodeClimateSeverityMajor {
+		t.Errorf("Unexpected cycle issue %+v", cycle)
+	}
+	if cycle.Content == nil || !strings.Contains(cycle.Content.Body, "Remove the import") {
+		t.Errorf("Expected breaking suggestion in content, got %+v", cycle.Content)
+	}
+}
+
+func TestBuildCodeClimateIssues_ClonePairs(t *testing.T) {
+	clone := func(path string, line int) *domain.Clone {
+		return &domain.Clone{Location: &domain.CloneLocation{FilePath: path, StartLine: line, EndLine: line + 19}}
+	}
+	clones := &domain.CloneResponse{ClonePairs: []*domain.ClonePair{
+		{Clone1: clone("src/a.ts", 1), Clone2: clone("src/b.ts", 30), Type: domain.Type2Clone, Similarity: 0.95},
+	}}
+
+	issues := BuildCodeClimateIssues(nil, nil, clones, nil, nil)
+	if len(issues) != 2 {
+		t.Fatalf("Expected one issue per copy, got %d", len(issues))
+	}
+	first := issues[0]
+	if first.CheckName != "jscan/duplication/type-2" || first.Categories[0] != domain.CodeClimateCategoryDuplication {
+		t.Errorf("Unexpected clone issue %+v", first)
+	}
+	if len(first.OtherLocations) != 1 || first.OtherLocations[0].Path != "src/b.ts" || first.OtherLocations[0].Lines.Begin != 30 {
+		t.Errorf("Expected the other copy as other location, got %+v", first.OtherLocations)
+	}
+	if expected := domain.CloneIssuePenalty(20) * domain.CodeClimateRemediationPointsPerPenalty; first.RemediationPoints != expected {
+		t.Errorf("Expected %d remediation points, got %d", expected, first.RemediationPoints)
+	}
+	if issues[0].Fingerprint == issues[1].Fingerprint {
+		t.Error("Expected distinct fingerprints for the two copies")
+	}
+}
+
+func TestOutputFormatterWriteAnalyzeCodeClimate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteAnalyze(nil, nil, nil, nil, nil, domain.OutputFormatCodeClimate, &buf, 0); err != nil {
+		t.Fatalf("WriteAnalyze failed: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty issue list, got %q", buf.String())
+	}
+}
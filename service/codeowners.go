@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// codeOwnersLocations are the places GitHub and GitLab look for a CODEOWNERS file,
+// relative to the repository root, in order of precedence
+var codeOwnersLocations = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+// CodeOwners maps files to their owners using the rules of a CODEOWNERS file
+type CodeOwners struct {
+	path  string
+	root  string
+	rules []codeOwnersRule
+}
+
+// codeOwnersRule is a single CODEOWNERS line; a rule without owners unassigns the files it matches
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// FindCodeOwners looks for a CODEOWNERS file in dir and its parents, stopping at the
+// repository root, and parses the first one found
+func FindCodeOwners(dir string) (*CodeOwners, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(current); err == nil && !info.IsDir() {
+		current = filepath.Dir(current)
+	}
+
+	for {
+		for _, location := range codeOwnersLocations {
+			path := filepath.Join(current, location)
+			file, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			owners, err := ParseCodeOwners(file, current)
+			file.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			owners.path = path
+			return owners, nil
+		}
+
+		// The repository root is as far as a CODEOWNERS file applies
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	return nil, fmt.Errorf("no CODEOWNERS file found for %s", dir)
+}
+
+// ParseCodeOwners parses CODEOWNERS rules whose patterns are relative to root
+func ParseCodeOwners(r io.Reader, root string) (*CodeOwners, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	owners := &CodeOwners{root: absRoot}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// GitLab sections ([Section] @owner) only group rules
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern, err := compileCodeOwnersPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		var ruleOwners []string
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			ruleOwners = append(ruleOwners, field)
+		}
+		owners.rules = append(owners.rules, codeOwnersRule{pattern: pattern, owners: ruleOwners})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// Path returns the CODEOWNERS file the rules were read from, if any
+func (c *CodeOwners) Path() string {
+	return c.path
+}
+
+// Owners returns the owners of file; the last matching rule wins, and files outside
+// the repository or matched by no rule have no owners
+func (c *CodeOwners) Owners(file string) []string {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil
+	}
+	rel, err := filepath.Rel(c.root, absFile)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(rel) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// compileCodeOwnersPattern converts a gitignore-style CODEOWNERS pattern to a regular
+// expression matching slash-separated paths relative to the repository root
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	// A leading or inner slash anchors the pattern to the root; otherwise it matches at any depth
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" matches zero or more directories
+					i++
+					expr.WriteString("(?:.*/)?")
+				} else {
+					expr.WriteString(".*")
+				}
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	// A pattern matching a directory owns everything below it, except that a
+	// trailing "/*" only owns the files directly in the directory
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "**"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(expr.String())
+}
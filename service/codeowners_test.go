@@ -0,0 +1,82 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodeOwners_Owners(t *testing.T) {
+	root := t.TempDir()
+	rules := `# Default owners
+*                   @org/all
+*.ts                @org/typescript
+/src/a/             @org/team-a @alice
+src/b/c/**          @org/team-c  # nested
+docs/*              @org/docs
+/src/b/generated.js
+`
+	owners, err := ParseCodeOwners(strings.NewReader(rules), root)
+	if err != nil {
+		t.Fatalf("ParseCodeOwners failed: %v", err)
+	}
+
+	tests := []struct {
+		file     string
+		expected []string
+	}{
+		{"index.js", []string{"@org/all"}},
+		{"lib/util.ts", []string{"@org/typescript"}},
+		{"src/a/x.ts", []string{"@org/team-a", "@alice"}},
+		{"src/a/deep/y.js", []string{"@org/team-a", "@alice"}},
+		{"lib/src/a/z.js", []string{"@org/all"}},
+		{"src/b/c/d/w.js", []string{"@org/team-c"}},
+		{"docs/guide.js", []string{"@org/docs"}},
+		{"docs/nested/guide.js", []string{"@org/all"}},
+		{"src/b/generated.js", nil},
+	}
+	for _, tt := range tests {
+		got := owners.Owners(filepath.Join(root, filepath.FromSlash(tt.file)))
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Owners(%s) = %v, want %v", tt.file, got, tt.expected)
+		}
+	}
+
+	if got := owners.Owners(filepath.Join(filepath.Dir(root), "outside.js")); got != nil {
+		t.Errorf("Expected no owners outside the repository, got %v", got)
+	}
+}
+
+func TestFindCodeOwners(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{".git", ".github", filepath.Join("src", "app")} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(root, ".github", "CODEOWNERS")
+	if err := os.WriteFile(path, []byte("/src/ @org/web\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	owners, err := FindCodeOwners(filepath.Join(root, "src", "app"))
+	if err != nil {
+		t.Fatalf("FindCodeOwners failed: %v", err)
+	}
+	if owners.Path() != path {
+		t.Errorf("Expected %s, got %s", path, owners.Path())
+	}
+	if got := owners.Owners(filepath.Join(root, "src", "app", "main.js")); !reflect.DeepEqual(got, []string{"@org/web"}) {
+		t.Errorf("Unexpected owners %v", got)
+	}
+
+	// The search stops at the repository root
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindCodeOwners(filepath.Join(root, "src")); err == nil {
+		t.Error("Expected an error without a CODEOWNERS file")
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/ludo-technologies/jscan/domain"
 	"github.com/ludo-technologies/jscan/internal/analyzer"
 	"github.com/ludo-technologies/jscan/internal/config"
-	"github.com/ludo-technologies/jscan/internal/parser"
 	"github.com/ludo-technologies/jscan/internal/version"
 )
 
@@ -109,15 +108,19 @@ func (s *ComplexityServiceImpl) analyzeFile(ctx context.Context, filePath string
 	var warnings []string
 	var errors []string
 
-	// Parse the file
-	content, err := s.readFile(filePath)
+	// Parse the file, preferring the in-memory source of the request
+	content, ok := req.Sources[filePath]
+	var err error
+	if !ok {
+		content, err = s.readFile(filePath)
+	}
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
 		return functions, warnings, errors
 	}
 
-	// Parse JavaScript/TypeScript
-	ast, err := parser.ParseForLanguage(filePath, content)
+	// Parse JavaScript/TypeScript, unless the request holds the AST
+	ast, err := parseSource(req.ASTs, filePath, content)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("[%s] Failed to parse: %v", filePath, err))
 		return functions, warnings, errors
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ludo-technologies/jscan/domain"
 	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/parser"
 )
 
 func TestNewComplexityService(t *testing.T) {
@@ -152,6 +153,27 @@ function complex(x) {
 	}
 }
 
+func TestComplexityService_Analyze_ParsedAST(t *testing.T) {
+	ast, err := parser.ParseForLanguage("a.js", []byte("function parsed(x) { return x ? 1 : 0; }\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewComplexityService(&config.ComplexityConfig{LowThreshold: 5, MediumThreshold: 10, ReportUnchanged: true})
+	resp, err := service.Analyze(context.Background(), domain.ComplexityRequest{
+		Paths:   []string{"a.js"},
+		Sources: map[string][]byte{"a.js": []byte("function source() {}\n")},
+		ASTs:    map[string]*parser.Node{"a.js": ast},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	// The AST of the request is analyzed instead of parsing the source
+	if len(resp.Functions) != 1 || resp.Functions[0].Name != "parsed" {
+		t.Errorf("Expected the function of the AST, got %+v", resp.Functions)
+	}
+}
+
 func TestComplexityService_Analyze_ContextCancellation(t *testing.T) {
 	cfg := &config.ComplexityConfig{
 		LowThreshold:    5,
@@ -463,6 +485,31 @@ func TestComplexityService_readFile_NonExistent(t *testing.T) {
 	}
 }
 
+func TestComplexityService_Analyze_InMemorySources(t *testing.T) {
+	cfg := &config.ComplexityConfig{LowThreshold: 9, MediumThreshold: 19}
+	service := NewComplexityService(cfg)
+
+	// The file does not exist on disk; its content comes from the request
+	path := filepath.Join(t.TempDir(), "virtual.js")
+	response, err := service.Analyze(context.Background(), domain.ComplexityRequest{
+		Paths: []string{path},
+		Sources: map[string][]byte{
+			path: []byte("function pick(a) { if (a) { return 1; } return 2; }"),
+		},
+		LowThreshold:    9,
+		MediumThreshold: 19,
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(response.Functions) != 1 || response.Functions[0].Name != "pick" {
+		t.Fatalf("expected function pick, got %+v", response.Functions)
+	}
+	if response.Functions[0].Metrics.Complexity != 2 {
+		t.Errorf("expected complexity 2, got %d", response.Functions[0].Metrics.Complexity)
+	}
+}
+
 func TestComplexityService_Analyze_WithProgress(t *testing.T) {
 	// Create a temp JS file
 	tempDir := t.TempDir()
@@ -3,13 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
-	"os"
 	"sort"
 	"time"
 
 	"github.com/ludo-technologies/jscan/domain"
 	"github.com/ludo-technologies/jscan/internal/analyzer"
-	"github.com/ludo-technologies/jscan/internal/parser"
 	"github.com/ludo-technologies/jscan/internal/version"
 )
 
@@ -51,6 +49,7 @@ func AnalyzeDeadCodeWithTask(ctx context.Context, req domain.DeadCodeRequest, ta
 	moduleAnalyzer := analyzer.NewModuleAnalyzer(nil)
 	allModuleInfos := make(map[string]*domain.ModuleInfo)
 	analyzedFiles := make(map[string]bool)
+	typeUsages := make(map[string]*analyzer.TypeUsage)
 	unusedFuncDedup := make(map[string]map[int]bool) // filePath -> startLine -> true
 
 	addFileLevelFinding := func(f domain.DeadCodeFinding) {
@@ -102,14 +101,14 @@ func AnalyzeDeadCodeWithTask(ctx context.Context, req domain.DeadCodeRequest, ta
 
 		analyzedFiles[filePath] = true
 
-		content, err := os.ReadFile(filePath)
+		content, err := readSource(req.Sources, filePath)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("[%s] failed to read file: %v", filePath, err))
 			incrementTask()
 			continue
 		}
 
-		ast, err := parser.ParseForLanguage(filePath, content)
+		ast, err := parseSource(req.ASTs, filePath, content)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("[%s] failed to parse file: %v", filePath, err))
 			incrementTask()
@@ -136,6 +135,7 @@ func AnalyzeDeadCodeWithTask(ctx context.Context, req domain.DeadCodeRequest, ta
 		} else if moduleInfo != nil {
 			allModuleInfos[filePath] = moduleInfo
 		}
+		typeUsages[filePath] = analyzer.CollectTypeUsage(ast)
 
 		var fileFunctions []domain.FunctionDeadCode
 		var fileLevelFindings []domain.DeadCodeFinding
@@ -144,7 +144,7 @@ func AnalyzeDeadCodeWithTask(ctx context.Context, req domain.DeadCodeRequest, ta
 		fileTotalBlocks := 0
 
 		if moduleInfo != nil {
-			unusedImports := analyzer.DetectUnusedImports(ast, moduleInfo, filePath)
+			unusedImports := analyzer.DetectUnusedImportsInSource(ast, moduleInfo, filePath, content)
 			for _, finding := range unusedImports {
 				f := domain.DeadCodeFinding{
 					Location: domain.DeadCodeLocation{
@@ -317,6 +317,27 @@ func AnalyzeDeadCodeWithTask(ctx context.Context, req domain.DeadCodeRequest, ta
 		addFileLevelFinding(f)
 	}
 
+	unusedTypes := analyzer.DetectUnusedTypes(allModuleInfos, typeUsages, graph)
+	for _, finding := range unusedTypes {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("dead code analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		f := domain.DeadCodeFinding{
+			Location: domain.DeadCodeLocation{
+				FilePath:  finding.FilePath,
+				StartLine: finding.StartLine,
+				EndLine:   finding.EndLine,
+			},
+			Reason:      string(finding.Reason),
+			Severity:    domain.DeadCodeSeverity(finding.Severity),
+			Description: finding.Description,
+		}
+		addFileLevelFinding(f)
+	}
+
 	orphanFindings := analyzer.DetectOrphanFiles(allModuleInfos, graph)
 	for _, finding := range orphanFindings {
 		select {
@@ -49,6 +49,32 @@ func (s *DependencyGraphServiceImpl) Analyze(ctx context.Context, req domain.Dep
 	var warnings []string
 	var errors []string
 
+	if req.Query != nil {
+		if err := req.Query.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if req.TemporalCoupling != nil {
+		if err := req.TemporalCoupling.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if req.Packages != nil {
+		if err := req.Packages.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if req.Barrels != nil {
+		if err := req.Barrels.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if req.CodeSplitting != nil {
+		if err := req.CodeSplitting.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Apply request options to config
 	config := *s.graphBuilderConfig
 	if req.IncludeExternal != nil {
@@ -59,7 +85,7 @@ func (s *DependencyGraphServiceImpl) Analyze(ctx context.Context, req domain.Dep
 	}
 
 	// Parse all files
-	asts, parseWarnings, parseErrors := s.parseFiles(ctx, req.Paths)
+	asts, parseWarnings, parseErrors := s.parseFiles(ctx, req.Paths, req.Sources)
 	warnings = append(warnings, parseWarnings...)
 	errors = append(errors, parseErrors...)
 
@@ -76,7 +102,7 @@ func (s *DependencyGraphServiceImpl) Analyze(ctx context.Context, req domain.Dep
 
 	// Build dependency graph
 	graphBuilder := analyzer.NewDependencyGraphBuilder(&config)
-	graph, err := graphBuilder.BuildGraphFromASTs(asts)
+	moduleResult, err := graphBuilder.AnalyzeModules(asts)
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("Failed to build dependency graph: %v", err))
 		return &domain.DependencyGraphResponse{
@@ -89,6 +115,8 @@ func (s *DependencyGraphServiceImpl) Analyze(ctx context.Context, req domain.Dep
 		}, nil
 	}
 
+	graph := graphBuilder.BuildGraph(moduleResult)
+
 	// Detect cycles
 	var circularDeps *domain.CircularDependencyAnalysis
 	if req.DetectCycles == nil || *req.DetectCycles {
@@ -118,25 +146,72 @@ func (s *DependencyGraphServiceImpl) Analyze(ctx context.Context, req domain.Dep
 	// Build analysis result
 	analysis := s.buildAnalysisResult(graph, circularDeps, couplingAnalysis, moduleMetrics, maxDepth)
 
+	// Evaluate query on the built graph
+	var queryResult *domain.DependencyQueryResult
+	if req.Query != nil {
+		queryResult, err = analyzer.NewDependencyQueryEngine(graph).Evaluate(*req.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compare the git co-change history with the graph
+	var temporalCoupling *domain.TemporalCouplingResult
+	if req.TemporalCoupling != nil {
+		temporalCoupling, err = AnalyzeTemporalCoupling(ctx, graph, *req.TemporalCoupling)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compare the package imports with package.json
+	var packages *domain.PackageHygieneResult
+	if req.Packages != nil {
+		var packageWarnings []string
+		packages, packageWarnings, err = AnalyzePackageHygiene(asts, req.Sources, *req.Packages)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, packageWarnings...)
+	}
+
+	// Resolve the imports through barrel files
+	var barrels *domain.BarrelResult
+	if req.Barrels != nil {
+		barrels = analyzer.NewBarrelAnalyzer(graphBuilder, graph, moduleResult, *req.Barrels).Analyze()
+	}
+
+	// Split the graph into the chunks of the entry points and dynamic imports
+	var codeSplitting *domain.CodeSplittingResult
+	if req.CodeSplitting != nil {
+		sizes := moduleSizes(req.Sources, graph)
+		codeSplitting, err = analyzer.NewCodeSplittingAnalyzer(graph, sizes, *req.CodeSplitting).Analyze()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &domain.DependencyGraphResponse{
-		Graph:       graph,
-		Analysis:    analysis,
-		Warnings:    warnings,
-		Errors:      errors,
-		GeneratedAt: time.Now().Format(time.RFC3339),
-		Version:     version.GetVersion(),
+		Graph:            graph,
+		Analysis:         analysis,
+		Query:            queryResult,
+		TemporalCoupling: temporalCoupling,
+		Packages:         packages,
+		Barrels:          barrels,
+		CodeSplitting:    codeSplitting,
+		Warnings:         warnings,
+		Errors:           errors,
+		GeneratedAt:      time.Now().Format(time.RFC3339),
+		Version:          version.GetVersion(),
 	}, nil
 }
 
-// parseFiles parses all input files and returns their ASTs
-func (s *DependencyGraphServiceImpl) parseFiles(ctx context.Context, paths []string) (map[string]*parser.Node, []string, []string) {
+// parseFiles parses all input files, preferring their in-memory sources, and returns their ASTs
+func (s *DependencyGraphServiceImpl) parseFiles(ctx context.Context, paths []string, sources map[string][]byte) (map[string]*parser.Node, []string, []string) {
 	asts := make(map[string]*parser.Node)
 	var warnings []string
 	var errors []string
 
-	p := parser.NewParser()
-	defer p.Close()
-
 	for _, filePath := range paths {
 		// Check context cancellation
 		select {
@@ -146,14 +221,15 @@ func (s *DependencyGraphServiceImpl) parseFiles(ctx context.Context, paths []str
 		}
 
 		// Read file
-		content, err := os.ReadFile(filePath)
+		content, err := readSource(sources, filePath)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("Failed to read %s: %v", filePath, err))
 			continue
 		}
 
-		// Parse file
-		ast, err := p.ParseString(string(content))
+		// Parse file with the grammar matching its extension so that
+		// TypeScript type-only imports are recognized
+		ast, err := parser.ParseForLanguage(filePath, content)
 		if err != nil {
 			warnings = append(warnings, fmt.Sprintf("Failed to parse %s: %v", filePath, err))
 			continue
@@ -165,6 +241,21 @@ func (s *DependencyGraphServiceImpl) parseFiles(ctx context.Context, paths []str
 	return asts, warnings, errors
 }
 
+// moduleSizes returns the source size of the project modules of the graph, preferring
+// the in-memory sources; modules whose size cannot be read count as empty
+func moduleSizes(sources map[string][]byte, graph *domain.DependencyGraph) map[string]int64 {
+	sizes := make(map[string]int64, len(graph.Nodes))
+	for id, node := range graph.Nodes {
+		if node.IsExternal || node.FilePath == "" {
+			continue
+		}
+		if size, err := sourceSize(sources, node.FilePath); err == nil {
+			sizes[id] = size
+		}
+	}
+	return sizes
+}
+
 // buildAnalysisResult builds a DependencyAnalysisResult from the analysis components
 func (s *DependencyGraphServiceImpl) buildAnalysisResult(
 	graph *domain.DependencyGraph,
@@ -297,10 +388,7 @@ func (s *DependencyGraphServiceImpl) AnalyzeSingleFile(ctx context.Context, file
 	}
 
 	// Parse file
-	p := parser.NewParser()
-	defer p.Close()
-
-	ast, err := p.ParseString(string(content))
+	ast, err := parser.ParseForLanguage(filePath, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse file: %w", err)
 	}
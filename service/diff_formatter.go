@@ -0,0 +1,339 @@
+package service
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// maxDiffTextItems is the number of items listed per category in text and Markdown diffs
+const maxDiffTextItems = 50
+
+// WriteDiff writes the comparison of two analysis reports in the specified format
+func (f *OutputFormatterImpl) WriteDiff(response *domain.DiffResponse, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatJSON:
+		return WriteJSON(writer, response)
+	case domain.OutputFormatText:
+		return f.writeDiffText(response, writer)
+	case domain.OutputFormatMarkdown:
+		return f.writeDiffMarkdown(response, writer)
+	case domain.OutputFormatHTML:
+		return f.writeDiffHTML(response, writer)
+	default:
+		return fmt.Errorf("unsupported output format for diff report: %s", format)
+	}
+}
+
+// diffSection is the items of one category, as listed by the text and Markdown reports
+type diffSection struct {
+	Summary domain.DiffCategorySummary
+	Items   []domain.DiffItem
+	Omitted int
+}
+
+// diffSections groups the items of a response by category, listing at most limit
+// items per category (limit <= 0 lists all)
+func diffSections(response *domain.DiffResponse, limit int) []diffSection {
+	sections := make([]diffSection, 0, len(response.Categories))
+	for _, summary := range response.Categories {
+		section := diffSection{Summary: summary}
+		for _, item := range response.Items {
+			if item.Category != summary.Category {
+				continue
+			}
+			if limit > 0 && len(section.Items) >= limit {
+				section.Omitted++
+				continue
+			}
+			section.Items = append(section.Items, item)
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// diffItemLocation formats the location of an item, empty for cycles
+func diffItemLocation(item domain.DiffItem) string {
+	if item.FilePath == "" {
+		return ""
+	}
+	if item.Line > 0 {
+		return fmt.Sprintf("%s:%d", item.FilePath, item.Line)
+	}
+	return item.FilePath
+}
+
+// diffReportLabel describes one compared report
+func diffReportLabel(info domain.DiffReportInfo) string {
+	label := info.Path
+	if info.Version != "" {
+		label += " (jscan " + info.Version
+		if info.GeneratedAt != "" {
+			label += ", " + info.GeneratedAt
+		}
+		label += ")"
+	}
+	return label
+}
+
+// writeDiffText writes the report comparison as plain text
+func (f *OutputFormatterImpl) writeDiffText(response *domain.DiffResponse, writer io.Writer) error {
+	summary := response.Summary
+	fmt.Fprintf(writer, "\n=== Analysis Diff ===\n\n")
+	fmt.Fprintf(writer, "Old: %s\n", diffReportLabel(response.Old))
+	fmt.Fprintf(writer, "New: %s\n\n", diffReportLabel(response.New))
+
+	fmt.Fprintln(writer, "Summary:")
+	fmt.Fprintf(writer, "  Health score: %d (%s) -> %d (%s) (%+d)\n",
+		summary.ScoreBefore, summary.GradeBefore, summary.ScoreAfter, summary.GradeAfter, summary.ScoreDelta)
+	fmt.Fprintf(writer, "  Changes: %d added, %d removed, %d worsened, %d improved\n",
+		summary.Added, summary.Removed, summary.Worsened, summary.Improved)
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "Categories:")
+	fmt.Fprintf(writer, "  %-22s %-16s %6s %8s %9s %9s\n", "CATEGORY", "SCORE", "ADDED", "REMOVED", "WORSENED", "IMPROVED")
+	for _, c := range response.Categories {
+		score := fmt.Sprintf("%d -> %d (%+d)", c.ScoreBefore, c.ScoreAfter, c.ScoreDelta)
+		if !c.Compared {
+			fmt.Fprintf(writer, "  %-22s %-16s %6s %8s %9s %9s\n", c.Label, score, "-", "-", "-", "-")
+			continue
+		}
+		fmt.Fprintf(writer, "  %-22s %-16s %6d %8d %9d %9d\n", c.Label, score, c.Added, c.Removed, c.Worsened, c.Improved)
+	}
+	fmt.Fprintln(writer)
+
+	for _, section := range diffSections(response, maxDiffTextItems) {
+		if len(section.Items) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "%s:\n", section.Summary.Label)
+		for _, item := range section.Items {
+			fmt.Fprintf(writer, "  %-9s %s", item.Status, item.Name)
+			if location := diffItemLocation(item); location != "" {
+				fmt.Fprintf(writer, " (%s)", location)
+			}
+			fmt.Fprintf(writer, ": %s\n", item.Detail)
+		}
+		if section.Omitted > 0 {
+			fmt.Fprintf(writer, "  ... and %d more (use --format json for the full list)\n", section.Omitted)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.Warnings) > 0 {
+		fmt.Fprintln(writer, "Warnings:")
+		for _, warning := range response.Warnings {
+			fmt.Fprintf(writer, "  %s\n", warning)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return nil
+}
+
+// writeDiffMarkdown writes the report comparison as Markdown, e.g. for a PR comment
+func (f *OutputFormatterImpl) writeDiffMarkdown(response *domain.DiffResponse, writer io.Writer) error {
+	summary := response.Summary
+	fmt.Fprintf(writer, "## jscan Analysis Diff\n\n")
+	fmt.Fprintf(writer, "**Health score:** %d (%s) → %d (%s) (%+d) %s\n\n",
+		summary.ScoreBefore, summary.GradeBefore, summary.ScoreAfter, summary.GradeAfter, summary.ScoreDelta,
+		scoreIndicator(summary.ScoreAfter))
+	fmt.Fprintf(writer, "**Changes:** %d added, %d removed, %d worsened, %d improved\n\n",
+		summary.Added, summary.Removed, summary.Worsened, summary.Improved)
+
+	fmt.Fprintln(writer, "| Category | Score | Added | Removed | Worsened | Improved |")
+	fmt.Fprintln(writer, "|---|---|---:|---:|---:|---:|")
+	for _, c := range response.Categories {
+		score := fmt.Sprintf("%d → %d (%+d)", c.ScoreBefore, c.ScoreAfter, c.ScoreDelta)
+		if !c.Compared {
+			fmt.Fprintf(writer, "| %s | %s | - | - | - | - |\n", c.Label, score)
+			continue
+		}
+		fmt.Fprintf(writer, "| %s | %s | %d | %d | %d | %d |\n", c.Label, score, c.Added, c.Removed, c.Worsened, c.Improved)
+	}
+	fmt.Fprintln(writer)
+
+	for _, section := range diffSections(response, maxDiffTextItems) {
+		if len(section.Items) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "### %s\n\n", section.Summary.Label)
+		fmt.Fprintln(writer, "| Status | Item | Location | Change |")
+		fmt.Fprintln(writer, "|---|---|---|---|")
+		for _, item := range section.Items {
+			fmt.Fprintf(writer, "| %s | %s | %s | %s |\n", item.Status, markdownEscape(item.Name),
+				markdownLink(item.FilePath, item.Line), markdownEscape(item.Detail))
+		}
+		if section.Omitted > 0 {
+			fmt.Fprintf(writer, "\n_… and %d more_\n", section.Omitted)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.Warnings) > 0 {
+		for _, warning := range response.Warnings {
+			fmt.Fprintf(writer, "> ⚠️ %s\n", warning)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	fmt.Fprintf(writer, "<sub>Compared %s with %s</sub>\n", markdownEscape(response.Old.Path), markdownEscape(response.New.Path))
+	return nil
+}
+
+// writeDiffHTML writes the report comparison as a standalone HTML page
+func (f *OutputFormatterImpl) writeDiffHTML(response *domain.DiffResponse, writer io.Writer) error {
+	data := struct {
+		Response *domain.DiffResponse
+		Sections []diffSection
+	}{
+		Response: response,
+		Sections: diffSections(response, 0),
+	}
+
+	funcMap := template.FuncMap{
+		"location":    diffItemLocation,
+		"reportLabel": diffReportLabel,
+		"signed": func(n int) string {
+			return fmt.Sprintf("%+d", n)
+		},
+	}
+
+	tmpl := template.Must(template.New("diff").Funcs(funcMap).Parse(diffHTMLTemplate))
+	return tmpl.Execute(writer, data)
+}
+
+const diffHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>jscan Analysis Diff</title>
+    <style>
+        * { margin: 0; padding: 0; box-sizing: border-box; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            min-height: 100vh;
+        }
+        .container { max-width: 1200px; margin: 0 auto; padding: 20px; }
+        .panel {
+            background: white;
+            border-radius: 10px;
+            padding: 30px;
+            margin-bottom: 20px;
+            box-shadow: 0 10px 30px rgba(0,0,0,0.1);
+        }
+        h1 { color: #667eea; margin-bottom: 10px; }
+        h2 { color: #667eea; margin-bottom: 15px; }
+        .subtitle { color: #666; font-size: 14px; }
+        .metric-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 15px; margin-top: 20px; }
+        .metric-card { background: #f8f9fa; padding: 20px; border-radius: 8px; text-align: center; }
+        .metric-value { font-size: 28px; font-weight: bold; color: #667eea; }
+        .metric-label { color: #666; font-size: 14px; }
+        .table { width: 100%; border-collapse: collapse; }
+        .table th, .table td { padding: 10px; text-align: left; border-bottom: 1px solid #eee; }
+        .table th { background: #f8f9fa; font-weight: 600; }
+        .status-added, .status-worsened { color: #f44336; font-weight: bold; }
+        .status-removed, .status-improved { color: #4caf50; font-weight: bold; }
+        .delta-negative { color: #f44336; font-weight: bold; }
+        .delta-positive { color: #4caf50; font-weight: bold; }
+        .warning { color: #ff9800; }
+        code { font-family: 'SFMono-Regular', Consolas, monospace; font-size: 13px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="panel">
+            <h1>jscan Analysis Diff</h1>
+            <div class="subtitle">Old: {{reportLabel .Response.Old}}</div>
+            <div class="subtitle">New: {{reportLabel .Response.New}}</div>
+            <div class="metric-grid">
+                <div class="metric-card">
+                    <div class="metric-value">{{.Response.Summary.ScoreBefore}} → {{.Response.Summary.ScoreAfter}}</div>
+                    <div class="metric-label">Health Score ({{.Response.Summary.GradeBefore}} → {{.Response.Summary.GradeAfter}})</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value {{if lt .Response.Summary.ScoreDelta 0}}delta-negative{{else if gt .Response.Summary.ScoreDelta 0}}delta-positive{{end}}">{{signed .Response.Summary.ScoreDelta}}</div>
+                    <div class="metric-label">Score Change</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Response.Summary.Added}} / {{.Response.Summary.Worsened}}</div>
+                    <div class="metric-label">Added / Worsened</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Response.Summary.Removed}} / {{.Response.Summary.Improved}}</div>
+                    <div class="metric-label">Removed / Improved</div>
+                </div>
+            </div>
+            {{range .Response.Warnings}}<p class="warning">⚠️ {{.}}</p>{{end}}
+        </div>
+
+        <div class="panel">
+            <h2>Categories</h2>
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Category</th>
+                        <th>Score</th>
+                        <th>Added</th>
+                        <th>Removed</th>
+                        <th>Worsened</th>
+                        <th>Improved</th>
+                        <th>Unchanged</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Response.Categories}}
+                    <tr>
+                        <td>{{.Label}}</td>
+                        <td>{{.ScoreBefore}} → {{.ScoreAfter}} <span class="{{if lt .ScoreDelta 0}}delta-negative{{else if gt .ScoreDelta 0}}delta-positive{{end}}">({{signed .ScoreDelta}})</span></td>
+                        {{if .Compared}}
+                        <td>{{.Added}}</td>
+                        <td>{{.Removed}}</td>
+                        <td>{{.Worsened}}</td>
+                        <td>{{.Improved}}</td>
+                        <td>{{.Unchanged}}</td>
+                        {{else}}
+                        <td colspan="5">not compared</td>
+                        {{end}}
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        {{range .Sections}}{{if .Items}}
+        <div class="panel">
+            <h2>{{.Summary.Label}}</h2>
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Status</th>
+                        <th>Item</th>
+                        <th>Location</th>
+                        <th>Change</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Items}}
+                    <tr>
+                        <td class="status-{{.Status}}">{{.Status}}</td>
+                        <td>{{.Name}}</td>
+                        <td><code>{{location .}}</code></td>
+                        <td>{{.Detail}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}{{end}}
+    </div>
+</body>
+</html>
+`
@@ -0,0 +1,516 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// DiffServiceImpl compares two `jscan analyze --format json` reports
+type DiffServiceImpl struct{}
+
+// NewDiffService creates a new diff service
+func NewDiffService() *DiffServiceImpl {
+	return &DiffServiceImpl{}
+}
+
+// Diff loads both reports and compares them
+func (s *DiffServiceImpl) Diff(ctx context.Context, req domain.DiffRequest) (*domain.DiffResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	oldReport, err := LoadAnalyzeReport(req.OldPath)
+	if err != nil {
+		return nil, err
+	}
+	newReport, err := LoadAnalyzeReport(req.NewPath)
+	if err != nil {
+		return nil, err
+	}
+
+	response := CompareAnalyzeReports(oldReport, newReport)
+	response.Old.Path = req.OldPath
+	response.New.Path = req.NewPath
+	return response, nil
+}
+
+// LoadAnalyzeReport reads a report written by `jscan analyze --format json`
+func LoadAnalyzeReport(path string) (*AnalyzeResponseJSON, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("failed to read report %s", path), err)
+	}
+
+	var report AnalyzeResponseJSON
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid analysis report %s", path), err)
+	}
+	if report.Summary == nil && report.Complexity == nil && report.DeadCode == nil &&
+		report.Clone == nil && report.CBO == nil && report.Deps == nil {
+		return nil, domain.NewInvalidInputError(
+			fmt.Sprintf("%s is not a 'jscan analyze --format json' report", path), nil)
+	}
+	return &report, nil
+}
+
+// diffEntry is an item of one report, identified by its stable key
+type diffEntry struct {
+	key      string
+	name     string
+	filePath string
+	line     int
+	value    float64
+	members  []string // Cycle modules, used to pair cycles that grew or shrank
+}
+
+// diffDescriber describes an item added (before == nil), removed (after == nil) or changed
+type diffDescriber func(before, after *diffEntry) string
+
+// CompareAnalyzeReports matches the items of two reports by stable identity and
+// reports what was added, removed, worsened or improved
+func CompareAnalyzeReports(oldReport, newReport *AnalyzeResponseJSON) *domain.DiffResponse {
+	response := &domain.DiffResponse{
+		Old:         domain.DiffReportInfo{Version: oldReport.Version, GeneratedAt: oldReport.GeneratedAt},
+		New:         domain.DiffReportInfo{Version: newReport.Version, GeneratedAt: newReport.GeneratedAt},
+		Items:       []domain.DiffItem{},
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.GetVersion(),
+	}
+
+	if oldReport.Summary != nil {
+		response.Summary.ScoreBefore = oldReport.Summary.HealthScore
+		response.Summary.GradeBefore = oldReport.Summary.Grade
+	}
+	if newReport.Summary != nil {
+		response.Summary.ScoreAfter = newReport.Summary.HealthScore
+		response.Summary.GradeAfter = newReport.Summary.Grade
+	}
+	response.Summary.ScoreDelta = response.Summary.ScoreAfter - response.Summary.ScoreBefore
+
+	for _, category := range domain.DiffCategories {
+		summary := domain.DiffCategorySummary{
+			Category:    category,
+			Label:       category.Label(),
+			ScoreBefore: category.Score(oldReport.Summary),
+			ScoreAfter:  category.Score(newReport.Summary),
+		}
+		summary.ScoreDelta = summary.ScoreAfter - summary.ScoreBefore
+
+		oldEntries, oldOK := diffEntries(category, oldReport)
+		newEntries, newOK := diffEntries(category, newReport)
+		if oldOK && newOK {
+			summary.Compared = true
+			var items []domain.DiffItem
+			if category == domain.DiffCategoryCycles {
+				items, summary.Unchanged = compareCycles(oldEntries, newEntries)
+			} else {
+				items, summary.Unchanged = compareDiffEntries(category, oldEntries, newEntries)
+			}
+			for _, item := range items {
+				switch item.Status {
+				case domain.DiffStatusAdded:
+					summary.Added++
+				case domain.DiffStatusRemoved:
+					summary.Removed++
+				case domain.DiffStatusWorsened:
+					summary.Worsened++
+				case domain.DiffStatusImproved:
+					summary.Improved++
+				}
+			}
+			response.Items = append(response.Items, items...)
+		} else if oldOK || newOK {
+			missing := "old"
+			if oldOK {
+				missing = "new"
+			}
+			response.Warnings = append(response.Warnings, fmt.Sprintf(
+				"%s not compared: the %s report has no %s results", category.Label(), missing, category))
+		}
+
+		response.Summary.Added += summary.Added
+		response.Summary.Removed += summary.Removed
+		response.Summary.Worsened += summary.Worsened
+		response.Summary.Improved += summary.Improved
+		response.Categories = append(response.Categories, summary)
+	}
+
+	return response
+}
+
+// diffEntries extracts the items of a category from a report. ok is false if the
+// report does not contain the category.
+func diffEntries(category domain.DiffCategory, report *AnalyzeResponseJSON) (entries []diffEntry, ok bool) {
+	switch category {
+	case domain.DiffCategoryComplexity:
+		if report.Complexity == nil {
+			return nil, false
+		}
+		for _, fn := range report.Complexity.Functions {
+			entries = append(entries, diffEntry{
+				key:      fn.FilePath + "::" + stableFunctionName(fn.Name),
+				name:     fn.Name,
+				filePath: fn.FilePath,
+				line:     fn.StartLine,
+				value:    float64(fn.Metrics.Complexity),
+			})
+		}
+
+	case domain.DiffCategoryDeadCode:
+		if report.DeadCode == nil {
+			return nil, false
+		}
+		for _, file := range report.DeadCode.Files {
+			for _, finding := range file.FileLevelFindings {
+				entries = append(entries, deadCodeDiffEntry(file.FilePath, finding))
+			}
+			for _, fn := range file.Functions {
+				for _, finding := range fn.Findings {
+					entries = append(entries, deadCodeDiffEntry(file.FilePath, finding))
+				}
+			}
+		}
+
+	case domain.DiffCategoryClones:
+		if report.Clone == nil {
+			return nil, false
+		}
+		for _, group := range report.Clone.CloneGroups {
+			if group == nil {
+				continue
+			}
+			entries = append(entries, cloneGroupDiffEntry(group))
+		}
+
+	case domain.DiffCategoryCoupling:
+		if report.CBO == nil {
+			return nil, false
+		}
+		for _, class := range report.CBO.Classes {
+			entries = append(entries, diffEntry{
+				key:      class.FilePath + "::" + class.Name,
+				name:     class.Name,
+				filePath: class.FilePath,
+				line:     class.StartLine,
+				value:    float64(class.Metrics.CouplingCount),
+			})
+		}
+
+	case domain.DiffCategoryCycles:
+		if report.Deps == nil || report.Deps.Analysis == nil {
+			return nil, false
+		}
+		if cycles := report.Deps.Analysis.CircularDependencies; cycles != nil {
+			for _, cycle := range cycles.CircularDependencies {
+				members := append([]string(nil), cycle.Modules...)
+				sort.Strings(members)
+				entries = append(entries, diffEntry{
+					key:     strings.Join(members, ", "),
+					name:    strings.Join(cycle.Modules, " -> "),
+					value:   float64(len(members)),
+					members: members,
+				})
+			}
+		}
+	}
+
+	return disambiguateDiffKeys(entries), true
+}
+
+// anonymousFunctionName matches the line-based names given to anonymous functions
+var anonymousFunctionName = regexp.MustCompile(`^anonymous_\d+$`)
+
+// stableFunctionName drops the line number from generated names of anonymous
+// functions; they are told apart by their order in the file instead
+func stableFunctionName(name string) string {
+	if anonymousFunctionName.MatchString(name) {
+		return "anonymous"
+	}
+	return name
+}
+
+// deadCodeDiffEntry identifies a finding by its file, function, reason, code and
+// description, so that it still matches after lines moved
+func deadCodeDiffEntry(filePath string, finding domain.DeadCodeFinding) diffEntry {
+	code := strings.Join(strings.Fields(finding.Code), " ")
+	function := stableFunctionName(finding.FunctionName)
+	name := finding.Description
+	if name == "" {
+		name = code
+	}
+	if name == "" {
+		name = finding.Reason
+	}
+	if finding.FunctionName != "" {
+		name = finding.FunctionName + ": " + name
+	}
+	return diffEntry{
+		key:      strings.Join([]string{filePath, function, finding.Reason, code, finding.Description}, "::"),
+		name:     name,
+		filePath: filePath,
+		line:     finding.Location.StartLine,
+		value:    float64(finding.Severity.Level()),
+	}
+}
+
+// cloneGroupDiffEntry identifies a clone group by the files its clones live in
+func cloneGroupDiffEntry(group *domain.CloneGroup) diffEntry {
+	entry := diffEntry{value: float64(len(group.Clones))}
+	seen := make(map[string]bool)
+	var files, locations []string
+	for _, clone := range group.Clones {
+		if clone == nil || clone.Location == nil {
+			continue
+		}
+		loc := clone.Location
+		if entry.filePath == "" || loc.FilePath < entry.filePath ||
+			(loc.FilePath == entry.filePath && loc.StartLine < entry.line) {
+			entry.filePath, entry.line = loc.FilePath, loc.StartLine
+		}
+		locations = append(locations, fmt.Sprintf("%s:%d", loc.FilePath, loc.StartLine))
+		if !seen[loc.FilePath] {
+			seen[loc.FilePath] = true
+			files = append(files, loc.FilePath)
+		}
+	}
+	sort.Strings(files)
+	sort.Strings(locations)
+	entry.key = strings.Join(files, ", ")
+	entry.name = strings.Join(locations, ", ")
+	return entry
+}
+
+// disambiguateDiffKeys suffixes repeated keys (e.g. several anonymous functions in
+// one file) with their occurrence number, in line order
+func disambiguateDiffKeys(entries []diffEntry) []diffEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].key != entries[j].key {
+			return entries[i].key < entries[j].key
+		}
+		return entries[i].line < entries[j].line
+	})
+	occurrences := make(map[string]int, len(entries))
+	for i := range entries {
+		base := entries[i].key
+		occurrences[base]++
+		if n := occurrences[base]; n > 1 {
+			entries[i].key = fmt.Sprintf("%s#%d", base, n)
+		}
+	}
+	return entries
+}
+
+// compareDiffEntries matches entries by key. A higher value is worse in every
+// keyed category (complexity, severity, clone count, coupling).
+func compareDiffEntries(category domain.DiffCategory, oldEntries, newEntries []diffEntry) ([]domain.DiffItem, int) {
+	metric, describe := diffMetric(category)
+	oldByKey := make(map[string]*diffEntry, len(oldEntries))
+	for i := range oldEntries {
+		oldByKey[oldEntries[i].key] = &oldEntries[i]
+	}
+
+	var items []domain.DiffItem
+	unchanged := 0
+	matched := make(map[string]bool, len(newEntries))
+	for i := range newEntries {
+		after := &newEntries[i]
+		before, ok := oldByKey[after.key]
+		if !ok {
+			items = append(items, newDiffItem(category, domain.DiffStatusAdded, metric, nil, after, describe))
+			continue
+		}
+		matched[after.key] = true
+		switch {
+		case after.value > before.value:
+			items = append(items, newDiffItem(category, domain.DiffStatusWorsened, metric, before, after, describe))
+		case after.value < before.value:
+			items = append(items, newDiffItem(category, domain.DiffStatusImproved, metric, before, after, describe))
+		default:
+			unchanged++
+		}
+	}
+	for i := range oldEntries {
+		if before := &oldEntries[i]; !matched[before.key] {
+			items = append(items, newDiffItem(category, domain.DiffStatusRemoved, metric, before, nil, describe))
+		}
+	}
+
+	sortDiffItems(items)
+	return items, unchanged
+}
+
+// compareCycles matches cycles by their module set. A cycle that grew or shrank
+// is paired with the remaining cycle it shares the most modules with.
+func compareCycles(oldEntries, newEntries []diffEntry) ([]domain.DiffItem, int) {
+	metric, describe := diffMetric(domain.DiffCategoryCycles)
+	oldByKey := make(map[string]int, len(oldEntries))
+	for i := range oldEntries {
+		oldByKey[oldEntries[i].key] = i
+	}
+
+	unchanged := 0
+	usedOld := make(map[int]bool)
+	var unmatched []int
+	for i := range newEntries {
+		if j, ok := oldByKey[newEntries[i].key]; ok {
+			usedOld[j] = true
+			unchanged++
+			continue
+		}
+		unmatched = append(unmatched, i)
+	}
+
+	var items []domain.DiffItem
+	for _, i := range unmatched {
+		after := &newEntries[i]
+		best, bestShared := -1, 0
+		for j := range oldEntries {
+			if usedOld[j] || oldEntries[j].value == after.value {
+				continue
+			}
+			if shared := sharedMembers(oldEntries[j].members, after.members); shared > bestShared {
+				best, bestShared = j, shared
+			}
+		}
+		if best < 0 {
+			items = append(items, newDiffItem(domain.DiffCategoryCycles, domain.DiffStatusAdded, metric, nil, after, describe))
+			continue
+		}
+		usedOld[best] = true
+		before := &oldEntries[best]
+		status := domain.DiffStatusWorsened
+		if after.value < before.value {
+			status = domain.DiffStatusImproved
+		}
+		items = append(items, newDiffItem(domain.DiffCategoryCycles, status, metric, before, after, describe))
+	}
+	for j := range oldEntries {
+		if !usedOld[j] {
+			items = append(items, newDiffItem(domain.DiffCategoryCycles, domain.DiffStatusRemoved, metric, &oldEntries[j], nil, describe))
+		}
+	}
+
+	sortDiffItems(items)
+	return items, unchanged
+}
+
+// sharedMembers counts the elements two sorted slices have in common
+func sharedMembers(a, b []string) int {
+	shared := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			shared++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return shared
+}
+
+// diffMetric returns the compared metric of a category and how to describe its changes
+func diffMetric(category domain.DiffCategory) (string, diffDescriber) {
+	valueChange := func(format string) diffDescriber {
+		return func(before, after *diffEntry) string {
+			switch {
+			case before == nil:
+				return fmt.Sprintf(format, fmt.Sprintf("%.0f", after.value))
+			case after == nil:
+				return fmt.Sprintf(format, fmt.Sprintf("%.0f", before.value))
+			default:
+				return fmt.Sprintf(format, fmt.Sprintf("%.0f -> %.0f", before.value, after.value))
+			}
+		}
+	}
+
+	switch category {
+	case domain.DiffCategoryComplexity:
+		return "complexity", valueChange("complexity %s")
+	case domain.DiffCategoryDeadCode:
+		return "severity", func(before, after *diffEntry) string {
+			switch {
+			case before == nil:
+				return string(severityFromLevel(after.value))
+			case after == nil:
+				return string(severityFromLevel(before.value))
+			default:
+				return fmt.Sprintf("%s -> %s", severityFromLevel(before.value), severityFromLevel(after.value))
+			}
+		}
+	case domain.DiffCategoryClones:
+		return "clones", valueChange("%s clones")
+	case domain.DiffCategoryCoupling:
+		return "cbo", valueChange("CBO %s")
+	default:
+		return "modules", valueChange("%s modules")
+	}
+}
+
+// severityFromLevel converts a DeadCodeSeverity level back to the severity
+func severityFromLevel(level float64) domain.DeadCodeSeverity {
+	for _, severity := range []domain.DeadCodeSeverity{
+		domain.DeadCodeSeverityInfo, domain.DeadCodeSeverityWarning, domain.DeadCodeSeverityCritical,
+	} {
+		if float64(severity.Level()) == level {
+			return severity
+		}
+	}
+	return ""
+}
+
+// newDiffItem builds an item from the matched entries; before or after is nil
+// for added and removed items
+func newDiffItem(category domain.DiffCategory, status domain.DiffStatus, metric string,
+	before, after *diffEntry, describe diffDescriber) domain.DiffItem {
+	located := after
+	if located == nil {
+		located = before
+	}
+	item := domain.DiffItem{
+		Category: category,
+		Status:   status,
+		Key:      located.key,
+		Name:     located.name,
+		FilePath: located.filePath,
+		Line:     located.line,
+		Metric:   metric,
+		Detail:   describe(before, after),
+	}
+	if before != nil {
+		item.Before = before.value
+	}
+	if after != nil {
+		item.After = after.value
+	}
+	return item
+}
+
+// sortDiffItems orders items by status (regressions first), then by location
+func sortDiffItems(items []domain.DiffItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Status != b.Status {
+			return a.Status.Order() < b.Status.Order()
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Key < b.Key
+	})
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func diffFunction(file, name string, line, complexity int) domain.FunctionComplexity {
+	return domain.FunctionComplexity{
+		Name:      name,
+		FilePath:  file,
+		StartLine: line,
+		Metrics:   domain.ComplexityMetrics{Complexity: complexity},
+	}
+}
+
+func diffCloneGroup(locations ...domain.CloneLocation) *domain.CloneGroup {
+	group := &domain.CloneGroup{}
+	for i := range locations {
+		group.AddClone(&domain.Clone{Location: &locations[i]})
+	}
+	return group
+}
+
+func diffCycles(cycles ...[]string) *DepsResponseJSON {
+	analysis := &domain.CircularDependencyAnalysis{}
+	for _, modules := range cycles {
+		analysis.CircularDependencies = append(analysis.CircularDependencies, domain.CircularDependency{Modules: modules})
+	}
+	return &DepsResponseJSON{Analysis: &domain.DependencyAnalysisResult{CircularDependencies: analysis}}
+}
+
+func diffReports() (*AnalyzeResponseJSON, *AnalyzeResponseJSON) {
+	oldReport := &AnalyzeResponseJSON{
+		Version: "0.6.0",
+		Summary: &domain.AnalyzeSummary{HealthScore: 90, Grade: "A", ComplexityScore: 100, DependencyScore: 80},
+		Complexity: &ComplexityResponseJSON{Functions: []domain.FunctionComplexity{
+			diffFunction("src/a.ts", "parse", 10, 4),
+			diffFunction("src/a.ts", "anonymous_20", 20, 2),
+			diffFunction("src/a.ts", "format", 30, 8),
+			diffFunction("src/b.ts", "legacy", 1, 3),
+		}},
+		DeadCode: &DeadCodeResponseJSON{Files: []domain.FileDeadCode{{
+			FilePath: "src/a.ts",
+			FileLevelFindings: []domain.DeadCodeFinding{{
+				Location: domain.DeadCodeLocation{FilePath: "src/a.ts", StartLine: 1},
+				Code:     "import { x } from './x'",
+				Reason:   "unused_import",
+				Severity: domain.DeadCodeSeverityInfo,
+			}},
+		}}},
+		Clone: &CloneResponseJSON{CloneGroups: []*domain.CloneGroup{
+			diffCloneGroup(domain.CloneLocation{FilePath: "src/a.ts", StartLine: 10}, domain.CloneLocation{FilePath: "src/b.ts", StartLine: 5}),
+		}},
+		CBO:  &CBOResponseJSON{Classes: []domain.ClassCoupling{{Name: "Parser", FilePath: "src/a.ts", Metrics: domain.CBOMetrics{CouplingCount: 3}}}},
+		Deps: diffCycles([]string{"src/a.ts", "src/b.ts"}, []string{"src/x.ts", "src/y.ts"}),
+	}
+
+	newReport := &AnalyzeResponseJSON{
+		Version: "0.7.0",
+		Summary: &domain.AnalyzeSummary{HealthScore: 84, Grade: "B", ComplexityScore: 94, DependencyScore: 85},
+		Complexity: &ComplexityResponseJSON{Functions: []domain.FunctionComplexity{
+			// Everything moved down by 5 lines
+			diffFunction("src/a.ts", "parse", 15, 4),
+			diffFunction("src/a.ts", "anonymous_25", 25, 2),
+			diffFunction("src/a.ts", "format", 35, 12),
+			diffFunction("src/c.ts", "render", 1, 6),
+		}},
+		DeadCode: &DeadCodeResponseJSON{Files: []domain.FileDeadCode{{
+			FilePath: "src/a.ts",
+			FileLevelFindings: []domain.DeadCodeFinding{{
+				Location: domain.DeadCodeLocation{FilePath: "src/a.ts", StartLine: 2},
+				Code:     "import {  x  } from './x'",
+				Reason:   "unused_import",
+				Severity: domain.DeadCodeSeverityWarning,
+			}},
+		}}},
+		Clone: &CloneResponseJSON{CloneGroups: []*domain.CloneGroup{
+			diffCloneGroup(domain.CloneLocation{FilePath: "src/b.ts", StartLine: 8}, domain.CloneLocation{FilePath: "src/a.ts", StartLine: 15},
+				domain.CloneLocation{FilePath: "src/a.ts", StartLine: 40}),
+		}},
+		CBO:  &CBOResponseJSON{Classes: []domain.ClassCoupling{{Name: "Parser", FilePath: "src/a.ts", Metrics: domain.CBOMetrics{CouplingCount: 2}}}},
+		Deps: diffCycles([]string{"src/b.ts", "src/a.ts", "src/c.ts"}),
+	}
+	return oldReport, newReport
+}
+
+func findDiffItem(items []domain.DiffItem, category domain.DiffCategory, name string) *domain.DiffItem {
+	for i := range items {
+		if items[i].Category == category && items[i].Name == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func TestCompareAnalyzeReports(t *testing.T) {
+	oldReport, newReport := diffReports()
+	resp := CompareAnalyzeReports(oldReport, newReport)
+
+	if resp.Summary.ScoreBefore != 90 || resp.Summary.ScoreAfter != 84 || resp.Summary.ScoreDelta != -6 {
+		t.Errorf("Unexpected score summary %+v", resp.Summary)
+	}
+	if resp.Old.Version != "0.6.0" || resp.New.Version != "0.7.0" {
+		t.Errorf("Expected report versions, got %+v / %+v", resp.Old, resp.New)
+	}
+
+	complexity := resp.Categories[0]
+	if complexity.Category != domain.DiffCategoryComplexity || complexity.ScoreDelta != -6 {
+		t.Errorf("Unexpected complexity category %+v", complexity)
+	}
+	// parse and the anonymous function only moved
+	if complexity.Added != 1 || complexity.Removed != 1 || complexity.Worsened != 1 || complexity.Unchanged != 2 {
+		t.Errorf("Expected 1 added, 1 removed, 1 worsened, 2 unchanged functions, got %+v", complexity)
+	}
+	if item := findDiffItem(resp.Items, domain.DiffCategoryComplexity, "format"); item == nil ||
+		item.Status != domain.DiffStatusWorsened || item.Before != 8 || item.After != 12 || item.Line != 35 {
+		t.Errorf("Expected format to have worsened from 8 to 12, got %+v", item)
+	}
+	if item := findDiffItem(resp.Items, domain.DiffCategoryComplexity, "legacy"); item == nil || item.Status != domain.DiffStatusRemoved {
+		t.Errorf("Expected legacy to be removed, got %+v", item)
+	}
+
+	// Findings match by code, regardless of whitespace and line
+	deadCode := resp.Categories[1]
+	if deadCode.Worsened != 1 || deadCode.Added != 0 || deadCode.Removed != 0 {
+		t.Errorf("Expected the unused import severity to have worsened, got %+v", deadCode)
+	}
+
+	clones := resp.Categories[2]
+	if clones.Worsened != 1 {
+		t.Errorf("Expected the clone group to have grown, got %+v", clones)
+	}
+
+	coupling := resp.Categories[3]
+	if coupling.Improved != 1 {
+		t.Errorf("Expected Parser coupling to have improved, got %+v", coupling)
+	}
+
+	cycles := resp.Categories[4]
+	if cycles.Worsened != 1 || cycles.Removed != 1 || cycles.Added != 0 || cycles.ScoreDelta != 5 {
+		t.Errorf("Expected one grown and one removed cycle, got %+v", cycles)
+	}
+
+	if resp.Summary.Added != 1 || resp.Summary.Removed != 2 || resp.Summary.Worsened != 4 || resp.Summary.Improved != 1 {
+		t.Errorf("Unexpected totals %+v", resp.Summary)
+	}
+
+	// Items are grouped by category with regressions first
+	for i := 1; i < len(resp.Items); i++ {
+		prev, curr := resp.Items[i-1], resp.Items[i]
+		if prev.Category == curr.Category && prev.Status.Order() > curr.Status.Order() {
+			t.Errorf("Items out of order: %s before %s", prev.Status, curr.Status)
+		}
+	}
+}
+
+func TestCompareAnalyzeReportsDuplicateNames(t *testing.T) {
+	oldReport := &AnalyzeResponseJSON{Complexity: &ComplexityResponseJSON{Functions: []domain.FunctionComplexity{
+		diffFunction("src/a.ts", "anonymous_3", 3, 1),
+		diffFunction("src/a.ts", "anonymous_9", 9, 5),
+	}}}
+	newReport := &AnalyzeResponseJSON{Complexity: &ComplexityResponseJSON{Functions: []domain.FunctionComplexity{
+		diffFunction("src/a.ts", "anonymous_4", 4, 1),
+		diffFunction("src/a.ts", "anonymous_10", 10, 7),
+	}}}
+
+	resp := CompareAnalyzeReports(oldReport, newReport)
+	if len(resp.Items) != 1 || resp.Items[0].Status != domain.DiffStatusWorsened || resp.Items[0].Name != "anonymous_10" {
+		t.Errorf("Expected the second anonymous function to have worsened, got %+v", resp.Items)
+	}
+}
+
+func TestCompareAnalyzeReportsMissingCategory(t *testing.T) {
+	oldReport, newReport := diffReports()
+	newReport.Clone = nil
+
+	resp := CompareAnalyzeReports(oldReport, newReport)
+	if resp.Categories[2].Compared {
+		t.Error("Expected clones not to be compared")
+	}
+	for _, item := range resp.Items {
+		if item.Category == domain.DiffCategoryClones {
+			t.Errorf("Unexpected clone item %+v", item)
+		}
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "new report has no clones results") {
+		t.Errorf("Expected a warning about missing clone results, got %v", resp.Warnings)
+	}
+}
+
+func TestDiffServiceDiff(t *testing.T) {
+	dir := t.TempDir()
+	oldReport, newReport := diffReports()
+	oldPath, newPath := filepath.Join(dir, "old.json"), filepath.Join(dir, "new.json")
+	for path, report := range map[string]*AnalyzeResponseJSON{oldPath: oldReport, newPath: newReport} {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, report); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := NewDiffService().Diff(context.Background(), domain.DiffRequest{OldPath: oldPath, NewPath: newPath})
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if resp.Old.Path != oldPath || resp.Summary.Worsened != 4 {
+		t.Errorf("Unexpected diff of the saved reports %+v", resp.Summary)
+	}
+
+	notReport := filepath.Join(dir, "other.json")
+	if err := os.WriteFile(notReport, []byte(`{"name": "package"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewDiffService().Diff(context.Background(), domain.DiffRequest{OldPath: oldPath, NewPath: notReport})
+	if err == nil || !strings.Contains(err.Error(), "is not a 'jscan analyze --format json' report") {
+		t.Errorf("Expected an invalid report error, got %v", err)
+	}
+
+	_, err = NewDiffService().Diff(context.Background(), domain.DiffRequest{OldPath: oldPath})
+	if err == nil {
+		t.Error("Expected a validation error without a new report")
+	}
+}
+
+func TestOutputFormatterWriteDiff(t *testing.T) {
+	oldReport, newReport := diffReports()
+	resp := CompareAnalyzeReports(oldReport, newReport)
+	resp.Old.Path, resp.New.Path = "old.json", "new.json"
+	formatter := NewOutputFormatter()
+
+	tests := []struct {
+		format   domain.OutputFormat
+		expected []string
+	}{
+		{domain.OutputFormatText, []string{
+			"Health score: 90 (A) -> 84 (B) (-6)",
+			"worsened  format (src/a.ts:35): complexity 8 -> 12",
+			"worsened  src/b.ts -> src/a.ts -> src/c.ts: 2 -> 3 modules",
+			"worsened  import { x } from './x' (src/a.ts:2): info -> warning",
+		}},
+		{domain.OutputFormatMarkdown, []string{
+			"## jscan Analysis Diff",
+			"| Complexity | 100 → 94 (-6) | 1 | 1 | 1 | 0 |",
+			"| worsened | format | [src/a.ts:35](src/a.ts#L35) | complexity 8 -> 12 |",
+		}},
+		{domain.OutputFormatHTML, []string{
+			"<title>jscan Analysis Diff</title>",
+			`<td class="status-worsened">worsened</td>`,
+		}},
+		{domain.OutputFormatJSON, []string{`"status": "worsened"`, `"score_delta": -6`}},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := formatter.WriteDiff(resp, tt.format, &buf); err != nil {
+			t.Fatalf("WriteDiff %s failed: %v", tt.format, err)
+		}
+		for _, expected := range tt.expected {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("Expected %s output to contain %q, got:\n%s", tt.format, expected, buf.String())
+			}
+		}
+	}
+}
@@ -76,6 +76,145 @@ var edgeStyles = map[domain.DependencyEdgeType]struct {
 	domain.EdgeTypeReExport: {style: "bold", arrow: "diamond"},
 }
 
+// queryHighlightColor is the color of modules and edges in a query result
+const queryHighlightColor = "#1E90FF"
+
+// cycleBreakColor is the color of edges recommended for removal to break a cycle
+const cycleBreakColor = "#FF8C00"
+
+// Temporal coupling layer colors: hidden co-change pairs and imports between modules
+// that rarely change together
+const (
+	temporalHiddenColor     = "#8A2BE2"
+	temporalStaticOnlyColor = "#20B2AA"
+)
+
+// temporalLayer holds the temporal coupling pairs drawn over the graph
+type temporalLayer struct {
+	hidden     []domain.TemporalCouplingPair
+	staticOnly map[[2]string]float64 // import edge -> co-change degree
+}
+
+// newTemporalLayer builds the temporal coupling layer (nil when it was not requested)
+func newTemporalLayer(result *domain.TemporalCouplingResult) *temporalLayer {
+	if result == nil {
+		return nil
+	}
+	layer := &temporalLayer{hidden: result.Hidden, staticOnly: make(map[[2]string]float64)}
+	for _, pair := range result.StaticOnly {
+		for _, edge := range pair.Edges {
+			layer.staticOnly[[2]string{edge.From, edge.To}] = pair.Degree
+		}
+	}
+	return layer
+}
+
+// Code-splitting cluster colors (fill, border) and the border of modules loaded both
+// statically and lazily
+var chunkColors = map[domain.ChunkKind]struct {
+	fill   string
+	border string
+}{
+	domain.ChunkEntry: {fill: "#EEF3FF", border: "#4169E1"},
+	domain.ChunkLazy:  {fill: "#EFFAF0", border: "#2E8B57"},
+}
+
+const (
+	chunkSharedFill   = "#FFF8E1"
+	chunkSharedBorder = "#DAA520"
+	chunkMixedColor   = "#FF4500"
+)
+
+// chunkCluster is a chunk drawn as a subgraph with the modules assigned to it
+type chunkCluster struct {
+	label   string
+	kind    domain.ChunkKind
+	modules []string
+}
+
+// chunkLayer clusters the modules by code-splitting chunk. A module belongs to the
+// first entry chunk loading it, else to its only lazy chunk; modules of several lazy
+// chunks only go to a shared cluster, as bundlers move them to a common chunk.
+type chunkLayer struct {
+	clusters []chunkCluster
+	shared   []string
+	mixed    map[string]bool
+}
+
+// newChunkLayer builds the chunk clusters (nil when code splitting was not requested)
+func newChunkLayer(result *domain.CodeSplittingResult, filteredNodes map[string]bool) *chunkLayer {
+	if result == nil {
+		return nil
+	}
+	layer := &chunkLayer{mixed: make(map[string]bool, len(result.Mixed))}
+	for _, module := range result.Mixed {
+		layer.mixed[module.Module] = true
+	}
+
+	assigned := make(map[string]int) // module -> cluster index
+	lazyCount := make(map[string]int)
+	for _, chunk := range result.Chunks {
+		if chunk.Kind == domain.ChunkLazy {
+			for _, module := range chunk.Modules {
+				lazyCount[module]++
+			}
+		}
+	}
+	for _, chunk := range result.Chunks {
+		cluster := chunkCluster{kind: chunk.Kind}
+		if chunk.Kind == domain.ChunkEntry {
+			cluster.label = fmt.Sprintf("entry: %s (%s)", shortenModuleName(chunk.Root), formatSourceSize(chunk.Size))
+		} else {
+			cluster.label = fmt.Sprintf("lazy: %s (%s, %s deferred)", shortenModuleName(chunk.Root),
+				formatSourceSize(chunk.Size), formatSourceSize(chunk.OwnSize))
+		}
+		for _, module := range chunk.Modules {
+			if _, done := assigned[module]; done || !filteredNodes[module] {
+				continue
+			}
+			if chunk.Kind == domain.ChunkLazy && lazyCount[module] > 1 {
+				continue
+			}
+			assigned[module] = len(layer.clusters)
+			cluster.modules = append(cluster.modules, module)
+		}
+		if len(cluster.modules) > 0 {
+			layer.clusters = append(layer.clusters, cluster)
+		}
+	}
+	for module, count := range lazyCount {
+		if _, done := assigned[module]; !done && count > 1 && filteredNodes[module] {
+			layer.shared = append(layer.shared, module)
+		}
+	}
+	sort.Strings(layer.shared)
+	return layer
+}
+
+// queryHighlight holds the modules and edges of a query result subgraph
+type queryHighlight struct {
+	nodes map[string]bool
+	edges map[[2]string]bool
+}
+
+// newQueryHighlight builds the highlight sets for a query result (nil when there is no query)
+func newQueryHighlight(result *domain.DependencyQueryResult) *queryHighlight {
+	if result == nil {
+		return nil
+	}
+	h := &queryHighlight{
+		nodes: make(map[string]bool, len(result.Modules)),
+		edges: make(map[[2]string]bool, len(result.Edges)),
+	}
+	for _, mod := range result.Modules {
+		h.nodes[mod] = true
+	}
+	for _, edge := range result.Edges {
+		h.edges[[2]string{edge.From, edge.To}] = true
+	}
+	return h
+}
+
 // FormatDependencyGraph formats a dependency graph as DOT and returns the string
 func (f *DOTFormatter) FormatDependencyGraph(response *domain.DependencyGraphResponse) (string, error) {
 	var sb strings.Builder
@@ -106,6 +245,8 @@ func (f *DOTFormatter) WriteDependencyGraph(response *domain.DependencyGraphResp
 
 	graph := response.Graph
 	analysis := response.Analysis
+	highlight := newQueryHighlight(response.Query)
+	temporal := newTemporalLayer(response.TemporalCoupling)
 
 	// Collect nodes that pass filtering
 	filteredNodes := f.filterNodes(graph, analysis)
@@ -120,6 +261,7 @@ func (f *DOTFormatter) WriteDependencyGraph(response *domain.DependencyGraphResp
 
 	// Build set of modules in cycles for quick lookup
 	cycleModules := make(map[string]int) // module -> cycle index
+	breakEdges := make(map[[2]string]bool)
 	var cycles []domain.CircularDependency
 
 	if analysis != nil && analysis.CircularDependencies != nil &&
@@ -131,6 +273,9 @@ func (f *DOTFormatter) WriteDependencyGraph(response *domain.DependencyGraphResp
 					cycleModules[mod] = i
 				}
 			}
+			for _, edge := range cycle.BreakingEdges {
+				breakEdges[[2]string{edge.From, edge.To}] = true
+			}
 		}
 	}
 
@@ -146,8 +291,15 @@ func (f *DOTFormatter) WriteDependencyGraph(response *domain.DependencyGraphResp
 	// Track which nodes have been written (to avoid duplicates when clustering)
 	writtenNodes := make(map[string]bool)
 
+	// Write chunk clusters; they replace the cycle clusters as a module can only be
+	// drawn in one cluster
+	chunks := newChunkLayer(response.CodeSplitting, filteredNodes)
+	if chunks != nil {
+		f.writeChunkClusters(writer, graph, analysis, highlight, chunks, writtenNodes)
+	}
+
 	// Write cycle clusters if enabled
-	if f.config.ClusterCycles && len(cycles) > 0 {
+	if f.config.ClusterCycles && len(cycles) > 0 && chunks == nil {
 		for i, cycle := range cycles {
 			// Only process if cycle has modules in the filtered set
 			hasFilteredModules := false
@@ -179,7 +331,7 @@ func (f *DOTFormatter) WriteDependencyGraph(response *domain.DependencyGraphResp
 				if node == nil {
 					continue
 				}
-				f.writeNode(writer, node, analysis, "        ")
+				f.writeNode(writer, node, analysis, highlight, "        ")
 				writtenNodes[moduleID] = true
 			}
 
@@ -205,18 +357,22 @@ func (f *DOTFormatter) WriteDependencyGraph(response *domain.DependencyGraphResp
 		if node == nil {
 			continue
 		}
-		f.writeNode(writer, node, analysis, "    ")
+		f.writeNode(writer, node, analysis, highlight, "    ")
 	}
 	fmt.Fprintln(writer)
 
 	// Write edges
 	fmt.Fprintln(writer, "    // Edges")
-	f.writeEdges(writer, graph, filteredNodes, cycleModules)
+	f.writeEdges(writer, graph, filteredNodes, cycleModules, breakEdges, highlight, temporal)
 	fmt.Fprintln(writer)
 
+	if temporal != nil {
+		f.writeTemporalCoupling(writer, temporal, filteredNodes)
+	}
+
 	// Write legend if enabled
 	if f.config.ShowLegend {
-		f.writeLegend(writer)
+		f.writeLegend(writer, len(breakEdges) > 0 && highlight == nil, highlight != nil, temporal != nil, chunks != nil)
 	}
 
 	fmt.Fprintln(writer, "}")
@@ -313,8 +469,9 @@ func (f *DOTFormatter) filterByDepth(graph *domain.DependencyGraph, _ *domain.De
 	return result
 }
 
-// writeNode writes a single node in DOT format
-func (f *DOTFormatter) writeNode(writer io.Writer, node *domain.ModuleNode, analysis *domain.DependencyAnalysisResult, indent string) {
+// writeNode writes a single node in DOT format. With a query highlight, result
+// modules get a bold blue border and all other modules are greyed out.
+func (f *DOTFormatter) writeNode(writer io.Writer, node *domain.ModuleNode, analysis *domain.DependencyAnalysisResult, highlight *queryHighlight, indent string) {
 	dotID := escapeDOTID(node.ID)
 	label := node.Name
 	if label == "" {
@@ -354,6 +511,11 @@ func (f *DOTFormatter) writeNode(writer io.Writer, node *domain.ModuleNode, anal
 		colors = nodeColors[domain.RiskLevelLow]
 	}
 
+	inQuery := highlight != nil && highlight.nodes[node.ID]
+	if inQuery {
+		colors.border = queryHighlightColor
+	}
+
 	fmt.Fprintf(writer, "%s%s [label=\"%s\", fillcolor=\"%s\", color=\"%s\"",
 		indent, dotID, escapeDOTLabel(label), colors.fill, colors.border)
 
@@ -361,11 +523,19 @@ func (f *DOTFormatter) writeNode(writer io.Writer, node *domain.ModuleNode, anal
 		fmt.Fprintf(writer, ", tooltip=\"%s\"", tooltip)
 	}
 
+	if inQuery {
+		fmt.Fprint(writer, ", penwidth=3")
+	} else if highlight != nil {
+		fmt.Fprint(writer, ", fontcolor=\"#999999\", style=\"filled,dashed\"")
+	}
+
 	fmt.Fprintln(writer, "];")
 }
 
-// writeEdges writes all edges in DOT format
-func (f *DOTFormatter) writeEdges(writer io.Writer, graph *domain.DependencyGraph, filteredNodes map[string]bool, cycleModules map[string]int) {
+// writeEdges writes all edges in DOT format. Edges recommended for removal to break
+// a cycle are drawn dashed and labeled "break"; with a temporal coupling layer, imports
+// between modules that rarely change together are colored and labeled with their degree.
+func (f *DOTFormatter) writeEdges(writer io.Writer, graph *domain.DependencyGraph, filteredNodes map[string]bool, cycleModules map[string]int, breakEdges map[[2]string]bool, highlight *queryHighlight, temporal *temporalLayer) {
 	// Collect and sort edges for deterministic output
 	type edgeKey struct {
 		from, to string
@@ -407,15 +577,40 @@ func (f *DOTFormatter) writeEdges(writer io.Writer, graph *domain.DependencyGrap
 		_, fromInCycle := cycleModules[edge.From]
 		_, toInCycle := cycleModules[edge.To]
 		isCycleEdge := fromInCycle && toInCycle
+		isBreakEdge := highlight == nil && breakEdges[[2]string{edge.From, edge.To}]
+		degree, isStaticOnly := 0.0, false
+		if temporal != nil && highlight == nil {
+			degree, isStaticOnly = temporal.staticOnly[[2]string{edge.From, edge.To}]
+		}
 
+		edgeStyle := style.style
+		if isBreakEdge {
+			edgeStyle = "dashed"
+		}
 		fmt.Fprintf(writer, "    %s -> %s [style=%s, arrowhead=%s",
-			fromID, toID, style.style, style.arrow)
-
-		if isCycleEdge {
+			fromID, toID, edgeStyle, style.arrow)
+
+		switch {
+		case highlight != nil && highlight.edges[[2]string{edge.From, edge.To}]:
+			fmt.Fprintf(writer, ", penwidth=3, color=\"%s\"", queryHighlightColor)
+		case highlight != nil:
+			fmt.Fprint(writer, ", color=\"#CCCCCC\"")
+		case isBreakEdge:
+			fmt.Fprintf(writer, ", penwidth=3, color=\"%s\", fontcolor=\"%s\"", cycleBreakColor, cycleBreakColor)
+		case isCycleEdge:
 			fmt.Fprint(writer, ", penwidth=2, color=\"#DC143C\"")
+		case isStaticOnly:
+			fmt.Fprintf(writer, ", penwidth=2, color=\"%s\", fontcolor=\"%s\"", temporalStaticOnlyColor, temporalStaticOnlyColor)
 		}
 
-		if edge.EdgeType != domain.EdgeTypeImport {
+		switch {
+		case isBreakEdge && edge.EdgeType != domain.EdgeTypeImport:
+			fmt.Fprintf(writer, ", label=\"break (%s)\"", edge.EdgeType)
+		case isBreakEdge:
+			fmt.Fprint(writer, ", label=\"break\"")
+		case isStaticOnly:
+			fmt.Fprintf(writer, ", label=\"co-change %.0f%%\"", degree*100)
+		case edge.EdgeType != domain.EdgeTypeImport:
 			fmt.Fprintf(writer, ", label=\"%s\"", edge.EdgeType)
 		}
 
@@ -423,8 +618,63 @@ func (f *DOTFormatter) writeEdges(writer io.Writer, graph *domain.DependencyGrap
 	}
 }
 
+// writeChunkClusters writes a subgraph per code-splitting chunk. Modules loaded both
+// statically and lazily get a bold border.
+func (f *DOTFormatter) writeChunkClusters(writer io.Writer, graph *domain.DependencyGraph, analysis *domain.DependencyAnalysisResult, highlight *queryHighlight, chunks *chunkLayer, writtenNodes map[string]bool) {
+	writeCluster := func(name, label, fill, border, style string, modules []string) {
+		fmt.Fprintf(writer, "    subgraph cluster_%s {\n", name)
+		fmt.Fprintf(writer, "        label=\"%s\";\n", escapeDOTLabel(label))
+		fmt.Fprintf(writer, "        style=\"%s\";\n", style)
+		fmt.Fprintf(writer, "        fillcolor=\"%s\";\n", fill)
+		fmt.Fprintf(writer, "        color=\"%s\";\n", border)
+		fmt.Fprintln(writer)
+		for _, moduleID := range modules {
+			node := graph.GetNode(moduleID)
+			if node == nil {
+				continue
+			}
+			f.writeNode(writer, node, analysis, highlight, "        ")
+			if chunks.mixed[moduleID] && highlight == nil {
+				fmt.Fprintf(writer, "        %s [color=\"%s\", penwidth=3];\n", escapeDOTID(moduleID), chunkMixedColor)
+			}
+			writtenNodes[moduleID] = true
+		}
+		fmt.Fprintln(writer, "    }")
+		fmt.Fprintln(writer)
+	}
+
+	for i, cluster := range chunks.clusters {
+		colors := chunkColors[cluster.kind]
+		style := "filled"
+		if cluster.kind == domain.ChunkLazy {
+			style = "filled,dashed"
+		}
+		fmt.Fprintf(writer, "    // Chunk %d\n", i)
+		writeCluster(fmt.Sprintf("chunk_%d", i), cluster.label, colors.fill, colors.border, style, cluster.modules)
+	}
+	if len(chunks.shared) > 0 {
+		fmt.Fprintln(writer, "    // Modules shared by lazy chunks")
+		writeCluster("chunk_shared", "shared by lazy chunks", chunkSharedFill, chunkSharedBorder, "filled,dashed", chunks.shared)
+	}
+}
+
+// writeTemporalCoupling writes the hidden temporal coupling pairs as undirected dashed
+// edges that do not affect the layout
+func (f *DOTFormatter) writeTemporalCoupling(writer io.Writer, temporal *temporalLayer, filteredNodes map[string]bool) {
+	fmt.Fprintln(writer, "    // Temporal coupling (co-change without import)")
+	for _, pair := range temporal.hidden {
+		if !filteredNodes[pair.ModuleA] || !filteredNodes[pair.ModuleB] {
+			continue
+		}
+		fmt.Fprintf(writer, "    %s -> %s [dir=none, style=dashed, constraint=false, penwidth=2, color=\"%s\", fontcolor=\"%s\", label=\"co-change %.0f%%\", tooltip=\"%d shared commits\"];\n",
+			escapeDOTID(pair.ModuleA), escapeDOTID(pair.ModuleB), temporalHiddenColor, temporalHiddenColor,
+			pair.Degree*100, pair.SharedCommits)
+	}
+	fmt.Fprintln(writer)
+}
+
 // writeLegend writes the legend subgraph
-func (f *DOTFormatter) writeLegend(writer io.Writer) {
+func (f *DOTFormatter) writeLegend(writer io.Writer, showBreak, showQuery, showTemporal, showChunks bool) {
 	fmt.Fprintln(writer, "    // Legend")
 	fmt.Fprintln(writer, "    subgraph cluster_legend {")
 	fmt.Fprintln(writer, "        label=\"Legend\";")
@@ -462,6 +712,36 @@ func (f *DOTFormatter) writeLegend(writer io.Writer) {
 	fmt.Fprintln(writer, "        legend_cycle_a [label=\"\", style=invis, width=0, height=0];")
 	fmt.Fprintln(writer, "        legend_cycle_b [label=\"cycle\", style=invis, width=0, height=0];")
 	fmt.Fprintln(writer, "        legend_cycle_a -> legend_cycle_b [penwidth=2, color=\"#DC143C\", label=\"cycle\"];")
+	if showBreak {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "        // Cycle-breaking recommendation")
+		fmt.Fprintln(writer, "        legend_break_a [label=\"\", style=invis, width=0, height=0];")
+		fmt.Fprintln(writer, "        legend_break_b [label=\"break\", style=invis, width=0, height=0];")
+		fmt.Fprintf(writer, "        legend_break_a -> legend_break_b [style=dashed, penwidth=3, color=\"%s\", label=\"remove to break cycle\"];\n", cycleBreakColor)
+	}
+	if showQuery {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "        // Query result")
+		fmt.Fprintln(writer, "        legend_query_a [label=\"\", style=invis, width=0, height=0];")
+		fmt.Fprintln(writer, "        legend_query_b [label=\"query\", style=invis, width=0, height=0];")
+		fmt.Fprintf(writer, "        legend_query_a -> legend_query_b [penwidth=3, color=\"%s\", label=\"query result\"];\n", queryHighlightColor)
+	}
+	if showTemporal {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "        // Temporal coupling")
+		fmt.Fprintln(writer, "        legend_hidden_a [label=\"\", style=invis, width=0, height=0];")
+		fmt.Fprintln(writer, "        legend_hidden_b [label=\"hidden\", style=invis, width=0, height=0];")
+		fmt.Fprintf(writer, "        legend_hidden_a -> legend_hidden_b [dir=none, style=dashed, penwidth=2, color=\"%s\", label=\"co-change, no import\"];\n", temporalHiddenColor)
+		fmt.Fprintln(writer, "        legend_static_a [label=\"\", style=invis, width=0, height=0];")
+		fmt.Fprintln(writer, "        legend_static_b [label=\"static_only\", style=invis, width=0, height=0];")
+		fmt.Fprintf(writer, "        legend_static_a -> legend_static_b [penwidth=2, color=\"%s\", label=\"import, rarely co-change\"];\n", temporalStaticOnlyColor)
+	}
+	if showChunks {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "        // Code splitting")
+		fmt.Fprintf(writer, "        legend_mixed [label=\"static and lazy\", fillcolor=\"%s\", color=\"%s\", penwidth=3];\n",
+			nodeColors[domain.RiskLevelLow].fill, chunkMixedColor)
+	}
 	fmt.Fprintln(writer, "    }")
 }
 
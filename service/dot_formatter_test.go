@@ -283,6 +283,49 @@ func TestDOTFormatterWithCycles(t *testing.T) {
 	}
 }
 
+func TestDOTFormatterCycleBreakingEdges(t *testing.T) {
+	graph := domain.NewDependencyGraph()
+	graph.AddNode(&domain.ModuleNode{ID: "a.ts", Name: "a"})
+	graph.AddNode(&domain.ModuleNode{ID: "b.ts", Name: "b"})
+	graph.AddEdge(&domain.DependencyEdge{From: "a.ts", To: "b.ts", EdgeType: domain.EdgeTypeImport})
+	graph.AddEdge(&domain.DependencyEdge{From: "b.ts", To: "a.ts", EdgeType: domain.EdgeTypeTypeOnly})
+
+	response := &domain.DependencyGraphResponse{
+		Graph: graph,
+		Analysis: &domain.DependencyAnalysisResult{
+			CircularDependencies: &domain.CircularDependencyAnalysis{
+				HasCircularDependencies: true,
+				TotalCycles:             1,
+				CircularDependencies: []domain.CircularDependency{
+					{
+						Modules:  []string{"a.ts", "b.ts"},
+						Severity: domain.CycleSeverityLow,
+						BreakingEdges: []domain.CycleBreakingEdge{
+							{From: "b.ts", To: "a.ts", EdgeType: domain.EdgeTypeTypeOnly, TypeOnly: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := NewDOTFormatter(nil).FormatDependencyGraph(response)
+	if err != nil {
+		t.Fatalf("FormatDependencyGraph failed: %v", err)
+	}
+
+	expected := `b_ts -> a_ts [style=dashed, arrowhead=odot, penwidth=3, color="#FF8C00", fontcolor="#FF8C00", label="break (type_only)"];`
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected break edge %s, got:\n%s", expected, result)
+	}
+	if !strings.Contains(result, `a_ts -> b_ts [style=solid, arrowhead=normal, penwidth=2, color="#DC143C"];`) {
+		t.Error("Expected the remaining cycle edge to keep the cycle style")
+	}
+	if !strings.Contains(result, "legend_break_a -> legend_break_b") {
+		t.Error("Missing cycle-breaking legend entry")
+	}
+}
+
 func TestDOTFormatterEdgeTypes(t *testing.T) {
 	graph := domain.NewDependencyGraph()
 
@@ -767,3 +810,166 @@ func TestDOTFormatterEmptyGraph(t *testing.T) {
 		t.Error("Expected empty graph message")
 	}
 }
+
+func TestDOTFormatterQueryHighlight(t *testing.T) {
+	graph := domain.NewDependencyGraph()
+	graph.AddNode(&domain.ModuleNode{ID: "src/ui/view.ts", Name: "view"})
+	graph.AddNode(&domain.ModuleNode{ID: "src/db/client.ts", Name: "client"})
+	graph.AddNode(&domain.ModuleNode{ID: "src/core/format.ts", Name: "format"})
+
+	queryEdge := &domain.DependencyEdge{From: "src/ui/view.ts", To: "src/db/client.ts", EdgeType: domain.EdgeTypeImport}
+	graph.AddEdge(queryEdge)
+	graph.AddEdge(&domain.DependencyEdge{From: "src/ui/view.ts", To: "src/core/format.ts", EdgeType: domain.EdgeTypeImport})
+
+	response := &domain.DependencyGraphResponse{
+		Graph:    graph,
+		Analysis: &domain.DependencyAnalysisResult{},
+		Query: &domain.DependencyQueryResult{
+			Query:   domain.DependencyQuery{Kind: domain.QueryBetween, Source: "src/ui/**", Target: "src/db/**"},
+			Modules: []string{"src/db/client.ts", "src/ui/view.ts"},
+			Edges:   []*domain.DependencyEdge{queryEdge},
+		},
+	}
+
+	result, err := NewDOTFormatter(nil).FormatDependencyGraph(response)
+	if err != nil {
+		t.Fatalf("FormatDependencyGraph failed: %v", err)
+	}
+
+	for _, line := range strings.Split(result, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "src__ui__view_ts -> src__db__client_ts"):
+			if !strings.Contains(line, "penwidth=3") || !strings.Contains(line, queryHighlightColor) {
+				t.Errorf("Query edge should be highlighted: %s", line)
+			}
+		case strings.HasPrefix(line, "src__ui__view_ts -> src__core__format_ts"):
+			if strings.Contains(line, queryHighlightColor) {
+				t.Errorf("Edge outside the query should not be highlighted: %s", line)
+			}
+		case strings.HasPrefix(line, "src__db__client_ts ["):
+			if !strings.Contains(line, "penwidth=3") {
+				t.Errorf("Query module should be highlighted: %s", line)
+			}
+		case strings.HasPrefix(line, "src__core__format_ts ["):
+			if !strings.Contains(line, "dashed") {
+				t.Errorf("Module outside the query should be greyed out: %s", line)
+			}
+		}
+	}
+
+	if !strings.Contains(result, "legend_query_a") {
+		t.Error("Legend should include the query result entry")
+	}
+}
+
+func TestDOTFormatterTemporalCouplingLayer(t *testing.T) {
+	graph := domain.NewDependencyGraph()
+	graph.AddNode(&domain.ModuleNode{ID: "src/a.ts", Name: "a"})
+	graph.AddNode(&domain.ModuleNode{ID: "src/b.ts", Name: "b"})
+	graph.AddNode(&domain.ModuleNode{ID: "src/c.ts", Name: "c"})
+
+	staticEdge := &domain.DependencyEdge{From: "src/a.ts", To: "src/b.ts", EdgeType: domain.EdgeTypeImport}
+	graph.AddEdge(staticEdge)
+
+	response := &domain.DependencyGraphResponse{
+		Graph:    graph,
+		Analysis: &domain.DependencyAnalysisResult{},
+		TemporalCoupling: &domain.TemporalCouplingResult{
+			Hidden: []domain.TemporalCouplingPair{
+				{ModuleA: "src/a.ts", ModuleB: "src/c.ts", Kind: domain.TemporalHidden, SharedCommits: 6, Degree: 0.75},
+			},
+			StaticOnly: []domain.TemporalCouplingPair{
+				{ModuleA: "src/a.ts", ModuleB: "src/b.ts", Kind: domain.TemporalStaticOnly, Degree: 0.1,
+					Edges: []*domain.DependencyEdge{staticEdge}},
+			},
+		},
+	}
+
+	result, err := NewDOTFormatter(nil).FormatDependencyGraph(response)
+	if err != nil {
+		t.Fatalf("FormatDependencyGraph failed: %v", err)
+	}
+
+	var hiddenFound, staticFound bool
+	for _, line := range strings.Split(result, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "src__a_ts -> src__c_ts"):
+			hiddenFound = true
+			if !strings.Contains(line, "dir=none") || !strings.Contains(line, temporalHiddenColor) ||
+				!strings.Contains(line, "co-change 75%") || !strings.Contains(line, "constraint=false") {
+				t.Errorf("Hidden coupling should be an undirected dashed edge: %s", line)
+			}
+		case strings.HasPrefix(line, "src__a_ts -> src__b_ts"):
+			staticFound = true
+			if !strings.Contains(line, temporalStaticOnlyColor) || !strings.Contains(line, "co-change 10%") {
+				t.Errorf("Static-only import should be colored with its degree: %s", line)
+			}
+		}
+	}
+	if !hiddenFound || !staticFound {
+		t.Errorf("Expected the temporal coupling layer in:\n%s", result)
+	}
+	if !strings.Contains(result, "legend_hidden_a") || !strings.Contains(result, "legend_static_a") {
+		t.Error("Legend should include the temporal coupling entries")
+	}
+
+	// Without the layer nothing is drawn
+	response.TemporalCoupling = nil
+	result, err = NewDOTFormatter(nil).FormatDependencyGraph(response)
+	if err != nil {
+		t.Fatalf("FormatDependencyGraph failed: %v", err)
+	}
+	if strings.Contains(result, "co-change") || strings.Contains(result, "src__a_ts -> src__c_ts") {
+		t.Error("Temporal coupling should only be drawn when requested")
+	}
+}
+
+func TestDOTFormatterCodeSplittingClusters(t *testing.T) {
+	graph := domain.NewDependencyGraph()
+	for _, id := range []string{"src/main.ts", "src/admin.ts", "src/settings.ts", "src/profile.ts", "src/chart.ts"} {
+		graph.AddNode(&domain.ModuleNode{ID: id, Name: shortenModuleName(id)})
+	}
+	graph.AddEdge(&domain.DependencyEdge{From: "src/main.ts", To: "src/admin.ts", EdgeType: domain.EdgeTypeImport})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/main.ts", To: "src/settings.ts", EdgeType: domain.EdgeTypeDynamic})
+
+	response := &domain.DependencyGraphResponse{
+		Graph:    graph,
+		Analysis: &domain.DependencyAnalysisResult{},
+		CodeSplitting: &domain.CodeSplittingResult{
+			Chunks: []domain.Chunk{
+				{Root: "src/main.ts", Kind: domain.ChunkEntry, Modules: []string{"src/admin.ts", "src/main.ts"}, Size: 2048},
+				{Root: "src/settings.ts", Kind: domain.ChunkLazy, Modules: []string{"src/chart.ts", "src/settings.ts"}, Size: 900, OwnSize: 900},
+				{Root: "src/profile.ts", Kind: domain.ChunkLazy, Modules: []string{"src/chart.ts", "src/profile.ts"}, Size: 700, OwnSize: 700},
+				{Root: "src/admin.ts", Kind: domain.ChunkLazy, Modules: []string{"src/admin.ts"}, Size: 100},
+			},
+			Mixed: []domain.MixedModule{{Module: "src/admin.ts", DefeatsSplit: true}},
+		},
+	}
+
+	result, err := NewDOTFormatter(nil).FormatDependencyGraph(response)
+	if err != nil {
+		t.Fatalf("FormatDependencyGraph failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`label="entry: main (2.0 KB)"`,
+		`label="lazy: settings (900 B, 900 B deferred)"`,
+		`label="shared by lazy chunks"`,
+		`src__admin_ts [color="` + chunkMixedColor + `", penwidth=3];`,
+		"legend_mixed",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in:\n%s", want, result)
+		}
+	}
+	// The admin chunk is loaded by the entry chunk and gets no cluster of its own
+	if strings.Contains(result, "lazy: admin") {
+		t.Errorf("Expected no cluster for a chunk without modules of its own:\n%s", result)
+	}
+	// chart.ts is drawn once, in the shared cluster
+	if strings.Count(result, "src__chart_ts [label=") != 1 {
+		t.Errorf("Expected chart.ts to be drawn once:\n%s", result)
+	}
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// runGit runs a git command in dir and returns its standard output
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+	}
+	return stdout.String(), nil
+}
+
+// GitRepositoryRoot returns the absolute path of the repository containing dir
+func GitRepositoryRoot(ctx context.Context, dir string) (string, error) {
+	out, err := runGit(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(out)), nil
+}
+
+// GitChangedFiles returns the absolute paths of the files changed between ref and the
+// working tree of the repository containing dir, including untracked files. Refs
+// starting with "-" are rejected so that they cannot pass options to git.
+func GitChangedFiles(ctx context.Context, dir, ref string) ([]string, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid git ref %q", ref), nil)
+	}
+	root, err := GitRepositoryRoot(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := runGit(ctx, root, "diff", "--name-only", "--no-renames", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := runGit(ctx, root, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, line := range strings.Split(diff+"\n"+untracked, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		files = append(files, filepath.Join(root, filepath.FromSlash(line)))
+	}
+	return files, nil
+}
+
+// GitCommitInfo describes the commit checked out in a working tree
+type GitCommitInfo struct {
+	Hash    string
+	Branch  string
+	Subject string
+	Dirty   bool // Tracked files have uncommitted changes
+}
+
+// GitHeadCommit returns the commit checked out in the repository containing dir
+func GitHeadCommit(ctx context.Context, dir string) (*GitCommitInfo, error) {
+	out, err := runGit(ctx, dir, "log", "-1", "--format=%H%n%s")
+	if err != nil {
+		return nil, err
+	}
+	hash, subject, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	info := &GitCommitInfo{Hash: hash, Subject: subject}
+
+	// A detached HEAD has no branch name
+	if branch, err := runGit(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		if branch = strings.TrimSpace(branch); branch != "HEAD" {
+			info.Branch = branch
+		}
+	}
+	// Untracked files are ignored so that the history file itself does not mark runs dirty
+	if status, err := runGit(ctx, dir, "status", "--porcelain", "--untracked-files=no"); err == nil {
+		info.Dirty = strings.TrimSpace(status) != ""
+	}
+	return info, nil
+}
+
+// gitLogCommitMarker starts each commit in the output of GitLog's git log format
+const gitLogCommitMarker = "\x1e"
+
+// gitPatchArgs make git print hunk headers without context lines, with fixed path prefixes
+var gitPatchArgs = []string{"--unified=0", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/"}
+
+// GitLog returns the non-merge commits of the repository containing dir that touch
+// files below dir, newest first, with the files each one changed. A zero since reads
+// the whole history.
+func GitLog(ctx context.Context, dir string, since time.Time) ([]domain.GitCommit, error) {
+	return gitLog(ctx, dir, since, false)
+}
+
+// GitLogWithHunks is GitLog with the changed line ranges of each file
+func GitLogWithHunks(ctx context.Context, dir string, since time.Time) ([]domain.GitCommit, error) {
+	return gitLog(ctx, dir, since, true)
+}
+
+func gitLog(ctx context.Context, dir string, since time.Time, hunks bool) ([]domain.GitCommit, error) {
+	root, err := GitRepositoryRoot(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"log", "--no-merges", "--no-renames", "--numstat", "--format=" + gitLogCommitMarker + "%H%x1f%an%x1f%ae%x1f%at"}
+	if hunks {
+		args = append(append(args, "--patch"), gitPatchArgs...)
+	}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	args = append(args, "--", ".")
+	out, err := runGit(ctx, dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []domain.GitCommit
+	// Patch lines start with a prefix, so the marker only starts a line before a commit
+	for _, record := range strings.Split("\n"+out, "\n"+gitLogCommitMarker) {
+		header, body, _ := strings.Cut(record, "\n")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected git log timestamp %q", fields[3])
+		}
+		commit := domain.GitCommit{Hash: fields[0], Author: fields[1], Email: fields[2], Time: time.Unix(timestamp, 0)}
+
+		numstat, patch := body, ""
+		if i := strings.Index("\n"+body, "\ndiff --git "); i >= 0 {
+			numstat, patch = body[:i], body[i:]
+		}
+		for _, line := range strings.Split(numstat, "\n") {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			// Binary files have no line counts ("-")
+			added, _ := strconv.Atoi(parts[0])
+			deleted, _ := strconv.Atoi(parts[1])
+			commit.Files = append(commit.Files, domain.GitFileChange{
+				Path:    filepath.Join(root, filepath.FromSlash(parts[2])),
+				Added:   added,
+				Deleted: deleted,
+			})
+		}
+		if patch != "" {
+			byPath := parseGitHunks(root, patch)
+			for i := range commit.Files {
+				commit.Files[i].Hunks = byPath[commit.Files[i].Path]
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// GitWorkingTreeHunks returns the changed line ranges of the files below dir that
+// differ between HEAD and the working tree, sorted by path
+func GitWorkingTreeHunks(ctx context.Context, dir string) ([]domain.GitFileChange, error) {
+	root, err := GitRepositoryRoot(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	args := append(append([]string{"diff", "--no-renames"}, gitPatchArgs...), "HEAD", "--", ".")
+	out, err := runGit(ctx, dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := parseGitHunks(root, out)
+	changes := make([]domain.GitFileChange, 0, len(byPath))
+	for path, hunks := range byPath {
+		changes = append(changes, domain.GitFileChange{Path: path, Hunks: hunks})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// parseGitHunks reads the hunk headers of a patch without context lines, by the
+// absolute path of the changed file. Deleted files are left out.
+func parseGitHunks(root, patch string) map[string][]domain.GitHunk {
+	byPath := make(map[string][]domain.GitHunk)
+	var current string
+	inHeader := false
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHeader, current = true, ""
+		case inHeader && strings.HasPrefix(line, "+++ "):
+			if path, ok := strings.CutPrefix(strings.TrimPrefix(line, "+++ "), "b/"); ok {
+				current = filepath.Join(root, filepath.FromSlash(path))
+			}
+		case strings.HasPrefix(line, "@@ "):
+			// Changed lines start with +, - or \, never with a hunk header
+			inHeader = false
+			if hunk, ok := parseGitHunkHeader(line); ok && current != "" {
+				byPath[current] = append(byPath[current], hunk)
+			}
+		}
+	}
+	return byPath
+}
+
+// parseGitHunkHeader parses "@@ -start[,lines] +start[,lines] @@"
+func parseGitHunkHeader(line string) (domain.GitHunk, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return domain.GitHunk{}, false
+	}
+	oldStart, oldLines, okOld := parseGitHunkRange(fields[1][1:])
+	newStart, newLines, okNew := parseGitHunkRange(fields[2][1:])
+	if !okOld || !okNew {
+		return domain.GitHunk{}, false
+	}
+	return domain.GitHunk{OldStart: oldStart, OldLines: oldLines, NewStart: newStart, NewLines: newLines}, true
+}
+
+// parseGitHunkRange parses "start[,lines]"; lines defaults to 1
+func parseGitHunkRange(s string) (int, int, bool) {
+	startText, linesText, hasLines := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !hasLines {
+		return start, 1, true
+	}
+	lines, err := strconv.Atoi(linesText)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, lines, true
+}
+
+// commonParentDirectory returns the deepest directory containing all the files, the
+// keys of byPath, so that git only reads the history below it
+func commonParentDirectory(byPath map[string]string) string {
+	dirs := make([][]string, 0, len(byPath))
+	for file := range byPath {
+		dirs = append(dirs, directoryParts(filepath.ToSlash(filepath.Dir(file))))
+	}
+	return filepath.FromSlash(directoryName(commonDirectoryParts(dirs)))
+}
+
+// lookupGitPath finds a path reported by git in a map keyed by canonical paths,
+// resolving symlinks when the path is not found as is
+func lookupGitPath(byPath map[string]string, path string) (string, bool) {
+	if value, ok := byPath[path]; ok {
+		return value, true
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		value, ok := byPath[resolved]
+		return value, ok
+	}
+	return "", false
+}
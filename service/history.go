@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// maxHistoryLineSize bounds a single history record (summaries are a few KB)
+const maxHistoryLineSize = 1024 * 1024
+
+// HistoryStore appends analysis summaries to a JSON Lines file
+type HistoryStore struct {
+	path string
+}
+
+// NewHistoryStore creates a history store backed by the file at path
+func NewHistoryStore(path string) *HistoryStore {
+	return &HistoryStore{path: path}
+}
+
+// Path returns the history file path
+func (s *HistoryStore) Path() string {
+	return s.path
+}
+
+// Append records an entry, creating the file and its directory if needed
+func (s *HistoryStore) Append(entry domain.HistoryEntry) (err error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close history file: %w", closeErr)
+		}
+	}()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+// Load reads all recorded entries in file order. A missing file has no entries.
+func (s *HistoryStore) Load() ([]domain.HistoryEntry, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	var entries []domain.HistoryEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxHistoryLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry domain.HistoryEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid history record at %s:%d", s.path, line), err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return entries, nil
+}
+
+// NewHistoryEntry creates a history entry for a summary, keyed by the git commit
+// checked out in dir when it is inside a repository
+func NewHistoryEntry(ctx context.Context, summary *domain.AnalyzeSummary, dir string) domain.HistoryEntry {
+	entry := domain.HistoryEntry{
+		Timestamp: time.Now().UTC(),
+		Version:   version.GetVersion(),
+		Summary:   *summary,
+	}
+	if info, err := GitHeadCommit(ctx, dir); err == nil {
+		entry.Commit = info.Hash
+		entry.Branch = info.Branch
+		entry.Subject = info.Subject
+		entry.Dirty = info.Dirty
+	}
+	return entry
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// WriteHotspots writes the hotspot report in the specified format
+func (f *OutputFormatterImpl) WriteHotspots(response *domain.HotspotResponse, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatJSON:
+		return WriteJSON(writer, response)
+	case domain.OutputFormatText:
+		return f.writeHotspotsText(response, writer)
+	case domain.OutputFormatHTML:
+		return f.writeHotspotsHTML(response, writer)
+	default:
+		return fmt.Errorf("unsupported output format for hotspot report: %s", format)
+	}
+}
+
+// writeHotspotsText writes the hotspot report as plain text
+func (f *OutputFormatterImpl) writeHotspotsText(response *domain.HotspotResponse, writer io.Writer) error {
+	summary := response.Summary
+	fmt.Fprintf(writer, "\n=== Hotspots ===\n\n")
+	fmt.Fprintf(writer, "Generated: %s\n", response.GeneratedAt)
+	fmt.Fprintf(writer, "Repository: %s\n\n", summary.Repository)
+
+	fmt.Fprintln(writer, "Summary:")
+	fmt.Fprintf(writer, "  History: %s\n", hotspotWindow(summary.Since))
+	if summary.RecentSince != nil {
+		fmt.Fprintf(writer, "  Recent window: %s (changes count %dx)\n", hotspotWindow(summary.RecentSince), domain.HotspotRecentWeight)
+	}
+	fmt.Fprintf(writer, "  Files: %d analyzed, %d changed\n", summary.FilesAnalyzed, summary.FilesChanged)
+	fmt.Fprintf(writer, "  Commits: %d by %d authors\n", summary.Commits, summary.Authors)
+	fmt.Fprintf(writer, "  High risk: %d files, %d functions\n", summary.HighRiskFiles, summary.HighRiskFunctions)
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "File Hotspots:")
+	if len(response.Files) == 0 {
+		fmt.Fprintln(writer, "  No changed files with complexity in the history window")
+	} else {
+		fmt.Fprintf(writer, "  %5s  %-6s  %7s  %6s  %7s  %4s  %4s  %-10s  %s\n",
+			"SCORE", "RISK", "COMMITS", "RECENT", "AUTHORS", "CPLX", "CBO", "CHANGED", "FILE")
+		for _, h := range response.Files {
+			fmt.Fprintf(writer, "  %5.1f  %-6s  %7d  %6d  %7d  %4d  %4d  %-10s  %s\n",
+				h.Score, h.RiskLevel, h.Commits, h.RecentCommits, h.Authors, h.TotalComplexity, h.MaxCBO,
+				h.LastChanged.Local().Format("2006-01-02"), hotspotPath(summary.Repository, h.FilePath))
+		}
+	}
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "Function Hotspots:")
+	if len(response.Functions) == 0 {
+		fmt.Fprintln(writer, "  No changed functions in the history window")
+	} else {
+		fmt.Fprintf(writer, "  %5s  %-6s  %7s  %6s  %7s  %4s  %-10s  %s\n",
+			"SCORE", "RISK", "COMMITS", "RECENT", "AUTHORS", "CPLX", "CHANGED", "FUNCTION")
+		for _, h := range response.Functions {
+			fmt.Fprintf(writer, "  %5.1f  %-6s  %7d  %6d  %7d  %4d  %-10s  %s (%s:%d-%d)\n",
+				h.Score, h.RiskLevel, h.Commits, h.RecentCommits, h.Authors, h.Complexity,
+				h.LastChanged.Local().Format("2006-01-02"), h.Name,
+				hotspotPath(summary.Repository, h.FilePath), h.StartLine, h.EndLine)
+		}
+	}
+	fmt.Fprintln(writer)
+
+	if len(response.Warnings) > 0 {
+		fmt.Fprintln(writer, "Warnings:")
+		for _, w := range response.Warnings {
+			fmt.Fprintf(writer, "  %s\n", w)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return nil
+}
+
+// hotspotWindow describes the start of a history window
+func hotspotWindow(since *time.Time) string {
+	if since == nil {
+		return "all commits"
+	}
+	return "since " + since.Local().Format("2006-01-02")
+}
+
+// hotspotPath shows a file relative to the repository root when it is inside it
+func hotspotPath(root, file string) string {
+	if root == "" {
+		return file
+	}
+	abs, err := canonicalPath(file)
+	if err != nil {
+		return file
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || filepath.IsAbs(rel) || len(rel) > 2 && rel[:3] == ".."+string(filepath.Separator) {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// writeHotspotsHTML writes the hotspot report as a standalone HTML page
+func (f *OutputFormatterImpl) writeHotspotsHTML(response *domain.HotspotResponse, writer io.Writer) error {
+	funcMap := template.FuncMap{
+		"formatDate": func(t time.Time) string {
+			return t.Local().Format("2006-01-02")
+		},
+		"relPath": func(file string) string {
+			return hotspotPath(response.Summary.Repository, file)
+		},
+		"window":       hotspotWindow,
+		"recentWeight": func() int { return domain.HotspotRecentWeight },
+	}
+
+	tmpl := template.Must(template.New("hotspots").Funcs(funcMap).Parse(hotspotsHTMLTemplate))
+	return tmpl.Execute(writer, response)
+}
+
+const hotspotsHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>jscan Hotspots</title>
+    <style>
+        * { margin: 0; padding: 0; box-sizing: border-box; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            min-height: 100vh;
+        }
+        .container { max-width: 1200px; margin: 0 auto; padding: 20px; }
+        .panel {
+            background: white;
+            border-radius: 10px;
+            padding: 30px;
+            margin-bottom: 20px;
+            box-shadow: 0 10px 30px rgba(0,0,0,0.1);
+        }
+        h1 { color: #667eea; margin-bottom: 10px; }
+        h2 { color: #667eea; margin-bottom: 15px; }
+        .subtitle { color: #666; font-size: 14px; }
+        .metric-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 15px; margin-top: 20px; }
+        .metric-card { background: #f8f9fa; padding: 20px; border-radius: 8px; text-align: center; }
+        .metric-value { font-size: 28px; font-weight: bold; color: #667eea; }
+        .metric-label { color: #666; font-size: 14px; }
+        .table { width: 100%; border-collapse: collapse; }
+        .table th, .table td { padding: 10px; text-align: left; border-bottom: 1px solid #eee; }
+        .table th { background: #f8f9fa; font-weight: 600; }
+        .bar { background: #eee; border-radius: 4px; height: 8px; width: 120px; }
+        .bar div { height: 8px; border-radius: 4px; }
+        .risk-high { color: #f44336; font-weight: bold; }
+        .risk-medium { color: #ff9800; font-weight: bold; }
+        .risk-low { color: #4caf50; }
+        .bar-high { background: #f44336; }
+        .bar-medium { background: #ff9800; }
+        .bar-low { background: #4caf50; }
+        code { font-family: 'SFMono-Regular', Consolas, monospace; font-size: 13px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="panel">
+            <h1>jscan Hotspots</h1>
+            <div class="subtitle">Generated: {{.GeneratedAt}} | Repository: {{.Summary.Repository}} | History: {{window .Summary.Since}}{{if .Summary.RecentSince}} | Recent: {{window .Summary.RecentSince}} (changes count {{recentWeight}}x){{end}} | Version: {{.Version}}</div>
+            <div class="metric-grid">
+                <div class="metric-card">
+                    <div class="metric-value">{{.Summary.FilesChanged}} / {{.Summary.FilesAnalyzed}}</div>
+                    <div class="metric-label">Files Changed</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Summary.Commits}}</div>
+                    <div class="metric-label">Commits</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Summary.Authors}}</div>
+                    <div class="metric-label">Authors</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Summary.HighRiskFiles}} / {{.Summary.HighRiskFunctions}}</div>
+                    <div class="metric-label">High Risk Files / Functions</div>
+                </div>
+            </div>
+        </div>
+
+        <div class="panel">
+            <h2>File Hotspots</h2>
+            <p class="subtitle" style="margin-bottom: 10px;">Score = churn × (total complexity + max CBO), relative to the top file.</p>
+            {{if .Files}}
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Score</th>
+                        <th>File</th>
+                        <th>Commits</th>
+                        <th>Recent</th>
+                        <th>Authors</th>
+                        <th>+/- Lines</th>
+                        <th>Complexity</th>
+                        <th>Max CBO</th>
+                        <th>Last Changed</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Files}}
+                    <tr>
+                        <td><span class="risk-{{.RiskLevel}}">{{printf "%.1f" .Score}}</span><div class="bar"><div class="bar-{{.RiskLevel}}" style="width: {{printf "%.0f" .Score}}%"></div></div></td>
+                        <td><code>{{relPath .FilePath}}</code>{{if .MainAuthor}}<br><span class="subtitle">mostly {{.MainAuthor}}</span>{{end}}</td>
+                        <td>{{.Commits}}</td>
+                        <td>{{.RecentCommits}}</td>
+                        <td>{{.Authors}}</td>
+                        <td>+{{.LinesAdded}} / -{{.LinesDeleted}}</td>
+                        <td>{{.TotalComplexity}} (max {{.MaxComplexity}})</td>
+                        <td>{{.MaxCBO}}</td>
+                        <td>{{formatDate .LastChanged}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+            {{else}}
+            <p>No changed files with complexity in the history window.</p>
+            {{end}}
+        </div>
+
+        <div class="panel">
+            <h2>Function Hotspots</h2>
+            <p class="subtitle" style="margin-bottom: 10px;">Churn counts the commits whose changes touched the lines of each function, followed back through the history.</p>
+            {{if .Functions}}
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Score</th>
+                        <th>Function</th>
+                        <th>Location</th>
+                        <th>Commits</th>
+                        <th>Recent</th>
+                        <th>Authors</th>
+                        <th>Complexity</th>
+                        <th>Last Changed</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Functions}}
+                    <tr>
+                        <td><span class="risk-{{.RiskLevel}}">{{printf "%.1f" .Score}}</span><div class="bar"><div class="bar-{{.RiskLevel}}" style="width: {{printf "%.0f" .Score}}%"></div></div></td>
+                        <td><code>{{.Name}}</code></td>
+                        <td><code>{{relPath .FilePath}}:{{.StartLine}}-{{.EndLine}}</code></td>
+                        <td>{{.Commits}}</td>
+                        <td>{{.RecentCommits}}</td>
+                        <td>{{.Authors}}</td>
+                        <td>{{.Complexity}}</td>
+                        <td>{{formatDate .LastChanged}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+            {{else}}
+            <p>No changed functions in the history window.</p>
+            {{end}}
+        </div>
+
+        {{if .Warnings}}
+        <div class="panel">
+            <h2>Warnings</h2>
+            <ul>
+                {{range .Warnings}}<li>{{.}}</li>{{end}}
+            </ul>
+        </div>
+        {{end}}
+    </div>
+</body>
+</html>
+`
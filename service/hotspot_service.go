@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// HotspotServiceImpl implements hotspot analysis, combining the git history of the
+// analyzed files with their complexity and coupling
+type HotspotServiceImpl struct {
+	complexityConfig *config.ComplexityConfig
+}
+
+// NewHotspotService creates a new hotspot service; cfg provides the complexity settings
+func NewHotspotService(cfg *config.ComplexityConfig) *HotspotServiceImpl {
+	return &HotspotServiceImpl{complexityConfig: cfg}
+}
+
+// Analyze reads the git history of the request paths and ranks the files and
+// functions that are both complex and frequently changed
+func (s *HotspotServiceImpl) Analyze(ctx context.Context, req domain.HotspotRequest) (*domain.HotspotResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Git reports canonical paths; map them back to the paths being analyzed
+	analyzed := make(map[string]string, len(req.Paths))
+	for _, file := range req.Paths {
+		canonical, err := canonicalPath(file)
+		if err != nil {
+			return nil, domain.NewFileNotFoundError(file, err)
+		}
+		analyzed[canonical] = file
+	}
+	dir := commonParentDirectory(analyzed)
+
+	root, err := GitRepositoryRoot(ctx, dir)
+	if err != nil {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("%s is not inside a git repository", dir), err)
+	}
+	commits, err := GitLogWithHunks(ctx, dir, req.Since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	response := &domain.HotspotResponse{
+		Files:       []domain.FileHotspot{},
+		Functions:   []domain.FunctionHotspot{},
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.GetVersion(),
+	}
+
+	// Keep only the changes to analyzed files
+	authors := make(map[string]bool)
+	changed := make(map[string]bool)
+	var history []domain.GitCommit
+	for _, commit := range commits {
+		var files []domain.GitFileChange
+		for _, change := range commit.Files {
+			file, ok := lookupGitPath(analyzed, change.Path)
+			if !ok {
+				continue
+			}
+			change.Path = file
+			files = append(files, change)
+			changed[file] = true
+		}
+		if len(files) == 0 {
+			continue
+		}
+		commit.Files = files
+		history = append(history, commit)
+		authors[commit.AuthorKey()] = true
+	}
+
+	// Trivial functions are kept so that file totals cover the whole file
+	complexityConfig := *s.complexityConfig
+	complexityConfig.ReportUnchanged = true
+	complexityResponse, err := NewComplexityService(&complexityConfig).Analyze(ctx, domain.ComplexityRequest{
+		Paths:           req.Paths,
+		LowThreshold:    complexityConfig.LowThreshold,
+		MediumThreshold: complexityConfig.MediumThreshold,
+		SortBy:          domain.SortByComplexity,
+	})
+	var analysisErr domain.DomainError
+	switch {
+	case err == nil:
+		response.Warnings = append(response.Warnings, complexityResponse.Errors...)
+	case errors.As(err, &analysisErr) && analysisErr.Code == domain.ErrCodeAnalysisError:
+		// No functions: files can still be hotspots through their coupling
+		complexityResponse = nil
+	default:
+		return nil, fmt.Errorf("complexity analysis failed: %w", err)
+	}
+
+	cboResponse, err := NewCBOServiceWithDefaults().Analyze(ctx, domain.CBORequest{
+		Paths:  req.Paths,
+		SortBy: domain.SortByCoupling,
+	})
+	if err != nil {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("CBO analysis failed: %v", err))
+		cboResponse = nil
+	}
+
+	// Only the changed files can have function hotspots
+	var functions []domain.FunctionComplexity
+	if complexityResponse != nil {
+		for _, fn := range complexityResponse.Functions {
+			if changed[fn.FilePath] {
+				functions = append(functions, fn)
+			}
+		}
+	}
+
+	// Function lines are numbered in the working tree; its uncommitted changes map
+	// them to the last commit
+	functionHistory := history
+	if len(functions) > 0 {
+		uncommitted, err := GitWorkingTreeHunks(ctx, dir)
+		if err != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("git diff failed, uncommitted changes are ignored: %v", err))
+		}
+		var worktree domain.GitCommit
+		for _, change := range uncommitted {
+			if file, ok := lookupGitPath(analyzed, change.Path); ok {
+				change.Path = file
+				worktree.Files = append(worktree.Files, change)
+			}
+		}
+		if len(worktree.Files) > 0 {
+			functionHistory = append([]domain.GitCommit{worktree}, history...)
+		}
+	}
+
+	hotspotAnalyzer := analyzer.NewHotspotAnalyzer(req.Since, req.RecentSince)
+	files := hotspotAnalyzer.RankFiles(history, complexityResponse, cboResponse)
+	fns := hotspotAnalyzer.RankFunctions(functions, functionHistory)
+
+	response.Summary = domain.HotspotSummary{
+		Repository:    root,
+		FilesAnalyzed: len(req.Paths),
+		FilesChanged:  len(changed),
+		Commits:       len(history),
+		Authors:       len(authors),
+	}
+	if !req.Since.IsZero() {
+		since := req.Since
+		response.Summary.Since = &since
+	}
+	if !req.RecentSince.IsZero() {
+		recentSince := req.RecentSince
+		response.Summary.RecentSince = &recentSince
+	}
+	for _, file := range files {
+		if file.RiskLevel == domain.RiskLevelHigh {
+			response.Summary.HighRiskFiles++
+		}
+	}
+	for _, fn := range fns {
+		if fn.RiskLevel == domain.RiskLevelHigh {
+			response.Summary.HighRiskFunctions++
+		}
+	}
+
+	if req.Top > 0 && len(files) > req.Top {
+		files = files[:req.Top]
+	}
+	if req.Top > 0 && len(fns) > req.Top {
+		fns = fns[:req.Top]
+	}
+	response.Files = append(response.Files, files...)
+	response.Functions = append(response.Functions, fns...)
+
+	return response, nil
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+)
+
+// hotspotRepo is a temporary git repository whose commits are dated explicitly
+type hotspotRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newHotspotRepo(t *testing.T) *hotspotRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := &hotspotRepo{t: t, dir: t.TempDir()}
+	repo.git("", time.Time{}, "init", "-q")
+	return repo
+}
+
+func (r *hotspotRepo) git(author string, date time.Time, args ...string) {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL="+author+"@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if !date.IsZero() {
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+date.Format(time.RFC3339), "GIT_COMMITTER_DATE="+date.Format(time.RFC3339))
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		r.t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+// commit writes the files and commits them as author at date
+func (r *hotspotRepo) commit(author string, date time.Time, files map[string]string) {
+	r.t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	r.git(author, date, "add", "-A")
+	r.git(author, date, "commit", "-q", "-m", "change by "+author)
+}
+
+const hotspotBusyV1 = `function busy(x) {
+  if (x > 1) {
+    return 1;
+  }
+  return 0;
+}
+
+function calm() {
+  return 2;
+}
+`
+
+const hotspotBusyV2 = `function busy(x) {
+  if (x > 1) {
+    return 1;
+  }
+  if (x < 0) {
+    return -1;
+  }
+  return 0;
+}
+
+function calm() {
+  return 2;
+}
+`
+
+const hotspotBusyV3 = `function busy(x) {
+  if (x > 1) {
+    return 1;
+  }
+  if (x < 0) {
+    return -1;
+  }
+  for (let i = 0; i < x; i++) {
+    x--;
+  }
+  return 0;
+}
+
+function calm() {
+  return 2;
+}
+`
+
+const hotspotStable = `export function stable(a) {
+  if (a) {
+    return 1;
+  }
+  return 2;
+}
+`
+
+func TestGitLogAndHunks(t *testing.T) {
+	repo := newHotspotRepo(t)
+	old := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	recent := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	repo.commit("ann", old, map[string]string{"src/busy.js": hotspotBusyV1, "README.md": "readme\n"})
+	repo.commit("bob", recent, map[string]string{"src/busy.js": hotspotBusyV2})
+
+	ctx := context.Background()
+	commits, err := GitLog(ctx, filepath.Join(repo.dir, "src"), time.Time{})
+	if err != nil {
+		t.Fatalf("GitLog failed: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Author != "bob" || commits[1].Email != "ann@example.com" {
+		t.Fatalf("Expected 2 commits newest first, got %+v", commits)
+	}
+	if !commits[0].Time.Equal(recent) || len(commits[0].Hash) != 40 {
+		t.Errorf("Unexpected commit metadata %+v", commits[0])
+	}
+	// Only the files below src are reported, with absolute paths
+	root, _ := GitRepositoryRoot(ctx, repo.dir)
+	busy := filepath.Join(root, "src", "busy.js")
+	if len(commits[1].Files) != 1 || commits[1].Files[0].Path != busy || commits[1].Files[0].Added != 10 {
+		t.Errorf("Unexpected files of the first commit %+v", commits[1].Files)
+	}
+	if files := commits[0].Files; len(files) != 1 || files[0].Added != 3 || files[0].Deleted != 0 {
+		t.Errorf("Unexpected numstat of the second commit %+v", files)
+	}
+
+	commits, err = GitLog(ctx, repo.dir, old.AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatalf("GitLog failed: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Author != "bob" {
+		t.Errorf("Expected only the recent commit, got %+v", commits)
+	}
+
+	commits, err = GitLogWithHunks(ctx, repo.dir, time.Time{})
+	if err != nil {
+		t.Fatalf("GitLogWithHunks failed: %v", err)
+	}
+	if len(commits) != 2 || len(commits[0].Files) != 1 || commits[0].Files[0].Path != busy {
+		t.Fatalf("Unexpected commits %+v", commits)
+	}
+	if hunks := commits[0].Files[0].Hunks; len(hunks) != 1 || hunks[0] != (domain.GitHunk{OldStart: 4, OldLines: 0, NewStart: 5, NewLines: 3}) {
+		t.Errorf("Expected lines 5-7 inserted by bob, got %+v", hunks)
+	}
+	if hunks := commits[1].Files[1].Hunks; len(hunks) != 1 || hunks[0].NewLines != 10 {
+		t.Errorf("Expected busy.js added by ann, got %+v", commits[1].Files)
+	}
+
+	// Uncommitted changes are diffed against HEAD
+	if err := os.WriteFile(busy, []byte(hotspotBusyV2+"// wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := GitWorkingTreeHunks(ctx, repo.dir)
+	if err != nil {
+		t.Fatalf("GitWorkingTreeHunks failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Path != busy || len(changes[0].Hunks) != 1 ||
+		changes[0].Hunks[0] != (domain.GitHunk{OldStart: 13, OldLines: 0, NewStart: 14, NewLines: 1}) {
+		t.Errorf("Expected the appended line, got %+v", changes)
+	}
+}
+
+func TestHotspotServiceAnalyze(t *testing.T) {
+	repo := newHotspotRepo(t)
+	now := time.Now().Truncate(time.Second)
+	repo.commit("ann", now.AddDate(-3, 0, 0), map[string]string{"src/ancient.js": hotspotStable})
+	repo.commit("ann", now.AddDate(0, -6, 0), map[string]string{"src/busy.js": hotspotBusyV1, "src/stable.js": hotspotStable})
+	repo.commit("bob", now.AddDate(0, -2, 0), map[string]string{"src/busy.js": hotspotBusyV2})
+	repo.commit("cat", now.AddDate(0, 0, -3), map[string]string{"src/busy.js": hotspotBusyV3})
+
+	src := filepath.Join(repo.dir, "src")
+	paths := []string{
+		filepath.Join(src, "ancient.js"),
+		filepath.Join(src, "busy.js"),
+		filepath.Join(src, "stable.js"),
+	}
+	cfg := config.DefaultConfig()
+	svc := NewHotspotService(&cfg.Complexity)
+
+	resp, err := svc.Analyze(context.Background(), domain.HotspotRequest{
+		Paths:       paths,
+		Since:       now.AddDate(-1, 0, 0),
+		RecentSince: now.AddDate(0, 0, -30),
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	summary := resp.Summary
+	if summary.FilesAnalyzed != 3 || summary.FilesChanged != 2 || summary.Commits != 3 || summary.Authors != 3 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if summary.Since == nil || summary.RecentSince == nil || summary.Repository == "" {
+		t.Errorf("Expected the windows and repository in the summary, got %+v", summary)
+	}
+
+	// busy.js: 3 commits + 1 recent = 4, x 5 = 20; stable.js: 1 x 2 = 2; ancient.js is outside the window
+	if len(resp.Files) != 2 {
+		t.Fatalf("Expected 2 file hotspots, got %+v", resp.Files)
+	}
+	if resp.Files[0].FilePath != paths[1] || resp.Files[0].Commits != 3 || resp.Files[0].RecentCommits != 1 ||
+		resp.Files[0].Authors != 3 || resp.Files[0].TotalComplexity != 5 || resp.Files[0].Score != 100 {
+		t.Errorf("Expected busy.js to be the top hotspot, got %+v", resp.Files[0])
+	}
+	if resp.Files[1].FilePath != paths[2] || resp.Files[1].Score != 10 {
+		t.Errorf("Unexpected second hotspot %+v", resp.Files[1])
+	}
+
+	// busy() was last changed by all three commits; calm() only by the first
+	if len(resp.Functions) < 2 {
+		t.Fatalf("Expected function hotspots, got %+v", resp.Functions)
+	}
+	if fn := resp.Functions[0]; fn.Name != "busy" || fn.Commits != 3 || fn.RecentCommits != 1 || fn.Complexity != 4 {
+		t.Errorf("Expected busy to be the top function, got %+v", fn)
+	}
+
+	resp, err = svc.Analyze(context.Background(), domain.HotspotRequest{Paths: paths, Top: 1})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(resp.Files) != 1 || len(resp.Functions) != 1 || resp.Summary.FilesChanged != 3 {
+		t.Errorf("Expected the whole history truncated to 1 hotspot, got %+v", resp)
+	}
+}
+
+func TestHotspotServiceOutsideGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	file := filepath.Join(t.TempDir(), "a.js")
+	if err := os.WriteFile(file, []byte(hotspotStable), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	_, err := NewHotspotService(&cfg.Complexity).Analyze(context.Background(), domain.HotspotRequest{Paths: []string{file}})
+	if err == nil || !strings.Contains(err.Error(), "not inside a git repository") {
+		t.Errorf("Expected a git repository error, got %v", err)
+	}
+}
+
+func TestWriteHotspots(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	response := &domain.HotspotResponse{
+		Files: []domain.FileHotspot{{
+			FilePath: "/repo/src/busy.js", Commits: 5, RecentCommits: 2, Authors: 2, MainAuthor: "Ann",
+			TotalComplexity: 12, MaxCBO: 3, Score: 100, RiskLevel: domain.RiskLevelHigh,
+		}},
+		Functions: []domain.FunctionHotspot{{
+			Name: "busy", FilePath: "/repo/src/busy.js", StartLine: 3, EndLine: 20,
+			Complexity: 8, Commits: 4, Score: 100, RiskLevel: domain.RiskLevelHigh,
+		}},
+		Summary: domain.HotspotSummary{Repository: "/repo", Since: &since, FilesAnalyzed: 10, FilesChanged: 4, Commits: 7},
+	}
+	formatter := NewOutputFormatter()
+
+	var text bytes.Buffer
+	if err := formatter.WriteHotspots(response, domain.OutputFormatText, &text); err != nil {
+		t.Fatalf("WriteHotspots text failed: %v", err)
+	}
+	for _, want := range []string{"Hotspots", "since 2026-01-01", "src/busy.js", "busy (src/busy.js:3-20)", "4 changed"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Text output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var html bytes.Buffer
+	if err := formatter.WriteHotspots(response, domain.OutputFormatHTML, &html); err != nil {
+		t.Fatalf("WriteHotspots html failed: %v", err)
+	}
+	for _, want := range []string{"<!DOCTYPE html>", "File Hotspots", "mostly Ann", "src/busy.js:3-20"} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("HTML output missing %q", want)
+		}
+	}
+
+	var js bytes.Buffer
+	if err := formatter.WriteHotspots(response, domain.OutputFormatJSON, &js); err != nil {
+		t.Fatalf("WriteHotspots json failed: %v", err)
+	}
+	if !strings.Contains(js.String(), `"recent_commits": 2`) {
+		t.Errorf("JSON output missing recent commits:\n%s", js.String())
+	}
+
+	if err := formatter.WriteHotspots(response, domain.OutputFormatCSV, &js); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
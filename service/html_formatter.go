@@ -22,6 +22,10 @@ type HTMLData struct {
 	CBO           *domain.CBOResponse
 	Deps          *domain.DependencyGraphResponse
 	Summary       *domain.AnalyzeSummary
+	Scoring       *domain.ScoringConfig
+	Breakdown     *domain.QualityBreakdown
+	TypeSafety    *domain.TypeSafetyResponse
+	React         *domain.ReactResponse
 	HasComplexity bool
 	HasDeadCode   bool
 	HasClone      bool
@@ -55,7 +59,12 @@ func (f *OutputFormatterImpl) WriteHTML(
 	}
 
 	// Build summary (reuse shared logic to avoid score divergence across output formats)
-	summary := BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+	summary := f.buildSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+
+	scoring := f.scoring
+	if scoring == nil {
+		scoring = domain.DefaultScoringConfig()
+	}
 
 	data := HTMLData{
 		GeneratedAt:   now.Format("2006-01-02 15:04:05"),
@@ -67,6 +76,10 @@ func (f *OutputFormatterImpl) WriteHTML(
 		CBO:           cboResponse,
 		Deps:          depsResponse,
 		Summary:       summary,
+		Scoring:       scoring,
+		Breakdown:     f.breakdown,
+		TypeSafety:    f.typeSafety,
+		React:         f.react,
 		HasComplexity: complexityResponse != nil,
 		HasDeadCode:   deadCodeResponse != nil,
 		HasClone:      cloneResponse != nil,
@@ -105,6 +118,10 @@ func (f *OutputFormatterImpl) WriteHTML(
 				return "poor"
 			}
 		},
+		"indent": func(depth int) int {
+			return 12 + depth*16
+		},
+		"treemapGroups": treemapGroups,
 		"gradeClass": func(grade string) string {
 			switch grade {
 			case "A":
@@ -125,6 +142,27 @@ func (f *OutputFormatterImpl) WriteHTML(
 	return tmpl.Execute(writer, data)
 }
 
+// treemapGroups returns the groups drawn in the breakdown treemap: every owner, or the
+// directories directly below the root directory (the root itself when it has none)
+func treemapGroups(breakdown *domain.QualityBreakdown) []domain.QualityGroup {
+	if breakdown.GroupBy == domain.GroupByOwner {
+		return breakdown.Groups
+	}
+	var top, root []domain.QualityGroup
+	for _, group := range breakdown.Groups {
+		switch group.Depth {
+		case 0:
+			root = append(root, group)
+		case 1:
+			top = append(top, group)
+		}
+	}
+	if len(top) == 0 {
+		return root
+	}
+	return top
+}
+
 const htmlTemplate = `<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -310,6 +348,32 @@ const htmlTemplate = `<!DOCTYPE html>
             font-weight: 700;
             color: white;
         }
+
+        .treemap {
+            display: flex;
+            flex-wrap: wrap;
+            gap: 4px;
+            margin: 20px 0;
+        }
+        .treemap-cell {
+            min-width: 120px;
+            min-height: 80px;
+            padding: 10px;
+            border-radius: 6px;
+            overflow: hidden;
+            font-size: 13px;
+        }
+        .treemap-cell .treemap-name {
+            font-weight: 700;
+            word-break: break-all;
+        }
+        .table.sortable th {
+            cursor: pointer;
+            user-select: none;
+        }
+        .table.sortable th:hover {
+            color: #667eea;
+        }
     </style>
 </head>
 <body>
@@ -340,6 +404,15 @@ const htmlTemplate = `<!DOCTYPE html>
                 {{if .HasDeps}}
                 <button class="tab-button" onclick="showTab('deps', this)">Dependencies</button>
                 {{end}}
+                {{if .Summary.TypeSafetyEnabled}}
+                <button class="tab-button" onclick="showTab('typesafety', this)">Type Safety</button>
+                {{end}}
+                {{if .Summary.ReactEnabled}}
+                <button class="tab-button" onclick="showTab('react', this)">React</button>
+                {{end}}
+                {{if .Breakdown}}
+                <button class="tab-button" onclick="showTab('breakdown', this)">Breakdown</button>
+                {{end}}
             </div>
 
             <div id="summary" class="tab-content active">
@@ -412,6 +485,15 @@ const htmlTemplate = `<!DOCTYPE html>
                     </div>
                     {{end}}
                 </div>
+                <p class="score-detail" style="margin-top: 12px;">
+                    Maximum penalties:
+                    {{if .HasComplexity}}complexity {{.Scoring.ComplexityWeight}}, {{end}}
+                    {{if .HasDeadCode}}dead code {{.Scoring.DeadCodeWeight}}, {{end}}
+                    {{if .HasClone}}duplication {{.Scoring.DuplicationWeight}}, {{end}}
+                    {{if .HasCBO}}coupling {{.Scoring.CouplingWeight}}, {{end}}
+                    {{if .HasDeps}}dependencies {{.Scoring.DependencyWeight}}, {{end}}
+                    grades: A &ge; {{.Scoring.GradeAThreshold}}, B &ge; {{.Scoring.GradeBThreshold}}, C &ge; {{.Scoring.GradeCThreshold}}, D &ge; {{.Scoring.GradeDThreshold}}
+                </p>
 
                 <h3 style="margin-top: 24px; margin-bottom: 16px; color: #2c3e50;">File Statistics</h3>
                 <div class="metric-grid">
@@ -722,6 +804,7 @@ const htmlTemplate = `<!DOCTYPE html>
                             <th>#</th>
                             <th>Severity</th>
                             <th>Modules in Cycle</th>
+                            <th>Suggested Break</th>
                         </tr>
                     </thead>
                     <tbody>
@@ -731,6 +814,7 @@ const htmlTemplate = `<!DOCTYPE html>
                             <td>{{add $i 1}}</td>
                             <td class="severity-{{$cycle.Severity}}">{{$cycle.Severity}}</td>
                             <td>{{join $cycle.Modules " → "}}</td>
+                            <td>{{range $cycle.BreakingEdges}}<div title="{{.Suggestion}}">{{.From}} → {{.To}}{{if .CanBeTypeOnly}} (import type){{else if .TypeOnly}} (type-only){{end}}</div>{{end}}</td>
                         </tr>
                         {{end}}
                         {{end}}
@@ -746,6 +830,215 @@ const htmlTemplate = `<!DOCTYPE html>
                 {{end}}
             </div>
             {{end}}
+
+            {{if .Summary.TypeSafetyEnabled}}
+            <div id="typesafety" class="tab-content">
+                <h2>TypeScript Type Safety</h2>
+
+                <div class="metric-grid">
+                    <div class="metric-card">
+                        <div class="metric-value">{{printf "%.1f" .Summary.TypeCoverage}}%</div>
+                        <div class="metric-label">Type Coverage</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.ExplicitAnyCount}}</div>
+                        <div class="metric-label">Explicit any</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.AsCastCount}}</div>
+                        <div class="metric-label">as Casts</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.NonNullAssertionCount}}</div>
+                        <div class="metric-label">Non-null Assertions</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.TSSuppressionCount}}</div>
+                        <div class="metric-label">@ts-ignore / @ts-expect-error</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.UntypedParamCount}}</div>
+                        <div class="metric-label">Untyped Parameters</div>
+                    </div>
+                </div>
+                <p class="score-detail">Type coverage is the share of parameters and type annotations that are typed with something other than any, over {{.TypeSafety.Summary.FilesAnalyzed}} TypeScript files. It is not part of the health score. Click a column header to sort the table.</p>
+
+                <table class="table sortable">
+                    <thead>
+                        <tr>
+                            <th onclick="sortTable(this)">File</th>
+                            <th onclick="sortTable(this)">Coverage</th>
+                            <th onclick="sortTable(this)">any</th>
+                            <th onclick="sortTable(this)">Casts</th>
+                            <th onclick="sortTable(this)">Non-null</th>
+                            <th onclick="sortTable(this)">Suppressions</th>
+                            <th onclick="sortTable(this)">Untyped Params</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .TypeSafety.Files}}
+                        {{if gt .Metrics.Escapes 0}}
+                        <tr>
+                            <td data-sort="{{.FilePath}}">{{.FilePath}}</td>
+                            <td data-sort="{{.TypeCoverage}}">{{printf "%.1f" .TypeCoverage}}%</td>
+                            <td data-sort="{{.Metrics.ExplicitAny}}">{{.Metrics.ExplicitAny}}</td>
+                            <td data-sort="{{.Metrics.AsCasts}}">{{.Metrics.AsCasts}}</td>
+                            <td data-sort="{{.Metrics.NonNullAssertions}}">{{.Metrics.NonNullAssertions}}</td>
+                            <td data-sort="{{.Metrics.TSSuppressions}}">{{.Metrics.TSSuppressions}}</td>
+                            <td data-sort="{{.Metrics.UntypedParams}}">{{.Metrics.UntypedParams}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            {{end}}
+
+            {{if .Summary.ReactEnabled}}
+            <div id="react" class="tab-content">
+                <h2>React Components</h2>
+
+                <div class="metric-grid">
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.ReactComponents}}</div>
+                        <div class="metric-label">Components</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.ReactOverThresholdCount}}</div>
+                        <div class="metric-label">Over Thresholds</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.NestedComponentCount}}</div>
+                        <div class="metric-label">Nested Components</div>
+                    </div>
+                    <div class="metric-card">
+                        <div class="metric-value">{{.Summary.HookViolationCount}}</div>
+                        <div class="metric-label">Hook Violations</div>
+                    </div>
+                </div>
+                {{with .React.Summary.Thresholds}}
+                <p class="score-detail">Thresholds: JSX depth {{.MaxJSXDepth}}, props {{.MaxProps}}, hooks {{.MaxHooks}}, conditional renders {{.MaxConditionalRenders}} (0 = no limit). React metrics are not part of the health score. Click a column header to sort the table.</p>
+                {{end}}
+
+                <table class="table sortable">
+                    <thead>
+                        <tr>
+                            <th onclick="sortTable(this)">Component</th>
+                            <th onclick="sortTable(this)">Location</th>
+                            <th onclick="sortTable(this)">JSX Depth</th>
+                            <th onclick="sortTable(this)">Props</th>
+                            <th onclick="sortTable(this)">Hooks</th>
+                            <th onclick="sortTable(this)">Conditional Renders</th>
+                            <th onclick="sortTable(this)">Issues</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $file := .React.Files}}
+                        {{range .Components}}
+                        <tr>
+                            <td data-sort="{{.Name}}">{{.Name}}</td>
+                            <td data-sort="{{$file.FilePath}}:{{.StartLine}}">{{$file.FilePath}}:{{.StartLine}}</td>
+                            <td data-sort="{{.JSXDepth}}">{{.JSXDepth}}</td>
+                            <td data-sort="{{.Props}}">{{.Props}}</td>
+                            <td data-sort="{{.Hooks}}">{{.Hooks}}</td>
+                            <td data-sort="{{.ConditionalRenders}}">{{.ConditionalRenders}}</td>
+                            <td>{{join .Exceeds ", "}}{{if .DefinedIn}}{{if .Exceeds}}, {{end}}defined in {{.DefinedIn}}{{end}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+
+                {{if gt .Summary.HookViolationCount 0}}
+                <h3>Hook Violations</h3>
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>Location</th>
+                            <th>Hook</th>
+                            <th>Function</th>
+                            <th>Reason</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $file := .React.Files}}
+                        {{range .HookViolations}}
+                        <tr>
+                            <td>{{$file.FilePath}}:{{.Line}}</td>
+                            <td>{{.Hook}}</td>
+                            <td>{{.Function}}</td>
+                            <td>{{.Reason}}</td>
+                        </tr>
+                        {{end}}
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
+            </div>
+            {{end}}
+
+            {{if .Breakdown}}
+            <div id="breakdown" class="tab-content">
+                <h2>Breakdown by {{if eq .Breakdown.GroupBy "owner"}}Owner{{else}}Directory{{end}}</h2>
+
+                <div class="treemap">
+                    {{range treemapGroups .Breakdown}}
+                    <div class="treemap-cell {{gradeClass .Summary.Grade}}" style="flex: {{.Lines}} 1 0;" title="{{.Name}}: {{.Files}} files, {{.Lines}} lines">
+                        <div class="treemap-name">{{.Name}}</div>
+                        <div>{{.Summary.HealthScore}}/100 ({{.Summary.Grade}})</div>
+                    </div>
+                    {{end}}
+                </div>
+                <p class="score-detail">Cells are sized by lines of code and colored by grade. Click a column header to sort the table.</p>
+
+                <table class="table sortable">
+                    <thead>
+                        <tr>
+                            <th onclick="sortTable(this)">{{if eq .Breakdown.GroupBy "owner"}}Owner{{else}}Directory{{end}}</th>
+                            <th onclick="sortTable(this)">Files</th>
+                            <th onclick="sortTable(this)">Lines</th>
+                            <th onclick="sortTable(this)">Health</th>
+                            <th onclick="sortTable(this)">Grade</th>
+                            {{if .HasComplexity}}<th onclick="sortTable(this)">Complexity</th><th onclick="sortTable(this)" title="Low / medium / high risk functions">Functions L/M/H</th>{{end}}
+                            {{if .HasDeadCode}}<th onclick="sortTable(this)">Dead Code</th><th onclick="sortTable(this)">Findings</th>{{end}}
+                            {{if .HasClone}}<th onclick="sortTable(this)">Duplication</th><th onclick="sortTable(this)">Dup %</th>{{end}}
+                            {{if .HasCBO}}<th onclick="sortTable(this)">Coupling</th>{{end}}
+                            {{if .HasDeps}}<th onclick="sortTable(this)">Dependencies</th><th onclick="sortTable(this)">Cycles</th>{{end}}
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Breakdown.Groups}}
+                        <tr>
+                            <td data-sort="{{.Name}}" style="padding-left: {{indent .Depth}}px;">{{.Name}}</td>
+                            <td data-sort="{{.Files}}">{{.Files}}</td>
+                            <td data-sort="{{.Lines}}">{{.Lines}}</td>
+                            <td data-sort="{{.Summary.HealthScore}}"><span class="score-badge-compact {{gradeClass .Summary.Grade}}">{{.Summary.HealthScore}}</span></td>
+                            <td data-sort="{{.Summary.Grade}}">{{.Summary.Grade}}</td>
+                            {{if $.HasComplexity}}
+                            <td data-sort="{{.Summary.ComplexityScore}}">{{.Summary.ComplexityScore}}</td>
+                            <td data-sort="{{.Summary.HighComplexityCount}}">{{.LowComplexityCount}} / {{.Summary.MediumComplexityCount}} / {{.Summary.HighComplexityCount}}</td>
+                            {{end}}
+                            {{if $.HasDeadCode}}
+                            <td data-sort="{{.Summary.DeadCodeScore}}">{{.Summary.DeadCodeScore}}</td>
+                            <td data-sort="{{.Summary.DeadCodeCount}}">{{.Summary.DeadCodeCount}}</td>
+                            {{end}}
+                            {{if $.HasClone}}
+                            <td data-sort="{{.Summary.DuplicationScore}}">{{.Summary.DuplicationScore}}</td>
+                            <td data-sort="{{.Summary.CodeDuplication}}">{{printf "%.1f" .Summary.CodeDuplication}}%</td>
+                            {{end}}
+                            {{if $.HasCBO}}
+                            <td data-sort="{{.Summary.CouplingScore}}">{{.Summary.CouplingScore}}</td>
+                            {{end}}
+                            {{if $.HasDeps}}
+                            <td data-sort="{{.Summary.DependencyScore}}">{{.Summary.DependencyScore}}</td>
+                            <td data-sort="{{.Cycles}}">{{.Cycles}}</td>
+                            {{end}}
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            {{end}}
         </div>
     </div>
 
@@ -760,6 +1053,25 @@ const htmlTemplate = `<!DOCTYPE html>
             document.getElementById(tabName).classList.add('active');
             if (el) { el.classList.add('active'); }
         }
+
+        function sortTable(th) {
+            const table = th.closest('table');
+            const index = Array.from(th.parentNode.children).indexOf(th);
+            const ascending = th.dataset.order !== 'asc';
+            th.parentNode.querySelectorAll('th').forEach(h => delete h.dataset.order);
+            th.dataset.order = ascending ? 'asc' : 'desc';
+
+            const tbody = table.querySelector('tbody');
+            const rows = Array.from(tbody.querySelectorAll('tr'));
+            const value = row => row.children[index].dataset.sort;
+            rows.sort((a, b) => {
+                const x = value(a), y = value(b);
+                const nx = parseFloat(x), ny = parseFloat(y);
+                const cmp = (!isNaN(nx) && !isNaN(ny)) ? nx - ny : x.localeCompare(y);
+                return ascending ? cmp : -cmp;
+            });
+            rows.forEach(row => tbody.appendChild(row));
+        }
     </script>
 </body>
 </html>`
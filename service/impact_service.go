@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// ImpactServiceImpl implements change impact analysis
+type ImpactServiceImpl struct{}
+
+// NewImpactService creates a new impact analysis service
+func NewImpactService() *ImpactServiceImpl {
+	return &ImpactServiceImpl{}
+}
+
+// Analyze builds the dependency graph of the request paths and reports the modules,
+// tests and entry points depending on the changed files
+func (s *ImpactServiceImpl) Analyze(ctx context.Context, req domain.ImpactRequest) (*domain.ImpactResponse, error) {
+	if len(req.ChangedFiles) == 0 {
+		return nil, domain.NewValidationError("no changed files specified")
+	}
+
+	includeTypes := req.IncludeTypeImports == nil || *req.IncludeTypeImports
+	graphService := NewDependencyGraphService(false, includeTypes)
+	graphResponse, err := graphService.Analyze(ctx, domain.DependencyGraphRequest{
+		Paths:              req.Paths,
+		IncludeExternal:    domain.BoolPtr(false),
+		IncludeTypeImports: domain.BoolPtr(includeTypes),
+		DetectCycles:       domain.BoolPtr(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	changed, unmatched := matchChangedFiles(graphResponse.Graph, req.ChangedFiles)
+
+	impactAnalyzer := analyzer.NewImpactAnalyzer(graphResponse.Graph, req.TestPatterns)
+	affected := impactAnalyzer.Analyze(changed)
+
+	response := &domain.ImpactResponse{
+		ChangedModules:  changed,
+		UnmatchedFiles:  unmatched,
+		AffectedModules: affected,
+		AffectedTests:   []domain.ImpactedModule{},
+		EntryPoints:     []domain.ImpactedModule{},
+		Warnings:        graphResponse.Warnings,
+		Errors:          graphResponse.Errors,
+		GeneratedAt:     time.Now().Format(time.RFC3339),
+		Version:         version.GetVersion(),
+	}
+
+	response.Summary.ChangedModules = len(changed)
+	for _, module := range affected {
+		if module.IsTest {
+			response.AffectedTests = append(response.AffectedTests, module)
+		}
+		// Test files are never imported, so they are not reported as entry points
+		if module.IsEntryPoint && !module.IsTest {
+			response.EntryPoints = append(response.EntryPoints, module)
+		}
+		if module.Distance == 0 {
+			continue
+		}
+		response.Summary.AffectedModules++
+		switch module.Kind {
+		case domain.ImpactRuntime:
+			response.Summary.RuntimeModules++
+		case domain.ImpactDynamic:
+			response.Summary.DynamicModules++
+		case domain.ImpactTypeOnly:
+			response.Summary.TypeOnlyModules++
+		}
+	}
+	response.Summary.AffectedTests = len(response.AffectedTests)
+	response.Summary.EntryPoints = len(response.EntryPoints)
+
+	return response, nil
+}
+
+// matchChangedFiles maps changed files to module IDs by comparing absolute paths.
+// Changed files outside the graph (deleted, non-JS or excluded files) are returned
+// separately.
+func matchChangedFiles(graph *domain.DependencyGraph, changedFiles []string) ([]string, []string) {
+	byPath := make(map[string]string, graph.NodeCount())
+	for id, node := range graph.Nodes {
+		if node.IsExternal || node.FilePath == "" {
+			continue
+		}
+		if abs, err := canonicalPath(node.FilePath); err == nil {
+			byPath[abs] = id
+		}
+	}
+
+	seen := make(map[string]bool)
+	var changed, unmatched []string
+	for _, file := range changedFiles {
+		abs, err := canonicalPath(file)
+		if err != nil {
+			unmatched = append(unmatched, file)
+			continue
+		}
+		id, ok := byPath[abs]
+		if !ok {
+			unmatched = append(unmatched, file)
+			continue
+		}
+		if !seen[id] {
+			seen[id] = true
+			changed = append(changed, id)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed, unmatched
+}
+
+// canonicalPath returns the absolute path of a file with symlinks resolved when
+// the file exists, so that paths reported by git match the analyzed paths
+func canonicalPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+	return abs, nil
+}
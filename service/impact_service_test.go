@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func writeImpactFixture(t *testing.T, dir string) []string {
+	t.Helper()
+	files := map[string]string{
+		"src/db.ts":                 "export const query = () => 1\n",
+		"src/types.ts":              "export type Row = number\n",
+		"src/service.ts":            "import { query } from './db'\nimport type { Row } from './types'\nexport const run = (): Row => query()\n",
+		"src/routes.ts":             "export const load = () => import('./service')\n",
+		"src/__tests__/service.ts":  "import { run } from '../service'\ntest('run', () => run())\n",
+		"src/unrelated.ts":          "export const x = 1\n",
+		"src/service.spec.ts":       "import type { Row } from './types'\nconst r: Row = 1\n",
+		"src/nested/entry/index.ts": "import { load } from '../../routes'\nload()\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write fixture file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestImpactServiceAnalyze(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeImpactFixture(t, dir)
+
+	svc := NewImpactService()
+	resp, err := svc.Analyze(context.Background(), domain.ImpactRequest{
+		Paths:        paths,
+		ChangedFiles: []string{filepath.Join(dir, "src", "db.ts"), filepath.Join(dir, "src", "deleted.ts")},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(resp.ChangedModules) != 1 || len(resp.UnmatchedFiles) != 1 {
+		t.Fatalf("Expected 1 changed module and 1 unmatched file, got %v and %v",
+			resp.ChangedModules, resp.UnmatchedFiles)
+	}
+
+	kinds := make(map[string]domain.ImpactKind)
+	for _, m := range resp.AffectedModules {
+		kinds[filepath.Base(m.FilePath)] = m.Kind
+	}
+	if kinds["service.ts"] != domain.ImpactRuntime {
+		t.Errorf("Expected service.ts to be runtime impacted, got %q", kinds["service.ts"])
+	}
+	if kinds["routes.ts"] != domain.ImpactDynamic {
+		t.Errorf("Expected routes.ts to be dynamically impacted, got %q", kinds["routes.ts"])
+	}
+	if _, ok := kinds["unrelated.ts"]; ok {
+		t.Error("unrelated.ts should not be impacted")
+	}
+
+	if len(resp.AffectedTests) != 1 || !strings.HasSuffix(filepath.ToSlash(resp.AffectedTests[0].FilePath), "__tests__/service.ts") {
+		t.Errorf("Expected the __tests__ file as only affected test, got %+v", resp.AffectedTests)
+	}
+	if len(resp.EntryPoints) != 1 || !strings.HasSuffix(filepath.ToSlash(resp.EntryPoints[0].FilePath), "entry/index.ts") {
+		t.Errorf("Expected the entry index as only entry point, got %+v", resp.EntryPoints)
+	}
+	if resp.Summary.AffectedModules != 4 || resp.Summary.DynamicModules != 2 {
+		t.Errorf("Unexpected summary %+v", resp.Summary)
+	}
+}
+
+func TestImpactServiceTypeOnly(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeImpactFixture(t, dir)
+	changed := []string{filepath.Join(dir, "src", "types.ts")}
+
+	svc := NewImpactService()
+	resp, err := svc.Analyze(context.Background(), domain.ImpactRequest{Paths: paths, ChangedFiles: changed})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if resp.Summary.TypeOnlyModules != resp.Summary.AffectedModules || resp.Summary.AffectedModules == 0 {
+		t.Errorf("Expected all affected modules to be type-only, got %+v", resp.Summary)
+	}
+	if resp.Summary.AffectedTests != 2 {
+		t.Errorf("Expected both tests to be affected, got %d", resp.Summary.AffectedTests)
+	}
+
+	resp, err = svc.Analyze(context.Background(), domain.ImpactRequest{
+		Paths:              paths,
+		ChangedFiles:       changed,
+		IncludeTypeImports: domain.BoolPtr(false),
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if resp.Summary.AffectedModules != 0 {
+		t.Errorf("Expected no affected modules without type imports, got %d", resp.Summary.AffectedModules)
+	}
+}
+
+func TestImpactServiceNoChangedFiles(t *testing.T) {
+	if _, err := NewImpactService().Analyze(context.Background(), domain.ImpactRequest{}); err == nil {
+		t.Error("Expected error without changed files")
+	}
+}
+
+func TestOutputFormatterWriteImpactText(t *testing.T) {
+	response := &domain.ImpactResponse{
+		ChangedModules: []string{"src/db.ts"},
+		AffectedModules: []domain.ImpactedModule{
+			{ID: "src/db.ts", Kind: domain.ImpactRuntime},
+			{ID: "src/service.ts", Kind: domain.ImpactRuntime, Distance: 1, Via: "src/db.ts"},
+			{ID: "src/service.test.ts", Kind: domain.ImpactTypeOnly, Distance: 2, Via: "src/service.ts", IsTest: true},
+		},
+		AffectedTests: []domain.ImpactedModule{
+			{ID: "src/service.test.ts", Kind: domain.ImpactTypeOnly, Distance: 2, IsTest: true},
+		},
+		Summary: domain.ImpactSummary{ChangedModules: 1, AffectedModules: 2, RuntimeModules: 1, TypeOnlyModules: 1, AffectedTests: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteImpact(response, domain.OutputFormatText, &buf); err != nil {
+		t.Fatalf("WriteImpact failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"Affected modules: 2 (runtime: 1, dynamic: 0, type-only: 1)",
+		"src/service.ts [runtime] (distance 1, via src/db.ts)",
+		"Affected Tests:\n  - src/service.test.ts [type_only]",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if err := NewOutputFormatter().WriteImpact(response, domain.OutputFormatHTML, &buf); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestGitChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	writeImpactFixture(t, dir)
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+
+	if err := os.WriteFile(filepath.Join(dir, "src", "db.ts"), []byte("export const query = () => 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "new.ts"), []byte("export {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := GitChangedFiles(context.Background(), filepath.Join(dir, "src"), "HEAD")
+	if err != nil {
+		t.Fatalf("GitChangedFiles failed: %v", err)
+	}
+
+	var names []string
+	for _, f := range files {
+		if !filepath.IsAbs(f) {
+			t.Errorf("Expected absolute path, got %s", f)
+		}
+		names = append(names, filepath.Base(f))
+	}
+	if len(names) != 2 || !strings.Contains(strings.Join(names, ","), "db.ts") || !strings.Contains(strings.Join(names, ","), "new.ts") {
+		t.Errorf("Expected db.ts and new.ts, got %v", names)
+	}
+
+	if _, err := GitChangedFiles(context.Background(), dir, "no-such-ref"); err == nil {
+		t.Error("Expected error for unknown ref")
+	}
+	if _, err := GitChangedFiles(context.Background(), dir, "--output="+filepath.Join(dir, "diff")); err == nil || !strings.Contains(err.Error(), "invalid git ref") {
+		t.Errorf("Expected refs starting with - to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,702 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
+)
+
+// markdownTruncationReserve is the space kept for closing tags and the truncation notice
+const markdownTruncationReserve = 400
+
+// MarkdownFormatterConfig configures the Markdown formatter behavior
+type MarkdownFormatterConfig struct {
+	// MaxBytes is the size budget of a report (0 = unlimited). Sections and rows
+	// that do not fit are omitted and the report says so.
+	MaxBytes int
+
+	// MaxRows is the number of top offenders listed per section
+	MaxRows int
+
+	// Scoring computes the health score (nil uses the default scoring)
+	Scoring *domain.ScoringConfig
+}
+
+// DefaultMarkdownFormatterConfig returns a MarkdownFormatterConfig with sensible defaults
+func DefaultMarkdownFormatterConfig() *MarkdownFormatterConfig {
+	return &MarkdownFormatterConfig{
+		MaxBytes: config.DefaultMarkdownMaxBytes,
+		MaxRows:  10,
+	}
+}
+
+// MarkdownFormatter formats reports as Markdown for merge request comments
+type MarkdownFormatter struct {
+	config *MarkdownFormatterConfig
+}
+
+// NewMarkdownFormatter creates a new Markdown formatter with the given configuration
+func NewMarkdownFormatter(config *MarkdownFormatterConfig) *MarkdownFormatter {
+	if config == nil {
+		config = DefaultMarkdownFormatterConfig()
+	}
+	return &MarkdownFormatter{config: config}
+}
+
+// markdownDocument accumulates a report within the size budget
+type markdownDocument struct {
+	b        strings.Builder
+	maxBytes int
+	omitted  []string // Titles of sections that did not fit
+}
+
+// remaining returns the bytes left for optional content
+func (d *markdownDocument) remaining() int {
+	if d.maxBytes <= 0 {
+		return math.MaxInt
+	}
+	return d.maxBytes - markdownTruncationReserve - d.b.Len()
+}
+
+// write appends mandatory content (title and summary) regardless of the budget
+func (d *markdownDocument) write(format string, args ...interface{}) {
+	fmt.Fprintf(&d.b, format, args...)
+}
+
+// writeSection appends a collapsible section with a table of the top rows. total
+// is the number of offenders, which may exceed len(rows). Rows that do not fit the
+// budget are dropped; a section without room for a single row is omitted.
+func (d *markdownDocument) writeSection(title, summary, header string, rows []string, total int) {
+	open := fmt.Sprintf("<details>\n<summary><b>%s</b>: %s</summary>\n\n%s", title, summary, header)
+	const closing = "\n</details>\n\n"
+	const moreNote = "\n_… and 000000 more_\n"
+
+	if len(rows) > 0 && len(open)+len(rows[0])+len(closing)+len(moreNote) > d.remaining() {
+		d.omitted = append(d.omitted, title)
+		return
+	}
+
+	d.b.WriteString(open)
+	shown := 0
+	for _, row := range rows {
+		if len(row)+len(closing)+len(moreNote) > d.remaining() {
+			break
+		}
+		d.b.WriteString(row)
+		shown++
+	}
+	if total > shown {
+		fmt.Fprintf(&d.b, "\n_… and %d more_\n", total-shown)
+	}
+	d.b.WriteString(closing)
+}
+
+// finish writes the truncation notice, if any, and the document to writer
+func (d *markdownDocument) finish(writer io.Writer) error {
+	if len(d.omitted) > 0 {
+		fmt.Fprintf(&d.b, "> ⚠️ Report truncated to %d KB: %s omitted. Use `--format json` for the full results.\n\n",
+			d.maxBytes/1000, strings.Join(d.omitted, ", "))
+	}
+	_, err := io.WriteString(writer, d.b.String())
+	return err
+}
+
+// newDocument creates a document with the configured size budget
+func (f *MarkdownFormatter) newDocument() *markdownDocument {
+	return &markdownDocument{maxBytes: f.config.MaxBytes}
+}
+
+// topRows caps rows at the configured number of top offenders
+func (f *MarkdownFormatter) topRows(rows []string) []string {
+	if f.config.MaxRows > 0 && len(rows) > f.config.MaxRows {
+		return rows[:f.config.MaxRows]
+	}
+	return rows
+}
+
+// markdownEscape escapes text for use in a Markdown table cell
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// relativeReportPath returns a file path relative to the working directory with forward
+// slashes, so that links resolve from the repository root
+func relativeReportPath(path string) string {
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// markdownLink links to a file, and to a line of it when line > 0
+func markdownLink(path string, line int) string {
+	if path == "" {
+		return ""
+	}
+	path = relativeReportPath(path)
+	target := strings.ReplaceAll(path, " ", "%20")
+	text := path
+	if line > 0 {
+		target += fmt.Sprintf("#L%d", line)
+		text += fmt.Sprintf(":%d", line)
+	}
+	return fmt.Sprintf("[%s](%s)", markdownEscape(text), target)
+}
+
+// WriteAnalyze writes the unified analysis report as Markdown
+func (f *MarkdownFormatter) WriteAnalyze(
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+	writer io.Writer,
+	duration time.Duration,
+) error {
+	summary := BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, f.config.Scoring)
+	doc := f.newDocument()
+
+	doc.write("## jscan Analysis Report\n\n")
+	doc.write("%s **Health score: %d/100 (%s)** · %d files · %dms\n\n",
+		scoreIndicator(summary.HealthScore), summary.HealthScore, summary.Grade, summary.AnalyzedFiles, duration.Milliseconds())
+
+	doc.write("| Category | Score | Details |\n|---|---:|---|\n")
+	if summary.ComplexityEnabled {
+		doc.write("| %s Complexity | %d | avg %.1f, %d high / %d medium risk functions |\n", scoreIndicator(summary.ComplexityScore),
+			summary.ComplexityScore, summary.AverageComplexity, summary.HighComplexityCount, summary.MediumComplexityCount)
+	}
+	if summary.DeadCodeEnabled {
+		doc.write("| %s Dead Code | %d | %d findings (%d critical, %d warning) |\n", scoreIndicator(summary.DeadCodeScore),
+			summary.DeadCodeScore, summary.DeadCodeCount, summary.CriticalDeadCode, summary.WarningDeadCode)
+	}
+	if summary.CloneEnabled {
+		doc.write("| %s Duplication | %d | %.1f%% duplicated, %d clone groups |\n", scoreIndicator(summary.DuplicationScore),
+			summary.DuplicationScore, summary.CodeDuplication, summary.CloneGroups)
+	}
+	if summary.CBOEnabled {
+		doc.write("| %s Coupling | %d | %d high / %d medium coupling classes |\n", scoreIndicator(summary.CouplingScore),
+			summary.CouplingScore, summary.HighCouplingClasses, summary.MediumCouplingClasses)
+	}
+	if summary.DepsEnabled {
+		doc.write("| %s Dependencies | %d | %d modules in cycles, max depth %d |\n", scoreIndicator(summary.DependencyScore),
+			summary.DependencyScore, summary.DepsModulesInCycles, summary.DepsMaxDepth)
+	}
+	doc.write("\n")
+
+	if complexityResponse != nil {
+		f.writeComplexitySection(doc, complexityResponse)
+	}
+	if deadCodeResponse != nil {
+		f.writeDeadCodeSection(doc, deadCodeResponse)
+	}
+	if cloneResponse != nil {
+		f.writeCloneSection(doc, cloneResponse)
+	}
+	if cboResponse != nil {
+		f.writeCouplingSection(doc, cboResponse)
+	}
+	if depsResponse != nil && depsResponse.Analysis != nil {
+		f.writeCycleSection(doc, depsResponse.Analysis.CircularDependencies, depsResponse.Graph)
+	}
+
+	return doc.finish(writer)
+}
+
+// writeComplexitySection lists the most complex medium and high risk functions
+func (f *MarkdownFormatter) writeComplexitySection(doc *markdownDocument, response *domain.ComplexityResponse) {
+	var offenders []domain.FunctionComplexity
+	for _, fn := range response.Functions {
+		if fn.RiskLevel != domain.RiskLevelLow {
+			offenders = append(offenders, fn)
+		}
+	}
+	if len(offenders) == 0 {
+		return
+	}
+	sort.SliceStable(offenders, func(i, j int) bool {
+		a, b := offenders[i], offenders[j]
+		if a.Metrics.Complexity != b.Metrics.Complexity {
+			return a.Metrics.Complexity > b.Metrics.Complexity
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.StartLine < b.StartLine
+	})
+
+	var rows []string
+	for _, fn := range offenders {
+		rows = append(rows, fmt.Sprintf("| `%s` | %s | %d | %s |\n", markdownEscape(fn.Name),
+			markdownLink(fn.FilePath, fn.StartLine), fn.Metrics.Complexity, fn.RiskLevel))
+	}
+	doc.writeSection("Complexity", fmt.Sprintf("%d functions above the low risk threshold", len(offenders)),
+		"| Function | Location | Complexity | Risk |\n|---|---|---:|---|\n", f.topRows(rows), len(rows))
+}
+
+// writeDeadCodeSection lists dead code findings, most severe first
+func (f *MarkdownFormatter) writeDeadCodeSection(doc *markdownDocument, response *domain.DeadCodeResponse) {
+	var findings []domain.DeadCodeFinding
+	for _, file := range response.Files {
+		findings = append(findings, file.FileLevelFindings...)
+		for _, fn := range file.Functions {
+			findings = append(findings, fn.Findings...)
+		}
+	}
+	if len(findings) == 0 {
+		return
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity.Level() != b.Severity.Level() {
+			return a.Severity.Level() > b.Severity.Level()
+		}
+		if a.Location.FilePath != b.Location.FilePath {
+			return a.Location.FilePath < b.Location.FilePath
+		}
+		return a.Location.StartLine < b.Location.StartLine
+	})
+
+	var rows []string
+	for _, finding := range findings {
+		description := finding.Description
+		if description == "" {
+			description = finding.Reason
+		}
+		rows = append(rows, fmt.Sprintf("| %s | %s | %s |\n", finding.Severity,
+			markdownEscape(description), markdownLink(finding.Location.FilePath, finding.Location.StartLine)))
+	}
+	doc.writeSection("Dead Code", fmt.Sprintf("%d findings", len(findings)),
+		"| Severity | Finding | Location |\n|---|---|---|\n", f.topRows(rows), len(rows))
+}
+
+// writeCloneSection lists the clone groups with the most copies
+func (f *MarkdownFormatter) writeCloneSection(doc *markdownDocument, response *domain.CloneResponse) {
+	groups := make([]*domain.CloneGroup, 0, len(response.CloneGroups))
+	for _, group := range response.CloneGroups {
+		if group != nil && len(group.Clones) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Clones) != len(groups[j].Clones) {
+			return len(groups[i].Clones) > len(groups[j].Clones)
+		}
+		return groups[i].Similarity > groups[j].Similarity
+	})
+
+	var rows []string
+	for _, group := range groups {
+		locations := make([]string, 0, len(group.Clones))
+		lines := 0
+		for _, clone := range group.Clones {
+			if clone == nil || clone.Location == nil {
+				continue
+			}
+			locations = append(locations, markdownLink(clone.Location.FilePath, clone.Location.StartLine))
+			lines = max(lines, clone.Location.LineCount())
+		}
+		rows = append(rows, fmt.Sprintf("| %s | %d | %d | %.0f%% | %s |\n", group.Type, len(locations), lines,
+			group.Similarity*100, strings.Join(locations, "<br>")))
+	}
+	doc.writeSection("Duplication", fmt.Sprintf("%d clone groups", len(groups)),
+		"| Type | Copies | Lines | Similarity | Locations |\n|---|---:|---:|---:|---|\n", f.topRows(rows), len(rows))
+}
+
+// writeCouplingSection lists the classes with medium or high coupling
+func (f *MarkdownFormatter) writeCouplingSection(doc *markdownDocument, response *domain.CBOResponse) {
+	var offenders []domain.ClassCoupling
+	for _, class := range response.Classes {
+		if class.RiskLevel != domain.RiskLevelLow {
+			offenders = append(offenders, class)
+		}
+	}
+	if len(offenders) == 0 {
+		return
+	}
+	sort.SliceStable(offenders, func(i, j int) bool {
+		a, b := offenders[i], offenders[j]
+		if a.Metrics.CouplingCount != b.Metrics.CouplingCount {
+			return a.Metrics.CouplingCount > b.Metrics.CouplingCount
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.StartLine < b.StartLine
+	})
+
+	var rows []string
+	for _, class := range offenders {
+		rows = append(rows, fmt.Sprintf("| `%s` | %s | %d | %s |\n", markdownEscape(class.Name),
+			markdownLink(class.FilePath, class.StartLine), class.Metrics.CouplingCount, class.RiskLevel))
+	}
+	doc.writeSection("Coupling", fmt.Sprintf("%d classes with medium or high coupling", len(offenders)),
+		"| Class | Location | CBO | Risk |\n|---|---|---:|---|\n", f.topRows(rows), len(rows))
+}
+
+// writeCycleSection lists circular dependencies with the imports recommended for removal
+func (f *MarkdownFormatter) writeCycleSection(doc *markdownDocument, cycles *domain.CircularDependencyAnalysis, graph *domain.DependencyGraph) {
+	if cycles == nil || len(cycles.CircularDependencies) == 0 {
+		return
+	}
+
+	moduleLink := func(id string) string {
+		if graph != nil {
+			if node := graph.GetNode(id); node != nil && node.FilePath != "" {
+				return fmt.Sprintf("[%s](%s)", markdownEscape(id), strings.ReplaceAll(relativeReportPath(node.FilePath), " ", "%20"))
+			}
+		}
+		return "`" + markdownEscape(id) + "`"
+	}
+
+	var rows []string
+	for _, cycle := range cycles.CircularDependencies {
+		modules := make([]string, 0, len(cycle.Modules))
+		for _, id := range cycle.Modules {
+			modules = append(modules, moduleLink(id))
+		}
+		breaks := make([]string, 0, len(cycle.BreakingEdges))
+		for _, edge := range cycle.BreakingEdges {
+			edgeText := fmt.Sprintf("`%s` → `%s`", markdownEscape(edge.From), markdownEscape(edge.To))
+			if edge.CanBeTypeOnly {
+				edgeText += " (use `import type`)"
+			} else if edge.TypeOnly {
+				edgeText += " (type-only, erased at runtime)"
+			}
+			breaks = append(breaks, edgeText)
+		}
+		rows = append(rows, fmt.Sprintf("| %s | %s | %s |\n", cycle.Severity,
+			strings.Join(modules, " → "), strings.Join(breaks, "<br>")))
+	}
+	doc.writeSection("Circular Dependencies",
+		fmt.Sprintf("%d cycles, %d modules", cycles.TotalCycles, cycles.TotalModulesInCycles),
+		"| Severity | Modules | Suggested Break |\n|---|---|---|\n", f.topRows(rows), len(rows))
+}
+
+// WriteDependencyGraph writes a dependency analysis or query result as Markdown
+func (f *MarkdownFormatter) WriteDependencyGraph(response *domain.DependencyGraphResponse, writer io.Writer) error {
+	doc := f.newDocument()
+	if response.Query != nil {
+		f.writeDependencyQuery(doc, response.Query)
+		return doc.finish(writer)
+	}
+
+	doc.write("## jscan Dependency Analysis\n\n")
+	if response.Graph == nil {
+		doc.write("No graph data available.\n")
+		return doc.finish(writer)
+	}
+
+	analysis := response.Analysis
+	doc.write("| Metric | Value |\n|---|---:|\n")
+	doc.write("| Modules | %d |\n", response.Graph.NodeCount())
+	doc.write("| Dependencies | %d |\n", response.Graph.EdgeCount())
+	if analysis != nil {
+		cycles, modulesInCycles := 0, 0
+		if analysis.CircularDependencies != nil {
+			cycles = analysis.CircularDependencies.TotalCycles
+			modulesInCycles = analysis.CircularDependencies.TotalModulesInCycles
+		}
+		doc.write("| Entry points | %d |\n", len(analysis.RootModules))
+		doc.write("| Leaf modules | %d |\n", len(analysis.LeafModules))
+		doc.write("| Max depth | %d |\n", analysis.MaxDepth)
+		doc.write("| %s Cycles | %d (%d modules) |\n", cycleIndicator(cycles), cycles, modulesInCycles)
+	}
+	doc.write("\n")
+
+	if analysis != nil {
+		f.writeCycleSection(doc, analysis.CircularDependencies, response.Graph)
+
+		if len(analysis.LongestChains) > 0 {
+			var rows []string
+			for _, chain := range analysis.LongestChains {
+				rows = append(rows, fmt.Sprintf("| %d | `%s` |\n", chain.Length, markdownEscape(strings.Join(chain.Path, " → "))))
+			}
+			doc.writeSection("Longest Chains", fmt.Sprintf("max depth %d", analysis.MaxDepth),
+				"| Length | Chain |\n|---:|---|\n", f.topRows(rows), len(rows))
+		}
+	}
+
+	if response.Packages != nil {
+		f.writePackageHygiene(doc, response.Packages)
+	}
+	if response.Barrels != nil {
+		f.writeBarrels(doc, response.Barrels)
+	}
+	if response.CodeSplitting != nil {
+		f.writeCodeSplitting(doc, response.CodeSplitting)
+	}
+
+	if len(response.Warnings) > 0 {
+		var rows []string
+		for _, w := range response.Warnings {
+			rows = append(rows, fmt.Sprintf("| %s |\n", markdownEscape(w)))
+		}
+		doc.writeSection("Warnings", fmt.Sprintf("%d", len(rows)), "| Warning |\n|---|\n", f.topRows(rows), len(rows))
+	}
+
+	return doc.finish(writer)
+}
+
+// writePackageHygiene writes the packages whose imports disagree with package.json
+func (f *MarkdownFormatter) writePackageHygiene(doc *markdownDocument, packages *domain.PackageHygieneResult) {
+	var rows []string
+	for _, finding := range packages.Unused {
+		rows = append(rows, fmt.Sprintf("| Unused | `%s` | `%s` | |\n",
+			markdownEscape(finding.Package), markdownEscape(finding.Manifest)))
+	}
+	for _, group := range []struct {
+		label    string
+		findings []domain.PackageFinding
+	}{
+		{"Undeclared", packages.Undeclared},
+		{"Dev-only", packages.DevOnly},
+		{"Deep import", packages.DeepImports},
+	} {
+		for _, finding := range group.findings {
+			site := finding.Sites[0]
+			rows = append(rows, fmt.Sprintf("| %s | `%s` | `%s` | `%s:%d` |\n", group.label,
+				markdownEscape(finding.Package), markdownEscape(finding.Manifest), markdownEscape(site.FilePath), site.Line))
+		}
+	}
+	for _, duplicate := range packages.Duplicates {
+		versions := make([]string, 0, len(duplicate.Declarations))
+		for _, decl := range duplicate.Declarations {
+			versions = append(versions, decl.Version)
+		}
+		rows = append(rows, fmt.Sprintf("| Duplicate | `%s` | %d workspaces | %s |\n",
+			markdownEscape(duplicate.Package), len(duplicate.Declarations), markdownEscape(strings.Join(versions, ", "))))
+	}
+	if len(rows) == 0 {
+		doc.write("✅ No package issues in %d package.json files\n\n", len(packages.Manifests))
+		return
+	}
+	doc.writeSection("Packages", fmt.Sprintf("%d issues in %d package.json files", packages.TotalIssues(), len(packages.Manifests)),
+		"| Issue | Package | package.json | Where |\n|---|---|---|---|\n", f.topRows(rows), len(rows))
+}
+
+// writeBarrels writes the barrel files, the cycles they cause, their unused re-exports
+// and the suggested direct imports
+func (f *MarkdownFormatter) writeBarrels(doc *markdownDocument, barrels *domain.BarrelResult) {
+	if len(barrels.Barrels) == 0 {
+		doc.write("✅ No barrel files with %d or more re-exports\n\n", barrels.Options.MinReExports)
+		return
+	}
+
+	var rows []string
+	for _, barrel := range barrels.Barrels {
+		rows = append(rows, fmt.Sprintf("| `%s` | %d | %d | %d |\n",
+			markdownEscape(barrel.Module), barrel.ReExports, barrel.Symbols, barrel.Importers))
+	}
+	doc.writeSection("Barrels", fmt.Sprintf("%d files, %d imports through them", len(rows), len(barrels.Imports)),
+		"| Barrel | Re-exports | Symbols | Importers |\n|---|---:|---:|---:|\n", f.topRows(rows), len(rows))
+
+	if len(barrels.Cycles) > 0 {
+		rows = nil
+		for _, cycle := range barrels.Cycles {
+			rows = append(rows, fmt.Sprintf("| `%s` | `%s` |\n",
+				markdownEscape(strings.Join(cycle.Modules, ", ")), markdownEscape(strings.Join(cycle.Barrels, ", "))))
+		}
+		doc.writeSection("Barrel Cycles", fmt.Sprintf("❌ %d cycles only exist through barrels", len(rows)),
+			"| Modules | Barrels |\n|---|---|\n", f.topRows(rows), len(rows))
+	}
+
+	if len(barrels.UnusedReExports) > 0 {
+		rows = nil
+		for _, unused := range barrels.UnusedReExports {
+			rows = append(rows, fmt.Sprintf("| `%s:%d` | `%s` | `%s` |\n",
+				markdownEscape(unused.Barrel), unused.Line, markdownEscape(unused.Symbol), markdownEscape(unused.Source)))
+		}
+		doc.writeSection("Unused Re-exports", fmt.Sprintf("%d", len(rows)),
+			"| Barrel | Symbol | Defined in |\n|---|---|---|\n", f.topRows(rows), len(rows))
+	}
+
+	if len(barrels.Suggestions) > 0 {
+		rows = nil
+		for _, suggestion := range barrels.Suggestions {
+			rows = append(rows, fmt.Sprintf("| `%s:%d` | `%s` |\n", markdownEscape(suggestion.Importer), suggestion.Line,
+				markdownEscape(strings.Join(suggestion.Imports, "; "))))
+		}
+		doc.writeSection("Direct Imports", fmt.Sprintf("%d", len(rows)),
+			"| Import | Replace with |\n|---|---|\n", f.topRows(rows), len(rows))
+	}
+}
+
+// writeCodeSplitting writes the chunks and the modules loaded both statically and lazily
+func (f *MarkdownFormatter) writeCodeSplitting(doc *markdownDocument, splitting *domain.CodeSplittingResult) {
+	var rows []string
+	for _, chunk := range splitting.Chunks {
+		own := "-"
+		if chunk.Kind == domain.ChunkLazy {
+			own = formatSourceSize(chunk.OwnSize)
+		}
+		rows = append(rows, fmt.Sprintf("| `%s` | %s | %d | %s | %s |\n",
+			markdownEscape(chunk.Root), chunk.Kind, len(chunk.Modules), formatSourceSize(chunk.Size), own))
+	}
+	entries := splitting.EntryChunks()
+	doc.writeSection("Chunks", fmt.Sprintf("%d entry, %d lazy", entries, len(rows)-entries),
+		"| Root | Kind | Modules | Size | Deferred |\n|---|---|---:|---:|---:|\n", f.topRows(rows), len(rows))
+
+	if len(splitting.Mixed) == 0 {
+		doc.write("✅ No module is loaded both statically and lazily\n\n")
+		return
+	}
+	rows = nil
+	for _, module := range splitting.Mixed {
+		lazy := strings.Join(module.LazyChunks, ", ")
+		if module.DefeatsSplit {
+			lazy = "❌ dynamic import defeated"
+		}
+		rows = append(rows, fmt.Sprintf("| `%s` | %s | `%s` | %s |\n", markdownEscape(module.Module),
+			markdownEscape(lazy), markdownEscape(strings.Join(module.StaticPath, " -> ")), formatSourceSize(module.Size)))
+	}
+	doc.writeSection("Static and Lazy Modules", fmt.Sprintf("%d modules, %d dynamic imports defeated", len(rows), splitting.DefeatedSplits()),
+		"| Module | Lazy chunks | Static path | Size |\n|---|---|---|---:|\n", f.topRows(rows), len(rows))
+}
+
+// cycleIndicator returns a status emoji for a number of cycles
+func cycleIndicator(cycles int) string {
+	if cycles == 0 {
+		return "✅"
+	}
+	return "❌"
+}
+
+// writeDependencyQuery writes the result of a dependency query
+func (f *MarkdownFormatter) writeDependencyQuery(doc *markdownDocument, result *domain.DependencyQueryResult) {
+	query := result.Query
+	doc.write("## jscan Dependency Query\n\n")
+	switch query.Kind {
+	case domain.QueryWhy:
+		doc.write("Why does `%s` depend on `%s`?\n\n", markdownEscape(query.Source), markdownEscape(query.Target))
+	case domain.QueryBetween:
+		doc.write("Dependencies from `%s` to `%s`", markdownEscape(query.Source), markdownEscape(query.Target))
+	case domain.QueryDependentsOf:
+		doc.write("Dependents of `%s`", markdownEscape(query.Source))
+	case domain.QueryDependenciesOf:
+		doc.write("Dependencies of `%s`", markdownEscape(query.Source))
+	}
+	if query.Kind != domain.QueryWhy {
+		if query.Transitive {
+			doc.write(" (transitive)")
+		}
+		doc.write("\n\n")
+	}
+
+	if result.IsEmpty() {
+		doc.write("No dependencies found.\n")
+		return
+	}
+
+	if len(result.Paths) > 0 {
+		var rows []string
+		for i, path := range result.Paths {
+			rows = append(rows, fmt.Sprintf("| %d | `%s` |\n", i+1, markdownEscape(strings.Join(path, " → "))))
+		}
+		doc.writeSection("Paths", fmt.Sprintf("%d", len(rows)), "| # | Path |\n|---:|---|\n", rows, len(rows))
+	}
+
+	var rows []string
+	for _, edge := range result.Edges {
+		rows = append(rows, fmt.Sprintf("| `%s` | `%s` | %s |\n", markdownEscape(edge.From), markdownEscape(edge.To), edge.EdgeType))
+	}
+	doc.writeSection("Dependencies", fmt.Sprintf("%d between %d modules", len(result.Edges), len(result.Modules)),
+		"| From | To | Type |\n|---|---|---|\n", rows, len(rows))
+}
+
+// WriteCheck writes a quality gate result as Markdown
+func (f *MarkdownFormatter) WriteCheck(result *domain.CheckResult, writer io.Writer) error {
+	doc := f.newDocument()
+
+	status := "✅ Passed"
+	if !result.Passed {
+		status = "❌ Failed"
+	}
+	doc.write("## jscan Quality Check: %s\n\n", status)
+	doc.write("%d files analyzed in %dms · %d violations\n\n",
+		result.Summary.FilesAnalyzed, result.Duration, result.Summary.TotalViolations)
+
+	// Categories with errors fail, categories with only warnings are flagged
+	failed := make(map[string]bool)
+	warned := make(map[string]bool)
+	for _, v := range result.Violations {
+		if v.Severity == "warning" {
+			warned[v.Category] = true
+		} else {
+			failed[v.Category] = true
+		}
+	}
+	checkRow := func(category, label, details string) {
+		indicator := "✅"
+		if failed[category] {
+			indicator = "❌"
+		} else if warned[category] {
+			indicator = "⚠️"
+		}
+		doc.write("| %s %s | %s |\n", indicator, label, details)
+	}
+
+	doc.write("| Check | Details |\n|---|---|\n")
+	if result.Summary.ComplexityChecked {
+		checkRow("complexity", "Complexity", fmt.Sprintf("%d functions above the limit", result.Summary.HighComplexityFunctions))
+	}
+	if result.Summary.DeadCodeChecked {
+		checkRow("deadcode", "Dead code", fmt.Sprintf("%d findings", result.Summary.DeadCodeFindings))
+	}
+	if scores := result.AnalyzeSummary; scores != nil {
+		if result.Summary.CloneChecked {
+			checkRow("clone", "Duplication", fmt.Sprintf("%.1f%% duplicated code", scores.CodeDuplication))
+		}
+		if result.Summary.CBOChecked {
+			checkRow("cbo", "Coupling", fmt.Sprintf("%d high-coupling classes, avg CBO %.1f", scores.HighCouplingClasses, scores.AverageCoupling))
+		}
+		if result.Summary.TypeSafetyChecked {
+			checkRow("typesafety", "Type safety", fmt.Sprintf("%.1f%% type coverage, %d explicit any", scores.TypeCoverage, scores.ExplicitAnyCount))
+		}
+		if result.Summary.ReactChecked {
+			checkRow("react", "React", fmt.Sprintf("%d components, %d hook violations", scores.ReactComponents, scores.HookViolationCount))
+		}
+	}
+	if result.Summary.DepsChecked {
+		checkRow("deps", "Dependencies", fmt.Sprintf("%d circular dependencies", result.Summary.CircularDependencies))
+	}
+	if result.Summary.HealthScoreChecked {
+		checkRow("health", "Health score", fmt.Sprintf("%d/100 (grade %s)", result.Summary.HealthScore, result.Summary.Grade))
+	}
+	doc.write("\n")
+
+	if len(result.Violations) > 0 {
+		var rows []string
+		for _, v := range result.Violations {
+			value := v.Actual
+			if v.Threshold != "" {
+				limit := "max"
+				if strings.HasPrefix(v.Rule, "min-") {
+					limit = "min"
+				}
+				value = fmt.Sprintf("%s (%s %s)", v.Actual, limit, v.Threshold)
+			}
+			rows = append(rows, fmt.Sprintf("| %s | %s | %s | %s | %s |\n", v.Severity, v.Rule,
+				markdownEscape(v.Message), value, markdownLink(v.FileLine())))
+		}
+		doc.writeSection("Violations", fmt.Sprintf("%d", len(rows)),
+			"| Severity | Rule | Message | Value | Location |\n|---|---|---|---|---|\n", rows, len(rows))
+	}
+
+	return doc.finish(writer)
+}
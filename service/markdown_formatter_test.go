@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestMarkdownLink(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		line     int
+		expected string
+	}{
+		{name: "file and line", path: "src/a.ts", line: 12, expected: "[src/a.ts:12](src/a.ts#L12)"},
+		{name: "file only", path: "src/a.ts", expected: "[src/a.ts](src/a.ts)"},
+		{name: "spaces", path: "src/my file.ts", line: 3, expected: "[src/my file.ts:3](src/my%20file.ts#L3)"},
+		{name: "absolute under working directory", path: filepath.Join(wd, "src", "a.ts"), line: 1, expected: "[src/a.ts:1](src/a.ts#L1)"},
+		{name: "empty", path: "", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := markdownLink(tc.path, tc.line); result != tc.expected {
+				t.Errorf("markdownLink(%q, %d) = %q, want %q", tc.path, tc.line, result, tc.expected)
+			}
+		})
+	}
+}
+
+func markdownTestComplexity(count int) *domain.ComplexityResponse {
+	response := &domain.ComplexityResponse{}
+	for i := 0; i < count; i++ {
+		response.Functions = append(response.Functions, domain.FunctionComplexity{
+			Name:      fmt.Sprintf("fn%d", i),
+			FilePath:  fmt.Sprintf("src/file%d.ts", i),
+			StartLine: 10 + i,
+			Metrics:   domain.ComplexityMetrics{Complexity: 20 + i},
+			RiskLevel: domain.RiskLevelHigh,
+		})
+	}
+	response.Functions = append(response.Functions, domain.FunctionComplexity{
+		Name: "simple", FilePath: "src/simple.ts", StartLine: 1,
+		Metrics: domain.ComplexityMetrics{Complexity: 1}, RiskLevel: domain.RiskLevelLow,
+	})
+	return response
+}
+
+func TestMarkdownFormatterWriteAnalyze(t *testing.T) {
+	deadCode := &domain.DeadCodeResponse{
+		Files: []domain.FileDeadCode{{
+			FilePath: "src/a.ts",
+			Functions: []domain.FunctionDeadCode{{
+				Name: "run",
+				Findings: []domain.DeadCodeFinding{
+					{Location: domain.DeadCodeLocation{FilePath: "src/a.ts", StartLine: 4}, Severity: domain.DeadCodeSeverityWarning, Description: "Code after return | unreachable"},
+					{Location: domain.DeadCodeLocation{FilePath: "src/a.ts", StartLine: 9}, Severity: domain.DeadCodeSeverityCritical, Description: "Unreachable branch"},
+				},
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewMarkdownFormatter(&MarkdownFormatterConfig{MaxRows: 2})
+	if err := formatter.WriteAnalyze(markdownTestComplexity(3), deadCode, nil, nil, nil, &buf, 0); err != nil {
+		t.Fatalf("WriteAnalyze failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"## jscan Analysis Report",
+		"| Category | Score | Details |",
+		"<summary><b>Complexity</b>: 3 functions above the low risk threshold</summary>",
+		"| `fn2` | [src/file2.ts:12](src/file2.ts#L12) | 22 | high |",
+		"_… and 1 more_",
+		"| critical | Unreachable branch | [src/a.ts:9](src/a.ts#L9) |",
+		"Code after return \\| unreachable",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	// Low risk functions, skipped analyses and their sections are left out
+	for _, unexpected := range []string{"simple", "Duplication", "Coupling", "truncated"} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("Expected output not to contain %q, got:\n%s", unexpected, output)
+		}
+	}
+
+	// Critical findings come first
+	if strings.Index(output, "Unreachable branch") > strings.Index(output, "Code after return") {
+		t.Errorf("Expected critical findings before warnings, got:\n%s", output)
+	}
+}
+
+func TestMarkdownFormatterTruncation(t *testing.T) {
+	deadCode := &domain.DeadCodeResponse{Files: []domain.FileDeadCode{{
+		FilePath: "src/a.ts",
+		FileLevelFindings: []domain.DeadCodeFinding{
+			{Location: domain.DeadCodeLocation{FilePath: "src/a.ts", StartLine: 1}, Severity: domain.DeadCodeSeverityWarning, Description: "Unused export"},
+		},
+	}}}
+
+	maxBytes := 1500
+	var buf bytes.Buffer
+	formatter := NewMarkdownFormatter(&MarkdownFormatterConfig{MaxBytes: maxBytes, MaxRows: 100})
+	if err := formatter.WriteAnalyze(markdownTestComplexity(100), deadCode, nil, nil, nil, &buf, 0); err != nil {
+		t.Fatalf("WriteAnalyze failed: %v", err)
+	}
+	output := buf.String()
+
+	if buf.Len() > maxBytes {
+		t.Errorf("Expected report within %d bytes, got %d", maxBytes, buf.Len())
+	}
+	if !strings.Contains(output, "</details>") || !strings.Contains(output, "more_") {
+		t.Errorf("Expected complexity section cut short with a note, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Report truncated to 1 KB: Dead Code omitted") {
+		t.Errorf("Expected truncation notice naming the omitted section, got:\n%s", output)
+	}
+}
+
+func TestMarkdownFormatterWriteCheck(t *testing.T) {
+	result := &domain.CheckResult{
+		Passed: false,
+		Violations: []domain.CheckViolation{{
+			Category:  "complexity",
+			Rule:      "max-complexity",
+			Severity:  "error",
+			Message:   "Function 'run' has complexity 15",
+			Location:  "src/a.ts:3",
+			Actual:    "15",
+			Threshold: "10",
+		}},
+		Summary: domain.CheckSummary{
+			FilesAnalyzed:           2,
+			TotalViolations:         1,
+			ComplexityChecked:       true,
+			DepsChecked:             true,
+			HighComplexityFunctions: 1,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(nil).WriteCheck(result, &buf); err != nil {
+		t.Fatalf("WriteCheck failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"## jscan Quality Check: ❌ Failed",
+		"| ❌ Complexity | 1 functions above the limit |",
+		"| ✅ Dependencies | 0 circular dependencies |",
+		"| error | max-complexity | Function 'run' has complexity 15 | 15 (max 10) | [src/a.ts:3](src/a.ts#L3) |",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "Dead code") {
+		t.Errorf("Expected unchecked categories to be omitted, got:\n%s", output)
+	}
+}
+
+func TestMarkdownFormatterWriteDependencyGraph(t *testing.T) {
+	graph := domain.NewDependencyGraph()
+	graph.AddNode(&domain.ModuleNode{ID: "src/a.ts", FilePath: "src/a.ts"})
+	graph.AddNode(&domain.ModuleNode{ID: "src/b.ts", FilePath: "src/b.ts"})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/a.ts", To: "src/b.ts", EdgeType: domain.EdgeTypeImport})
+	graph.AddEdge(&domain.DependencyEdge{From: "src/b.ts", To: "src/a.ts", EdgeType: domain.EdgeTypeImport})
+
+	response := &domain.DependencyGraphResponse{
+		Graph: graph,
+		Analysis: &domain.DependencyAnalysisResult{
+			CircularDependencies: &domain.CircularDependencyAnalysis{
+				HasCircularDependencies: true,
+				TotalCycles:             1,
+				TotalModulesInCycles:    2,
+				CircularDependencies: []domain.CircularDependency{{
+					Modules:       []string{"src/a.ts", "src/b.ts"},
+					Severity:      domain.CycleSeverityLow,
+					BreakingEdges: []domain.CycleBreakingEdge{{From: "src/b.ts", To: "src/a.ts", CanBeTypeOnly: true}},
+				}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(nil).WriteDependencyGraph(response, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"## jscan Dependency Analysis",
+		"| Modules | 2 |",
+		"| ❌ Cycles | 1 (2 modules) |",
+		"[src/a.ts](src/a.ts) → [src/b.ts](src/b.ts)",
+		"`src/b.ts` → `src/a.ts` (use `import type`)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
@@ -11,18 +11,59 @@ import (
 	"time"
 
 	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
 	"github.com/ludo-technologies/jscan/internal/version"
 	"gopkg.in/yaml.v3"
 )
 
 // OutputFormatterImpl implements the OutputFormatter interface
-type OutputFormatterImpl struct{}
+type OutputFormatterImpl struct {
+	scoring    *domain.ScoringConfig      // nil uses the default scoring
+	breakdown  *domain.QualityBreakdown   // per-directory or per-owner view, if requested
+	typeSafety *domain.TypeSafetyResponse // TypeScript type-safety metrics, if measured
+	react      *domain.ReactResponse      // React component metrics, if measured
+}
 
 // NewOutputFormatter creates a new output formatter
 func NewOutputFormatter() *OutputFormatterImpl {
 	return &OutputFormatterImpl{}
 }
 
+// NewOutputFormatterWithScoring creates an output formatter that computes health
+// scores with the given scoring configuration
+func NewOutputFormatterWithScoring(scoring *domain.ScoringConfig) *OutputFormatterImpl {
+	return &OutputFormatterImpl{scoring: scoring}
+}
+
+// SetBreakdown adds a per-directory or per-owner breakdown to the unified analysis reports
+func (f *OutputFormatterImpl) SetBreakdown(breakdown *domain.QualityBreakdown) {
+	f.breakdown = breakdown
+}
+
+// SetTypeSafety adds the TypeScript type-safety metrics to the unified analysis reports
+func (f *OutputFormatterImpl) SetTypeSafety(typeSafety *domain.TypeSafetyResponse) {
+	f.typeSafety = typeSafety
+}
+
+// SetReact adds the React component metrics to the unified analysis reports
+func (f *OutputFormatterImpl) SetReact(react *domain.ReactResponse) {
+	f.react = react
+}
+
+// buildSummary builds the summary of the unified analysis reports
+func (f *OutputFormatterImpl) buildSummary(
+	complexityResponse *domain.ComplexityResponse,
+	deadCodeResponse *domain.DeadCodeResponse,
+	cloneResponse *domain.CloneResponse,
+	cboResponse *domain.CBOResponse,
+	depsResponse *domain.DependencyGraphResponse,
+) *domain.AnalyzeSummary {
+	summary := BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, f.scoring)
+	AddTypeSafetySummary(summary, f.typeSafety)
+	AddReactSummary(summary, f.react)
+	return summary
+}
+
 // FormatUtils provides formatting helper functions
 type FormatUtils struct{}
 
@@ -72,6 +113,7 @@ type CloneResponseJSON struct {
 	Statistics  *domain.CloneStatistics `json:"statistics"`
 	Success     bool                    `json:"success"`
 	Error       string                  `json:"error,omitempty"`
+	Warnings    []string                `json:"warnings,omitempty"`
 	Config      interface{}             `json:"config,omitempty"`
 }
 
@@ -99,15 +141,18 @@ type DepsResponseJSON struct {
 
 // AnalyzeResponseJSON represents the unified analysis response for JSON output
 type AnalyzeResponseJSON struct {
-	Version     string                  `json:"version"`
-	GeneratedAt string                  `json:"generated_at"`
-	DurationMs  int64                   `json:"duration_ms"`
-	Complexity  *ComplexityResponseJSON `json:"complexity,omitempty"`
-	DeadCode    *DeadCodeResponseJSON   `json:"dead_code,omitempty"`
-	Clone       *CloneResponseJSON      `json:"clone,omitempty"`
-	CBO         *CBOResponseJSON        `json:"cbo,omitempty"`
-	Deps        *DepsResponseJSON       `json:"deps,omitempty"`
-	Summary     *domain.AnalyzeSummary  `json:"summary,omitempty"`
+	Version     string                     `json:"version"`
+	GeneratedAt string                     `json:"generated_at"`
+	DurationMs  int64                      `json:"duration_ms"`
+	Complexity  *ComplexityResponseJSON    `json:"complexity,omitempty"`
+	DeadCode    *DeadCodeResponseJSON      `json:"dead_code,omitempty"`
+	Clone       *CloneResponseJSON         `json:"clone,omitempty"`
+	CBO         *CBOResponseJSON           `json:"cbo,omitempty"`
+	Deps        *DepsResponseJSON          `json:"deps,omitempty"`
+	TypeSafety  *domain.TypeSafetyResponse `json:"type_safety,omitempty"`
+	React       *domain.ReactResponse      `json:"react,omitempty"`
+	Summary     *domain.AnalyzeSummary     `json:"summary,omitempty"`
+	Breakdown   *domain.QualityBreakdown   `json:"breakdown,omitempty"`
 }
 
 // Write writes the complexity response in the specified format
@@ -156,6 +201,13 @@ func (f *OutputFormatterImpl) WriteAnalyze(
 		return f.writeAnalyzeYAML(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, writer, duration)
 	case domain.OutputFormatCSV:
 		return f.writeAnalyzeCSV(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, writer, duration)
+	case domain.OutputFormatMarkdown:
+		markdownConfig := DefaultMarkdownFormatterConfig()
+		markdownConfig.Scoring = f.scoring
+		markdownFormatter := NewMarkdownFormatter(markdownConfig)
+		return markdownFormatter.WriteAnalyze(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, writer, duration)
+	case domain.OutputFormatCodeClimate:
+		return f.writeAnalyzeCodeClimate(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse, writer)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -189,13 +241,35 @@ func (f *OutputFormatterImpl) writeDeadCodeJSON(response *domain.DeadCodeRespons
 	return WriteJSON(writer, jsonResponse)
 }
 
-// BuildAnalyzeSummary builds an AnalyzeSummary from analysis responses
+// ScoringFromConfig converts the scoring section of the configuration
+func ScoringFromConfig(cfg *config.ScoringConfig) *domain.ScoringConfig {
+	return &domain.ScoringConfig{
+		ComplexityWeight:      cfg.Weights.Complexity,
+		DeadCodeWeight:        cfg.Weights.DeadCode,
+		DuplicationWeight:     cfg.Weights.Duplication,
+		CouplingWeight:        cfg.Weights.Coupling,
+		DependencyWeight:      cfg.Weights.Dependencies,
+		ArchitectureWeight:    cfg.Weights.Architecture,
+		ComplexitySaturation:  cfg.Saturation.Complexity,
+		DeadCodeSaturation:    cfg.Saturation.DeadCode,
+		DuplicationSaturation: cfg.Saturation.Duplication,
+		CouplingSaturation:    cfg.Saturation.Coupling,
+		GradeAThreshold:       cfg.Grades.A,
+		GradeBThreshold:       cfg.Grades.B,
+		GradeCThreshold:       cfg.Grades.C,
+		GradeDThreshold:       cfg.Grades.D,
+	}
+}
+
+// BuildAnalyzeSummary builds an AnalyzeSummary from analysis responses and scores
+// it with the given scoring configuration (nil uses the default scoring)
 func BuildAnalyzeSummary(
 	complexityResponse *domain.ComplexityResponse,
 	deadCodeResponse *domain.DeadCodeResponse,
 	cloneResponse *domain.CloneResponse,
 	cboResponse *domain.CBOResponse,
 	depsResponse *domain.DependencyGraphResponse,
+	scoring *domain.ScoringConfig,
 ) *domain.AnalyzeSummary {
 	summary := &domain.AnalyzeSummary{}
 
@@ -253,10 +327,39 @@ func BuildAnalyzeSummary(
 		}
 	}
 
-	_ = summary.CalculateHealthScore()
+	_ = summary.CalculateHealthScoreWith(scoring)
 	return summary
 }
 
+// AddTypeSafetySummary copies the TypeScript type-safety totals into the summary; they
+// do not change the health score
+func AddTypeSafetySummary(summary *domain.AnalyzeSummary, typeSafety *domain.TypeSafetyResponse) {
+	if typeSafety == nil || typeSafety.Summary.FilesAnalyzed == 0 {
+		return
+	}
+	metrics := typeSafety.Summary.Metrics
+	summary.TypeSafetyEnabled = true
+	summary.TypeCoverage = typeSafety.Summary.TypeCoverage
+	summary.ExplicitAnyCount = metrics.ExplicitAny
+	summary.AsCastCount = metrics.AsCasts
+	summary.NonNullAssertionCount = metrics.NonNullAssertions
+	summary.TSSuppressionCount = metrics.TSSuppressions
+	summary.UntypedParamCount = metrics.UntypedParams
+}
+
+// AddReactSummary copies the React component totals into the summary; they do not
+// change the health score
+func AddReactSummary(summary *domain.AnalyzeSummary, react *domain.ReactResponse) {
+	if react == nil || react.Summary.Components == 0 {
+		return
+	}
+	summary.ReactEnabled = true
+	summary.ReactComponents = react.Summary.Components
+	summary.ReactOverThresholdCount = react.Summary.ComponentsOverThreshold
+	summary.NestedComponentCount = react.Summary.NestedComponents
+	summary.HookViolationCount = react.Summary.HookViolations
+}
+
 // FormatCLISummary formats an AnalyzeSummary as a compact CLI string (pyscn-style)
 func FormatCLISummary(summary *domain.AnalyzeSummary, duration time.Duration) string {
 	w := &strings.Builder{}
@@ -296,6 +399,16 @@ func FormatCLISummary(summary *domain.AnalyzeSummary, duration time.Duration) st
 			summary.DependencyScore, scoreIndicator(summary.DependencyScore),
 			cycles, summary.DepsMaxDepth)
 	}
+	if summary.TypeSafetyEnabled {
+		fmt.Fprintf(w, "  Type Coverage:   %5.1f%%     (%d any, %d casts, %d non-null, %d suppressions, %d untyped params)\n",
+			summary.TypeCoverage, summary.ExplicitAnyCount, summary.AsCastCount,
+			summary.NonNullAssertionCount, summary.TSSuppressionCount, summary.UntypedParamCount)
+	}
+	if summary.ReactEnabled {
+		fmt.Fprintf(w, "  React:           %3d components (%d over thresholds, %d nested, %d hook violations)\n",
+			summary.ReactComponents, summary.ReactOverThresholdCount,
+			summary.NestedComponentCount, summary.HookViolationCount)
+	}
 
 	return w.String()
 }
@@ -364,6 +477,7 @@ func (f *OutputFormatterImpl) writeAnalyzeJSON(
 			Statistics:  cloneResponse.Statistics,
 			Success:     cloneResponse.Success,
 			Error:       cloneResponse.Error,
+			Warnings:    cloneResponse.Warnings,
 		}
 	}
 	if cboResponse != nil {
@@ -388,8 +502,10 @@ func (f *OutputFormatterImpl) writeAnalyzeJSON(
 		}
 	}
 
-	summary := BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
-	response.Summary = summary
+	response.TypeSafety = f.typeSafety
+	response.React = f.react
+	response.Summary = f.buildSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+	response.Breakdown = f.breakdown
 
 	return WriteJSON(writer, response)
 }
@@ -613,7 +729,17 @@ func (f *OutputFormatterImpl) writeAnalyzeText(
 		}
 	}
 
-	summary := BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+	// TypeScript type-safety results
+	if f.typeSafety != nil {
+		writeTypeSafetyText(f.typeSafety, writer)
+	}
+
+	// React component results
+	if f.react != nil {
+		writeReactText(f.react, writer)
+	}
+
+	summary := f.buildSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
 
 	// Write Health Score section
 	fmt.Fprintf(writer, "\n=== Health Score ===\n\n")
@@ -625,9 +751,110 @@ func (f *OutputFormatterImpl) writeAnalyzeText(
 	fmt.Fprintf(writer, "  Coupling:         %3d/100\n", summary.CouplingScore)
 	fmt.Fprintf(writer, "  Dependencies:     %3d/100\n", summary.DependencyScore)
 
+	if f.breakdown != nil {
+		writeBreakdownText(f.breakdown, writer)
+	}
+
 	return nil
 }
 
+// writeBreakdownText writes the per-group scores as a table, worst health score first
+func writeBreakdownText(breakdown *domain.QualityBreakdown, writer io.Writer) {
+	title := "Directory"
+	if breakdown.GroupBy == domain.GroupByOwner {
+		title = "Owner"
+	}
+
+	groups := append([]domain.QualityGroup(nil), breakdown.Groups...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Summary.HealthScore < groups[j].Summary.HealthScore
+	})
+
+	fmt.Fprintf(writer, "\n=== Breakdown by %s ===\n\n", strings.ToLower(title))
+	fmt.Fprintf(writer, "%-40s %6s %6s %5s %10s %9s %5s %8s %6s\n",
+		title, "Health", "Grade", "Files", "Complexity", "Dead Code", "Dup%", "Coupling", "Cycles")
+	for _, group := range groups {
+		fmt.Fprintf(writer, "%-40s %6d %6s %5d %10d %9d %5.1f %8d %6d\n",
+			group.Name, group.Summary.HealthScore, group.Summary.Grade, group.Files,
+			group.Summary.ComplexityScore, group.Summary.DeadCodeScore, group.Summary.CodeDuplication,
+			group.Summary.CouplingScore, group.Cycles)
+	}
+}
+
+// writeTypeSafetyText writes the type-safety totals and the files with type-safety
+// escapes, least covered first
+func writeTypeSafetyText(response *domain.TypeSafetyResponse, writer io.Writer) {
+	summary := response.Summary
+	fmt.Fprintf(writer, "\n=== TypeScript Type Safety ===\n\n")
+	fmt.Fprintf(writer, "Summary:\n")
+	fmt.Fprintf(writer, "  TypeScript files: %d\n", summary.FilesAnalyzed)
+	fmt.Fprintf(writer, "  Type coverage: %.1f%%\n", summary.TypeCoverage)
+	fmt.Fprintf(writer, "  Explicit any: %d\n", summary.Metrics.ExplicitAny)
+	fmt.Fprintf(writer, "  as casts: %d\n", summary.Metrics.AsCasts)
+	fmt.Fprintf(writer, "  Non-null assertions: %d\n", summary.Metrics.NonNullAssertions)
+	fmt.Fprintf(writer, "  @ts-ignore/@ts-expect-error: %d\n", summary.Metrics.TSSuppressions)
+	fmt.Fprintf(writer, "  Untyped parameters: %d\n", summary.Metrics.UntypedParams)
+
+	header := false
+	for _, file := range response.Files {
+		if file.Metrics.Escapes() == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintf(writer, "\n%-50s %8s %4s %5s %8s %8s %7s\n",
+				"File", "Coverage", "Any", "Casts", "NonNull", "Suppress", "Untyped")
+			header = true
+		}
+		fmt.Fprintf(writer, "%-50s %7.1f%% %4d %5d %8d %8d %7d\n",
+			file.FilePath, file.TypeCoverage, file.Metrics.ExplicitAny, file.Metrics.AsCasts,
+			file.Metrics.NonNullAssertions, file.Metrics.TSSuppressions, file.Metrics.UntypedParams)
+	}
+}
+
+// writeReactText writes the React totals, the components over a threshold or defined
+// inside another component, and the hook violations
+func writeReactText(response *domain.ReactResponse, writer io.Writer) {
+	summary := response.Summary
+	fmt.Fprintf(writer, "\n=== React Components ===\n\n")
+	fmt.Fprintf(writer, "Summary:\n")
+	fmt.Fprintf(writer, "  Components: %d in %d files\n", summary.Components, summary.FilesAnalyzed)
+	fmt.Fprintf(writer, "  Over thresholds: %d\n", summary.ComponentsOverThreshold)
+	fmt.Fprintf(writer, "  Nested components: %d\n", summary.NestedComponents)
+	fmt.Fprintf(writer, "  Hook violations: %d\n", summary.HookViolations)
+	fmt.Fprintf(writer, "  Max JSX depth: %d\n", summary.MaxJSXDepth)
+
+	header := false
+	for _, file := range response.Files {
+		for _, component := range file.Components {
+			if len(component.Exceeds) == 0 && component.DefinedIn == "" {
+				continue
+			}
+			if !header {
+				fmt.Fprintf(writer, "\n%-50s %-24s %5s %5s %5s %6s  %s\n",
+					"Location", "Component", "Depth", "Props", "Hooks", "Branch", "Issues")
+				header = true
+			}
+			issues := append([]string(nil), component.Exceeds...)
+			if component.DefinedIn != "" {
+				issues = append(issues, "defined in "+component.DefinedIn)
+			}
+			fmt.Fprintf(writer, "%-50s %-24s %5d %5d %5d %6d  %s\n",
+				fmt.Sprintf("%s:%d", file.FilePath, component.StartLine), component.Name,
+				component.JSXDepth, component.Props, component.Hooks, component.ConditionalRenders,
+				strings.Join(issues, ", "))
+		}
+	}
+
+	if summary.HookViolations > 0 {
+		fmt.Fprintf(writer, "\nHook Violations:\n")
+		for _, file := range response.Files {
+			for _, v := range file.HookViolations {
+				fmt.Fprintf(writer, "  %s:%d  %s in %s (%s)\n", file.FilePath, v.Line, v.Hook, v.Function, v.Reason)
+			}
+		}
+	}
+}
+
 // writeDepsText writes dependency analysis results as plain text
 func (f *OutputFormatterImpl) writeDepsText(response *domain.DependencyGraphResponse, writer io.Writer) error {
 	fmt.Fprintf(writer, "\n=== Dependency Analysis ===\n\n")
@@ -655,6 +882,7 @@ func (f *OutputFormatterImpl) writeDepsText(response *domain.DependencyGraphResp
 					break
 				}
 				fmt.Fprintf(writer, "  Cycle %d [%s]: %v\n", i+1, cycle.Severity, cycle.Modules)
+				writeCycleBreakingEdges(writer, cycle, "    ")
 			}
 		} else {
 			fmt.Fprintf(writer, "\nNo circular dependencies detected.\n")
@@ -664,6 +892,20 @@ func (f *OutputFormatterImpl) writeDepsText(response *domain.DependencyGraphResp
 	return nil
 }
 
+// writeCycleBreakingEdges writes the imports recommended for removal to break a cycle
+func writeCycleBreakingEdges(writer io.Writer, cycle domain.CircularDependency, indent string) {
+	for _, edge := range cycle.BreakingEdges {
+		switch {
+		case edge.CanBeTypeOnly:
+			fmt.Fprintf(writer, "%sBreak: %s -> %s (use import type)\n", indent, edge.From, edge.To)
+		case edge.TypeOnly:
+			fmt.Fprintf(writer, "%sBreak: %s -> %s (type-only, erased at runtime: type-level cycle only)\n", indent, edge.From, edge.To)
+		default:
+			fmt.Fprintf(writer, "%sBreak: %s -> %s\n", indent, edge.From, edge.To)
+		}
+	}
+}
+
 // writeCloneText writes clone detection results as plain text
 func (f *OutputFormatterImpl) writeCloneText(response *domain.CloneResponse, writer io.Writer) error {
 	fmt.Fprintf(writer, "\n=== Clone Detection ===\n\n")
@@ -710,6 +952,14 @@ func (f *OutputFormatterImpl) writeCloneText(response *domain.CloneResponse, wri
 		fmt.Fprintf(writer, "No code clones detected.\n")
 	}
 
+	// Warnings
+	if len(response.Warnings) > 0 {
+		fmt.Fprintf(writer, "\nWarnings:\n")
+		for _, w := range response.Warnings {
+			fmt.Fprintf(writer, "  - %s\n", w)
+		}
+	}
+
 	return nil
 }
 
@@ -793,6 +1043,7 @@ func (f *OutputFormatterImpl) writeAnalyzeYAML(
 			Statistics:  cloneResponse.Statistics,
 			Success:     cloneResponse.Success,
 			Error:       cloneResponse.Error,
+			Warnings:    cloneResponse.Warnings,
 		}
 	}
 	if cboResponse != nil {
@@ -817,8 +1068,10 @@ func (f *OutputFormatterImpl) writeAnalyzeYAML(
 		}
 	}
 
-	summary := BuildAnalyzeSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
-	response.Summary = summary
+	response.TypeSafety = f.typeSafety
+	response.React = f.react
+	response.Summary = f.buildSummary(complexityResponse, deadCodeResponse, cloneResponse, cboResponse, depsResponse)
+	response.Breakdown = f.breakdown
 
 	// Write YAML
 	encoder := yaml.NewEncoder(writer)
@@ -1051,6 +1304,9 @@ func (f *OutputFormatterImpl) WriteDependencyGraph(response *domain.DependencyGr
 	case domain.OutputFormatDOT:
 		dotFormatter := NewDOTFormatter(nil)
 		return dotFormatter.WriteDependencyGraph(response, writer)
+	case domain.OutputFormatMarkdown:
+		markdownFormatter := NewMarkdownFormatter(nil)
+		return markdownFormatter.WriteDependencyGraph(response, writer)
 	default:
 		return fmt.Errorf("unsupported output format for dependency graph: %s", format)
 	}
@@ -1063,6 +1319,10 @@ func (f *OutputFormatterImpl) writeDependencyGraphJSON(response *domain.Dependen
 
 // writeDependencyGraphText writes dependency graph as plain text
 func (f *OutputFormatterImpl) writeDependencyGraphText(response *domain.DependencyGraphResponse, writer io.Writer) error {
+	if response.Query != nil {
+		return f.writeDependencyQueryText(response.Query, writer)
+	}
+
 	fmt.Fprintf(writer, "\n=== Dependency Graph Analysis ===\n\n")
 	fmt.Fprintf(writer, "Generated: %s\n", response.GeneratedAt)
 	fmt.Fprintf(writer, "Version: %s\n\n", response.Version)
@@ -1100,6 +1360,7 @@ func (f *OutputFormatterImpl) writeDependencyGraphText(response *domain.Dependen
 			for _, mod := range cycle.Modules {
 				fmt.Fprintf(writer, "    - %s\n", mod)
 			}
+			writeCycleBreakingEdges(writer, cycle, "    ")
 		}
 		fmt.Fprintln(writer)
 	}
@@ -1115,6 +1376,22 @@ func (f *OutputFormatterImpl) writeDependencyGraphText(response *domain.Dependen
 		fmt.Fprintln(writer)
 	}
 
+	if response.TemporalCoupling != nil {
+		writeTemporalCouplingText(writer, response.TemporalCoupling)
+	}
+
+	if response.Packages != nil {
+		writePackageHygieneText(writer, response.Packages)
+	}
+
+	if response.Barrels != nil {
+		writeBarrelsText(writer, response.Barrels)
+	}
+
+	if response.CodeSplitting != nil {
+		writeCodeSplittingText(writer, response.CodeSplitting)
+	}
+
 	// Entry points
 	if analysis != nil && len(analysis.RootModules) > 0 {
 		fmt.Fprintln(writer, "Entry Points:")
@@ -1144,3 +1421,294 @@ func (f *OutputFormatterImpl) writeDependencyGraphText(response *domain.Dependen
 
 	return nil
 }
+
+// writeTemporalCouplingText writes the pairs whose co-change history disagrees with the imports
+func writeTemporalCouplingText(writer io.Writer, tc *domain.TemporalCouplingResult) {
+	fmt.Fprintln(writer, "Temporal Coupling:")
+	fmt.Fprintf(writer, "  Commits: %d (%d skipped for changing more than %d modules)\n",
+		tc.Commits, tc.SkippedCommits, tc.Options.MaxCommitFiles)
+	fmt.Fprintf(writer, "  Hidden coupling (co-change >= %.0f%%, no import): %d\n", tc.Options.MinDegree*100, len(tc.Hidden))
+	for _, pair := range tc.Hidden {
+		fmt.Fprintf(writer, "    %s <-> %s: %.0f%% (%d shared of %d/%d commits)\n",
+			pair.ModuleA, pair.ModuleB, pair.Degree*100, pair.SharedCommits, pair.CommitsA, pair.CommitsB)
+	}
+	fmt.Fprintf(writer, "  Imports rarely changing together (co-change < %.0f%%): %d\n", tc.Options.MinDegree*100, len(tc.StaticOnly))
+	for _, pair := range tc.StaticOnly {
+		for _, edge := range pair.Edges {
+			fmt.Fprintf(writer, "    %s -> %s: %.0f%% (%d shared of %d/%d commits)\n",
+				edge.From, edge.To, pair.Degree*100, pair.SharedCommits, pair.CommitsA, pair.CommitsB)
+		}
+	}
+	fmt.Fprintln(writer)
+}
+
+// writePackageHygieneText writes the packages whose imports disagree with package.json
+func writePackageHygieneText(writer io.Writer, packages *domain.PackageHygieneResult) {
+	fmt.Fprintln(writer, "Packages:")
+	fmt.Fprintf(writer, "  package.json files: %d\n", len(packages.Manifests))
+
+	fmt.Fprintf(writer, "  Unused dependencies: %d\n", len(packages.Unused))
+	for _, finding := range packages.Unused {
+		fmt.Fprintf(writer, "    %s@%s (%s)\n", finding.Package, finding.Version, finding.Manifest)
+	}
+	writePackageFindingsText(writer, "Undeclared imports", packages.Undeclared)
+	writePackageFindingsText(writer, "devDependencies used in production code", packages.DevOnly)
+	writePackageFindingsText(writer, "Deep imports", packages.DeepImports)
+
+	fmt.Fprintf(writer, "  Versions differing across workspaces: %d\n", len(packages.Duplicates))
+	for _, duplicate := range packages.Duplicates {
+		fmt.Fprintf(writer, "    %s:\n", duplicate.Package)
+		for _, decl := range duplicate.Declarations {
+			fmt.Fprintf(writer, "      %s in %s (%s)\n", decl.Version, decl.Manifest, decl.Section)
+		}
+	}
+	fmt.Fprintln(writer)
+}
+
+// writePackageFindingsText writes package findings with their import sites
+func writePackageFindingsText(writer io.Writer, title string, findings []domain.PackageFinding) {
+	fmt.Fprintf(writer, "  %s: %d\n", title, len(findings))
+	for _, finding := range findings {
+		fmt.Fprintf(writer, "    %s (%s)\n", finding.Package, finding.Manifest)
+		for _, site := range finding.Sites {
+			fmt.Fprintf(writer, "      %s:%d '%s'\n", site.FilePath, site.Line, site.Source)
+		}
+	}
+}
+
+// writeBarrelsText writes the barrel files, the imports through them, the cycles they
+// cause and their unused re-exports
+func writeBarrelsText(writer io.Writer, barrels *domain.BarrelResult) {
+	fmt.Fprintln(writer, "Barrels:")
+	fmt.Fprintf(writer, "  Barrel files (>= %d re-exports): %d\n", barrels.Options.MinReExports, len(barrels.Barrels))
+	for _, barrel := range barrels.Barrels {
+		fmt.Fprintf(writer, "    %s: %d re-exports (%d export *), %d symbols, %d importers\n",
+			barrel.Module, barrel.ReExports, barrel.StarExports, barrel.Symbols, barrel.Importers)
+		if len(barrel.NestedBarrels) > 0 {
+			fmt.Fprintf(writer, "      re-exports barrels: %s\n", strings.Join(barrel.NestedBarrels, ", "))
+		}
+	}
+
+	fmt.Fprintf(writer, "  Imports through barrels: %d\n", len(barrels.Imports))
+	for _, imp := range barrels.Imports {
+		source := imp.Source
+		if source == "" {
+			source = "(unresolved)"
+		}
+		fmt.Fprintf(writer, "    %s:%d %s via %s -> %s\n", imp.Importer, imp.Line, imp.Symbol, imp.Barrel, source)
+	}
+
+	fmt.Fprintf(writer, "  Cycles caused by barrels: %d\n", len(barrels.Cycles))
+	for _, cycle := range barrels.Cycles {
+		fmt.Fprintf(writer, "    %s (through %s)\n", strings.Join(cycle.Modules, ", "), strings.Join(cycle.Barrels, ", "))
+	}
+
+	fmt.Fprintf(writer, "  Unused re-exports: %d\n", len(barrels.UnusedReExports))
+	for _, unused := range barrels.UnusedReExports {
+		fmt.Fprintf(writer, "    %s:%d %s (from %s)\n", unused.Barrel, unused.Line, unused.Symbol, unused.Source)
+	}
+
+	if barrels.Options.Suggest {
+		fmt.Fprintf(writer, "  Direct imports: %d\n", len(barrels.Suggestions))
+		for _, suggestion := range barrels.Suggestions {
+			fmt.Fprintf(writer, "    %s:%d (instead of %s)\n", suggestion.Importer, suggestion.Line, suggestion.Barrel)
+			for _, statement := range suggestion.Imports {
+				fmt.Fprintf(writer, "      %s\n", statement)
+			}
+		}
+	}
+	fmt.Fprintln(writer)
+}
+
+// writeCodeSplittingText writes the entry and lazy chunks and the modules loaded
+// both statically and lazily
+func writeCodeSplittingText(writer io.Writer, splitting *domain.CodeSplittingResult) {
+	fmt.Fprintln(writer, "Code splitting:")
+	entries := splitting.EntryChunks()
+	fmt.Fprintf(writer, "  Entry chunks: %d\n", entries)
+	for _, chunk := range splitting.Chunks[:entries] {
+		fmt.Fprintf(writer, "    %s: %d modules, %s\n", chunk.Root, len(chunk.Modules), formatSourceSize(chunk.Size))
+	}
+
+	fmt.Fprintf(writer, "  Lazy chunks: %d\n", len(splitting.Chunks)-entries)
+	for _, chunk := range splitting.Chunks[entries:] {
+		sites := make([]string, 0, len(chunk.ImportedBy))
+		for _, site := range chunk.ImportedBy {
+			sites = append(sites, fmt.Sprintf("%s:%d", site.Importer, site.Line))
+		}
+		fmt.Fprintf(writer, "    %s: %d modules, %s (%d modules, %s deferred), imported by %s\n",
+			chunk.Root, len(chunk.Modules), formatSourceSize(chunk.Size), chunk.OwnModules,
+			formatSourceSize(chunk.OwnSize), strings.Join(sites, ", "))
+	}
+
+	fmt.Fprintf(writer, "  Modules loaded statically and lazily: %d (%d dynamic imports defeated)\n",
+		len(splitting.Mixed), splitting.DefeatedSplits())
+	for _, module := range splitting.Mixed {
+		if module.DefeatsSplit {
+			fmt.Fprintf(writer, "    %s (%s): dynamic import defeated, loaded statically via %s\n",
+				module.Module, formatSourceSize(module.Size), strings.Join(module.StaticPath, " -> "))
+			continue
+		}
+		fmt.Fprintf(writer, "    %s (%s): in lazy %s, loaded statically via %s\n",
+			module.Module, formatSourceSize(module.Size), strings.Join(module.LazyChunks, ", "),
+			strings.Join(module.StaticPath, " -> "))
+	}
+	fmt.Fprintln(writer)
+}
+
+// formatSourceSize formats a size in bytes as B, KB or MB
+func formatSourceSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+// writeDependencyQueryText writes the result of a dependency query as plain text
+func (f *OutputFormatterImpl) writeDependencyQueryText(result *domain.DependencyQueryResult, writer io.Writer) error {
+	query := result.Query
+
+	fmt.Fprintf(writer, "\n=== Dependency Query ===\n\n")
+	switch query.Kind {
+	case domain.QueryWhy:
+		fmt.Fprintf(writer, "Why does %s depend on %s?\n", query.Source, query.Target)
+	case domain.QueryBetween:
+		fmt.Fprintf(writer, "Dependencies from %s to %s", query.Source, query.Target)
+	case domain.QueryDependentsOf:
+		fmt.Fprintf(writer, "Dependents of %s", query.Source)
+	case domain.QueryDependenciesOf:
+		fmt.Fprintf(writer, "Dependencies of %s", query.Source)
+	}
+	if query.Kind != domain.QueryWhy {
+		if query.Transitive {
+			fmt.Fprint(writer, " (transitive)")
+		}
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintln(writer)
+
+	if result.IsEmpty() {
+		fmt.Fprintln(writer, "No dependencies found.")
+		return nil
+	}
+
+	if len(result.Paths) > 0 {
+		fmt.Fprintf(writer, "Paths (%d):\n", len(result.Paths))
+		for i, path := range result.Paths {
+			fmt.Fprintf(writer, "  %d. %s\n", i+1, strings.Join(path, " -> "))
+		}
+		if result.Truncated {
+			fmt.Fprintf(writer, "  ... stopped after %d paths\n", len(result.Paths))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	fmt.Fprintf(writer, "Modules (%d):\n", len(result.Modules))
+	for _, mod := range result.Modules {
+		fmt.Fprintf(writer, "  - %s\n", mod)
+	}
+	fmt.Fprintln(writer)
+
+	fmt.Fprintf(writer, "Dependencies (%d):\n", len(result.Edges))
+	for _, edge := range result.Edges {
+		fmt.Fprintf(writer, "  %s -> %s [%s]\n", edge.From, edge.To, edge.EdgeType)
+	}
+	fmt.Fprintln(writer)
+
+	return nil
+}
+
+// WriteImpact writes the change impact response in the specified format
+func (f *OutputFormatterImpl) WriteImpact(response *domain.ImpactResponse, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatJSON:
+		return WriteJSON(writer, response)
+	case domain.OutputFormatText:
+		return f.writeImpactText(response, writer)
+	default:
+		return fmt.Errorf("unsupported output format for impact analysis: %s", format)
+	}
+}
+
+// writeImpactText writes the change impact response as plain text
+func (f *OutputFormatterImpl) writeImpactText(response *domain.ImpactResponse, writer io.Writer) error {
+	fmt.Fprintf(writer, "\n=== Change Impact Analysis ===\n\n")
+	fmt.Fprintf(writer, "Generated: %s\n", response.GeneratedAt)
+	fmt.Fprintf(writer, "Version: %s\n\n", response.Version)
+
+	summary := response.Summary
+	fmt.Fprintln(writer, "Summary:")
+	fmt.Fprintf(writer, "  Changed modules: %d\n", summary.ChangedModules)
+	fmt.Fprintf(writer, "  Affected modules: %d (runtime: %d, dynamic: %d, type-only: %d)\n",
+		summary.AffectedModules, summary.RuntimeModules, summary.DynamicModules, summary.TypeOnlyModules)
+	fmt.Fprintf(writer, "  Affected tests: %d\n", summary.AffectedTests)
+	fmt.Fprintf(writer, "  Affected entry points: %d\n", summary.EntryPoints)
+	fmt.Fprintln(writer)
+
+	if len(response.ChangedModules) > 0 {
+		fmt.Fprintln(writer, "Changed Modules:")
+		for _, mod := range response.ChangedModules {
+			fmt.Fprintf(writer, "  - %s\n", mod)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.UnmatchedFiles) > 0 {
+		fmt.Fprintln(writer, "Not in Dependency Graph:")
+		for _, file := range response.UnmatchedFiles {
+			fmt.Fprintf(writer, "  - %s\n", file)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	var dependents []domain.ImpactedModule
+	for _, mod := range response.AffectedModules {
+		if mod.Distance > 0 {
+			dependents = append(dependents, mod)
+		}
+	}
+	if len(dependents) > 0 {
+		fmt.Fprintln(writer, "Affected Modules:")
+		for _, mod := range dependents {
+			fmt.Fprintf(writer, "  - %s [%s] (distance %d, via %s)\n", mod.ID, mod.Kind, mod.Distance, mod.Via)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.AffectedTests) > 0 {
+		fmt.Fprintln(writer, "Affected Tests:")
+		for _, mod := range response.AffectedTests {
+			fmt.Fprintf(writer, "  - %s [%s]\n", mod.ID, mod.Kind)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.EntryPoints) > 0 {
+		fmt.Fprintln(writer, "Affected Entry Points:")
+		for _, mod := range response.EntryPoints {
+			fmt.Fprintf(writer, "  - %s [%s]\n", mod.ID, mod.Kind)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.Warnings) > 0 {
+		fmt.Fprintln(writer, "Warnings:")
+		for _, w := range response.Warnings {
+			fmt.Fprintf(writer, "  - %s\n", w)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(response.Errors) > 0 {
+		fmt.Fprintln(writer, "Errors:")
+		for _, e := range response.Errors {
+			fmt.Fprintf(writer, "  - %s\n", e)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return nil
+}
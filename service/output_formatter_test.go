@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/config"
 )
 
 func TestWriteJSON(t *testing.T) {
@@ -414,7 +415,7 @@ func TestBuildAnalyzeSummary_WiresMSD(t *testing.T) {
 		},
 	}
 
-	summary := BuildAnalyzeSummary(nil, nil, nil, nil, depsResponse)
+	summary := BuildAnalyzeSummary(nil, nil, nil, nil, depsResponse, nil)
 
 	if summary.DepsMainSequenceDeviation != 0.42 {
 		t.Errorf("DepsMainSequenceDeviation = %f, want 0.42", summary.DepsMainSequenceDeviation)
@@ -440,7 +441,7 @@ func TestBuildAnalyzeSummary_WiresCycles(t *testing.T) {
 		},
 	}
 
-	summary := BuildAnalyzeSummary(nil, nil, nil, nil, depsResponse)
+	summary := BuildAnalyzeSummary(nil, nil, nil, nil, depsResponse, nil)
 
 	if summary.DepsModulesInCycles != 10 {
 		t.Errorf("DepsModulesInCycles = %d, want 10", summary.DepsModulesInCycles)
@@ -449,3 +450,27 @@ func TestBuildAnalyzeSummary_WiresCycles(t *testing.T) {
 		t.Errorf("DependencyScore should be < 100 when cycles exist, got %d", summary.DependencyScore)
 	}
 }
+
+func TestScoringFromConfig_MatchesDefaults(t *testing.T) {
+	scoring := ScoringFromConfig(&config.DefaultConfig().Scoring)
+	if *scoring != *domain.DefaultScoringConfig() {
+		t.Errorf("Default scoring config %+v does not match domain defaults %+v", *scoring, *domain.DefaultScoringConfig())
+	}
+}
+
+func TestBuildAnalyzeSummary_Scoring(t *testing.T) {
+	complexityResponse := &domain.ComplexityResponse{Summary: domain.ComplexitySummary{TotalFunctions: 10, HighRiskFunctions: 1}}
+
+	summary := BuildAnalyzeSummary(complexityResponse, nil, nil, nil, nil, nil)
+	if summary.HealthScore != 80 {
+		t.Fatalf("Expected default health score 80, got %d", summary.HealthScore)
+	}
+
+	scoring := domain.DefaultScoringConfig()
+	scoring.ComplexityWeight = 50
+	scoring.GradeDThreshold = 40
+	summary = BuildAnalyzeSummary(complexityResponse, nil, nil, nil, nil, scoring)
+	if summary.HealthScore != 50 || summary.Grade != "D" {
+		t.Errorf("Expected health score 50 (D) with custom scoring, got %d (%s)", summary.HealthScore, summary.Grade)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// AnalyzePackageHygiene compares the package imports of the parsed files with the
+// package.json files above them and the workspaces those declare. Manifests are read
+// from sources when present there, from disk otherwise.
+func AnalyzePackageHygiene(asts map[string]*parser.Node, sources map[string][]byte, options domain.PackageHygieneOptions) (*domain.PackageHygieneResult, []string, error) {
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	loader := &manifestLoader{sources: sources, byDir: make(map[string]*domain.PackageManifest)}
+
+	// Manifests are looked up from absolute paths so that the nearest package.json is
+	// found above the working directory too; sites keep the paths as given
+	moduleAnalyzer := analyzer.NewModuleAnalyzer(nil)
+	modules := make(map[string]*domain.ModuleInfo, len(asts))
+	originalPaths := make(map[string]string, len(asts))
+	for filePath, ast := range asts {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			absPath = filePath
+		}
+		info, err := moduleAnalyzer.AnalyzeFile(ast, filePath)
+		if err != nil {
+			continue
+		}
+		modules[absPath] = info
+		originalPaths[absPath] = filePath
+		loader.loadAbove(filepath.Dir(absPath))
+	}
+	loader.loadWorkspaces()
+
+	result := analyzer.NewPackageHygieneAnalyzer(loader.manifests(), options).Analyze(modules)
+	for _, findings := range [][]domain.PackageFinding{result.Undeclared, result.DevOnly, result.DeepImports} {
+		for i := range findings {
+			for j := range findings[i].Sites {
+				findings[i].Sites[j].FilePath = originalPaths[findings[i].Sites[j].FilePath]
+			}
+		}
+	}
+	return result, loader.warnings, nil
+}
+
+// manifestLoader reads the package.json files of directories, once per directory
+type manifestLoader struct {
+	sources  map[string][]byte
+	byDir    map[string]*domain.PackageManifest // nil for directories without one
+	warnings []string
+}
+
+// load reads the package.json of a directory, if it has one
+func (l *manifestLoader) load(dir string) *domain.PackageManifest {
+	if manifest, ok := l.byDir[dir]; ok {
+		return manifest
+	}
+	l.byDir[dir] = nil
+
+	path := filepath.Join(dir, "package.json")
+	content, err := readSource(l.sources, path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			l.warnings = append(l.warnings, fmt.Sprintf("Failed to read %s: %v", path, err))
+		}
+		return nil
+	}
+	manifest, err := analyzer.ParsePackageManifest(path, content)
+	if err != nil {
+		l.warnings = append(l.warnings, fmt.Sprintf("Failed to parse %s: %v", path, err))
+		return nil
+	}
+	l.byDir[dir] = manifest
+	return manifest
+}
+
+// loadAbove reads the package.json files of a directory and its parents
+func (l *manifestLoader) loadAbove(dir string) {
+	for {
+		if _, ok := l.byDir[dir]; ok {
+			return
+		}
+		l.load(dir)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+	}
+}
+
+// loadWorkspaces reads the package.json files of the workspaces declared by the loaded
+// monorepo roots, so that their versions are compared even without analyzed files
+func (l *manifestLoader) loadWorkspaces() {
+	for _, root := range l.manifests() {
+		for _, pattern := range root.Workspaces {
+			matches, err := filepath.Glob(filepath.Join(root.Dir(), filepath.FromSlash(pattern), "package.json"))
+			if err != nil {
+				l.warnings = append(l.warnings, fmt.Sprintf("Invalid workspace pattern %q in %s", pattern, root.Path))
+				continue
+			}
+			for _, match := range matches {
+				l.load(filepath.Dir(match))
+			}
+		}
+	}
+}
+
+// manifests returns the loaded package.json files ordered by path
+func (l *manifestLoader) manifests() []*domain.PackageManifest {
+	manifests := make([]*domain.PackageManifest, 0, len(l.byDir))
+	for _, manifest := range l.byDir {
+		if manifest != nil {
+			manifests = append(manifests, manifest)
+		}
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Path < manifests[j].Path
+	})
+	return manifests
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDependencyGraphServicePackages(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"package.json":                  `{"private": true, "workspaces": ["packages/*"], "devDependencies": {"typescript": "^5.4.0"}}`,
+		"packages/app/package.json":     `{"name": "app", "dependencies": {"react": "^18.2.0", "dayjs": "^1.11.0"}, "devDependencies": {"vitest": "^2.0.0"}}`,
+		"packages/app/src/index.ts":     "import React from 'react';\nimport { z } from 'zod';\nimport { bar } from 'react/cjs/react.development';\nexport const x = z;\n",
+		"packages/app/src/util.ts":      "import { it } from 'vitest';\nexport const y = it;\n",
+		"packages/app/src/util.test.ts": "import { it } from 'vitest';\n",
+		"packages/lib/package.json":     `{"name": "lib", "dependencies": {"react": "^17.0.2"}}`,
+	})
+	paths := []string{
+		filepath.Join(dir, "packages/app/src/index.ts"),
+		filepath.Join(dir, "packages/app/src/util.ts"),
+		filepath.Join(dir, "packages/app/src/util.test.ts"),
+	}
+
+	resp, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:    paths,
+		Packages: &domain.PackageHygieneOptions{},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	packages := resp.Packages
+	if packages == nil {
+		t.Fatal("Expected a package hygiene result")
+	}
+	// The lib workspace is loaded from the root workspaces without analyzed files
+	if len(packages.Manifests) < 3 {
+		t.Errorf("Expected the root, app and lib package.json files, got %v", packages.Manifests)
+	}
+	if len(packages.Unused) != 1 || packages.Unused[0].Package != "dayjs" {
+		t.Errorf("Expected dayjs to be unused, got %+v", packages.Unused)
+	}
+	if len(packages.Undeclared) != 1 || packages.Undeclared[0].Package != "zod" ||
+		packages.Undeclared[0].Sites[0].FilePath != paths[0] || packages.Undeclared[0].Sites[0].Line != 2 {
+		t.Errorf("Expected zod to be undeclared in index.ts, got %+v", packages.Undeclared)
+	}
+	if len(packages.DevOnly) != 1 || packages.DevOnly[0].Package != "vitest" || len(packages.DevOnly[0].Sites) != 1 {
+		t.Errorf("Expected vitest used in production from util.ts only, got %+v", packages.DevOnly)
+	}
+	if len(packages.DeepImports) != 1 || packages.DeepImports[0].Package != "react" {
+		t.Errorf("Expected a deep import of react, got %+v", packages.DeepImports)
+	}
+	if len(packages.Duplicates) != 1 || packages.Duplicates[0].Package != "react" {
+		t.Errorf("Expected react versions to differ across workspaces, got %+v", packages.Duplicates)
+	}
+
+	var buf bytes.Buffer
+	if err := NewOutputFormatter().WriteDependencyGraph(resp, domain.OutputFormatText, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	for _, want := range []string{"Unused dependencies: 1", "dayjs@^1.11.0", "Undeclared imports: 1", "'react/cjs/react.development'", "^17.0.2 in "} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the text report to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := NewMarkdownFormatter(nil).WriteDependencyGraph(resp, &buf); err != nil {
+		t.Fatalf("WriteDependencyGraph failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<b>Packages</b>: 5 issues") || !strings.Contains(buf.String(), "| Dev-only | `vitest` |") {
+		t.Errorf("Expected a Markdown packages section, got:\n%s", buf.String())
+	}
+}
+
+func TestDependencyGraphServicePackages_InvalidOptions(t *testing.T) {
+	_, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:    []string{"/repo/src/a.ts"},
+		Packages: &domain.PackageHygieneOptions{DevFilePatterns: []string{""}},
+	})
+	if err == nil {
+		t.Error("Expected an error for an empty dev file pattern")
+	}
+}
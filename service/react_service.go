@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/config"
+	"github.com/ludo-technologies/jscan/internal/parser"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// ReactServiceImpl implements the React component analysis
+type ReactServiceImpl struct{}
+
+// NewReactService creates a new React component analysis service
+func NewReactService() *ReactServiceImpl {
+	return &ReactServiceImpl{}
+}
+
+// ReactThresholdsFromConfig returns the component thresholds of the react config section
+func ReactThresholdsFromConfig(cfg *config.ReactConfig) domain.ReactThresholds {
+	return domain.ReactThresholds{
+		MaxJSXDepth:           cfg.MaxJSXDepth,
+		MaxProps:              cfg.MaxProps,
+		MaxHooks:              cfg.MaxHooks,
+		MaxConditionalRenders: cfg.MaxConditionalRenders,
+	}
+}
+
+// Analyze measures the function components of the request and checks the rules of
+// hooks; files without components or hook violations are left out of the response
+func (s *ReactServiceImpl) Analyze(ctx context.Context, req domain.ReactRequest) (*domain.ReactResponse, error) {
+	response := &domain.ReactResponse{
+		Files:       []domain.FileReact{},
+		Summary:     domain.ReactSummary{Thresholds: req.Thresholds},
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.Version,
+	}
+
+	for _, filePath := range req.Paths {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("React analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		content, err := readSource(req.Sources, filePath)
+		if err != nil {
+			response.Errors = append(response.Errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
+			continue
+		}
+		ast, err := parser.ParseForLanguage(filePath, content)
+		if err != nil {
+			response.Errors = append(response.Errors, fmt.Sprintf("[%s] Failed to parse: %v", filePath, err))
+			continue
+		}
+
+		file := analyzer.AnalyzeReact(ast, filePath, req.Thresholds)
+		if len(file.Components) == 0 && len(file.HookViolations) == 0 {
+			continue
+		}
+		response.Files = append(response.Files, *file)
+
+		summary := &response.Summary
+		summary.FilesAnalyzed++
+		summary.Components += len(file.Components)
+		summary.HookViolations += len(file.HookViolations)
+		for _, component := range file.Components {
+			if len(component.Exceeds) > 0 {
+				summary.ComponentsOverThreshold++
+			}
+			if component.DefinedIn != "" {
+				summary.NestedComponents++
+			}
+			if component.JSXDepth > summary.MaxJSXDepth {
+				summary.MaxJSXDepth = component.JSXDepth
+			}
+		}
+	}
+
+	// Files with the most problems first
+	sort.SliceStable(response.Files, func(i, j int) bool {
+		a, b := reactProblems(response.Files[i]), reactProblems(response.Files[j])
+		if a != b {
+			return a > b
+		}
+		return response.Files[i].FilePath < response.Files[j].FilePath
+	})
+
+	return response, nil
+}
+
+// reactProblems counts the hook violations, nested components and components over
+// a threshold of a file
+func reactProblems(file domain.FileReact) int {
+	problems := len(file.HookViolations)
+	for _, component := range file.Components {
+		if len(component.Exceeds) > 0 {
+			problems++
+		}
+		if component.DefinedIn != "" {
+			problems++
+		}
+	}
+	return problems
+}
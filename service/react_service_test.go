@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestReactServiceAnalyze(t *testing.T) {
+	sources := map[string][]byte{
+		"/repo/src/Button.jsx": []byte("export function Button({ label }) {\n  return <button>{label}</button>;\n}\n"),
+		"/repo/src/Page.tsx": []byte(`export function Page({ user }: Props) {
+  if (user) {
+    useEffect(() => {});
+  }
+  function Row() {
+    return <li />;
+  }
+  return <ul><Row /></ul>;
+}
+`),
+		"/repo/src/util.ts": []byte("export const sum = (a: number, b: number) => a + b;\n"),
+	}
+
+	resp, err := NewReactService().Analyze(context.Background(), domain.ReactRequest{
+		Paths:      []string{"/repo/src/Button.jsx", "/repo/src/Page.tsx", "/repo/src/util.ts"},
+		Sources:    sources,
+		Thresholds: domain.ReactThresholds{MaxJSXDepth: 1},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(resp.Files) != 2 || resp.Files[0].FilePath != "/repo/src/Page.tsx" {
+		t.Fatalf("Expected Page.tsx with the most problems first, got %+v", resp.Files)
+	}
+	want := domain.ReactSummary{
+		FilesAnalyzed:           2,
+		Components:              3,
+		ComponentsOverThreshold: 1,
+		NestedComponents:        1,
+		HookViolations:          1,
+		MaxJSXDepth:             2,
+		Thresholds:              domain.ReactThresholds{MaxJSXDepth: 1},
+	}
+	if resp.Summary != want {
+		t.Errorf("Expected summary %+v, got %+v", want, resp.Summary)
+	}
+
+	summary := &domain.AnalyzeSummary{}
+	AddReactSummary(summary, resp)
+	if !summary.ReactEnabled || summary.ReactComponents != 3 || summary.HookViolationCount != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if !strings.Contains(FormatCLISummary(summary, time.Second), "React:             3 components") {
+		t.Errorf("Expected the CLI summary to report the components, got:\n%s", FormatCLISummary(summary, time.Second))
+	}
+
+	formatter := NewOutputFormatter()
+	formatter.SetReact(resp)
+	var buf bytes.Buffer
+	if err := formatter.WriteAnalyze(nil, nil, nil, nil, nil, domain.OutputFormatText, &buf, time.Second); err != nil {
+		t.Fatalf("WriteAnalyze with text failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "useEffect in Page (condition)") || !strings.Contains(buf.String(), "defined in Page") {
+		t.Errorf("Expected the text report to list the hook violation and the nested component, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := formatter.WriteAnalyze(nil, nil, nil, nil, nil, domain.OutputFormatHTML, &buf, time.Second); err != nil {
+		t.Fatalf("WriteAnalyze with HTML failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `id="react"`) || !strings.Contains(buf.String(), "/repo/src/Page.tsx:5") {
+		t.Error("Expected the HTML report to have a React tab listing the Row component")
+	}
+}
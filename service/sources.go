@@ -0,0 +1,38 @@
+package service
+
+import (
+	"os"
+
+	"github.com/ludo-technologies/jscan/internal/parser"
+)
+
+// readSource returns the content of filePath from the in-memory sources of a request,
+// falling back to the file on disk
+func readSource(sources map[string][]byte, filePath string) ([]byte, error) {
+	if content, ok := sources[filePath]; ok {
+		return content, nil
+	}
+	return os.ReadFile(filePath)
+}
+
+// parseSource returns the AST of filePath from the already parsed files of a request,
+// parsing content otherwise
+func parseSource(asts map[string]*parser.Node, filePath string, content []byte) (*parser.Node, error) {
+	if ast := asts[filePath]; ast != nil {
+		return ast, nil
+	}
+	return parser.ParseForLanguage(filePath, content)
+}
+
+// sourceSize returns the size in bytes of filePath in the in-memory sources of a
+// request, falling back to the file on disk
+func sourceSize(sources map[string][]byte, filePath string) (int64, error) {
+	if content, ok := sources[filePath]; ok {
+		return int64(len(content)), nil
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
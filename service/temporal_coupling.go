@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+)
+
+// AnalyzeTemporalCoupling reads the git history of the modules in graph and compares
+// their co-changes with the import edges between them
+func AnalyzeTemporalCoupling(ctx context.Context, graph *domain.DependencyGraph, options domain.TemporalCouplingOptions) (*domain.TemporalCouplingResult, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Git reports canonical paths; map them to module IDs
+	modules := make(map[string]string, graph.NodeCount())
+	for id, node := range graph.Nodes {
+		if node.IsExternal || node.FilePath == "" {
+			continue
+		}
+		if canonical, err := canonicalPath(node.FilePath); err == nil {
+			modules[canonical] = id
+		}
+	}
+	if len(modules) == 0 {
+		return nil, domain.NewValidationError("no modules to compare with the git history")
+	}
+	dir := commonParentDirectory(modules)
+
+	root, err := GitRepositoryRoot(ctx, dir)
+	if err != nil {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("%s is not inside a git repository", dir), err)
+	}
+	commits, err := GitLog(ctx, dir, options.Since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	for i := range commits {
+		var files []domain.GitFileChange
+		for _, change := range commits[i].Files {
+			if id, ok := lookupGitPath(modules, change.Path); ok {
+				change.Path = id
+				files = append(files, change)
+			}
+		}
+		commits[i].Files = files
+	}
+
+	result := analyzer.NewTemporalCouplingAnalyzer(graph, options).Analyze(commits)
+	result.Repository = root
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestDependencyGraphServiceTemporalCoupling(t *testing.T) {
+	repo := newHotspotRepo(t)
+	start := time.Now().AddDate(0, -1, 0).Truncate(time.Second)
+	repo.commit("ann", start, map[string]string{
+		"src/a.js": "import { b } from './b';\nexport const a = () => b();\n",
+		"src/b.js": "export const b = () => 1;\n",
+		"src/c.js": "export const c = 1;\n",
+		"src/d.js": "export const d = 1;\n",
+	})
+	for i, suffix := range []string{"2", "3", "4"} {
+		date := start.Add(time.Duration(i+1) * time.Hour)
+		repo.commit("ann", date, map[string]string{
+			"src/c.js": "export const c = " + suffix + ";\n",
+			"src/d.js": "export const d = " + suffix + ";\n",
+		})
+		repo.commit("bob", date.Add(time.Minute), map[string]string{
+			"src/b.js": "export const b = () => " + suffix + ";\n",
+		})
+	}
+	repo.commit("cat", start.Add(10*time.Hour), map[string]string{
+		"src/a.js": "import { b } from './b';\nexport const a = () => b() + 1;\n",
+	})
+	repo.commit("cat", start.Add(11*time.Hour), map[string]string{
+		"src/a.js": "import { b } from './b';\nexport const a = () => b() + 2;\n",
+	})
+
+	var paths []string
+	for _, name := range []string{"a.js", "b.js", "c.js", "d.js"} {
+		paths = append(paths, filepath.Join(repo.dir, "src", name))
+	}
+
+	resp, err := NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:            paths,
+		TemporalCoupling: &domain.TemporalCouplingOptions{},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	tc := resp.TemporalCoupling
+	if tc == nil {
+		t.Fatal("Expected a temporal coupling result")
+	}
+	if tc.Commits != 9 || tc.Repository == "" {
+		t.Errorf("Expected 9 commits touching the modules, got %+v", tc)
+	}
+
+	if len(tc.Hidden) != 1 || !strings.HasSuffix(tc.Hidden[0].ModuleA, "c.js") ||
+		!strings.HasSuffix(tc.Hidden[0].ModuleB, "d.js") || tc.Hidden[0].SharedCommits != 4 {
+		t.Errorf("Expected c.js and d.js to be hidden coupling, got %+v", tc.Hidden)
+	}
+	// a.js and b.js share only the initial commit
+	if len(tc.StaticOnly) != 1 || !strings.HasSuffix(tc.StaticOnly[0].ModuleA, "a.js") ||
+		tc.StaticOnly[0].SharedCommits != 1 || tc.StaticOnly[0].Degree != 0.29 {
+		t.Errorf("Expected the a.js -> b.js import to rarely change together, got %+v", tc.StaticOnly)
+	}
+
+	// The history window excludes the initial commit
+	resp, err = NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:            paths,
+		TemporalCoupling: &domain.TemporalCouplingOptions{Since: start.Add(time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if resp.TemporalCoupling.Commits != 8 || resp.TemporalCoupling.Hidden[0].SharedCommits != 3 {
+		t.Errorf("Expected the history window to apply, got %+v", resp.TemporalCoupling)
+	}
+
+	_, err = NewDependencyGraphServiceWithDefaults().Analyze(context.Background(), domain.DependencyGraphRequest{
+		Paths:            paths,
+		TemporalCoupling: &domain.TemporalCouplingOptions{MinDegree: 2},
+	})
+	if err == nil {
+		t.Error("Expected invalid options to be rejected")
+	}
+}
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+// maxTrendTextRuns is the number of most recent runs listed in the text report
+const maxTrendTextRuns = 20
+
+// sparkBlocks are the glyphs of a text sparkline, from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// WriteTrend writes the quality trend report in the specified format
+func (f *OutputFormatterImpl) WriteTrend(response *domain.TrendResponse, format domain.OutputFormat, writer io.Writer) error {
+	switch format {
+	case domain.OutputFormatJSON:
+		return WriteJSON(writer, response)
+	case domain.OutputFormatText:
+		return f.writeTrendText(response, writer)
+	case domain.OutputFormatHTML:
+		return f.writeTrendHTML(response, writer)
+	default:
+		return fmt.Errorf("unsupported output format for trend report: %s", format)
+	}
+}
+
+// writeTrendText writes the quality trend report as plain text
+func (f *OutputFormatterImpl) writeTrendText(response *domain.TrendResponse, writer io.Writer) error {
+	fmt.Fprintf(writer, "\n=== Quality Trend ===\n\n")
+	fmt.Fprintf(writer, "Generated: %s\n", response.GeneratedAt)
+	fmt.Fprintf(writer, "History: %s\n\n", response.HistoryPath)
+
+	summary := response.Summary
+	fmt.Fprintln(writer, "Summary:")
+	fmt.Fprintf(writer, "  Runs: %d (%s to %s)\n", summary.Runs,
+		summary.From.Format("2006-01-02"), summary.To.Format("2006-01-02"))
+	fmt.Fprintf(writer, "  Health score: %d -> %d (%+d), grade %s\n",
+		summary.FirstScore, summary.LastScore, summary.ScoreChange, summary.LastGrade)
+	fmt.Fprintf(writer, "  Regressions: %d\n", summary.Regressions)
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "Metrics:")
+	for _, series := range response.Series {
+		values := make([]float64, len(series.Points))
+		for i, p := range series.Points {
+			values[i] = p.Value
+		}
+		status := "unchanged"
+		if series.Change != 0 {
+			status = "worse"
+			if series.Improved() {
+				status = "better"
+			}
+		}
+		fmt.Fprintf(writer, "  %-26s %8s -> %-8s %-9s %s\n", series.Label,
+			formatTrendValue(series.Metric, series.First), formatTrendValue(series.Metric, series.Last),
+			status, sparkline(values))
+	}
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "Runs:")
+	fmt.Fprintf(writer, "  %-10s %-16s %5s %5s %6s %6s %6s\n", "RUN", "DATE", "SCORE", "CPLX", "DUP%", "DEAD", "CYCLES")
+	entries := response.Entries
+	if len(entries) > maxTrendTextRuns {
+		fmt.Fprintf(writer, "  ... %d earlier runs\n", len(entries)-maxTrendTextRuns)
+		entries = entries[len(entries)-maxTrendTextRuns:]
+	}
+	for i := range entries {
+		entry := &entries[i]
+		s := &entry.Summary
+		fmt.Fprintf(writer, "  %-10s %-16s %5d %5.1f %6.1f %6d %6d\n", entry.Label(),
+			entry.Timestamp.Local().Format("2006-01-02 15:04"), s.HealthScore, s.AverageComplexity,
+			s.CodeDuplication, s.DeadCodeCount, s.DepsModulesInCycles)
+	}
+	fmt.Fprintln(writer)
+
+	if len(response.Regressions) > 0 {
+		fmt.Fprintln(writer, "Biggest Regressions:")
+		for i, r := range response.Regressions {
+			fmt.Fprintf(writer, "  %d. %s (%s) score %d -> %d (%d) since %s\n", i+1, r.Label,
+				r.Timestamp.Local().Format("2006-01-02"), r.ScoreBefore, r.ScoreAfter, r.ScoreDelta, r.PreviousLabel)
+			if r.Subject != "" {
+				fmt.Fprintf(writer, "     %s\n", r.Subject)
+			}
+			for _, c := range r.Changes {
+				fmt.Fprintf(writer, "     %s: %s -> %s\n", c.Metric.Label(),
+					formatTrendValue(c.Metric, c.Before), formatTrendValue(c.Metric, c.After))
+			}
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return nil
+}
+
+// formatTrendValue formats a metric value with the precision it is reported with
+func formatTrendValue(metric domain.TrendMetric, value float64) string {
+	switch metric {
+	case domain.TrendMetricComplexity, domain.TrendMetricDuplication:
+		return fmt.Sprintf("%.1f", value)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+// sparkline renders values as a line of block glyphs scaled between their min and max
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// Trend chart geometry (SVG user units)
+const (
+	trendChartWidth   = 560.0
+	trendChartHeight  = 160.0
+	trendChartPadding = 12.0
+)
+
+// trendChart is the template view of one metric series
+type trendChart struct {
+	Label    string
+	First    string
+	Last     string
+	Min      string
+	Max      string
+	Status   string
+	Polyline string
+	Points   []trendChartPoint
+}
+
+// trendChartPoint is one run plotted on a chart
+type trendChartPoint struct {
+	X, Y       float64
+	Title      string
+	Regression bool
+}
+
+// trendHTMLData is the data of the trend HTML template
+type trendHTMLData struct {
+	Response *domain.TrendResponse
+	Charts   []trendChart
+	Width    float64
+	Height   float64
+}
+
+// writeTrendHTML writes the quality trend report as a standalone HTML page with SVG charts
+func (f *OutputFormatterImpl) writeTrendHTML(response *domain.TrendResponse, writer io.Writer) error {
+	regressed := make(map[int64]bool, len(response.Regressions))
+	for _, r := range response.Regressions {
+		regressed[r.Timestamp.UnixNano()] = true
+	}
+
+	data := trendHTMLData{Response: response, Width: trendChartWidth, Height: trendChartHeight}
+	for _, series := range response.Series {
+		chart := trendChart{
+			Label:  series.Label,
+			First:  formatTrendValue(series.Metric, series.First),
+			Last:   formatTrendValue(series.Metric, series.Last),
+			Min:    formatTrendValue(series.Metric, series.Min),
+			Max:    formatTrendValue(series.Metric, series.Max),
+			Status: "unchanged",
+		}
+		if series.Change != 0 {
+			chart.Status = "worse"
+			if series.Improved() {
+				chart.Status = "better"
+			}
+		}
+
+		coords := make([]string, 0, len(series.Points))
+		for i, p := range series.Points {
+			x, y := trendChartPosition(i, len(series.Points), p.Value, series.Min, series.Max)
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", x, y))
+			chart.Points = append(chart.Points, trendChartPoint{
+				X:          x,
+				Y:          y,
+				Title:      fmt.Sprintf("%s (%s): %s", p.Label, p.Timestamp.Local().Format("2006-01-02 15:04"), formatTrendValue(series.Metric, p.Value)),
+				Regression: regressed[p.Timestamp.UnixNano()],
+			})
+		}
+		chart.Polyline = strings.Join(coords, " ")
+		data.Charts = append(data.Charts, chart)
+	}
+
+	funcMap := template.FuncMap{
+		"formatTime": func(t time.Time) string {
+			return t.Local().Format("2006-01-02 15:04")
+		},
+		"metricLabel": func(m domain.TrendMetric) string {
+			return m.Label()
+		},
+		"metricValue": formatTrendValue,
+	}
+
+	tmpl := template.Must(template.New("trend").Funcs(funcMap).Parse(trendHTMLTemplate))
+	return tmpl.Execute(writer, data)
+}
+
+// trendChartPosition maps the i-th of n values to chart coordinates. A flat series
+// is drawn through the middle of the chart.
+func trendChartPosition(i, n int, value, lo, hi float64) (float64, float64) {
+	x := trendChartWidth / 2
+	if n > 1 {
+		x = trendChartPadding + float64(i)*(trendChartWidth-2*trendChartPadding)/float64(n-1)
+	}
+	y := trendChartHeight / 2
+	if hi > lo {
+		y = trendChartHeight - trendChartPadding - (value-lo)/(hi-lo)*(trendChartHeight-2*trendChartPadding)
+	}
+	return x, y
+}
+
+const trendHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>jscan Quality Trend</title>
+    <style>
+        * { margin: 0; padding: 0; box-sizing: border-box; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            min-height: 100vh;
+        }
+        .container { max-width: 1200px; margin: 0 auto; padding: 20px; }
+        .panel {
+            background: white;
+            border-radius: 10px;
+            padding: 30px;
+            margin-bottom: 20px;
+            box-shadow: 0 10px 30px rgba(0,0,0,0.1);
+        }
+        h1 { color: #667eea; margin-bottom: 10px; }
+        h2 { color: #667eea; margin-bottom: 15px; }
+        .subtitle { color: #666; font-size: 14px; }
+        .metric-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 15px; margin-top: 20px; }
+        .metric-card { background: #f8f9fa; padding: 20px; border-radius: 8px; text-align: center; }
+        .metric-value { font-size: 28px; font-weight: bold; color: #667eea; }
+        .metric-label { color: #666; font-size: 14px; }
+        .charts { display: grid; grid-template-columns: repeat(auto-fit, minmax(500px, 1fr)); gap: 20px; }
+        .chart h3 { font-size: 16px; margin-bottom: 5px; }
+        .chart svg { width: 100%; height: auto; background: #f8f9fa; border-radius: 8px; }
+        .chart .range { color: #666; font-size: 13px; }
+        .status-better { color: #4caf50; font-weight: bold; }
+        .status-worse { color: #f44336; font-weight: bold; }
+        .status-unchanged { color: #999; }
+        .table { width: 100%; border-collapse: collapse; }
+        .table th, .table td { padding: 10px; text-align: left; border-bottom: 1px solid #eee; }
+        .table th { background: #f8f9fa; font-weight: 600; }
+        .delta { color: #f44336; font-weight: bold; }
+        code { font-family: 'SFMono-Regular', Consolas, monospace; font-size: 13px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="panel">
+            <h1>jscan Quality Trend</h1>
+            <div class="subtitle">Generated: {{.Response.GeneratedAt}} | History: {{.Response.HistoryPath}} | Version: {{.Response.Version}}</div>
+            <div class="metric-grid">
+                <div class="metric-card">
+                    <div class="metric-value">{{.Response.Summary.Runs}}</div>
+                    <div class="metric-label">Recorded Runs</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Response.Summary.LastScore}} ({{.Response.Summary.LastGrade}})</div>
+                    <div class="metric-label">Current Health Score</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{printf "%+d" .Response.Summary.ScoreChange}}</div>
+                    <div class="metric-label">Score Change</div>
+                </div>
+                <div class="metric-card">
+                    <div class="metric-value">{{.Response.Summary.Regressions}}</div>
+                    <div class="metric-label">Regressions</div>
+                </div>
+            </div>
+        </div>
+
+        <div class="panel">
+            <h2>Metrics Over Time</h2>
+            <div class="charts">
+                {{range .Charts}}
+                <div class="chart">
+                    <h3>{{.Label}} <span class="status-{{.Status}}">{{.First}} → {{.Last}} ({{.Status}})</span></h3>
+                    <svg viewBox="0 0 {{$.Width}} {{$.Height}}" role="img" aria-label="{{.Label}}">
+                        <polyline points="{{.Polyline}}" fill="none" stroke="#667eea" stroke-width="2"/>
+                        {{range .Points}}
+                        <circle cx="{{.X}}" cy="{{.Y}}" r="{{if .Regression}}6{{else}}4{{end}}" fill="{{if .Regression}}#f44336{{else}}#667eea{{end}}"><title>{{.Title}}</title></circle>
+                        {{end}}
+                    </svg>
+                    <div class="range">min {{.Min}} · max {{.Max}}</div>
+                </div>
+                {{end}}
+            </div>
+            <p class="subtitle" style="margin-top: 10px;">Red points mark the runs with the biggest health score regressions.</p>
+        </div>
+
+        {{if .Response.Regressions}}
+        <div class="panel">
+            <h2>Biggest Regressions</h2>
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Run</th>
+                        <th>Date</th>
+                        <th>Score</th>
+                        <th>Worsened Metrics</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Response.Regressions}}
+                    <tr>
+                        <td><code>{{.Label}}</code>{{if .Subject}}<br>{{.Subject}}{{end}}</td>
+                        <td>{{formatTime .Timestamp}}</td>
+                        <td>{{.ScoreBefore}} → {{.ScoreAfter}} <span class="delta">({{.ScoreDelta}})</span></td>
+                        <td>{{range .Changes}}<div>{{metricLabel .Metric}}: {{metricValue .Metric .Before}} → {{metricValue .Metric .After}}</div>{{end}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        <div class="panel">
+            <h2>Runs</h2>
+            <table class="table">
+                <thead>
+                    <tr>
+                        <th>Run</th>
+                        <th>Date</th>
+                        <th>Branch</th>
+                        <th>Score</th>
+                        <th>Avg Complexity</th>
+                        <th>Duplication</th>
+                        <th>Dead Code</th>
+                        <th>Modules in Cycles</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Response.Entries}}
+                    <tr>
+                        <td><code>{{.Label}}</code></td>
+                        <td>{{formatTime .Timestamp}}</td>
+                        <td>{{.Branch}}</td>
+                        <td>{{.Summary.HealthScore}} ({{.Summary.Grade}})</td>
+                        <td>{{printf "%.1f" .Summary.AverageComplexity}}</td>
+                        <td>{{printf "%.1f" .Summary.CodeDuplication}}%</td>
+                        <td>{{.Summary.DeadCodeCount}}</td>
+                        <td>{{.Summary.DepsModulesInCycles}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+    </div>
+</body>
+</html>
+`
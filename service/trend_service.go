@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// TrendServiceImpl implements quality trend reports over the analysis history
+type TrendServiceImpl struct{}
+
+// NewTrendService creates a new trend service
+func NewTrendService() *TrendServiceImpl {
+	return &TrendServiceImpl{}
+}
+
+// Analyze loads the recorded runs and computes metric series and regressions
+func (s *TrendServiceImpl) Analyze(ctx context.Context, req domain.TrendRequest) (*domain.TrendResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := NewHistoryStore(req.HistoryPath).Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, domain.NewInvalidInputError(
+			fmt.Sprintf("no analysis runs recorded in %s (run 'jscan analyze --history' first)", req.HistoryPath), nil)
+	}
+
+	trendAnalyzer := analyzer.NewTrendAnalyzer(entries)
+	entries = trendAnalyzer.Entries()
+	if req.Limit > 0 && len(entries) > req.Limit {
+		trendAnalyzer = analyzer.NewTrendAnalyzer(entries[len(entries)-req.Limit:])
+		entries = trendAnalyzer.Entries()
+	}
+
+	maxRegressions := req.MaxRegressions
+	if maxRegressions == 0 {
+		maxRegressions = domain.DefaultMaxTrendRegressions
+	}
+
+	response := &domain.TrendResponse{
+		HistoryPath: req.HistoryPath,
+		Entries:     entries,
+		Series:      trendAnalyzer.Series(),
+		Regressions: trendAnalyzer.Regressions(maxRegressions),
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.GetVersion(),
+	}
+
+	first, last := entries[0], entries[len(entries)-1]
+	response.Summary = domain.TrendSummary{
+		Runs:        len(entries),
+		From:        first.Timestamp,
+		To:          last.Timestamp,
+		FirstScore:  first.Summary.HealthScore,
+		LastScore:   last.Summary.HealthScore,
+		ScoreChange: last.Summary.HealthScore - first.Summary.HealthScore,
+		LastGrade:   last.Summary.Grade,
+		Regressions: len(trendAnalyzer.Regressions(0)),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func writeTrendHistory(t *testing.T, path string) {
+	t.Helper()
+	store := NewHistoryStore(path)
+	runs := []struct {
+		commit  string
+		subject string
+		score   int
+		dead    int
+	}{
+		{"aaaaaaaaaaaa", "Initial import", 90, 1},
+		{"bbbbbbbbbbbb", "Add reporting", 75, 6},
+		{"cccccccccccc", "Clean up dead code", 85, 2},
+	}
+	for i, run := range runs {
+		err := store.Append(domain.HistoryEntry{
+			Timestamp: time.Date(2026, 3, i+1, 9, 0, 0, 0, time.UTC),
+			Commit:    run.commit,
+			Subject:   run.subject,
+			Summary:   domain.AnalyzeSummary{HealthScore: run.score, Grade: "B", DeadCodeCount: run.dead},
+		})
+		if err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+}
+
+func TestHistoryStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".jscan", "history.jsonl")
+	store := NewHistoryStore(path)
+
+	entries, err := store.Load()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Expected no entries for a missing file, got %v (err %v)", entries, err)
+	}
+
+	writeTrendHistory(t, path)
+	entries, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(entries) != 3 || entries[1].Commit != "bbbbbbbbbbbb" || entries[1].Summary.DeadCodeCount != 6 {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
+
+func TestHistoryStoreInvalidRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	writeTrendHistory(t, path)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("\n{not json\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	_, err = NewHistoryStore(path).Load()
+	if err == nil || !strings.Contains(err.Error(), "history.jsonl:5") {
+		t.Errorf("Expected an error pointing at line 5, got %v", err)
+	}
+}
+
+func TestNewHistoryEntry(t *testing.T) {
+	summary := &domain.AnalyzeSummary{HealthScore: 80}
+
+	// Outside a repository the entry has no commit
+	entry := NewHistoryEntry(context.Background(), summary, t.TempDir())
+	if entry.Commit != "" || entry.Summary.HealthScore != 80 || entry.Timestamp.IsZero() {
+		t.Errorf("Unexpected entry outside git %+v", entry)
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"commit", "-q", "--allow-empty", "-m", "First commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	entry = NewHistoryEntry(context.Background(), summary, dir)
+	if len(entry.Commit) != 40 || entry.Branch != "main" || entry.Subject != "First commit" || entry.Dirty {
+		t.Errorf("Unexpected git metadata %+v", entry)
+	}
+}
+
+func TestTrendServiceAnalyze(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	writeTrendHistory(t, path)
+
+	resp, err := NewTrendService().Analyze(context.Background(), domain.TrendRequest{HistoryPath: path})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if resp.Summary.Runs != 3 || resp.Summary.FirstScore != 90 || resp.Summary.LastScore != 85 || resp.Summary.Regressions != 1 {
+		t.Errorf("Unexpected summary %+v", resp.Summary)
+	}
+	if len(resp.Regressions) != 1 || resp.Regressions[0].Commit != "bbbbbbbbbbbb" || resp.Regressions[0].ScoreDelta != -15 {
+		t.Errorf("Unexpected regressions %+v", resp.Regressions)
+	}
+
+	resp, err = NewTrendService().Analyze(context.Background(), domain.TrendRequest{HistoryPath: path, Limit: 2})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if resp.Summary.Runs != 2 || resp.Entries[0].Commit != "bbbbbbbbbbbb" {
+		t.Errorf("Expected the 2 most recent runs, got %+v", resp.Entries)
+	}
+}
+
+func TestTrendServiceNoHistory(t *testing.T) {
+	_, err := NewTrendService().Analyze(context.Background(), domain.TrendRequest{
+		HistoryPath: filepath.Join(t.TempDir(), "missing.jsonl"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "no analysis runs recorded") {
+		t.Errorf("Expected a missing history error, got %v", err)
+	}
+}
+
+func TestOutputFormatterWriteTrend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	writeTrendHistory(t, path)
+	resp, err := NewTrendService().Analyze(context.Background(), domain.TrendRequest{HistoryPath: path})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	var text bytes.Buffer
+	if err := NewOutputFormatter().WriteTrend(resp, domain.OutputFormatText, &text); err != nil {
+		t.Fatalf("WriteTrend text failed: %v", err)
+	}
+	for _, expected := range []string{
+		"Health score: 90 -> 85 (-5), grade B",
+		"1. bbbbbbbb (2026-03-02) score 90 -> 75 (-15) since aaaaaaaa",
+		"Dead code findings: 1 -> 6",
+		"█▁▆",
+	} {
+		if !strings.Contains(text.String(), expected) {
+			t.Errorf("Expected text output to contain %q, got:\n%s", expected, text.String())
+		}
+	}
+
+	var html bytes.Buffer
+	if err := NewOutputFormatter().WriteTrend(resp, domain.OutputFormatHTML, &html); err != nil {
+		t.Fatalf("WriteTrend html failed: %v", err)
+	}
+	for _, expected := range []string{"<polyline points=", "fill=\"#f44336\"", "Add reporting"} {
+		if !strings.Contains(html.String(), expected) {
+			t.Errorf("Expected HTML output to contain %q", expected)
+		}
+	}
+}
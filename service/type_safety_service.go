@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+	"github.com/ludo-technologies/jscan/internal/analyzer"
+	"github.com/ludo-technologies/jscan/internal/parser"
+	"github.com/ludo-technologies/jscan/internal/version"
+)
+
+// TypeSafetyServiceImpl implements the TypeScript type-safety analysis
+type TypeSafetyServiceImpl struct{}
+
+// NewTypeSafetyService creates a new type-safety analysis service
+func NewTypeSafetyService() *TypeSafetyServiceImpl {
+	return &TypeSafetyServiceImpl{}
+}
+
+// Analyze measures the type-safety escapes of the TypeScript files of the request;
+// JavaScript and declaration files are skipped
+func (s *TypeSafetyServiceImpl) Analyze(ctx context.Context, req domain.TypeSafetyRequest) (*domain.TypeSafetyResponse, error) {
+	response := &domain.TypeSafetyResponse{
+		Files:       []domain.FileTypeSafety{},
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Version:     version.Version,
+	}
+
+	for _, filePath := range req.Paths {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("type-safety analysis cancelled: %w", ctx.Err())
+		default:
+		}
+
+		if !analyzer.IsTypeScriptFile(filePath) {
+			response.Summary.SkippedFiles++
+			continue
+		}
+
+		content, err := readSource(req.Sources, filePath)
+		if err != nil {
+			response.Errors = append(response.Errors, fmt.Sprintf("[%s] Failed to read file: %v", filePath, err))
+			continue
+		}
+		ast, err := parser.ParseForLanguage(filePath, content)
+		if err != nil {
+			response.Errors = append(response.Errors, fmt.Sprintf("[%s] Failed to parse: %v", filePath, err))
+			continue
+		}
+
+		file := analyzer.AnalyzeTypeSafety(ast, filePath)
+		response.Files = append(response.Files, *file)
+		response.Summary.FilesAnalyzed++
+		response.Summary.Functions += len(file.Functions)
+		response.Summary.Metrics.Add(file.Metrics)
+	}
+	response.Summary.TypeCoverage = response.Summary.Metrics.TypeCoverage()
+
+	// Least covered files first, then the ones with the most escapes
+	sort.SliceStable(response.Files, func(i, j int) bool {
+		a, b := response.Files[i], response.Files[j]
+		if a.TypeCoverage != b.TypeCoverage {
+			return a.TypeCoverage < b.TypeCoverage
+		}
+		if a.Metrics.Escapes() != b.Metrics.Escapes() {
+			return a.Metrics.Escapes() > b.Metrics.Escapes()
+		}
+		return a.FilePath < b.FilePath
+	})
+
+	return response, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ludo-technologies/jscan/domain"
+)
+
+func TestTypeSafetyServiceAnalyze(t *testing.T) {
+	sources := map[string][]byte{
+		"/repo/src/typed.ts":   []byte("export const add = (a: number, b: number): number => a + b;\n"),
+		"/repo/src/loose.ts":   []byte("export function parse(input: any, opts) {\n  // @ts-ignore\n  return (input as Config)!.value;\n}\n"),
+		"/repo/src/legacy.js":  []byte("export function parse(input) { return input; }\n"),
+		"/repo/src/types.d.ts": []byte("declare const value: any;\n"),
+	}
+
+	resp, err := NewTypeSafetyService().Analyze(context.Background(), domain.TypeSafetyRequest{
+		Paths:   []string{"/repo/src/typed.ts", "/repo/src/loose.ts", "/repo/src/legacy.js", "/repo/src/types.d.ts"},
+		Sources: sources,
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if resp.Summary.FilesAnalyzed != 2 || resp.Summary.SkippedFiles != 2 {
+		t.Fatalf("Expected 2 analyzed and 2 skipped files, got %+v", resp.Summary)
+	}
+	if len(resp.Files) != 2 || resp.Files[0].FilePath != "/repo/src/loose.ts" {
+		t.Fatalf("Expected the least covered file first, got %+v", resp.Files)
+	}
+
+	metrics := resp.Summary.Metrics
+	if metrics.ExplicitAny != 1 || metrics.AsCasts != 1 || metrics.NonNullAssertions != 1 ||
+		metrics.TSSuppressions != 1 || metrics.UntypedParams != 1 {
+		t.Errorf("Unexpected totals %+v", metrics)
+	}
+	// Typed: a, b and the return type of add; untyped: input (any) and opts
+	if resp.Summary.TypeCoverage != 60 {
+		t.Errorf("Expected type coverage 60, got %.1f", resp.Summary.TypeCoverage)
+	}
+
+	summary := &domain.AnalyzeSummary{}
+	AddTypeSafetySummary(summary, resp)
+	if !summary.TypeSafetyEnabled || summary.TypeCoverage != 60 || summary.ExplicitAnyCount != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if !strings.Contains(FormatCLISummary(summary, time.Second), "Type Coverage:    60.0%") {
+		t.Errorf("Expected the CLI summary to report the type coverage, got:\n%s", FormatCLISummary(summary, time.Second))
+	}
+
+	formatter := NewOutputFormatter()
+	formatter.SetTypeSafety(resp)
+	var buf bytes.Buffer
+	if err := formatter.WriteAnalyze(nil, nil, nil, nil, nil, domain.OutputFormatHTML, &buf, time.Second); err != nil {
+		t.Fatalf("WriteAnalyze with HTML failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `id="typesafety"`) || !strings.Contains(buf.String(), "/repo/src/loose.ts") {
+		t.Error("Expected the HTML report to have a type safety tab listing loose.ts")
+	}
+}
+
+func TestAddTypeSafetySummary_NoTypeScript(t *testing.T) {
+	summary := &domain.AnalyzeSummary{}
+	AddTypeSafetySummary(summary, &domain.TypeSafetyResponse{Summary: domain.TypeSafetySummary{SkippedFiles: 3}})
+	if summary.TypeSafetyEnabled {
+		t.Error("Expected type safety to stay disabled without TypeScript files")
+	}
+}